@@ -120,6 +120,7 @@ func createOrUpdateMongodbIndex(ctx context.Context) {
 		template.NewProductColl(),
 		commonrepo.NewApplicationColl(),
 		commonrepo.NewApplicationFieldDefinitionColl(),
+		commonrepo.NewApplicationScorecardRuleColl(),
 		commonrepo.NewBasicImageColl(),
 		commonrepo.NewBuildColl(),
 		commonrepo.NewCallbackRequestColl(),
@@ -128,11 +129,16 @@ func createOrUpdateMongodbIndex(ctx context.Context) {
 		commonrepo.NewCounterColl(),
 		commonrepo.NewCronjobColl(),
 		commonrepo.NewCustomWorkflowTestReportColl(),
+		commonrepo.NewImageScanResultColl(),
+		commonrepo.NewSBOMColl(),
+		commonrepo.NewKeyVaultProviderColl(),
+		commonrepo.NewAdmissionPolicyColl(),
 		commonrepo.NewDeliveryActivityColl(),
 		commonrepo.NewDeliveryArtifactColl(),
 		commonrepo.NewDeliveryDeployColl(),
 		commonrepo.NewDeliveryDistributeColl(),
 		commonrepo.NewDeliveryVersionV2Coll(),
+		commonrepo.NewDeliveryReleaseNoteColl(),
 		commonrepo.NewDiffNoteColl(),
 		commonrepo.NewDindCleanColl(),
 		commonrepo.NewIMAppColl(),
@@ -171,6 +177,12 @@ func createOrUpdateMongodbIndex(ctx context.Context) {
 		commonrepo.NewProjectClusterRelationColl(),
 		commonrepo.NewEnvResourceColl(),
 		commonrepo.NewEnvSvcDependColl(),
+		commonrepo.NewServiceDependencyColl(),
+		commonrepo.NewCostPricingColl(),
+		commonrepo.NewDailyCostColl(),
+		commonrepo.NewCostBudgetColl(),
+		commonrepo.NewMockServiceColl(),
+		commonrepo.NewEnvMockColl(),
 		commonrepo.NewBuildTemplateColl(),
 		commonrepo.NewScanningColl(),
 		commonrepo.NewWorkflowV4Coll(),
@@ -181,6 +193,7 @@ func createOrUpdateMongodbIndex(ctx context.Context) {
 		commonrepo.NewWorkflowV4TemplateColl(),
 		commonrepo.NewVariableSetColl(),
 		commonrepo.NewJobInfoColl(),
+		commonrepo.NewJobResourceUsageColl(),
 		commonrepo.NewStatDashboardConfigColl(),
 		commonrepo.NewProjectManagementColl(),
 		commonrepo.NewImageTagsCollColl(),
@@ -207,6 +220,8 @@ func createOrUpdateMongodbIndex(ctx context.Context) {
 
 		systemrepo.NewAnnouncementColl(),
 		systemrepo.NewOperationLogColl(),
+		systemrepo.NewAuditLogStateColl(),
+		systemrepo.NewAuditLogDeadLetterColl(),
 		modeMongodb.NewCollaborationModeColl(),
 		modeMongodb.NewCollaborationInstanceColl(),
 
@@ -227,10 +242,12 @@ func createOrUpdateMongodbIndex(ctx context.Context) {
 
 		// vm job related db index
 		vmcommonrepo.NewVMJobColl(),
+		vmcommonrepo.NewAgentPoolColl(),
 
 		statrepo.NewWeeklyDeployStatColl(),
 		statrepo.NewMonthlyDeployStatColl(),
 		statrepo.NewMonthlyReleaseStatColl(),
+		statrepo.NewWeeklyDoraStatColl(),
 	} {
 		wg.Add(1)
 		go func(r indexer) {
@@ -18,7 +18,10 @@ package agent
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
+	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/koderover/zadig/v2/pkg/cli/zadig-agent/config"
@@ -35,8 +38,10 @@ func NewAgentController() *AgentController {
 		Client:               network.NewZadigClient(),
 		StopPollingJobChan:   make(chan struct{}, 1),
 		StopRunJobChan:       make(chan struct{}, 1),
+		JobFinishedChan:      make(chan struct{}, 1),
 		ConcurrencyBlockTime: common.DefaultAgentConcurrencyBlockTime,
-		CurrentJobNum:        0,
+		executors:            make(map[string]*jobexecutor.JobExecutor),
+		jobs:                 make(map[string]bool),
 	}
 }
 
@@ -47,14 +52,21 @@ type AgentController struct {
 	StopRunJobChan       chan struct{}
 	Concurrency          int
 	ConcurrencyBlockTime int
-	CurrentJobNum        int
+	CurrentJobNum        atomic.Int32
 	WorkingDirectory     string
+	JobFinishedChan      chan struct{}
+
+	mu        sync.RWMutex
+	jobConn   *network.JobConn
+	executors map[string]*jobexecutor.JobExecutor
+	// jobs holds the received jobs until they finish, they are reported in the ready messages
+	jobs map[string]bool
 }
 
 func (c *AgentController) Start(ctx context.Context) {
 	c.JobChan = make(chan *types.ZadigJobTask, c.Concurrency)
 
-	go c.PollingJob(ctx)
+	go c.ReceiveJob(ctx)
 
 	go c.RunJob(ctx)
 }
@@ -71,22 +83,158 @@ func (c *AgentController) StopRunJob() {
 	c.StopRunJobChan <- struct{}{}
 }
 
-func (c *AgentController) PollingJob(ctx context.Context) {
+// ReceiveJob holds the job connection to zadig server, jobs and cancel signals are pushed over it.
+// The agent falls back to polling for a while when the connection can not be established.
+func (c *AgentController) ReceiveJob(ctx context.Context) {
 	defer func() {
 		close(c.JobChan)
 	}()
 
-	log.Infof("start polling job.")
 	for {
 		select {
 		case <-ctx.Done():
-			log.Infof("stop polling job, received context cancel signal.")
+			log.Infof("stop receiving job, received context cancel signal.")
 			return
 		case <-c.StopPollingJobChan:
-			log.Infof("stop polling job, received stop signal.")
+			log.Infof("stop receiving job, received stop signal.")
 			return
 		default:
-			if config.GetAgentStatus() == common.AGENT_STATUS_RUNNING && config.GetScheduleWorkflow() && c.CurrentJobNum < config.GetConcurrency() {
+		}
+
+		conn, err := network.ConnectJob(c.Client.AgentConfig)
+		if err != nil {
+			log.Warnf("failed to connect zadig server, fall back to polling job, error: %s", err)
+			if c.PollingJob(ctx, common.DefaultAgentPollingFallbackTime*time.Second) {
+				return
+			}
+			continue
+		}
+
+		stopped := c.serveJobConn(ctx, conn)
+		conn.Close()
+		if stopped {
+			return
+		}
+	}
+}
+
+// serveJobConn returns true if the agent is stopped, and false if the connection is broken
+func (c *AgentController) serveJobConn(ctx context.Context, conn *network.JobConn) bool {
+	c.setJobConn(conn)
+	defer c.setJobConn(nil)
+
+	log.Infof("job connection to zadig server is established.")
+	ticker := time.NewTicker(common.DefaultAgentReadyInterval * time.Second)
+	defer ticker.Stop()
+
+	c.sendReady(conn)
+	for {
+		select {
+		case <-ctx.Done():
+			log.Infof("stop receiving job, received context cancel signal.")
+			return true
+		case <-c.StopPollingJobChan:
+			log.Infof("stop receiving job, received stop signal.")
+			return true
+		case <-ticker.C:
+			c.sendReady(conn)
+		case <-c.JobFinishedChan:
+			c.sendReady(conn)
+		case msg, ok := <-conn.Messages():
+			if !ok {
+				return false
+			}
+
+			switch msg.Type {
+			case network.JobConnMessageJob:
+				job := new(types.ZadigJobTask)
+				if err := json.Unmarshal(msg.Data, job); err != nil {
+					log.Errorf("failed to unmarshal job %s, error: %s", msg.JobID, err)
+					continue
+				}
+				c.receivedJob(job)
+				// acknowledge the job, zadig server counts it in the free slots until then
+				c.sendReady(conn)
+			case network.JobConnMessageCancel:
+				c.cancelJob(msg.JobID)
+			}
+		}
+	}
+}
+
+func (c *AgentController) sendReady(conn *network.JobConn) {
+	freeSlots := 0
+	if config.GetAgentStatus() == common.AGENT_STATUS_RUNNING && config.GetScheduleWorkflow() {
+		freeSlots = config.GetConcurrency() - int(c.CurrentJobNum.Load())
+	}
+	if freeSlots < 0 {
+		freeSlots = 0
+	}
+
+	c.mu.RLock()
+	jobIDs := make([]string, 0, len(c.jobs))
+	for jobID := range c.jobs {
+		jobIDs = append(jobIDs, jobID)
+	}
+	c.mu.RUnlock()
+
+	if err := conn.Ready(freeSlots, jobIDs); err != nil {
+		log.Errorf("failed to send ready to zadig server, error: %s", err)
+	}
+}
+
+func (c *AgentController) receivedJob(job *types.ZadigJobTask) {
+	c.mu.Lock()
+	c.jobs[job.ID] = true
+	c.mu.Unlock()
+	c.CurrentJobNum.Add(1)
+	c.JobChan <- job
+	log.Infof("received job workflow name: %v, task id: %v, project name: %v, job name: %v",
+		job.WorkflowName, job.TaskID, job.ProjectName, job.JobName)
+	if config.GetEnableDebug() {
+		log.Debugf("received job detail: %+v", job)
+	}
+}
+
+func (c *AgentController) setJobConn(conn *network.JobConn) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.jobConn = conn
+}
+
+// GetJobConn returns the job connection to zadig server, it is nil when the agent is polling job
+func (c *AgentController) GetJobConn() *network.JobConn {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.jobConn
+}
+
+func (c *AgentController) cancelJob(jobID string) {
+	c.mu.RLock()
+	executor, ok := c.executors[jobID]
+	c.mu.RUnlock()
+	if !ok {
+		return
+	}
+
+	log.Infof("job %s is cancelled by zadig server.", jobID)
+	executor.CancelJob()
+}
+
+// PollingJob requests job from zadig server periodically until the duration passes, it returns true if the agent is stopped
+func (c *AgentController) PollingJob(ctx context.Context, duration time.Duration) bool {
+	log.Infof("start polling job.")
+	deadline := time.Now().Add(duration)
+	for time.Now().Before(deadline) {
+		select {
+		case <-ctx.Done():
+			log.Infof("stop polling job, received context cancel signal.")
+			return true
+		case <-c.StopPollingJobChan:
+			log.Infof("stop polling job, received stop signal.")
+			return true
+		default:
+			if config.GetAgentStatus() == common.AGENT_STATUS_RUNNING && config.GetScheduleWorkflow() && int(c.CurrentJobNum.Load()) < config.GetConcurrency() {
 				job, err := c.Client.RequestJob()
 				if err != nil {
 					log.Errorf("failed to request job from zadig server, error: %s", err)
@@ -95,24 +243,19 @@ func (c *AgentController) PollingJob(ctx context.Context) {
 				}
 
 				if job != nil && job.ID != "" {
-					c.JobChan <- job
-					c.CurrentJobNum++
-					log.Infof("PollingJob: received job workflow name: %v, task id: %v, project name: %v, job name: %v",
-						job.WorkflowName, job.TaskID, job.ProjectName, job.JobName)
-					if config.GetEnableDebug() {
-						log.Debugf("received job detail: %+v", job)
-					}
+					c.receivedJob(job)
 				}
 
 				time.Sleep(common.DefaultAgentPollingInterval * time.Second)
 			} else {
-				if c.CurrentJobNum >= config.GetConcurrency() {
-					log.Infof("current job num %d is equal to concurrency %d, will block %d seconds to request job again.", c.CurrentJobNum, config.GetConcurrency(), c.ConcurrencyBlockTime)
+				if currentJobNum := int(c.CurrentJobNum.Load()); currentJobNum >= config.GetConcurrency() {
+					log.Infof("current job num %d is equal to concurrency %d, will block %d seconds to request job again.", currentJobNum, config.GetConcurrency(), c.ConcurrencyBlockTime)
 				}
 				time.Sleep(time.Duration(c.ConcurrencyBlockTime) * time.Second)
 			}
 		}
 	}
+	return false
 }
 
 func (c *AgentController) RunJob(ctx context.Context) {
@@ -133,7 +276,14 @@ func (c *AgentController) RunJob(ctx context.Context) {
 
 			go func() {
 				defer func() {
-					c.CurrentJobNum--
+					c.mu.Lock()
+					delete(c.jobs, job.ID)
+					c.mu.Unlock()
+					c.CurrentJobNum.Add(-1)
+					select {
+					case c.JobFinishedChan <- struct{}{}:
+					default:
+					}
 				}()
 				if err := c.RunSingleJob(ctx, job); err != nil {
 					log.Errorf("failed to run job, error: %s", err)
@@ -148,6 +298,15 @@ func (c *AgentController) RunSingleJob(ctx context.Context, job *types.ZadigJobT
 	jobCtx, cancel := context.WithCancel(ctx)
 	executor := jobexecutor.NewJobExecutor(ctx, job, c.Client, cancel)
 
+	c.mu.Lock()
+	c.executors[job.ID] = executor
+	c.mu.Unlock()
+	defer func() {
+		c.mu.Lock()
+		delete(c.executors, job.ID)
+		c.mu.Unlock()
+	}()
+
 	// execute some init job before execute zadig job
 	err = executor.BeforeExecute()
 	if err != nil {
@@ -193,6 +352,8 @@ func (c *AgentController) RunSingleJob(ctx context.Context, job *types.ZadigJobT
 		case <-ctx.Done():
 			log.Infof("stop running job, received context cancel signal.")
 			return nil
+		case <-executor.CancelledChan:
+			return fmt.Errorf("job %s id %s is canceled by user", job.JobName, job.ID)
 		// TODO: how to deal with job cancel by better way, if restart the same job after cancel immediately?
 		case <-executor.FinishedChan:
 			log.Infof("workflow %s job %s finished.", job.WorkflowName, job.JobName)
@@ -23,18 +23,21 @@ import (
 	"io/ioutil"
 	"os"
 	"path/filepath"
+	"runtime"
 	"strings"
 	"sync"
 	"time"
 
 	"github.com/koderover/zadig/v2/pkg/cli/zadig-agent/config"
 	"github.com/koderover/zadig/v2/pkg/cli/zadig-agent/helper/log"
+	"github.com/koderover/zadig/v2/pkg/cli/zadig-agent/internal/agent/jobcontainer"
 	"github.com/koderover/zadig/v2/pkg/cli/zadig-agent/internal/agent/reporter"
 	"github.com/koderover/zadig/v2/pkg/cli/zadig-agent/internal/agent/step"
 	"github.com/koderover/zadig/v2/pkg/cli/zadig-agent/internal/common"
 	"github.com/koderover/zadig/v2/pkg/cli/zadig-agent/internal/common/types"
 	"github.com/koderover/zadig/v2/pkg/cli/zadig-agent/internal/network"
 	jobctl "github.com/koderover/zadig/v2/pkg/microservice/aslan/core/common/service/workflowcontroller/jobcontroller"
+	"github.com/koderover/zadig/v2/pkg/tool/tracing"
 	"github.com/koderover/zadig/v2/pkg/types/job"
 )
 
@@ -43,15 +46,18 @@ func NewJobExecutor(ctx context.Context, job *types.ZadigJobTask, client *networ
 	result.SetStatus(common.StatusPrepare)
 
 	cancel := new(bool)
+	stepCtx, stepCancel := context.WithCancel(ctx)
 	return &JobExecutor{
-		Ctx:            ctx,
+		Ctx:            stepCtx,
 		Job:            job,
 		Client:         client,
 		Reporter:       reporter.NewJobReporter(result, client, cancel),
 		JobResult:      result,
 		Cancel:         cancel,
+		CancelledChan:  make(chan struct{}),
 		FinishedChan:   make(chan struct{}, 1),
 		ReporterCancel: reporterCancel,
+		stepCancel:     stepCancel,
 	}
 }
 
@@ -69,9 +75,16 @@ type JobExecutor struct {
 	JobResult        *types.JobExecuteResult
 	OutputsJsonBytes []byte
 	Cancel           *bool
+	CancelledChan    chan struct{}
 	FinishedChan     chan struct{}
 	ReporterCancel   context.CancelFunc
 	Dirs             *types.AgentWorkDirs
+	Tracer           *tracing.Tracer
+	// Container is set when the script steps run in a container instead of the agent host
+	Container *jobcontainer.Container
+
+	stepCancel context.CancelFunc
+	cancelOnce sync.Once
 }
 
 // BeforeExecute init execute context and command
@@ -95,6 +108,17 @@ func (e *JobExecutor) BeforeExecute() error {
 		return err
 	}
 
+	if e.JobCtx.Container != nil {
+		if runtime.GOOS == "windows" {
+			return fmt.Errorf("container execution mode is not supported on windows agents")
+		}
+		e.Container = jobcontainer.New(e.Job.ID, e.JobCtx.Container, e.Dirs.Workspace, e.Dirs.JobScriptDir, e.Dirs.JobOutputsDir)
+		if err := e.Container.Start(e.Ctx, e.Logger); err != nil {
+			log.Errorf("failed to start job container, error: %v", err)
+			return err
+		}
+	}
+
 	return nil
 }
 
@@ -126,6 +150,13 @@ func (e *JobExecutor) initJobContext() error {
 	}
 	e.JobCtx = jobCtx
 
+	tracer, err := tracing.NewTracer("zadig-agent", jobCtx.TraceEndpoint)
+	if err != nil {
+		log.Errorf("failed to init tracer, tracing is disabled: %v", err)
+		tracer, _ = tracing.NewTracer("zadig-agent", "")
+	}
+	e.Tracer = tracer
+
 	return nil
 }
 
@@ -238,6 +269,12 @@ func (e *JobExecutor) Execute() {
 	defer func() {
 		e.ReporterCancel()
 
+		if e.Container != nil {
+			if err := e.Container.Remove(); err != nil {
+				log.Errorf("%v", err)
+			}
+		}
+
 		if outputs, err := e.getJobOutputVars(); err != nil {
 			e.Logger.Errorf("failed to collect job result, error: %w", err)
 			e.JobResult.SetError(fmt.Errorf("failed to collect job result, error: %s", err))
@@ -251,6 +288,12 @@ func (e *JobExecutor) Execute() {
 
 		e.Logger.Printf("====================== Job Executor End. Duration: %.2f seconds ======================\n", time.Since(start).Seconds())
 		e.Logger.Sync()
+
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		if err := e.Tracer.Shutdown(shutdownCtx); err != nil {
+			log.Warnf("failed to flush trace spans, error: %v", err)
+		}
 	}()
 	e.Logger.Printf("====================== Job Executor Start ======================\n")
 	if e.CheckZadigCancel() {
@@ -272,6 +315,7 @@ func (e *JobExecutor) run() error {
 	hasFailed := false
 	var respErr error
 
+	ctx := tracing.ContextFromTraceParent(e.Ctx, e.JobCtx.TraceParent)
 	for _, stepInfo := range e.JobCtx.Steps {
 		if e.CheckZadigCancel() {
 			return fmt.Errorf("user cancel job %s", e.Job.JobName)
@@ -279,7 +323,17 @@ func (e *JobExecutor) run() error {
 		if hasFailed && !stepInfo.Onfailure {
 			continue
 		}
-		if err := step.RunStep(e.Ctx, e.JobCtx, stepInfo, e.Dirs, e.getUserEnvs(), e.JobCtx.SecretEnvs, e.Logger); err != nil {
+		stepCtx, span := e.Tracer.StartSpan(ctx, "step "+stepInfo.Name,
+			tracing.AttrProject.String(e.Job.ProjectName),
+			tracing.AttrWorkflow.String(e.Job.WorkflowName),
+			tracing.AttrTaskID.Int64(e.Job.TaskID),
+			tracing.AttrJob.String(e.Job.JobName),
+			tracing.AttrStep.String(stepInfo.Name),
+			tracing.AttrStepType.String(string(stepInfo.StepType)),
+		)
+		err := step.RunStep(stepCtx, e.JobCtx, stepInfo, e.Dirs, e.getUserEnvs(), e.JobCtx.SecretEnvs, e.Container, e.Logger)
+		tracing.EndSpan(span, err)
+		if err != nil {
 			hasFailed = true
 			respErr = err
 		}
@@ -299,6 +353,9 @@ func (e *JobExecutor) getUserEnvs() []string {
 	//e.JobCtx.Paths = strings.Replace(e.JobCtx.Paths, "$HOME", config.Home(), -1)
 	//envs = append(envs, fmt.Sprintf("PATH=%s", e.JobCtx.Paths))
 	envs = append(envs, fmt.Sprintf("DOCKER_HOST=%s", e.DockerHost))
+	if e.JobCtx.TraceParent != "" {
+		envs = append(envs, fmt.Sprintf("%s=%s", tracing.EnvTraceParent, e.JobCtx.TraceParent))
+	}
 	envs = append(envs, e.JobCtx.Envs...)
 	envs = append(envs, e.JobCtx.SecretEnvs...)
 	// share output var between steps.
@@ -429,6 +486,16 @@ func (e *JobExecutor) StopJob() error {
 	return nil
 }
 
+// CancelJob stops the job at once when zadig server pushes the cancel signal, the running step is killed
+// instead of waiting for CheckZadigCancel between steps.
+func (e *JobExecutor) CancelJob() {
+	e.cancelOnce.Do(func() {
+		*e.Cancel = true
+		e.stepCancel()
+		close(e.CancelledChan)
+	})
+}
+
 func (e *JobExecutor) CheckZadigCancel() bool {
 	if *e.Cancel {
 		return true
@@ -0,0 +1,142 @@
+/*
+Copyright 2025 The KodeRover Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package jobcontainer
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/exec"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/util/sets"
+
+	"github.com/koderover/zadig/v2/pkg/cli/zadig-agent/helper/log"
+	jobctl "github.com/koderover/zadig/v2/pkg/microservice/aslan/core/common/service/workflowcontroller/jobcontroller"
+)
+
+// hostOnlyEnvs are set by the agent for the host, they are wrong in the container
+var hostOnlyEnvs = sets.NewString("HOME", "DOCKER_HOST")
+
+// dockerBinary is the docker client running the job containers
+var dockerBinary = "docker"
+
+// Container isolates a vm job from the agent host. The job directories are bind-mounted at the same paths,
+// so the steps running on the host, like git, cache and archive, work on the same files as the script steps
+// running in the container. The commands run as the user of the agent, so the files they leave in the job
+// directories can be cleaned up by the agent.
+type Container struct {
+	Name   string
+	spec   *jobctl.JobContainer
+	mounts []string
+	// configDir is the docker config of the job, the registry credential is kept there instead of the config of the host
+	configDir string
+}
+
+func New(jobID string, spec *jobctl.JobContainer, mounts ...string) *Container {
+	return &Container{
+		Name:   fmt.Sprintf("zadig-job-%s", jobID),
+		spec:   spec,
+		mounts: mounts,
+	}
+}
+
+// Start pulls the image and keeps the container running until Remove is called
+func (c *Container) Start(ctx context.Context, logger *log.JobLogger) (err error) {
+	c.configDir, err = os.MkdirTemp("", c.Name+"-docker-config-")
+	if err != nil {
+		return fmt.Errorf("failed to create docker config of job container %s: %s", c.Name, err)
+	}
+	defer func() {
+		if err != nil {
+			_ = os.RemoveAll(c.configDir)
+		}
+	}()
+
+	if registry := c.spec.Registry; registry != nil && registry.Username != "" {
+		cmd := c.dockerCommand(ctx, "login", "-u", registry.Username, registry.Address, "--password-stdin")
+		cmd.Stdin = strings.NewReader(registry.Password)
+		if out, err := cmd.CombinedOutput(); err != nil {
+			return fmt.Errorf("failed to login registry %s: %s, %s", registry.Address, err, out)
+		}
+	}
+
+	// a container left by the previous run of the same job is replaced
+	_ = c.dockerCommand(context.Background(), "rm", "-f", c.Name).Run()
+
+	logger.Infof("Starting job container %s with image %s.", c.Name, c.spec.Image)
+	if out, err := c.dockerCommand(ctx, c.runArgs()...).CombinedOutput(); err != nil {
+		return fmt.Errorf("failed to start job container %s: %s, %s", c.Name, err, out)
+	}
+	return nil
+}
+
+func (c *Container) runArgs() []string {
+	args := []string{"run", "-d", "--name", c.Name, "--entrypoint", "sleep"}
+	// there are no users on windows, the container runs as the user of its image
+	if uid, gid := os.Getuid(), os.Getgid(); uid >= 0 && gid >= 0 {
+		args = append(args, "--user", fmt.Sprintf("%d:%d", uid, gid))
+	}
+	for _, mount := range c.mounts {
+		if mount == "" {
+			continue
+		}
+		args = append(args, "-v", fmt.Sprintf("%s:%s", mount, mount))
+	}
+	return append(args, c.spec.Image, "infinity")
+}
+
+func (c *Container) dockerCommand(ctx context.Context, arg ...string) *exec.Cmd {
+	cmd := exec.CommandContext(ctx, dockerBinary, arg...)
+	cmd.Env = append(os.Environ(), "DOCKER_CONFIG="+c.configDir)
+	return cmd
+}
+
+// CommandContext runs the command in the container. The docker client gets the job envs in its own environment
+// and passes them by name, so that the secrets don't show up in the process list. The envs inherited from the
+// agent host are left out, the container keeps the ones of its image.
+func (c *Container) CommandContext(ctx context.Context, dir string, envs []string, name string, arg ...string) *exec.Cmd {
+	hostEnvs := sets.NewString(os.Environ()...)
+	args := []string{"exec", "-i", "-w", dir}
+	for _, env := range envs {
+		key := strings.SplitN(env, "=", 2)[0]
+		if key == "" || hostOnlyEnvs.Has(key) || hostEnvs.Has(env) {
+			continue
+		}
+		args = append(args, "-e", key)
+	}
+	args = append(args, c.Name, name)
+	args = append(args, arg...)
+
+	cmd := exec.CommandContext(ctx, dockerBinary, args...)
+	cmd.Env = append(os.Environ(), envs...)
+	return cmd
+}
+
+// Remove removes the container and the docker config of the job
+func (c *Container) Remove() error {
+	out, err := c.dockerCommand(context.Background(), "rm", "-f", c.Name).CombinedOutput()
+	if c.configDir != "" {
+		if err := os.RemoveAll(c.configDir); err != nil {
+			return fmt.Errorf("failed to remove docker config of job container %s: %s", c.Name, err)
+		}
+	}
+	if err != nil {
+		return fmt.Errorf("failed to remove job container %s: %s, %s", c.Name, err, out)
+	}
+	return nil
+}
@@ -0,0 +1,102 @@
+/*
+Copyright 2025 The KodeRover Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package jobcontainer
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/koderover/zadig/v2/pkg/cli/zadig-agent/helper/log"
+	jobctl "github.com/koderover/zadig/v2/pkg/microservice/aslan/core/common/service/workflowcontroller/jobcontroller"
+)
+
+// fakeDocker replaces the docker client with a script recording the commands, their DOCKER_CONFIG and stdin
+func fakeDocker(t *testing.T) string {
+	if runtime.GOOS == "windows" {
+		t.Skip("the fake docker client is a shell script")
+	}
+
+	dir := t.TempDir()
+	record := filepath.Join(dir, "record")
+	script := fmt.Sprintf("#!/bin/sh\necho \"$DOCKER_CONFIG $*\" >> %s\nif [ \"$1\" = login ]; then cat >> %s; echo >> %s; fi\n", record, record, record)
+	bin := filepath.Join(dir, "docker")
+	require.NoError(t, os.WriteFile(bin, []byte(script), 0755))
+
+	old := dockerBinary
+	dockerBinary = bin
+	t.Cleanup(func() { dockerBinary = old })
+	return record
+}
+
+func TestContainer(t *testing.T) {
+	ast := require.New(t)
+	record := fakeDocker(t)
+
+	c := New("job1", &jobctl.JobContainer{
+		Image: "koderover/ubuntu:22.04",
+		Registry: &jobctl.JobContainerAuth{
+			Address:  "registry.example.com",
+			Username: "user",
+			Password: "secret",
+		},
+	}, "/workspace", "", "/scripts")
+	ast.NoError(c.Start(context.Background(), log.NewJobLogger(filepath.Join(t.TempDir(), "job.log"))))
+
+	configDir := c.configDir
+	ast.DirExists(configDir)
+	ast.NotEqual(os.Getenv("DOCKER_CONFIG"), configDir)
+
+	b, err := os.ReadFile(record)
+	ast.NoError(err)
+	ast.Equal([]string{
+		configDir + " login -u user registry.example.com --password-stdin",
+		"secret",
+		configDir + " rm -f zadig-job-job1",
+		fmt.Sprintf("%s run -d --name zadig-job-job1 --entrypoint sleep --user %d:%d -v /workspace:/workspace -v /scripts:/scripts koderover/ubuntu:22.04 infinity", configDir, os.Getuid(), os.Getgid()),
+	}, strings.Split(strings.TrimSpace(string(b)), "\n"))
+
+	ast.NoError(c.Remove())
+	ast.NoDirExists(configDir)
+}
+
+func TestContainerStartFailed(t *testing.T) {
+	ast := require.New(t)
+	fakeDocker(t)
+	dockerBinary = filepath.Join(t.TempDir(), "docker")
+
+	c := New("job1", &jobctl.JobContainer{Image: "koderover/ubuntu:22.04"})
+	ast.Error(c.Start(context.Background(), log.NewJobLogger(filepath.Join(t.TempDir(), "job.log"))))
+	ast.NoDirExists(c.configDir)
+}
+
+func TestContainerCommandContext(t *testing.T) {
+	ast := require.New(t)
+
+	t.Setenv("ZADIG_TEST_HOST_ENV", "host")
+	c := New("job1", &jobctl.JobContainer{Image: "koderover/ubuntu:22.04"})
+	cmd := c.CommandContext(context.Background(), "/workspace", []string{"HOME=/root", "ZADIG_TEST_HOST_ENV=host", "TOKEN=secret", "=invalid"}, "bash", "-c", "echo $TOKEN")
+
+	ast.Equal([]string{dockerBinary, "exec", "-i", "-w", "/workspace", "-e", "TOKEN", "zadig-job-job1", "bash", "-c", "echo $TOKEN"}, cmd.Args)
+	ast.Contains(cmd.Env, "TOKEN=secret")
+}
@@ -173,12 +173,12 @@ func (s *DockerBuildStep) dockerCommands() []*exec.Cmd {
 		s.spec.WorkDir,
 		s.spec.BuildArgs,
 		s.spec.IgnoreCache,
-		s.spec.EnableBuildkit,
-		s.spec.Platform,
+		s.spec.UseBuildx(),
+		s.spec.GetPlatform(),
 	)
 
-	if s.spec.EnableBuildkit {
-		initBuildxCmd := dockerInitBuildxCmd(s.spec.Platform, s.spec.BuildKitImage)
+	if s.spec.UseBuildx() {
+		initBuildxCmd := dockerInitBuildxCmd(s.spec.GetPlatform(), s.spec.BuildKitImage)
 		cmds = append(
 			cmds,
 			initBuildxCmd,
@@ -31,6 +31,7 @@ import (
 
 	"github.com/koderover/zadig/v2/pkg/cli/zadig-agent/config"
 	"github.com/koderover/zadig/v2/pkg/cli/zadig-agent/helper/log"
+	"github.com/koderover/zadig/v2/pkg/cli/zadig-agent/internal/agent/jobcontainer"
 	"github.com/koderover/zadig/v2/pkg/cli/zadig-agent/internal/agent/step/helper"
 	"github.com/koderover/zadig/v2/pkg/cli/zadig-agent/internal/common/types"
 	"github.com/koderover/zadig/v2/pkg/util"
@@ -43,6 +44,8 @@ type ShellStep struct {
 	secretEnvs []string
 	dirs       *types.AgentWorkDirs
 	Logger     *log.JobLogger
+	// Container runs the script instead of the agent host if it is set
+	Container *jobcontainer.Container
 }
 
 type StepShellSpec struct {
@@ -78,9 +81,14 @@ func (s *ShellStep) Run(ctx context.Context) error {
 	if err != nil {
 		return fmt.Errorf("generate script failed: %v", err)
 	}
-	cmd := exec.Command("bash", userScriptFile)
+	var cmd *exec.Cmd
+	if s.Container != nil {
+		cmd = s.Container.CommandContext(ctx, s.dirs.Workspace, s.envs, "bash", userScriptFile)
+	} else {
+		cmd = exec.CommandContext(ctx, "bash", userScriptFile)
+		cmd.Env = s.envs
+	}
 	cmd.Dir = s.dirs.Workspace
-	cmd.Env = s.envs
 
 	fileName := s.Logger.GetLogfilePath()
 
@@ -21,6 +21,7 @@ import (
 	"fmt"
 
 	"github.com/koderover/zadig/v2/pkg/cli/zadig-agent/helper/log"
+	"github.com/koderover/zadig/v2/pkg/cli/zadig-agent/internal/agent/jobcontainer"
 	"github.com/koderover/zadig/v2/pkg/cli/zadig-agent/internal/agent/step/archive"
 	"github.com/koderover/zadig/v2/pkg/cli/zadig-agent/internal/agent/step/docker"
 	"github.com/koderover/zadig/v2/pkg/cli/zadig-agent/internal/agent/step/git"
@@ -44,7 +45,7 @@ type Step interface {
 	Run(ctx context.Context) error
 }
 
-func RunStep(ctx context.Context, jobCtx *jobctl.JobContext, step *commonmodels.StepTask, dirs *types.AgentWorkDirs, envs, secretEnvs []string, logger *log.JobLogger) error {
+func RunStep(ctx context.Context, jobCtx *jobctl.JobContext, step *commonmodels.StepTask, dirs *types.AgentWorkDirs, envs, secretEnvs []string, container *jobcontainer.Container, logger *log.JobLogger) error {
 	var stepInstance Step
 	var err error
 
@@ -60,10 +61,12 @@ func RunStep(ctx context.Context, jobCtx *jobctl.JobContext, step *commonmodels.
 			return err
 		}
 	case "shell":
-		stepInstance, err = script.NewShellStep(jobCtx.Outputs, step.Spec, dirs, envs, secretEnvs, logger)
+		shellStep, err := script.NewShellStep(jobCtx.Outputs, step.Spec, dirs, envs, secretEnvs, logger)
 		if err != nil {
 			return err
 		}
+		shellStep.Container = container
+		stepInstance = shellStep
 	case "git":
 		stepInstance, err = git.NewGitStep(step.Spec, dirs, envs, secretEnvs, logger)
 		if err != nil {
@@ -79,6 +82,16 @@ func RunStep(ctx context.Context, jobCtx *jobctl.JobContext, step *commonmodels.
 		if err != nil {
 			return err
 		}
+	case "image_sign":
+		stepInstance, err = docker.NewImageSignStep(step.Spec, dirs, envs, secretEnvs, logger)
+		if err != nil {
+			return err
+		}
+	case "sbom":
+		stepInstance, err = docker.NewSBOMStep(step.Spec, dirs, envs, secretEnvs, logger)
+		if err != nil {
+			return err
+		}
 	case "archive":
 		stepInstance, err = archive.NewArchiveStep(step.Spec, dirs, envs, secretEnvs, logger)
 		if err != nil {
@@ -74,6 +74,8 @@ const (
 	DefaultAgentConcurrency          = 10
 	DefaultAgentConcurrencyBlockTime = 5
 	DefaultAgentPollingInterval      = 3
+	DefaultAgentPollingFallbackTime  = 60
+	DefaultAgentReadyInterval        = 30
 	DefaultJobReportInterval         = 1
 	DefaultJobLogReadNum             = 100
 )
@@ -0,0 +1,171 @@
+/*
+Copyright 2025 The KodeRover Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package network
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/gorilla/websocket"
+
+	"github.com/koderover/zadig/v2/pkg/cli/zadig-agent/helper/log"
+)
+
+const (
+	ConnectBaseUrl = "/api/aslan/vm/agents/connect"
+
+	JobConnMessageReady     = "ready"
+	JobConnMessageHeartbeat = "heartbeat"
+	JobConnMessageJob       = "job"
+	JobConnMessageCancel    = "cancel"
+
+	jobConnDialTimeout      = 10 * time.Second
+	jobConnWriteTimeout     = 10 * time.Second
+	jobConnHeartbeatTimeout = 10 * time.Second
+)
+
+// JobConnMessage is the message exchanged with zadig server over the job connection
+type JobConnMessage struct {
+	Type      string          `json:"type"`
+	JobID     string          `json:"job_id,omitempty"`
+	FreeSlots int             `json:"free_slots,omitempty"`
+	JobIDs    []string        `json:"job_ids,omitempty"`
+	Data      json.RawMessage `json:"data,omitempty"`
+}
+
+// JobConn is the persistent connection to zadig server, jobs and cancel signals are pushed over it
+// and heartbeats are sent over it instead of http requests.
+type JobConn struct {
+	ws       *websocket.Conn
+	messages chan *JobConnMessage
+
+	writeMu       sync.Mutex
+	heartbeatMu   sync.Mutex
+	heartbeatResp chan *HeartbeatServerResponse
+}
+
+func ConnectJob(config *AgentConfig) (*JobConn, error) {
+	u, err := url.Parse(GetFullURL(config.URL, ConnectBaseUrl))
+	if err != nil {
+		return nil, fmt.Errorf("invalid zadig server url %s, error: %s", config.URL, err)
+	}
+	switch strings.ToLower(u.Scheme) {
+	case "https":
+		u.Scheme = "wss"
+	default:
+		u.Scheme = "ws"
+	}
+	u.RawQuery = url.Values{"token": []string{config.Token}}.Encode()
+
+	dialer := &websocket.Dialer{
+		Proxy:            websocket.DefaultDialer.Proxy,
+		HandshakeTimeout: jobConnDialTimeout,
+	}
+	ws, _, err := dialer.Dial(u.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	conn := &JobConn{
+		ws:            ws,
+		messages:      make(chan *JobConnMessage, 16),
+		heartbeatResp: make(chan *HeartbeatServerResponse, 1),
+	}
+	go conn.read()
+	return conn, nil
+}
+
+// Messages returns the jobs and cancel signals from zadig server, it is closed when the connection is broken
+func (c *JobConn) Messages() <-chan *JobConnMessage {
+	return c.messages
+}
+
+func (c *JobConn) read() {
+	defer close(c.messages)
+
+	for {
+		msg := new(JobConnMessage)
+		if err := c.ws.ReadJSON(msg); err != nil {
+			log.Warnf("job connection is closed: %s", err)
+			return
+		}
+
+		if msg.Type == JobConnMessageHeartbeat {
+			resp := new(HeartbeatServerResponse)
+			if err := json.Unmarshal(msg.Data, resp); err != nil {
+				log.Errorf("invalid heartbeat response: %s", err)
+				continue
+			}
+			select {
+			case c.heartbeatResp <- resp:
+			default:
+			}
+			continue
+		}
+		c.messages <- msg
+	}
+}
+
+func (c *JobConn) Send(msg *JobConnMessage) error {
+	c.writeMu.Lock()
+	defer c.writeMu.Unlock()
+
+	if err := c.ws.SetWriteDeadline(time.Now().Add(jobConnWriteTimeout)); err != nil {
+		return err
+	}
+	return c.ws.WriteJSON(msg)
+}
+
+// Ready tells zadig server how many jobs the agent can take now and which jobs it holds
+func (c *JobConn) Ready(freeSlots int, jobIDs []string) error {
+	return c.Send(&JobConnMessage{Type: JobConnMessageReady, FreeSlots: freeSlots, JobIDs: jobIDs})
+}
+
+func (c *JobConn) Heartbeat(parameters *HeartbeatParameters) (*HeartbeatServerResponse, error) {
+	c.heartbeatMu.Lock()
+	defer c.heartbeatMu.Unlock()
+
+	data, err := json.Marshal(parameters)
+	if err != nil {
+		return nil, err
+	}
+
+	// drop the response of the previous heartbeat that is timed out
+	select {
+	case <-c.heartbeatResp:
+	default:
+	}
+
+	if err := c.Send(&JobConnMessage{Type: JobConnMessageHeartbeat, Data: data}); err != nil {
+		return nil, err
+	}
+
+	select {
+	case resp := <-c.heartbeatResp:
+		return resp, nil
+	case <-time.After(jobConnHeartbeatTimeout):
+		return nil, fmt.Errorf("heartbeat over job connection timed out")
+	}
+}
+
+func (c *JobConn) Close() error {
+	return c.ws.Close()
+}
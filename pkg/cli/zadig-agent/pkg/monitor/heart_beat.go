@@ -122,7 +122,17 @@ func Heartbeat(agentCtl *agent.AgentController, errChan chan error, successChan
 		URL:   agentconfig.GetServerURL(),
 	}
 
-	resp, err := network.Heartbeat(config, params)
+	// heartbeat goes over the job connection if it is established, http request is the fallback
+	var resp *network.HeartbeatServerResponse
+	if conn := agentCtl.GetJobConn(); conn != nil {
+		resp, err = conn.Heartbeat(params)
+		if err != nil {
+			log.Warnf("failed to heartbeat over job connection, error: %v", err)
+		}
+	}
+	if resp == nil {
+		resp, err = network.Heartbeat(config, params)
+	}
 	if err != nil {
 		errChan <- err
 		return
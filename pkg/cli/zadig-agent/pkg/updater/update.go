@@ -31,7 +31,7 @@ func UpdateAgent(agentCtl *agent.AgentController, version string) error {
 
 	for {
 		// check agent current job number
-		if agentCtl.CurrentJobNum == 0 {
+		if agentCtl.CurrentJobNum.Load() == 0 {
 			break
 		}
 		time.Sleep(1 * time.Second)
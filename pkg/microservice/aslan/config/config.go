@@ -166,6 +166,20 @@ func BuildKitImage() string {
 	return viper.GetString(setting.ENVBuildKitImage)
 }
 
+func RootlessBuildKitImage() string {
+	if image := viper.GetString(setting.ENVRootlessBuildKitImage); image != "" {
+		return image
+	}
+	return setting.DefaultRootlessBuildKitImage
+}
+
+func MockResponderImage() string {
+	if image := viper.GetString(setting.ENVMockResponderImage); image != "" {
+		return image
+	}
+	return setting.DefaultMockResponderImage
+}
+
 func ProxySocks5Addr() string {
 	return viper.GetString(setting.ProxySocks5Addr)
 }
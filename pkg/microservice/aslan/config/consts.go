@@ -209,6 +209,10 @@ const (
 	StepDistributeImage   StepType = "distribute_image"
 	StepDebugBefore       StepType = "debug_before"
 	StepDebugAfter        StepType = "debug_after"
+	StepImageScan         StepType = "image_scan"
+	StepImageSign         StepType = "image_sign"
+	StepSBOM              StepType = "sbom"
+	StepMockVerify        StepType = "mock_verify"
 )
 
 type JobType string
@@ -223,6 +227,7 @@ const (
 	JobZadigDistributeImage JobType = "zadig-distribute-image"
 	JobZadigTesting         JobType = "zadig-test"
 	JobZadigScanning        JobType = "zadig-scanning"
+	JobZadigImageScan       JobType = "zadig-image-scan"
 	JobCustomDeploy         JobType = "custom-deploy"
 	JobZadigDeploy          JobType = "zadig-deploy"
 	JobZadigVMDeploy        JobType = "zadig-vm-deploy"
@@ -626,6 +631,9 @@ const (
 	DashboardDataTypeReleaseSuccessRate     = "release_success_rate"
 	DashboardDataTypeReleaseAverageDuration = "release_average_duration"
 	DashboardDataTypeReleaseFrequency       = "release_frequency"
+	DashboardDataTypeLeadTime               = "lead_time"
+	DashboardDataTypeChangeFailureRate      = "change_failure_rate"
+	DashboardDataTypeMTTR                   = "mttr"
 
 	DashboardDataSourceZadig = "zadig"
 	DashboardDataSourceApi   = "api"
@@ -667,6 +675,7 @@ const (
 	ReleasePlanStatusWaitForExecuteExternalCheckFailed ReleasePlanStatus = "wait_for_execute_external_check_failed"
 	ReleasePlanStatusWaitForAllDoneExternalCheckFailed ReleasePlanStatus = "wait_for_all_done_external_check_failed"
 	ReleasePlanStatusCancel                            ReleasePlanStatus = "cancel"
+	ReleasePlanStatusFailed                            ReleasePlanStatus = "failed" // only used by rollback plans
 )
 
 // ReleasePlanStatusMap is a map of status and its available next status
@@ -834,6 +843,17 @@ const (
 	ApplicationFieldSourceCustom  ApplicationFieldSourceType = "custom"
 )
 
+// Application scorecard rule types
+type ApplicationScorecardRuleType string
+
+const (
+	ApplicationScorecardRuleTypeTestingPassRate  ApplicationScorecardRuleType = "testing_pass_rate"
+	ApplicationScorecardRuleTypeProductionDeploy ApplicationScorecardRuleType = "production_deploy"
+	ApplicationScorecardRuleTypeImageScan        ApplicationScorecardRuleType = "image_scan"
+	ApplicationScorecardRuleTypeOwnerSet         ApplicationScorecardRuleType = "owner_set"
+	ApplicationScorecardRuleTypeCustomFieldSet   ApplicationScorecardRuleType = "custom_field_set"
+)
+
 // Application filter field categories used by search filtering
 type ApplicationFilterFieldType string
 
@@ -891,3 +911,56 @@ const (
 	ApisixItemTypeService  ApisixItemType = "service"
 	ApisixItemTypeProto    ApisixItemType = "proto"
 )
+
+type ImageSigningKeySource string
+
+const (
+	ImageSigningKeySourceKeyVault ImageSigningKeySource = "keyvault"
+	ImageSigningKeySourceKMS      ImageSigningKeySource = "kms"
+)
+
+type KeyVaultProviderType string
+
+const (
+	KeyVaultProviderTypeNative            KeyVaultProviderType = "native"
+	KeyVaultProviderTypeVault             KeyVaultProviderType = "vault"
+	KeyVaultProviderTypeAWSSecretsManager KeyVaultProviderType = "aws_secrets_manager"
+	KeyVaultProviderTypeKubernetes        KeyVaultProviderType = "kubernetes"
+)
+
+type AdmissionPolicyScope string
+
+const (
+	AdmissionPolicyScopeWorkflowTask AdmissionPolicyScope = "workflow_task"
+	AdmissionPolicyScopeDeploy       AdmissionPolicyScope = "deploy"
+)
+
+type AdmissionPolicyEnforcement string
+
+const (
+	// AdmissionPolicyEnforcementDeny rejects the operation on violations
+	AdmissionPolicyEnforcementDeny AdmissionPolicyEnforcement = "deny"
+	// AdmissionPolicyEnforcementAudit only records the violations, used to try out a policy
+	AdmissionPolicyEnforcementAudit AdmissionPolicyEnforcement = "audit"
+)
+
+// Resource cost categories
+type CostCategory string
+
+const (
+	CostCategoryEnvironment CostCategory = "environment"
+	CostCategoryBuild       CostCategory = "build"
+	CostCategoryTesting     CostCategory = "testing"
+)
+
+// CostUsageMode decides the resource amount billed for a pod
+type CostUsageMode string
+
+const (
+	// CostUsageModeRequest bills the resource requests
+	CostUsageModeRequest CostUsageMode = "request"
+	// CostUsageModeUsage bills the actual usage
+	CostUsageModeUsage CostUsageMode = "usage"
+	// CostUsageModeMax bills the larger one of the requests and the usage
+	CostUsageModeMax CostUsageMode = "max"
+)
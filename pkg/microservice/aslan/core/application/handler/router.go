@@ -37,6 +37,22 @@ func (*Router) Inject(router *gin.RouterGroup) {
 		application.POST("/search", SearchApplications)
 	}
 
+	catalog := router.Group("catalog")
+	{
+		catalog.POST("/discover", DiscoverCatalog)
+		catalog.GET("/export", ExportCatalog)
+	}
+
+	scorecards := router.Group("scorecards")
+	{
+		scorecards.GET("/rules", ListScorecardRules)
+		scorecards.POST("/rules", CreateScorecardRule)
+		scorecards.PUT("/rules/:id", UpdateScorecardRule)
+		scorecards.DELETE("/rules/:id", DeleteScorecardRule)
+		scorecards.POST("/evaluate", EvaluateScorecards)
+		scorecards.GET("/rollups", ListScorecardRollups)
+	}
+
 	fields := router.Group("fields")
 	{
 		fields.POST("", CreateFieldDefinition)
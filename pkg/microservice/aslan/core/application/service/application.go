@@ -392,6 +392,8 @@ func CreateApplication(app *commonmodels.Application, logger *zap.SugaredLogger)
 	if err := validateAndPruneCustomFields(app); err != nil {
 		return nil, err
 	}
+	// scorecards are only written by the scorecard evaluation
+	app.Scorecard = nil
 
 	oid, err := commonrepo.NewApplicationColl().Create(context.Background(), app)
 	if err != nil {
@@ -438,6 +440,7 @@ func BulkCreateApplications(apps []*commonmodels.Application, logger *zap.Sugare
 		if err := validateAndPruneCustomFields(app); err != nil {
 			return err
 		}
+		app.Scorecard = nil
 	}
 
 	// Check for conflicts within the bulk data itself
@@ -707,6 +710,8 @@ func getAppFieldValue(app *commonmodels.Application, path string) (interface{},
 		return app.Type, true
 	case "owner":
 		return app.Owner, true
+	case "lifecycle":
+		return app.Lifecycle, true
 	case "description":
 		return app.Description, true
 	case "create_time":
@@ -718,7 +723,28 @@ func getAppFieldValue(app *commonmodels.Application, path string) (interface{},
 			return nil, false
 		}
 		return app.Repository.CodehostID, true
+	case "scorecard.score", "scorecard.passed", "scorecard.total", "scorecard.evaluate_time":
+		if app.Scorecard == nil {
+			return nil, false
+		}
+		switch path {
+		case "scorecard.score":
+			return app.Scorecard.Score, true
+		case "scorecard.passed":
+			return app.Scorecard.Passed, true
+		case "scorecard.total":
+			return app.Scorecard.Total, true
+		default:
+			return app.Scorecard.EvaluateTime, true
+		}
 	default:
+		if strings.HasPrefix(path, "scorecard.rules.") {
+			if app.Scorecard == nil {
+				return nil, false
+			}
+			v, ok := app.Scorecard.Rules[strings.TrimPrefix(path, "scorecard.rules.")]
+			return v, ok
+		}
 		if strings.HasPrefix(path, "custom_fields.") {
 			key := strings.TrimPrefix(path, "custom_fields.")
 			if app.CustomFields == nil {
@@ -782,6 +808,7 @@ func UpdateApplication(id string, app *commonmodels.Application, logger *zap.Sug
 	}
 
 	app.ID = old.ID
+	app.Scorecard = old.Scorecard
 
 	// Check if service links have changed
 	testingChanged := app.TestingServiceName != old.TestingServiceName
@@ -1308,11 +1335,16 @@ func resolveField(field string, defs map[string]*commonmodels.ApplicationFieldDe
 		return field, cat, nil
 	}
 	switch field {
-	case "name", "key", "project", "description", "testing_service_config", "production_service_config", "owner", "type":
+	case "name", "key", "project", "description", "testing_service_config", "production_service_config", "owner", "type", "lifecycle":
 		return field, string(config.ApplicationFilterFieldTypeString), nil
 	case "repository.codehost_id", "create_time", "update_time":
 		return field, string(config.ApplicationFilterFieldTypeNumber), nil
+	case "scorecard.score", "scorecard.passed", "scorecard.total", "scorecard.evaluate_time":
+		return field, string(config.ApplicationFilterFieldTypeNumber), nil
 	default:
+		if strings.HasPrefix(field, "scorecard.rules.") && len(field) > len("scorecard.rules.") {
+			return field, string(config.ApplicationFilterFieldTypeBool), nil
+		}
 		return "", "", e.ErrInvalidParam.AddDesc("unknown field: " + field)
 	}
 }
@@ -0,0 +1,588 @@
+/*
+Copyright 2025 The KodeRover Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package service
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.uber.org/zap"
+	"gopkg.in/yaml.v3"
+	"k8s.io/apimachinery/pkg/util/sets"
+
+	"github.com/koderover/zadig/v2/pkg/microservice/aslan/config"
+	"github.com/koderover/zadig/v2/pkg/microservice/aslan/core/code/client"
+	"github.com/koderover/zadig/v2/pkg/microservice/aslan/core/code/client/open"
+	commonmodels "github.com/koderover/zadig/v2/pkg/microservice/aslan/core/common/repository/models"
+	commonrepo "github.com/koderover/zadig/v2/pkg/microservice/aslan/core/common/repository/mongodb"
+	"github.com/koderover/zadig/v2/pkg/microservice/aslan/core/common/service/fs"
+	"github.com/koderover/zadig/v2/pkg/setting"
+	"github.com/koderover/zadig/v2/pkg/shared/client/systemconfig"
+	e "github.com/koderover/zadig/v2/pkg/tool/errors"
+)
+
+const (
+	CatalogAPIVersion    = "backstage.io/v1alpha1"
+	CatalogKindComponent = "Component"
+	CatalogDefaultPath   = "catalog-info.yaml"
+
+	// annotations carrying zadig specific information on a catalog entity
+	CatalogAnnotationProject           = "zadig.io/project"
+	CatalogAnnotationTestingService    = "zadig.io/testing-service"
+	CatalogAnnotationProductionService = "zadig.io/production-service"
+	// custom fields are stored as zadig.io/field.<key>, a label named <key> is used as a fallback.
+	CatalogAnnotationFieldPrefix    = "zadig.io/field."
+	CatalogAnnotationSourceLocation = "backstage.io/source-location"
+
+	CatalogImportActionCreated = "created"
+	CatalogImportActionUpdated = "updated"
+	CatalogImportActionFailed  = "failed"
+
+	catalogListPageSize = 100
+)
+
+// CatalogEntity is the subset of a Backstage catalog entity that maps to an application.
+type CatalogEntity struct {
+	APIVersion string                 `yaml:"apiVersion" json:"apiVersion"`
+	Kind       string                 `yaml:"kind"       json:"kind"`
+	Metadata   *CatalogEntityMetadata `yaml:"metadata"   json:"metadata"`
+	Spec       *CatalogEntitySpec     `yaml:"spec"       json:"spec"`
+}
+
+type CatalogEntityMetadata struct {
+	Name        string            `yaml:"name"                  json:"name"`
+	Title       string            `yaml:"title,omitempty"       json:"title,omitempty"`
+	Description string            `yaml:"description,omitempty" json:"description,omitempty"`
+	Labels      map[string]string `yaml:"labels,omitempty"      json:"labels,omitempty"`
+	Annotations map[string]string `yaml:"annotations,omitempty" json:"annotations,omitempty"`
+	Tags        []string          `yaml:"tags,omitempty"        json:"tags,omitempty"`
+}
+
+type CatalogEntitySpec struct {
+	Type      string `yaml:"type,omitempty"      json:"type,omitempty"`
+	Lifecycle string `yaml:"lifecycle,omitempty" json:"lifecycle,omitempty"`
+	Owner     string `yaml:"owner,omitempty"     json:"owner,omitempty"`
+	System    string `yaml:"system,omitempty"    json:"system,omitempty"`
+}
+
+type CatalogSource struct {
+	CodehostID    int    `json:"codehost_id"`
+	Namespace     string `json:"namespace"`
+	NamespaceType string `json:"namespace_type"`
+	// Repos limits the scan to the given repositories, all repositories of the namespace are scanned if empty.
+	Repos []string `json:"repos"`
+	// Branch overrides the default branch of the scanned repositories.
+	Branch string `json:"branch"`
+}
+
+type DiscoverCatalogRequest struct {
+	Sources []*CatalogSource `json:"sources"`
+	// Project is used for entities without the zadig.io/project annotation.
+	Project string `json:"project"`
+	Path    string `json:"path"`
+	DryRun  bool   `json:"dry_run"`
+}
+
+type CatalogImportResult struct {
+	Key      string `json:"key"`
+	Location string `json:"location"`
+	Action   string `json:"action"`
+	Error    string `json:"error,omitempty"`
+}
+
+type DiscoverCatalogResponse struct {
+	ScannedRepos int                    `json:"scanned_repos"`
+	Results      []*CatalogImportResult `json:"results"`
+}
+
+type catalogRepo struct {
+	owner  string
+	name   string
+	branch string
+}
+
+// DiscoverCatalog scans the repositories of the given code host sources for catalog descriptors
+// and upserts an application for every Component entity found, keyed by the entity name.
+func DiscoverCatalog(req *DiscoverCatalogRequest, logger *zap.SugaredLogger) (*DiscoverCatalogResponse, error) {
+	if req == nil || len(req.Sources) == 0 {
+		return nil, e.ErrInvalidParam.AddDesc("at least one source is required")
+	}
+	path := req.Path
+	if path == "" {
+		path = CatalogDefaultPath
+	}
+
+	defMap, err := listFieldDefinitionMap()
+	if err != nil {
+		return nil, err
+	}
+
+	resp := &DiscoverCatalogResponse{Results: make([]*CatalogImportResult, 0)}
+	for _, source := range req.Sources {
+		ch, err := systemconfig.New().GetCodeHost(source.CodehostID)
+		if err != nil {
+			return nil, e.ErrInvalidParam.AddDesc(fmt.Sprintf("failed to get codehost %d: %s", source.CodehostID, err))
+		}
+		getter, err := fs.GetTreeGetter(source.CodehostID)
+		if err != nil {
+			return nil, e.ErrInvalidParam.AddDesc(fmt.Sprintf("codehost %d does not support catalog discovery: %s", source.CodehostID, err))
+		}
+		repos, err := listCatalogRepos(ch, source, logger)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, repo := range repos {
+			resp.ScannedRepos++
+			location := fmt.Sprintf("%s/%s@%s:%s", repo.owner, repo.name, repo.branch, path)
+			content, err := getter.GetFileContent(repo.owner, repo.name, path, repo.branch)
+			if err != nil || len(content) == 0 {
+				logger.Debugf("no catalog descriptor found in %s: %v", location, err)
+				continue
+			}
+
+			entities, err := ParseCatalogEntities(content)
+			if err != nil {
+				resp.Results = append(resp.Results, &CatalogImportResult{Location: location, Action: CatalogImportActionFailed, Error: err.Error()})
+				continue
+			}
+			for _, entity := range entities {
+				if entity.Kind != CatalogKindComponent {
+					continue
+				}
+				result := &CatalogImportResult{Key: entity.Metadata.Name, Location: location}
+				app, err := catalogEntityToApplication(entity, defMap, req.Project)
+				if err == nil {
+					app.Repository = &commonmodels.ApplicationRepositoryRef{
+						CodehostID:    source.CodehostID,
+						RepoOwner:     repo.owner,
+						RepoNamespace: repo.owner,
+						RepoName:      repo.name,
+						Branch:        repo.branch,
+					}
+					result.Action, err = importCatalogApplication(app, req.DryRun, logger)
+				}
+				if err != nil {
+					result.Action = CatalogImportActionFailed
+					result.Error = err.Error()
+				}
+				resp.Results = append(resp.Results, result)
+			}
+		}
+	}
+	return resp, nil
+}
+
+func listCatalogRepos(ch *systemconfig.CodeHost, source *CatalogSource, logger *zap.SugaredLogger) ([]*catalogRepo, error) {
+	cli, err := open.OpenClient(ch, logger)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open codehost %d: %w", ch.ID, err)
+	}
+
+	namespaces := []*client.Namespace{{Name: source.Namespace, Path: source.Namespace, Kind: source.NamespaceType}}
+	if source.Namespace == "" {
+		namespaces, err = cli.ListNamespaces("")
+		if err != nil {
+			return nil, fmt.Errorf("failed to list namespaces of codehost %d: %w", ch.ID, err)
+		}
+	}
+
+	wanted := sets.NewString(source.Repos...)
+	repos := make([]*catalogRepo, 0)
+	for _, ns := range namespaces {
+		seen := sets.NewInt()
+		// some code hosts ignore pagination and return every project on each page, stop once no new project shows up
+		for page := 1; ; page++ {
+			projects, err := cli.ListProjects(client.ListOpt{
+				Namespace:     ns.Path,
+				NamespaceType: ns.Kind,
+				Page:          page,
+				PerPage:       catalogListPageSize,
+			})
+			if err != nil {
+				return nil, fmt.Errorf("failed to list projects of %s: %w", ns.Path, err)
+			}
+
+			found := false
+			for _, p := range projects {
+				if seen.Has(p.ID) {
+					continue
+				}
+				seen.Insert(p.ID)
+				found = true
+
+				name := p.Name
+				if p.RepoID != "" {
+					name = p.RepoID
+				}
+				if wanted.Len() > 0 && !wanted.Has(name) {
+					continue
+				}
+				owner := p.Namespace
+				if owner == "" {
+					owner = ns.Path
+				}
+				branch := p.DefaultBranch
+				if source.Branch != "" {
+					branch = source.Branch
+				}
+				repos = append(repos, &catalogRepo{owner: owner, name: name, branch: branch})
+			}
+			if !found || len(projects) < catalogListPageSize {
+				break
+			}
+		}
+	}
+	return repos, nil
+}
+
+// ParseCatalogEntities decodes all entities of a, possibly multi-document, catalog descriptor.
+func ParseCatalogEntities(content []byte) ([]*CatalogEntity, error) {
+	entities := make([]*CatalogEntity, 0)
+	decoder := yaml.NewDecoder(bytes.NewReader(content))
+	for {
+		entity := new(CatalogEntity)
+		err := decoder.Decode(entity)
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("invalid catalog descriptor: %w", err)
+		}
+		if entity.Kind == "" {
+			continue
+		}
+		if entity.Metadata == nil || entity.Metadata.Name == "" {
+			return nil, fmt.Errorf("catalog entity of kind %s has no metadata.name", entity.Kind)
+		}
+		if entity.Spec == nil {
+			entity.Spec = new(CatalogEntitySpec)
+		}
+		entities = append(entities, entity)
+	}
+	return entities, nil
+}
+
+func catalogEntityToApplication(entity *CatalogEntity, defMap map[string]*commonmodels.ApplicationFieldDefinition, defaultProject string) (*commonmodels.Application, error) {
+	meta := entity.Metadata
+	app := &commonmodels.Application{
+		Name:                  meta.Title,
+		Key:                   meta.Name,
+		Project:               meta.Annotations[CatalogAnnotationProject],
+		Type:                  entity.Spec.Type,
+		Owner:                 parseCatalogOwner(entity.Spec.Owner),
+		Lifecycle:             entity.Spec.Lifecycle,
+		Description:           meta.Description,
+		TestingServiceName:    meta.Annotations[CatalogAnnotationTestingService],
+		ProductionServiceName: meta.Annotations[CatalogAnnotationProductionService],
+		CustomFields:          map[string]interface{}{},
+	}
+	if app.Name == "" {
+		app.Name = meta.Name
+	}
+	if app.Project == "" {
+		app.Project = defaultProject
+	}
+
+	for key, def := range defMap {
+		if def.Source == config.ApplicationFieldSourceBuiltin {
+			continue
+		}
+		raw, ok := meta.Annotations[CatalogAnnotationFieldPrefix+key]
+		if !ok {
+			raw, ok = meta.Labels[key]
+		}
+		if !ok {
+			continue
+		}
+		val, err := parseCatalogFieldValue(def.Type, raw)
+		if err != nil {
+			return nil, fmt.Errorf("invalid value %q for custom field %s: %w", raw, key, err)
+		}
+		if val != nil {
+			app.CustomFields[key] = val
+		}
+	}
+	return app, nil
+}
+
+// parseCatalogOwner turns an entity reference like group:default/team-a into team-a.
+func parseCatalogOwner(ref string) string {
+	if i := strings.Index(ref, ":"); i >= 0 {
+		ref = ref[i+1:]
+	}
+	if i := strings.LastIndex(ref, "/"); i >= 0 {
+		ref = ref[i+1:]
+	}
+	return ref
+}
+
+// parseCatalogFieldValue converts the string form used in annotations and labels into the
+// value type expected by the custom field definition. Unsupported types are ignored.
+func parseCatalogFieldValue(fieldType config.ApplicationCustomFieldType, raw string) (interface{}, error) {
+	raw = strings.TrimSpace(raw)
+	switch fieldType {
+	case config.ApplicationCustomFieldTypeText, config.ApplicationCustomFieldTypeSingleSelect, config.ApplicationCustomFieldTypeLink, config.ApplicationCustomFieldTypeUser, config.ApplicationCustomFieldTypeUserGroup, config.ApplicationCustomFieldTypeProject:
+		return raw, nil
+	case config.ApplicationCustomFieldTypeNumber:
+		return strconv.ParseFloat(raw, 64)
+	case config.ApplicationCustomFieldTypeDatetime:
+		if f, err := strconv.ParseFloat(raw, 64); err == nil {
+			return f, nil
+		}
+		t, err := time.Parse(time.RFC3339, raw)
+		if err != nil {
+			return nil, err
+		}
+		return float64(t.Unix()), nil
+	case config.ApplicationCustomFieldTypeBool:
+		return strconv.ParseBool(raw)
+	case config.ApplicationCustomFieldTypeMultiSelect:
+		values := make([]interface{}, 0)
+		for _, v := range strings.Split(raw, ",") {
+			if v = strings.TrimSpace(v); v != "" {
+				values = append(values, v)
+			}
+		}
+		return values, nil
+	default:
+		return nil, nil
+	}
+}
+
+func formatCatalogFieldValue(val interface{}) string {
+	switch v := val.(type) {
+	case string:
+		return v
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64)
+	case bool:
+		return strconv.FormatBool(v)
+	case primitive.A:
+		return formatCatalogFieldValue([]interface{}(v))
+	case []interface{}:
+		values := make([]string, 0, len(v))
+		for _, it := range v {
+			values = append(values, fmt.Sprint(it))
+		}
+		return strings.Join(values, ",")
+	default:
+		return fmt.Sprint(v)
+	}
+}
+
+// importCatalogApplication creates the application or updates the one with the same key. Fields absent
+// from the catalog entity are kept, and services named after the application key are linked when no
+// service link is given explicitly.
+func importCatalogApplication(app *commonmodels.Application, dryRun bool, logger *zap.SugaredLogger) (string, error) {
+	existing, err := commonrepo.NewApplicationColl().GetByKey(context.Background(), app.Key)
+	if err != nil && !errors.Is(err, mongo.ErrNoDocuments) {
+		return "", err
+	}
+
+	if errors.Is(err, mongo.ErrNoDocuments) {
+		if err := validateApplicationBaseFields(app); err != nil {
+			return "", err
+		}
+		autoLinkCatalogServices(app, primitive.NilObjectID, logger)
+		if dryRun {
+			return CatalogImportActionCreated, validateAndPruneCustomFields(app)
+		}
+		if _, err := CreateApplication(app, logger); err != nil {
+			return "", err
+		}
+		return CatalogImportActionCreated, nil
+	}
+
+	merged := *existing
+	merged.Name = app.Name
+	merged.Owner = app.Owner
+	merged.Lifecycle = app.Lifecycle
+	merged.Repository = app.Repository
+	if app.Type != "" {
+		merged.Type = app.Type
+	}
+	if app.Description != "" {
+		merged.Description = app.Description
+	}
+	if app.Project != "" {
+		merged.Project = app.Project
+	}
+	if app.TestingServiceName != "" {
+		merged.TestingServiceName = app.TestingServiceName
+	}
+	if app.ProductionServiceName != "" {
+		merged.ProductionServiceName = app.ProductionServiceName
+	}
+	merged.CustomFields = map[string]interface{}{}
+	for k, v := range existing.CustomFields {
+		merged.CustomFields[k] = v
+	}
+	for k, v := range app.CustomFields {
+		merged.CustomFields[k] = v
+	}
+	autoLinkCatalogServices(&merged, existing.ID, logger)
+
+	if dryRun {
+		if err := validateApplicationBaseFields(&merged); err != nil {
+			return "", err
+		}
+		return CatalogImportActionUpdated, validateAndPruneCustomFields(&merged)
+	}
+	if err := UpdateApplication(existing.ID.Hex(), &merged, logger); err != nil {
+		return "", err
+	}
+	return CatalogImportActionUpdated, nil
+}
+
+// autoLinkCatalogServices fills in empty service links with the testing and production services
+// that carry the application key as name, as long as they are not linked to another application.
+func autoLinkCatalogServices(app *commonmodels.Application, id primitive.ObjectID, logger *zap.SugaredLogger) {
+	if app.TestingServiceName == "" && validateServiceLink(app.Key, app.Project, id, true, logger) == nil {
+		app.TestingServiceName = app.Key
+	}
+	if app.ProductionServiceName == "" && validateServiceLink(app.Key, app.Project, id, false, logger) == nil {
+		app.ProductionServiceName = app.Key
+	}
+}
+
+// ExportCatalog renders the applications, optionally limited to a project, as catalog Component entities.
+func ExportCatalog(project string, logger *zap.SugaredLogger) ([]byte, error) {
+	query := bson.M{}
+	if project != "" {
+		query["project"] = project
+	}
+
+	codehosts := map[int]*systemconfig.CodeHost{}
+	buf := new(bytes.Buffer)
+	encoder := yaml.NewEncoder(buf)
+	encoder.SetIndent(2)
+	for page := int64(1); ; page++ {
+		apps, total, err := commonrepo.NewApplicationColl().List(context.Background(), &commonrepo.ApplicationListOptions{
+			Query:    query,
+			Sort:     bson.D{{Key: "key", Value: 1}},
+			Page:     page,
+			PageSize: catalogListPageSize,
+		})
+		if err != nil {
+			return nil, err
+		}
+		for _, app := range apps {
+			if err := encoder.Encode(applicationToCatalogEntity(app, codehosts, logger)); err != nil {
+				return nil, fmt.Errorf("failed to encode catalog entity %s: %w", app.Key, err)
+			}
+		}
+		if page*catalogListPageSize >= total {
+			break
+		}
+	}
+	if err := encoder.Close(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+func applicationToCatalogEntity(app *commonmodels.Application, codehosts map[int]*systemconfig.CodeHost, logger *zap.SugaredLogger) *CatalogEntity {
+	annotations := map[string]string{
+		CatalogAnnotationProject: app.Project,
+	}
+	if app.TestingServiceName != "" {
+		annotations[CatalogAnnotationTestingService] = app.TestingServiceName
+	}
+	if app.ProductionServiceName != "" {
+		annotations[CatalogAnnotationProductionService] = app.ProductionServiceName
+	}
+	for key, val := range app.CustomFields {
+		annotations[CatalogAnnotationFieldPrefix+key] = formatCatalogFieldValue(val)
+	}
+	if location := catalogSourceLocation(app.Repository, codehosts, logger); location != "" {
+		annotations[CatalogAnnotationSourceLocation] = location
+	}
+
+	entity := &CatalogEntity{
+		APIVersion: CatalogAPIVersion,
+		Kind:       CatalogKindComponent,
+		Metadata: &CatalogEntityMetadata{
+			Name:        app.Key,
+			Description: app.Description,
+			Annotations: annotations,
+		},
+		Spec: &CatalogEntitySpec{
+			Type:      app.Type,
+			Lifecycle: app.Lifecycle,
+			Owner:     app.Owner,
+		},
+	}
+	if app.Name != app.Key {
+		entity.Metadata.Title = app.Name
+	}
+	return entity
+}
+
+func catalogSourceLocation(repo *commonmodels.ApplicationRepositoryRef, codehosts map[int]*systemconfig.CodeHost, logger *zap.SugaredLogger) string {
+	if repo == nil || repo.CodehostID == 0 || repo.RepoName == "" {
+		return ""
+	}
+	ch, ok := codehosts[repo.CodehostID]
+	if !ok {
+		var err error
+		ch, err = systemconfig.New().GetCodeHost(repo.CodehostID)
+		if err != nil {
+			logger.Warnf("failed to get codehost %d: %s", repo.CodehostID, err)
+		}
+		codehosts[repo.CodehostID] = ch
+	}
+	if ch == nil {
+		return ""
+	}
+
+	owner := repo.RepoNamespace
+	if owner == "" {
+		owner = repo.RepoOwner
+	}
+	location := fmt.Sprintf("url:%s/%s/%s", strings.TrimSuffix(ch.Address, "/"), owner, repo.RepoName)
+	if repo.Branch == "" {
+		return location
+	}
+	switch ch.Type {
+	case setting.SourceFromGitlab:
+		return fmt.Sprintf("%s/-/tree/%s/", location, repo.Branch)
+	default:
+		return fmt.Sprintf("%s/tree/%s/", location, repo.Branch)
+	}
+}
+
+func listFieldDefinitionMap() (map[string]*commonmodels.ApplicationFieldDefinition, error) {
+	defs, err := commonrepo.NewApplicationFieldDefinitionColl().List(context.Background())
+	if err != nil {
+		return nil, err
+	}
+	defMap := map[string]*commonmodels.ApplicationFieldDefinition{}
+	for _, d := range defs {
+		defMap[d.Key] = d
+	}
+	return defMap, nil
+}
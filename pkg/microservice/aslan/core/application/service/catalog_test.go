@@ -0,0 +1,140 @@
+/*
+Copyright 2025 The KodeRover Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package service
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/koderover/zadig/v2/pkg/microservice/aslan/config"
+	commonmodels "github.com/koderover/zadig/v2/pkg/microservice/aslan/core/common/repository/models"
+)
+
+const testCatalogDescriptor = `
+apiVersion: backstage.io/v1alpha1
+kind: Component
+metadata:
+  name: payment
+  title: Payment Service
+  description: handles payments
+  labels:
+    tier: backend
+  annotations:
+    zadig.io/project: shop
+    zadig.io/production-service: payment-prod
+    zadig.io/field.replicas: "3"
+    zadig.io/field.regions: cn, us
+spec:
+  type: service
+  lifecycle: production
+  owner: group:default/team-pay
+---
+apiVersion: backstage.io/v1alpha1
+kind: API
+metadata:
+  name: payment-api
+spec:
+  type: openapi
+`
+
+var _ = Describe("Testing service catalog", func() {
+
+	defMap := map[string]*commonmodels.ApplicationFieldDefinition{
+		"tier":     {Key: "tier", Type: config.ApplicationCustomFieldTypeText},
+		"replicas": {Key: "replicas", Type: config.ApplicationCustomFieldTypeNumber},
+		"regions":  {Key: "regions", Type: config.ApplicationCustomFieldTypeMultiSelect, Options: []string{"cn", "us"}},
+		"critical": {Key: "critical", Type: config.ApplicationCustomFieldTypeBool},
+	}
+
+	Context("ParseCatalogEntities", func() {
+		It("should decode every entity of a multi-document descriptor", func() {
+			entities, err := ParseCatalogEntities([]byte(testCatalogDescriptor))
+			Expect(err).NotTo(HaveOccurred())
+			Expect(entities).To(HaveLen(2))
+			Expect(entities[0].Kind).To(Equal(CatalogKindComponent))
+			Expect(entities[1].Kind).To(Equal("API"))
+		})
+
+		It("should reject entities without a name", func() {
+			_, err := ParseCatalogEntities([]byte("kind: Component\nmetadata:\n  title: nameless\n"))
+			Expect(err).To(HaveOccurred())
+		})
+	})
+
+	Context("catalogEntityToApplication", func() {
+		It("should map owner, lifecycle, service links and custom fields", func() {
+			entities, err := ParseCatalogEntities([]byte(testCatalogDescriptor))
+			Expect(err).NotTo(HaveOccurred())
+
+			app, err := catalogEntityToApplication(entities[0], defMap, "default-project")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(app.Key).To(Equal("payment"))
+			Expect(app.Name).To(Equal("Payment Service"))
+			Expect(app.Project).To(Equal("shop"))
+			Expect(app.Owner).To(Equal("team-pay"))
+			Expect(app.Lifecycle).To(Equal("production"))
+			Expect(app.Type).To(Equal("service"))
+			Expect(app.TestingServiceName).To(BeEmpty())
+			Expect(app.ProductionServiceName).To(Equal("payment-prod"))
+			Expect(app.CustomFields).To(Equal(map[string]interface{}{
+				"tier":     "backend",
+				"replicas": float64(3),
+				"regions":  []interface{}{"cn", "us"},
+			}))
+		})
+
+		It("should fail on values not matching the field type", func() {
+			entity := &CatalogEntity{
+				Kind:     CatalogKindComponent,
+				Metadata: &CatalogEntityMetadata{Name: "svc", Annotations: map[string]string{"zadig.io/field.critical": "maybe"}},
+				Spec:     &CatalogEntitySpec{},
+			}
+			_, err := catalogEntityToApplication(entity, defMap, "default-project")
+			Expect(err).To(HaveOccurred())
+		})
+	})
+
+	Context("applicationToCatalogEntity", func() {
+		It("should round trip through the catalog mapping", func() {
+			app := &commonmodels.Application{
+				Name:               "Payment Service",
+				Key:                "payment",
+				Project:            "shop",
+				Type:               "service",
+				Owner:              "team-pay",
+				Lifecycle:          "production",
+				TestingServiceName: "payment",
+				CustomFields: map[string]interface{}{
+					"replicas": float64(3),
+					"regions":  []interface{}{"cn", "us"},
+					"critical": true,
+				},
+			}
+			entity := applicationToCatalogEntity(app, nil, nil)
+			Expect(entity.Metadata.Annotations).To(HaveKeyWithValue(CatalogAnnotationFieldPrefix+"regions", "cn,us"))
+
+			back, err := catalogEntityToApplication(entity, defMap, "")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(back.Name).To(Equal(app.Name))
+			Expect(back.Project).To(Equal(app.Project))
+			Expect(back.Owner).To(Equal(app.Owner))
+			Expect(back.Lifecycle).To(Equal(app.Lifecycle))
+			Expect(back.TestingServiceName).To(Equal(app.TestingServiceName))
+			Expect(back.CustomFields).To(Equal(app.CustomFields))
+		})
+	})
+})
@@ -0,0 +1,360 @@
+/*
+Copyright 2025 The KodeRover Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package service
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.uber.org/zap"
+
+	"github.com/koderover/zadig/v2/pkg/microservice/aslan/config"
+	commonmodels "github.com/koderover/zadig/v2/pkg/microservice/aslan/core/common/repository/models"
+	commonrepo "github.com/koderover/zadig/v2/pkg/microservice/aslan/core/common/repository/mongodb"
+	"github.com/koderover/zadig/v2/pkg/tool/cache"
+	e "github.com/koderover/zadig/v2/pkg/tool/errors"
+	"github.com/koderover/zadig/v2/pkg/tool/log"
+	"github.com/koderover/zadig/v2/pkg/types/step"
+)
+
+const (
+	scorecardEvaluateLockKey    = "application_scorecard_evaluate"
+	scorecardEvaluateLockExpiry = 30 * time.Minute
+)
+
+// scorecardSeverities is ordered from the most to the least severe.
+var scorecardSeverities = []string{
+	step.ImageScanSeverityCritical,
+	step.ImageScanSeverityHigh,
+	step.ImageScanSeverityMedium,
+	step.ImageScanSeverityLow,
+}
+
+func CreateScorecardRule(rule *commonmodels.ApplicationScorecardRule, logger *zap.SugaredLogger) (*commonmodels.ApplicationScorecardRule, error) {
+	if rule == nil {
+		return nil, e.ErrInvalidParam.AddDesc("empty body")
+	}
+	rule.Key = strings.TrimSpace(rule.Key)
+	if err := rule.Validate(); err != nil {
+		return nil, e.ErrInvalidParam.AddDesc(err.Error())
+	}
+	oid, err := commonrepo.NewApplicationScorecardRuleColl().Create(context.Background(), rule)
+	if err != nil {
+		return nil, err
+	}
+	rule.ID = oid
+	return rule, nil
+}
+
+func ListScorecardRules(logger *zap.SugaredLogger) ([]*commonmodels.ApplicationScorecardRule, error) {
+	return commonrepo.NewApplicationScorecardRuleColl().List(context.Background(), false)
+}
+
+func UpdateScorecardRule(id string, rule *commonmodels.ApplicationScorecardRule, logger *zap.SugaredLogger) error {
+	if rule == nil {
+		return e.ErrInvalidParam.AddDesc("empty body")
+	}
+	old, err := commonrepo.NewApplicationScorecardRuleColl().GetByID(context.Background(), id)
+	if err != nil {
+		return e.ErrInvalidParam.AddDesc(fmt.Sprintf("scorecard rule %s not found", id))
+	}
+	if rule.Key != old.Key {
+		return e.ErrInvalidParam.AddDesc("key is immutable")
+	}
+	if err := rule.Validate(); err != nil {
+		return e.ErrInvalidParam.AddDesc(err.Error())
+	}
+	rule.ID = old.ID
+	rule.CreateTime = old.CreateTime
+	return commonrepo.NewApplicationScorecardRuleColl().UpdateByID(context.Background(), id, rule)
+}
+
+func DeleteScorecardRule(id string, logger *zap.SugaredLogger) error {
+	return commonrepo.NewApplicationScorecardRuleColl().DeleteByID(context.Background(), id)
+}
+
+// EvaluateApplicationScorecards evaluates the enabled scorecard rules against every application and
+// stores the result on the application, where it can be used by search filters.
+func EvaluateApplicationScorecards() {
+	lock := cache.NewRedisLockWithExpiry(scorecardEvaluateLockKey, scorecardEvaluateLockExpiry)
+	if err := lock.TryLock(); err != nil {
+		return
+	}
+	defer lock.Unlock()
+
+	rules, err := commonrepo.NewApplicationScorecardRuleColl().List(context.Background(), true)
+	if err != nil {
+		log.Errorf("failed to list scorecard rules, error: %s", err)
+		return
+	}
+
+	now := time.Now()
+	for page := int64(1); ; page++ {
+		apps, total, err := commonrepo.NewApplicationColl().List(context.Background(), &commonrepo.ApplicationListOptions{
+			Sort:     bson.D{{Key: "_id", Value: 1}},
+			Page:     page,
+			PageSize: catalogListPageSize,
+		})
+		if err != nil {
+			log.Errorf("failed to list applications, error: %s", err)
+			return
+		}
+		for _, app := range apps {
+			scorecard := EvaluateApplicationScorecard(app, rules, now)
+			if err := commonrepo.NewApplicationColl().UpdateScorecard(context.Background(), app.ID, scorecard); err != nil {
+				log.Errorf("failed to update scorecard of application %s, error: %s", app.Key, err)
+			}
+		}
+		if page*catalogListPageSize >= total {
+			break
+		}
+	}
+}
+
+// EvaluateApplicationScorecard evaluates the rules against the application. A rule which cannot be
+// evaluated counts as failed, with the reason in the scorecard messages.
+func EvaluateApplicationScorecard(app *commonmodels.Application, rules []*commonmodels.ApplicationScorecardRule, now time.Time) *commonmodels.ApplicationScorecard {
+	scorecard := &commonmodels.ApplicationScorecard{
+		Rules:        make(map[string]bool),
+		Messages:     make(map[string]string),
+		EvaluateTime: now.Unix(),
+	}
+
+	totalWeight, passedWeight := 0, 0
+	for _, rule := range rules {
+		passed, message, err := evaluateScorecardRule(app, rule, now)
+		if err != nil {
+			passed, message = false, fmt.Sprintf("evaluation failed: %s", err)
+		}
+
+		weight := rule.Weight
+		if weight <= 0 {
+			weight = 1
+		}
+		totalWeight += weight
+		scorecard.Total++
+		if passed {
+			passedWeight += weight
+			scorecard.Passed++
+		}
+		scorecard.Rules[rule.Key] = passed
+		if message != "" {
+			scorecard.Messages[rule.Key] = message
+		}
+	}
+	if totalWeight > 0 {
+		scorecard.Score = math.Round(float64(passedWeight)*1000/float64(totalWeight)) / 10
+	}
+	return scorecard
+}
+
+func evaluateScorecardRule(app *commonmodels.Application, rule *commonmodels.ApplicationScorecardRule, now time.Time) (bool, string, error) {
+	since := now.AddDate(0, 0, -rule.Days).Unix()
+
+	switch rule.Type {
+	case config.ApplicationScorecardRuleTypeOwnerSet:
+		if strings.TrimSpace(app.Owner) == "" {
+			return false, "owner is not set", nil
+		}
+		return true, "", nil
+
+	case config.ApplicationScorecardRuleTypeCustomFieldSet:
+		if isEmptyScorecardValue(app.CustomFields[rule.FieldKey]) {
+			return false, fmt.Sprintf("custom field %s is not set", rule.FieldKey), nil
+		}
+		return true, "", nil
+
+	case config.ApplicationScorecardRuleTypeTestingPassRate:
+		if app.TestingServiceName == "" {
+			return false, "no testing service linked", nil
+		}
+		reports, err := commonrepo.NewCustomWorkflowTestReportColl().ListByService(app.Project, app.TestingServiceName, since)
+		if err != nil {
+			return false, "", err
+		}
+		caseNum, successNum := 0, 0
+		for _, report := range reports {
+			caseNum += report.TestCaseNum
+			successNum += report.SuccessCaseNum
+		}
+		if caseNum == 0 {
+			return false, fmt.Sprintf("no test results in the last %d days", rule.Days), nil
+		}
+		rate := float64(successNum) * 100 / float64(caseNum)
+		message := fmt.Sprintf("pass rate %.1f%% over %d test reports", rate, len(reports))
+		return rate >= rule.Threshold, message, nil
+
+	case config.ApplicationScorecardRuleTypeProductionDeploy:
+		if app.ProductionServiceName == "" {
+			return false, "no production service linked", nil
+		}
+		count, err := commonrepo.NewJobInfoColl().CountServiceDeployJobs(since, app.Project, app.ProductionServiceName, true)
+		if err != nil {
+			return false, "", err
+		}
+		if count == 0 {
+			return false, fmt.Sprintf("no production deploy in the last %d days", rule.Days), nil
+		}
+		return true, fmt.Sprintf("%d production deploys in the last %d days", count, rule.Days), nil
+
+	case config.ApplicationScorecardRuleTypeImageScan:
+		serviceName := app.ProductionServiceName
+		if serviceName == "" {
+			serviceName = app.TestingServiceName
+		}
+		if serviceName == "" {
+			return false, "no service linked", nil
+		}
+		result, err := commonrepo.NewImageScanResultColl().FindLatestByService(app.Project, serviceName)
+		if err != nil {
+			return false, "", err
+		}
+		if result == nil {
+			return false, "image has never been scanned", nil
+		}
+		severity := strings.ToUpper(rule.Severity)
+		if severity == "" {
+			severity = step.ImageScanSeverityCritical
+		}
+		for _, s := range scorecardSeverities {
+			if result.Summary[s] > 0 {
+				return false, fmt.Sprintf("%s has %d %s vulnerabilities", result.Image, result.Summary[s], s), nil
+			}
+			if s == severity {
+				break
+			}
+		}
+		return true, fmt.Sprintf("%s scanned at %s", result.Image, time.Unix(result.CreateTime, 0).Format(time.RFC3339)), nil
+
+	default:
+		return false, "", fmt.Errorf("unsupported rule type %s", rule.Type)
+	}
+}
+
+func isEmptyScorecardValue(v interface{}) bool {
+	switch t := v.(type) {
+	case nil:
+		return true
+	case string:
+		return strings.TrimSpace(t) == ""
+	case []interface{}:
+		return len(t) == 0
+	case primitive.A:
+		return len(t) == 0
+	case []string:
+		return len(t) == 0
+	default:
+		return false
+	}
+}
+
+type ScorecardRuleRollup struct {
+	Passed int `json:"passed"`
+	Total  int `json:"total"`
+}
+
+type ScorecardRollup struct {
+	// Group is the owner, or the value of the custom field the rollup is grouped by.
+	Group        string                          `json:"group"`
+	Applications int                             `json:"applications"`
+	AverageScore float64                         `json:"average_score"`
+	Rules        map[string]*ScorecardRuleRollup `json:"rules"`
+}
+
+// ListScorecardRollups rolls up the application scorecards per owner, or per value of the custom field
+// given as custom_fields.<key>, e.g. a team field. Applications without a scorecard are left out.
+func ListScorecardRollups(groupBy, project string, logger *zap.SugaredLogger) ([]*ScorecardRollup, error) {
+	fieldKey := ""
+	if groupBy != "" && groupBy != "owner" {
+		if !strings.HasPrefix(groupBy, "custom_fields.") {
+			return nil, e.ErrInvalidParam.AddDesc("group_by must be owner or custom_fields.<key>")
+		}
+		fieldKey = strings.TrimPrefix(groupBy, "custom_fields.")
+	}
+
+	query := bson.M{"scorecard": bson.M{"$ne": nil}}
+	if project != "" {
+		query["project"] = project
+	}
+
+	rollups := make(map[string]*ScorecardRollup)
+	scoreSum := make(map[string]float64)
+	for page := int64(1); ; page++ {
+		apps, total, err := commonrepo.NewApplicationColl().List(context.Background(), &commonrepo.ApplicationListOptions{
+			Query:    query,
+			Sort:     bson.D{{Key: "_id", Value: 1}},
+			Page:     page,
+			PageSize: catalogListPageSize,
+		})
+		if err != nil {
+			return nil, err
+		}
+		for _, app := range apps {
+			groups := []string{app.Owner}
+			if fieldKey != "" {
+				switch v := app.CustomFields[fieldKey].(type) {
+				case string:
+					groups = []string{v}
+				case primitive.A:
+					groups = toStringArray([]interface{}(v))
+				default:
+					groups = toStringArray(v)
+				}
+			}
+			if len(groups) == 0 {
+				groups = []string{""}
+			}
+			for _, group := range groups {
+				rollup, ok := rollups[group]
+				if !ok {
+					rollup = &ScorecardRollup{Group: group, Rules: make(map[string]*ScorecardRuleRollup)}
+					rollups[group] = rollup
+				}
+				rollup.Applications++
+				scoreSum[group] += app.Scorecard.Score
+				for key, passed := range app.Scorecard.Rules {
+					rule, ok := rollup.Rules[key]
+					if !ok {
+						rule = new(ScorecardRuleRollup)
+						rollup.Rules[key] = rule
+					}
+					rule.Total++
+					if passed {
+						rule.Passed++
+					}
+				}
+			}
+		}
+		if page*catalogListPageSize >= total {
+			break
+		}
+	}
+
+	resp := make([]*ScorecardRollup, 0, len(rollups))
+	for group, rollup := range rollups {
+		rollup.AverageScore = math.Round(scoreSum[group]*10/float64(rollup.Applications)) / 10
+		resp = append(resp, rollup)
+	}
+	sort.Slice(resp, func(i, j int) bool { return resp[i].Group < resp[j].Group })
+	return resp, nil
+}
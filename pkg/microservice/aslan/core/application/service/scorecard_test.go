@@ -0,0 +1,72 @@
+/*
+Copyright 2025 The KodeRover Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package service
+
+import (
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/koderover/zadig/v2/pkg/microservice/aslan/config"
+	commonmodels "github.com/koderover/zadig/v2/pkg/microservice/aslan/core/common/repository/models"
+)
+
+var _ = Describe("Testing application scorecards", func() {
+
+	rules := []*commonmodels.ApplicationScorecardRule{
+		{Key: "owner", Type: config.ApplicationScorecardRuleTypeOwnerSet, Weight: 3},
+		{Key: "runbook", Type: config.ApplicationScorecardRuleTypeCustomFieldSet, FieldKey: "runbook"},
+	}
+
+	Context("EvaluateApplicationScorecard", func() {
+		It("should weight the passed rules", func() {
+			now := time.Unix(1700000000, 0)
+			scorecard := EvaluateApplicationScorecard(&commonmodels.Application{Owner: "alice"}, rules, now)
+			Expect(scorecard.Rules).To(Equal(map[string]bool{"owner": true, "runbook": false}))
+			Expect(scorecard.Passed).To(Equal(1))
+			Expect(scorecard.Total).To(Equal(2))
+			Expect(scorecard.Score).To(Equal(75.0))
+			Expect(scorecard.Messages).To(HaveKey("runbook"))
+			Expect(scorecard.EvaluateTime).To(Equal(now.Unix()))
+		})
+
+		It("should pass every rule of a complete application", func() {
+			app := &commonmodels.Application{Owner: "alice", CustomFields: map[string]interface{}{"runbook": "https://wiki/runbook"}}
+			scorecard := EvaluateApplicationScorecard(app, rules, time.Now())
+			Expect(scorecard.Score).To(Equal(100.0))
+		})
+
+		It("should fail rules of unknown types", func() {
+			scorecard := EvaluateApplicationScorecard(&commonmodels.Application{}, []*commonmodels.ApplicationScorecardRule{{Key: "bad", Type: "unknown"}}, time.Now())
+			Expect(scorecard.Rules["bad"]).To(BeFalse())
+			Expect(scorecard.Messages["bad"]).To(ContainSubstring("evaluation failed"))
+		})
+	})
+
+	Context("resolveField", func() {
+		It("should resolve scorecard fields for search filters", func() {
+			_, fType, err := resolveField("scorecard.score", nil)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(fType).To(Equal(string(config.ApplicationFilterFieldTypeNumber)))
+
+			_, fType, err = resolveField("scorecard.rules.owner", nil)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(fType).To(Equal(string(config.ApplicationFilterFieldTypeBool)))
+		})
+	})
+})
@@ -29,6 +29,7 @@ import (
 	commonmodels "github.com/koderover/zadig/v2/pkg/microservice/aslan/core/common/repository/models"
 	commonrepo "github.com/koderover/zadig/v2/pkg/microservice/aslan/core/common/repository/mongodb"
 	commonservice "github.com/koderover/zadig/v2/pkg/microservice/aslan/core/common/service"
+	"github.com/koderover/zadig/v2/pkg/microservice/aslan/core/common/service/resourceprofile"
 	commonutil "github.com/koderover/zadig/v2/pkg/microservice/aslan/core/common/util"
 	"github.com/koderover/zadig/v2/pkg/setting"
 	"github.com/koderover/zadig/v2/pkg/shared/client/systemconfig"
@@ -88,6 +89,14 @@ func FindBuild(name, productName string, log *zap.SugaredLogger) (*commonmodels.
 
 	commonservice.EnsureBuildResp(resp)
 
+	if resp.PreBuild != nil {
+		recommendation, err := resourceprofile.Recommend(resp.ProductName, resourceprofile.ConfigTypeBuild, resp.Name)
+		if err != nil {
+			log.Warnf("failed to recommend resource for build %s: %s", name, err)
+		}
+		resp.PreBuild.ResourceRecommendation = recommendation
+	}
+
 	return resp, nil
 }
 
@@ -487,6 +496,9 @@ func correctFields(build *commonmodels.Build) error {
 	if build.PostBuild != nil && build.PostBuild.DockerBuild != nil {
 		build.PostBuild.DockerBuild.DockerFile = strings.Trim(build.PostBuild.DockerBuild.DockerFile, " ")
 		build.PostBuild.DockerBuild.WorkDir = strings.Trim(build.PostBuild.DockerBuild.WorkDir, " ")
+		if err := commonutil.CheckImageBuildBackend(build.PostBuild.DockerBuild.Backend); err != nil {
+			return err
+		}
 	}
 	if build.TemplateID == "" {
 		for _, repo := range build.Repos {
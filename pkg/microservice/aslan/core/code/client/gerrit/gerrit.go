@@ -105,3 +105,16 @@ func (c *Client) ListProjects(opt client.ListOpt) ([]*client.Project, error) {
 func (c *Client) ListCommits(opt client.ListOpt) ([]*client.Commit, error) {
 	return make([]*client.Commit, 0), nil
 }
+
+func (c *Client) GetCommit(namespace, projectName, commitID string) (*client.Commit, error) {
+	commit, err := c.Client.GetCommitByID(projectName, commitID)
+	if err != nil {
+		return nil, e.ErrCodehostGetCommit.AddDesc(err.Error())
+	}
+	return &client.Commit{
+		ID:        commit.Commit,
+		Message:   commit.Message,
+		Author:    commit.Author.Name,
+		CreatedAt: commit.Committer.Date.Unix(),
+	}, nil
+}
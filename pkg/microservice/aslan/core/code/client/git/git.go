@@ -111,6 +111,10 @@ func (c *Client) ListCommits(opt client.ListOpt) ([]*client.Commit, error) {
 	return nil, fmt.Errorf("not support list commits")
 }
 
+func (c *Client) GetCommit(namespace, projectName, commitID string) (*client.Commit, error) {
+	return nil, fmt.Errorf("not support get commit")
+}
+
 func (c *Client) initRepo(repoDir, namespace, projectName string) error {
 	// lock := cache.NewRedisLock(fmt.Sprintf("init_repo:%d:%s:%s:%s", c.Config.ID, c.Config.Address, c.Config.Namespace, c.Config.RepoName))
 	// if err := lock.Lock(); err != nil {
@@ -25,6 +25,7 @@ import (
 
 	"github.com/koderover/zadig/v2/pkg/microservice/aslan/config"
 	"github.com/koderover/zadig/v2/pkg/microservice/aslan/core/code/client"
+	e "github.com/koderover/zadig/v2/pkg/tool/errors"
 	"github.com/koderover/zadig/v2/pkg/tool/gitee"
 )
 
@@ -165,3 +166,20 @@ func (c *Client) ListProjects(opt client.ListOpt) ([]*client.Project, error) {
 func (c *Client) ListCommits(opt client.ListOpt) ([]*client.Commit, error) {
 	return make([]*client.Commit, 0), nil
 }
+
+func (c *Client) GetCommit(namespace, projectName, commitID string) (*client.Commit, error) {
+	return getGiteeCommit(c.Client, c.Address, c.AccessToken, namespace, projectName, commitID)
+}
+
+func getGiteeCommit(cli *gitee.Client, address, accessToken, namespace, projectName, commitID string) (*client.Commit, error) {
+	commit, err := cli.GetSingleCommitOfProject(context.TODO(), address, accessToken, namespace, projectName, commitID)
+	if err != nil {
+		return nil, e.ErrCodehostGetCommit.AddDesc(err.Error())
+	}
+	return &client.Commit{
+		ID:        commit.Sha,
+		Message:   commit.Commit.Message,
+		Author:    commit.Commit.Author.Name,
+		CreatedAt: commit.Commit.Committer.Date.Unix(),
+	}, nil
+}
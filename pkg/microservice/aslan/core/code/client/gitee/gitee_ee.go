@@ -170,3 +170,7 @@ func (c *EEClient) ListProjects(opt client.ListOpt) ([]*client.Project, error) {
 func (c *EEClient) ListCommits(opt client.ListOpt) ([]*client.Commit, error) {
 	return make([]*client.Commit, 0), nil
 }
+
+func (c *EEClient) GetCommit(namespace, projectName, commitID string) (*client.Commit, error) {
+	return getGiteeCommit(c.Client, c.Address, c.AccessToken, namespace, projectName, commitID)
+}
@@ -185,3 +185,16 @@ func (c *Client) ListCommits(opt client.ListOpt) ([]*client.Commit, error) {
 	}
 	return res, nil
 }
+
+func (c *Client) GetCommit(namespace, projectName, commitID string) (*client.Commit, error) {
+	commit, _, err := c.Client.Repositories.GetCommit(context.TODO(), namespace, projectName, commitID)
+	if err != nil {
+		return nil, e.ErrCodehostGetCommit.AddDesc(err.Error())
+	}
+	return &client.Commit{
+		ID:        commit.GetSHA(),
+		Message:   commit.GetCommit().GetMessage(),
+		Author:    commit.GetCommit().GetAuthor().GetName(),
+		CreatedAt: commit.GetCommit().GetCommitter().GetDate().Unix(),
+	}, nil
+}
@@ -188,3 +188,19 @@ func (c *Client) ListCommits(opt client.ListOpt) ([]*client.Commit, error) {
 	}
 	return res, nil
 }
+
+func (c *Client) GetCommit(namespace, projectName, commitID string) (*client.Commit, error) {
+	commit, err := c.Client.GetSingleCommitOfProject(namespace, projectName, commitID)
+	if err != nil {
+		return nil, e.ErrCodehostGetCommit.AddDesc(err.Error())
+	}
+	res := &client.Commit{
+		ID:      commit.ID,
+		Message: commit.Message,
+		Author:  commit.AuthorName,
+	}
+	if commit.CreatedAt != nil {
+		res.CreatedAt = commit.CreatedAt.Unix()
+	}
+	return res, nil
+}
@@ -30,6 +30,7 @@ type CodeHostClient interface {
 	ListNamespaces(keyword string) ([]*Namespace, error)
 	ListProjects(opt ListOpt) ([]*Project, error)
 	ListCommits(opt ListOpt) ([]*Commit, error)
+	GetCommit(namespace, projectName, commitID string) (*Commit, error)
 }
 
 type ListOpt struct {
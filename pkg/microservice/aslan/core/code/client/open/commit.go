@@ -0,0 +1,72 @@
+/*
+Copyright 2025 The KodeRover Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package open
+
+import (
+	"fmt"
+
+	"go.uber.org/zap"
+	"k8s.io/apimachinery/pkg/util/sets"
+
+	"github.com/koderover/zadig/v2/pkg/microservice/aslan/core/code/client"
+	commonmodels "github.com/koderover/zadig/v2/pkg/microservice/aslan/core/common/repository/models"
+	"github.com/koderover/zadig/v2/pkg/shared/client/systemconfig"
+	"github.com/koderover/zadig/v2/pkg/types"
+)
+
+// OpenCommitClient returns the client of the codehost holding the repo of the commit. Opened clients are cached in
+// clients by codehost id. Other and perforce codehosts are refused since they have no commit api, callers can refuse
+// more types whose clients don't implement what they need.
+func OpenCommitClient(commit *commonmodels.ActivityCommit, clients map[int]client.CodeHostClient, unsupportedTypes []string, log *zap.SugaredLogger) (client.CodeHostClient, error) {
+	var (
+		ch  *systemconfig.CodeHost
+		err error
+	)
+	if commit.CodehostID > 0 {
+		if cli, ok := clients[commit.CodehostID]; ok {
+			return cli, nil
+		}
+		ch, err = systemconfig.New().GetCodeHost(commit.CodehostID)
+	} else {
+		// activities generated by older versions don't record the codehost id
+		ch, err = systemconfig.New().GetCodeHostByAddressAndOwner(commit.Address, commit.RepoOwner, commit.Source)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to find codehost, err: %v", err)
+	}
+	if cli, ok := clients[ch.ID]; ok {
+		return cli, nil
+	}
+	if sets.NewString(unsupportedTypes...).Insert(types.ProviderOther, types.ProviderPerforce).Has(ch.Type) {
+		return nil, fmt.Errorf("commits are not supported for codehost type: %s", ch.Type)
+	}
+
+	cli, err := OpenClient(ch, log)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open codehost client, err: %v", err)
+	}
+	clients[ch.ID] = cli
+	return cli, nil
+}
+
+// CommitNamespace returns the namespace of the repo of the commit, which is the owner unless a namespace is recorded.
+func CommitNamespace(commit *commonmodels.ActivityCommit) string {
+	if commit.RepoNamespace != "" {
+		return commit.RepoNamespace
+	}
+	return commit.RepoOwner
+}
@@ -0,0 +1,48 @@
+/*
+Copyright 2025 The KodeRover Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package models
+
+import (
+	"go.mongodb.org/mongo-driver/bson/primitive"
+
+	"github.com/koderover/zadig/v2/pkg/microservice/aslan/config"
+)
+
+// AdmissionPolicy is a rego policy uploaded by admins, its deny rule is evaluated when a workflow task is created
+// or a deploy job starts.
+type AdmissionPolicy struct {
+	ID          primitive.ObjectID `bson:"_id,omitempty" json:"id"`
+	Name        string             `bson:"name"          json:"name"`
+	Description string             `bson:"description"   json:"description"`
+	Enabled     bool               `bson:"enabled"       json:"enabled"`
+	// Package is parsed from the rego module, it must be under zadig.admission
+	Package     string                            `bson:"package"     json:"package"`
+	Rego        string                            `bson:"rego"        json:"rego"`
+	Scopes      []config.AdmissionPolicyScope     `bson:"scopes"      json:"scopes"`
+	Enforcement config.AdmissionPolicyEnforcement `bson:"enforcement" json:"enforcement"`
+	// Projects limits the policy to the projects, empty means all projects
+	Projects []string `bson:"projects" json:"projects"`
+
+	CreatedBy string `bson:"created_by" json:"created_by"`
+	CreatedAt int64  `bson:"created_at" json:"created_at"`
+	UpdatedBy string `bson:"updated_by" json:"updated_by"`
+	UpdatedAt int64  `bson:"updated_at" json:"updated_at"`
+}
+
+func (AdmissionPolicy) TableName() string {
+	return "admission_policy"
+}
@@ -32,12 +32,15 @@ type Application struct {
 	Repository            *ApplicationRepositoryRef `bson:"repository,omitempty"              json:"repository,omitempty"`
 	Type                  string                    `bson:"type"                              json:"type"`
 	Owner                 string                    `bson:"owner"                             json:"owner"`
+	Lifecycle             string                    `bson:"lifecycle,omitempty"               json:"lifecycle,omitempty"`
 	CreateTime            int64                     `bson:"create_time,omitempty"             json:"create_time"`
 	UpdateTime            int64                     `bson:"update_time"                       json:"update_time"`
 	Description           string                    `bson:"description,omitempty"             json:"description,omitempty"`
 	TestingServiceName    string                    `bson:"testing_service_name,omitempty"    json:"testing_service_name,omitempty"`
 	ProductionServiceName string                    `bson:"production_service_name,omitempty" json:"production_service_name,omitempty"`
 	CustomFields          map[string]interface{}    `bson:"custom_fields,omitempty"           json:"custom_fields"`
+	// Scorecard is maintained by the periodic scorecard evaluation and ignored on create and update.
+	Scorecard *ApplicationScorecard `bson:"scorecard,omitempty"              json:"scorecard,omitempty"`
 	// field used only for frontend, showing which plugin is activated on a specific application.
 	Plugins []string `bson:"-"                                json:"plugins,omitempty"`
 }
@@ -0,0 +1,109 @@
+/*
+Copyright 2025 The KodeRover Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package models
+
+import (
+	"fmt"
+	"strings"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+
+	"github.com/koderover/zadig/v2/pkg/microservice/aslan/config"
+	"github.com/koderover/zadig/v2/pkg/types/step"
+)
+
+// ApplicationScorecardRule is an admin defined check of the operational maturity of an application.
+type ApplicationScorecardRule struct {
+	ID          primitive.ObjectID                  `bson:"_id,omitempty"         json:"id"`
+	Key         string                              `bson:"key"                   json:"key"`
+	Name        string                              `bson:"name"                  json:"name"`
+	Description string                              `bson:"description,omitempty" json:"description,omitempty"`
+	Type        config.ApplicationScorecardRuleType `bson:"type"                  json:"type"`
+	// Days is the look-back window of testing_pass_rate and production_deploy rules.
+	Days int `bson:"days,omitempty"        json:"days,omitempty"`
+	// Threshold is the minimal test case pass rate in percent of testing_pass_rate rules.
+	Threshold float64 `bson:"threshold,omitempty"   json:"threshold,omitempty"`
+	// Severity is the lowest severity an image_scan rule does not tolerate, CRITICAL by default.
+	Severity string `bson:"severity,omitempty"    json:"severity,omitempty"`
+	// FieldKey is the custom field checked by custom_field_set rules.
+	FieldKey string `bson:"field_key,omitempty"   json:"field_key,omitempty"`
+	// Weight of the rule in the application score, 1 if not set.
+	Weight  int  `bson:"weight"                json:"weight"`
+	Enabled bool `bson:"enabled"               json:"enabled"`
+
+	CreateTime int64 `bson:"create_time"           json:"create_time"`
+	UpdateTime int64 `bson:"update_time"           json:"update_time"`
+}
+
+func (ApplicationScorecardRule) TableName() string { return "application_scorecard_rule" }
+
+// Validate checks business rules for ApplicationScorecardRule.
+func (r *ApplicationScorecardRule) Validate() error {
+	if r == nil {
+		return fmt.Errorf("empty body")
+	}
+	if strings.TrimSpace(r.Key) == "" || strings.TrimSpace(r.Name) == "" {
+		return fmt.Errorf("key, name are required")
+	}
+	if strings.ContainsAny(r.Key, ".$") {
+		return fmt.Errorf("key must not contain '.' or '$'")
+	}
+	if r.Weight < 0 {
+		return fmt.Errorf("weight must not be negative")
+	}
+
+	switch r.Type {
+	case config.ApplicationScorecardRuleTypeTestingPassRate:
+		if r.Days <= 0 {
+			return fmt.Errorf("days must be positive")
+		}
+		if r.Threshold < 0 || r.Threshold > 100 {
+			return fmt.Errorf("threshold must be between 0 and 100")
+		}
+	case config.ApplicationScorecardRuleTypeProductionDeploy:
+		if r.Days <= 0 {
+			return fmt.Errorf("days must be positive")
+		}
+	case config.ApplicationScorecardRuleTypeImageScan:
+		switch strings.ToUpper(r.Severity) {
+		case "", step.ImageScanSeverityCritical, step.ImageScanSeverityHigh, step.ImageScanSeverityMedium, step.ImageScanSeverityLow:
+		default:
+			return fmt.Errorf("invalid severity: %s", r.Severity)
+		}
+	case config.ApplicationScorecardRuleTypeOwnerSet:
+	case config.ApplicationScorecardRuleTypeCustomFieldSet:
+		if strings.TrimSpace(r.FieldKey) == "" {
+			return fmt.Errorf("field_key is required")
+		}
+	default:
+		return fmt.Errorf("invalid type")
+	}
+	return nil
+}
+
+// ApplicationScorecard is the latest evaluation of the enabled scorecard rules against an application.
+type ApplicationScorecard struct {
+	// Score is the weighted percentage of passed rules.
+	Score  float64 `bson:"score"  json:"score"`
+	Passed int     `bson:"passed" json:"passed"`
+	Total  int     `bson:"total"  json:"total"`
+	// Rules maps the rule key to whether the application passed it.
+	Rules map[string]bool `bson:"rules"  json:"rules"`
+	// Messages explains the outcome of the rules, keyed by rule key.
+	Messages     map[string]string `bson:"messages,omitempty" json:"messages,omitempty"`
+	EvaluateTime int64             `bson:"evaluate_time"      json:"evaluate_time"`
+}
@@ -52,10 +52,12 @@ type Build struct {
 	PreBuild       *PreBuild              `bson:"pre_build"                     json:"pre_build"`
 	Infrastructure string                 `bson:"infrastructure"                json:"infrastructure"`
 	VMLabels       []string               `bson:"vm_labels"                     json:"vm_labels"`
-	JenkinsBuild   *JenkinsBuild          `bson:"jenkins_build,omitempty"       json:"jenkins_build,omitempty"`
-	ScriptType     types.ScriptType       `bson:"script_type"                   json:"script_type"`
-	Scripts        string                 `bson:"scripts"                       json:"scripts"`
-	PostBuild      *PostBuild             `bson:"post_build,omitempty"          json:"post_build"`
+	// VMExecutionMode is host or container, it only works for the vm infrastructure
+	VMExecutionMode string           `bson:"vm_execution_mode"             json:"vm_execution_mode"`
+	JenkinsBuild    *JenkinsBuild    `bson:"jenkins_build,omitempty"       json:"jenkins_build,omitempty"`
+	ScriptType      types.ScriptType `bson:"script_type"                   json:"script_type"`
+	Scripts         string           `bson:"scripts"                       json:"scripts"`
+	PostBuild       *PostBuild       `bson:"post_build,omitempty"          json:"post_build"`
 
 	// TODO: Deprecated.
 	Caches               []string                   `bson:"caches"                        json:"caches"`
@@ -87,6 +89,8 @@ type PreBuild struct {
 	// ResReq defines job requested resources
 	ResReq     setting.Request     `bson:"res_req"                json:"res_req"`
 	ResReqSpec setting.RequestSpec `bson:"res_req_spec"           json:"res_req_spec"`
+	// ResourceRecommendation is calculated from the resource usage of recent runs, it is not saved
+	ResourceRecommendation *ResourceRecommendation `bson:"-" json:"resource_recommendation,omitempty"`
 	// BuildOS defines job image OS, it supports 18.04 and 20.04
 	BuildOS   string `bson:"build_os"                      json:"build_os"`
 	ImageFrom string `bson:"image_from"                    json:"image_from"`
@@ -165,6 +169,10 @@ type DockerBuild struct {
 	EnableBuildkit bool `bson:"enable_buildkit" json:"enable_buildkit"`
 	// Platform is the platform of the docker build
 	Platform string `bson:"platform" json:"platform"`
+	// Platforms builds a multi-arch image pushed as a manifest list with buildx, e.g. [linux/amd64, linux/arm64]
+	Platforms []string `bson:"platforms,omitempty" json:"platforms,omitempty"`
+	// Backend is the image build backend, docker or buildkit, the backend of the cluster is used if it's empty
+	Backend string `bson:"backend,omitempty" json:"backend,omitempty"`
 }
 
 type JenkinsBuild struct {
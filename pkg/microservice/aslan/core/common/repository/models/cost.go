@@ -0,0 +1,103 @@
+/*
+Copyright 2025 The KodeRover Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package models
+
+import (
+	"go.mongodb.org/mongo-driver/bson/primitive"
+
+	"github.com/koderover/zadig/v2/pkg/microservice/aslan/config"
+)
+
+// CostPricing is the system wide unit prices used to calculate the resource cost
+type CostPricing struct {
+	Enabled   bool                 `bson:"enabled"            json:"enabled"`
+	Currency  string               `bson:"currency"           json:"currency"`
+	UsageMode config.CostUsageMode `bson:"usage_mode"         json:"usage_mode"`
+	// DefaultPrice is used for the nodes matching no node class and the build/testing job pods
+	DefaultPrice *NodeClassPrice   `bson:"default_price"      json:"default_price"`
+	NodeClasses  []*NodeClassPrice `bson:"node_classes"       json:"node_classes"`
+	// StorageGBHour is the price of 1GiB PVC capacity for an hour
+	StorageGBHour float64 `bson:"storage_gb_hour"    json:"storage_gb_hour"`
+	// LoadBalancerHour is the price of a LoadBalancer service for an hour
+	LoadBalancerHour float64 `bson:"load_balancer_hour" json:"load_balancer_hour"`
+	UpdateBy         string  `bson:"update_by"          json:"update_by"`
+	UpdateTime       int64   `bson:"update_time"        json:"update_time"`
+}
+
+// NodeClassPrice is the unit prices of the nodes whose labels match the node selector
+type NodeClassPrice struct {
+	Name         string            `bson:"name"            json:"name"`
+	NodeSelector map[string]string `bson:"node_selector"   json:"node_selector"`
+	// CPUCoreHour is the price of 1 cpu core for an hour
+	CPUCoreHour float64 `bson:"cpu_core_hour"   json:"cpu_core_hour"`
+	// MemoryGBHour is the price of 1GiB memory for an hour
+	MemoryGBHour float64 `bson:"memory_gb_hour"  json:"memory_gb_hour"`
+}
+
+func (CostPricing) TableName() string {
+	return "cost_pricing"
+}
+
+// DailyCost is the resource cost of a service in an environment, or of a build/testing, in a day
+type DailyCost struct {
+	Date        string              `bson:"date"                json:"date"`
+	Category    config.CostCategory `bson:"category"            json:"category"`
+	ProjectKey  string              `bson:"project_key"         json:"project_key"`
+	EnvName     string              `bson:"env_name"            json:"env_name"`
+	Production  bool                `bson:"production"          json:"production"`
+	ServiceName string              `bson:"service_name"        json:"service_name"`
+	// ConfigName is the name of the build or testing for the job costs
+	ConfigName        string  `bson:"config_name"         json:"config_name"`
+	ClusterID         string  `bson:"cluster_id"          json:"cluster_id"`
+	CPUCoreHours      float64 `bson:"cpu_core_hours"      json:"cpu_core_hours"`
+	MemoryGBHours     float64 `bson:"memory_gb_hours"     json:"memory_gb_hours"`
+	StorageGBHours    float64 `bson:"storage_gb_hours"    json:"storage_gb_hours"`
+	LoadBalancerHours float64 `bson:"load_balancer_hours" json:"load_balancer_hours"`
+	CPUCost           float64 `bson:"cpu_cost"            json:"cpu_cost"`
+	MemoryCost        float64 `bson:"memory_cost"         json:"memory_cost"`
+	StorageCost       float64 `bson:"storage_cost"        json:"storage_cost"`
+	LoadBalancerCost  float64 `bson:"load_balancer_cost"  json:"load_balancer_cost"`
+	TotalCost         float64 `bson:"total_cost"          json:"total_cost"`
+	UpdateTime        int64   `bson:"update_time"         json:"update_time"`
+}
+
+func (DailyCost) TableName() string {
+	return "cost_stat_daily"
+}
+
+// CostBudget is the monthly budget of a project or an environment of it, notifications are sent when the cost of the
+// month reaches the thresholds
+type CostBudget struct {
+	ID         primitive.ObjectID `bson:"_id,omitempty"      json:"id"`
+	Name       string             `bson:"name"               json:"name"`
+	ProjectKey string             `bson:"project_key"        json:"project_key"`
+	// EnvName is empty for the budget of the whole project
+	EnvName    string       `bson:"env_name"           json:"env_name"`
+	Amount     float64      `bson:"amount"             json:"amount"`
+	Thresholds []int        `bson:"thresholds"         json:"thresholds"`
+	NotifyCtls []*NotifyCtl `bson:"notify_ctls"        json:"notify_ctls"`
+	// AlertMonth and AlertThreshold are the month and the highest threshold alerted, a threshold is alerted once a month
+	AlertMonth     string `bson:"alert_month"        json:"alert_month"`
+	AlertThreshold int    `bson:"alert_threshold"    json:"alert_threshold"`
+	CreatedBy      string `bson:"created_by"         json:"created_by"`
+	CreateTime     int64  `bson:"create_time"        json:"create_time"`
+	UpdateTime     int64  `bson:"update_time"        json:"update_time"`
+}
+
+func (CostBudget) TableName() string {
+	return "cost_budget"
+}
@@ -44,7 +44,9 @@ type DeliveryActivity struct {
 type ActivityCommit struct {
 	Address       string `bson:"address"                   json:"address"`
 	Source        string `bson:"source,omitempty"          json:"source,omitempty"`
+	CodehostID    int    `bson:"codehost_id,omitempty"     json:"codehost_id,omitempty"`
 	RepoOwner     string `bson:"repo_owner"                json:"repo_owner"`
+	RepoNamespace string `bson:"repo_namespace,omitempty"  json:"repo_namespace,omitempty"`
 	RepoName      string `bson:"repo_name"                 json:"repo_name"`
 	Branch        string `bson:"branch"                    json:"branch"`
 	PR            int    `bson:"pr,omitempty"              json:"pr,omitempty"`
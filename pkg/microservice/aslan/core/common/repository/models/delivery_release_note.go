@@ -0,0 +1,84 @@
+/*
+Copyright 2025 The KodeRover Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package models
+
+import (
+	"go.mongodb.org/mongo-driver/bson/primitive"
+
+	"github.com/koderover/zadig/v2/pkg/setting"
+)
+
+// DeliveryReleaseNote is the generated change log of a delivery version, it records the commits
+// of each service between the previously released version and this one.
+type DeliveryReleaseNote struct {
+	ID                primitive.ObjectID                `bson:"_id,omitempty"        json:"id,omitempty"`
+	DeliveryVersionID string                            `bson:"delivery_version_id"  json:"delivery_version_id"`
+	ProjectName       string                            `bson:"project_name"         json:"project_name"`
+	Version           string                            `bson:"version"              json:"version"`
+	PreviousVersion   string                            `bson:"previous_version"     json:"previous_version"`
+	Services          []*ReleaseNoteService             `bson:"services"             json:"services"`
+	Summary           string                            `bson:"summary"              json:"summary"`
+	Status            setting.DeliveryReleaseNoteStatus `bson:"status"               json:"status"`
+	Error             string                            `bson:"error"                json:"error"`
+	CreatedBy         string                            `bson:"created_by"           json:"created_by"`
+	CreatedAt         int64                             `bson:"created_at"           json:"created_at"`
+	UpdatedAt         int64                             `bson:"updated_at"           json:"updated_at"`
+}
+
+type ReleaseNoteService struct {
+	ServiceName string             `bson:"service_name" json:"service_name"`
+	Repos       []*ReleaseNoteRepo `bson:"repos"        json:"repos"`
+	Authors     []string           `bson:"authors"      json:"authors"`
+}
+
+type ReleaseNoteRepo struct {
+	CodehostID    int                  `bson:"codehost_id"    json:"codehost_id"`
+	Source        string               `bson:"source"         json:"source"`
+	RepoOwner     string               `bson:"repo_owner"     json:"repo_owner"`
+	RepoNamespace string               `bson:"repo_namespace" json:"repo_namespace"`
+	RepoName      string               `bson:"repo_name"      json:"repo_name"`
+	Branch        string               `bson:"branch"         json:"branch"`
+	FromCommit    string               `bson:"from_commit"    json:"from_commit"`
+	ToCommit      string               `bson:"to_commit"      json:"to_commit"`
+	Commits       []*ReleaseNoteCommit `bson:"commits"        json:"commits"`
+	// Truncated marks that the previous commit was not found within the scan limit
+	Truncated bool   `bson:"truncated" json:"truncated"`
+	Error     string `bson:"error"     json:"error"`
+}
+
+type ReleaseNoteCommit struct {
+	CommitID  string              `bson:"commit_id"  json:"commit_id"`
+	Message   string              `bson:"message"    json:"message"`
+	Type      string              `bson:"type"       json:"type"`
+	Scope     string              `bson:"scope"      json:"scope"`
+	Subject   string              `bson:"subject"    json:"subject"`
+	Breaking  bool                `bson:"breaking"   json:"breaking"`
+	Author    string              `bson:"author"     json:"author"`
+	CreatedAt int64               `bson:"created_at" json:"created_at"`
+	PRs       []int               `bson:"prs"        json:"prs"`
+	Issues    []*ReleaseNoteIssue `bson:"issues"     json:"issues"`
+}
+
+type ReleaseNoteIssue struct {
+	System setting.ProjectManagementType `bson:"system" json:"system"`
+	Key    string                        `bson:"key"    json:"key"`
+	URL    string                        `bson:"url"    json:"url"`
+}
+
+func (DeliveryReleaseNote) TableName() string {
+	return "delivery_release_note"
+}
@@ -67,6 +67,9 @@ type DeliveryVersionImage struct {
 	PushImage      bool           `bson:"push_image"            json:"push_image"`
 	Status         config.Status  `bson:"status"                json:"status"`
 	Error          string         `bson:"error"                 json:"error"`
+	// SBOMID and Digest refer to the sbom of the source image when the version is created
+	SBOMID string `bson:"sbom_id,omitempty"     json:"sbom_id,omitempty"`
+	Digest string `bson:"digest,omitempty"      json:"digest,omitempty"`
 }
 
 func (DeliveryVersionV2) TableName() string {
@@ -31,6 +31,11 @@ type HelmRepo struct {
 	UpdateBy    string             `bson:"update_by"             json:"update_by"`
 	CreatedAt   int64              `bson:"created_at"            json:"created_at"`
 	UpdatedAt   int64              `bson:"updated_at"            json:"updated_at"`
+
+	// RegistryID reuses the credentials of the image registry for oci:// chart repos served by the same registry
+	RegistryID string `bson:"registry_id,omitempty" json:"registry_id,omitempty"`
+	// PlainHTTP talks to the oci:// chart repo over http, e.g. a local registry:2 without tls
+	PlainHTTP bool `bson:"plain_http" json:"plain_http"`
 }
 
 func (h HelmRepo) TableName() string {
@@ -0,0 +1,44 @@
+/*
+Copyright 2025 The KodeRover Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package models
+
+import (
+	"go.mongodb.org/mongo-driver/bson/primitive"
+
+	"github.com/koderover/zadig/v2/pkg/types/step"
+)
+
+type ImageScanResult struct {
+	ID              primitive.ObjectID         `bson:"_id,omitempty"     json:"id"`
+	ProjectName     string                     `bson:"project_name"      json:"project_name"`
+	WorkflowName    string                     `bson:"workflow_name"     json:"workflow_name"`
+	JobName         string                     `bson:"job_name"          json:"job_name"`
+	TaskID          int64                      `bson:"task_id"           json:"task_id"`
+	ServiceName     string                     `bson:"service_name"      json:"service_name"`
+	ServiceModule   string                     `bson:"service_module"    json:"service_module"`
+	Image           string                     `bson:"image"             json:"image"`
+	Digest          string                     `bson:"digest"            json:"digest"`
+	Passed          bool                       `bson:"passed"            json:"passed"`
+	Summary         map[string]int             `bson:"summary"           json:"summary"`
+	Violations      []string                   `bson:"violations"        json:"violations"`
+	Vulnerabilities []*step.ImageVulnerability `bson:"vulnerabilities"   json:"vulnerabilities"`
+	CreateTime      int64                      `bson:"create_time"       json:"create_time"`
+}
+
+func (ImageScanResult) TableName() string {
+	return "image_scan_result"
+}
@@ -0,0 +1,66 @@
+/*
+Copyright 2025 The KodeRover Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package models
+
+import (
+	"go.mongodb.org/mongo-driver/bson/primitive"
+
+	"github.com/koderover/zadig/v2/pkg/setting"
+)
+
+// JobResourceUsage is the resource usage of one run of a build or testing job,
+// cpu is in millicores and memory is in MiB
+type JobResourceUsage struct {
+	ID           primitive.ObjectID `bson:"_id,omitempty"  json:"id"`
+	ProjectName  string             `bson:"project_name"   json:"project_name"`
+	WorkflowName string             `bson:"workflow_name"  json:"workflow_name"`
+	TaskID       int64              `bson:"task_id"        json:"task_id"`
+	JobName      string             `bson:"job_name"       json:"job_name"`
+	// ConfigType and ConfigName identify the build or testing the job runs
+	ConfigType string              `bson:"config_type"    json:"config_type"`
+	ConfigName string              `bson:"config_name"    json:"config_name"`
+	ClusterID  string              `bson:"cluster_id"     json:"cluster_id"`
+	ResReq     setting.Request     `bson:"res_req"        json:"res_req"`
+	ResReqSpec setting.RequestSpec `bson:"res_req_spec"   json:"res_req_spec"`
+	Status     string              `bson:"status"         json:"status"`
+	CpuPeak    int                 `bson:"cpu_peak"       json:"cpu_peak"`
+	CpuAvg     int                 `bson:"cpu_avg"        json:"cpu_avg"`
+	MemoryPeak int                 `bson:"memory_peak"    json:"memory_peak"`
+	MemoryAvg  int                 `bson:"memory_avg"     json:"memory_avg"`
+	OOMKilled  bool                `bson:"oom_killed"     json:"oom_killed"`
+	CreateTime int64               `bson:"create_time"    json:"create_time"`
+}
+
+func (JobResourceUsage) TableName() string {
+	return "job_resource_usage"
+}
+
+// ResourceProfile identifies the build or testing whose resource usage is recorded
+type ResourceProfile struct {
+	ProjectName string `bson:"project_name" json:"project_name"`
+	ConfigType  string `bson:"config_type"  json:"config_type"`
+	ConfigName  string `bson:"config_name"  json:"config_name"`
+}
+
+// ResourceRecommendation is the resource spec recommended from the usage of recent runs
+type ResourceRecommendation struct {
+	// Samples is the number of runs the recommendation is calculated from
+	Samples       int                 `json:"samples"`
+	CpuPeakP95    int                 `json:"cpu_peak_p95"`
+	MemoryPeakP95 int                 `json:"memory_peak_p95"`
+	ResReqSpec    setting.RequestSpec `json:"res_req_spec"`
+}
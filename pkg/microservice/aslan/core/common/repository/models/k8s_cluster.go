@@ -77,6 +77,8 @@ type AdvancedConfig struct {
 	ScheduleStrategy  []*ScheduleStrategy        `json:"schedule_strategy"        bson:"schedule_strategy"`
 	EnableIRSA        bool                       `json:"enable_irsa"              bson:"enable_irsa"`
 	IRSARoleARM       string                     `json:"irsa_role_arn"            bson:"irsa_role_arn"`
+	// ImageBuildBackend is the default image build backend of the build jobs in the cluster, docker or buildkit
+	ImageBuildBackend string `json:"image_build_backend,omitempty" bson:"image_build_backend,omitempty"`
 
 	AgentNodeSelector string `json:"agent_node_selector"            bson:"agent_node_selector"`
 	AgentToleration   string `json:"agent_toleration"               bson:"agent_toleration"`
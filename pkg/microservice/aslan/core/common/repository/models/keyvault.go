@@ -29,6 +29,11 @@ type KeyVaultItem struct {
 	IsSensitive bool   `bson:"is_sensitive"           json:"is_sensitive"`
 	Description string `bson:"description"            json:"description"`
 
+	// ProviderID is the external backend holding the value, the value is stored in the item itself when it's empty
+	ProviderID string `bson:"provider_id,omitempty"  json:"provider_id,omitempty"`
+	// Reference locates the secret in the external backend, in the form of path#field
+	Reference string `bson:"reference,omitempty"    json:"reference,omitempty"`
+
 	ProjectName  string `bson:"project_name"           json:"project_name"`
 	IsSystemWide bool   `bson:"is_system_wide"         json:"is_system_wide"`
 
@@ -0,0 +1,60 @@
+/*
+Copyright 2025 The KodeRover Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package models
+
+import (
+	"go.mongodb.org/mongo-driver/bson/primitive"
+
+	"github.com/koderover/zadig/v2/pkg/microservice/aslan/config"
+)
+
+// KeyVaultProvider is an external secret backend key vault items can be read from
+type KeyVaultProvider struct {
+	ID   primitive.ObjectID          `bson:"_id,omitempty" json:"id"`
+	Name string                      `bson:"name"          json:"name"`
+	Type config.KeyVaultProviderType `bson:"type"          json:"type"`
+
+	Vault      *VaultProviderConfig             `bson:"vault,omitempty"      json:"vault,omitempty"`
+	AWS        *AWSSecretsManagerProviderConfig `bson:"aws,omitempty"        json:"aws,omitempty"`
+	Kubernetes *KubernetesSecretProviderConfig  `bson:"kubernetes,omitempty" json:"kubernetes,omitempty"`
+
+	UpdatedBy string `bson:"updated_by" json:"updated_by"`
+	UpdatedAt int64  `bson:"updated_at" json:"updated_at"`
+}
+
+type VaultProviderConfig struct {
+	Address   string `bson:"address"   json:"address"`
+	Token     string `bson:"token"     json:"token"`
+	Namespace string `bson:"namespace" json:"namespace"`
+	// Mount is the path of the KV v2 secrets engine, secret by default
+	Mount string `bson:"mount" json:"mount"`
+}
+
+type AWSSecretsManagerProviderConfig struct {
+	Region          string `bson:"region"            json:"region"`
+	AccessKeyID     string `bson:"access_key_id"     json:"access_key_id"`
+	SecretAccessKey string `bson:"secret_access_key" json:"secret_access_key"`
+}
+
+type KubernetesSecretProviderConfig struct {
+	ClusterID string `bson:"cluster_id" json:"cluster_id"`
+	Namespace string `bson:"namespace"  json:"namespace"`
+}
+
+func (KeyVaultProvider) TableName() string {
+	return "keyvault_provider"
+}
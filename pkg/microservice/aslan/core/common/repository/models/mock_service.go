@@ -0,0 +1,66 @@
+/*
+Copyright 2025 The KodeRover Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package models
+
+import (
+	"go.mongodb.org/mongo-driver/bson/primitive"
+
+	"github.com/koderover/zadig/v2/pkg/types"
+)
+
+// MockService is a revision of the mock of a service template, the mock is deployed to the environments as a mock
+// responder answering the requests in place of the service
+type MockService struct {
+	ID          primitive.ObjectID `bson:"_id,omitempty"      json:"id,omitempty"`
+	ProductName string             `bson:"product_name"       json:"product_name"`
+	ServiceName string             `bson:"service_name"       json:"service_name"`
+	Revision    int64              `bson:"revision"           json:"revision"`
+	Protocol    types.MockProtocol `bson:"protocol"           json:"protocol"`
+	// K8sServiceName is the name of the k8s service of the mock, it is the name the callers reach the mocked service by
+	// and defaults to the service name
+	K8sServiceName string `bson:"k8s_service_name"   json:"k8s_service_name"`
+	Port           int    `bson:"port"               json:"port"`
+	// Hosts are the external hosts routed to the mock in the environments with istio enabled, like api.example.com
+	Hosts []string `bson:"hosts"              json:"hosts"`
+	// HostPorts are the ports of the hosts routed to the mock, they default to 80 for http mocks and the mock port
+	// for grpc mocks. The mock doesn't terminate tls, the callers send plain text requests on every port including 443.
+	HostPorts []int `bson:"host_ports"         json:"host_ports"`
+	// DescriptorSet is the base64 encoded FileDescriptorSet of the grpc services, it is required for grpc mocks
+	DescriptorSet string            `bson:"descriptor_set"     json:"descriptor_set"`
+	Stubs         []*types.MockStub `bson:"stubs"              json:"stubs"`
+	CreateBy      string            `bson:"create_by"          json:"create_by"`
+	CreateTime    int64             `bson:"create_time"        json:"create_time"`
+}
+
+func (MockService) TableName() string {
+	return "mock_service"
+}
+
+// EnvMock is the mock deployed in an environment
+type EnvMock struct {
+	ID          primitive.ObjectID `bson:"_id,omitempty"      json:"id,omitempty"`
+	ProductName string             `bson:"product_name"       json:"product_name"`
+	EnvName     string             `bson:"env_name"           json:"env_name"`
+	ServiceName string             `bson:"service_name"       json:"service_name"`
+	Revision    int64              `bson:"revision"           json:"revision"`
+	UpdateBy    string             `bson:"update_by"          json:"update_by"`
+	UpdateTime  int64              `bson:"update_time"        json:"update_time"`
+}
+
+func (EnvMock) TableName() string {
+	return "env_mock"
+}
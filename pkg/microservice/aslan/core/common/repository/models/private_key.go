@@ -51,6 +51,8 @@ type PrivateKey struct {
 	Agent            *VMAgent `bson:"agent"                  json:"agent,omitempty"`
 	VMInfo           *VMInfo  `bson:"vm_info"                json:"vm_info,omitempty"`
 	Type             string   `bson:"type"                   json:"type"`
+	// PoolAgent is set when the vm is created by an agent pool
+	PoolAgent *PoolAgent `bson:"pool_agent,omitempty"   json:"pool_agent,omitempty"`
 }
 
 type VMInfo struct {
@@ -78,6 +80,15 @@ type VMAgent struct {
 	LastHeartbeatTime int64  `bson:"last_heartbeat_time"  json:"last_heartbeat_time"`
 }
 
+type PoolAgent struct {
+	PoolName   string `bson:"pool_name"            json:"pool_name"`
+	InstanceID string `bson:"instance_id"          json:"instance_id"`
+	Ephemeral  bool   `bson:"ephemeral"            json:"ephemeral"`
+	// JobID is the only job run by the ephemeral agent
+	JobID     string `bson:"job_id"               json:"job_id"`
+	IdleSince int64  `bson:"idle_since"           json:"idle_since"`
+}
+
 func (PrivateKey) TableName() string {
 	return "private_key"
 }
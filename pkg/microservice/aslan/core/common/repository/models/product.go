@@ -86,6 +86,9 @@ type Product struct {
 	// New Since v2.1.0.
 	IstioGrayscale IstioGrayscale `bson:"istio_grayscale" json:"istio_grayscale"`
 
+	// ImageTrustPolicy decides which image signatures are trusted by the deployments into the environment
+	ImageTrustPolicy *ImageTrustPolicy `bson:"image_trust_policy,omitempty" json:"image_trust_policy,omitempty"`
+
 	// For production environment
 	Production bool `json:"production" bson:"production"`
 }
@@ -248,6 +251,19 @@ type IstioGrayscale struct {
 	HeaderMatchConfigs []IstioHeaderMatchConfig `bson:"header_match_configs" json:"header_match_configs"`
 }
 
+type ImageTrustPolicy struct {
+	Enabled bool `bson:"enabled"      json:"enabled"`
+	// TrustedKeys are key vault items holding PEM encoded public keys, project items take priority over system wide ones
+	TrustedKeys []*KeyVaultKeyRef `bson:"trusted_keys" json:"trusted_keys"`
+	// PublicKeys holds PEM encoded public keys configured in the environment directly
+	PublicKeys string `bson:"public_keys"  json:"public_keys"`
+}
+
+type KeyVaultKeyRef struct {
+	Group string `bson:"group" json:"group"`
+	Key   string `bson:"key"   json:"key"`
+}
+
 type GrayscaleStrategyType string
 
 var (
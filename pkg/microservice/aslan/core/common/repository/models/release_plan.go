@@ -60,6 +60,33 @@ type ReleasePlan struct {
 	WaitForExecuteExternalCheckTime        int64  `bson:"wait_for_execute_external_check_time"       yaml:"wait_for_execute_external_check_time"                   json:"wait_for_execute_external_check_time"`
 	WaitForAllDoneExternalCheckTime        int64  `bson:"wait_for_all_done_external_check_time"      yaml:"wait_for_all_done_external_check_time"                   json:"wait_for_all_done_external_check_time"`
 	ExternalCheckFailedReason              string `bson:"external_check_failed_reason"       yaml:"external_check_failed_reason"                   json:"external_check_failed_reason"`
+
+	// RollbackSnapshot is the production state of the services affected by the plan, captured right before execution
+	RollbackSnapshot []*ReleasePlanServiceSnapshot `bson:"rollback_snapshot,omitempty"       yaml:"rollback_snapshot,omitempty"       json:"rollback_snapshot,omitempty"`
+	// RollbackPlanID is the id of the plan which rolls back this plan
+	RollbackPlanID string `bson:"rollback_plan_id,omitempty"        yaml:"rollback_plan_id,omitempty"        json:"rollback_plan_id,omitempty"`
+	// RollbackFromPlanID is the id of the plan rolled back by this plan
+	RollbackFromPlanID string `bson:"rollback_from_plan_id,omitempty"   yaml:"rollback_from_plan_id,omitempty"   json:"rollback_from_plan_id,omitempty"`
+}
+
+type ReleasePlanServiceSnapshot struct {
+	ProjectName string `bson:"project_name"       yaml:"project_name"       json:"project_name"`
+	EnvName     string `bson:"env_name"           yaml:"env_name"           json:"env_name"`
+	// ServiceName is the release name for helm chart services
+	ServiceName string `bson:"service_name"       yaml:"service_name"       json:"service_name"`
+	ServiceType string `bson:"service_type"       yaml:"service_type"       json:"service_type"`
+	Production  bool   `bson:"production"         yaml:"production"         json:"production"`
+	IsHelmChart bool   `bson:"is_helm_chart"      yaml:"is_helm_chart"      json:"is_helm_chart"`
+	// Revision is the env service version revision which holds the full service state, the variables of k8s yaml
+	// services are restored from it
+	Revision   int64        `bson:"revision"           yaml:"revision"           json:"revision"`
+	Containers []*Container `bson:"containers"         yaml:"containers"         json:"containers"`
+	ValuesYaml string       `bson:"values_yaml"        yaml:"values_yaml"        json:"values_yaml"`
+	// chart of the helm chart services
+	ChartRepo    string `bson:"chart_repo,omitempty"      yaml:"chart_repo,omitempty"      json:"chart_repo,omitempty"`
+	ChartName    string `bson:"chart_name,omitempty"      yaml:"chart_name,omitempty"      json:"chart_name,omitempty"`
+	ChartVersion string `bson:"chart_version,omitempty"   yaml:"chart_version,omitempty"   json:"chart_version,omitempty"`
+	CreateTime   int64  `bson:"create_time"        yaml:"create_time"        json:"create_time"`
 }
 
 type HookSettings struct {
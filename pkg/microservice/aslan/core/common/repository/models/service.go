@@ -24,6 +24,7 @@ import (
 	templatemodels "github.com/koderover/zadig/v2/pkg/microservice/aslan/core/common/repository/models/template"
 	commontypes "github.com/koderover/zadig/v2/pkg/microservice/aslan/core/common/types"
 	"github.com/koderover/zadig/v2/pkg/setting"
+	"github.com/koderover/zadig/v2/pkg/tool/kustomize"
 )
 
 // TODO: move Revision out of Service.
@@ -62,6 +63,7 @@ type Service struct {
 	ServiceVariableKVs []*commontypes.ServiceVariableKV `bson:"service_variable_kvs"           json:"service_variable_kvs"` // New since 1.18.0, stores the variable kvs of k8s services
 	ServiceVars        []string                         `bson:"service_vars"                   json:"service_vars"`         // DEPRECATED, New since 1.16.0, stores keys in variables which can be set in env
 	HelmChart          *HelmChart                       `bson:"helm_chart,omitempty"           json:"helm_chart,omitempty"`
+	Kustomize          *Kustomize                       `bson:"kustomize,omitempty"            json:"kustomize,omitempty"`
 	EnvConfigs         []*EnvConfig                     `bson:"env_configs,omitempty"          json:"env_configs,omitempty"`
 	EnvStatuses        []*EnvStatus                     `bson:"env_statuses,omitempty"         json:"env_statuses,omitempty"`
 	ReleaseNaming      string                           `bson:"release_naming"                 json:"release_naming"`
@@ -88,6 +90,29 @@ type Service struct {
 	Production    bool                `bson:"-"                              json:"-"` // check current service data is production service
 }
 
+// Kustomize is set for k8s services loaded from a kustomization, the files under LoadPath are kept so the overlay of
+// each environment can be built when the service is rendered.
+type Kustomize struct {
+	DefaultOverlay string                 `bson:"default_overlay"        json:"default_overlay"`
+	EnvOverlays    []*KustomizeEnvOverlay `bson:"env_overlays,omitempty" json:"env_overlays,omitempty"`
+	Files          []*kustomize.File      `bson:"files"                  json:"-"`
+}
+
+type KustomizeEnvOverlay struct {
+	EnvName string `bson:"env_name" json:"env_name"`
+	Overlay string `bson:"overlay"  json:"overlay"`
+}
+
+// Overlay returns the overlay used in the environment.
+func (k *Kustomize) Overlay(envName string) string {
+	for _, overlay := range k.EnvOverlays {
+		if overlay.EnvName == envName && overlay.Overlay != "" {
+			return overlay.Overlay
+		}
+	}
+	return k.DefaultOverlay
+}
+
 type CreateFromRepo struct {
 	GitRepoConfig *templatemodels.GitRepoConfig `bson:"git_repo_config,omitempty"      json:"git_repo_config,omitempty"`
 	Commit        *Commit                       `bson:"commit,omitempty"               json:"commit,omitempty"`
@@ -0,0 +1,35 @@
+/*
+Copyright 2025 The KodeRover Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package models
+
+import "go.mongodb.org/mongo-driver/bson/primitive"
+
+// ServiceDependency saves the runtime dependencies declared for a service,
+// it is kept apart from the service template so that it survives template revisions
+type ServiceDependency struct {
+	ID           primitive.ObjectID `bson:"_id,omitempty"  json:"id,omitempty"`
+	ProductName  string             `bson:"product_name"   json:"product_name"`
+	ServiceName  string             `bson:"service_name"   json:"service_name"`
+	Production   bool               `bson:"production"     json:"production"`
+	Dependencies []string           `bson:"dependencies"   json:"dependencies"`
+	UpdateBy     string             `bson:"update_by"      json:"update_by"`
+	UpdateTime   int64              `bson:"update_time"    json:"update_time"`
+}
+
+func (ServiceDependency) TableName() string {
+	return "service_dependency"
+}
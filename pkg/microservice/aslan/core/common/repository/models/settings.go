@@ -16,7 +16,13 @@ limitations under the License.
 
 package models
 
-import "go.mongodb.org/mongo-driver/bson/primitive"
+import (
+	"go.mongodb.org/mongo-driver/bson/primitive"
+
+	"github.com/koderover/zadig/v2/pkg/microservice/aslan/config"
+	"github.com/koderover/zadig/v2/pkg/tool/auditsink"
+	"github.com/koderover/zadig/v2/pkg/tool/sbom"
+)
 
 type SystemSetting struct {
 	ID                  primitive.ObjectID       `bson:"_id,omitempty" json:"id,omitempty"`
@@ -29,6 +35,7 @@ type SystemSetting struct {
 	Language            string                   `bson:"language" json:"language"`
 	ServerURL           string                   `bson:"server_url" json:"server_url"`
 	ReleasePlanHook     *ReleasePlanHookSettings `bson:"release_plan_hook" json:"release_plan_hook"`
+	AuditLog            *AuditLogSettings        `bson:"audit_log" json:"audit_log"`
 	UpdateTime          int64                    `bson:"update_time" json:"update_time"`
 }
 
@@ -66,6 +73,28 @@ type CustomTheme struct {
 
 type SecuritySettings struct {
 	TokenExpirationTime int64 `json:"token_expiration_time" bson:"token_expiration_time"`
+	// ProductionImageScanRequired makes deploy jobs of production environments refuse images without a passing image scan
+	ProductionImageScanRequired bool `json:"production_image_scan_required" bson:"production_image_scan_required"`
+	// ImageSigning signs the images pushed by build and distribute jobs
+	ImageSigning *ImageSigningSettings `json:"image_signing" bson:"image_signing"`
+	// SBOM generates a software bill of materials for the images pushed by build jobs, trivy is required in the build environment
+	SBOM *SBOMSettings `json:"sbom" bson:"sbom"`
+}
+
+type SBOMSettings struct {
+	Enabled bool        `json:"enabled" bson:"enabled"`
+	Format  sbom.Format `json:"format"  bson:"format"`
+}
+
+type ImageSigningSettings struct {
+	Enabled bool `json:"enabled" bson:"enabled"`
+	// KeySource is where the signing key comes from, keyvault or kms
+	KeySource config.ImageSigningKeySource `json:"key_source" bson:"key_source"`
+	// KeyVaultGroup and KeyVaultKey locate the system wide key vault item holding the PEM encoded private key
+	KeyVaultGroup string `json:"key_vault_group" bson:"key_vault_group"`
+	KeyVaultKey   string `json:"key_vault_key"   bson:"key_vault_key"`
+	// KMSKeyRef references a key held by the executor, env://NAME or file:///path/to/key.pem
+	KMSKeyRef string `json:"kms_key_ref" bson:"kms_key_ref"`
 }
 
 type PrivacySettings struct {
@@ -100,3 +129,47 @@ const (
 func (SystemSetting) TableName() string {
 	return "system_setting"
 }
+
+// AuditLogSettings configures the retention of the operation logs and their export to external systems
+type AuditLogSettings struct {
+	// RetentionDays removes the operation logs older than the days, logs are kept forever when it's 0
+	RetentionDays int             `json:"retention_days" bson:"retention_days"`
+	Sinks         []*AuditLogSink `json:"sinks"          bson:"sinks"`
+}
+
+type AuditLogSink struct {
+	ID      string               `json:"id"                bson:"id"`
+	Name    string               `json:"name"              bson:"name"`
+	Type    auditsink.Type       `json:"type"              bson:"type"`
+	Enabled bool                 `json:"enabled"           bson:"enabled"`
+	Syslog  *AuditLogSyslogSink  `json:"syslog,omitempty"  bson:"syslog,omitempty"`
+	Webhook *AuditLogWebhookSink `json:"webhook,omitempty" bson:"webhook,omitempty"`
+	Kafka   *AuditLogKafkaSink   `json:"kafka,omitempty"   bson:"kafka,omitempty"`
+}
+
+type AuditLogSyslogSink struct {
+	Address string           `json:"address"  bson:"address"`
+	AppName string           `json:"app_name" bson:"app_name"`
+	TLS     *AuditLogSinkTLS `json:"tls"      bson:"tls"`
+}
+
+type AuditLogWebhookSink struct {
+	URL    string `json:"url"    bson:"url"`
+	Secret string `json:"secret" bson:"secret"`
+}
+
+type AuditLogKafkaSink struct {
+	RESTProxyURL string           `json:"rest_proxy_url" bson:"rest_proxy_url"`
+	Topic        string           `json:"topic"          bson:"topic"`
+	Username     string           `json:"username"       bson:"username"`
+	Password     string           `json:"password"       bson:"password"`
+	TLS          *AuditLogSinkTLS `json:"tls"            bson:"tls"`
+}
+
+type AuditLogSinkTLS struct {
+	Enabled            bool   `json:"enabled"              bson:"enabled"`
+	CACert             string `json:"ca_cert"              bson:"ca_cert"`
+	ClientCert         string `json:"client_cert"          bson:"client_cert"`
+	ClientKey          string `json:"client_key"           bson:"client_key"`
+	InsecureSkipVerify bool   `json:"insecure_skip_verify" bson:"insecure_skip_verify"`
+}
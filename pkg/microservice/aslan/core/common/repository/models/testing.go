@@ -25,21 +25,23 @@ import (
 )
 
 type Testing struct {
-	ID             primitive.ObjectID  `bson:"_id,omitempty"            json:"id,omitempty"`
-	Name           string              `bson:"name"                     json:"name"`
-	ProductName    string              `bson:"product_name"             json:"product_name"`
-	Desc           string              `bson:"desc"                     json:"desc"`
-	Timeout        int                 `bson:"timeout"                  json:"timeout"`
-	Team           string              `bson:"team"                     json:"team"`
-	Infrastructure string              `bson:"infrastructure"           json:"infrastructure"`
-	VMLabels       []string            `bson:"vm_labels"                json:"vm_labels"`
-	Repos          []*types.Repository `bson:"repos"                    json:"repos"`
-	PreTest        *PreTest            `bson:"pre_test"                 json:"pre_test"`
-	PostTest       *PostTest           `bson:"post_test"                json:"post_test"`
-	ScriptType     types.ScriptType    `bson:"script_type"              json:"script_type"`
-	Scripts        string              `bson:"scripts"                  json:"scripts"`
-	UpdateTime     int64               `bson:"update_time"              json:"update_time"`
-	UpdateBy       string              `bson:"update_by"                json:"update_by"`
+	ID             primitive.ObjectID `bson:"_id,omitempty"            json:"id,omitempty"`
+	Name           string             `bson:"name"                     json:"name"`
+	ProductName    string             `bson:"product_name"             json:"product_name"`
+	Desc           string             `bson:"desc"                     json:"desc"`
+	Timeout        int                `bson:"timeout"                  json:"timeout"`
+	Team           string             `bson:"team"                     json:"team"`
+	Infrastructure string             `bson:"infrastructure"           json:"infrastructure"`
+	VMLabels       []string           `bson:"vm_labels"                json:"vm_labels"`
+	// VMExecutionMode is host or container, it only works for the vm infrastructure
+	VMExecutionMode string              `bson:"vm_execution_mode"        json:"vm_execution_mode"`
+	Repos           []*types.Repository `bson:"repos"                    json:"repos"`
+	PreTest         *PreTest            `bson:"pre_test"                 json:"pre_test"`
+	PostTest        *PostTest           `bson:"post_test"                json:"post_test"`
+	ScriptType      types.ScriptType    `bson:"script_type"              json:"script_type"`
+	Scripts         string              `bson:"scripts"                  json:"scripts"`
+	UpdateTime      int64               `bson:"update_time"              json:"update_time"`
+	UpdateBy        string              `bson:"update_by"                json:"update_by"`
 	// Junit 测试报告
 	TestResultPath          string `bson:"test_result_path"         json:"test_result_path"`
 	JUnitTestResultPassRate int    `bson:"junit_test_result_pass_rate"         json:"junit_test_result_pass_rate"`
@@ -72,6 +74,8 @@ type Testing struct {
 	// New since V1.10.0. Only to tell the webpage should the advanced settings be displayed
 	AdvancedSettingsModified bool      `bson:"advanced_setting_modified" json:"advanced_setting_modified"`
 	Outputs                  []*Output `bson:"outputs"                   json:"outputs"`
+	// MockAssertions are verified against the requests received by the mocks in the environments once the testing passes
+	MockAssertions []*types.MockAssertion `bson:"mock_assertions"           json:"mock_assertions"`
 }
 
 type TestingHookCtrl struct {
@@ -98,6 +102,8 @@ type PreTest struct {
 	// ResReq defines job requested resources
 	ResReq     setting.Request     `bson:"res_req"                json:"res_req"`
 	ResReqSpec setting.RequestSpec `bson:"res_req_spec"           json:"res_req_spec"`
+	// ResourceRecommendation is calculated from the resource usage of recent runs, it is not saved
+	ResourceRecommendation *ResourceRecommendation `bson:"-" json:"resource_recommendation,omitempty"`
 	// Installs defines apps to be installed for build
 	Installs []*Item `bson:"installs,omitempty"    json:"installs"`
 	// Envs stores user defined env key val for build
@@ -0,0 +1,64 @@
+/*
+Copyright 2025 The KodeRover Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package vm
+
+import (
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+const (
+	ProvisionerTypeDocker = "docker"
+)
+
+// AgentPool is a group of vm agents created and destroyed by aslan on demand. Its agents carry the pool label,
+// so the vm jobs with the label are run on them.
+type AgentPool struct {
+	ID          primitive.ObjectID `bson:"_id,omitempty"          json:"id,omitempty"`
+	Name        string             `bson:"name"                   json:"name"`
+	Description string             `bson:"description"            json:"description"`
+	Label       string             `bson:"label"                  json:"label"`
+	Enabled     bool               `bson:"enabled"                json:"enabled"`
+	// Ephemeral agents run only one job and are destroyed after the job finishes
+	Ephemeral       bool         `bson:"ephemeral"              json:"ephemeral"`
+	MinAgents       int          `bson:"min_agents"             json:"min_agents"`
+	MaxAgents       int          `bson:"max_agents"             json:"max_agents"`
+	TaskConcurrency int          `bson:"task_concurrency"       json:"task_concurrency"`
+	Workspace       string       `bson:"workspace"              json:"workspace"`
+	IdleTimeout     int64        `bson:"idle_timeout"           json:"idle_timeout"`
+	Provisioner     *Provisioner `bson:"provisioner"            json:"provisioner"`
+	CreateTime      int64        `bson:"create_time"            json:"create_time"`
+	UpdateTime      int64        `bson:"update_time"            json:"update_time"`
+	UpdateBy        string       `bson:"update_by"              json:"update_by"`
+}
+
+type Provisioner struct {
+	Type   string             `bson:"type"                   json:"type"`
+	Docker *DockerProvisioner `bson:"docker,omitempty"       json:"docker,omitempty"`
+}
+
+// DockerProvisioner runs every agent in a container, it is mainly used to try agent pools locally
+type DockerProvisioner struct {
+	// Host is the docker daemon address, the environment of aslan is used if it is empty
+	Host    string   `bson:"host"                   json:"host"`
+	Image   string   `bson:"image"                  json:"image"`
+	Network string   `bson:"network"                json:"network"`
+	Envs    []string `bson:"envs"                   json:"envs"`
+}
+
+func (AgentPool) TableName() string {
+	return "vm_agent_pool"
+}
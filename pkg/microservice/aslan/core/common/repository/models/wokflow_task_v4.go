@@ -25,6 +25,7 @@ import (
 	commontypes "github.com/koderover/zadig/v2/pkg/microservice/aslan/core/common/types"
 	"github.com/koderover/zadig/v2/pkg/setting"
 	"github.com/koderover/zadig/v2/pkg/tool/blueking"
+	"github.com/koderover/zadig/v2/pkg/tool/imagesign"
 	"github.com/koderover/zadig/v2/pkg/tool/pingcode"
 	"github.com/koderover/zadig/v2/pkg/types"
 )
@@ -122,6 +123,9 @@ type JobTask struct {
 	ServiceModules   []*WorkflowServiceModule `bson:"service_modules"     json:"service_modules"`
 	Infrastructure   string                   `bson:"infrastructure"      json:"infrastructure"`
 	VMLabels         []string                 `bson:"vm_labels"           json:"vm_labels"`
+	VMExecutionMode  string                   `bson:"vm_execution_mode"   json:"vm_execution_mode"`
+	// DependsOn is the names of the jobs in the same stage that must be done before this job runs
+	DependsOn []string `bson:"depends_on,omitempty" json:"depends_on,omitempty"`
 
 	ErrorPolicy   *JobErrorPolicy   `bson:"error_policy"         yaml:"error_policy"         json:"error_policy"`
 	ExecutePolicy *JobExecutePolicy `bson:"execute_policy"       yaml:"execute_policy"       json:"execute_policy"`
@@ -233,6 +237,8 @@ type JobTaskDeploySpec struct {
 	Timeout            int                             `bson:"timeout"                          json:"timeout"                             yaml:"timeout"`
 	ReplaceResources   []Resource                      `bson:"replace_resources"                json:"replace_resources"                   yaml:"replace_resources"`
 	RelatedPodLabels   []map[string]string             `bson:"-"                                json:"-"                                   yaml:"-"`
+	// SignatureVerifications records the image signature verification results against the trust policy of the environment
+	SignatureVerifications []*imagesign.VerifyResult `bson:"signature_verifications"          json:"signature_verifications"             yaml:"signature_verifications"`
 	// for compatibility
 	ServiceModule string `bson:"service_module"                   json:"service_module"                      yaml:"-"`
 	Image         string `bson:"image"                            json:"image"                               yaml:"-"`
@@ -306,6 +312,8 @@ type JobTaskHelmDeploySpec struct {
 	ReplaceResources             []Resource                `bson:"replace_resources"                json:"replace_resources"                   yaml:"replace_resources"`
 	OriginRevision               int64                     `bson:"origin_revision"                  json:"origin_revision"                     yaml:"origin_revision"`
 	ValueMergeStrategy           config.ValueMergeStrategy `bson:"value_merge_strategy"             json:"value_merge_strategy"                yaml:"value_merge_strategy"`
+	// SignatureVerifications records the image signature verification results against the trust policy of the environment
+	SignatureVerifications []*imagesign.VerifyResult `bson:"signature_verifications"          json:"signature_verifications"             yaml:"signature_verifications"`
 }
 
 func (j *JobTaskHelmDeploySpec) GetDeployImages() []string {
@@ -318,11 +326,14 @@ func (j *JobTaskHelmDeploySpec) GetDeployImages() []string {
 
 type JobTaskHelmChartDeploySpec struct {
 	Env                string           `bson:"env"                              json:"env"                                 yaml:"env"`
+	IsProduction       bool             `bson:"is_production"                    json:"is_production"                       yaml:"is_production"`
 	DeployHelmChart    *DeployHelmChart `bson:"deploy_helm_chart"       yaml:"deploy_helm_chart"          json:"deploy_helm_chart"`
 	SkipCheckRunStatus bool             `bson:"skip_check_run_status"            json:"skip_check_run_status"               yaml:"skip_check_run_status"`
 	ClusterID          string           `bson:"cluster_id"                       json:"cluster_id"                          yaml:"cluster_id"`
 	Timeout            int              `bson:"timeout"                          json:"timeout"                             yaml:"timeout"`
 	MaxHistory         int              `bson:"max_history"                      json:"max_history"                         yaml:"max_history"`
+	// SignatureVerifications records the image signature verification results against the trust policy of the environment
+	SignatureVerifications []*imagesign.VerifyResult `bson:"signature_verifications"          json:"signature_verifications"             yaml:"signature_verifications"`
 }
 
 type ImageAndServiceModule struct {
@@ -364,6 +375,8 @@ type JobTaskBlueGreenDeployV2Spec struct {
 	Service       *BlueGreenDeployV2Service `bson:"service"                      json:"service"                     yaml:"service"`
 	Events        *Events                   `bson:"events"                      json:"events"                     yaml:"events"`
 	DeployTimeout int                       `bson:"deploy_timeout"              json:"deploy_timeout"             yaml:"deploy_timeout"`
+	// SignatureVerifications records the image signature verification results against the trust policy of the environment
+	SignatureVerifications []*imagesign.VerifyResult `bson:"signature_verifications"          json:"signature_verifications"             yaml:"signature_verifications"`
 }
 
 type JobTaskBlueGreenReleaseSpec struct {
@@ -405,6 +418,8 @@ type JobTaskCanaryDeploySpec struct {
 	Version            string  `bson:"version"                        json:"version"                       yaml:"version"`
 	Image              string  `bson:"image"                          json:"image"                         yaml:"image"`
 	Events             *Events `bson:"events"                         json:"events"                        yaml:"events"`
+	// SignatureVerifications records the image signature verification results against the trust policy of the environment
+	SignatureVerifications []*imagesign.VerifyResult `bson:"signature_verifications"          json:"signature_verifications"             yaml:"signature_verifications"`
 }
 
 type JobTaskCanaryReleaseSpec struct {
@@ -402,6 +402,8 @@ type RuntimeInfo struct {
 	Installs []*Item `bson:"installs" json:"installs" yaml:"installs"`
 	// 执行主机
 	VMLabels []string `bson:"vm_labels" json:"vm_labels" yaml:"vm_labels"`
+	// VMExecutionMode is host or container, it only works for the vm infrastructure
+	VMExecutionMode string `bson:"vm_execution_mode" json:"vm_execution_mode" yaml:"vm_execution_mode,omitempty"`
 }
 
 type FreestyleJobAdvancedSettings struct {
@@ -461,6 +463,8 @@ type ZadigDeployJobSpec struct {
 	DeployType                  string                       `bson:"deploy_type"              yaml:"deploy_type,omitempty"       json:"deploy_type"`
 	SkipCheckRunStatus          bool                         `bson:"skip_check_run_status"    yaml:"skip_check_run_status"       json:"skip_check_run_status"`
 	SkipCheckHelmWorkloadStatus bool                         `bson:"skip_check_helm_workload_status" yaml:"skip_check_helm_workload_status" json:"skip_check_helm_workload_status"`
+	// DependencyOrder deploys the services after the ones they depend on, the ones without dependencies between them run in parallel in a parallel stage
+	DependencyOrder bool `bson:"dependency_order" yaml:"dependency_order" json:"dependency_order"`
 	// fromjob/runtime, runtime 表示运行时输入，fromjob 表示从上游构建任务中获取
 	Source         config.DeploySourceType `bson:"source"     yaml:"source"     json:"source"`
 	EnvSource      config.ParamSourceType  `bson:"env_source" yaml:"env_source" json:"env_source"`
@@ -672,6 +676,42 @@ type DistributeTarget struct {
 	UpdateTag bool `bson:"update_tag"                yaml:"update_tag"                json:"update_tag"`
 }
 
+type ZadigImageScanJobSpec struct {
+	// fromjob/runtime, `runtime` means runtime input, `fromjob` means that images are obtained from the upstream build or distribute job
+	Source config.DeploySourceType `bson:"source"                         json:"source"                        yaml:"source"`
+	// required when source is `fromjob`
+	JobName string `bson:"job_name"                       json:"job_name"                      yaml:"job_name"`
+	// not required when source is fromjob, directly obtained from upstream job information
+	RegistryID    string             `bson:"registry_id"                    json:"registry_id"                   yaml:"registry_id"`
+	Targets       []*ImageScanTarget `bson:"targets"                        json:"targets"                       yaml:"targets"`
+	TargetOptions []*ImageScanTarget `bson:"target_options"                 json:"target_options"                yaml:"target_options"`
+	// Installs is installed before scanning, it must include trivy which runs the scan
+	Installs []*Item          `bson:"installs"                       json:"installs"                      yaml:"installs"`
+	Policy   *ImageScanPolicy `bson:"policy"                         json:"policy"                        yaml:"policy"`
+	// unit is minute.
+	Timeout           int64            `bson:"timeout"                        json:"timeout"                       yaml:"timeout"`
+	ClusterID         string           `bson:"cluster_id"                     json:"cluster_id"                    yaml:"cluster_id"`
+	ClusterSource     string           `bson:"cluster_source"                 json:"cluster_source"                yaml:"cluster_source"`
+	StrategyID        string           `bson:"strategy_id"                    json:"strategy_id"                   yaml:"strategy_id"`
+	CustomAnnotations []*util.KeyValue `bson:"custom_annotations"             json:"custom_annotations"            yaml:"custom_annotations"`
+	CustomLabels      []*util.KeyValue `bson:"custom_labels"                  json:"custom_labels"                 yaml:"custom_labels"`
+}
+
+type ImageScanTarget struct {
+	ServiceName   string `bson:"service_name"              yaml:"service_name"               json:"service_name"`
+	ServiceModule string `bson:"service_module"            yaml:"service_module"             json:"service_module"`
+	ImageName     string `bson:"image_name,omitempty"      yaml:"image_name,omitempty"       json:"image_name,omitempty"`
+	Tag           string `bson:"tag,omitempty"             yaml:"tag,omitempty"              json:"tag,omitempty"`
+	Image         string `bson:"image,omitempty"           yaml:"image,omitempty"            json:"image,omitempty"`
+}
+
+// ImageScanPolicy fails the scan when a vulnerability of the given severities is found,
+// only the ones with a fix available are counted if FixableOnly is set.
+type ImageScanPolicy struct {
+	Severities  []string `bson:"severities"       yaml:"severities"       json:"severities"`
+	FixableOnly bool     `bson:"fixable_only"     yaml:"fixable_only"     json:"fixable_only"`
+}
+
 type ZadigTestingJobSpec struct {
 	TestType      config.TestModuleType   `bson:"test_type"         yaml:"test_type"         json:"test_type"`
 	Source        config.DeploySourceType `bson:"source"            yaml:"source"            json:"source"`
@@ -1553,9 +1593,12 @@ type JobProperties struct {
 	ShareStorageDetails []*StorageDetail       `bson:"share_storage_details"  json:"share_storage_details" yaml:"-"`
 	EnablePrivileged    bool                   `bson:"enable_privileged,omitempty" json:"enable_privileged,omitempty" yaml:"enable_privileged,omitempty"`
 	UseHostDockerDaemon bool                   `bson:"use_host_docker_daemon,omitempty" json:"use_host_docker_daemon,omitempty" yaml:"use_host_docker_daemon"`
+	ImageBuildBackend   string                 `bson:"image_build_backend,omitempty" json:"image_build_backend,omitempty" yaml:"image_build_backend,omitempty"`
 	Storages            []*types.NFSProperties `bson:"storages"                 json:"storages"                 yaml:"storages"`
 	// for VM deploy to get service name to save
 	ServiceName string `bson:"service_name" json:"service_name" yaml:"service_name"`
+	// for build and testing jobs to record the resource usage of the build or testing
+	ResourceProfile *ResourceProfile `bson:"resource_profile,omitempty" json:"resource_profile,omitempty" yaml:"-"`
 
 	CustomAnnotations []*util.KeyValue `bson:"custom_annotations" json:"custom_annotations" yaml:"custom_annotations"`
 	CustomLabels      []*util.KeyValue `bson:"custom_labels"      json:"custom_labels"      yaml:"custom_labels"`
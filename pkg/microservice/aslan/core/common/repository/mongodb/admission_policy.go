@@ -0,0 +1,153 @@
+/*
+Copyright 2025 The KodeRover Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package mongodb
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+
+	"github.com/koderover/zadig/v2/pkg/microservice/aslan/config"
+	"github.com/koderover/zadig/v2/pkg/microservice/aslan/core/common/repository/models"
+	mongotool "github.com/koderover/zadig/v2/pkg/tool/mongo"
+)
+
+type AdmissionPolicyColl struct {
+	*mongo.Collection
+
+	coll string
+}
+
+func NewAdmissionPolicyColl() *AdmissionPolicyColl {
+	name := models.AdmissionPolicy{}.TableName()
+	return &AdmissionPolicyColl{
+		Collection: mongotool.Database(config.MongoDatabase()).Collection(name),
+		coll:       name,
+	}
+}
+
+func (c *AdmissionPolicyColl) GetCollectionName() string {
+	return c.coll
+}
+
+func (c *AdmissionPolicyColl) EnsureIndex(ctx context.Context) error {
+	mods := []mongo.IndexModel{
+		{
+			Keys:    bson.D{bson.E{Key: "name", Value: 1}},
+			Options: options.Index().SetUnique(true),
+		},
+		{
+			Keys:    bson.D{bson.E{Key: "package", Value: 1}},
+			Options: options.Index().SetUnique(true),
+		},
+	}
+	_, err := c.Indexes().CreateMany(ctx, mods, mongotool.CreateIndexOptions(ctx))
+	return err
+}
+
+func (c *AdmissionPolicyColl) List() ([]*models.AdmissionPolicy, error) {
+	resp := make([]*models.AdmissionPolicy, 0)
+	opts := options.Find().SetSort(bson.D{{Key: "name", Value: 1}})
+	cursor, err := c.Collection.Find(context.Background(), bson.M{}, opts)
+	if err != nil {
+		return nil, err
+	}
+	return resp, cursor.All(context.Background(), &resp)
+}
+
+// ListEnabled lists the enabled policies of the scope which apply to the project.
+func (c *AdmissionPolicyColl) ListEnabled(scope config.AdmissionPolicyScope, projectName string) ([]*models.AdmissionPolicy, error) {
+	query := bson.M{
+		"enabled": true,
+		"scopes":  scope,
+		"$or": bson.A{
+			bson.M{"projects": bson.M{"$size": 0}},
+			bson.M{"projects": nil},
+			bson.M{"projects": projectName},
+		},
+	}
+
+	resp := make([]*models.AdmissionPolicy, 0)
+	cursor, err := c.Collection.Find(context.Background(), query)
+	if err != nil {
+		return nil, err
+	}
+	return resp, cursor.All(context.Background(), &resp)
+}
+
+func (c *AdmissionPolicyColl) GetByID(idHex string) (*models.AdmissionPolicy, error) {
+	id, err := primitive.ObjectIDFromHex(idHex)
+	if err != nil {
+		return nil, err
+	}
+
+	resp := &models.AdmissionPolicy{}
+	err = c.FindOne(context.Background(), bson.M{"_id": id}).Decode(resp)
+	return resp, err
+}
+
+func (c *AdmissionPolicyColl) Create(args *models.AdmissionPolicy) error {
+	if args == nil {
+		return fmt.Errorf("nil admission policy")
+	}
+
+	if args.ID.IsZero() {
+		args.ID = primitive.NewObjectID()
+	}
+	args.CreatedAt = time.Now().Unix()
+	args.UpdatedAt = args.CreatedAt
+	_, err := c.InsertOne(context.TODO(), args)
+	return err
+}
+
+func (c *AdmissionPolicyColl) Update(idHex string, args *models.AdmissionPolicy) error {
+	id, err := primitive.ObjectIDFromHex(idHex)
+	if err != nil {
+		return err
+	}
+
+	args.UpdatedAt = time.Now().Unix()
+	change := bson.M{"$set": bson.M{
+		"name":        args.Name,
+		"description": args.Description,
+		"enabled":     args.Enabled,
+		"package":     args.Package,
+		"rego":        args.Rego,
+		"scopes":      args.Scopes,
+		"enforcement": args.Enforcement,
+		"projects":    args.Projects,
+		"updated_by":  args.UpdatedBy,
+		"updated_at":  args.UpdatedAt,
+	}}
+	_, err = c.UpdateOne(context.TODO(), bson.M{"_id": id}, change)
+	return err
+}
+
+func (c *AdmissionPolicyColl) Delete(idHex string) error {
+	id, err := primitive.ObjectIDFromHex(idHex)
+	if err != nil {
+		return err
+	}
+
+	_, err = c.DeleteOne(context.TODO(), bson.M{"_id": id})
+	return err
+}
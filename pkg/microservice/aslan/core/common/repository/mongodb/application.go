@@ -52,6 +52,7 @@ func (c *ApplicationColl) EnsureIndex(ctx context.Context) error {
 		{Keys: bson.D{{Key: "create_time", Value: -1}}},
 		{Keys: bson.D{{Key: "update_time", Value: -1}}},
 		{Keys: bson.D{{Key: "repository.codehost_id", Value: 1}}},
+		{Keys: bson.D{{Key: "scorecard.score", Value: -1}}},
 	}
 	_, err := c.Indexes().CreateMany(ctx, idxes, mongotool.CreateIndexOptions(ctx))
 	return err
@@ -175,6 +176,12 @@ func (c *ApplicationColl) GetByID(ctx context.Context, id string) (*commonmodels
 	return res, err
 }
 
+func (c *ApplicationColl) GetByKey(ctx context.Context, key string) (*commonmodels.Application, error) {
+	res := new(commonmodels.Application)
+	err := c.FindOne(ctx, bson.M{"key": key}).Decode(res)
+	return res, err
+}
+
 func (c *ApplicationColl) UpdateByID(ctx context.Context, id string, app *commonmodels.Application) error {
 	oid, err := primitive.ObjectIDFromHex(id)
 	if err != nil {
@@ -188,6 +195,12 @@ func (c *ApplicationColl) UpdateByID(ctx context.Context, id string, app *common
 	return err
 }
 
+// UpdateScorecard replaces the scorecard of the application without touching its update time.
+func (c *ApplicationColl) UpdateScorecard(ctx context.Context, id primitive.ObjectID, scorecard *commonmodels.ApplicationScorecard) error {
+	_, err := c.UpdateOne(ctx, bson.M{"_id": id}, bson.M{"$set": bson.M{"scorecard": scorecard}})
+	return err
+}
+
 func (c *ApplicationColl) DeleteByID(ctx context.Context, id string) error {
 	oid, err := primitive.ObjectIDFromHex(id)
 	if err != nil {
@@ -0,0 +1,117 @@
+/*
+Copyright 2025 The KodeRover Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package mongodb
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+
+	"github.com/koderover/zadig/v2/pkg/microservice/aslan/config"
+	commonmodels "github.com/koderover/zadig/v2/pkg/microservice/aslan/core/common/repository/models"
+	mongotool "github.com/koderover/zadig/v2/pkg/tool/mongo"
+)
+
+type ApplicationScorecardRuleColl struct {
+	*mongo.Collection
+	coll string
+}
+
+func NewApplicationScorecardRuleColl() *ApplicationScorecardRuleColl {
+	name := commonmodels.ApplicationScorecardRule{}.TableName()
+	return &ApplicationScorecardRuleColl{Collection: mongotool.Database(config.MongoDatabase()).Collection(name), coll: name}
+}
+
+func (c *ApplicationScorecardRuleColl) GetCollectionName() string { return c.coll }
+
+func (c *ApplicationScorecardRuleColl) EnsureIndex(ctx context.Context) error {
+	_, err := c.Indexes().CreateMany(ctx, []mongo.IndexModel{{Keys: bson.D{{Key: "key", Value: 1}}, Options: options.Index().SetUnique(true)}}, mongotool.CreateIndexOptions(ctx))
+	return err
+}
+
+func (c *ApplicationScorecardRuleColl) Create(ctx context.Context, rule *commonmodels.ApplicationScorecardRule) (primitive.ObjectID, error) {
+	if rule == nil {
+		return primitive.NilObjectID, errors.New("nil scorecard rule")
+	}
+	now := time.Now().Unix()
+	rule.CreateTime = now
+	rule.UpdateTime = now
+	res, err := c.InsertOne(ctx, rule)
+	if err != nil {
+		return primitive.NilObjectID, err
+	}
+	oid, ok := res.InsertedID.(primitive.ObjectID)
+	if !ok {
+		return primitive.NilObjectID, fmt.Errorf("unexpected inserted id type")
+	}
+	return oid, nil
+}
+
+func (c *ApplicationScorecardRuleColl) GetByID(ctx context.Context, id string) (*commonmodels.ApplicationScorecardRule, error) {
+	oid, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return nil, err
+	}
+	res := new(commonmodels.ApplicationScorecardRule)
+	err = c.FindOne(ctx, bson.M{"_id": oid}).Decode(res)
+	return res, err
+}
+
+// List returns the scorecard rules ordered by key, only the enabled ones if onlyEnabled is set.
+func (c *ApplicationScorecardRuleColl) List(ctx context.Context, onlyEnabled bool) ([]*commonmodels.ApplicationScorecardRule, error) {
+	query := bson.M{}
+	if onlyEnabled {
+		query["enabled"] = true
+	}
+	cur, err := c.Find(ctx, query, options.Find().SetSort(bson.D{{Key: "key", Value: 1}}))
+	if err != nil {
+		return nil, err
+	}
+	rules := make([]*commonmodels.ApplicationScorecardRule, 0)
+	if err := cur.All(ctx, &rules); err != nil {
+		return nil, err
+	}
+	return rules, nil
+}
+
+func (c *ApplicationScorecardRuleColl) UpdateByID(ctx context.Context, id string, rule *commonmodels.ApplicationScorecardRule) error {
+	oid, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return err
+	}
+	if rule == nil {
+		return errors.New("nil scorecard rule")
+	}
+	rule.UpdateTime = time.Now().Unix()
+	_, err = c.UpdateOne(ctx, bson.M{"_id": oid}, bson.M{"$set": rule})
+	return err
+}
+
+func (c *ApplicationScorecardRuleColl) DeleteByID(ctx context.Context, id string) error {
+	oid, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return err
+	}
+	_, err = c.DeleteOne(ctx, bson.M{"_id": oid})
+	return err
+}
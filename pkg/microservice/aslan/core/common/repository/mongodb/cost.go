@@ -0,0 +1,315 @@
+/*
+Copyright 2025 The KodeRover Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package mongodb
+
+import (
+	"context"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+
+	"github.com/koderover/zadig/v2/pkg/microservice/aslan/config"
+	"github.com/koderover/zadig/v2/pkg/microservice/aslan/core/common/repository/models"
+	mongotool "github.com/koderover/zadig/v2/pkg/tool/mongo"
+)
+
+type CostPricingColl struct {
+	*mongo.Collection
+
+	coll string
+}
+
+func NewCostPricingColl() *CostPricingColl {
+	name := models.CostPricing{}.TableName()
+	return &CostPricingColl{Collection: mongotool.Database(config.MongoDatabase()).Collection(name), coll: name}
+}
+
+func (c *CostPricingColl) GetCollectionName() string {
+	return c.coll
+}
+
+func (c *CostPricingColl) EnsureIndex(_ context.Context) error {
+	return nil
+}
+
+// Get returns the cost pricing, it returns nil if it is not configured yet
+func (c *CostPricingColl) Get() (*models.CostPricing, error) {
+	resp := new(models.CostPricing)
+	err := c.FindOne(context.TODO(), bson.M{}).Decode(resp)
+	if err == mongo.ErrNoDocuments {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+func (c *CostPricingColl) Upsert(args *models.CostPricing) error {
+	args.UpdateTime = time.Now().Unix()
+	_, err := c.ReplaceOne(context.TODO(), bson.M{}, args, options.Replace().SetUpsert(true))
+	return err
+}
+
+type DailyCostColl struct {
+	*mongo.Collection
+
+	coll string
+}
+
+func NewDailyCostColl() *DailyCostColl {
+	name := models.DailyCost{}.TableName()
+	return &DailyCostColl{Collection: mongotool.Database(config.MongoDatabase()).Collection(name), coll: name}
+}
+
+func (c *DailyCostColl) GetCollectionName() string {
+	return c.coll
+}
+
+func (c *DailyCostColl) EnsureIndex(ctx context.Context) error {
+	mod := []mongo.IndexModel{
+		{
+			Keys: bson.D{
+				bson.E{Key: "date", Value: 1},
+				bson.E{Key: "category", Value: 1},
+				bson.E{Key: "project_key", Value: 1},
+				bson.E{Key: "env_name", Value: 1},
+				bson.E{Key: "service_name", Value: 1},
+				bson.E{Key: "config_name", Value: 1},
+				bson.E{Key: "cluster_id", Value: 1},
+			},
+			Options: options.Index().SetUnique(true),
+		},
+		{
+			Keys: bson.D{
+				bson.E{Key: "project_key", Value: 1},
+				bson.E{Key: "date", Value: 1},
+			},
+			Options: options.Index().SetUnique(false),
+		},
+	}
+
+	_, err := c.Indexes().CreateMany(ctx, mod, mongotool.CreateIndexOptions(ctx))
+	return err
+}
+
+// Inc adds the resource amounts and costs to the daily cost of the same date, category, project, env, service,
+// build/testing and cluster
+func (c *DailyCostColl) Inc(args *models.DailyCost) error {
+	query := bson.M{
+		"date":         args.Date,
+		"category":     args.Category,
+		"project_key":  args.ProjectKey,
+		"env_name":     args.EnvName,
+		"service_name": args.ServiceName,
+		"config_name":  args.ConfigName,
+		"cluster_id":   args.ClusterID,
+	}
+	change := bson.M{
+		"$set": bson.M{
+			"production":  args.Production,
+			"update_time": time.Now().Unix(),
+		},
+		"$inc": bson.M{
+			"cpu_core_hours":      args.CPUCoreHours,
+			"memory_gb_hours":     args.MemoryGBHours,
+			"storage_gb_hours":    args.StorageGBHours,
+			"load_balancer_hours": args.LoadBalancerHours,
+			"cpu_cost":            args.CPUCost,
+			"memory_cost":         args.MemoryCost,
+			"storage_cost":        args.StorageCost,
+			"load_balancer_cost":  args.LoadBalancerCost,
+			"total_cost":          args.TotalCost,
+		},
+	}
+	_, err := c.UpdateOne(context.TODO(), query, change, options.Update().SetUpsert(true))
+	return err
+}
+
+type DailyCostListOption struct {
+	// StartDate and EndDate are inclusive, in the format of 2006-01-02
+	StartDate string
+	EndDate   string
+	Projects  []string
+	EnvName   string
+}
+
+func (c *DailyCostColl) List(opt *DailyCostListOption) ([]*models.DailyCost, error) {
+	query := bson.M{}
+	dateQuery := bson.M{}
+	if opt.StartDate != "" {
+		dateQuery["$gte"] = opt.StartDate
+	}
+	if opt.EndDate != "" {
+		dateQuery["$lte"] = opt.EndDate
+	}
+	if len(dateQuery) > 0 {
+		query["date"] = dateQuery
+	}
+	if len(opt.Projects) > 0 {
+		query["project_key"] = bson.M{"$in": opt.Projects}
+	}
+	if opt.EnvName != "" {
+		query["env_name"] = opt.EnvName
+	}
+
+	resp := make([]*models.DailyCost, 0)
+	cursor, err := c.Collection.Find(context.TODO(), query, options.Find().SetSort(bson.D{{Key: "date", Value: 1}}))
+	if err != nil {
+		return nil, err
+	}
+	if err := cursor.All(context.TODO(), &resp); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+// SumTotalCost returns the total cost of the project, or the env of it if envName is not empty, since the start date
+func (c *DailyCostColl) SumTotalCost(startDate, projectKey, envName string) (float64, error) {
+	match := bson.M{
+		"date":        bson.M{"$gte": startDate},
+		"project_key": projectKey,
+	}
+	if envName != "" {
+		match["env_name"] = envName
+	}
+	pipeline := []bson.M{
+		{"$match": match},
+		{"$group": bson.M{"_id": nil, "total": bson.M{"$sum": "$total_cost"}}},
+	}
+
+	cursor, err := c.Aggregate(context.TODO(), pipeline)
+	if err != nil {
+		return 0, err
+	}
+	var res []struct {
+		Total float64 `bson:"total"`
+	}
+	if err := cursor.All(context.TODO(), &res); err != nil {
+		return 0, err
+	}
+	if len(res) == 0 {
+		return 0, nil
+	}
+	return res[0].Total, nil
+}
+
+type CostBudgetColl struct {
+	*mongo.Collection
+
+	coll string
+}
+
+func NewCostBudgetColl() *CostBudgetColl {
+	name := models.CostBudget{}.TableName()
+	return &CostBudgetColl{Collection: mongotool.Database(config.MongoDatabase()).Collection(name), coll: name}
+}
+
+func (c *CostBudgetColl) GetCollectionName() string {
+	return c.coll
+}
+
+func (c *CostBudgetColl) EnsureIndex(ctx context.Context) error {
+	mod := mongo.IndexModel{
+		Keys: bson.D{
+			bson.E{Key: "project_key", Value: 1},
+			bson.E{Key: "env_name", Value: 1},
+		},
+		Options: options.Index().SetUnique(true),
+	}
+
+	_, err := c.Indexes().CreateOne(ctx, mod, mongotool.CreateIndexOptions(ctx))
+	return err
+}
+
+func (c *CostBudgetColl) Create(args *models.CostBudget) error {
+	args.CreateTime = time.Now().Unix()
+	args.UpdateTime = time.Now().Unix()
+	_, err := c.InsertOne(context.TODO(), args)
+	return err
+}
+
+func (c *CostBudgetColl) GetByID(id string) (*models.CostBudget, error) {
+	oid, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return nil, err
+	}
+
+	resp := new(models.CostBudget)
+	if err := c.FindOne(context.TODO(), bson.M{"_id": oid}).Decode(resp); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+// List returns the budgets of the projects, all the budgets are returned if no project is given
+func (c *CostBudgetColl) List(projects []string) ([]*models.CostBudget, error) {
+	query := bson.M{}
+	if len(projects) > 0 {
+		query["project_key"] = bson.M{"$in": projects}
+	}
+
+	resp := make([]*models.CostBudget, 0)
+	cursor, err := c.Collection.Find(context.TODO(), query, options.Find().SetSort(bson.D{{Key: "project_key", Value: 1}, {Key: "env_name", Value: 1}}))
+	if err != nil {
+		return nil, err
+	}
+	if err := cursor.All(context.TODO(), &resp); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+func (c *CostBudgetColl) Update(id string, args *models.CostBudget) error {
+	oid, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return err
+	}
+
+	change := bson.M{"$set": bson.M{
+		"name":        args.Name,
+		"amount":      args.Amount,
+		"thresholds":  args.Thresholds,
+		"notify_ctls": args.NotifyCtls,
+		"update_time": time.Now().Unix(),
+	}}
+	_, err = c.UpdateOne(context.TODO(), bson.M{"_id": oid}, change)
+	return err
+}
+
+// UpdateAlert saves the highest threshold alerted in the month
+func (c *CostBudgetColl) UpdateAlert(id primitive.ObjectID, month string, threshold int) error {
+	change := bson.M{"$set": bson.M{
+		"alert_month":     month,
+		"alert_threshold": threshold,
+	}}
+	_, err := c.UpdateOne(context.TODO(), bson.M{"_id": id}, change)
+	return err
+}
+
+func (c *CostBudgetColl) DeleteByID(id string) error {
+	oid, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return err
+	}
+
+	_, err = c.DeleteOne(context.TODO(), bson.M{"_id": oid})
+	return err
+}
@@ -20,8 +20,10 @@ import (
 	"context"
 	"errors"
 	"strings"
+	"time"
 
 	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
 	"go.mongodb.org/mongo-driver/mongo"
 	"go.mongodb.org/mongo-driver/mongo/options"
 
@@ -125,3 +127,20 @@ func (c *CustomWorkflowTestReportColl) ListByWorkflowJobTaskName(workflowName, j
 	err = cursor.All(context.TODO(), &result)
 	return result, err
 }
+
+// ListByService returns the test reports of the service created after startTime.
+func (c *CustomWorkflowTestReportColl) ListByService(projectName, serviceName string, startTime int64) ([]*models.CustomWorkflowTestReport, error) {
+	resp := make([]*models.CustomWorkflowTestReport, 0)
+	query := bson.M{
+		"_id":                bson.M{"$gte": primitive.NewObjectIDFromTimestamp(time.Unix(startTime, 0))},
+		"zadig_test_project": projectName,
+		"service_name":       serviceName,
+	}
+
+	cursor, err := c.Collection.Find(context.TODO(), query, options.Find().SetProjection(bson.M{"test_cases": 0}))
+	if err != nil {
+		return nil, err
+	}
+	err = cursor.All(context.TODO(), &resp)
+	return resp, err
+}
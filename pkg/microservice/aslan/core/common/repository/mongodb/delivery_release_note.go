@@ -0,0 +1,101 @@
+/*
+Copyright 2025 The KodeRover Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package mongodb
+
+import (
+	"context"
+	"errors"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+
+	"github.com/koderover/zadig/v2/pkg/microservice/aslan/config"
+	"github.com/koderover/zadig/v2/pkg/microservice/aslan/core/common/repository/models"
+	mongotool "github.com/koderover/zadig/v2/pkg/tool/mongo"
+)
+
+type DeliveryReleaseNoteColl struct {
+	*mongo.Collection
+
+	coll string
+}
+
+func NewDeliveryReleaseNoteColl() *DeliveryReleaseNoteColl {
+	name := models.DeliveryReleaseNote{}.TableName()
+	return &DeliveryReleaseNoteColl{
+		Collection: mongotool.Database(config.MongoDatabase()).Collection(name),
+		coll:       name,
+	}
+}
+
+func (c *DeliveryReleaseNoteColl) GetCollectionName() string {
+	return c.coll
+}
+
+func (c *DeliveryReleaseNoteColl) EnsureIndex(ctx context.Context) error {
+	mod := []mongo.IndexModel{
+		{
+			Keys: bson.D{
+				bson.E{Key: "delivery_version_id", Value: 1},
+			},
+			Options: options.Index().SetUnique(true),
+		},
+		{
+			Keys: bson.D{
+				bson.E{Key: "project_name", Value: 1},
+				bson.E{Key: "version", Value: 1},
+			},
+			Options: options.Index().SetUnique(false),
+		},
+	}
+
+	_, err := c.Indexes().CreateMany(ctx, mod, mongotool.CreateIndexOptions(ctx))
+	return err
+}
+
+func (c *DeliveryReleaseNoteColl) GetByDeliveryVersionID(deliveryVersionID string) (*models.DeliveryReleaseNote, error) {
+	if deliveryVersionID == "" {
+		return nil, errors.New("empty delivery version id")
+	}
+
+	resp := new(models.DeliveryReleaseNote)
+	query := bson.M{"delivery_version_id": deliveryVersionID}
+	err := c.FindOne(context.TODO(), query).Decode(resp)
+	if err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+// Upsert replaces the release note of the delivery version, there is at most one release note per delivery version.
+func (c *DeliveryReleaseNoteColl) Upsert(args *models.DeliveryReleaseNote) error {
+	if args == nil || args.DeliveryVersionID == "" {
+		return errors.New("nil delivery release note")
+	}
+
+	query := bson.M{"delivery_version_id": args.DeliveryVersionID}
+	opts := options.Replace().SetUpsert(true)
+	_, err := c.ReplaceOne(context.TODO(), query, args, opts)
+	return err
+}
+
+func (c *DeliveryReleaseNoteColl) DeleteByDeliveryVersionID(deliveryVersionID string) error {
+	query := bson.M{"delivery_version_id": deliveryVersionID}
+	_, err := c.DeleteMany(context.TODO(), query)
+	return err
+}
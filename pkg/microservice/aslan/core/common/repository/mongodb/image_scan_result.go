@@ -0,0 +1,127 @@
+/*
+Copyright 2025 The KodeRover Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package mongodb
+
+import (
+	"context"
+	"errors"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+
+	"github.com/koderover/zadig/v2/pkg/microservice/aslan/config"
+	"github.com/koderover/zadig/v2/pkg/microservice/aslan/core/common/repository/models"
+	mongotool "github.com/koderover/zadig/v2/pkg/tool/mongo"
+)
+
+type ImageScanResultColl struct {
+	*mongo.Collection
+
+	coll string
+}
+
+func NewImageScanResultColl() *ImageScanResultColl {
+	name := models.ImageScanResult{}.TableName()
+	return &ImageScanResultColl{Collection: mongotool.Database(config.MongoDatabase()).Collection(name), coll: name}
+}
+
+func (c *ImageScanResultColl) GetCollectionName() string {
+	return c.coll
+}
+
+func (c *ImageScanResultColl) EnsureIndex(ctx context.Context) error {
+	mod := []mongo.IndexModel{
+		{
+			Keys: bson.D{
+				bson.E{Key: "image", Value: 1},
+				bson.E{Key: "create_time", Value: -1},
+			},
+			Options: options.Index().SetUnique(false).SetName("image_index"),
+		},
+		{
+			Keys: bson.D{
+				bson.E{Key: "digest", Value: 1},
+				bson.E{Key: "create_time", Value: -1},
+			},
+			Options: options.Index().SetUnique(false).SetName("digest_index"),
+		},
+		{
+			Keys: bson.D{
+				bson.E{Key: "workflow_name", Value: 1},
+				bson.E{Key: "task_id", Value: 1},
+			},
+			Options: options.Index().SetUnique(false).SetName("task_index"),
+		},
+	}
+
+	_, err := c.Indexes().CreateMany(ctx, mod, mongotool.CreateIndexOptions(ctx))
+
+	return err
+}
+
+func (c *ImageScanResultColl) Create(args *models.ImageScanResult) error {
+	if args == nil {
+		return errors.New("nil image scan result")
+	}
+
+	_, err := c.InsertOne(context.TODO(), args)
+	return err
+}
+
+// FindLatestByImage returns the most recent scan result of the image, nil if the image has never been scanned.
+func (c *ImageScanResultColl) FindLatestByImage(image string) (*models.ImageScanResult, error) {
+	return c.findLatest(bson.M{"image": image})
+}
+
+// FindLatestByDigest returns the most recent scan result of the image digest, nil if the digest has never been scanned.
+func (c *ImageScanResultColl) FindLatestByDigest(digest string) (*models.ImageScanResult, error) {
+	return c.findLatest(bson.M{"digest": digest})
+}
+
+// FindLatestByService returns the most recent scan result of the service images, nil if none of them has been scanned.
+func (c *ImageScanResultColl) FindLatestByService(projectName, serviceName string) (*models.ImageScanResult, error) {
+	return c.findLatest(bson.M{"project_name": projectName, "service_name": serviceName})
+}
+
+func (c *ImageScanResultColl) findLatest(query bson.M) (*models.ImageScanResult, error) {
+	resp := new(models.ImageScanResult)
+	opts := options.FindOne().SetSort(bson.D{bson.E{Key: "create_time", Value: -1}})
+	err := c.FindOne(context.TODO(), query, opts).Decode(resp)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return resp, nil
+}
+
+func (c *ImageScanResultColl) ListByTask(workflowName string, taskID int64) ([]*models.ImageScanResult, error) {
+	resp := make([]*models.ImageScanResult, 0)
+	query := bson.M{
+		"workflow_name": workflowName,
+		"task_id":       taskID,
+	}
+
+	cursor, err := c.Find(context.TODO(), query)
+	if err != nil {
+		return nil, err
+	}
+	err = cursor.All(context.TODO(), &resp)
+	return resp, err
+}
@@ -252,6 +252,26 @@ func (c *JobInfoColl) GetDeployJobs(startTime, endTime int64, projectNames []str
 	return resp, err
 }
 
+// CountServiceDeployJobs counts the passed deploy jobs of the service started after startTime.
+func (c *JobInfoColl) CountServiceDeployJobs(startTime int64, projectName, serviceName string, production bool) (int64, error) {
+	query := bson.M{
+		"start_time":   bson.M{"$gte": startTime},
+		"product_name": projectName,
+		"service_name": serviceName,
+		"production":   production,
+		"status":       string(config.StatusPassed),
+		"type": bson.M{"$in": []string{
+			string(config.JobZadigDeploy),
+			string(config.JobZadigHelmDeploy),
+			string(config.JobZadigHelmChartDeploy),
+			string(config.JobDeploy),
+			string(config.JobZadigVMDeploy),
+			string(config.JobCustomDeploy),
+		}},
+	}
+	return c.CountDocuments(context.Background(), query)
+}
+
 func (c *JobInfoColl) GetDeployJobsStats(startTime, endTime int64, projectNames []string, productionType config.ProductionType) ([]*models.ServiceDeployCountWithStatus, error) {
 	query := bson.M{}
 	if startTime > 0 && endTime > 0 {
@@ -0,0 +1,92 @@
+/*
+Copyright 2025 The KodeRover Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package mongodb
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+
+	"github.com/koderover/zadig/v2/pkg/microservice/aslan/config"
+	"github.com/koderover/zadig/v2/pkg/microservice/aslan/core/common/repository/models"
+	mongotool "github.com/koderover/zadig/v2/pkg/tool/mongo"
+)
+
+type JobResourceUsageColl struct {
+	*mongo.Collection
+
+	coll string
+}
+
+func NewJobResourceUsageColl() *JobResourceUsageColl {
+	name := models.JobResourceUsage{}.TableName()
+	return &JobResourceUsageColl{
+		Collection: mongotool.Database(config.MongoDatabase()).Collection(name),
+		coll:       name,
+	}
+}
+
+func (c *JobResourceUsageColl) GetCollectionName() string {
+	return c.coll
+}
+
+func (c *JobResourceUsageColl) EnsureIndex(ctx context.Context) error {
+	mod := mongo.IndexModel{
+		Keys: bson.D{
+			bson.E{Key: "project_name", Value: 1},
+			bson.E{Key: "config_type", Value: 1},
+			bson.E{Key: "config_name", Value: 1},
+			bson.E{Key: "create_time", Value: -1},
+		},
+		Options: options.Index().SetUnique(false),
+	}
+	_, err := c.Indexes().CreateOne(ctx, mod, mongotool.CreateIndexOptions(ctx))
+	return err
+}
+
+func (c *JobResourceUsageColl) Create(args *models.JobResourceUsage) error {
+	if args == nil {
+		return fmt.Errorf("nil job resource usage")
+	}
+
+	args.ID = primitive.NewObjectID()
+	args.CreateTime = time.Now().Unix()
+	_, err := c.InsertOne(context.TODO(), args)
+	return err
+}
+
+// ListRecent lists the usages of the latest runs of the build or testing
+func (c *JobResourceUsageColl) ListRecent(projectName, configType, configName string, limit int64) ([]*models.JobResourceUsage, error) {
+	query := bson.M{
+		"project_name": projectName,
+		"config_type":  configType,
+		"config_name":  configName,
+	}
+	opts := options.Find().SetSort(bson.D{{Key: "create_time", Value: -1}}).SetLimit(limit)
+
+	resp := make([]*models.JobResourceUsage, 0)
+	cursor, err := c.Collection.Find(context.TODO(), query, opts)
+	if err != nil {
+		return nil, err
+	}
+	return resp, cursor.All(context.TODO(), &resp)
+}
@@ -220,14 +220,16 @@ func (c *KeyVaultItemColl) Update(id string, args *models.KeyVaultItem) error {
 
 	query := bson.M{"_id": oid}
 	change := bson.M{"$set": bson.M{
-		"group":           args.Group,
-		"key":             args.Key,
-		"value":           args.Value,
-		"is_sensitive":    args.IsSensitive,
-		"description":     args.Description,
-		"project_name":    args.ProjectName,
-		"updated_by":      args.UpdatedBy,
-		"updated_at":      time.Now().Unix(),
+		"group":        args.Group,
+		"key":          args.Key,
+		"value":        args.Value,
+		"is_sensitive": args.IsSensitive,
+		"description":  args.Description,
+		"provider_id":  args.ProviderID,
+		"reference":    args.Reference,
+		"project_name": args.ProjectName,
+		"updated_by":   args.UpdatedBy,
+		"updated_at":   time.Now().Unix(),
 	}}
 
 	_, err = c.UpdateOne(context.TODO(), query, change)
@@ -264,3 +266,7 @@ func (c *KeyVaultItemColl) DeleteByGroup(group string, deleteOpt *KeyVaultItemDe
 	return err
 }
 
+// CountByProvider returns the number of items read from the external provider
+func (c *KeyVaultItemColl) CountByProvider(providerID string) (int64, error) {
+	return c.CountDocuments(context.TODO(), bson.M{"provider_id": providerID})
+}
@@ -0,0 +1,119 @@
+/*
+Copyright 2025 The KodeRover Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package mongodb
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+
+	"github.com/koderover/zadig/v2/pkg/microservice/aslan/config"
+	"github.com/koderover/zadig/v2/pkg/microservice/aslan/core/common/repository/models"
+	mongotool "github.com/koderover/zadig/v2/pkg/tool/mongo"
+)
+
+type KeyVaultProviderColl struct {
+	*mongo.Collection
+
+	coll string
+}
+
+func NewKeyVaultProviderColl() *KeyVaultProviderColl {
+	name := models.KeyVaultProvider{}.TableName()
+	return &KeyVaultProviderColl{
+		Collection: mongotool.Database(config.MongoDatabase()).Collection(name),
+		coll:       name,
+	}
+}
+
+func (c *KeyVaultProviderColl) GetCollectionName() string {
+	return c.coll
+}
+
+func (c *KeyVaultProviderColl) EnsureIndex(ctx context.Context) error {
+	mod := mongo.IndexModel{
+		Keys:    bson.D{bson.E{Key: "name", Value: 1}},
+		Options: options.Index().SetUnique(true),
+	}
+	_, err := c.Indexes().CreateOne(ctx, mod, mongotool.CreateIndexOptions(ctx))
+	return err
+}
+
+func (c *KeyVaultProviderColl) List() ([]*models.KeyVaultProvider, error) {
+	resp := make([]*models.KeyVaultProvider, 0)
+	cursor, err := c.Collection.Find(context.Background(), bson.M{})
+	if err != nil {
+		return nil, err
+	}
+	return resp, cursor.All(context.Background(), &resp)
+}
+
+func (c *KeyVaultProviderColl) GetByID(idHex string) (*models.KeyVaultProvider, error) {
+	id, err := primitive.ObjectIDFromHex(idHex)
+	if err != nil {
+		return nil, err
+	}
+
+	resp := &models.KeyVaultProvider{}
+	err = c.FindOne(context.Background(), bson.M{"_id": id}).Decode(resp)
+	return resp, err
+}
+
+func (c *KeyVaultProviderColl) Create(args *models.KeyVaultProvider) error {
+	if args == nil {
+		return fmt.Errorf("nil keyvault provider")
+	}
+
+	args.UpdatedAt = time.Now().Unix()
+	_, err := c.InsertOne(context.TODO(), args)
+	return err
+}
+
+func (c *KeyVaultProviderColl) Update(idHex string, args *models.KeyVaultProvider) error {
+	id, err := primitive.ObjectIDFromHex(idHex)
+	if err != nil {
+		return err
+	}
+
+	args.UpdatedAt = time.Now().Unix()
+	change := bson.M{"$set": bson.M{
+		"name":       args.Name,
+		"type":       args.Type,
+		"vault":      args.Vault,
+		"aws":        args.AWS,
+		"kubernetes": args.Kubernetes,
+		"updated_by": args.UpdatedBy,
+		"updated_at": args.UpdatedAt,
+	}}
+	_, err = c.UpdateOne(context.TODO(), bson.M{"_id": id}, change)
+	return err
+}
+
+func (c *KeyVaultProviderColl) Delete(idHex string) error {
+	id, err := primitive.ObjectIDFromHex(idHex)
+	if err != nil {
+		return err
+	}
+
+	_, err = c.DeleteOne(context.TODO(), bson.M{"_id": id})
+	return err
+}
@@ -0,0 +1,210 @@
+/*
+Copyright 2025 The KodeRover Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package mongodb
+
+import (
+	"context"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+
+	"github.com/koderover/zadig/v2/pkg/microservice/aslan/config"
+	"github.com/koderover/zadig/v2/pkg/microservice/aslan/core/common/repository/models"
+	mongotool "github.com/koderover/zadig/v2/pkg/tool/mongo"
+)
+
+type MockServiceColl struct {
+	*mongo.Collection
+
+	coll string
+}
+
+func NewMockServiceColl() *MockServiceColl {
+	name := models.MockService{}.TableName()
+	return &MockServiceColl{Collection: mongotool.Database(config.MongoDatabase()).Collection(name), coll: name}
+}
+
+func (c *MockServiceColl) GetCollectionName() string {
+	return c.coll
+}
+
+func (c *MockServiceColl) EnsureIndex(ctx context.Context) error {
+	mod := mongo.IndexModel{
+		Keys: bson.D{
+			bson.E{Key: "product_name", Value: 1},
+			bson.E{Key: "service_name", Value: 1},
+			bson.E{Key: "revision", Value: 1},
+		},
+		Options: options.Index().SetUnique(true),
+	}
+
+	_, err := c.Indexes().CreateOne(ctx, mod, mongotool.CreateIndexOptions(ctx))
+	return err
+}
+
+func (c *MockServiceColl) Create(args *models.MockService) error {
+	args.CreateTime = time.Now().Unix()
+	_, err := c.InsertOne(context.TODO(), args)
+	return err
+}
+
+// Find returns the given revision of the mock, the latest revision is returned if the revision is 0
+func (c *MockServiceColl) Find(productName, serviceName string, revision int64) (*models.MockService, error) {
+	query := bson.M{"product_name": productName, "service_name": serviceName}
+	opts := options.FindOne()
+	if revision > 0 {
+		query["revision"] = revision
+	} else {
+		opts.SetSort(bson.D{{Key: "revision", Value: -1}})
+	}
+
+	resp := new(models.MockService)
+	if err := c.FindOne(context.TODO(), query, opts).Decode(resp); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+// ListRevisions returns the revisions of the mock, the latest first
+func (c *MockServiceColl) ListRevisions(productName, serviceName string) ([]*models.MockService, error) {
+	query := bson.M{"product_name": productName, "service_name": serviceName}
+	opts := options.Find().SetSort(bson.D{{Key: "revision", Value: -1}})
+
+	resp := make([]*models.MockService, 0)
+	cursor, err := c.Collection.Find(context.TODO(), query, opts)
+	if err != nil {
+		return nil, err
+	}
+	if err := cursor.All(context.TODO(), &resp); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+// ListLatest returns the latest revisions of the mocks in the project
+func (c *MockServiceColl) ListLatest(productName string) ([]*models.MockService, error) {
+	pipeline := []bson.M{
+		{"$match": bson.M{"product_name": productName}},
+		{"$sort": bson.M{"revision": -1}},
+		{"$group": bson.M{"_id": "$service_name", "mock": bson.M{"$first": "$$ROOT"}}},
+		{"$replaceRoot": bson.M{"newRoot": "$mock"}},
+		{"$sort": bson.M{"service_name": 1}},
+	}
+
+	resp := make([]*models.MockService, 0)
+	cursor, err := c.Aggregate(context.TODO(), pipeline)
+	if err != nil {
+		return nil, err
+	}
+	if err := cursor.All(context.TODO(), &resp); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+func (c *MockServiceColl) Delete(productName, serviceName string) error {
+	query := bson.M{"product_name": productName, "service_name": serviceName}
+	_, err := c.DeleteMany(context.TODO(), query)
+	return err
+}
+
+type EnvMockColl struct {
+	*mongo.Collection
+
+	coll string
+}
+
+func NewEnvMockColl() *EnvMockColl {
+	name := models.EnvMock{}.TableName()
+	return &EnvMockColl{Collection: mongotool.Database(config.MongoDatabase()).Collection(name), coll: name}
+}
+
+func (c *EnvMockColl) GetCollectionName() string {
+	return c.coll
+}
+
+func (c *EnvMockColl) EnsureIndex(ctx context.Context) error {
+	mod := mongo.IndexModel{
+		Keys: bson.D{
+			bson.E{Key: "product_name", Value: 1},
+			bson.E{Key: "env_name", Value: 1},
+			bson.E{Key: "service_name", Value: 1},
+		},
+		Options: options.Index().SetUnique(true),
+	}
+
+	_, err := c.Indexes().CreateOne(ctx, mod, mongotool.CreateIndexOptions(ctx))
+	return err
+}
+
+func (c *EnvMockColl) List(productName, envName string) ([]*models.EnvMock, error) {
+	return c.list(bson.M{"product_name": productName, "env_name": envName})
+}
+
+// ListByService returns the environments the mock of the service is deployed to
+func (c *EnvMockColl) ListByService(productName, serviceName string) ([]*models.EnvMock, error) {
+	return c.list(bson.M{"product_name": productName, "service_name": serviceName})
+}
+
+func (c *EnvMockColl) list(query bson.M) ([]*models.EnvMock, error) {
+	resp := make([]*models.EnvMock, 0)
+	cursor, err := c.Collection.Find(context.TODO(), query, options.Find().SetSort(bson.D{{Key: "service_name", Value: 1}}))
+	if err != nil {
+		return nil, err
+	}
+	if err := cursor.All(context.TODO(), &resp); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+func (c *EnvMockColl) Find(productName, envName, serviceName string) (*models.EnvMock, error) {
+	query := bson.M{"product_name": productName, "env_name": envName, "service_name": serviceName}
+
+	resp := new(models.EnvMock)
+	if err := c.FindOne(context.TODO(), query).Decode(resp); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+func (c *EnvMockColl) Upsert(args *models.EnvMock) error {
+	args.UpdateTime = time.Now().Unix()
+
+	query := bson.M{"product_name": args.ProductName, "env_name": args.EnvName, "service_name": args.ServiceName}
+	change := bson.M{"$set": bson.M{
+		"revision":    args.Revision,
+		"update_by":   args.UpdateBy,
+		"update_time": args.UpdateTime,
+	}}
+	_, err := c.UpdateOne(context.TODO(), query, change, options.Update().SetUpsert(true))
+	return err
+}
+
+func (c *EnvMockColl) Delete(productName, envName, serviceName string) error {
+	query := bson.M{"product_name": productName, "env_name": envName, "service_name": serviceName}
+	_, err := c.DeleteOne(context.TODO(), query)
+	return err
+}
+
+func (c *EnvMockColl) DeleteByEnv(productName, envName string) error {
+	query := bson.M{"product_name": productName, "env_name": envName}
+	_, err := c.DeleteMany(context.TODO(), query)
+	return err
+}
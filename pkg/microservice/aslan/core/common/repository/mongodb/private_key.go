@@ -35,6 +35,7 @@ type PrivateKeyArgs struct {
 	Name        string
 	ProjectName string
 	SystemOnly  bool
+	PoolName    string
 }
 
 type PrivateKeyColl struct {
@@ -118,6 +119,9 @@ func (c *PrivateKeyColl) List(args *PrivateKeyArgs) ([]*models.PrivateKey, error
 	if args.SystemOnly {
 		query["project_name"] = bson.M{"$exists": false}
 	}
+	if args.PoolName != "" {
+		query["pool_agent.pool_name"] = args.PoolName
+	}
 
 	resp := make([]*models.PrivateKey, 0)
 	ctx := context.Background()
@@ -181,6 +185,33 @@ func (c *PrivateKeyColl) Update(id string, args *models.PrivateKey) error {
 	return err
 }
 
+// UpdateStatusByID sets the status of the vm only, the fields updated by the agent are kept
+func (c *PrivateKeyColl) UpdateStatusByID(id, status string) error {
+	return c.updateFields(id, bson.M{"status": status, "update_time": time.Now().Unix()})
+}
+
+func (c *PrivateKeyColl) UpdatePoolAgentIdleSince(id string, idleSince int64) error {
+	return c.updateFields(id, bson.M{"pool_agent.idle_since": idleSince})
+}
+
+func (c *PrivateKeyColl) UpdatePoolAgentInstanceID(id, instanceID string) error {
+	return c.updateFields(id, bson.M{"pool_agent.instance_id": instanceID})
+}
+
+func (c *PrivateKeyColl) UpdatePoolAgentJobID(id, jobID string) error {
+	return c.updateFields(id, bson.M{"pool_agent.job_id": jobID})
+}
+
+func (c *PrivateKeyColl) updateFields(id string, fields bson.M) error {
+	oid, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return err
+	}
+
+	_, err = c.UpdateOne(context.TODO(), bson.M{"_id": oid}, bson.M{"$set": fields})
+	return err
+}
+
 func (c *PrivateKeyColl) Delete(id string) error {
 	oid, err := primitive.ObjectIDFromHex(id)
 	if err != nil {
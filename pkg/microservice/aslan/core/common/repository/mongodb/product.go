@@ -600,6 +600,17 @@ func (c *ProductColl) UpdateProductAlias(envName, productName, alias string) err
 	return err
 }
 
+func (c *ProductColl) UpdateImageTrustPolicy(envName, productName string, policy *models.ImageTrustPolicy) error {
+	query := bson.M{"env_name": envName, "product_name": productName}
+
+	change := bson.M{"$set": bson.M{
+		"image_trust_policy": policy,
+	}}
+	_, err := c.UpdateOne(context.TODO(), query, change)
+
+	return err
+}
+
 func (c *ProductColl) UpdateIsPublic(envName, productName string, isPublic bool) error {
 	query := bson.M{"env_name": envName, "product_name": productName}
 	change := bson.M{"$set": bson.M{
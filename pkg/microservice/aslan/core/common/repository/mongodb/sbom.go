@@ -0,0 +1,148 @@
+/*
+Copyright 2025 The KodeRover Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package mongodb
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"regexp"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+
+	"github.com/koderover/zadig/v2/pkg/microservice/aslan/config"
+	"github.com/koderover/zadig/v2/pkg/microservice/aslan/core/common/repository/models"
+	mongotool "github.com/koderover/zadig/v2/pkg/tool/mongo"
+)
+
+type SBOMColl struct {
+	*mongo.Collection
+
+	coll string
+}
+
+func NewSBOMColl() *SBOMColl {
+	name := models.SBOM{}.TableName()
+	return &SBOMColl{Collection: mongotool.Database(config.MongoDatabase()).Collection(name), coll: name}
+}
+
+func (c *SBOMColl) GetCollectionName() string {
+	return c.coll
+}
+
+func (c *SBOMColl) EnsureIndex(ctx context.Context) error {
+	mod := []mongo.IndexModel{
+		{
+			Keys: bson.D{
+				bson.E{Key: "image", Value: 1},
+				bson.E{Key: "create_time", Value: -1},
+			},
+			Options: options.Index().SetUnique(false).SetName("image_index"),
+		},
+		{
+			Keys: bson.D{
+				bson.E{Key: "digest", Value: 1},
+				bson.E{Key: "create_time", Value: -1},
+			},
+			Options: options.Index().SetUnique(false).SetName("digest_index"),
+		},
+		{
+			Keys: bson.D{
+				bson.E{Key: "components.name", Value: 1},
+				bson.E{Key: "components.version", Value: 1},
+			},
+			Options: options.Index().SetUnique(false).SetName("component_index"),
+		},
+	}
+
+	_, err := c.Indexes().CreateMany(ctx, mod, mongotool.CreateIndexOptions(ctx))
+
+	return err
+}
+
+func (c *SBOMColl) Create(args *models.SBOM) error {
+	if args == nil {
+		return errors.New("nil sbom")
+	}
+
+	_, err := c.InsertOne(context.TODO(), args)
+	return err
+}
+
+func (c *SBOMColl) GetByID(id string) (*models.SBOM, error) {
+	oid, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return nil, err
+	}
+	resp := new(models.SBOM)
+	err = c.FindOne(context.TODO(), bson.M{"_id": oid}).Decode(resp)
+	return resp, err
+}
+
+// FindLatestByImage returns the most recent sbom of the image without the component list, nil if there is none.
+func (c *SBOMColl) FindLatestByImage(image string) (*models.SBOM, error) {
+	return c.findLatest(bson.M{"image": image})
+}
+
+// FindLatestByDigest returns the most recent sbom of the image digest without the component list, nil if there is none.
+func (c *SBOMColl) FindLatestByDigest(digest string) (*models.SBOM, error) {
+	return c.findLatest(bson.M{"digest": digest})
+}
+
+func (c *SBOMColl) findLatest(query bson.M) (*models.SBOM, error) {
+	resp := new(models.SBOM)
+	opts := options.FindOne().
+		SetSort(bson.D{bson.E{Key: "create_time", Value: -1}}).
+		SetProjection(bson.M{"components": 0})
+	err := c.FindOne(context.TODO(), query, opts).Decode(resp)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return resp, nil
+}
+
+// ListByComponent returns the sboms that contain the component, the name matches the component name, the last
+// segment of a grouped name or the package name of the purl, case insensitive. An empty version matches all versions.
+func (c *SBOMColl) ListByComponent(name, version string) ([]*models.SBOM, error) {
+	if name == "" {
+		return nil, errors.New("empty component name")
+	}
+	quoted := regexp.QuoteMeta(name)
+	match := bson.M{
+		"$or": bson.A{
+			bson.M{"name": primitive.Regex{Pattern: fmt.Sprintf("^(.*/)?%s$", quoted), Options: "i"}},
+			bson.M{"purl": primitive.Regex{Pattern: fmt.Sprintf("/%s(@|$)", quoted), Options: "i"}},
+		},
+	}
+	if version != "" {
+		match["version"] = version
+	}
+
+	resp := make([]*models.SBOM, 0)
+	cursor, err := c.Find(context.TODO(), bson.M{"components": bson.M{"$elemMatch": match}})
+	if err != nil {
+		return nil, err
+	}
+	err = cursor.All(context.TODO(), &resp)
+	return resp, err
+}
@@ -0,0 +1,94 @@
+/*
+Copyright 2025 The KodeRover Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package mongodb
+
+import (
+	"context"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+
+	"github.com/koderover/zadig/v2/pkg/microservice/aslan/config"
+	"github.com/koderover/zadig/v2/pkg/microservice/aslan/core/common/repository/models"
+	mongotool "github.com/koderover/zadig/v2/pkg/tool/mongo"
+)
+
+type ServiceDependencyColl struct {
+	*mongo.Collection
+
+	coll string
+}
+
+func NewServiceDependencyColl() *ServiceDependencyColl {
+	name := models.ServiceDependency{}.TableName()
+	return &ServiceDependencyColl{Collection: mongotool.Database(config.MongoDatabase()).Collection(name), coll: name}
+}
+
+func (c *ServiceDependencyColl) GetCollectionName() string {
+	return c.coll
+}
+
+func (c *ServiceDependencyColl) EnsureIndex(ctx context.Context) error {
+	mod := mongo.IndexModel{
+		Keys: bson.D{
+			bson.E{Key: "product_name", Value: 1},
+			bson.E{Key: "service_name", Value: 1},
+			bson.E{Key: "production", Value: 1},
+		},
+		Options: options.Index().SetUnique(true),
+	}
+
+	_, err := c.Indexes().CreateOne(ctx, mod, mongotool.CreateIndexOptions(ctx))
+	return err
+}
+
+// List returns the declared dependencies of all the services in the project
+func (c *ServiceDependencyColl) List(productName string, production bool) ([]*models.ServiceDependency, error) {
+	query := bson.M{"product_name": productName, "production": production}
+
+	resp := make([]*models.ServiceDependency, 0)
+	cursor, err := c.Collection.Find(context.TODO(), query)
+	if err != nil {
+		return nil, err
+	}
+	if err := cursor.All(context.TODO(), &resp); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+// Upsert replaces the declared dependencies of the service
+func (c *ServiceDependencyColl) Upsert(args *models.ServiceDependency) error {
+	args.UpdateTime = time.Now().Unix()
+
+	query := bson.M{"product_name": args.ProductName, "service_name": args.ServiceName, "production": args.Production}
+	change := bson.M{"$set": bson.M{
+		"dependencies": args.Dependencies,
+		"update_by":    args.UpdateBy,
+		"update_time":  args.UpdateTime,
+	}}
+	_, err := c.UpdateOne(context.TODO(), query, change, options.Update().SetUpsert(true))
+	return err
+}
+
+func (c *ServiceDependencyColl) Delete(productName, serviceName string, production bool) error {
+	query := bson.M{"product_name": productName, "service_name": serviceName, "production": production}
+	_, err := c.DeleteOne(context.TODO(), query)
+	return err
+}
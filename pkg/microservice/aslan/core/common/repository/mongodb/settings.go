@@ -80,10 +80,10 @@ func (c *SystemSettingColl) UpdateConcurrencySetting(workflowConcurrency, buildC
 	return err
 }
 
-func (c *SystemSettingColl) UpdateSecuritySetting(tokenExpirationTime int64) error {
+func (c *SystemSettingColl) UpdateSecuritySetting(security *models.SecuritySettings) error {
 	id, _ := primitive.ObjectIDFromHex(setting.LocalClusterID)
 	change := bson.M{"$set": bson.M{
-		"security.token_expiration_time": tokenExpirationTime,
+		"security": security,
 	}}
 	query := bson.M{"_id": id}
 	_, err := c.UpdateOne(context.TODO(), query, change)
@@ -205,6 +205,16 @@ func (c *SystemSettingColl) UpdateReleasePlanHookSetting(hookSetting *models.Rel
 	return err
 }
 
+func (c *SystemSettingColl) UpdateAuditLogSetting(auditLog *models.AuditLogSettings) error {
+	id, _ := primitive.ObjectIDFromHex(setting.LocalClusterID)
+	query := bson.M{"_id": id}
+
+	change := bson.M{"$set": bson.M{"audit_log": auditLog}}
+
+	_, err := c.UpdateOne(context.TODO(), query, change)
+	return err
+}
+
 func (c *SystemSettingColl) GetReleasePlanHookSetting() (*models.ReleasePlanHookSettings, error) {
 	query := bson.M{}
 	resp := &models.SystemSetting{}
@@ -0,0 +1,116 @@
+/*
+Copyright 2025 The KodeRover Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package vm
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+
+	"github.com/koderover/zadig/v2/pkg/microservice/aslan/config"
+	"github.com/koderover/zadig/v2/pkg/microservice/aslan/core/common/repository/models/vm"
+	mongotool "github.com/koderover/zadig/v2/pkg/tool/mongo"
+)
+
+type AgentPoolColl struct {
+	*mongo.Collection
+
+	coll string
+}
+
+func NewAgentPoolColl() *AgentPoolColl {
+	name := vm.AgentPool{}.TableName()
+	return &AgentPoolColl{
+		Collection: mongotool.Database(config.MongoDatabase()).Collection(name),
+		coll:       name,
+	}
+}
+
+func (c *AgentPoolColl) GetCollectionName() string {
+	return c.coll
+}
+
+func (c *AgentPoolColl) EnsureIndex(ctx context.Context) error {
+	mod := mongo.IndexModel{
+		Keys:    bson.M{"name": 1},
+		Options: options.Index().SetUnique(true),
+	}
+
+	_, err := c.Indexes().CreateOne(ctx, mod, mongotool.CreateIndexOptions(ctx))
+	return err
+}
+
+func (c *AgentPoolColl) Create(obj *vm.AgentPool) error {
+	if obj == nil {
+		return errors.New("nil agent pool")
+	}
+
+	obj.CreateTime = time.Now().Unix()
+	obj.UpdateTime = time.Now().Unix()
+	result, err := c.InsertOne(context.Background(), obj)
+	if err != nil {
+		return err
+	}
+
+	insertedID, ok := result.InsertedID.(primitive.ObjectID)
+	if !ok {
+		return errors.New("Failed to convert inserted ID to ObjectID")
+	}
+	obj.ID = insertedID
+	return nil
+}
+
+func (c *AgentPoolColl) Update(name string, obj *vm.AgentPool) error {
+	if obj == nil {
+		return errors.New("nil agent pool")
+	}
+
+	obj.UpdateTime = time.Now().Unix()
+	query := bson.M{"name": name}
+	change := bson.M{"$set": obj}
+	_, err := c.UpdateOne(context.Background(), query, change)
+	return err
+}
+
+func (c *AgentPoolColl) Find(name string) (*vm.AgentPool, error) {
+	resp := new(vm.AgentPool)
+	query := bson.M{"name": name}
+	err := c.FindOne(context.Background(), query).Decode(resp)
+	return resp, err
+}
+
+func (c *AgentPoolColl) List() ([]*vm.AgentPool, error) {
+	resp := make([]*vm.AgentPool, 0)
+	cursor, err := c.Collection.Find(context.Background(), bson.M{})
+	if err != nil {
+		return nil, err
+	}
+
+	err = cursor.All(context.Background(), &resp)
+	return resp, err
+}
+
+func (c *AgentPoolColl) Delete(name string) error {
+	query := bson.M{"name": name}
+	_, err := c.DeleteOne(context.Background(), query)
+	return err
+}
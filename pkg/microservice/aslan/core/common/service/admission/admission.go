@@ -0,0 +1,217 @@
+/*
+Copyright 2025 The KodeRover Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package admission evaluates the rego policies uploaded by admins when a workflow task is created or a deploy job
+// starts. Every policy lives in its own package under zadig.admission and rejects an operation through its deny
+// rule, a set of messages explaining the violations.
+package admission
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/koderover/zadig/v2/pkg/config"
+	aslanconfig "github.com/koderover/zadig/v2/pkg/microservice/aslan/config"
+	commonmodels "github.com/koderover/zadig/v2/pkg/microservice/aslan/core/common/repository/models"
+	commonrepo "github.com/koderover/zadig/v2/pkg/microservice/aslan/core/common/repository/mongodb"
+	systemmodels "github.com/koderover/zadig/v2/pkg/microservice/aslan/core/system/repository/models"
+	systemrepo "github.com/koderover/zadig/v2/pkg/microservice/aslan/core/system/repository/mongodb"
+	"github.com/koderover/zadig/v2/pkg/setting"
+	"github.com/koderover/zadig/v2/pkg/tool/log"
+	"github.com/koderover/zadig/v2/pkg/tool/opa"
+	"github.com/koderover/zadig/v2/pkg/types"
+)
+
+const PackagePrefix = "zadig.admission."
+
+var packagePattern = regexp.MustCompile(`(?m)^\s*package\s+([A-Za-z_][A-Za-z0-9_.]*)\s*$`)
+
+// ParsePackage returns the package of the rego module, which must be under zadig.admission.
+func ParsePackage(module string) (string, error) {
+	match := packagePattern.FindStringSubmatch(module)
+	if match == nil {
+		return "", fmt.Errorf("package declaration not found")
+	}
+	if !strings.HasPrefix(match[1], PackagePrefix) || len(match[1]) == len(PackagePrefix) {
+		return "", fmt.Errorf("package %s must be under %s", match[1], strings.TrimSuffix(PackagePrefix, "."))
+	}
+	return match[1], nil
+}
+
+func moduleID(policy *commonmodels.AdmissionPolicy) string {
+	return "zadig/admission/" + policy.ID.Hex()
+}
+
+func newClient() *opa.Client {
+	return opa.NewClient(config.OPAServiceAddress())
+}
+
+// Push compiles the policy into the OPA server, a policy without a deny rule is refused.
+func Push(policy *commonmodels.AdmissionPolicy) error {
+	client := newClient()
+	if err := client.PutPolicy(moduleID(policy), policy.Rego); err != nil {
+		return err
+	}
+
+	if _, err := query(client, policy, &Input{}); err != nil {
+		_ = client.DeletePolicy(moduleID(policy))
+		return err
+	}
+	return nil
+}
+
+func Remove(policy *commonmodels.AdmissionPolicy) error {
+	return newClient().DeletePolicy(moduleID(policy))
+}
+
+func query(client *opa.Client, policy *commonmodels.AdmissionPolicy, input *Input) ([]string, error) {
+	deny := make([]interface{}, 0)
+	defined, err := client.Query(policy.Package+".deny", input, &deny)
+	if err != nil {
+		return nil, err
+	}
+	if !defined {
+		return nil, fmt.Errorf("rule deny is not defined in package %s", policy.Package)
+	}
+
+	resp := make([]string, 0, len(deny))
+	for _, msg := range deny {
+		if s, ok := msg.(string); ok {
+			resp = append(resp, s)
+			continue
+		}
+		b, _ := json.Marshal(msg)
+		resp = append(resp, string(b))
+	}
+	return resp, nil
+}
+
+// evaluate runs the deny rule of the policy, the policy is pushed again if the OPA server lost it after a restart.
+func evaluate(client *opa.Client, policy *commonmodels.AdmissionPolicy, input *Input) ([]string, error) {
+	resp, err := query(client, policy, input)
+	if err == nil {
+		return resp, nil
+	}
+
+	if err := client.PutPolicy(moduleID(policy), policy.Rego); err != nil {
+		return nil, fmt.Errorf("failed to load the policy into opa: %s", err)
+	}
+	return query(client, policy, input)
+}
+
+type Violation struct {
+	Policy      string                                 `json:"policy"`
+	Enforcement aslanconfig.AdmissionPolicyEnforcement `json:"enforcement"`
+	Messages    []string                               `json:"messages"`
+}
+
+// DeniedError is returned when enforced policies are violated.
+type DeniedError struct {
+	Violations []*Violation
+}
+
+func (e *DeniedError) Error() string {
+	msgs := make([]string, 0)
+	for _, violation := range e.Violations {
+		msgs = append(msgs, fmt.Sprintf("policy %s: %s", violation.Policy, strings.Join(violation.Messages, "; ")))
+	}
+	return "denied by admission policies, " + strings.Join(msgs, ", ")
+}
+
+// Evaluate checks the input against the enabled policies of its scope and project. Policies which can't be evaluated
+// reject the operation unless they are in audit mode. Every violation is recorded in the operation log.
+func Evaluate(input *Input) error {
+	policies, err := commonrepo.NewAdmissionPolicyColl().ListEnabled(input.Scope, input.Project)
+	if err != nil {
+		return fmt.Errorf("failed to list admission policies, error: %s", err)
+	}
+
+	denied := &DeniedError{}
+	for _, violation := range EvaluatePolicies(policies, input) {
+		record(input, violation)
+		if violation.Enforcement != aslanconfig.AdmissionPolicyEnforcementAudit {
+			denied.Violations = append(denied.Violations, violation)
+		}
+	}
+
+	if len(denied.Violations) > 0 {
+		return denied
+	}
+	return nil
+}
+
+// EvaluatePolicies returns the violations of the policies without recording them.
+func EvaluatePolicies(policies []*commonmodels.AdmissionPolicy, input *Input) []*Violation {
+	violations := make([]*Violation, 0)
+	if len(policies) == 0 {
+		return violations
+	}
+
+	client := newClient()
+	for _, policy := range policies {
+		msgs, err := evaluate(client, policy, input)
+		if err != nil {
+			log.Errorf("failed to evaluate admission policy %s, error: %s", policy.Name, err)
+			msgs = []string{fmt.Sprintf("failed to evaluate the policy: %s", err)}
+		}
+		if len(msgs) == 0 {
+			continue
+		}
+
+		violations = append(violations, &Violation{
+			Policy:      policy.Name,
+			Enforcement: policy.Enforcement,
+			Messages:    msgs,
+		})
+	}
+	return violations
+}
+
+func record(input *Input, violation *Violation) {
+	if input.Workflow == nil {
+		return
+	}
+
+	username := ""
+	if input.User != nil {
+		username = input.User.Name
+	}
+	method, status := "拒绝", 403
+	if violation.Enforcement == aslanconfig.AdmissionPolicyEnforcementAudit {
+		method, status = "告警", 200
+	}
+	detail := fmt.Sprintf("%s:%s:%s", input.Workflow.Name, violation.Policy, strings.Join(violation.Messages, "; "))
+
+	err := systemrepo.NewOperationLogColl().Insert(&systemmodels.OperationLog{
+		Username:    username,
+		ProductName: input.Project,
+		Method:      method,
+		Function:    "准入策略",
+		Scene:       setting.OperationSceneWorkflow,
+		Targets:     []string{input.Workflow.Name},
+		Name:        detail,
+		NameEn:      detail,
+		BodyType:    types.RequestBodyTypeJSON,
+		Status:      status,
+		CreatedAt:   time.Now().Unix(),
+	})
+	if err != nil {
+		log.Errorf("failed to record admission policy violation, error: %s", err)
+	}
+}
@@ -0,0 +1,279 @@
+/*
+Copyright 2025 The KodeRover Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package admission
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/koderover/zadig/v2/pkg/microservice/aslan/config"
+	commonmodels "github.com/koderover/zadig/v2/pkg/microservice/aslan/core/common/repository/models"
+	commonrepo "github.com/koderover/zadig/v2/pkg/microservice/aslan/core/common/repository/mongodb"
+	commonutil "github.com/koderover/zadig/v2/pkg/microservice/aslan/core/common/util"
+	"github.com/koderover/zadig/v2/pkg/tool/log"
+	"github.com/koderover/zadig/v2/pkg/types/step"
+)
+
+// Input is the document policies read as input.
+type Input struct {
+	Scope    config.AdmissionPolicyScope `json:"scope"`
+	Project  string                      `json:"project"`
+	Workflow *WorkflowInput              `json:"workflow,omitempty"`
+	User     *UserInput                  `json:"user,omitempty"`
+	// Job is the deploy job being started, only set in the deploy scope
+	Job *JobInput `json:"job,omitempty"`
+	// Envs are the target environments, the deploy scope has exactly one
+	Envs      []*EnvInput      `json:"envs"`
+	Images    []string         `json:"images"`
+	Branches  []*RepoInput     `json:"branches"`
+	Approvals []*ApprovalInput `json:"approvals"`
+}
+
+type WorkflowInput struct {
+	Name        string `json:"name"`
+	DisplayName string `json:"display_name"`
+	TaskID      int64  `json:"task_id"`
+	Type        string `json:"type"`
+}
+
+type UserInput struct {
+	ID      string `json:"id"`
+	Name    string `json:"name"`
+	Account string `json:"account"`
+}
+
+type JobInput struct {
+	Name string `json:"name"`
+	Type string `json:"type"`
+}
+
+type EnvInput struct {
+	Name       string `json:"name"`
+	Production bool   `json:"production"`
+}
+
+type RepoInput struct {
+	Source   string `json:"source"`
+	Owner    string `json:"owner"`
+	Name     string `json:"name"`
+	Branch   string `json:"branch"`
+	Tag      string `json:"tag"`
+	PRs      []int  `json:"prs"`
+	CommitID string `json:"commit_id"`
+}
+
+type ApprovalInput struct {
+	Job       string              `json:"job"`
+	Type      config.ApprovalType `json:"type"`
+	Status    config.Status       `json:"status"`
+	Approvers []string            `json:"approvers"`
+}
+
+// NewTaskInput collects the input from the jobs of the task. Images are the ones known when the task is created,
+// images coming from other jobs are only resolved in the deploy scope.
+func NewTaskInput(scope config.AdmissionPolicyScope, task *commonmodels.WorkflowTask) *Input {
+	input := &Input{
+		Scope:   scope,
+		Project: task.ProjectName,
+		Workflow: &WorkflowInput{
+			Name:        task.WorkflowName,
+			DisplayName: task.WorkflowDisplayName,
+			TaskID:      task.TaskID,
+			Type:        string(task.Type),
+		},
+		User: &UserInput{
+			ID:      task.TaskCreatorID,
+			Name:    task.TaskCreator,
+			Account: task.TaskCreatorAccount,
+		},
+		Envs:      make([]*EnvInput, 0),
+		Images:    make([]string, 0),
+		Branches:  make([]*RepoInput, 0),
+		Approvals: make([]*ApprovalInput, 0),
+	}
+
+	envs := make(map[string]bool)
+	addEnv := func(name string, production bool) {
+		if name == "" || envs[name] {
+			return
+		}
+		envs[name] = true
+		input.Envs = append(input.Envs, &EnvInput{Name: name, Production: production})
+	}
+
+	for _, stage := range task.Stages {
+		for _, job := range stage.Jobs {
+			switch job.JobType {
+			case string(config.JobZadigDeploy):
+				spec := &commonmodels.JobTaskDeploySpec{}
+				if err := commonmodels.IToi(job.Spec, spec); err != nil {
+					continue
+				}
+				addEnv(spec.Env, spec.Production)
+				for _, svc := range spec.ServiceAndImages {
+					input.addImage(svc.Image)
+				}
+			case string(config.JobZadigHelmDeploy):
+				spec := &commonmodels.JobTaskHelmDeploySpec{}
+				if err := commonmodels.IToi(job.Spec, spec); err != nil {
+					continue
+				}
+				addEnv(spec.Env, spec.IsProduction)
+				for _, module := range spec.ImageAndModules {
+					input.addImage(module.Image)
+				}
+			case string(config.JobZadigHelmChartDeploy):
+				spec := &commonmodels.JobTaskHelmChartDeploySpec{}
+				if err := commonmodels.IToi(job.Spec, spec); err != nil || spec.DeployHelmChart == nil {
+					continue
+				}
+				addEnv(spec.Env, spec.IsProduction)
+				// the images are in the chart values, a chart which can't be fetched fails the deploy job anyway
+				images, err := commonutil.GetHelmChartDeployImages(task.ProjectName, spec.IsProduction, spec.DeployHelmChart)
+				if err != nil {
+					log.Warnf("failed to get images of helm chart release %s, error: %v", spec.DeployHelmChart.ReleaseName, err)
+				}
+				for _, image := range images {
+					input.addImage(image)
+				}
+			case string(config.JobK8sCanaryDeploy):
+				spec := &commonmodels.JobTaskCanaryDeploySpec{}
+				if err := commonmodels.IToi(job.Spec, spec); err != nil {
+					continue
+				}
+				env, err := NamespaceEnv(spec.ClusterID, spec.Namespace)
+				if err != nil {
+					log.Warnf("failed to find env of namespace %s, error: %v", spec.Namespace, err)
+				} else {
+					addEnv(env.EnvName, env.Production)
+				}
+				input.addImage(spec.Image)
+			case string(config.JobApproval):
+				input.addApproval(job)
+			default:
+				spec := &commonmodels.JobTaskFreestyleSpec{}
+				if err := commonmodels.IToi(job.Spec, spec); err != nil {
+					continue
+				}
+				input.addFreestyle(spec)
+			}
+		}
+	}
+	return input
+}
+
+// NewDeployInput builds the input of a deploy job from its task, the target environment and the images are the
+// ones of the job.
+func NewDeployInput(workflowCtx *commonmodels.WorkflowTaskCtx, job *commonmodels.JobTask, env *commonmodels.Product, images []string) (*Input, error) {
+	task, err := commonrepo.NewworkflowTaskv4Coll().Find(workflowCtx.WorkflowName, workflowCtx.TaskID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find workflow task %s/%d, error: %s", workflowCtx.WorkflowName, workflowCtx.TaskID, err)
+	}
+
+	input := NewTaskInput(config.AdmissionPolicyScopeDeploy, task)
+	input.Job = &JobInput{Name: job.Name, Type: job.JobType}
+	input.Envs = []*EnvInput{{Name: env.EnvName, Production: env.Production}}
+	input.Images = make([]string, 0)
+	for _, image := range images {
+		input.addImage(image)
+	}
+	return input, nil
+}
+
+// NamespaceEnv returns the environment deployed into the namespace of the cluster, canary deploy jobs only know the
+// namespace. A namespace which is not managed by any environment is returned as a non-production env named after it.
+func NamespaceEnv(clusterID, namespace string) (*commonmodels.Product, error) {
+	envs, err := commonrepo.NewProductColl().List(&commonrepo.ProductListOptions{
+		ClusterID: clusterID,
+		Namespace: namespace,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list envs of namespace %s, error: %s", namespace, err)
+	}
+	if len(envs) > 0 {
+		return envs[0], nil
+	}
+	return &commonmodels.Product{
+		EnvName:   namespace,
+		Namespace: namespace,
+		ClusterID: clusterID,
+	}, nil
+}
+
+func (i *Input) addImage(image string) {
+	// images referring to other jobs are rendered as variables until the task runs
+	if image == "" || strings.Contains(image, "{{.") {
+		return
+	}
+	for _, existing := range i.Images {
+		if existing == image {
+			return
+		}
+	}
+	i.Images = append(i.Images, image)
+}
+
+func (i *Input) addFreestyle(spec *commonmodels.JobTaskFreestyleSpec) {
+	for _, env := range spec.Properties.Envs {
+		if env.Key == "IMAGE" {
+			i.addImage(env.Value)
+		}
+	}
+
+	for _, stepTask := range spec.Steps {
+		if stepTask.StepType != config.StepGit {
+			continue
+		}
+		stepSpec := &step.StepGitSpec{}
+		if err := commonmodels.IToi(stepTask.Spec, stepSpec); err != nil {
+			continue
+		}
+		for _, repo := range stepSpec.Repos {
+			i.Branches = append(i.Branches, &RepoInput{
+				Source:   repo.Source,
+				Owner:    repo.RepoOwner,
+				Name:     repo.RepoName,
+				Branch:   repo.Branch,
+				Tag:      repo.Tag,
+				PRs:      repo.PRs,
+				CommitID: repo.CommitID,
+			})
+		}
+	}
+}
+
+func (i *Input) addApproval(job *commonmodels.JobTask) {
+	spec := &commonmodels.JobTaskApprovalSpec{}
+	if err := commonmodels.IToi(job.Spec, spec); err != nil {
+		return
+	}
+
+	approval := &ApprovalInput{
+		Job:       job.Name,
+		Type:      spec.Type,
+		Status:    job.Status,
+		Approvers: make([]string, 0),
+	}
+	if spec.NativeApproval != nil {
+		for _, user := range spec.NativeApproval.ApproveUsers {
+			if user.RejectOrApprove == config.ApprovalStatusApprove {
+				approval.Approvers = append(approval.Approvers, user.UserName)
+			}
+		}
+	}
+	i.Approvals = append(i.Approvals, approval)
+}
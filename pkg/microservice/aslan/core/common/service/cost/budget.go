@@ -0,0 +1,108 @@
+/*
+Copyright 2025 The KodeRover Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cost
+
+import (
+	"fmt"
+	"sort"
+	"time"
+
+	configbase "github.com/koderover/zadig/v2/pkg/config"
+	commonmodels "github.com/koderover/zadig/v2/pkg/microservice/aslan/core/common/repository/models"
+	commonrepo "github.com/koderover/zadig/v2/pkg/microservice/aslan/core/common/repository/mongodb"
+	"github.com/koderover/zadig/v2/pkg/microservice/aslan/core/common/service/instantmessage"
+	"github.com/koderover/zadig/v2/pkg/tool/log"
+)
+
+// DefaultBudgetThresholds are the percentages of the budget alerted when a budget has no thresholds
+var DefaultBudgetThresholds = []int{80, 100}
+
+// CheckBudgets sends notifications for the budgets whose cost of the month reaches a threshold not alerted yet in the month
+func CheckBudgets(now time.Time, pricing *commonmodels.CostPricing) {
+	budgets, err := commonrepo.NewCostBudgetColl().List(nil)
+	if err != nil {
+		log.Errorf("failed to list cost budgets, error: %s", err)
+		return
+	}
+
+	month := now.Format(monthLayout)
+	startDate := now.Format(monthLayout) + "-01"
+	for _, budget := range budgets {
+		if budget.Amount <= 0 {
+			continue
+		}
+		total, err := commonrepo.NewDailyCostColl().SumTotalCost(startDate, budget.ProjectKey, budget.EnvName)
+		if err != nil {
+			log.Errorf("failed to sum the cost of budget %s, error: %s", budget.Name, err)
+			continue
+		}
+
+		alerted := 0
+		if budget.AlertMonth == month {
+			alerted = budget.AlertThreshold
+		}
+		threshold := reachedThreshold(budget, total)
+		if threshold <= alerted {
+			continue
+		}
+
+		sendBudgetAlert(budget, total, threshold, pricing.Currency)
+		if err := commonrepo.NewCostBudgetColl().UpdateAlert(budget.ID, month, threshold); err != nil {
+			log.Errorf("failed to update the alert of budget %s, error: %s", budget.Name, err)
+		}
+	}
+}
+
+// reachedThreshold returns the highest threshold the cost reaches, or 0 if it reaches none
+func reachedThreshold(budget *commonmodels.CostBudget, total float64) int {
+	thresholds := budget.Thresholds
+	if len(thresholds) == 0 {
+		thresholds = DefaultBudgetThresholds
+	}
+	thresholds = append([]int{}, thresholds...)
+	sort.Sort(sort.Reverse(sort.IntSlice(thresholds)))
+
+	for _, threshold := range thresholds {
+		if total >= budget.Amount*float64(threshold)/100 {
+			return threshold
+		}
+	}
+	return 0
+}
+
+func sendBudgetAlert(budget *commonmodels.CostBudget, total float64, threshold int, currency string) {
+	scope := fmt.Sprintf("项目 %s", budget.ProjectKey)
+	if budget.EnvName != "" {
+		scope = fmt.Sprintf("项目 %s 环境 %s", budget.ProjectKey, budget.EnvName)
+	}
+	title := fmt.Sprintf("成本预算告警：%s", budget.Name)
+	content := fmt.Sprintf("%s 本月成本已达预算的 %d%%\n\n- 本月成本：%.2f %s\n- 月度预算：%.2f %s",
+		scope, threshold, total, currency, budget.Amount, currency)
+	link := fmt.Sprintf("%s/v1/projects/detail/%s/envs/detail", configbase.SystemAddress(), budget.ProjectKey)
+	if budget.EnvName != "" {
+		link = fmt.Sprintf("%s?envName=%s", link, budget.EnvName)
+	}
+
+	for _, notify := range budget.NotifyCtls {
+		if notify == nil || !notify.Enabled {
+			continue
+		}
+		if err := instantmessage.NewWeChatClient().SendGeneralNotification(title, content, link, notify); err != nil {
+			log.Errorf("failed to send the alert of budget %s, error: %s", budget.Name, err)
+		}
+	}
+}
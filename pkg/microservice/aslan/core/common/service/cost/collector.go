@@ -0,0 +1,198 @@
+/*
+Copyright 2025 The KodeRover Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cost
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	commonmodels "github.com/koderover/zadig/v2/pkg/microservice/aslan/core/common/repository/models"
+	commonrepo "github.com/koderover/zadig/v2/pkg/microservice/aslan/core/common/repository/mongodb"
+	"github.com/koderover/zadig/v2/pkg/setting"
+	"github.com/koderover/zadig/v2/pkg/tool/cache"
+	"github.com/koderover/zadig/v2/pkg/tool/clientmanager"
+	"github.com/koderover/zadig/v2/pkg/tool/log"
+)
+
+const (
+	collectLockKey = "environment_cost_collect"
+	// collectInterval is the interval the environment resources are sampled at, the sampled resources are billed for the whole interval
+	collectInterval = time.Hour
+	// the lock expires before the next collection and is never released, so the replicas collect only once an interval
+	collectLockExpiry = 55 * time.Minute
+
+	dateLayout  = "2006-01-02"
+	monthLayout = "2006-01"
+)
+
+type resourceUsage struct {
+	cpu    float64
+	memory float64
+}
+
+// CollectEnvironmentCosts samples the resources of all the environments, adds their cost of the interval to the daily costs
+// of the services, and then checks the budgets
+func CollectEnvironmentCosts() {
+	lock := cache.NewRedisLockWithExpiry(collectLockKey, collectLockExpiry)
+	if err := lock.TryLock(); err != nil {
+		return
+	}
+
+	pricing, err := commonrepo.NewCostPricingColl().Get()
+	if err != nil {
+		log.Errorf("failed to get cost pricing, error: %s", err)
+		return
+	}
+	if pricing == nil || !pricing.Enabled {
+		return
+	}
+
+	envs, err := commonrepo.NewProductColl().List(&commonrepo.ProductListOptions{
+		ExcludeStatus: []string{setting.ProductStatusDeleting, setting.ProductStatusUnknown},
+	})
+	if err != nil {
+		log.Errorf("failed to list environments, error: %s", err)
+		return
+	}
+	clusterEnvs := make(map[string][]*commonmodels.Product)
+	for _, env := range envs {
+		if env.Namespace == "" {
+			continue
+		}
+		clusterEnvs[env.ClusterID] = append(clusterEnvs[env.ClusterID], env)
+	}
+
+	now := time.Now()
+	date := now.Format(dateLayout)
+	for clusterID, envs := range clusterEnvs {
+		costs, err := collectClusterCosts(clusterID, envs, pricing)
+		if err != nil {
+			log.Warnf("failed to collect the environment costs of cluster %s, error: %s", clusterID, err)
+			continue
+		}
+		for _, cost := range costs {
+			cost.Date = date
+			if err := commonrepo.NewDailyCostColl().Inc(cost); err != nil {
+				log.Errorf("failed to save the cost of env %s/%s, error: %s", cost.ProjectKey, cost.EnvName, err)
+			}
+		}
+	}
+
+	CheckBudgets(now, pricing)
+}
+
+func collectClusterCosts(clusterID string, envs []*commonmodels.Product, pricing *commonmodels.CostPricing) ([]*commonmodels.DailyCost, error) {
+	clientset, err := clientmanager.NewKubeClientManager().GetKubernetesClientSet(clusterID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get kube client, error: %s", err)
+	}
+
+	nodes, err := clientset.CoreV1().Nodes().List(context.TODO(), metav1.ListOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list nodes, error: %s", err)
+	}
+	nodePrices := make(map[string]*commonmodels.NodeClassPrice)
+	for _, node := range nodes.Items {
+		nodePrices[node.Name] = nodeClassPrice(pricing, node.Labels)
+	}
+
+	// the requests are billed if the metrics server is not available
+	metricsClient, err := clientmanager.NewKubeClientManager().GetKubernetesMetricsClient(clusterID)
+	if err != nil {
+		log.Warnf("failed to get metrics client of cluster %s, error: %s", clusterID, err)
+	}
+
+	acc := newAccumulator(pricing, clusterID, collectInterval.Hours())
+	for _, env := range envs {
+		releaseServices := make(map[string]string)
+		for _, svc := range env.GetSvcList() {
+			if svc.ReleaseName != "" {
+				releaseServices[svc.ReleaseName] = svc.ServiceName
+			}
+		}
+
+		usages := make(map[string]*resourceUsage)
+		if metricsClient != nil {
+			podMetrics, err := metricsClient.PodMetricses(env.Namespace).List(context.TODO(), metav1.ListOptions{})
+			if err != nil {
+				log.Warnf("failed to list pod metrics in namespace %s, error: %s", env.Namespace, err)
+			} else {
+				for _, item := range podMetrics.Items {
+					usage := &resourceUsage{}
+					for _, container := range item.Containers {
+						usage.cpu += float64(container.Usage.Cpu().MilliValue()) / 1000
+						usage.memory += float64(container.Usage.Memory().Value()) / gib
+					}
+					usages[item.Name] = usage
+				}
+			}
+		}
+
+		pods, err := clientset.CoreV1().Pods(env.Namespace).List(context.TODO(), metav1.ListOptions{})
+		if err != nil {
+			log.Warnf("failed to list pods in namespace %s, error: %s", env.Namespace, err)
+			continue
+		}
+		for i := range pods.Items {
+			pod := &pods.Items[i]
+			if pod.Status.Phase != corev1.PodRunning {
+				continue
+			}
+			cpu, memory := podRequests(pod)
+			usage, hasUsage := usages[pod.Name]
+			if hasUsage {
+				cpu = billed(pricing.UsageMode, cpu, usage.cpu, true)
+				memory = billed(pricing.UsageMode, memory, usage.memory, true)
+			}
+			price, ok := nodePrices[pod.Spec.NodeName]
+			if !ok {
+				price = defaultPrice(pricing)
+			}
+			acc.addCompute(env, resourceServiceName(pod.Labels, pod.Annotations, releaseServices), cpu, memory, price)
+		}
+
+		pvcs, err := clientset.CoreV1().PersistentVolumeClaims(env.Namespace).List(context.TODO(), metav1.ListOptions{})
+		if err != nil {
+			log.Warnf("failed to list pvcs in namespace %s, error: %s", env.Namespace, err)
+		} else {
+			for _, pvc := range pvcs.Items {
+				if pvc.Status.Phase != corev1.ClaimBound {
+					continue
+				}
+				capacity := pvc.Status.Capacity[corev1.ResourceStorage]
+				acc.addStorage(env, resourceServiceName(pvc.Labels, pvc.Annotations, releaseServices), float64(capacity.Value())/gib)
+			}
+		}
+
+		services, err := clientset.CoreV1().Services(env.Namespace).List(context.TODO(), metav1.ListOptions{})
+		if err != nil {
+			log.Warnf("failed to list services in namespace %s, error: %s", env.Namespace, err)
+		} else {
+			for _, svc := range services.Items {
+				if svc.Spec.Type != corev1.ServiceTypeLoadBalancer {
+					continue
+				}
+				acc.addLoadBalancer(env, resourceServiceName(svc.Labels, svc.Annotations, releaseServices))
+			}
+		}
+	}
+	return acc.result(), nil
+}
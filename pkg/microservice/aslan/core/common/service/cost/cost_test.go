@@ -0,0 +1,105 @@
+/*
+Copyright 2025 The KodeRover Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cost
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+
+	"github.com/koderover/zadig/v2/pkg/microservice/aslan/config"
+	commonmodels "github.com/koderover/zadig/v2/pkg/microservice/aslan/core/common/repository/models"
+	"github.com/koderover/zadig/v2/pkg/setting"
+)
+
+func TestPricing(t *testing.T) {
+	ast := require.New(t)
+
+	pricing := &commonmodels.CostPricing{
+		UsageMode:    config.CostUsageModeMax,
+		DefaultPrice: &commonmodels.NodeClassPrice{Name: "default", CPUCoreHour: 0.1, MemoryGBHour: 0.01},
+		NodeClasses: []*commonmodels.NodeClassPrice{
+			{Name: "spot", NodeSelector: map[string]string{"node.kubernetes.io/lifecycle": "spot"}, CPUCoreHour: 0.03, MemoryGBHour: 0.003},
+			{Name: "gpu", NodeSelector: map[string]string{"accelerator": "nvidia", "zone": "a"}, CPUCoreHour: 1, MemoryGBHour: 0.1},
+		},
+		StorageGBHour:    0.001,
+		LoadBalancerHour: 0.5,
+	}
+
+	ast.Equal("spot", nodeClassPrice(pricing, map[string]string{"node.kubernetes.io/lifecycle": "spot", "zone": "a"}).Name)
+	ast.Equal("default", nodeClassPrice(pricing, map[string]string{"accelerator": "nvidia"}).Name)
+	ast.Equal("gpu", nodeClassPrice(pricing, map[string]string{"accelerator": "nvidia", "zone": "a"}).Name)
+
+	ast.Equal(2.0, billed(config.CostUsageModeMax, 1, 2, true))
+	ast.Equal(0.5, billed(config.CostUsageModeUsage, 1, 0.5, true))
+	ast.Equal(1.0, billed(config.CostUsageModeUsage, 1, 0.5, false))
+	ast.Equal(1.0, billed(config.CostUsageModeRequest, 1, 2, true))
+
+	pod := &corev1.Pod{Spec: corev1.PodSpec{Containers: []corev1.Container{
+		{Resources: corev1.ResourceRequirements{Requests: corev1.ResourceList{
+			corev1.ResourceCPU:    resource.MustParse("500m"),
+			corev1.ResourceMemory: resource.MustParse("512Mi"),
+		}}},
+		{Resources: corev1.ResourceRequirements{Requests: corev1.ResourceList{
+			corev1.ResourceCPU: resource.MustParse("1500m"),
+		}}},
+	}}}
+	cpu, memory := podRequests(pod)
+	ast.Equal(2.0, cpu)
+	ast.Equal(0.5, memory)
+
+	releases := map[string]string{"demo-dev-redis": "redis"}
+	ast.Equal("user", resourceServiceName(map[string]string{setting.ServiceLabel: "user"}, nil, releases))
+	ast.Equal("redis", resourceServiceName(nil, map[string]string{setting.HelmReleaseNameAnnotation: "demo-dev-redis"}, releases))
+	ast.Equal("redis", resourceServiceName(map[string]string{helmInstanceLabel: "demo-dev-redis"}, nil, releases))
+	ast.Equal("", resourceServiceName(map[string]string{"app": "other"}, nil, releases))
+
+	env := &commonmodels.Product{ProductName: "demo", EnvName: "dev"}
+	acc := newAccumulator(pricing, "cluster", 2)
+	acc.addCompute(env, "user", 2, 4, pricing.DefaultPrice)
+	acc.addCompute(env, "user", 1, 0, pricing.NodeClasses[0])
+	acc.addStorage(env, "", 100)
+	acc.addLoadBalancer(env, "")
+
+	costs := acc.result()
+	ast.Len(costs, 2)
+	ast.Equal("user", costs[0].ServiceName)
+	ast.Equal(config.CostCategoryEnvironment, costs[0].Category)
+	ast.InDelta(6.0, costs[0].CPUCoreHours, 1e-9)
+	ast.InDelta(0.46, costs[0].CPUCost, 1e-9)
+	ast.InDelta(0.08, costs[0].MemoryCost, 1e-9)
+	ast.InDelta(0.54, costs[0].TotalCost, 1e-9)
+	ast.InDelta(0.2, costs[1].StorageCost, 1e-9)
+	ast.InDelta(1.0, costs[1].LoadBalancerCost, 1e-9)
+	ast.InDelta(1.2, costs[1].TotalCost, 1e-9)
+}
+
+func TestReachedThreshold(t *testing.T) {
+	ast := require.New(t)
+
+	budget := &commonmodels.CostBudget{Amount: 1000}
+	ast.Equal(0, reachedThreshold(budget, 799))
+	ast.Equal(80, reachedThreshold(budget, 800))
+	ast.Equal(100, reachedThreshold(budget, 1200))
+
+	budget.Thresholds = []int{50, 120, 90}
+	ast.Equal(90, reachedThreshold(budget, 1000))
+	ast.Equal(120, reachedThreshold(budget, 1200))
+	ast.Equal([]int{50, 120, 90}, budget.Thresholds)
+}
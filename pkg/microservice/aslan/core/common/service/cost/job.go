@@ -0,0 +1,70 @@
+/*
+Copyright 2025 The KodeRover Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cost
+
+import (
+	"time"
+
+	"github.com/koderover/zadig/v2/pkg/microservice/aslan/config"
+	commonmodels "github.com/koderover/zadig/v2/pkg/microservice/aslan/core/common/repository/models"
+	commonrepo "github.com/koderover/zadig/v2/pkg/microservice/aslan/core/common/repository/mongodb"
+)
+
+// JobCost is the resource a build or testing job pod takes, cpu is in millicores and memory is in MiB
+type JobCost struct {
+	ProjectName   string
+	ConfigName    string
+	Category      config.CostCategory
+	ClusterID     string
+	CPURequest    int
+	MemoryRequest int
+	// CPUUsage and MemoryUsage are the average usage sampled by the job executor, they are ignored if HasUsage is false
+	CPUUsage    int
+	MemoryUsage int
+	HasUsage    bool
+	// Duration is in seconds
+	Duration int64
+}
+
+// RecordJobCost adds the cost of a build or testing job pod to the daily costs, the job pods are billed at the default price
+func RecordJobCost(args *JobCost) error {
+	pricing, err := commonrepo.NewCostPricingColl().Get()
+	if err != nil {
+		return err
+	}
+	if pricing == nil || !pricing.Enabled || args.Duration <= 0 {
+		return nil
+	}
+
+	price := defaultPrice(pricing)
+	hours := float64(args.Duration) / 3600
+	cpuHours := billed(pricing.UsageMode, float64(args.CPURequest)/1000, float64(args.CPUUsage)/1000, args.HasUsage) * hours
+	memoryHours := billed(pricing.UsageMode, float64(args.MemoryRequest)/1024, float64(args.MemoryUsage)/1024, args.HasUsage) * hours
+
+	return commonrepo.NewDailyCostColl().Inc(&commonmodels.DailyCost{
+		Date:          time.Now().Format(dateLayout),
+		Category:      args.Category,
+		ProjectKey:    args.ProjectName,
+		ConfigName:    args.ConfigName,
+		ClusterID:     args.ClusterID,
+		CPUCoreHours:  cpuHours,
+		MemoryGBHours: memoryHours,
+		CPUCost:       cpuHours * price.CPUCoreHour,
+		MemoryCost:    memoryHours * price.MemoryGBHour,
+		TotalCost:     cpuHours*price.CPUCoreHour + memoryHours*price.MemoryGBHour,
+	})
+}
@@ -0,0 +1,180 @@
+/*
+Copyright 2025 The KodeRover Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cost
+
+import (
+	"math"
+
+	corev1 "k8s.io/api/core/v1"
+
+	"github.com/koderover/zadig/v2/pkg/microservice/aslan/config"
+	commonmodels "github.com/koderover/zadig/v2/pkg/microservice/aslan/core/common/repository/models"
+	"github.com/koderover/zadig/v2/pkg/setting"
+)
+
+const (
+	gib = 1 << 30
+
+	// helmInstanceLabel is the label the charts conventionally put the release name in
+	helmInstanceLabel = "app.kubernetes.io/instance"
+)
+
+// nodeClassPrice returns the unit prices of the node, the first node class whose selector matches the node labels wins
+func nodeClassPrice(pricing *commonmodels.CostPricing, nodeLabels map[string]string) *commonmodels.NodeClassPrice {
+	for _, class := range pricing.NodeClasses {
+		if len(class.NodeSelector) == 0 {
+			continue
+		}
+		matched := true
+		for k, v := range class.NodeSelector {
+			if nodeLabels[k] != v {
+				matched = false
+				break
+			}
+		}
+		if matched {
+			return class
+		}
+	}
+	return defaultPrice(pricing)
+}
+
+func defaultPrice(pricing *commonmodels.CostPricing) *commonmodels.NodeClassPrice {
+	if pricing.DefaultPrice == nil {
+		return &commonmodels.NodeClassPrice{}
+	}
+	return pricing.DefaultPrice
+}
+
+// billed returns the resource amount billed by the usage mode, the requests are billed if the usage is not sampled
+func billed(mode config.CostUsageMode, request, usage float64, hasUsage bool) float64 {
+	if !hasUsage {
+		return request
+	}
+	switch mode {
+	case config.CostUsageModeUsage:
+		return usage
+	case config.CostUsageModeMax:
+		return math.Max(request, usage)
+	default:
+		return request
+	}
+}
+
+// podRequests returns the cpu cores and the memory GiB requested by the containers of the pod
+func podRequests(pod *corev1.Pod) (float64, float64) {
+	var cpu, memory float64
+	for _, container := range pod.Spec.Containers {
+		if q, ok := container.Resources.Requests[corev1.ResourceCPU]; ok {
+			cpu += float64(q.MilliValue()) / 1000
+		}
+		if q, ok := container.Resources.Requests[corev1.ResourceMemory]; ok {
+			memory += float64(q.Value()) / gib
+		}
+	}
+	return cpu, memory
+}
+
+// resourceServiceName returns the service a resource in the env belongs to by the labels zadig applies,
+// the resources of helm services are found by their release names
+func resourceServiceName(labels, annotations map[string]string, releaseServices map[string]string) string {
+	if svc := labels[setting.ServiceLabel]; svc != "" {
+		return svc
+	}
+	if release := annotations[setting.HelmReleaseNameAnnotation]; release != "" {
+		return releaseServices[release]
+	}
+	if release := labels[helmInstanceLabel]; release != "" {
+		return releaseServices[release]
+	}
+	return ""
+}
+
+type costKey struct {
+	projectKey  string
+	envName     string
+	serviceName string
+}
+
+// accumulator sums up the resource amounts and costs of the services in the envs of a cluster for an interval
+type accumulator struct {
+	pricing   *commonmodels.CostPricing
+	hours     float64
+	clusterID string
+	costs     map[costKey]*commonmodels.DailyCost
+	keys      []costKey
+}
+
+func newAccumulator(pricing *commonmodels.CostPricing, clusterID string, hours float64) *accumulator {
+	return &accumulator{
+		pricing:   pricing,
+		hours:     hours,
+		clusterID: clusterID,
+		costs:     make(map[costKey]*commonmodels.DailyCost),
+	}
+}
+
+func (a *accumulator) get(env *commonmodels.Product, serviceName string) *commonmodels.DailyCost {
+	key := costKey{projectKey: env.ProductName, envName: env.EnvName, serviceName: serviceName}
+	if cost, ok := a.costs[key]; ok {
+		return cost
+	}
+	cost := &commonmodels.DailyCost{
+		Category:    config.CostCategoryEnvironment,
+		ProjectKey:  env.ProductName,
+		EnvName:     env.EnvName,
+		Production:  env.Production,
+		ServiceName: serviceName,
+		ClusterID:   a.clusterID,
+	}
+	a.costs[key] = cost
+	a.keys = append(a.keys, key)
+	return cost
+}
+
+func (a *accumulator) addCompute(env *commonmodels.Product, serviceName string, cpuCores, memoryGB float64, price *commonmodels.NodeClassPrice) {
+	cost := a.get(env, serviceName)
+	cpuHours, memoryHours := cpuCores*a.hours, memoryGB*a.hours
+	cost.CPUCoreHours += cpuHours
+	cost.MemoryGBHours += memoryHours
+	cost.CPUCost += cpuHours * price.CPUCoreHour
+	cost.MemoryCost += memoryHours * price.MemoryGBHour
+	cost.TotalCost += cpuHours*price.CPUCoreHour + memoryHours*price.MemoryGBHour
+}
+
+func (a *accumulator) addStorage(env *commonmodels.Product, serviceName string, storageGB float64) {
+	cost := a.get(env, serviceName)
+	storageHours := storageGB * a.hours
+	cost.StorageGBHours += storageHours
+	cost.StorageCost += storageHours * a.pricing.StorageGBHour
+	cost.TotalCost += storageHours * a.pricing.StorageGBHour
+}
+
+func (a *accumulator) addLoadBalancer(env *commonmodels.Product, serviceName string) {
+	cost := a.get(env, serviceName)
+	cost.LoadBalancerHours += a.hours
+	cost.LoadBalancerCost += a.hours * a.pricing.LoadBalancerHour
+	cost.TotalCost += a.hours * a.pricing.LoadBalancerHour
+}
+
+func (a *accumulator) result() []*commonmodels.DailyCost {
+	resp := make([]*commonmodels.DailyCost, 0, len(a.keys))
+	for _, key := range a.keys {
+		resp = append(resp, a.costs[key])
+	}
+	return resp
+}
@@ -0,0 +1,167 @@
+/*
+Copyright 2025 The KodeRover Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package imagetrust verifies the images deployed into an environment against its image trust policy.
+package imagetrust
+
+import (
+	"context"
+	"crypto"
+	"fmt"
+	"strings"
+
+	commonmodels "github.com/koderover/zadig/v2/pkg/microservice/aslan/core/common/repository/models"
+	commonrepo "github.com/koderover/zadig/v2/pkg/microservice/aslan/core/common/repository/mongodb"
+	"github.com/koderover/zadig/v2/pkg/microservice/aslan/core/common/service/keyvault"
+	"github.com/koderover/zadig/v2/pkg/tool/imagesign"
+)
+
+// TrustedKeys returns the public keys trusted by the policy, key vault items of the project take priority over system wide ones.
+func TrustedKeys(projectName string, policy *commonmodels.ImageTrustPolicy) ([]crypto.PublicKey, error) {
+	resp := make([]crypto.PublicKey, 0)
+	if policy == nil {
+		return resp, nil
+	}
+
+	if strings.TrimSpace(policy.PublicKeys) != "" {
+		keys, err := imagesign.ParsePublicKeys([]byte(policy.PublicKeys))
+		if err != nil {
+			return nil, err
+		}
+		resp = append(resp, keys...)
+	}
+
+	for _, ref := range policy.TrustedKeys {
+		item, err := commonrepo.NewKeyVaultItemColl().Find(&commonrepo.KeyVaultItemFindOption{
+			Group:       ref.Group,
+			Key:         ref.Key,
+			ProjectName: projectName,
+		})
+		if err != nil {
+			item, err = commonrepo.NewKeyVaultItemColl().Find(&commonrepo.KeyVaultItemFindOption{
+				Group:        ref.Group,
+				Key:          ref.Key,
+				IsSystemWide: true,
+			})
+			if err != nil {
+				return nil, fmt.Errorf("trusted key %s/%s not found in the key vault", ref.Group, ref.Key)
+			}
+		}
+		value, err := keyvault.ResolveItem(context.TODO(), item)
+		if err != nil {
+			return nil, err
+		}
+		keys, err := imagesign.ParsePublicKeys([]byte(value))
+		if err != nil {
+			return nil, fmt.Errorf("invalid trusted key %s/%s: %s", ref.Group, ref.Key, err)
+		}
+		resp = append(resp, keys...)
+	}
+	return resp, nil
+}
+
+// Verify checks the signatures of the images against the trust policy of the environment. The results of all
+// the images are returned along with an error when any of them is not verified.
+func Verify(env *commonmodels.Product, images []string) ([]*imagesign.VerifyResult, error) {
+	resp := make([]*imagesign.VerifyResult, 0)
+	if env.ImageTrustPolicy == nil || !env.ImageTrustPolicy.Enabled {
+		return resp, nil
+	}
+
+	keys, err := TrustedKeys(env.ProductName, env.ImageTrustPolicy)
+	if err != nil {
+		return resp, err
+	}
+	if len(keys) == 0 {
+		return resp, fmt.Errorf("no trusted key is configured in the image trust policy of environment %s", env.EnvName)
+	}
+
+	registries, err := commonrepo.NewRegistryNamespaceColl().FindAll(&commonrepo.FindRegOps{})
+	if err != nil {
+		return resp, fmt.Errorf("failed to list registries: %s", err)
+	}
+
+	failed := make([]string, 0)
+	for _, image := range images {
+		if image == "" {
+			continue
+		}
+		result, err := imagesign.Verify(image, keys, registryAuth(image, registries))
+		if err != nil {
+			result = &imagesign.VerifyResult{Image: image, Message: err.Error()}
+		}
+		resp = append(resp, result)
+		if !result.Verified {
+			failed = append(failed, fmt.Sprintf("%s: %s", image, result.Message))
+		}
+	}
+	if len(failed) > 0 {
+		return resp, fmt.Errorf("image signature verification failed, %s", strings.Join(failed, "; "))
+	}
+	return resp, nil
+}
+
+// ResolveDigests resolves the manifest digests of the images from their registries, keyed by image.
+func ResolveDigests(images []string) (map[string]string, error) {
+	registries, err := commonrepo.NewRegistryNamespaceColl().FindAll(&commonrepo.FindRegOps{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list registries: %s", err)
+	}
+
+	resp := make(map[string]string)
+	for _, image := range images {
+		if image == "" {
+			continue
+		}
+		digest, err := imagesign.ResolveDigest(image, registryAuth(image, registries))
+		if err != nil {
+			return nil, fmt.Errorf("failed to resolve digest of image %s: %s", image, err)
+		}
+		resp[image] = digest.DigestStr()
+	}
+	return resp, nil
+}
+
+// registryAuth finds the credential of the image by the longest matching registry prefix.
+func registryAuth(image string, registries []*commonmodels.RegistryNamespace) *imagesign.RegistryAuth {
+	var matched *commonmodels.RegistryNamespace
+	matchedPrefix := ""
+	for _, registry := range registries {
+		prefix := registry.RegAddr
+		if len(registry.Namespace) > 0 {
+			prefix = fmt.Sprintf("%s/%s", registry.RegAddr, registry.Namespace)
+		}
+		prefix = strings.TrimPrefix(prefix, "http://")
+		prefix = strings.TrimPrefix(prefix, "https://")
+		if strings.HasPrefix(image, prefix) && len(prefix) > len(matchedPrefix) {
+			matched = registry
+			matchedPrefix = prefix
+		}
+	}
+	if matched == nil {
+		return nil
+	}
+
+	auth := &imagesign.RegistryAuth{
+		Username: matched.AccessKey,
+		Password: matched.SecretKey,
+		Insecure: true,
+	}
+	if matched.AdvancedSetting != nil {
+		auth.Insecure = !matched.AdvancedSetting.TLSEnabled
+	}
+	return auth
+}
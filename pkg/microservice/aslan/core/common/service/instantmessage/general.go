@@ -0,0 +1,91 @@
+/*
+Copyright 2025 The KodeRover Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package instantmessage
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/koderover/zadig/v2/pkg/microservice/aslan/config"
+	"github.com/koderover/zadig/v2/pkg/microservice/aslan/core/common/repository/models"
+	larkservice "github.com/koderover/zadig/v2/pkg/microservice/aslan/core/common/service/lark"
+	"github.com/koderover/zadig/v2/pkg/setting"
+)
+
+// SendGeneralNotification sends a notification not bound to any task through the im configured by the notify ctl,
+// the content is in markdown and the link is the page to see more details
+func (w *Service) SendGeneralNotification(title, content, link string, notify *models.NotifyCtl) error {
+	if notify == nil {
+		return fmt.Errorf("notification is not configured")
+	}
+	notConfigured := fmt.Errorf("%s notification is not configured", notify.WebHookType)
+
+	card := NewLarkCard()
+	card.SetConfig(true)
+	card.SetHeader("orange", title, "plain_text")
+	card.AddI18NElementsZhcnFeild(content, true)
+	if link != "" {
+		card.AddI18NElementsZhcnAction("点击查看更多信息", link)
+	}
+
+	switch notify.WebHookType {
+	case setting.NotifyWebHookTypeMSTeam:
+		if notify.MSTeamsNotificationConfig == nil {
+			return notConfigured
+		}
+		return w.sendMSTeamsMessage(notify.MSTeamsNotificationConfig.HookAddress, title, fmt.Sprintf("%s\n%s", title, content), link, notify.MSTeamsNotificationConfig.AtEmails, config.StatusFailed)
+	case setting.NotifyWebHookTypeDingDing:
+		if notify.DingDingNotificationConfig == nil {
+			return notConfigured
+		}
+		return w.sendDingDingMessage(notify.DingDingNotificationConfig.HookAddress, title, fmt.Sprintf("### %s\n%s", title, content), link, notify.DingDingNotificationConfig.AtMobiles, notify.DingDingNotificationConfig.IsAtAll)
+	case setting.NotifyWebHookTypeFeishu:
+		if notify.LarkHookNotificationConfig == nil {
+			return notConfigured
+		}
+		return w.sendFeishuMessage(notify.LarkHookNotificationConfig.HookAddress, card)
+	case setting.NotifyWebHookTypeMail:
+		if notify.MailNotificationConfig == nil {
+			return notConfigured
+		}
+		return w.sendMailMessage(title, content, notify.MailNotificationConfig.TargetUsers)
+	case setting.NotifyWebhookTypeFeishuApp:
+		if notify.LarkGroupNotificationConfig == nil || notify.LarkGroupNotificationConfig.Chat == nil {
+			return notConfigured
+		}
+		client, err := larkservice.GetLarkClientByIMAppID(notify.LarkGroupNotificationConfig.AppID)
+		if err != nil {
+			return fmt.Errorf("failed to create lark client appID: %s, error: %s", notify.LarkGroupNotificationConfig.AppID, err)
+		}
+		messageContent, err := json.Marshal(card)
+		if err != nil {
+			return fmt.Errorf("failed to parse the lark card, error: %s", err)
+		}
+		return w.sendFeishuMessageFromClient(client, LarkReceiverTypeChat, notify.LarkGroupNotificationConfig.Chat.ChatID, LarkMessageTypeCard, string(messageContent))
+	case setting.NotifyWebHookTypeWechatWork:
+		if notify.WechatNotificationConfig == nil {
+			return notConfigured
+		}
+		content = fmt.Sprintf("### %s\n%s", title, content)
+		if link != "" {
+			content = fmt.Sprintf("%s\n[点击查看更多信息](%s)", content, link)
+		}
+		return w.SendWeChatWorkMessage(WeChatTextTypeMarkdown, notify.WechatNotificationConfig.HookAddress, "", "", content)
+	default:
+		return fmt.Errorf("notify type %s is not supported", notify.WebHookType)
+	}
+}
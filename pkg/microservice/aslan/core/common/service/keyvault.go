@@ -19,6 +19,7 @@ package service
 import (
 	commonmodels "github.com/koderover/zadig/v2/pkg/microservice/aslan/core/common/repository/models"
 	commonrepo "github.com/koderover/zadig/v2/pkg/microservice/aslan/core/common/repository/mongodb"
+	"github.com/koderover/zadig/v2/pkg/microservice/aslan/core/common/service/keyvault"
 	e "github.com/koderover/zadig/v2/pkg/tool/errors"
 	"github.com/koderover/zadig/v2/pkg/tool/log"
 )
@@ -35,6 +36,7 @@ type KeyVaultListResponse struct {
 // ListAvailableKeyVaultItemsForProject returns merged KV items for a project
 // It merges project-specific items with system-wide items, where project items take priority
 // If getSensitiveValue is true, sensitive values are returned; otherwise they are removed
+// Items stored in external providers are returned as placeholders resolved at job execution time
 func ListAvailableKeyVaultItemsForProject(projectName string, getSensitiveValue bool) (*KeyVaultListResponse, error) {
 	// 1. List all KV pairs for the project
 	projectItems, err := commonrepo.NewKeyVaultItemColl().List(&commonrepo.KeyVaultItemListOption{
@@ -82,9 +84,7 @@ func ListAvailableKeyVaultItemsForProject(projectName string, getSensitiveValue
 	// Handle sensitive values and group by group name
 	groupMap := make(map[string][]*commonmodels.KeyVaultItem)
 	for _, item := range mergedMap {
-		if !getSensitiveValue && item.IsSensitive {
-			item.Value = ""
-		}
+		setItemValue(item, getSensitiveValue)
 		groupMap[item.Group] = append(groupMap[item.Group], item)
 	}
 
@@ -118,9 +118,7 @@ func ListAvailableKeyVaultItemsForSystem(getSensitiveValue bool) (*KeyVaultListR
 	groupSet := make(map[string]bool)
 
 	for _, item := range systemItems {
-		if !getSensitiveValue && item.IsSensitive {
-			item.Value = ""
-		}
+		setItemValue(item, getSensitiveValue)
 		groupMap[item.Group] = append(groupMap[item.Group], item)
 		if !groupSet[item.Group] {
 			groupOrder = append(groupOrder, item.Group)
@@ -140,3 +138,13 @@ func ListAvailableKeyVaultItemsForSystem(getSensitiveValue bool) (*KeyVaultListR
 
 	return &KeyVaultListResponse{Groups: groups}, nil
 }
+
+func setItemValue(item *commonmodels.KeyVaultItem, getSensitiveValue bool) {
+	if !getSensitiveValue && item.IsSensitive {
+		item.Value = ""
+		return
+	}
+	if keyvault.IsExternal(item) {
+		item.Value = keyvault.Placeholder(item)
+	}
+}
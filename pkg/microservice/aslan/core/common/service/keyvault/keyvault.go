@@ -0,0 +1,180 @@
+/*
+Copyright 2025 The KodeRover Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package keyvault resolves key vault items stored in external secret backends. The values of external items are
+// never written to task documents, a placeholder is rendered in their place and replaced with the real value when
+// the job is handed to the executor.
+package keyvault
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"sort"
+
+	"github.com/koderover/zadig/v2/pkg/microservice/aslan/config"
+	commonmodels "github.com/koderover/zadig/v2/pkg/microservice/aslan/core/common/repository/models"
+	commonrepo "github.com/koderover/zadig/v2/pkg/microservice/aslan/core/common/repository/mongodb"
+	"github.com/koderover/zadig/v2/pkg/tool/clientmanager"
+	"github.com/koderover/zadig/v2/pkg/tool/secretstore"
+)
+
+var placeholderRegex = regexp.MustCompile(`\[\[keyvault:([0-9a-f]{24})\]\]`)
+
+// IsExternal reports whether the value of the item lives in an external provider.
+func IsExternal(item *commonmodels.KeyVaultItem) bool {
+	return item.ProviderID != ""
+}
+
+// Placeholder returns the string rendered in place of the value of an item, it's resolved by the Resolver right
+// before the value is handed to the executor.
+func Placeholder(item *commonmodels.KeyVaultItem) string {
+	return fmt.Sprintf("[[keyvault:%s]]", item.ID.Hex())
+}
+
+// HasPlaceholder reports whether the string references any external item.
+func HasPlaceholder(s string) bool {
+	return placeholderRegex.MatchString(s)
+}
+
+// NewStore creates the secret store of the provider.
+func NewStore(provider *commonmodels.KeyVaultProvider) (secretstore.Store, error) {
+	switch provider.Type {
+	case config.KeyVaultProviderTypeVault:
+		if provider.Vault == nil {
+			return nil, fmt.Errorf("vault config of provider %s is empty", provider.Name)
+		}
+		return secretstore.NewVaultStore(&secretstore.VaultConfig{
+			Address:   provider.Vault.Address,
+			Token:     provider.Vault.Token,
+			Namespace: provider.Vault.Namespace,
+			Mount:     provider.Vault.Mount,
+		})
+	case config.KeyVaultProviderTypeAWSSecretsManager:
+		if provider.AWS == nil {
+			return nil, fmt.Errorf("aws config of provider %s is empty", provider.Name)
+		}
+		return secretstore.NewAWSSecretsManagerStore(&secretstore.AWSSecretsManagerConfig{
+			Region:          provider.AWS.Region,
+			AccessKeyID:     provider.AWS.AccessKeyID,
+			SecretAccessKey: provider.AWS.SecretAccessKey,
+		})
+	case config.KeyVaultProviderTypeKubernetes:
+		if provider.Kubernetes == nil {
+			return nil, fmt.Errorf("kubernetes config of provider %s is empty", provider.Name)
+		}
+		client, err := clientmanager.NewKubeClientManager().GetKubernetesClientSet(provider.Kubernetes.ClusterID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get kube client of cluster %s: %s", provider.Kubernetes.ClusterID, err)
+		}
+		return secretstore.NewKubernetesStore(client, provider.Kubernetes.Namespace)
+	default:
+		return nil, fmt.Errorf("unsupported keyvault provider type: %s", provider.Type)
+	}
+}
+
+// ResolveItem returns the value of the item, reading it from the external provider when there is one.
+func ResolveItem(ctx context.Context, item *commonmodels.KeyVaultItem) (string, error) {
+	return NewResolver().resolveItem(ctx, item)
+}
+
+type ResolvedItem struct {
+	Group string
+	Key   string
+	Value string
+}
+
+// Resolver replaces placeholders with the values of external items. Providers and values are cached so a job
+// reads each secret once.
+type Resolver struct {
+	stores map[string]secretstore.Store
+	items  map[string]*ResolvedItem
+}
+
+func NewResolver() *Resolver {
+	return &Resolver{
+		stores: make(map[string]secretstore.Store),
+		items:  make(map[string]*ResolvedItem),
+	}
+}
+
+// Resolve replaces all placeholders in the string with the values they stand for.
+func (r *Resolver) Resolve(ctx context.Context, s string) (string, error) {
+	var resolveErr error
+	resp := placeholderRegex.ReplaceAllStringFunc(s, func(match string) string {
+		if resolveErr != nil {
+			return match
+		}
+		id := placeholderRegex.FindStringSubmatch(match)[1]
+		if resolved, ok := r.items[id]; ok {
+			return resolved.Value
+		}
+
+		item, err := commonrepo.NewKeyVaultItemColl().FindByID(id)
+		if err != nil {
+			resolveErr = fmt.Errorf("failed to find keyvault item %s: %s", id, err)
+			return match
+		}
+		value, err := r.resolveItem(ctx, item)
+		if err != nil {
+			resolveErr = err
+			return match
+		}
+		r.items[id] = &ResolvedItem{Group: item.Group, Key: item.Key, Value: value}
+		return value
+	})
+	return resp, resolveErr
+}
+
+// Resolved returns the items resolved so far, callers use it to mask the values in logs.
+func (r *Resolver) Resolved() []*ResolvedItem {
+	resp := make([]*ResolvedItem, 0, len(r.items))
+	for _, item := range r.items {
+		resp = append(resp, item)
+	}
+	sort.Slice(resp, func(i, j int) bool {
+		if resp[i].Group != resp[j].Group {
+			return resp[i].Group < resp[j].Group
+		}
+		return resp[i].Key < resp[j].Key
+	})
+	return resp
+}
+
+func (r *Resolver) resolveItem(ctx context.Context, item *commonmodels.KeyVaultItem) (string, error) {
+	if !IsExternal(item) {
+		return item.Value, nil
+	}
+
+	store, ok := r.stores[item.ProviderID]
+	if !ok {
+		provider, err := commonrepo.NewKeyVaultProviderColl().GetByID(item.ProviderID)
+		if err != nil {
+			return "", fmt.Errorf("failed to find keyvault provider %s of item %s/%s: %s", item.ProviderID, item.Group, item.Key, err)
+		}
+		store, err = NewStore(provider)
+		if err != nil {
+			return "", err
+		}
+		r.stores[item.ProviderID] = store
+	}
+
+	value, err := store.Get(ctx, item.Reference)
+	if err != nil {
+		return "", fmt.Errorf("failed to read keyvault item %s/%s: %s", item.Group, item.Key, err)
+	}
+	return value, nil
+}
@@ -0,0 +1,87 @@
+/*
+Copyright 2025 The KodeRover Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package kube
+
+import (
+	"github.com/pkg/errors"
+	"k8s.io/apimachinery/pkg/util/sets"
+
+	commonmodels "github.com/koderover/zadig/v2/pkg/microservice/aslan/core/common/repository/models"
+	commonutil "github.com/koderover/zadig/v2/pkg/microservice/aslan/core/common/util"
+	"github.com/koderover/zadig/v2/pkg/tool/kustomize"
+	"github.com/koderover/zadig/v2/pkg/util"
+)
+
+// ServiceTemplateYaml returns the yaml of the service template used in the environment.
+// For kustomize services the overlay of the environment is built, and the images of the containers are overridden by
+// the images field of kustomize, so that the images referenced outside of containers are replaced too.
+func ServiceTemplateYaml(svcTmpl *commonmodels.Service, envName string, containers []*commonmodels.Container) (string, error) {
+	if svcTmpl.Kustomize == nil {
+		return svcTmpl.Yaml, nil
+	}
+
+	overlay := svcTmpl.Kustomize.Overlay(envName)
+	manifests, err := kustomize.Build(svcTmpl.Kustomize.Files, overlay, nil)
+	if err != nil {
+		return "", errors.Wrapf(err, "failed to build overlay %s of service %s", overlay, svcTmpl.ServiceName)
+	}
+	if len(containers) == 0 {
+		return manifests, nil
+	}
+
+	// the images field matches images by the name used in the kustomization, find it by the container name
+	built := &commonmodels.Service{
+		ServiceName: svcTmpl.ServiceName,
+		ProductName: svcTmpl.ProductName,
+		KubeYamls:   util.SplitYaml(manifests),
+	}
+	if err := commonutil.SetCurrentContainerImages(built); err != nil {
+		return "", errors.Wrapf(err, "failed to find containers of service %s", svcTmpl.ServiceName)
+	}
+
+	containerMap := buildContainerMap(containers)
+	images := make([]*kustomize.Image, 0)
+	imageNames := sets.NewString()
+	for _, container := range built.Containers {
+		target, ok := containerMap[container.Name]
+		if !ok || target.Image == "" || target.Image == container.Image {
+			continue
+		}
+		name, _, _ := kustomize.ParseImage(container.Image)
+		if imageNames.Has(name) {
+			continue
+		}
+		imageNames.Insert(name)
+
+		newName, newTag, digest := kustomize.ParseImage(target.Image)
+		images = append(images, &kustomize.Image{
+			Name:    name,
+			NewName: newName,
+			NewTag:  newTag,
+			Digest:  digest,
+		})
+	}
+	if len(images) == 0 {
+		return manifests, nil
+	}
+
+	manifests, err = kustomize.Build(svcTmpl.Kustomize.Files, overlay, images)
+	if err != nil {
+		return "", errors.Wrapf(err, "failed to build overlay %s of service %s", overlay, svcTmpl.ServiceName)
+	}
+	return manifests, nil
+}
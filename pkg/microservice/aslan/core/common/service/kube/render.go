@@ -27,6 +27,7 @@ import (
 	appsv1 "k8s.io/api/apps/v1"
 	batchv1 "k8s.io/api/batch/v1"
 	batchv1beta1 "k8s.io/api/batch/v1beta1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/runtime"
 	yamlutil "k8s.io/apimachinery/pkg/util/yaml"
 	"k8s.io/cli-runtime/pkg/printers"
@@ -220,6 +221,41 @@ func ReplaceWorkloadImages(rawYaml string, images []*commonmodels.Container) (st
 			if err != nil {
 				return "", nil, err
 			}
+		case setting.Rollout:
+			// only Argo Rollouts contain pod templates, Kruise Rollouts manage other workloads
+			if !strings.HasPrefix(resKind.APIVersion, getter.ArgoRolloutGVK.Group+"/") {
+				break
+			}
+			rollout := &unstructured.Unstructured{}
+			if err := decoder.Decode(&rollout.Object); err != nil {
+				return "", nil, fmt.Errorf("unmarshal Rollout error: %v", err)
+			}
+			workloadRes = append(workloadRes, &WorkloadResource{
+				Name: resKind.Metadata.Name,
+				Type: resKind.Kind,
+			})
+			for _, field := range []string{"containers", "initContainers"} {
+				containers, found, err := unstructured.NestedSlice(rollout.Object, "spec", "template", "spec", field)
+				if err != nil || !found {
+					continue
+				}
+				for _, c := range containers {
+					container, ok := c.(map[string]interface{})
+					if !ok {
+						continue
+					}
+					if image, ok := imageMap[fmt.Sprint(container["name"])]; ok {
+						container["image"] = image.Image
+					}
+				}
+				if err := unstructured.SetNestedSlice(rollout.Object, containers, "spec", "template", "spec", field); err != nil {
+					return "", nil, err
+				}
+			}
+			yamlStr, err = resourceToYaml(rollout)
+			if err != nil {
+				return "", nil, err
+			}
 		case setting.CronJob:
 			if resKind.APIVersion == batchv1beta1.SchemeGroupVersion.String() {
 				cronJob := &batchv1beta1.CronJob{}
@@ -393,12 +429,16 @@ func FetchCurrentAppliedYaml(option *GeneSvcYamlOption) (string, int, error) {
 		return "", 0, errors.Wrapf(err, "failed to find service %s with revision %d", option.ServiceName, curProductSvc.Revision)
 	}
 
-	fullRenderedYaml, err := RenderServiceYaml(prodSvcTemplate.Yaml, option.ProductName, option.ServiceName, curProductSvc.GetServiceRender())
+	mergedContainers := mergeContainers(prodSvcTemplate.Containers, curProductSvc.Containers)
+	svcYaml, err := ServiceTemplateYaml(prodSvcTemplate, productInfo.EnvName, mergedContainers)
+	if err != nil {
+		return "", 0, err
+	}
+	fullRenderedYaml, err := RenderServiceYaml(svcYaml, option.ProductName, option.ServiceName, curProductSvc.GetServiceRender())
 	if err != nil {
 		return "", 0, err
 	}
 	fullRenderedYaml = ParseSysKeys(productInfo.Namespace, productInfo.EnvName, option.ProductName, option.ServiceName, fullRenderedYaml)
-	mergedContainers := mergeContainers(prodSvcTemplate.Containers, curProductSvc.Containers)
 	fullRenderedYaml, _, err = ReplaceWorkloadImages(fullRenderedYaml, mergedContainers)
 	return fullRenderedYaml, 0, nil
 }
@@ -564,7 +604,11 @@ func GenerateRenderedYaml(option *GeneSvcYamlOption) (string, int, []*WorkloadRe
 
 	serviceRender.OverrideYaml.YamlContent = mergedYaml
 
-	fullRenderedYaml, err := RenderServiceYaml(latestSvcTemplate.Yaml, option.ProductName, option.ServiceName, serviceRender)
+	svcYaml, err := ServiceTemplateYaml(latestSvcTemplate, productInfo.EnvName, mergeContainers(curContainers, svcContainersInProduct, option.Containers))
+	if err != nil {
+		return "", 0, nil, err
+	}
+	fullRenderedYaml, err := RenderServiceYaml(svcYaml, option.ProductName, option.ServiceName, serviceRender)
 	if err != nil {
 		return "", 0, nil, err
 	}
@@ -607,7 +651,12 @@ func RenderEnvService(prod *commonmodels.Product, serviceRender *template.Servic
 
 func RenderEnvServiceWithTempl(prod *commonmodels.Product, serviceRender *template.ServiceRender, service *commonmodels.ProductService, svcTmpl *commonmodels.Service) (yaml string, err error) {
 	// Note only the keys in TemplateService.ServiceVar can work
-	parsedYaml, err := RenderServiceYaml(svcTmpl.Yaml, prod.ProductName, svcTmpl.ServiceName, serviceRender)
+	svcYaml, err := ServiceTemplateYaml(svcTmpl, prod.EnvName, service.Containers)
+	if err != nil {
+		log.Errorf("failed to build service yaml, err: %s", err)
+		return "", err
+	}
+	parsedYaml, err := RenderServiceYaml(svcYaml, prod.ProductName, svcTmpl.ServiceName, serviceRender)
 	if err != nil {
 		log.Errorf("failed to render service yaml, err: %s", err)
 		return "", err
@@ -20,6 +20,7 @@ import (
 	appsv1 "k8s.io/api/apps/v1"
 	batchv1 "k8s.io/api/batch/v1"
 	batchv1beta1 "k8s.io/api/batch/v1beta1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/client-go/kubernetes"
 	crClient "sigs.k8s.io/controller-runtime/pkg/client"
 
@@ -83,3 +84,22 @@ func FetchSelectedWorkloads(namespace string, Resource []*WorkloadResource, kube
 	}
 	return deployments, statefulSets, cronJobs, betaCronJobs, jobs, nil
 }
+
+// FetchSelectedArgoRollouts fetches the Argo Rollouts in the resources, Kruise Rollouts are skipped since they are not workloads.
+func FetchSelectedArgoRollouts(namespace string, resources []*WorkloadResource, kubeclient crClient.Client) []*unstructured.Unstructured {
+	var rollouts []*unstructured.Unstructured
+	for _, item := range resources {
+		if item.Type != setting.Rollout {
+			continue
+		}
+		rollout, found, err := getter.GetArgoRollout(namespace, item.Name, kubeclient)
+		if err != nil {
+			log.Errorf("failed to fetch rollout %s, error: %v", item.Name, err)
+			continue
+		}
+		if found {
+			rollouts = append(rollouts, rollout)
+		}
+	}
+	return rollouts
+}
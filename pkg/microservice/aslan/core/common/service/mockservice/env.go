@@ -0,0 +1,498 @@
+/*
+Copyright 2025 The KodeRover Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package mockservice
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+
+	networkingv1alpha3 "istio.io/api/networking/v1alpha3"
+	istionetworkingv1alpha3 "istio.io/client-go/pkg/apis/networking/v1alpha3"
+	versionedclient "istio.io/client-go/pkg/clientset/versioned"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/koderover/zadig/v2/pkg/microservice/aslan/config"
+	commonmodels "github.com/koderover/zadig/v2/pkg/microservice/aslan/core/common/repository/models"
+	commonrepo "github.com/koderover/zadig/v2/pkg/microservice/aslan/core/common/repository/mongodb"
+	"github.com/koderover/zadig/v2/pkg/microservice/aslan/core/common/service/kube"
+	"github.com/koderover/zadig/v2/pkg/setting"
+	"github.com/koderover/zadig/v2/pkg/tool/clientmanager"
+	"github.com/koderover/zadig/v2/pkg/tool/kube/updater"
+	kubeutil "github.com/koderover/zadig/v2/pkg/tool/kube/util"
+	"github.com/koderover/zadig/v2/pkg/tool/log"
+	"github.com/koderover/zadig/v2/pkg/types"
+	"github.com/koderover/zadig/v2/pkg/util"
+)
+
+const (
+	mockContainerName = "mock-responder"
+	mockConfigDir     = "/etc/zadig-mock"
+	mockConfigVolume  = "mock-config"
+	// configChecksumAnnotation restarts the mock responder when the mock config changes
+	configChecksumAnnotation = "zadig.koderover.io/mock-config-checksum"
+)
+
+type EnvMockInfo struct {
+	*commonmodels.EnvMock
+	Protocol       types.MockProtocol `json:"protocol"`
+	K8sServiceName string             `json:"k8s_service_name"`
+	Port           int                `json:"port"`
+	Hosts          []string           `json:"hosts"`
+	// LatestRevision is the latest revision of the mock, the mock in the environment is outdated if it is newer than
+	// the deployed revision
+	LatestRevision int64 `json:"latest_revision"`
+}
+
+// JournalURL is the url of the request journal of the mock in the namespace, it is reachable from the same cluster
+func JournalURL(namespace, k8sServiceName string) string {
+	return fmt.Sprintf("http://%s.%s.svc.cluster.local:%d%s", k8sServiceName, namespace, types.MockAdminPort, types.MockJournalPath)
+}
+
+func ListEnvMocks(projectName, envName string) ([]*EnvMockInfo, error) {
+	envMocks, err := commonrepo.NewEnvMockColl().List(projectName, envName)
+	if err != nil {
+		return nil, err
+	}
+
+	resp := make([]*EnvMockInfo, 0, len(envMocks))
+	for _, envMock := range envMocks {
+		info := &EnvMockInfo{EnvMock: envMock}
+		mock, err := commonrepo.NewMockServiceColl().Find(projectName, envMock.ServiceName, envMock.Revision)
+		if err != nil {
+			log.Warnf("failed to find revision %d of the mock of service %s: %s", envMock.Revision, envMock.ServiceName, err)
+		} else {
+			info.Protocol = mock.Protocol
+			info.K8sServiceName = mock.K8sServiceName
+			info.Port = mock.Port
+			info.Hosts = mock.Hosts
+		}
+		if latest, err := GetServiceMock(projectName, envMock.ServiceName); err == nil && latest != nil {
+			info.LatestRevision = latest.Revision
+		}
+		resp = append(resp, info)
+	}
+	return resp, nil
+}
+
+// DeployEnvMock deploys the revision of the mock of the service to the environment, the latest revision is deployed
+// if revision is 0. The mock takes the k8s service name of the mocked service, so the service must not be deployed
+// in the environment at the same time.
+func DeployEnvMock(ctx context.Context, projectName, envName, serviceName string, revision int64, username string) error {
+	env, err := findEnv(projectName, envName)
+	if err != nil {
+		return err
+	}
+	mock, err := commonrepo.NewMockServiceColl().Find(projectName, serviceName, revision)
+	if err != nil {
+		return fmt.Errorf("failed to find the mock of service %s: %s", serviceName, err)
+	}
+
+	kclient, err := clientmanager.NewKubeClientManager().GetControllerRuntimeClient(env.ClusterID)
+	if err != nil {
+		return fmt.Errorf("failed to get kube client: %s", err)
+	}
+	istioClient, err := clientmanager.NewKubeClientManager().GetIstioClientSet(env.ClusterID)
+	if err != nil {
+		return fmt.Errorf("failed to get istio client: %s", err)
+	}
+
+	// the previous revision may be reached by another k8s service name
+	if deployed, err := commonrepo.NewEnvMockColl().Find(projectName, envName, serviceName); err == nil {
+		previous, err := commonrepo.NewMockServiceColl().Find(projectName, serviceName, deployed.Revision)
+		if err == nil && previous.K8sServiceName != mock.K8sServiceName {
+			if err := removeMockResources(ctx, env, previous, kclient, istioClient); err != nil {
+				return err
+			}
+		}
+	}
+
+	svc := &corev1.Service{}
+	err = kclient.Get(ctx, client.ObjectKey{Name: mock.K8sServiceName, Namespace: env.Namespace}, svc)
+	if err == nil && svc.Labels[types.ZadigLabelKeyMock] != serviceName {
+		if svc.Labels[types.ZadigLabelKeyGlobalOwner] != types.Zadig {
+			return fmt.Errorf("service %s already exists in env %s, the mocked service should be removed from the env first", mock.K8sServiceName, envName)
+		}
+		// the service created by zadig in a sub env routes the requests to the base env, the mock takes its place
+		if err := kube.EnsureDeleteK8sService(ctx, env.Namespace, mock.K8sServiceName, kclient, true); err != nil {
+			return fmt.Errorf("failed to delete service %s: %s", mock.K8sServiceName, err)
+		}
+	} else if kubeutil.IgnoreNotFoundError(err) != nil {
+		return fmt.Errorf("failed to find service %s: %s", mock.K8sServiceName, err)
+	}
+
+	if err := applyMockResources(env, mock, kclient); err != nil {
+		return err
+	}
+
+	if err := kube.EnsureUpdateZadigService(ctx, env, mock.K8sServiceName, kclient, istioClient); err != nil {
+		return fmt.Errorf("failed to update the routing of service %s: %s", mock.K8sServiceName, err)
+	}
+	if err := ensureHostRouting(ctx, env, mock, istioClient); err != nil {
+		return fmt.Errorf("failed to route the hosts to the mock: %s", err)
+	}
+
+	return commonrepo.NewEnvMockColl().Upsert(&commonmodels.EnvMock{
+		ProductName: projectName,
+		EnvName:     envName,
+		ServiceName: serviceName,
+		Revision:    mock.Revision,
+		UpdateBy:    username,
+	})
+}
+
+// DeleteEnvMock removes the mock from the environment, the sub environments route the requests of the service to the
+// base environment again
+func DeleteEnvMock(projectName, envName, serviceName string) error {
+	ctx := context.TODO()
+	env, err := findEnv(projectName, envName)
+	if err != nil {
+		return err
+	}
+	envMock, err := commonrepo.NewEnvMockColl().Find(projectName, envName, serviceName)
+	if err != nil {
+		return fmt.Errorf("mock of service %s is not deployed in env %s", serviceName, envName)
+	}
+
+	kclient, err := clientmanager.NewKubeClientManager().GetControllerRuntimeClient(env.ClusterID)
+	if err != nil {
+		return fmt.Errorf("failed to get kube client: %s", err)
+	}
+	istioClient, err := clientmanager.NewKubeClientManager().GetIstioClientSet(env.ClusterID)
+	if err != nil {
+		return fmt.Errorf("failed to get istio client: %s", err)
+	}
+
+	mock, err := commonrepo.NewMockServiceColl().Find(projectName, serviceName, envMock.Revision)
+	if err != nil {
+		return fmt.Errorf("failed to find revision %d of the mock of service %s: %s", envMock.Revision, serviceName, err)
+	}
+	if err := removeMockResources(ctx, env, mock, kclient, istioClient); err != nil {
+		return err
+	}
+
+	if env.ShareEnv.Enable && !env.ShareEnv.IsBase {
+		if err := kube.EnsureGrayEnvConfig(ctx, env, kclient, istioClient); err != nil {
+			return fmt.Errorf("failed to ensure gray env config: %s", err)
+		}
+	} else if env.IstioGrayscale.Enable && !env.IstioGrayscale.IsBase {
+		if err := kube.EnsureFullPathGrayScaleConfig(ctx, env, kclient, istioClient); err != nil {
+			return fmt.Errorf("failed to ensure full path gray scale config: %s", err)
+		}
+	}
+
+	return commonrepo.NewEnvMockColl().Delete(projectName, envName, serviceName)
+}
+
+// DeleteEnvMocks deletes the mocks of the environment being deleted, the resources are removed too if removeResources
+// is set, which is the case when the namespace is kept
+func DeleteEnvMocks(ctx context.Context, env *commonmodels.Product, removeResources bool) error {
+	if removeResources {
+		envMocks, err := commonrepo.NewEnvMockColl().List(env.ProductName, env.EnvName)
+		if err != nil {
+			return err
+		}
+		if len(envMocks) > 0 {
+			kclient, err := clientmanager.NewKubeClientManager().GetControllerRuntimeClient(env.ClusterID)
+			if err != nil {
+				return fmt.Errorf("failed to get kube client: %s", err)
+			}
+			istioClient, err := clientmanager.NewKubeClientManager().GetIstioClientSet(env.ClusterID)
+			if err != nil {
+				return fmt.Errorf("failed to get istio client: %s", err)
+			}
+			for _, envMock := range envMocks {
+				mock, err := commonrepo.NewMockServiceColl().Find(env.ProductName, envMock.ServiceName, envMock.Revision)
+				if err != nil {
+					log.Warnf("failed to find revision %d of the mock of service %s: %s", envMock.Revision, envMock.ServiceName, err)
+					continue
+				}
+				if err := removeMockResources(ctx, env, mock, kclient, istioClient); err != nil {
+					log.Warnf("failed to remove the mock of service %s: %s", envMock.ServiceName, err)
+				}
+			}
+		}
+	}
+	return commonrepo.NewEnvMockColl().DeleteByEnv(env.ProductName, env.EnvName)
+}
+
+// GetEnvMockJournal returns the requests the mock in the environment received since the unix time in milliseconds
+func GetEnvMockJournal(ctx context.Context, projectName, envName, serviceName string, since int64) ([]*types.MockJournalEntry, error) {
+	env, err := findEnv(projectName, envName)
+	if err != nil {
+		return nil, err
+	}
+	envMock, err := commonrepo.NewEnvMockColl().Find(projectName, envName, serviceName)
+	if err != nil {
+		return nil, fmt.Errorf("mock of service %s is not deployed in env %s", serviceName, envName)
+	}
+	mock, err := commonrepo.NewMockServiceColl().Find(projectName, serviceName, envMock.Revision)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find revision %d of the mock of service %s: %s", envMock.Revision, serviceName, err)
+	}
+
+	clientset, err := clientmanager.NewKubeClientManager().GetKubernetesClientSet(env.ClusterID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get kube client: %s", err)
+	}
+	data, err := clientset.CoreV1().Services(env.Namespace).
+		ProxyGet("http", mock.K8sServiceName, strconv.Itoa(types.MockAdminPort), types.MockJournalPath, map[string]string{"since": strconv.FormatInt(since, 10)}).
+		DoRaw(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get the request journal of the mock: %s", err)
+	}
+
+	resp := make([]*types.MockJournalEntry, 0)
+	if err := json.Unmarshal(data, &resp); err != nil {
+		return nil, fmt.Errorf("failed to parse the request journal of the mock: %s", err)
+	}
+	return resp, nil
+}
+
+func findEnv(projectName, envName string) (*commonmodels.Product, error) {
+	env, err := commonrepo.NewProductColl().Find(&commonrepo.ProductFindOptions{
+		Name:       projectName,
+		EnvName:    envName,
+		Production: util.GetBoolPointer(false),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to find env %s: %s", envName, err)
+	}
+	return env, nil
+}
+
+func mockResourceName(mock *commonmodels.MockService) string {
+	return fmt.Sprintf("%s-mock", mock.K8sServiceName)
+}
+
+func applyMockResources(env *commonmodels.Product, mock *commonmodels.MockService, kclient client.Client) error {
+	mockConfig, err := json.Marshal(&types.MockConfig{
+		Name:          mock.ServiceName,
+		Protocol:      mock.Protocol,
+		Port:          mock.Port,
+		DescriptorSet: mock.DescriptorSet,
+		Stubs:         mock.Stubs,
+	})
+	if err != nil {
+		return err
+	}
+
+	name := mockResourceName(mock)
+	selector := map[string]string{types.ZadigLabelKeyMock: mock.ServiceName}
+	labels := map[string]string{
+		types.ZadigLabelKeyMock: mock.ServiceName,
+		setting.ProductLabel:    env.ProductName,
+	}
+
+	cm := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: env.Namespace, Labels: labels},
+		Data:       map[string]string{types.MockConfigFileName: string(mockConfig)},
+	}
+	if err := updater.CreateOrPatchConfigMap(cm, kclient); err != nil {
+		return fmt.Errorf("failed to apply config map %s: %s", name, err)
+	}
+
+	portName := string(mock.Protocol)
+	replicas := int32(1)
+	deployment := &appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: env.Namespace, Labels: labels},
+		Spec: appsv1.DeploymentSpec{
+			Replicas: &replicas,
+			Selector: &metav1.LabelSelector{MatchLabels: selector},
+			Template: corev1.PodTemplateSpec{
+				ObjectMeta: metav1.ObjectMeta{
+					Labels:      labels,
+					Annotations: map[string]string{configChecksumAnnotation: fmt.Sprintf("%x", sha256.Sum256(mockConfig))},
+				},
+				Spec: corev1.PodSpec{
+					Containers: []corev1.Container{
+						{
+							Name:  mockContainerName,
+							Image: config.MockResponderImage(),
+							Ports: []corev1.ContainerPort{
+								{Name: portName, ContainerPort: int32(mock.Port)},
+								{Name: "http-admin", ContainerPort: types.MockAdminPort},
+							},
+							VolumeMounts: []corev1.VolumeMount{{Name: mockConfigVolume, MountPath: mockConfigDir}},
+							ReadinessProbe: &corev1.Probe{
+								ProbeHandler: corev1.ProbeHandler{
+									HTTPGet: &corev1.HTTPGetAction{Path: "/healthz", Port: intstr.FromInt(types.MockAdminPort)},
+								},
+							},
+						},
+					},
+					Volumes: []corev1.Volume{
+						{
+							Name: mockConfigVolume,
+							VolumeSource: corev1.VolumeSource{
+								ConfigMap: &corev1.ConfigMapVolumeSource{LocalObjectReference: corev1.LocalObjectReference{Name: name}},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+	if err := updater.CreateOrPatchDeployment(deployment, kclient); err != nil {
+		return fmt.Errorf("failed to apply deployment %s: %s", name, err)
+	}
+
+	svc := &corev1.Service{
+		ObjectMeta: metav1.ObjectMeta{Name: mock.K8sServiceName, Namespace: env.Namespace, Labels: labels},
+		Spec: corev1.ServiceSpec{
+			Selector: selector,
+			Ports: []corev1.ServicePort{
+				{Name: portName, Port: int32(mock.Port), TargetPort: intstr.FromString(portName)},
+				{Name: "http-admin", Port: types.MockAdminPort, TargetPort: intstr.FromString("http-admin")},
+			},
+		},
+	}
+	if err := updater.CreateOrPatchService(svc, kclient); err != nil {
+		return fmt.Errorf("failed to apply service %s: %s", mock.K8sServiceName, err)
+	}
+	return nil
+}
+
+func removeMockResources(ctx context.Context, env *commonmodels.Product, mock *commonmodels.MockService, kclient client.Client, istioClient versionedclient.Interface) error {
+	svc := &corev1.Service{}
+	err := kclient.Get(ctx, client.ObjectKey{Name: mock.K8sServiceName, Namespace: env.Namespace}, svc)
+	if err == nil && svc.Labels[types.ZadigLabelKeyMock] == mock.ServiceName {
+		if err := kube.EnsureDeleteZadigServiceBySvcName(ctx, env, mock.K8sServiceName, kclient, istioClient); err != nil {
+			return fmt.Errorf("failed to delete the routing of service %s: %s", mock.K8sServiceName, err)
+		}
+		if err := kubeutil.IgnoreNotFoundError(updater.DeleteService(env.Namespace, mock.K8sServiceName, kclient)); err != nil {
+			return fmt.Errorf("failed to delete service %s: %s", mock.K8sServiceName, err)
+		}
+	} else if kubeutil.IgnoreNotFoundError(err) != nil {
+		return fmt.Errorf("failed to find service %s: %s", mock.K8sServiceName, err)
+	}
+
+	name := mockResourceName(mock)
+	if err := kubeutil.IgnoreNotFoundError(updater.DeleteDeploymentAndWait(env.Namespace, name, kclient)); err != nil {
+		return fmt.Errorf("failed to delete deployment %s: %s", name, err)
+	}
+	if err := kubeutil.IgnoreNotFoundError(updater.DeleteConfigMap(env.Namespace, name, kclient)); err != nil {
+		return fmt.Errorf("failed to delete config map %s: %s", name, err)
+	}
+	return deleteHostRouting(ctx, env, mock, istioClient)
+}
+
+// ensureHostRouting routes the requests sent from the environment to the hosts of the mock to the mock, it takes
+// effect only in the environments with istio enabled
+func ensureHostRouting(ctx context.Context, env *commonmodels.Product, mock *commonmodels.MockService, istioClient versionedclient.Interface) error {
+	if len(mock.Hosts) == 0 || !(env.ShareEnv.Enable || env.IstioGrayscale.Enable) {
+		return deleteHostRouting(ctx, env, mock, istioClient)
+	}
+
+	name := mockResourceName(mock)
+	labels := map[string]string{
+		types.ZadigLabelKeyGlobalOwner: types.Zadig,
+		types.ZadigLabelKeyMock:        mock.ServiceName,
+	}
+	ports := make([]*networkingv1alpha3.Port, 0, len(mock.HostPorts))
+	for _, number := range hostPorts(mock) {
+		protocol := "HTTP"
+		if mock.Protocol == types.MockProtocolGRPC {
+			protocol = "GRPC"
+		}
+		ports = append(ports, &networkingv1alpha3.Port{Number: uint32(number), Protocol: protocol, Name: fmt.Sprintf("%s-%d", strings.ToLower(protocol), number)})
+	}
+
+	seObj := &istionetworkingv1alpha3.ServiceEntry{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: env.Namespace, Labels: labels},
+		Spec: networkingv1alpha3.ServiceEntry{
+			Hosts:      mock.Hosts,
+			Ports:      ports,
+			Location:   networkingv1alpha3.ServiceEntry_MESH_EXTERNAL,
+			Resolution: networkingv1alpha3.ServiceEntry_DNS,
+			ExportTo:   []string{"."},
+		},
+	}
+	existedSE, err := istioClient.NetworkingV1alpha3().ServiceEntries(env.Namespace).Get(ctx, name, metav1.GetOptions{})
+	if err == nil {
+		seObj.ResourceVersion = existedSE.ResourceVersion
+		_, err = istioClient.NetworkingV1alpha3().ServiceEntries(env.Namespace).Update(ctx, seObj, metav1.UpdateOptions{})
+	} else if apierrors.IsNotFound(err) {
+		_, err = istioClient.NetworkingV1alpha3().ServiceEntries(env.Namespace).Create(ctx, seObj, metav1.CreateOptions{})
+	}
+	if err != nil {
+		return fmt.Errorf("failed to apply ServiceEntry %s: %s", name, err)
+	}
+
+	vsObj := &istionetworkingv1alpha3.VirtualService{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: env.Namespace, Labels: labels},
+		Spec: networkingv1alpha3.VirtualService{
+			Hosts:    mock.Hosts,
+			ExportTo: []string{"."},
+			Http: []*networkingv1alpha3.HTTPRoute{
+				{
+					Route: []*networkingv1alpha3.HTTPRouteDestination{
+						{
+							Destination: &networkingv1alpha3.Destination{
+								Host: fmt.Sprintf("%s.%s.svc.cluster.local", mock.K8sServiceName, env.Namespace),
+								Port: &networkingv1alpha3.PortSelector{Number: uint32(mock.Port)},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+	existedVS, err := istioClient.NetworkingV1alpha3().VirtualServices(env.Namespace).Get(ctx, name, metav1.GetOptions{})
+	if err == nil {
+		vsObj.ResourceVersion = existedVS.ResourceVersion
+		_, err = istioClient.NetworkingV1alpha3().VirtualServices(env.Namespace).Update(ctx, vsObj, metav1.UpdateOptions{})
+	} else if apierrors.IsNotFound(err) {
+		_, err = istioClient.NetworkingV1alpha3().VirtualServices(env.Namespace).Create(ctx, vsObj, metav1.CreateOptions{})
+	}
+	if err != nil {
+		return fmt.Errorf("failed to apply VirtualService %s: %s", name, err)
+	}
+	return nil
+}
+
+// hostPorts returns the ports of the hosts routed to the mock, all of them are routed to the mock port
+func hostPorts(mock *commonmodels.MockService) []int {
+	if len(mock.HostPorts) > 0 {
+		return mock.HostPorts
+	}
+	if mock.Protocol == types.MockProtocolGRPC {
+		return []int{mock.Port}
+	}
+	return []int{80}
+}
+
+func deleteHostRouting(ctx context.Context, env *commonmodels.Product, mock *commonmodels.MockService, istioClient versionedclient.Interface) error {
+	name := mockResourceName(mock)
+	err := istioClient.NetworkingV1alpha3().VirtualServices(env.Namespace).Delete(ctx, name, metav1.DeleteOptions{})
+	if kubeutil.IgnoreNotFoundError(err) != nil {
+		return fmt.Errorf("failed to delete VirtualService %s: %s", name, err)
+	}
+	err = istioClient.NetworkingV1alpha3().ServiceEntries(env.Namespace).Delete(ctx, name, metav1.DeleteOptions{})
+	if kubeutil.IgnoreNotFoundError(err) != nil {
+		return fmt.Errorf("failed to delete ServiceEntry %s: %s", name, err)
+	}
+	return nil
+}
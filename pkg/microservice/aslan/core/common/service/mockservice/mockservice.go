@@ -0,0 +1,157 @@
+/*
+Copyright 2025 The KodeRover Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package mockservice
+
+import (
+	"fmt"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"k8s.io/apimachinery/pkg/util/validation"
+
+	commonmodels "github.com/koderover/zadig/v2/pkg/microservice/aslan/core/common/repository/models"
+	commonrepo "github.com/koderover/zadig/v2/pkg/microservice/aslan/core/common/repository/mongodb"
+	"github.com/koderover/zadig/v2/pkg/microservice/aslan/core/common/service/repository"
+	"github.com/koderover/zadig/v2/pkg/setting"
+	"github.com/koderover/zadig/v2/pkg/tool/log"
+	"github.com/koderover/zadig/v2/pkg/tool/mockresponder"
+	"github.com/koderover/zadig/v2/pkg/types"
+)
+
+// GetServiceMock returns the latest revision of the mock of the service, nil is returned if the service has no mock
+func GetServiceMock(projectName, serviceName string) (*commonmodels.MockService, error) {
+	mock, err := commonrepo.NewMockServiceColl().Find(projectName, serviceName, 0)
+	if err == mongo.ErrNoDocuments {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to find the mock of service %s: %s", serviceName, err)
+	}
+	return mock, nil
+}
+
+func ListServiceMockRevisions(projectName, serviceName string) ([]*commonmodels.MockService, error) {
+	return commonrepo.NewMockServiceColl().ListRevisions(projectName, serviceName)
+}
+
+// UpdateServiceMock saves the mock as a new revision, the environments keep running the revision deployed to them
+// until the mock is deployed again
+func UpdateServiceMock(projectName, serviceName string, args *commonmodels.MockService, username string) (*commonmodels.MockService, error) {
+	_, err := repository.QueryTemplateService(&commonrepo.ServiceFindOption{
+		ProductName:   projectName,
+		ServiceName:   serviceName,
+		ExcludeStatus: setting.ProductStatusDeleting,
+	}, false)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find service %s: %s", serviceName, err)
+	}
+
+	args.ProductName = projectName
+	args.ServiceName = serviceName
+	if args.K8sServiceName == "" {
+		args.K8sServiceName = serviceName
+	}
+	if args.Protocol == "" {
+		args.Protocol = types.MockProtocolHTTP
+	}
+	if err := Validate(args); err != nil {
+		return nil, err
+	}
+
+	revision, err := commonrepo.NewCounterColl().GetNextSeq(fmt.Sprintf(setting.MockServiceCounterName, serviceName, projectName))
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate the revision of the mock: %s", err)
+	}
+	args.ID = primitive.NilObjectID
+	args.Revision = revision
+	args.CreateBy = username
+	args.CreateTime = time.Now().Unix()
+	if err := commonrepo.NewMockServiceColl().Create(args); err != nil {
+		return nil, fmt.Errorf("failed to save the mock of service %s: %s", serviceName, err)
+	}
+	return args, nil
+}
+
+// DeleteServiceMock removes the mock of the service from the environments and deletes all of its revisions
+func DeleteServiceMock(projectName, serviceName string) error {
+	envMocks, err := commonrepo.NewEnvMockColl().ListByService(projectName, serviceName)
+	if err != nil {
+		return err
+	}
+	for _, envMock := range envMocks {
+		if err := DeleteEnvMock(projectName, envMock.EnvName, serviceName); err != nil {
+			log.Errorf("failed to delete the mock of service %s in env %s: %s", serviceName, envMock.EnvName, err)
+		}
+	}
+	return commonrepo.NewMockServiceColl().Delete(projectName, serviceName)
+}
+
+func Validate(mock *commonmodels.MockService) error {
+	if errs := validation.IsDNS1035Label(mock.K8sServiceName); len(errs) > 0 {
+		return fmt.Errorf("invalid k8s service name %s: %v", mock.K8sServiceName, errs)
+	}
+	if mock.Port <= 0 || mock.Port > 65535 || mock.Port == types.MockAdminPort {
+		return fmt.Errorf("invalid port %d, the port should be in 1-65535 and not %d", mock.Port, types.MockAdminPort)
+	}
+	for _, host := range mock.Hosts {
+		if errs := validation.IsDNS1123Subdomain(host); len(errs) > 0 {
+			return fmt.Errorf("invalid host %s: %v", host, errs)
+		}
+	}
+	hostPorts := make(map[int]bool)
+	for _, port := range mock.HostPorts {
+		if port <= 0 || port > 65535 || hostPorts[port] {
+			return fmt.Errorf("invalid or duplicated host port %d", port)
+		}
+		hostPorts[port] = true
+	}
+
+	switch mock.Protocol {
+	case types.MockProtocolHTTP:
+	case types.MockProtocolGRPC:
+		if mock.DescriptorSet == "" {
+			return fmt.Errorf("descriptor set is required for grpc mocks")
+		}
+		if _, err := mockresponder.ParseDescriptorSet(mock.DescriptorSet); err != nil {
+			return err
+		}
+	default:
+		return fmt.Errorf("unsupported protocol %s", mock.Protocol)
+	}
+
+	names := make(map[string]bool)
+	for _, stub := range mock.Stubs {
+		if stub.Name == "" {
+			return fmt.Errorf("stub name is required")
+		}
+		if names[stub.Name] {
+			return fmt.Errorf("duplicated stub %s", stub.Name)
+		}
+		names[stub.Name] = true
+		if stub.Response == nil {
+			return fmt.Errorf("response of stub %s is required", stub.Name)
+		}
+		if stub.Response.Delay < 0 {
+			return fmt.Errorf("invalid delay of stub %s", stub.Name)
+		}
+		if err := mockresponder.ValidateRequest(stub.Request); err != nil {
+			return fmt.Errorf("invalid request of stub %s: %s", stub.Name, err)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,96 @@
+/*
+Copyright 2025 The KodeRover Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package resourceprofile
+
+import (
+	"go.uber.org/zap"
+
+	commonmodels "github.com/koderover/zadig/v2/pkg/microservice/aslan/core/common/repository/models"
+	commonrepo "github.com/koderover/zadig/v2/pkg/microservice/aslan/core/common/repository/mongodb"
+	"github.com/koderover/zadig/v2/pkg/setting"
+	"github.com/koderover/zadig/v2/pkg/tool/resourceusage"
+)
+
+const (
+	ConfigTypeBuild   = "build"
+	ConfigTypeTesting = "testing"
+
+	// recentRuns is the number of latest runs the recommendation is calculated from
+	recentRuns = 20
+)
+
+// Record saves the resource usage of a run of the build or testing
+func Record(args *commonmodels.JobResourceUsage) error {
+	return commonrepo.NewJobResourceUsageColl().Create(args)
+}
+
+// Recommend calculates the recommended resource spec of the build or testing from its recent runs,
+// it returns nil if there are not enough runs
+func Recommend(projectName, configType, configName string) (*commonmodels.ResourceRecommendation, error) {
+	records, err := commonrepo.NewJobResourceUsageColl().ListRecent(projectName, configType, configName, recentRuns)
+	if err != nil {
+		return nil, err
+	}
+
+	usages := make([]*resourceusage.Usage, 0, len(records))
+	cpuPeaks, memoryPeaks := make([]int, 0, len(records)), make([]int, 0, len(records))
+	for _, record := range records {
+		usages = append(usages, &resourceusage.Usage{
+			CpuPeak:    record.CpuPeak,
+			CpuAvg:     record.CpuAvg,
+			MemoryPeak: record.MemoryPeak,
+			MemoryAvg:  record.MemoryAvg,
+			OOMKilled:  record.OOMKilled,
+		})
+		if !record.OOMKilled {
+			cpuPeaks = append(cpuPeaks, record.CpuPeak)
+		}
+		memoryPeaks = append(memoryPeaks, record.MemoryPeak)
+	}
+
+	spec, ok := resourceusage.Recommend(usages)
+	if !ok {
+		return nil, nil
+	}
+	return &commonmodels.ResourceRecommendation{
+		Samples:       len(records),
+		CpuPeakP95:    resourceusage.Percentile(cpuPeaks, 95),
+		MemoryPeakP95: resourceusage.Percentile(memoryPeaks, 95),
+		ResReqSpec:    spec,
+	}, nil
+}
+
+// ResolveRequest turns the auto resource mode into the recommended spec of the build or testing,
+// the default spec is used until there are enough runs to recommend
+func ResolveRequest(req setting.Request, spec setting.RequestSpec, projectName, configType, configName string, log *zap.SugaredLogger) (setting.Request, setting.RequestSpec) {
+	if req != setting.AutoRequest {
+		return req, spec
+	}
+
+	recommendation, err := Recommend(projectName, configType, configName)
+	if err != nil {
+		log.Warnf("failed to recommend resource for %s %s/%s, use the default: %s", configType, projectName, configName, err)
+		return setting.DefaultRequest, setting.DefaultRequestSpec
+	}
+	if recommendation == nil {
+		return setting.DefaultRequest, setting.DefaultRequestSpec
+	}
+
+	// keep the gpu limit which is not profiled
+	recommendation.ResReqSpec.GpuLimit = spec.GpuLimit
+	return setting.DefineRequest, recommendation.ResReqSpec
+}
@@ -38,6 +38,7 @@ import (
 	batchv1 "k8s.io/api/batch/v1"
 	"k8s.io/api/batch/v1beta1"
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/util/sets"
 	"k8s.io/client-go/informers"
@@ -1416,7 +1417,12 @@ func GetServiceImpl(serviceName string, serviceTmpl *commonmodels.Service, workL
 			return nil, e.ErrGetService.AddDesc(fmt.Sprintf("failed to find service in environment: %s", envName))
 		}
 
-		parsedYaml, err := kube.RenderServiceYaml(serviceTmpl.Yaml, productName, serviceTmpl.ServiceName, service.GetServiceRender())
+		svcYaml, err := kube.ServiceTemplateYaml(serviceTmpl, envName, service.Containers)
+		if err != nil {
+			log.Errorf("failed to build service yaml, err: %s", err)
+			return nil, err
+		}
+		parsedYaml, err := kube.RenderServiceYaml(svcYaml, productName, serviceTmpl.ServiceName, service.GetServiceRender())
 		if err != nil {
 			log.Errorf("failed to render service yaml, err: %s", err)
 			return nil, err
@@ -1424,6 +1430,7 @@ func GetServiceImpl(serviceName string, serviceTmpl *commonmodels.Service, workL
 		// 渲染系统变量键值
 		parsedYaml = kube.ParseSysKeys(namespace, envName, productName, service.ServiceName, parsedYaml)
 
+		kruiseRollouts := make([]*unstructured.Unstructured, 0)
 		manifests := releaseutil.SplitManifests(parsedYaml)
 		for _, item := range manifests {
 			u, err := serializer.NewDecoder().YamlToUnstructured([]byte(item))
@@ -1441,6 +1448,24 @@ func GetServiceImpl(serviceName string, serviceTmpl *commonmodels.Service, workL
 
 				ret.Scales = append(ret.Scales, GetDeploymentWorkloadResource(d, inf, log))
 				ret.Workloads = append(ret.Workloads, ToDeploymentWorkload(d))
+			case setting.Rollout:
+				kubeClient, err := clientmanager.NewKubeClientManager().GetControllerRuntimeClient(env.ClusterID)
+				if err != nil {
+					continue
+				}
+				if u.GroupVersionKind().Group == getter.KruiseRolloutGVK.Group {
+					rollout, found, err := getter.GetKruiseRollout(namespace, u.GetName(), kubeClient)
+					if err == nil && found {
+						kruiseRollouts = append(kruiseRollouts, rollout)
+					}
+					continue
+				}
+				rollout, found, err := getter.GetArgoRollout(namespace, u.GetName(), kubeClient)
+				if err != nil || !found {
+					continue
+				}
+				ret.Scales = append(ret.Scales, GetArgoRolloutWorkloadResource(rollout, inf, log))
+				ret.Workloads = append(ret.Workloads, ToArgoRolloutWorkload(rollout))
 			case setting.CloneSet:
 				dc, err := clientmanager.NewKubeClientManager().GetKruiseClient(env.ClusterID)
 				if err != nil {
@@ -1513,6 +1538,16 @@ func GetServiceImpl(serviceName string, serviceTmpl *commonmodels.Service, workL
 				ret.Services = append(ret.Services, wrapper.Service(svc).Resource())
 			}
 		}
+
+		// Kruise Rollouts are shown with the workloads they manage
+		for _, rollout := range kruiseRollouts {
+			kind, name := wrapper.KruiseRollout(rollout).WorkloadRef()
+			for _, scale := range ret.Scales {
+				if scale.Type == kind && scale.Name == name {
+					scale.Rollout = wrapper.KruiseRollout(rollout).RolloutStatus()
+				}
+			}
+		}
 	}
 	return
 }
@@ -1535,6 +1570,15 @@ func GetCloneSetWorkloadResource(d *v1alpha1.CloneSet, informer informers.Shared
 	return wrapper.CloneSet(d).WorkloadResource(pods)
 }
 
+func GetArgoRolloutWorkloadResource(rollout *unstructured.Unstructured, informer informers.SharedInformerFactory, log *zap.SugaredLogger) *internalresource.Workload {
+	pods, err := getter.ListPodsWithCache(labels.SelectorFromValidatedSet(wrapper.ArgoRollout(rollout).Selector().MatchLabels), informer)
+	if err != nil {
+		log.Warnf("Failed to get pods, err: %s", err)
+	}
+
+	return wrapper.ArgoRollout(rollout).WorkloadResource(pods)
+}
+
 func getStatefulSetWorkloadResource(sts *appsv1.StatefulSet, informer informers.SharedInformerFactory, log *zap.SugaredLogger) *internalresource.Workload {
 	pods, err := getter.ListPodsWithCache(labels.SelectorFromValidatedSet(sts.Spec.Selector.MatchLabels), informer)
 	if err != nil {
@@ -1589,6 +1633,21 @@ func ToDeploymentWorkload(v *appsv1.Deployment) *Workload {
 	return workload
 }
 
+func ToArgoRolloutWorkload(v *unstructured.Unstructured) *Workload {
+	rollout := wrapper.ArgoRollout(v)
+	workload := &Workload{
+		Name:       v.GetName(),
+		Spec:       rollout.Template(),
+		Selector:   rollout.Selector(),
+		Type:       setting.Rollout,
+		Images:     rollout.ImageInfos(),
+		Containers: rollout.GetContainers(),
+		Ready:      rollout.Ready(),
+		Annotation: v.GetAnnotations(),
+	}
+	return workload
+}
+
 func ToCloneSetWorkload(v *v1alpha1.CloneSet) *Workload {
 	workload := &Workload{
 		Name:       v.Name,
@@ -0,0 +1,338 @@
+/*
+Copyright 2025 The KodeRover Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package servicedependency
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+
+	"sigs.k8s.io/yaml"
+
+	"github.com/koderover/zadig/v2/pkg/setting"
+	"github.com/koderover/zadig/v2/pkg/util"
+)
+
+const (
+	SourceDeclared = "declared"
+	SourceInferred = "inferred"
+)
+
+type Edge struct {
+	// From depends on To
+	From   string `json:"from"`
+	To     string `json:"to"`
+	Source string `json:"source"`
+}
+
+// Graph is the directed dependency graph of the services, an edge from a to b means a depends on b
+type Graph struct {
+	nodes []string
+	index map[string]bool
+	edges map[string]map[string]string
+}
+
+func NewGraph(services []string) *Graph {
+	g := &Graph{
+		index: make(map[string]bool),
+		edges: make(map[string]map[string]string),
+	}
+	for _, svc := range services {
+		if g.index[svc] {
+			continue
+		}
+		g.index[svc] = true
+		g.nodes = append(g.nodes, svc)
+	}
+	return g
+}
+
+func (g *Graph) Nodes() []string {
+	return g.nodes
+}
+
+func (g *Graph) HasNode(svc string) bool {
+	return g.index[svc]
+}
+
+// AddEdge adds the dependency of from on to, edges between unknown services and self dependencies are ignored.
+// A declared dependency takes precedence over the inferred one.
+func (g *Graph) AddEdge(from, to, source string) {
+	if from == to || !g.index[from] || !g.index[to] {
+		return
+	}
+	if g.edges[from] == nil {
+		g.edges[from] = make(map[string]string)
+	}
+	if g.edges[from][to] == SourceDeclared {
+		return
+	}
+	g.edges[from][to] = source
+}
+
+func (g *Graph) AddEdges(edges []*Edge) {
+	for _, edge := range edges {
+		g.AddEdge(edge.From, edge.To, edge.Source)
+	}
+}
+
+// Edges returns all the edges sorted by from and to
+func (g *Graph) Edges() []*Edge {
+	resp := make([]*Edge, 0)
+	for _, from := range g.nodes {
+		for _, to := range g.Dependencies(from) {
+			resp = append(resp, &Edge{From: from, To: to, Source: g.edges[from][to]})
+		}
+	}
+	return resp
+}
+
+// Dependencies returns the services the given service depends on directly
+func (g *Graph) Dependencies(svc string) []string {
+	resp := make([]string, 0, len(g.edges[svc]))
+	for to := range g.edges[svc] {
+		resp = append(resp, to)
+	}
+	sort.Strings(resp)
+	return resp
+}
+
+// TransitiveDependencies returns all the services the given service depends on directly or indirectly
+func (g *Graph) TransitiveDependencies(svc string) []string {
+	visited := map[string]bool{svc: true}
+	queue := []string{svc}
+	resp := make([]string, 0)
+	for len(queue) > 0 {
+		cur := queue[0]
+		queue = queue[1:]
+		for _, to := range g.Dependencies(cur) {
+			if visited[to] {
+				continue
+			}
+			visited[to] = true
+			resp = append(resp, to)
+			queue = append(queue, to)
+		}
+	}
+	sort.Strings(resp)
+	return resp
+}
+
+// Dependents returns all the services that depend on the given service directly or indirectly,
+// which are the ones affected when the given service changes
+func (g *Graph) Dependents(svc string) []string {
+	reverse := make(map[string][]string)
+	for from, tos := range g.edges {
+		for to := range tos {
+			reverse[to] = append(reverse[to], from)
+		}
+	}
+
+	visited := map[string]bool{svc: true}
+	queue := []string{svc}
+	resp := make([]string, 0)
+	for len(queue) > 0 {
+		cur := queue[0]
+		queue = queue[1:]
+		for _, from := range reverse[cur] {
+			if visited[from] {
+				continue
+			}
+			visited[from] = true
+			resp = append(resp, from)
+			queue = append(queue, from)
+		}
+	}
+	sort.Strings(resp)
+	return resp
+}
+
+// Layers groups the given services into layers in which every service only depends on the services of the previous layers,
+// the services depending on each other in a cycle are put into the same layer. Services not in the graph are put into the first layer.
+// The order of the given services is kept inside a layer.
+func (g *Graph) Layers(services []string) [][]string {
+	deps := g.SubsetDependencies(services)
+
+	placed := make(map[string]bool)
+	remaining := services
+	resp := make([][]string, 0)
+	for len(remaining) > 0 {
+		layer, rest := make([]string, 0), make([]string, 0)
+		for _, svc := range remaining {
+			ready := true
+			for _, dep := range deps[svc] {
+				if !placed[dep] && !dependsOn(deps, dep, svc) {
+					ready = false
+					break
+				}
+			}
+			if ready {
+				layer = append(layer, svc)
+			} else {
+				rest = append(rest, svc)
+			}
+		}
+		// should not happen since the services in a cycle are ready together, keep it safe anyway
+		if len(layer) == 0 {
+			layer, rest = rest, nil
+		}
+		for _, svc := range layer {
+			placed[svc] = true
+		}
+		resp = append(resp, layer)
+		remaining = rest
+	}
+	return resp
+}
+
+// SubsetDependencies returns the dependencies among the given services only, a service depending on another one
+// through services not given still depends on it
+func (g *Graph) SubsetDependencies(services []string) map[string][]string {
+	subset := make(map[string]bool)
+	for _, svc := range services {
+		subset[svc] = true
+	}
+
+	resp := make(map[string][]string)
+	for _, svc := range services {
+		for _, dep := range g.TransitiveDependencies(svc) {
+			if subset[dep] {
+				resp[svc] = append(resp[svc], dep)
+			}
+		}
+	}
+	return resp
+}
+
+// dependsOn tells if a depends on b in the given dependencies
+func dependsOn(deps map[string][]string, a, b string) bool {
+	for _, dep := range deps[a] {
+		if dep == b {
+			return true
+		}
+	}
+	return false
+}
+
+// FindCycle returns a dependency cycle starting from the given service, or nil if there is no cycle
+func (g *Graph) FindCycle(svc string) []string {
+	var path []string
+	visited := make(map[string]bool)
+
+	var dfs func(cur string) bool
+	dfs = func(cur string) bool {
+		path = append(path, cur)
+		for _, to := range g.Dependencies(cur) {
+			if to == svc {
+				path = append(path, to)
+				return true
+			}
+			if visited[to] {
+				continue
+			}
+			visited[to] = true
+			if dfs(to) {
+				return true
+			}
+		}
+		path = path[:len(path)-1]
+		return false
+	}
+
+	if dfs(svc) {
+		return path
+	}
+	return nil
+}
+
+type manifestMeta struct {
+	Kind     string `json:"kind"`
+	Metadata struct {
+		Name string `json:"name"`
+	} `json:"metadata"`
+}
+
+// InferDependencies infers the dependencies between the services from their K8s manifests, a service depends on another one
+// if its manifests refer to the K8s Service defined by the other one as a host, e.g. in an url, a cluster domain name,
+// a host like environment variable or an istio route destination.
+func InferDependencies(manifests map[string]string) []*Edge {
+	// K8s Service name => service defining it
+	definitions := make(map[string]string)
+	services := make([]string, 0, len(manifests))
+	for svc, manifest := range manifests {
+		services = append(services, svc)
+		for _, item := range util.SplitManifests(manifest) {
+			meta := &manifestMeta{}
+			if err := yaml.Unmarshal([]byte(item), meta); err != nil {
+				continue
+			}
+			if meta.Kind == setting.Service && meta.Metadata.Name != "" {
+				definitions[meta.Metadata.Name] = svc
+			}
+		}
+	}
+	sort.Strings(services)
+
+	names := make([]string, 0, len(definitions))
+	for name := range definitions {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	resp := make([]*Edge, 0)
+	for _, name := range names {
+		to := definitions[name]
+		pattern := hostReferencePattern(name)
+		for _, from := range services {
+			if from == to || !pattern.MatchString(manifests[from]) {
+				continue
+			}
+			resp = append(resp, &Edge{From: from, To: to, Source: SourceInferred})
+		}
+	}
+	return dedupEdges(resp)
+}
+
+func hostReferencePattern(name string) *regexp.Regexp {
+	name = regexp.QuoteMeta(name)
+	patterns := []string{
+		// scheme://name, user@name
+		fmt.Sprintf(`(?:://|@)%s(?:[.:/"'\s]|$)`, name),
+		// name.svc, name.namespace.svc.cluster.local
+		fmt.Sprintf(`(?:^|[^a-z0-9.-])%s\.(?:[a-z0-9-]+\.)?svc\b`, name),
+		// REDIS_HOST: name, host: name, addr=name:6379
+		fmt.Sprintf(`(?:^|[^a-z0-9.])[a-z0-9_-]*(?:host|addr|server|endpoint|url)[a-z0-9_-]*["']?\s*[:=]\s*["']?%s(?:[:/"'\s]|$)`, name),
+		// value: name:6379
+		fmt.Sprintf(`value:\s*["']?%s:\d+`, name),
+	}
+	return regexp.MustCompile(`(?mi)` + strings.Join(patterns, "|"))
+}
+
+func dedupEdges(edges []*Edge) []*Edge {
+	seen := make(map[string]bool)
+	resp := make([]*Edge, 0, len(edges))
+	for _, edge := range edges {
+		key := edge.From + "/" + edge.To
+		if seen[key] {
+			continue
+		}
+		seen[key] = true
+		resp = append(resp, edge)
+	}
+	return resp
+}
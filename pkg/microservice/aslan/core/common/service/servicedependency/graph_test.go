@@ -0,0 +1,122 @@
+/*
+Copyright 2025 The KodeRover Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package servicedependency
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestInferDependencies(t *testing.T) {
+	ast := require.New(t)
+
+	manifests := map[string]string{
+		"mysql": `apiVersion: v1
+kind: Service
+metadata:
+  name: mysql
+---
+apiVersion: apps/v1
+kind: StatefulSet
+metadata:
+  name: mysql
+`,
+		"redis": `apiVersion: v1
+kind: Service
+metadata:
+  name: redis
+`,
+		"user": `apiVersion: v1
+kind: Service
+metadata:
+  name: user-api
+---
+apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: user
+spec:
+  template:
+    spec:
+      containers:
+      - name: user
+        image: koderover/redis:6.2
+        env:
+        - name: DB_URL
+          value: mysql://root@mysql.default.svc.cluster.local:3306/user
+`,
+		"order": `apiVersion: networking.istio.io/v1alpha3
+kind: VirtualService
+metadata:
+  name: order
+spec:
+  http:
+  - route:
+    - destination:
+        host: user-api
+---
+apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: order
+spec:
+  template:
+    spec:
+      containers:
+      - name: order
+        env:
+        - name: REDIS_ADDR
+          value: redis:6379
+`,
+	}
+
+	edges := InferDependencies(manifests)
+	ast.ElementsMatch([]*Edge{
+		{From: "user", To: "mysql", Source: SourceInferred},
+		{From: "order", To: "redis", Source: SourceInferred},
+		{From: "order", To: "user", Source: SourceInferred},
+	}, edges)
+}
+
+func TestGraph(t *testing.T) {
+	ast := require.New(t)
+
+	g := NewGraph([]string{"gateway", "order", "user", "mysql", "redis"})
+	g.AddEdge("gateway", "order", SourceDeclared)
+	g.AddEdge("order", "user", SourceInferred)
+	g.AddEdge("order", "user", SourceDeclared)
+	g.AddEdge("user", "mysql", SourceInferred)
+	g.AddEdge("order", "redis", SourceInferred)
+	g.AddEdge("order", "user", SourceInferred)
+	g.AddEdge("order", "order", SourceDeclared)
+	g.AddEdge("order", "unknown", SourceDeclared)
+
+	ast.Equal([]string{"redis", "user"}, g.Dependencies("order"))
+	ast.Equal(&Edge{From: "order", To: "user", Source: SourceDeclared}, g.Edges()[2])
+	ast.Equal([]string{"mysql", "order", "redis", "user"}, g.TransitiveDependencies("gateway"))
+	ast.Equal([]string{"gateway", "order", "user"}, g.Dependents("mysql"))
+	ast.Nil(g.FindCycle("order"))
+
+	ast.Equal([][]string{{"mysql", "redis"}, {"user"}, {"order"}, {"gateway"}}, g.Layers(g.Nodes()))
+	// gateway depends on mysql through services not deployed
+	ast.Equal([][]string{{"mysql"}, {"gateway"}}, g.Layers([]string{"gateway", "mysql"}))
+
+	g.AddEdge("mysql", "order", SourceDeclared)
+	ast.Equal([]string{"order", "user", "mysql", "order"}, g.FindCycle("order"))
+	ast.Equal([][]string{{"redis"}, {"order", "user", "mysql"}, {"gateway"}}, g.Layers(g.Nodes()))
+}
@@ -0,0 +1,222 @@
+/*
+Copyright 2025 The KodeRover Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package servicedependency
+
+import (
+	"fmt"
+	"strings"
+
+	"golang.org/x/exp/slices"
+
+	commonmodels "github.com/koderover/zadig/v2/pkg/microservice/aslan/core/common/repository/models"
+	commonrepo "github.com/koderover/zadig/v2/pkg/microservice/aslan/core/common/repository/mongodb"
+	"github.com/koderover/zadig/v2/pkg/microservice/aslan/core/common/service/repository"
+	"github.com/koderover/zadig/v2/pkg/setting"
+	e "github.com/koderover/zadig/v2/pkg/tool/errors"
+)
+
+type ServiceDependencies struct {
+	ServiceName string   `json:"service_name"`
+	Declared    []string `json:"declared"`
+	Inferred    []string `json:"inferred"`
+	// Dependents are the services depending on this service directly or indirectly, they are affected when this service changes
+	Dependents []string `json:"dependents"`
+}
+
+type EnvGraphNode struct {
+	ServiceName string `json:"service_name"`
+	Type        string `json:"type"`
+	// Group is the index of the service group in the environment
+	Group int `json:"group"`
+}
+
+type EnvGraph struct {
+	Nodes  []*EnvGraphNode `json:"nodes"`
+	Edges  []*Edge         `json:"edges"`
+	Layers [][]string      `json:"layers"`
+}
+
+// GetProjectGraph builds the dependency graph of the latest service templates of the project
+func GetProjectGraph(productName string, production bool) (*Graph, error) {
+	services, err := repository.ListMaxRevisionsServices(productName, production, false)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list services of project %s, error: %s", productName, err)
+	}
+
+	names := make([]string, 0, len(services))
+	manifests := make(map[string]string)
+	for _, svc := range services {
+		names = append(names, svc.ServiceName)
+		if svc.Type == setting.K8SDeployType {
+			manifests[svc.ServiceName] = svc.Yaml
+		}
+	}
+
+	g := NewGraph(names)
+	g.AddEdges(InferDependencies(manifests))
+	if err := addDeclaredEdges(g, productName, production); err != nil {
+		return nil, err
+	}
+	return g, nil
+}
+
+// GetEnvGraph builds the dependency graph of the services deployed in the environment,
+// the dependencies are inferred from the rendered manifests in the environment
+func GetEnvGraph(env *commonmodels.Product) (*Graph, error) {
+	manifests := make(map[string]string)
+	for _, svc := range env.GetSvcList() {
+		if svc.Type == setting.K8SDeployType && svc.RenderedYaml != "" {
+			manifests[svc.ServiceName] = svc.RenderedYaml
+		}
+	}
+
+	g := NewGraph(env.GetProductSvcNames())
+	g.AddEdges(InferDependencies(manifests))
+	if err := addDeclaredEdges(g, env.ProductName, env.Production); err != nil {
+		return nil, err
+	}
+	return g, nil
+}
+
+func addDeclaredEdges(g *Graph, productName string, production bool) error {
+	declared, err := commonrepo.NewServiceDependencyColl().List(productName, production)
+	if err != nil {
+		return fmt.Errorf("failed to list service dependencies of project %s, error: %s", productName, err)
+	}
+	for _, dependency := range declared {
+		for _, to := range dependency.Dependencies {
+			g.AddEdge(dependency.ServiceName, to, SourceDeclared)
+		}
+	}
+	return nil
+}
+
+// GetEnvDependencyGraph returns the dependency graph of the environment for visualization,
+// the layers are the order in which the services could be deployed
+func GetEnvDependencyGraph(projectName, envName string, production bool) (*EnvGraph, error) {
+	env, err := commonrepo.NewProductColl().Find(&commonrepo.ProductFindOptions{Name: projectName, EnvName: envName, Production: &production})
+	if err != nil {
+		return nil, e.ErrFindProduct.AddErr(err)
+	}
+
+	g, err := GetEnvGraph(env)
+	if err != nil {
+		return nil, err
+	}
+
+	resp := &EnvGraph{
+		Nodes: make([]*EnvGraphNode, 0),
+		Edges: g.Edges(),
+	}
+	for group, services := range env.Services {
+		for _, svc := range services {
+			resp.Nodes = append(resp.Nodes, &EnvGraphNode{
+				ServiceName: svc.ServiceName,
+				Type:        svc.Type,
+				Group:       group,
+			})
+		}
+	}
+	resp.Layers = g.Layers(g.Nodes())
+	return resp, nil
+}
+
+// GetServiceDependencies returns the dependencies of the service and the services affected when it changes
+func GetServiceDependencies(projectName, serviceName string, production bool) (*ServiceDependencies, error) {
+	g, err := GetProjectGraph(projectName, production)
+	if err != nil {
+		return nil, err
+	}
+	if !g.HasNode(serviceName) {
+		return nil, e.ErrInvalidParam.AddDesc(fmt.Sprintf("service %s not found", serviceName))
+	}
+
+	resp := &ServiceDependencies{
+		ServiceName: serviceName,
+		Declared:    make([]string, 0),
+		Inferred:    make([]string, 0),
+		Dependents:  g.Dependents(serviceName),
+	}
+	for _, edge := range g.Edges() {
+		if edge.From != serviceName {
+			continue
+		}
+		if edge.Source == SourceDeclared {
+			resp.Declared = append(resp.Declared, edge.To)
+		} else {
+			resp.Inferred = append(resp.Inferred, edge.To)
+		}
+	}
+	return resp, nil
+}
+
+// UpdateServiceDependencies replaces the declared dependencies of the service,
+// the dependencies must be services of the same project and must not make a cycle with the other declared ones
+func UpdateServiceDependencies(projectName, serviceName string, production bool, dependencies []string, username string) error {
+	services, err := repository.ListMaxRevisionsServices(projectName, production, false)
+	if err != nil {
+		return fmt.Errorf("failed to list services of project %s, error: %s", projectName, err)
+	}
+	names := make([]string, 0, len(services))
+	for _, svc := range services {
+		names = append(names, svc.ServiceName)
+	}
+
+	g := NewGraph(names)
+	if !g.HasNode(serviceName) {
+		return e.ErrInvalidParam.AddDesc(fmt.Sprintf("service %s not found", serviceName))
+	}
+	deduped := make([]string, 0, len(dependencies))
+	for _, dep := range dependencies {
+		if dep == serviceName {
+			return e.ErrInvalidParam.AddDesc("a service can not depend on itself")
+		}
+		if !g.HasNode(dep) {
+			return e.ErrInvalidParam.AddDesc(fmt.Sprintf("service %s not found", dep))
+		}
+		if !slices.Contains(deduped, dep) {
+			deduped = append(deduped, dep)
+		}
+	}
+
+	declared, err := commonrepo.NewServiceDependencyColl().List(projectName, production)
+	if err != nil {
+		return fmt.Errorf("failed to list service dependencies of project %s, error: %s", projectName, err)
+	}
+	for _, dependency := range declared {
+		if dependency.ServiceName == serviceName {
+			continue
+		}
+		for _, to := range dependency.Dependencies {
+			g.AddEdge(dependency.ServiceName, to, SourceDeclared)
+		}
+	}
+	for _, dep := range deduped {
+		g.AddEdge(serviceName, dep, SourceDeclared)
+	}
+	if cycle := g.FindCycle(serviceName); cycle != nil {
+		return e.ErrInvalidParam.AddDesc(fmt.Sprintf("dependency cycle found: %s", strings.Join(cycle, " -> ")))
+	}
+
+	return commonrepo.NewServiceDependencyColl().Upsert(&commonmodels.ServiceDependency{
+		ProductName:  projectName,
+		ServiceName:  serviceName,
+		Production:   production,
+		Dependencies: deduped,
+		UpdateBy:     username,
+	})
+}
@@ -64,6 +64,11 @@ func NewWebhookController() *controller {
 	return webhookController()
 }
 
+// QueueLength returns the number of webhook tasks waiting to be processed
+func QueueLength() int {
+	return len(webhookController().queue)
+}
+
 // Run starts the controller and blocks until receiving signal from stopCh.
 func (c *controller) Run(workers int, stopCh <-chan struct{}) {
 	defer close(c.queue)
@@ -32,7 +32,12 @@ import (
 
 	"github.com/koderover/zadig/v2/pkg/microservice/aslan/config"
 	commonmodels "github.com/koderover/zadig/v2/pkg/microservice/aslan/core/common/repository/models"
+	commonrepo "github.com/koderover/zadig/v2/pkg/microservice/aslan/core/common/repository/mongodb"
+	"github.com/koderover/zadig/v2/pkg/microservice/aslan/core/common/service/admission"
+	"github.com/koderover/zadig/v2/pkg/microservice/aslan/core/common/service/imagetrust"
+	"github.com/koderover/zadig/v2/pkg/tool/imagesign"
 	"github.com/koderover/zadig/v2/pkg/tool/log"
+	"github.com/koderover/zadig/v2/pkg/tool/metrics"
 	workflowtool "github.com/koderover/zadig/v2/pkg/tool/workflow"
 	"github.com/koderover/zadig/v2/pkg/util"
 	"github.com/koderover/zadig/v2/pkg/util/rand"
@@ -129,6 +134,7 @@ func initJobCtl(job *commonmodels.JobTask, workflowCtx *commonmodels.WorkflowTas
 
 func runJob(ctx context.Context, job *commonmodels.JobTask, workflowCtx *commonmodels.WorkflowTaskCtx, logger *zap.SugaredLogger, ack func()) {
 	jobCtl := initJobCtl(job, workflowCtx, logger, ack)
+	started := false
 	defer func(jobInfo *JobCtl) {
 		if err := recover(); err != nil {
 			errMsg := fmt.Sprintf("job: %s panic: %v", job.Name, err)
@@ -142,6 +148,9 @@ func runJob(ctx context.Context, job *commonmodels.JobTask, workflowCtx *commonm
 		logger.Infof("finish job: %s,status: %s", job.Name, job.Status)
 		setJobFinalStatusContext(job, workflowCtx)
 		ack()
+		if started {
+			metrics.ObserveJobDuration(workflowCtx.ProjectName, job.JobType, string(job.Status), job.EndTime-job.StartTime)
+		}
 		logger.Infof("updating job info into db...")
 		err := jobCtl.SaveInfo(ctx)
 		if err != nil {
@@ -194,6 +203,13 @@ func runJob(ctx context.Context, job *commonmodels.JobTask, workflowCtx *commonm
 		return
 	}
 
+	if err := checkJobSecretPlaceholders(jobCtl, job); err != nil {
+		logger.Error(err)
+		job.Status = config.StatusFailed
+		job.Error = err.Error()
+		return
+	}
+
 	job.Status = config.StatusPrepare
 	job.StartTime = time.Now().Unix()
 	job.K8sJobName = getJobName(workflowCtx.WorkflowName, workflowCtx.TaskID)
@@ -201,10 +217,12 @@ func runJob(ctx context.Context, job *commonmodels.JobTask, workflowCtx *commonm
 
 	logger.Infof("start job: %s,status: %s", job.Name, job.Status)
 
+	started = true
 	jobCtl.Run(ctx)
 
 	// if the job is in a failed state, do the error handling policy
 	if (job.Status == config.StatusFailed || job.Status == config.StatusTimeout) && job.ErrorPolicy != nil {
+		metrics.IncJobErrorPolicy(workflowCtx.ProjectName, job.JobType, string(job.ErrorPolicy.Policy))
 		switch job.ErrorPolicy.Policy {
 		case config.JobErrorPolicyStop:
 			return
@@ -319,11 +337,20 @@ type Pool struct {
 	ack         func()
 	ctx         context.Context
 	wg          sync.WaitGroup
+	// done is closed when the job of the name is done, used by the jobs depending on it
+	done   map[string]chan struct{}
+	jobMap map[string]*commonmodels.JobTask
 }
 
 // NewPool initializes a new pool with the given tasks and
 // at the given concurrency.
 func NewPool(ctx context.Context, jobs []*commonmodels.JobTask, workflowCtx *commonmodels.WorkflowTaskCtx, concurrency int, logger *zap.SugaredLogger, ack func()) *Pool {
+	done := make(map[string]chan struct{}, len(jobs))
+	jobMap := make(map[string]*commonmodels.JobTask, len(jobs))
+	for _, job := range jobs {
+		done[job.Name] = make(chan struct{})
+		jobMap[job.Name] = job
+	}
 	return &Pool{
 		Jobs:        jobs,
 		concurrency: concurrency,
@@ -332,6 +359,8 @@ func NewPool(ctx context.Context, jobs []*commonmodels.JobTask, workflowCtx *com
 		logger:      logger,
 		ack:         ack,
 		ctx:         ctx,
+		done:        done,
+		jobMap:      jobMap,
 	}
 }
 
@@ -356,11 +385,45 @@ func (p *Pool) Run() {
 // The work loop for any single goroutine.
 func (p *Pool) work() {
 	for job := range p.jobsChan {
-		runJob(p.ctx, job, p.workflowCtx, p.logger, p.ack)
+		if p.waitDependencies(job) {
+			runJob(p.ctx, job, p.workflowCtx, p.logger, p.ack)
+		}
+		close(p.done[job.Name])
 		p.wg.Done()
 	}
 }
 
+// waitDependencies blocks until the jobs the given job depends on are done, it returns false if the job
+// should not run since one of them didn't succeed or the workflow is cancelled, the job is marked as failed or
+// cancelled then. The jobs are sent to the workers in dependency order, so the jobs depended on are always picked up before.
+func (p *Pool) waitDependencies(job *commonmodels.JobTask) bool {
+	for _, name := range job.DependsOn {
+		done, ok := p.done[name]
+		if !ok {
+			continue
+		}
+		select {
+		case <-done:
+		case <-p.ctx.Done():
+			p.skipJob(job, config.StatusCancelled, fmt.Sprintf("job %s is not run since the workflow is cancelled", job.Name))
+			return false
+		}
+		if status := p.jobMap[name].Status; !jobStatusSucceeded(status) {
+			p.skipJob(job, config.StatusFailed, fmt.Sprintf("job %s is not run since the job %s it depends on is %s", job.Name, name, status))
+			return false
+		}
+	}
+	return true
+}
+
+func (p *Pool) skipJob(job *commonmodels.JobTask, status config.Status, msg string) {
+	p.logger.Info(msg)
+	job.Status = status
+	job.Error = msg
+	job.EndTime = time.Now().Unix()
+	p.ack()
+}
+
 func saveFile(src io.Reader, localFile string) error {
 	out, err := os.Create(localFile)
 	if err != nil {
@@ -388,6 +451,12 @@ func getJobName(workflowName string, taskID int64) string {
 	return rand.GenerateName(base)
 }
 
+// jobStatusSucceeded reports whether the jobs after the job can run, the jobs skipped by the execute policy or
+// passed in the previous run of a restarted task count as succeeded
+func jobStatusSucceeded(status config.Status) bool {
+	return status == config.StatusPassed || status == config.StatusSkipped
+}
+
 func jobStatusFailed(status config.Status) bool {
 	if status == config.StatusCancelled || status == config.StatusFailed || status == config.StatusTimeout || status == config.StatusReject {
 		return true
@@ -417,6 +486,63 @@ func getMatchedRegistries(image string, registries []*commonmodels.RegistryNames
 	return resp
 }
 
+// checkProductionImageScan refuses images without a passing image scan when it is required by the security settings.
+// Tags are mutable, so the image is resolved to its manifest digest and the latest scan of that digest is used.
+func checkProductionImageScan(images []string) error {
+	systemSetting, err := commonrepo.NewSystemSettingColl().Get()
+	if err != nil {
+		return fmt.Errorf("failed to get system settings, error: %v", err)
+	}
+	if systemSetting.Security == nil || !systemSetting.Security.ProductionImageScanRequired {
+		return nil
+	}
+
+	digests, err := imagetrust.ResolveDigests(images)
+	if err != nil {
+		return fmt.Errorf("%v, a passing image scan is required to deploy to production", err)
+	}
+	for _, image := range images {
+		if image == "" {
+			continue
+		}
+		result, err := commonrepo.NewImageScanResultColl().FindLatestByDigest(digests[image])
+		if err != nil {
+			return fmt.Errorf("failed to find image scan result of %s, error: %v", image, err)
+		}
+		if result == nil {
+			return fmt.Errorf("image %s (%s) has not been scanned, a passing image scan is required to deploy to production", image, digests[image])
+		}
+		if !result.Passed {
+			return fmt.Errorf("image %s failed the image scan of workflow %s task %d, deploying to production is refused", image, result.WorkflowName, result.TaskID)
+		}
+	}
+	return nil
+}
+
+// checkDeployImages runs the checks every deploy job passes before touching the environment: the admission policies,
+// the image scan required to deploy to production and the signatures required by the trust policy of the environment.
+// The signature verification results are returned to be recorded in the job task spec.
+func checkDeployImages(workflowCtx *commonmodels.WorkflowTaskCtx, job *commonmodels.JobTask, env *commonmodels.Product, images []string) ([]*imagesign.VerifyResult, error) {
+	if err := checkAdmissionPolicies(workflowCtx, job, env, images); err != nil {
+		return nil, err
+	}
+	if env.Production {
+		if err := checkProductionImageScan(images); err != nil {
+			return nil, err
+		}
+	}
+	return imagetrust.Verify(env, images)
+}
+
+// checkAdmissionPolicies evaluates the admission policies of the deploy scope before anything is deployed.
+func checkAdmissionPolicies(workflowCtx *commonmodels.WorkflowTaskCtx, job *commonmodels.JobTask, env *commonmodels.Product, images []string) error {
+	input, err := admission.NewDeployInput(workflowCtx, job, env, images)
+	if err != nil {
+		return err
+	}
+	return admission.Evaluate(input)
+}
+
 // evaluateExecuteRule evaluates a single execute rule against the global context
 func evaluateExecuteRule(rule *commonmodels.JobExecuteRule) bool {
 	ruleValue := rule.Value
@@ -92,6 +92,16 @@ func (c *BlueGreenDeployV2JobCtl) run(ctx context.Context) error {
 		logError(c.job, msg, c.logger)
 		return errors.New(msg)
 	}
+	deployImages := make([]string, 0)
+	for _, svc := range c.jobTaskSpec.Service.ServiceAndImage {
+		deployImages = append(deployImages, svc.Image)
+	}
+	c.jobTaskSpec.SignatureVerifications, err = checkDeployImages(c.workflowCtx, c.job, env, deployImages)
+	if err != nil {
+		logError(c.job, err.Error(), c.logger)
+		c.jobTaskSpec.Events.Error(err.Error())
+		return err
+	}
 	c.namespace = env.Namespace
 	clusterID := env.ClusterID
 
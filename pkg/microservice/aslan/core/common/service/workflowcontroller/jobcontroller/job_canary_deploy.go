@@ -30,6 +30,7 @@ import (
 	"github.com/koderover/zadig/v2/pkg/microservice/aslan/config"
 	commonmodels "github.com/koderover/zadig/v2/pkg/microservice/aslan/core/common/repository/models"
 	"github.com/koderover/zadig/v2/pkg/microservice/aslan/core/common/repository/mongodb"
+	"github.com/koderover/zadig/v2/pkg/microservice/aslan/core/common/service/admission"
 	"github.com/koderover/zadig/v2/pkg/setting"
 	"github.com/koderover/zadig/v2/pkg/shared/kube/wrapper"
 	"github.com/koderover/zadig/v2/pkg/tool/kube/getter"
@@ -88,6 +89,19 @@ func (c *CanaryDeployJobCtl) run(ctx context.Context) error {
 		return errors.New(msg)
 	}
 
+	env, err := admission.NamespaceEnv(c.jobTaskSpec.ClusterID, c.jobTaskSpec.Namespace)
+	if err != nil {
+		logError(c.job, err.Error(), c.logger)
+		c.jobTaskSpec.Events.Error(err.Error())
+		return err
+	}
+	c.jobTaskSpec.SignatureVerifications, err = checkDeployImages(c.workflowCtx, c.job, env, []string{c.jobTaskSpec.Image})
+	if err != nil {
+		logError(c.job, err.Error(), c.logger)
+		c.jobTaskSpec.Events.Error(err.Error())
+		return err
+	}
+
 	_, exist, err := getter.GetService(c.jobTaskSpec.Namespace, c.jobTaskSpec.K8sServiceName, c.kubeClient)
 	if err != nil || !exist {
 		msg := fmt.Sprintf("service: %s not found: %v", c.jobTaskSpec.K8sServiceName, err)
@@ -143,6 +143,15 @@ func (c *DeployJobCtl) run(ctx context.Context) error {
 		logError(c.job, msg, c.logger)
 		return errors.New(msg)
 	}
+	deployImages := make([]string, 0)
+	for _, svc := range c.jobTaskSpec.ServiceAndImages {
+		deployImages = append(deployImages, svc.Image)
+	}
+	c.jobTaskSpec.SignatureVerifications, err = checkDeployImages(c.workflowCtx, c.job, env, deployImages)
+	if err != nil {
+		logError(c.job, err.Error(), c.logger)
+		return err
+	}
 
 	c.namespace = env.Namespace
 	c.jobTaskSpec.ClusterID = env.ClusterID
@@ -343,6 +352,16 @@ func (c *DeployJobCtl) updateSystemService(env *commonmodels.Product, currentYam
 			c.jobTaskSpec.ReplaceResources = append(c.jobTaskSpec.ReplaceResources, commonmodels.Resource{Name: us.GetName(), Kind: us.GetKind()})
 		case setting.CronJob, setting.Job:
 			c.jobTaskSpec.ReplaceResources = append(c.jobTaskSpec.ReplaceResources, commonmodels.Resource{Name: us.GetName(), Kind: us.GetKind()})
+		case setting.Rollout:
+			// Kruise Rollouts are checked through the workloads they manage
+			if us.GroupVersionKind().Group != getter.ArgoRolloutGVK.Group {
+				continue
+			}
+			podLabels, _, err := unstructured.NestedStringMap(us.Object, "spec", "template", "metadata", "labels")
+			if err == nil {
+				c.jobTaskSpec.RelatedPodLabels = append(c.jobTaskSpec.RelatedPodLabels, podLabels)
+			}
+			c.jobTaskSpec.ReplaceResources = append(c.jobTaskSpec.ReplaceResources, commonmodels.Resource{Name: us.GetName(), Kind: us.GetKind()})
 		}
 	}
 	return nil
@@ -358,6 +377,8 @@ func UpdateExternalServiceModule(ctx context.Context, kubeClient client.Client,
 
 	logManager := joblog.NewJobLogManager(jobLogctx)
 
+	rollouts := kube.FetchSelectedArgoRollouts(env.Namespace, resources, kubeClient)
+
 L:
 	for _, deploy := range deployments {
 		for _, container := range deploy.Spec.Template.Spec.Containers {
@@ -477,6 +498,38 @@ Loop:
 			}
 		}
 	}
+RolloutLoop:
+	for _, rollout := range rollouts {
+		template := wrapper.ArgoRollout(rollout).Template()
+		for _, initContainer := range []bool{false, true} {
+			containers := template.Spec.Containers
+			if initContainer {
+				containers = template.Spec.InitContainers
+			}
+			for _, container := range containers {
+				if container.Name != serviceModule.ServiceModule {
+					continue
+				}
+				err = updater.UpdateArgoRolloutImage(rollout.GetNamespace(), rollout.GetName(), serviceModule.ServiceModule, serviceModule.Image, initContainer, kubeClient)
+				if err != nil {
+					return nil, nil, fmt.Errorf("failed to update container image in %s/rollouts/%s/%s: %v", env.Namespace, rollout.GetName(), container.Name, err)
+				}
+
+				logContent := fmt.Sprintf("Update rollout %s/%s, set container %s image to %s", rollout.GetNamespace(), rollout.GetName(), container.Name, serviceModule.Image)
+				logManager.SaveJobLog(logContent)
+
+				replaceResources = append(replaceResources, commonmodels.Resource{
+					Kind:      setting.Rollout,
+					Container: container.Name,
+					Origin:    container.Image,
+					Name:      rollout.GetName(),
+				})
+				replaced = true
+				relatedPodLabels = append(relatedPodLabels, template.Labels)
+				break RolloutLoop
+			}
+		}
+	}
 CronLoop:
 	for _, cron := range cronJobs {
 		for _, container := range cron.Spec.JobTemplate.Spec.Template.Spec.Containers {
@@ -817,6 +870,7 @@ func CheckDeployStatus(ctx context.Context, kubeClient crClient.Client, namespac
 			time.Sleep(time.Second * 2)
 			ready := true
 			var err error
+			kruiseRollouts := kruiseRolloutsOfDeployments(kubeClient, namespace, replaceResources, logger)
 		L:
 			for _, resource := range replaceResources {
 				if err := workLoadDeployStat(kubeClient, namespace, relatedPodLabels, resource.PodOwnerUID, jobLogCtx); err != nil {
@@ -824,6 +878,12 @@ func CheckDeployStatus(ctx context.Context, kubeClient crClient.Client, namespac
 				}
 				switch resource.Kind {
 				case setting.Deployment:
+					// the deployment stays not ready while the Kruise Rollout is paused at a step waiting for approval
+					if rollout, ok := kruiseRollouts[resource.Name]; ok && wrapper.KruiseRollout(rollout).Phase() == setting.RolloutPhasePaused {
+						jobLogManager.SaveJobLog(fmt.Sprintf("Kruise Rollout %s/%s of Deployment %s is paused, approve it to continue the release", namespace, rollout.GetName(), resource.Name))
+						continue
+					}
+
 					d, found, e := getter.GetDeployment(namespace, resource.Name, kubeClient)
 					if e != nil {
 						err = e
@@ -868,6 +928,31 @@ func CheckDeployStatus(ctx context.Context, kubeClient crClient.Client, namespac
 					} else {
 						jobLogManager.SaveJobLog(fmt.Sprintf("StatefulSet %s/%s is ready", namespace, resource.Name))
 					}
+				case setting.Rollout:
+					rollout, found, e := getter.GetArgoRollout(namespace, resource.Name, kubeClient)
+					if e != nil || !found {
+						newErr := fmt.Errorf("Failed to check rollout status %s/%s/%s - %v", namespace, resource.Kind, resource.Name, e)
+						jobLogManager.SaveJobLog(newErr.Error())
+						logger.Error(newErr)
+						ready = false
+						break L
+					}
+
+					status := wrapper.ArgoRollout(rollout).RolloutStatus()
+					switch status.Phase {
+					case setting.RolloutPhaseHealthy:
+						jobLogManager.SaveJobLog(fmt.Sprintf("Rollout %s/%s is healthy", namespace, resource.Name))
+					case setting.RolloutPhasePaused:
+						jobLogManager.SaveJobLog(fmt.Sprintf("Rollout %s/%s is paused at step %d, promote or abort it to finish the release", namespace, resource.Name, status.Step))
+					case setting.RolloutPhaseDegraded:
+						msg := fmt.Sprintf("Rollout %s/%s is degraded: %s", namespace, resource.Name, status.Message)
+						jobLogManager.SaveJobLog(msg)
+						return config.StatusFailed, errors.New(msg)
+					default:
+						ready = false
+						jobLogManager.SaveJobLog(fmt.Sprintf("Checking Rollout %s/%s status ...", namespace, resource.Name))
+						break L
+					}
 				}
 			}
 
@@ -879,6 +964,26 @@ func CheckDeployStatus(ctx context.Context, kubeClient crClient.Client, namespac
 	}
 }
 
+// kruiseRolloutsOfDeployments returns the Kruise Rollouts managing the replaced deployments by the deployment names.
+func kruiseRolloutsOfDeployments(kubeClient crClient.Client, namespace string, replaceResources []commonmodels.Resource, logger *zap.SugaredLogger) map[string]*unstructured.Unstructured {
+	ret := make(map[string]*unstructured.Unstructured)
+	if !slices.ContainsFunc(replaceResources, func(resource commonmodels.Resource) bool { return resource.Kind == setting.Deployment }) {
+		return ret
+	}
+
+	rollouts, err := getter.ListKruiseRollouts(namespace, nil, kubeClient)
+	if err != nil {
+		logger.Warnf("failed to list kruise rollouts in namespace %s, err: %s", namespace, err)
+		return ret
+	}
+	for _, rollout := range rollouts {
+		if kind, name := wrapper.KruiseRollout(rollout).WorkloadRef(); kind == setting.Deployment {
+			ret[name] = rollout
+		}
+	}
+	return ret
+}
+
 func (c *DeployJobCtl) timeout() int {
 	if c.jobTaskSpec.Timeout == 0 {
 		c.jobTaskSpec.Timeout = setting.DeployTimeout
@@ -51,12 +51,18 @@ import (
 	vmmodels "github.com/koderover/zadig/v2/pkg/microservice/aslan/core/common/repository/models/vm"
 	"github.com/koderover/zadig/v2/pkg/microservice/aslan/core/common/repository/mongodb"
 	vmmongodb "github.com/koderover/zadig/v2/pkg/microservice/aslan/core/common/repository/mongodb/vm"
+	"github.com/koderover/zadig/v2/pkg/microservice/aslan/core/common/service/cost"
+	"github.com/koderover/zadig/v2/pkg/microservice/aslan/core/common/service/resourceprofile"
 	"github.com/koderover/zadig/v2/pkg/microservice/aslan/core/common/service/workflowcontroller/stepcontroller"
+	commonutil "github.com/koderover/zadig/v2/pkg/microservice/aslan/core/common/util"
 	"github.com/koderover/zadig/v2/pkg/microservice/aslan/core/multicluster/service"
 	"github.com/koderover/zadig/v2/pkg/setting"
 	"github.com/koderover/zadig/v2/pkg/tool/dockerhost"
+	redisEventBus "github.com/koderover/zadig/v2/pkg/tool/eventbus/redis"
 	"github.com/koderover/zadig/v2/pkg/tool/kube/updater"
+	"github.com/koderover/zadig/v2/pkg/tool/resourceusage"
 	s3tool "github.com/koderover/zadig/v2/pkg/tool/s3"
+	"github.com/koderover/zadig/v2/pkg/tool/tracing"
 	"github.com/koderover/zadig/v2/pkg/types/step"
 	"github.com/koderover/zadig/v2/pkg/util"
 )
@@ -188,7 +194,16 @@ func (c *FreestyleJobCtl) run(ctx context.Context) error {
 
 	// decide which docker host to use.
 	// TODO: do not use code in warpdrive moudule, should move to a public place
-	if !c.jobTaskSpec.Properties.UseHostDockerDaemon {
+	// images are built by the buildkitd sidecar of the job with the buildkit backend, no docker daemon is needed
+	if c.jobTaskSpec.Properties.ImageBuildBackend == setting.ImageBuildBackendBuildKit {
+		if err := checkBuildKitCluster(c.jobTaskSpec.Properties.ClusterID); err != nil {
+			logError(c.job, err.Error(), c.logger)
+			return err
+		}
+		// buildkitd runs rootless, the build container itself never needs to be privileged
+		c.jobTaskSpec.Properties.UseHostDockerDaemon = false
+		c.jobTaskSpec.Properties.EnablePrivileged = false
+	} else if !c.jobTaskSpec.Properties.UseHostDockerDaemon {
 		dockerhosts := dockerhost.NewDockerHosts(hubServerAddr, c.logger)
 		c.jobTaskSpec.Properties.DockerHost = dockerhosts.GetBestHost(dockerhost.ClusterID(c.jobTaskSpec.Properties.ClusterID), fmt.Sprintf("%v", c.workflowCtx.TaskID))
 	}
@@ -222,6 +237,13 @@ func (c *FreestyleJobCtl) run(ctx context.Context) error {
 		logError(c.job, msg, c.logger)
 		return errors.New(msg)
 	}
+	// the job context only lives in the configmap of the job, so secrets from external keyvault providers are resolved here
+	jobCtxBytes, err = ResolveJobContextSecrets(ctx, jobCtxBytes)
+	if err != nil {
+		msg := fmt.Sprintf("failed to resolve keyvault secrets: %v", err)
+		logError(c.job, msg, c.logger)
+		return errors.New(msg)
+	}
 
 	jobLabel := &JobLabel{
 		JobType: string(c.job.JobType),
@@ -342,9 +364,19 @@ func (c *FreestyleJobCtl) runVMJob(ctx context.Context) (string, error) {
 		logError(c.job, msg, c.logger)
 		return "", errors.New(msg)
 	}
+	publishVMJobEvent(setting.EventBusChannelVMJobCreated, vmJob.ID.Hex(), c.logger)
 	return vmJob.ID.Hex(), nil
 }
 
+// publishVMJobEvent notifies the aslan instances holding the agent connections, the agents still pick up jobs
+// by polling if it fails
+func publishVMJobEvent(channel, jobID string, logger *zap.SugaredLogger) {
+	eb := redisEventBus.New(zadigconfig.RedisCommonCacheTokenDB())
+	if err := eb.Publish(channel, jobID); err != nil {
+		logger.Warnf("failed to publish vm job %s to %s, error: %s", jobID, channel, err)
+	}
+}
+
 func (c *FreestyleJobCtl) checkAndPrepareFileTypes(ctx context.Context) error {
 	// the file will be downloaded by agent in VM type job
 	if c.job.Infrastructure == setting.JobVMInfrastructure {
@@ -1200,12 +1232,14 @@ func (c *FreestyleJobCtl) vmJobWait(ctx context.Context, jobID string) {
 			c.logger.Errorf("update vm job status error: %v", err)
 			c.job.Error = fmt.Errorf("update vm job status %s error: %v", string(config.ReleasePlanStatusCancel), err).Error()
 		}
+		publishVMJobEvent(setting.EventBusChannelVMJobCancelled, jobID, c.logger)
 	case config.StatusTimeout:
 		err := vmmongodb.NewVMJobColl().UpdateStatus(jobID, string(config.StatusTimeout))
 		if err != nil {
 			c.logger.Errorf("update vm job status error: %v", err)
 			c.job.Error = fmt.Errorf("update vm job status %s error: %v", string(config.StatusTimeout), err).Error()
 		}
+		publishVMJobEvent(setting.EventBusChannelVMJobCancelled, jobID, c.logger)
 	}
 }
 
@@ -1248,6 +1282,9 @@ func (c *FreestyleJobCtl) complete(ctx context.Context) {
 		c.job.Status, c.job.Error = config.StatusFailed, errors.Wrap(err, "get job outputs").Error()
 	}
 
+	c.recordResourceUsage()
+	c.recordJobCost()
+
 	if err := saveContainerLog(c.jobTaskSpec.Properties.Namespace, c.jobTaskSpec.Properties.ClusterID, c.workflowCtx.WorkflowName, c.job.Name, c.workflowCtx.TaskID, jobLabel, c.kubeclient); err != nil {
 		c.logger.Error(err)
 		if c.job.Error == "" {
@@ -1262,6 +1299,94 @@ func (c *FreestyleJobCtl) complete(ctx context.Context) {
 	}
 }
 
+// recordResourceUsage saves the resource usage sampled by the job executor for resource profiling of the build or testing
+func (c *FreestyleJobCtl) recordResourceUsage() {
+	profile := c.jobTaskSpec.Properties.ResourceProfile
+	if profile == nil || c.job.Status == config.StatusCancelled || c.job.Status == config.StatusTimeout {
+		return
+	}
+
+	usage, err := getJobResourceUsageFromConfigMap(c.jobTaskSpec.Properties.Namespace, c.job, c.informer)
+	if err != nil {
+		c.logger.Warnf("failed to get resource usage of job %s: %s", c.job.Name, err)
+		return
+	}
+
+	// the executor can't report the usage when it is OOM killed, the memory limit is what the job needed at least
+	oomKilled, err := jobContainerOOMKilled(c.jobTaskSpec.Properties.Namespace, GetJobContainerName(c.job.Name), c.job, c.kubeclient)
+	if err != nil {
+		c.logger.Warnf("failed to check if job %s was OOM killed: %s", c.job.Name, err)
+	}
+	if memoryLimit := getResourceRequestSpec(c.jobTaskSpec.Properties.ResourceRequest, c.jobTaskSpec.Properties.ResReqSpec).MemoryLimit; oomKilled && memoryLimit > 0 {
+		usage = &resourceusage.Usage{
+			MemoryPeak: memoryLimit,
+			MemoryAvg:  memoryLimit,
+			OOMKilled:  true,
+		}
+	}
+	if usage == nil {
+		return
+	}
+
+	err = resourceprofile.Record(&commonmodels.JobResourceUsage{
+		ProjectName:  profile.ProjectName,
+		WorkflowName: c.workflowCtx.WorkflowName,
+		TaskID:       c.workflowCtx.TaskID,
+		JobName:      c.job.Name,
+		ConfigType:   profile.ConfigType,
+		ConfigName:   profile.ConfigName,
+		ClusterID:    c.jobTaskSpec.Properties.ClusterID,
+		ResReq:       c.jobTaskSpec.Properties.ResourceRequest,
+		ResReqSpec:   c.jobTaskSpec.Properties.ResReqSpec,
+		Status:       string(c.job.Status),
+		CpuPeak:      usage.CpuPeak,
+		CpuAvg:       usage.CpuAvg,
+		MemoryPeak:   usage.MemoryPeak,
+		MemoryAvg:    usage.MemoryAvg,
+		OOMKilled:    usage.OOMKilled,
+	})
+	if err != nil {
+		c.logger.Warnf("failed to record resource usage of job %s: %s", c.job.Name, err)
+	}
+}
+
+// recordJobCost adds the resource the job pod took from its start to the cost of the build or testing, cancelled and
+// timeout jobs are billed as well
+func (c *FreestyleJobCtl) recordJobCost() {
+	profile := c.jobTaskSpec.Properties.ResourceProfile
+	if profile == nil || c.job.StartTime == 0 {
+		return
+	}
+
+	// the time waiting for scheduling takes no resource of the cluster
+	startTime, err := getJobPodStartTime(c.jobTaskSpec.Properties.Namespace, c.job, c.kubeclient)
+	if err != nil {
+		c.logger.Warnf("failed to get pod start time of job %s, the job start time is used: %s", c.job.Name, err)
+		startTime = c.job.StartTime
+	}
+	if startTime == 0 {
+		return
+	}
+
+	spec := getResourceRequestSpec(c.jobTaskSpec.Properties.ResourceRequest, c.jobTaskSpec.Properties.ResReqSpec)
+	args := &cost.JobCost{
+		ProjectName:   profile.ProjectName,
+		ConfigName:    profile.ConfigName,
+		Category:      config.CostCategory(profile.ConfigType),
+		ClusterID:     c.jobTaskSpec.Properties.ClusterID,
+		CPURequest:    spec.CpuReq,
+		MemoryRequest: spec.MemoryReq,
+		Duration:      time.Now().Unix() - startTime,
+	}
+	if usage, err := getJobResourceUsageFromConfigMap(c.jobTaskSpec.Properties.Namespace, c.job, c.informer); err == nil && usage != nil {
+		args.CPUUsage, args.MemoryUsage, args.HasUsage = usage.CpuAvg, usage.MemoryAvg, true
+	}
+
+	if err := cost.RecordJobCost(args); err != nil {
+		c.logger.Warnf("failed to record cost of job %s: %s", c.job.Name, err)
+	}
+}
+
 func (c *FreestyleJobCtl) vmComplete(ctx context.Context, jobID string) {
 	defer func() {
 		go func() {
@@ -1360,17 +1485,68 @@ func BuildJobExecutorContext(jobTaskSpec *commonmodels.JobTaskFreestyleSpec, job
 		Files:         files,
 	}
 
+	if tracing.Enabled() {
+		jobContext.TraceEndpoint = tracing.Endpoint()
+		jobContext.TraceParent = tracing.TraceParent(tracing.TaskTraceID(workflowCtx.WorkflowName, workflowCtx.TaskID), JobSpanID(workflowCtx.WorkflowName, workflowCtx.TaskID, job.Name))
+	}
+
 	if job.Infrastructure == setting.JobVMInfrastructure {
 		jobContext.Cache = &JobCacheConfig{
 			CacheEnable:  jobTaskSpec.Properties.CacheEnable,
 			CacheDirType: jobTaskSpec.Properties.CacheDirType,
 			CacheUserDir: jobTaskSpec.Properties.CacheUserDir,
 		}
+
+		if job.VMExecutionMode == setting.VMExecutionModeContainer {
+			jobContext.Container = buildJobContainer(jobTaskSpec.Properties)
+		}
 	}
 
 	return jobContext
 }
 
+// buildJobContainer uses the same image as the job in kubernetes, with the credential of the registry it is pulled from
+func buildJobContainer(properties commonmodels.JobProperties) *JobContainer {
+	container := &JobContainer{
+		Image: getBaseImage(properties.BuildOS, properties.ImageFrom),
+	}
+	for _, registry := range getMatchedRegistries(container.Image, properties.Registries) {
+		if registry.AccessKey == "" {
+			continue
+		}
+		container.Registry = &JobContainerAuth{
+			Address:    registry.RegAddr,
+			Username:   registry.AccessKey,
+			RegistryID: registry.ID.Hex(),
+		}
+		break
+	}
+	return container
+}
+
+// ResolveJobContainerAuth fills the password of the job container registry right before the job context is handed
+// to the agent, so that the vm job stores no registry credential.
+func ResolveJobContainerAuth(jobCtxBytes []byte) ([]byte, error) {
+	jobCtx := new(JobContext)
+	if err := yaml.Unmarshal(jobCtxBytes, jobCtx); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal job context: %s", err)
+	}
+	if jobCtx.Container == nil || jobCtx.Container.Registry == nil || jobCtx.Container.Registry.RegistryID == "" {
+		return jobCtxBytes, nil
+	}
+
+	registry, err := mongodb.NewRegistryNamespaceColl().Find(&mongodb.FindRegOps{ID: jobCtx.Container.Registry.RegistryID})
+	if err != nil {
+		return nil, fmt.Errorf("failed to find registry %s: %s", jobCtx.Container.Registry.RegistryID, err)
+	}
+	registry, err = commonutil.DecodeRegistry(registry)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode registry %s: %s", jobCtx.Container.Registry.RegistryID, err)
+	}
+	jobCtx.Container.Registry.Password = registry.SecretKey
+	return yaml.Marshal(jobCtx)
+}
+
 func (c *FreestyleJobCtl) SaveInfo(ctx context.Context) error {
 	// save delivery artifact for archive step
 	if c.job.Status == config.StatusPassed {
@@ -1415,7 +1591,9 @@ func (c *FreestyleJobCtl) SaveInfo(ctx context.Context) error {
 						deliveryCommit := new(commonmodels.ActivityCommit)
 						deliveryCommit.Address = repo.Address
 						deliveryCommit.Source = repo.Source
+						deliveryCommit.CodehostID = repo.CodehostID
 						deliveryCommit.RepoOwner = repo.RepoOwner
+						deliveryCommit.RepoNamespace = repo.RepoNamespace
 						deliveryCommit.RepoName = repo.RepoName
 						deliveryCommit.Branch = repo.Branch
 						deliveryCommit.Tag = repo.Tag
@@ -31,6 +31,7 @@ import (
 	"github.com/koderover/zadig/v2/pkg/microservice/aslan/core/common/repository/models/template"
 	commonrepo "github.com/koderover/zadig/v2/pkg/microservice/aslan/core/common/repository/mongodb"
 	"github.com/koderover/zadig/v2/pkg/microservice/aslan/core/common/service/kube"
+	commonutil "github.com/koderover/zadig/v2/pkg/microservice/aslan/core/common/util"
 	"github.com/koderover/zadig/v2/pkg/setting"
 )
 
@@ -85,6 +86,16 @@ func (c *HelmChartDeployJobCtl) Run(ctx context.Context) {
 	c.jobTaskSpec.ClusterID = productInfo.ClusterID
 
 	deploy := c.jobTaskSpec.DeployHelmChart
+	images, err := commonutil.GetHelmChartDeployImages(c.workflowCtx.ProjectName, productInfo.Production, deploy)
+	if err != nil {
+		logError(c.job, err.Error(), c.logger)
+		return
+	}
+	c.jobTaskSpec.SignatureVerifications, err = checkDeployImages(c.workflowCtx, c.job, productInfo, images)
+	if err != nil {
+		logError(c.job, err.Error(), c.logger)
+		return
+	}
 
 	var productChartService *commonmodels.ProductService
 
@@ -99,6 +99,15 @@ func (c *HelmDeployJobCtl) Run(ctx context.Context) {
 		logError(c.job, msg, c.logger)
 		return
 	}
+	deployImages := make([]string, 0)
+	for _, svc := range c.jobTaskSpec.ImageAndModules {
+		deployImages = append(deployImages, svc.Image)
+	}
+	c.jobTaskSpec.SignatureVerifications, err = checkDeployImages(c.workflowCtx, c.job, productInfo, deployImages)
+	if err != nil {
+		logError(c.job, err.Error(), c.logger)
+		return
+	}
 
 	c.namespace = productInfo.Namespace
 	c.jobTaskSpec.ClusterID = productInfo.ClusterID
@@ -0,0 +1,122 @@
+/*
+Copyright 2025 The KodeRover Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package jobcontroller
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"gopkg.in/yaml.v2"
+
+	commonmodels "github.com/koderover/zadig/v2/pkg/microservice/aslan/core/common/repository/models"
+	"github.com/koderover/zadig/v2/pkg/microservice/aslan/core/common/service/keyvault"
+)
+
+// ResolveJobContextSecrets replaces the keyvault placeholders in the marshalled job context with the values of
+// the key vault items. It's called right before the context is handed to the executor so the values are
+// never stored in the task, and every resolved value is added to the secret envs to keep it masked in the logs.
+func ResolveJobContextSecrets(ctx context.Context, jobCtxBytes []byte) ([]byte, error) {
+	if !keyvault.HasPlaceholder(string(jobCtxBytes)) {
+		return jobCtxBytes, nil
+	}
+
+	jobCtx := yaml.MapSlice{}
+	if err := yaml.Unmarshal(jobCtxBytes, &jobCtx); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal job context: %s", err)
+	}
+
+	resolver := keyvault.NewResolver()
+	resolved, err := resolveSecretPlaceholders(ctx, resolver, jobCtx)
+	if err != nil {
+		return nil, err
+	}
+	jobCtx = resolved.(yaml.MapSlice)
+
+	secretEnvIndex := -1
+	for i, item := range jobCtx {
+		if item.Key == "secret_envs" {
+			secretEnvIndex = i
+			break
+		}
+	}
+	if secretEnvIndex < 0 {
+		jobCtx = append(jobCtx, yaml.MapItem{Key: "secret_envs"})
+		secretEnvIndex = len(jobCtx) - 1
+	}
+
+	secretEnvs, _ := jobCtx[secretEnvIndex].Value.([]interface{})
+	existing := make(map[string]bool)
+	for _, env := range secretEnvs {
+		if s, ok := env.(string); ok {
+			existing[strings.SplitN(s, "=", 2)[0]] = true
+		}
+	}
+	for _, item := range resolver.Resolved() {
+		key := strings.Join([]string{"parameter", item.Group, item.Key}, ".")
+		if !existing[key] {
+			secretEnvs = append(secretEnvs, strings.Join([]string{key, item.Value}, "="))
+		}
+	}
+	jobCtx[secretEnvIndex].Value = secretEnvs
+
+	return yaml.Marshal(jobCtx)
+}
+
+func resolveSecretPlaceholders(ctx context.Context, resolver *keyvault.Resolver, value interface{}) (interface{}, error) {
+	var err error
+	switch v := value.(type) {
+	case string:
+		return resolver.Resolve(ctx, v)
+	case yaml.MapSlice:
+		for i := range v {
+			if v[i].Value, err = resolveSecretPlaceholders(ctx, resolver, v[i].Value); err != nil {
+				return nil, err
+			}
+		}
+		return v, nil
+	case []interface{}:
+		for i := range v {
+			if v[i], err = resolveSecretPlaceholders(ctx, resolver, v[i]); err != nil {
+				return nil, err
+			}
+		}
+		return v, nil
+	default:
+		return value, nil
+	}
+}
+
+// checkJobSecretPlaceholders refuses keyvault placeholders in the jobs which can't resolve them. Placeholders are only
+// resolved when the job is handed to an executor, deploy, sql, notification and the other jobs run in aslan and would
+// use the placeholder as the value.
+func checkJobSecretPlaceholders(jobCtl JobCtl, job *commonmodels.JobTask) error {
+	switch jobCtl.(type) {
+	case *FreestyleJobCtl, *PluginJobCtl:
+		return nil
+	}
+
+	b, err := json.Marshal(job.Spec)
+	if err != nil {
+		return fmt.Errorf("failed to marshal job spec: %s", err)
+	}
+	if keyvault.HasPlaceholder(string(b)) {
+		return fmt.Errorf("job %s references key vault items from external providers, which are only supported in build, testing, scanning, freestyle and plugin jobs", job.Name)
+	}
+	return nil
+}
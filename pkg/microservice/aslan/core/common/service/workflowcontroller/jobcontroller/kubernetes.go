@@ -50,15 +50,18 @@ import (
 	"github.com/koderover/zadig/v2/pkg/microservice/aslan/config"
 	commonmodels "github.com/koderover/zadig/v2/pkg/microservice/aslan/core/common/repository/models"
 	commonrepo "github.com/koderover/zadig/v2/pkg/microservice/aslan/core/common/repository/mongodb"
+	"github.com/koderover/zadig/v2/pkg/microservice/aslan/core/common/service/keyvault"
 	"github.com/koderover/zadig/v2/pkg/microservice/aslan/core/common/service/kube"
 	commonutil "github.com/koderover/zadig/v2/pkg/microservice/aslan/core/common/util"
 	"github.com/koderover/zadig/v2/pkg/microservice/aslan/core/multicluster/service"
 	"github.com/koderover/zadig/v2/pkg/setting"
+	kubeclient "github.com/koderover/zadig/v2/pkg/shared/kube/client"
 	"github.com/koderover/zadig/v2/pkg/shared/kube/wrapper"
 	"github.com/koderover/zadig/v2/pkg/tool/kube/containerlog"
 	"github.com/koderover/zadig/v2/pkg/tool/kube/getter"
 	"github.com/koderover/zadig/v2/pkg/tool/kube/updater"
 	"github.com/koderover/zadig/v2/pkg/tool/log"
+	"github.com/koderover/zadig/v2/pkg/tool/resourceusage"
 	s3tool "github.com/koderover/zadig/v2/pkg/tool/s3"
 	commontypes "github.com/koderover/zadig/v2/pkg/types"
 	"github.com/koderover/zadig/v2/pkg/types/job"
@@ -214,7 +217,7 @@ func getBaseImage(buildOS, imageFrom string) string {
 	return jobImage
 }
 
-func buildPlainJob(jobName string, resReq setting.Request, resReqSpec setting.RequestSpec, jobTask *commonmodels.JobTask, jobTaskSpec *commonmodels.JobTaskPluginSpec, workflowCtx *commonmodels.WorkflowTaskCtx, customLabels, customAnnotations map[string]string) (*batchv1.Job, error) {
+func buildPlainJob(ctx context.Context, jobName string, resReq setting.Request, resReqSpec setting.RequestSpec, jobTask *commonmodels.JobTask, jobTaskSpec *commonmodels.JobTaskPluginSpec, workflowCtx *commonmodels.WorkflowTaskCtx, customLabels, customAnnotations map[string]string) (*batchv1.Job, error) {
 	collectJobOutput := `OLD_IFS=$IFS
 export IFS=","
 files='%s'
@@ -251,9 +254,23 @@ echo $result > %s
 		return nil, err
 	}
 
+	// keyvault placeholders are resolved into the pod spec only, the task keeps the placeholders
+	resolver := keyvault.NewResolver()
 	envs := []corev1.EnvVar{}
 	for _, env := range jobTaskSpec.Plugin.Envs {
-		envs = append(envs, corev1.EnvVar{Name: env.Name, Value: env.Value})
+		value, err := resolver.Resolve(ctx, env.Value)
+		if err != nil {
+			return nil, err
+		}
+		envs = append(envs, corev1.EnvVar{Name: env.Name, Value: value})
+	}
+	args := make([]string, 0, len(jobTaskSpec.Plugin.Args))
+	for _, arg := range jobTaskSpec.Plugin.Args {
+		value, err := resolver.Resolve(ctx, arg)
+		if err != nil {
+			return nil, err
+		}
+		args = append(args, value)
 	}
 
 	clusterID := jobTaskSpec.Properties.ClusterID
@@ -293,7 +310,7 @@ echo $result > %s
 							ImagePullPolicy: util.ToPullPolicy(configbase.ImagePullPolicy()),
 							Name:            GetJobContainerName(jobTask.Name),
 							Image:           jobTaskSpec.Plugin.Image,
-							Args:            jobTaskSpec.Plugin.Args,
+							Args:            args,
 							Command:         jobTaskSpec.Plugin.Cmds,
 							Lifecycle: &corev1.Lifecycle{
 								PreStop: &corev1.LifecycleHandler{
@@ -512,10 +529,93 @@ EOF`,
 		}
 	}
 
+	if jobTaskSpec.Properties.ImageBuildBackend == setting.ImageBuildBackendBuildKit {
+		setBuildKitSidecar(job)
+	}
+
 	ensureVolumeMounts(job)
 	return job, nil
 }
 
+// checkBuildKitCluster refuses clusters which can't run buildkitd as a native sidecar, a regular sidecar would keep
+// the job pod running after the build finishes.
+func checkBuildKitCluster(clusterID string) error {
+	clientset, err := clientmanager.NewKubeClientManager().GetKubernetesClientSet(clusterID)
+	if err != nil {
+		return fmt.Errorf("failed to get kubernetes client of cluster %s: %v", clusterID, err)
+	}
+	version, err := clientset.Discovery().ServerVersion()
+	if err != nil {
+		return fmt.Errorf("failed to get kubernetes version of cluster %s: %v", clusterID, err)
+	}
+	if kubeclient.VersionLessThan129(version) {
+		return fmt.Errorf("the buildkit image build backend requires kubernetes 1.29 or later, the version of cluster %s is %s", clusterID, version.String())
+	}
+	return nil
+}
+
+// setBuildKitSidecar runs a rootless buildkitd as a native sidecar of the job pod and
+// copies buildctl into the executor volume, so that images can be built without docker daemon
+func setBuildKitSidecar(job *batchv1.Job) {
+	restartAlways := corev1.ContainerRestartPolicyAlways
+	buildKitStateVolume := "buildkit-state"
+	buildKitImage := config.RootlessBuildKitImage()
+
+	job.Spec.Template.Spec.Volumes = append(job.Spec.Template.Spec.Volumes, corev1.Volume{
+		Name: buildKitStateVolume,
+		VolumeSource: corev1.VolumeSource{
+			EmptyDir: &corev1.EmptyDirVolumeSource{},
+		},
+	})
+
+	job.Spec.Template.Spec.InitContainers = append(job.Spec.Template.Spec.InitContainers,
+		corev1.Container{
+			ImagePullPolicy: corev1.PullIfNotPresent,
+			Name:            "buildctl-init",
+			Image:           buildKitImage,
+			Command:         []string{"/bin/sh", "-c", fmt.Sprintf("cp /usr/bin/buildctl %s", setting.RootlessBuildctlPath)},
+			VolumeMounts: []corev1.VolumeMount{
+				{
+					Name:      ExecutorResourceVolumeName,
+					MountPath: ExecutorVolumePath,
+				},
+			},
+		},
+		corev1.Container{
+			ImagePullPolicy: corev1.PullIfNotPresent,
+			Name:            "buildkitd",
+			Image:           buildKitImage,
+			RestartPolicy:   &restartAlways,
+			Args:            []string{"--addr", setting.RootlessBuildKitAddr, "--oci-worker-no-process-sandbox"},
+			SecurityContext: &corev1.SecurityContext{
+				RunAsUser:  int64Ptr(1000),
+				RunAsGroup: int64Ptr(1000),
+				SeccompProfile: &corev1.SeccompProfile{
+					Type: corev1.SeccompProfileTypeUnconfined,
+				},
+				AppArmorProfile: &corev1.AppArmorProfile{
+					Type: corev1.AppArmorProfileTypeUnconfined,
+				},
+			},
+			StartupProbe: &corev1.Probe{
+				ProbeHandler: corev1.ProbeHandler{
+					Exec: &corev1.ExecAction{
+						Command: []string{"buildctl", "--addr", setting.RootlessBuildKitAddr, "debug", "workers"},
+					},
+				},
+				PeriodSeconds:    2,
+				FailureThreshold: 60,
+			},
+			VolumeMounts: []corev1.VolumeMount{
+				{
+					Name:      buildKitStateVolume,
+					MountPath: "/home/user/.local/share/buildkit",
+				},
+			},
+		},
+	)
+}
+
 // generateVolumeNameFromPath generates a safe volume name from mount path
 func generateVolumeNameFromPath(mountPath string) string {
 	volumeName := strings.ReplaceAll(mountPath, "/", "-")
@@ -689,7 +789,12 @@ func getEnvs(configMapMountDir string, jobTaskSpec *commonmodels.JobTaskFreestyl
 		Value: path.Join(configMapMountDir, "job-config.xml"),
 	})
 
-	if !jobTaskSpec.Properties.UseHostDockerDaemon {
+	if jobTaskSpec.Properties.ImageBuildBackend == setting.ImageBuildBackendBuildKit {
+		ret = append(ret, corev1.EnvVar{
+			Name:  setting.BuildKitHost,
+			Value: setting.RootlessBuildKitAddr,
+		})
+	} else if !jobTaskSpec.Properties.UseHostDockerDaemon {
 		ret = append(ret, corev1.EnvVar{
 			Name:  setting.DockerHost,
 			Value: jobTaskSpec.Properties.DockerHost,
@@ -778,25 +883,29 @@ func getVolumes(jobName string, userHostDockerDaemon bool) []corev1.Volume {
 }
 
 func getResourceRequirements(resReq setting.Request, resReqSpec setting.RequestSpec) corev1.ResourceRequirements {
+	return generateResourceRequirements(resReq, getResourceRequestSpec(resReq, resReqSpec))
+}
 
+// getResourceRequestSpec returns the spec of the resource request, the presets are resolved to their specs
+func getResourceRequestSpec(resReq setting.Request, resReqSpec setting.RequestSpec) setting.RequestSpec {
 	switch resReq {
 	case setting.HighRequest:
-		return generateResourceRequirements(setting.HighRequest, setting.HighRequestSpec)
+		return setting.HighRequestSpec
 
 	case setting.MediumRequest:
-		return generateResourceRequirements(setting.MediumRequest, setting.MediumRequestSpec)
+		return setting.MediumRequestSpec
 
 	case setting.LowRequest:
-		return generateResourceRequirements(setting.LowRequest, setting.LowRequestSpec)
+		return setting.LowRequestSpec
 
 	case setting.MinRequest:
-		return generateResourceRequirements(setting.MinRequest, setting.MinRequestSpec)
+		return setting.MinRequestSpec
 
 	case setting.DefineRequest:
-		return generateResourceRequirements(resReq, resReqSpec)
+		return resReqSpec
 
 	default:
-		return generateResourceRequirements(setting.DefaultRequest, setting.DefaultRequestSpec)
+		return setting.DefaultRequestSpec
 	}
 }
 
@@ -1092,6 +1201,69 @@ func getJobOutputFromConfigMap(namespace, containerName string, jobTask *commonm
 	return nil
 }
 
+func getJobResourceUsageFromConfigMap(namespace string, jobTask *commonmodels.JobTask, informer informers.SharedInformerFactory) (*resourceusage.Usage, error) {
+	cm, err := informer.Core().V1().ConfigMaps().Lister().ConfigMaps(namespace).Get(jobTask.K8sJobName)
+	if err != nil {
+		return nil, errors.Wrap(err, "get config map")
+	}
+	if len(cm.Data[commontypes.JobResourceUsageKey]) == 0 {
+		return nil, nil
+	}
+
+	usage := &resourceusage.Usage{}
+	if err := json.Unmarshal([]byte(cm.Data[commontypes.JobResourceUsageKey]), usage); err != nil {
+		return nil, errors.Wrap(err, "unmarshal resource usage")
+	}
+	return usage, nil
+}
+
+func listJobPods(namespace string, jobTask *commonmodels.JobTask, kubeClient crClient.Client) ([]*corev1.Pod, error) {
+	ls := getJobLabels(&JobLabel{
+		JobType: string(jobTask.JobType),
+		JobName: jobTask.K8sJobName,
+	})
+	return getter.ListPods(namespace, labels.Set(ls).AsSelector(), kubeClient)
+}
+
+// getJobPodStartTime returns the time the first pod of the job was started, it is 0 if no pod has started
+func getJobPodStartTime(namespace string, jobTask *commonmodels.JobTask, kubeClient crClient.Client) (int64, error) {
+	pods, err := listJobPods(namespace, jobTask, kubeClient)
+	if err != nil {
+		return 0, err
+	}
+	var startTime int64
+	for _, pod := range pods {
+		if pod.Status.StartTime == nil {
+			continue
+		}
+		if t := pod.Status.StartTime.Unix(); startTime == 0 || t < startTime {
+			startTime = t
+		}
+	}
+	return startTime, nil
+}
+
+// jobContainerOOMKilled reports whether the container of the job was killed for running out of memory
+func jobContainerOOMKilled(namespace, containerName string, jobTask *commonmodels.JobTask, kubeClient crClient.Client) (bool, error) {
+	pods, err := listJobPods(namespace, jobTask, kubeClient)
+	if err != nil {
+		return false, err
+	}
+	for _, pod := range pods {
+		for _, containerStatus := range pod.Status.ContainerStatuses {
+			if containerStatus.Name != containerName {
+				continue
+			}
+			for _, state := range []corev1.ContainerState{containerStatus.State, containerStatus.LastTerminationState} {
+				if state.Terminated != nil && state.Terminated.Reason == "OOMKilled" {
+					return true, nil
+				}
+			}
+		}
+	}
+	return false, nil
+}
+
 // @var write jobs output info to globalcontext so other job can use like this {{.job.jobKey.output.outputName}}
 func writeOutputs(outputs []*job.JobOutput, outputKey string, workflowCtx *commonmodels.WorkflowTaskCtx) {
 	outputsMap := make(map[string]*job.JobOutput)
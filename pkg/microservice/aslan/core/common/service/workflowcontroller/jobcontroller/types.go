@@ -17,9 +17,13 @@ limitations under the License.
 package jobcontroller
 
 import (
+	"strconv"
+
+	"go.opentelemetry.io/otel/trace"
 	"gopkg.in/yaml.v2"
 
 	commonmodels "github.com/koderover/zadig/v2/pkg/microservice/aslan/core/common/repository/models"
+	"github.com/koderover/zadig/v2/pkg/tool/tracing"
 	"github.com/koderover/zadig/v2/pkg/types"
 )
 
@@ -43,6 +47,10 @@ type JobContext struct {
 	Paths string `yaml:"paths"`
 	// ConfigMapName save the name of the configmap in which the jobContext resides
 	ConfigMapName string `yaml:"config_map_name"`
+	// TraceParent w3c trace context of the job span, used to link the step spans to the workflow task trace
+	TraceParent string `yaml:"trace_parent"`
+	// TraceEndpoint otlp endpoint to export the step spans to, tracing is disabled if it is empty
+	TraceEndpoint string `yaml:"trace_endpoint"`
 
 	Steps   []*commonmodels.StepTask `yaml:"steps"`
 	Outputs []string                 `yaml:"outputs"`
@@ -50,6 +58,14 @@ type JobContext struct {
 	Cache *JobCacheConfig `yaml:"cache"`
 	// Files to be downloaded for VM jobs, DO NOT USE in k8s infrastructure
 	Files []*JobFileInfo `yaml:"files"`
+	// Container is set when the vm job runs its script steps in a container instead of the agent host
+	Container *JobContainer `yaml:"container,omitempty"`
+}
+
+// JobSpanID is the span id of a job in the workflow task trace, it is known before the job starts
+// so that the executors can attach their step spans to it.
+func JobSpanID(workflowName string, taskID int64, jobName string) trace.SpanID {
+	return tracing.SpanID(workflowName, strconv.FormatInt(taskID, 10), "job", jobName)
 }
 
 func (j *JobContext) Decode(job string) error {
@@ -65,6 +81,20 @@ type JobCacheConfig struct {
 	CacheUserDir string             `json:"cache_user_dir"`
 }
 
+// JobContainer is the container a vm job runs in, the workspace of the job is bind-mounted into it
+type JobContainer struct {
+	Image    string            `yaml:"image"`
+	Registry *JobContainerAuth `yaml:"registry,omitempty"`
+}
+
+type JobContainerAuth struct {
+	Address  string `yaml:"address"`
+	Username string `yaml:"username"`
+	// Password is filled from the registry only when the job is handed to the agent, it is not stored in the job context
+	Password   string `yaml:"password,omitempty"`
+	RegistryID string `yaml:"registry_id"`
+}
+
 // JobFileInfo contains information about files that need to be downloaded for VM jobs
 type JobFileInfo struct {
 	// EnvKey is the environment variable name that will contain the file path
@@ -101,6 +101,14 @@ func instantiateStepCtl(step *commonmodels.StepTask, workflowCtx *commonmodels.W
 		stepCtl, err = NewSonarGetMetricsCtl(step, workflowCtx, logger)
 	case config.StepDistributeImage:
 		stepCtl, err = NewDistributeCtl(step, workflowCtx, jobKey, logger)
+	case config.StepImageScan:
+		stepCtl, err = NewImageScanCtl(step, workflowCtx, jobKey, logger)
+	case config.StepImageSign:
+		stepCtl, err = NewImageSignCtl(step, logger)
+	case config.StepSBOM:
+		stepCtl, err = NewSBOMCtl(step, workflowCtx, jobKey, logger)
+	case config.StepMockVerify:
+		stepCtl, err = NewMockVerifyCtl(step, logger)
 	case config.StepDebugBefore, config.StepDebugAfter:
 		stepCtl, err = NewDebugCtl()
 	default:
@@ -113,7 +113,9 @@ func (s *dockerBuildCtl) AfterRun(ctx context.Context) error {
 		deliveryCommit := new(commonmodels.ActivityCommit)
 		deliveryCommit.Address = repo.Address
 		deliveryCommit.Source = repo.Source
+		deliveryCommit.CodehostID = repo.CodehostID
 		deliveryCommit.RepoOwner = repo.RepoOwner
+		deliveryCommit.RepoNamespace = repo.RepoNamespace
 		deliveryCommit.RepoName = repo.RepoName
 		deliveryCommit.Branch = repo.Branch
 		deliveryCommit.Tag = repo.Tag
@@ -0,0 +1,146 @@
+/*
+Copyright 2025 The KodeRover Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package stepcontroller
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"go.uber.org/zap"
+	"gopkg.in/yaml.v2"
+
+	commonmodels "github.com/koderover/zadig/v2/pkg/microservice/aslan/core/common/repository/models"
+	commonrepo "github.com/koderover/zadig/v2/pkg/microservice/aslan/core/common/repository/mongodb"
+	"github.com/koderover/zadig/v2/pkg/microservice/aslan/core/common/service/s3"
+	s3tool "github.com/koderover/zadig/v2/pkg/tool/s3"
+	"github.com/koderover/zadig/v2/pkg/types/step"
+	"github.com/koderover/zadig/v2/pkg/util"
+)
+
+type imageScanCtl struct {
+	step          *commonmodels.StepTask
+	imageScanSpec *step.StepImageScanSpec
+	workflowCtx   *commonmodels.WorkflowTaskCtx
+	jobKey        string
+	log           *zap.SugaredLogger
+}
+
+func NewImageScanCtl(stepTask *commonmodels.StepTask, workflowCtx *commonmodels.WorkflowTaskCtx, jobKey string, log *zap.SugaredLogger) (*imageScanCtl, error) {
+	yamlString, err := yaml.Marshal(stepTask.Spec)
+	if err != nil {
+		return nil, fmt.Errorf("marshal image scan spec error: %v", err)
+	}
+	imageScanSpec := &step.StepImageScanSpec{}
+	if err := yaml.Unmarshal(yamlString, &imageScanSpec); err != nil {
+		return nil, fmt.Errorf("unmarshal image scan spec error: %v", err)
+	}
+	stepTask.Spec = imageScanSpec
+	return &imageScanCtl{imageScanSpec: imageScanSpec, workflowCtx: workflowCtx, jobKey: jobKey, log: log, step: stepTask}, nil
+}
+
+func (s *imageScanCtl) PreRun(ctx context.Context) error {
+	if s.imageScanSpec.S3Storage == nil {
+		modelS3, err := commonrepo.NewS3StorageColl().FindDefault()
+		if err != nil {
+			return err
+		}
+		s.imageScanSpec.S3Storage = modelS3toS3(modelS3)
+	}
+	s.step.Spec = s.imageScanSpec
+	return nil
+}
+
+// AfterRun saves the scan report of every image and writes the digest and the verdict back to the step
+// so the task detail shows them.
+func (s *imageScanCtl) AfterRun(ctx context.Context) error {
+	reports, err := s.downloadReports()
+	if err != nil {
+		s.log.Errorf("download image scan report error: %v", err)
+		return err
+	}
+
+	reportMap := make(map[string]*step.ImageScanReport)
+	for _, report := range reports {
+		reportMap[report.Image] = report
+	}
+	for _, target := range s.imageScanSpec.Targets {
+		report, ok := reportMap[target.Image]
+		if !ok {
+			continue
+		}
+		target.Digest = report.Digest
+		target.Summary = report.Summary()
+		target.Violations = s.imageScanSpec.Policy.Violations(report)
+		target.Passed = len(target.Violations) == 0
+
+		err := commonrepo.NewImageScanResultColl().Create(&commonmodels.ImageScanResult{
+			ProjectName:     s.workflowCtx.ProjectName,
+			WorkflowName:    s.workflowCtx.WorkflowName,
+			JobName:         s.jobKey,
+			TaskID:          s.workflowCtx.TaskID,
+			ServiceName:     target.ServiceName,
+			ServiceModule:   target.ServiceModule,
+			Image:           target.Image,
+			Digest:          target.Digest,
+			Passed:          target.Passed,
+			Summary:         target.Summary,
+			Violations:      target.Violations,
+			Vulnerabilities: report.Vulnerabilities,
+			CreateTime:      time.Now().Unix(),
+		})
+		if err != nil {
+			s.log.Errorf("save image scan result of %s error: %v", target.Image, err)
+		}
+	}
+	s.step.Spec = s.imageScanSpec
+	return nil
+}
+
+func (s *imageScanCtl) downloadReports() ([]*step.ImageScanReport, error) {
+	filename, err := util.GenerateTmpFile()
+	if err != nil {
+		return nil, err
+	}
+	defer os.Remove(filename)
+
+	storage, err := s3.FindDefaultS3()
+	if err != nil {
+		return nil, err
+	}
+	client, err := s3tool.NewClient(storage.Endpoint, storage.Ak, storage.Sk, storage.Region, storage.Insecure, storage.Provider)
+	if err != nil {
+		return nil, err
+	}
+	objectKey := filepath.Join(s.imageScanSpec.S3Storage.Subfolder, s.imageScanSpec.S3DestDir, s.imageScanSpec.FileName)
+	if err := client.Download(storage.Bucket, objectKey, filename); err != nil {
+		return nil, err
+	}
+
+	b, err := os.ReadFile(filename)
+	if err != nil {
+		return nil, err
+	}
+	reports := make([]*step.ImageScanReport, 0)
+	if err := json.Unmarshal(b, &reports); err != nil {
+		return nil, err
+	}
+	return reports, nil
+}
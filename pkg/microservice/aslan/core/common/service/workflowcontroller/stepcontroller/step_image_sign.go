@@ -0,0 +1,92 @@
+/*
+Copyright 2025 The KodeRover Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package stepcontroller
+
+import (
+	"context"
+	"fmt"
+
+	"go.uber.org/zap"
+	"gopkg.in/yaml.v2"
+
+	"github.com/koderover/zadig/v2/pkg/microservice/aslan/config"
+	commonmodels "github.com/koderover/zadig/v2/pkg/microservice/aslan/core/common/repository/models"
+	commonrepo "github.com/koderover/zadig/v2/pkg/microservice/aslan/core/common/repository/mongodb"
+	"github.com/koderover/zadig/v2/pkg/microservice/aslan/core/common/service/keyvault"
+	"github.com/koderover/zadig/v2/pkg/types/step"
+)
+
+type imageSignCtl struct {
+	step          *commonmodels.StepTask
+	imageSignSpec *step.StepImageSignSpec
+	log           *zap.SugaredLogger
+}
+
+func NewImageSignCtl(stepTask *commonmodels.StepTask, log *zap.SugaredLogger) (*imageSignCtl, error) {
+	yamlString, err := yaml.Marshal(stepTask.Spec)
+	if err != nil {
+		return nil, fmt.Errorf("marshal image sign spec error: %v", err)
+	}
+	imageSignSpec := &step.StepImageSignSpec{}
+	if err := yaml.Unmarshal(yamlString, &imageSignSpec); err != nil {
+		return nil, fmt.Errorf("unmarshal image sign spec error: %v", err)
+	}
+	stepTask.Spec = imageSignSpec
+	return &imageSignCtl{imageSignSpec: imageSignSpec, log: log, step: stepTask}, nil
+}
+
+func (s *imageSignCtl) PreRun(ctx context.Context) error {
+	systemSetting, err := commonrepo.NewSystemSettingColl().Get()
+	if err != nil {
+		return fmt.Errorf("failed to get system settings: %v", err)
+	}
+	if systemSetting.Security == nil || systemSetting.Security.ImageSigning == nil || !systemSetting.Security.ImageSigning.Enabled {
+		return fmt.Errorf("image signing is not enabled")
+	}
+	signing := systemSetting.Security.ImageSigning
+
+	switch signing.KeySource {
+	case config.ImageSigningKeySourceKeyVault:
+		item, err := commonrepo.NewKeyVaultItemColl().Find(&commonrepo.KeyVaultItemFindOption{
+			Group:        signing.KeyVaultGroup,
+			Key:          signing.KeyVaultKey,
+			IsSystemWide: true,
+		})
+		if err != nil {
+			return fmt.Errorf("failed to find signing key %s/%s: %v", signing.KeyVaultGroup, signing.KeyVaultKey, err)
+		}
+		// only the placeholder is saved with the task, the key is resolved when the job context is sent to the executor
+		s.imageSignSpec.PrivateKey = keyvault.Placeholder(item)
+	case config.ImageSigningKeySourceKMS:
+		s.imageSignSpec.KeyRef = signing.KMSKeyRef
+	default:
+		return fmt.Errorf("invalid signing key source %s", signing.KeySource)
+	}
+
+	for _, target := range s.imageSignSpec.Targets {
+		if target.Distribute != nil {
+			target.Distribute.SetTargetImage(s.imageSignSpec.Registry)
+			target.Image = target.Distribute.TargetImage
+		}
+	}
+	s.step.Spec = s.imageSignSpec
+	return nil
+}
+
+func (s *imageSignCtl) AfterRun(ctx context.Context) error {
+	return nil
+}
@@ -0,0 +1,95 @@
+/*
+Copyright 2025 The KodeRover Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package stepcontroller
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"go.uber.org/zap"
+	"gopkg.in/yaml.v2"
+
+	commonmodels "github.com/koderover/zadig/v2/pkg/microservice/aslan/core/common/repository/models"
+	commonrepo "github.com/koderover/zadig/v2/pkg/microservice/aslan/core/common/repository/mongodb"
+	"github.com/koderover/zadig/v2/pkg/microservice/aslan/core/common/service/mockservice"
+	"github.com/koderover/zadig/v2/pkg/setting"
+	"github.com/koderover/zadig/v2/pkg/types/step"
+	"github.com/koderover/zadig/v2/pkg/util"
+)
+
+type mockVerifyCtl struct {
+	step           *commonmodels.StepTask
+	mockVerifySpec *step.StepMockVerifySpec
+	log            *zap.SugaredLogger
+}
+
+func NewMockVerifyCtl(stepTask *commonmodels.StepTask, log *zap.SugaredLogger) (*mockVerifyCtl, error) {
+	yamlString, err := yaml.Marshal(stepTask.Spec)
+	if err != nil {
+		return nil, fmt.Errorf("marshal mock verify spec error: %v", err)
+	}
+	mockVerifySpec := &step.StepMockVerifySpec{}
+	if err := yaml.Unmarshal(yamlString, &mockVerifySpec); err != nil {
+		return nil, fmt.Errorf("unmarshal mock verify spec error: %v", err)
+	}
+	stepTask.Spec = mockVerifySpec
+	return &mockVerifyCtl{mockVerifySpec: mockVerifySpec, log: log, step: stepTask}, nil
+}
+
+// PreRun resolves the request journals of the mocks deployed in the environments, only the requests received after
+// the job started are verified. The journals are reached by the in-cluster service address, so the environments must
+// be in the cluster the testing runs in.
+func (s *mockVerifyCtl) PreRun(ctx context.Context) error {
+	since := time.Now().UnixMilli()
+	for _, target := range s.mockVerifySpec.Targets {
+		env, err := commonrepo.NewProductColl().Find(&commonrepo.ProductFindOptions{
+			Name:       s.mockVerifySpec.ProjectName,
+			EnvName:    target.EnvName,
+			Production: util.GetBoolPointer(false),
+		})
+		if err != nil {
+			return fmt.Errorf("failed to find env %s: %v", target.EnvName, err)
+		}
+		if clusterIDOrLocal(env.ClusterID) != clusterIDOrLocal(s.mockVerifySpec.ClusterID) {
+			return fmt.Errorf("the mock of service %s in env %s can't be verified, env %s is not in the cluster the testing runs in", target.ServiceName, target.EnvName, target.EnvName)
+		}
+		envMock, err := commonrepo.NewEnvMockColl().Find(s.mockVerifySpec.ProjectName, target.EnvName, target.ServiceName)
+		if err != nil {
+			return fmt.Errorf("mock of service %s is not deployed in env %s", target.ServiceName, target.EnvName)
+		}
+		mock, err := commonrepo.NewMockServiceColl().Find(s.mockVerifySpec.ProjectName, target.ServiceName, envMock.Revision)
+		if err != nil {
+			return fmt.Errorf("failed to find revision %d of the mock of service %s: %v", envMock.Revision, target.ServiceName, err)
+		}
+		target.JournalURL = mockservice.JournalURL(env.Namespace, mock.K8sServiceName)
+		target.Since = since
+	}
+	s.step.Spec = s.mockVerifySpec
+	return nil
+}
+
+func (s *mockVerifyCtl) AfterRun(ctx context.Context) error {
+	return nil
+}
+
+func clusterIDOrLocal(clusterID string) string {
+	if clusterID == "" {
+		return setting.LocalClusterID
+	}
+	return clusterID
+}
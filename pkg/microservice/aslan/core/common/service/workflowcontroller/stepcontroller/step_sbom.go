@@ -0,0 +1,151 @@
+/*
+Copyright 2025 The KodeRover Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package stepcontroller
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path"
+	"strings"
+	"time"
+
+	"go.uber.org/zap"
+	"gopkg.in/yaml.v2"
+
+	commonmodels "github.com/koderover/zadig/v2/pkg/microservice/aslan/core/common/repository/models"
+	commonrepo "github.com/koderover/zadig/v2/pkg/microservice/aslan/core/common/repository/mongodb"
+	"github.com/koderover/zadig/v2/pkg/microservice/aslan/core/common/service/s3"
+	s3tool "github.com/koderover/zadig/v2/pkg/tool/s3"
+	"github.com/koderover/zadig/v2/pkg/tool/sbom"
+	"github.com/koderover/zadig/v2/pkg/types/step"
+	"github.com/koderover/zadig/v2/pkg/util"
+)
+
+type sbomCtl struct {
+	step        *commonmodels.StepTask
+	sbomSpec    *step.StepSBOMSpec
+	workflowCtx *commonmodels.WorkflowTaskCtx
+	jobKey      string
+	log         *zap.SugaredLogger
+}
+
+func NewSBOMCtl(stepTask *commonmodels.StepTask, workflowCtx *commonmodels.WorkflowTaskCtx, jobKey string, log *zap.SugaredLogger) (*sbomCtl, error) {
+	yamlString, err := yaml.Marshal(stepTask.Spec)
+	if err != nil {
+		return nil, fmt.Errorf("marshal sbom spec error: %v", err)
+	}
+	sbomSpec := &step.StepSBOMSpec{}
+	if err := yaml.Unmarshal(yamlString, &sbomSpec); err != nil {
+		return nil, fmt.Errorf("unmarshal sbom spec error: %v", err)
+	}
+	stepTask.Spec = sbomSpec
+	return &sbomCtl{sbomSpec: sbomSpec, workflowCtx: workflowCtx, jobKey: jobKey, log: log, step: stepTask}, nil
+}
+
+func (s *sbomCtl) PreRun(ctx context.Context) error {
+	if s.sbomSpec.S3Storage == nil {
+		modelS3, err := commonrepo.NewS3StorageColl().FindDefault()
+		if err != nil {
+			return err
+		}
+		s.sbomSpec.S3Storage = modelS3toS3(modelS3)
+	}
+	s.step.Spec = s.sbomSpec
+	return nil
+}
+
+// AfterRun indexes the components of every archived sbom document by the image digest, the step may not have
+// run at all when an earlier step failed, in which case there is nothing to collect.
+func (s *sbomCtl) AfterRun(ctx context.Context) error {
+	storage, err := s3.FindDefaultS3()
+	if err != nil {
+		s.log.Errorf("find default s3 error: %v", err)
+		return nil
+	}
+	client, err := s3tool.NewClient(storage.Endpoint, storage.Ak, storage.Sk, storage.Region, storage.Insecure, storage.Provider)
+	if err != nil {
+		s.log.Errorf("create s3 client error: %v", err)
+		return nil
+	}
+
+	index := make([]*step.SBOMTarget, 0)
+	if err := s.download(client, storage.Bucket, step.SBOMIndexFileName, func(b []byte) error {
+		return json.Unmarshal(b, &index)
+	}); err != nil {
+		s.log.Warnf("no sbom index found for job %s: %v", s.jobKey, err)
+		return nil
+	}
+
+	for _, target := range index {
+		var components []*sbom.Component
+		err := s.download(client, storage.Bucket, target.FileName, func(b []byte) error {
+			var err error
+			components, err = sbom.Parse(s.sbomSpec.Format, b)
+			return err
+		})
+		if err != nil {
+			s.log.Errorf("read sbom of image %s error: %v", target.Image, err)
+			continue
+		}
+		target.Components = len(components)
+
+		err = commonrepo.NewSBOMColl().Create(&commonmodels.SBOM{
+			ProjectName:   s.workflowCtx.ProjectName,
+			WorkflowName:  s.workflowCtx.WorkflowName,
+			JobName:       s.jobKey,
+			TaskID:        s.workflowCtx.TaskID,
+			ServiceName:   target.ServiceName,
+			ServiceModule: target.ServiceModule,
+			Image:         target.Image,
+			Digest:        target.Digest,
+			Format:        s.sbomSpec.Format,
+			StorageID:     storage.ID.Hex(),
+			ObjectKey:     s.objectKey(target.FileName),
+			Components:    components,
+			CreateTime:    time.Now().Unix(),
+		})
+		if err != nil {
+			s.log.Errorf("save sbom of image %s error: %v", target.Image, err)
+		}
+	}
+	s.sbomSpec.Targets = index
+	s.step.Spec = s.sbomSpec
+	return nil
+}
+
+func (s *sbomCtl) objectKey(fileName string) string {
+	return strings.TrimLeft(path.Join(s.sbomSpec.S3Storage.Subfolder, s.sbomSpec.S3DestDir, fileName), "/")
+}
+
+func (s *sbomCtl) download(client *s3tool.Client, bucket, fileName string, handle func([]byte) error) error {
+	filename, err := util.GenerateTmpFile()
+	if err != nil {
+		return err
+	}
+	defer os.Remove(filename)
+
+	if err := client.Download(bucket, s.objectKey(fileName), filename); err != nil {
+		return err
+	}
+	b, err := os.ReadFile(filename)
+	if err != nil {
+		return err
+	}
+	return handle(b)
+}
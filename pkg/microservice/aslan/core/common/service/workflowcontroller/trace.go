@@ -0,0 +1,156 @@
+/*
+Copyright 2025 The KodeRover Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package workflowcontroller
+
+import (
+	"context"
+	"strconv"
+	"strings"
+	"time"
+
+	"go.opentelemetry.io/otel/attribute"
+
+	"github.com/koderover/zadig/v2/pkg/microservice/aslan/config"
+	commonmodels "github.com/koderover/zadig/v2/pkg/microservice/aslan/core/common/repository/models"
+	"github.com/koderover/zadig/v2/pkg/microservice/aslan/core/common/service/workflowcontroller/jobcontroller"
+	"github.com/koderover/zadig/v2/pkg/tool/tracing"
+	"github.com/koderover/zadig/v2/pkg/util"
+)
+
+// exportWorkflowTaskTrace exports the finished workflow task as a trace: a task span with queueing, stage and job
+// spans below it. The job span ids are the ones already propagated to jobexecutor and zadig-agent, so the step
+// spans reported by them are linked to the same trace.
+func exportWorkflowTaskTrace(task *commonmodels.WorkflowTask) {
+	if !tracing.Enabled() {
+		return
+	}
+
+	taskID := strconv.FormatInt(task.TaskID, 10)
+	traceID := tracing.TaskTraceID(task.WorkflowName, task.TaskID)
+	baseAttrs := []attribute.KeyValue{
+		tracing.AttrProject.String(task.ProjectName),
+		tracing.AttrWorkflow.String(task.WorkflowName),
+		tracing.AttrTaskID.Int64(task.TaskID),
+	}
+
+	createTime := task.CreateTime
+	if createTime == 0 {
+		createTime = task.StartTime
+	}
+	taskCtx := tracing.Record(context.Background(), &tracing.Span{
+		Name:       task.WorkflowName + " #" + taskID,
+		TraceID:    traceID,
+		SpanID:     tracing.SpanID(task.WorkflowName, taskID),
+		Start:      time.Unix(createTime, 0),
+		End:        time.Unix(task.EndTime, 0),
+		Failed:     isTraceFailedStatus(task.Status),
+		Message:    task.Error,
+		Attributes: append(baseAttrs, tracing.AttrStatus.String(string(task.Status)), tracing.AttrTaskCreator.String(task.TaskCreator)),
+	})
+
+	// a task canceled in the queue has never started
+	if task.StartTime != 0 {
+		tracing.Record(taskCtx, &tracing.Span{
+			Name:       "queue",
+			TraceID:    traceID,
+			SpanID:     tracing.SpanID(task.WorkflowName, taskID, "queue"),
+			Start:      time.Unix(createTime, 0),
+			End:        time.Unix(task.StartTime, 0),
+			Attributes: baseAttrs,
+		})
+	}
+
+	for _, stage := range task.Stages {
+		if stage.StartTime == 0 {
+			continue
+		}
+		stageAttrs := append(baseAttrs, tracing.AttrStage.String(stage.Name))
+		stageCtx := tracing.Record(taskCtx, &tracing.Span{
+			Name:       "stage " + stage.Name,
+			TraceID:    traceID,
+			SpanID:     tracing.SpanID(task.WorkflowName, taskID, "stage", stage.Name),
+			Start:      time.Unix(stage.StartTime, 0),
+			End:        time.Unix(traceEndTime(stage.StartTime, stage.EndTime), 0),
+			Failed:     isTraceFailedStatus(stage.Status),
+			Message:    stage.Error,
+			Attributes: append(stageAttrs, tracing.AttrStatus.String(string(stage.Status))),
+		})
+
+		for _, job := range stage.Jobs {
+			if job.StartTime == 0 {
+				continue
+			}
+			tracing.Record(stageCtx, &tracing.Span{
+				Name:       "job " + job.Name,
+				TraceID:    traceID,
+				SpanID:     jobcontroller.JobSpanID(task.WorkflowName, task.TaskID, job.Name),
+				Start:      time.Unix(job.StartTime, 0),
+				End:        time.Unix(traceEndTime(job.StartTime, job.EndTime), 0),
+				Failed:     isTraceFailedStatus(job.Status),
+				Message:    job.Error,
+				Attributes: append(jobTraceAttributes(job), stageAttrs...),
+			})
+		}
+	}
+}
+
+func jobTraceAttributes(job *commonmodels.JobTask) []attribute.KeyValue {
+	attrs := []attribute.KeyValue{
+		tracing.AttrJob.String(job.Name),
+		tracing.AttrJobType.String(job.JobType),
+		tracing.AttrStatus.String(string(job.Status)),
+		tracing.AttrInfra.String(job.Infrastructure),
+	}
+
+	services := make([]string, 0, len(job.ServiceModules))
+	for _, module := range job.ServiceModules {
+		services = append(services, module.ServiceName)
+	}
+	if len(services) > 0 {
+		attrs = append(attrs, tracing.AttrService.String(strings.Join(services, ",")))
+	}
+
+	// most of the job specs carry the cluster either directly or in the job properties
+	spec := &struct {
+		ClusterID  string `json:"cluster_id"`
+		Properties struct {
+			ClusterID string `json:"cluster_id"`
+		} `json:"properties"`
+	}{}
+	if err := util.IToi(job.Spec, spec); err == nil {
+		clusterID := spec.ClusterID
+		if clusterID == "" {
+			clusterID = spec.Properties.ClusterID
+		}
+		if clusterID != "" {
+			attrs = append(attrs, tracing.AttrCluster.String(clusterID))
+		}
+	}
+
+	return attrs
+}
+
+func traceEndTime(start, end int64) int64 {
+	if end < start {
+		return start
+	}
+	return end
+}
+
+func isTraceFailedStatus(status config.Status) bool {
+	return status == config.StatusFailed || status == config.StatusTimeout || status == config.StatusReject
+}
@@ -48,6 +48,7 @@ import (
 	"github.com/koderover/zadig/v2/pkg/tool/kube/podexec"
 	"github.com/koderover/zadig/v2/pkg/tool/kube/updater"
 	"github.com/koderover/zadig/v2/pkg/tool/log"
+	"github.com/koderover/zadig/v2/pkg/tool/metrics"
 )
 
 const (
@@ -187,11 +188,16 @@ func (c *workflowCtl) Run(ctx context.Context, concurrency int) {
 	c.workflowTask.Status = config.StatusRunning
 	c.workflowTask.StartTime = time.Now().Unix()
 	c.ack()
+	if c.workflowTask.CreateTime > 0 {
+		metrics.ObserveQueueWaitTime(c.workflowTask.ProjectName, c.workflowTask.StartTime-c.workflowTask.CreateTime)
+	}
 	c.logger.Infof("start workflow: %s,status: %s", c.workflowTask.WorkflowName, c.workflowTask.Status)
 	defer func() {
 		c.workflowTask.EndTime = time.Now().Unix()
 		c.logger.Infof("finish workflow: %s,status: %s", c.workflowTask.WorkflowName, c.workflowTask.Status)
 		c.ack()
+		metrics.IncTaskTotal(c.workflowTask.ProjectName, string(c.workflowTask.Status))
+		exportWorkflowTaskTrace(c.workflowTask)
 
 		if c.workflowTask.Status == config.StatusPassed {
 			// clean share storage after workflow finished
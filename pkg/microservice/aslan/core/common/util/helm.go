@@ -26,7 +26,9 @@ import (
 
 	"github.com/27149chen/afero"
 	"gopkg.in/yaml.v2"
+	helmregistry "helm.sh/helm/v3/pkg/registry"
 	"helm.sh/helm/v3/pkg/repo"
+	k8syaml "sigs.k8s.io/yaml"
 
 	"github.com/koderover/zadig/v2/pkg/microservice/aslan/config"
 	commonmodels "github.com/koderover/zadig/v2/pkg/microservice/aslan/core/common/repository/models"
@@ -320,12 +322,27 @@ func ReplaceImage(sourceYaml string, imageValuesMap ...map[string]interface{}) (
 }
 
 func GeneHelmRepo(chartRepo *commonmodels.HelmRepo) *repo.Entry {
-	return &repo.Entry{
+	entry := &repo.Entry{
 		Name:     chartRepo.RepoName,
 		URL:      chartRepo.URL,
 		Username: chartRepo.Username,
 		Password: chartRepo.Password,
 	}
+
+	// oci chart repos served by an image registry, e.g. harbor or ecr, log in with the credentials of the registry
+	if chartRepo.RegistryID != "" && helmregistry.IsOCI(chartRepo.URL) {
+		reg, err := commonrepo.NewRegistryNamespaceColl().Find(&commonrepo.FindRegOps{ID: chartRepo.RegistryID})
+		if err == nil {
+			reg, err = DecodeRegistry(reg)
+		}
+		if err != nil {
+			log.Errorf("failed to get credentials of registry %s for chart repo %s, err: %s", chartRepo.RegistryID, chartRepo.RepoName, err)
+			return entry
+		}
+		entry.Username = reg.AccessKey
+		entry.Password = reg.SecretKey
+	}
+	return entry
 }
 
 func GetValidMatchData(spec *commonmodels.ImagePathSpec) map[string]string {
@@ -350,6 +367,7 @@ func NewHelmClient(chartRepo *commonmodels.HelmRepo) (*helmtool.HelmClient, erro
 	if err != nil {
 		return nil, fmt.Errorf("failed to new helm client, err: %s", err)
 	}
+	client.PlainHTTP = chartRepo.PlainHTTP
 
 	if !chartRepo.EnableProxy {
 		return client, nil
@@ -394,3 +412,38 @@ func GenHelmChartProxy(chartRepo *commonmodels.HelmRepo) (*helmtool.Proxy, error
 
 	return proxy, nil
 }
+
+// GetHelmChartDeployImages returns the images of the chart release, parsed from the default values of the chart merged
+// with the values of the deployment.
+func GetHelmChartDeployImages(projectName string, production bool, deploy *commonmodels.DeployHelmChart) ([]string, error) {
+	chartRepo, err := commonrepo.NewHelmRepoColl().Find(&commonrepo.HelmRepoFindOption{RepoName: deploy.ChartRepo})
+	if err != nil {
+		return nil, fmt.Errorf("failed to find chart repo %s, error: %v", deploy.ChartRepo, err)
+	}
+	client, err := NewHelmClient(chartRepo)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create helm client, error: %v", err)
+	}
+	chartValues, err := client.GetChartValues(GeneHelmRepo(chartRepo), projectName, deploy.ReleaseName, deploy.ChartRepo, deploy.ChartName, deploy.ChartVersion, production)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get values of chart %s/%s, error: %v", deploy.ChartRepo, deploy.ChartName, err)
+	}
+	mergedValues, err := helmtool.MergeOverrideValues(chartValues, "", deploy.ValuesYaml, "", nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to merge values of release %s, error: %v", deploy.ReleaseName, err)
+	}
+	valuesMap := make(map[string]interface{})
+	if err := k8syaml.Unmarshal([]byte(mergedValues), &valuesMap); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal values of release %s, error: %v", deploy.ReleaseName, err)
+	}
+	containers, err := ParseImagesForProductService(valuesMap, deploy.ReleaseName, projectName)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse images of release %s, error: %v", deploy.ReleaseName, err)
+	}
+
+	images := make([]string, 0, len(containers))
+	for _, container := range containers {
+		images = append(images, container.Image)
+	}
+	return images, nil
+}
@@ -158,7 +158,8 @@ func SetCurrentContainerImages(args *commonmodels.Service) error {
 			if resKind.Kind == setting.Deployment ||
 				resKind.Kind == setting.StatefulSet ||
 				resKind.Kind == setting.Job ||
-				resKind.Kind == setting.CloneSet {
+				resKind.Kind == setting.CloneSet ||
+				resKind.Kind == setting.Rollout {
 				containers, err := getContainers(yamlData)
 				if err != nil {
 					return fmt.Errorf("GetContainers error: %v", err)
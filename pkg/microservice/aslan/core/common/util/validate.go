@@ -56,6 +56,16 @@ func CheckDefineResourceParam(req setting.Request, reqSpec setting.RequestSpec)
 	return nil
 }
 
+// CheckImageBuildBackend checks the image build backend of clusters and builds, empty means the default one.
+func CheckImageBuildBackend(backend string) error {
+	switch backend {
+	case "", setting.ImageBuildBackendDocker, setting.ImageBuildBackendBuildKit:
+		return nil
+	default:
+		return fmt.Errorf("unsupported image build backend: %s", backend)
+	}
+}
+
 func CheckZadigProfessionalLicense() error {
 	licenseStatus, err := plutusvendor.New().CheckZadigXLicenseStatus()
 	if err != nil {
@@ -45,6 +45,9 @@ func (*Router) Inject(router *gin.RouterGroup) {
 		deliveryRelease.POST("/helm/global-variables", ApplyDeliveryGlobalVariables)
 		deliveryRelease.GET("/helm/charts", DownloadDeliveryChart)
 		deliveryRelease.GET("/helm/charts/version", GetChartVersionFromRepo)
+		deliveryRelease.POST("/:id/releaseNote", GenerateDeliveryReleaseNote)
+		deliveryRelease.GET("/:id/releaseNote", GetDeliveryReleaseNote)
+		deliveryRelease.GET("/:id/releaseNote/markdown", ExportDeliveryReleaseNote)
 		// deliveryRelease.GET("/helm/charts/preview", PreviewGetDeliveryChart)
 		// deliveryRelease.GET("/helm/charts/filePath", GetDeliveryChartFilePath)
 		// deliveryRelease.GET("/helm/charts/fileContent", GetDeliveryChartFileContent)
@@ -596,3 +596,156 @@ func CheckDeliveryVersion(c *gin.Context) {
 
 	ctx.RespErr = deliveryservice.CheckDeliveryVersion(projectName, versionName)
 }
+
+// @Summary Generate Delivery Version Release Note
+// @Description Generate the release note of a delivery version from the commits between the previous version and this one
+// @Tags 	delivery
+// @Accept 	json
+// @Produce json
+// @Param 	id 				path 		string											true	"id"
+// @Param 	projectName		query		string											true	"projectName"
+// @Param 	body 			body 		deliveryservice.GenerateDeliveryReleaseNoteArgs 	true 	"body"
+// @Success 200     {object} 	models.DeliveryReleaseNote
+// @Router /api/aslan/delivery/releases/{id}/releaseNote [post]
+func GenerateDeliveryReleaseNote(c *gin.Context) {
+	ctx, err := internalhandler.NewContextWithAuthorization(c)
+	defer func() { internalhandler.JSONResponse(c, ctx) }()
+
+	if err != nil {
+		ctx.RespErr = fmt.Errorf("authorization Info Generation failed: err %s", err)
+		ctx.UnAuthorized = true
+		return
+	}
+
+	projectKey := c.Query("projectName")
+	if projectKey == "" {
+		ctx.RespErr = e.ErrInvalidParam.AddDesc("projectName can't be empty!")
+		return
+	}
+
+	args := new(deliveryservice.GenerateDeliveryReleaseNoteArgs)
+	if err := c.ShouldBindJSON(args); err != nil {
+		ctx.RespErr = e.ErrInvalidParam.AddErr(err)
+		return
+	}
+
+	// authorization checks
+	if !ctx.Resources.IsSystemAdmin {
+		if _, ok := ctx.Resources.ProjectAuthInfo[projectKey]; !ok {
+			ctx.UnAuthorized = true
+			return
+		}
+
+		if !ctx.Resources.ProjectAuthInfo[projectKey].IsProjectAdmin &&
+			!ctx.Resources.ProjectAuthInfo[projectKey].Version.Create {
+			ctx.UnAuthorized = true
+			return
+		}
+	}
+
+	err = commonutil.CheckZadigProfessionalLicense()
+	if err != nil {
+		ctx.RespErr = err
+		return
+	}
+
+	internalhandler.InsertOperationLog(c, ctx.UserName, projectKey, "生成", "版本交付-发布说明", c.Param("id"), c.Param("id"), "", types.RequestBodyTypeJSON, ctx.Logger)
+
+	ctx.Resp, ctx.RespErr = deliveryservice.GenerateDeliveryReleaseNote(c.Param("id"), args, ctx.UserName, ctx.Logger)
+}
+
+// @Summary Get Delivery Version Release Note
+// @Description Get Delivery Version Release Note
+// @Tags 	delivery
+// @Accept 	json
+// @Produce json
+// @Param 	id 				path 		string							true	"id"
+// @Param 	projectName		query		string							true	"projectName"
+// @Success 200     {object} 	models.DeliveryReleaseNote
+// @Router /api/aslan/delivery/releases/{id}/releaseNote [get]
+func GetDeliveryReleaseNote(c *gin.Context) {
+	ctx, err := internalhandler.NewContextWithAuthorization(c)
+	defer func() { internalhandler.JSONResponse(c, ctx) }()
+
+	if err != nil {
+		ctx.RespErr = fmt.Errorf("authorization Info Generation failed: err %s", err)
+		ctx.UnAuthorized = true
+		return
+	}
+
+	projectKey := c.Query("projectName")
+	if projectKey == "" {
+		ctx.RespErr = e.ErrInvalidParam.AddDesc("projectName can't be empty!")
+		return
+	}
+
+	if !hasDeliveryVersionViewPermission(ctx, projectKey) {
+		ctx.UnAuthorized = true
+		return
+	}
+
+	err = commonutil.CheckZadigProfessionalLicense()
+	if err != nil {
+		ctx.RespErr = err
+		return
+	}
+
+	ctx.Resp, ctx.RespErr = deliveryservice.GetDeliveryReleaseNote(c.Param("id"), ctx.Logger)
+}
+
+// @Summary Export Delivery Version Release Note
+// @Description Export Delivery Version Release Note as markdown
+// @Tags 	delivery
+// @Accept 	json
+// @Produce octet-stream
+// @Param 	id 				path 		string							true	"id"
+// @Param 	projectName		query		string							true	"projectName"
+// @Success 200
+// @Router /api/aslan/delivery/releases/{id}/releaseNote/markdown [get]
+func ExportDeliveryReleaseNote(c *gin.Context) {
+	ctx, err := internalhandler.NewContextWithAuthorization(c)
+	defer func() { internalhandler.JSONResponse(c, ctx) }()
+
+	if err != nil {
+		ctx.RespErr = fmt.Errorf("authorization Info Generation failed: err %s", err)
+		ctx.UnAuthorized = true
+		return
+	}
+
+	projectKey := c.Query("projectName")
+	if projectKey == "" {
+		ctx.RespErr = e.ErrInvalidParam.AddDesc("projectName can't be empty!")
+		return
+	}
+
+	if !hasDeliveryVersionViewPermission(ctx, projectKey) {
+		ctx.UnAuthorized = true
+		return
+	}
+
+	err = commonutil.CheckZadigProfessionalLicense()
+	if err != nil {
+		ctx.RespErr = err
+		return
+	}
+
+	fileBytes, fileName, err := deliveryservice.ExportDeliveryReleaseNote(c.Param("id"), ctx.Logger)
+	if err != nil {
+		ctx.RespErr = err
+		return
+	}
+
+	c.Writer.Header().Set("Content-Disposition", fmt.Sprintf(`attachment; filename="%s"`, fileName))
+	c.Data(http.StatusOK, "text/markdown; charset=utf-8", fileBytes)
+}
+
+func hasDeliveryVersionViewPermission(ctx *internalhandler.Context, projectKey string) bool {
+	if ctx.Resources.IsSystemAdmin || ctx.Resources.SystemActions.DeliveryCenter.ViewVersion {
+		return true
+	}
+
+	if authInfo, ok := ctx.Resources.ProjectAuthInfo[projectKey]; ok {
+		return authInfo.IsProjectAdmin || authInfo.Version.View
+	}
+	return false
+}
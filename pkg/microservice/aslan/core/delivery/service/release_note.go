@@ -0,0 +1,608 @@
+/*
+Copyright 2025 The KodeRover Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package service
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	openapi "github.com/sashabaranov/go-openai"
+	"go.uber.org/zap"
+
+	"github.com/koderover/zadig/v2/pkg/microservice/aslan/core/code/client"
+	"github.com/koderover/zadig/v2/pkg/microservice/aslan/core/code/client/open"
+	commonmodels "github.com/koderover/zadig/v2/pkg/microservice/aslan/core/common/repository/models"
+	commonrepo "github.com/koderover/zadig/v2/pkg/microservice/aslan/core/common/repository/mongodb"
+	commonservice "github.com/koderover/zadig/v2/pkg/microservice/aslan/core/common/service"
+	"github.com/koderover/zadig/v2/pkg/setting"
+	e "github.com/koderover/zadig/v2/pkg/tool/errors"
+	"github.com/koderover/zadig/v2/pkg/tool/llm"
+	"github.com/koderover/zadig/v2/pkg/tool/log"
+	"github.com/koderover/zadig/v2/pkg/types"
+)
+
+const (
+	releaseNoteCommitsPerPage = 100
+	deliveryVersionsPerPage   = 100
+	// releaseNoteMaxCommitPages bounds the commits scanned per repo when looking for the previously released commit
+	releaseNoteMaxCommitPages = 10
+	// releaseNoteMaxSummaryInput bounds the markdown sent to the llm
+	releaseNoteMaxSummaryInput = 12000
+
+	releaseNoteCommitTypeOther = "other"
+
+	releaseNoteSummaryPrompt = `You are a release manager. Below, delimited by triple quotes, are the generated release notes of a software delivery version, grouped by service and by conventional commit type. Write a concise summary for end users in the same language as the commit messages: start with one sentence describing the release, then list the most important features, fixes and breaking changes as bullet points. Do not invent changes that are not in the notes.`
+)
+
+var (
+	conventionalCommitRegex = regexp.MustCompile(`^([a-zA-Z]+)(?:\(([^)]*)\))?(!)?:\s*(.+)$`)
+	breakingChangeRegex     = regexp.MustCompile(`(?m)^BREAKING[ -]CHANGE:`)
+
+	githubMergePRRegex   = regexp.MustCompile(`^Merge pull request #(\d+)`)
+	githubSquashPRRegex  = regexp.MustCompile(`\(#(\d+)\)\s*$`)
+	gitlabMergeRequestRe = regexp.MustCompile(`See merge request [^\s!]*!(\d+)`)
+
+	jiraIssueRegex     = regexp.MustCompile(`(?:^|[^#\w-])([A-Z][A-Z0-9_]+-\d+)\b`)
+	pingCodeIssueRegex = regexp.MustCompile(`#([A-Z][A-Z0-9_]*-\d+)\b`)
+	meegoIssueRegex    = regexp.MustCompile(`(?:^|[\s#(\[])([mf]-\d+)\b`)
+	tapdIssueRegex     = regexp.MustCompile(`--(story|bug|task)=(\d+)`)
+
+	// releaseNoteCommitTypeTitles defines the order and the title of each commit group in the exported markdown
+	releaseNoteCommitTypeTitles = []struct {
+		Type  string
+		Title string
+	}{
+		{"feat", "Features"},
+		{"fix", "Bug Fixes"},
+		{"perf", "Performance Improvements"},
+		{"refactor", "Code Refactoring"},
+		{"revert", "Reverts"},
+		{"docs", "Documentation"},
+		{"test", "Tests"},
+		{"build", "Build System"},
+		{"ci", "Continuous Integration"},
+		{"style", "Styles"},
+		{"chore", "Chores"},
+		{releaseNoteCommitTypeOther, "Other Changes"},
+	}
+)
+
+type GenerateDeliveryReleaseNoteArgs struct {
+	// Summarize asks the default llm integration to summarize the release note
+	Summarize bool `json:"summarize"`
+}
+
+// GenerateDeliveryReleaseNote starts to generate the release note of the given delivery version asynchronously,
+// the result can be fetched by GetDeliveryReleaseNote.
+func GenerateDeliveryReleaseNote(id string, args *GenerateDeliveryReleaseNoteArgs, username string, logger *zap.SugaredLogger) (*commonmodels.DeliveryReleaseNote, error) {
+	version, err := commonrepo.NewDeliveryVersionV2Coll().FindByID(id)
+	if err != nil {
+		logger.Errorf("failed to find delivery version %s, err: %v", id, err)
+		return nil, e.ErrGetDeliveryVersion.AddErr(err)
+	}
+	if version.Status != setting.DeliveryVersionStatusSuccess {
+		return nil, e.ErrGenerateReleaseNote.AddDesc(fmt.Sprintf("can't generate release note for version with status: %s", version.Status))
+	}
+
+	if args == nil {
+		args = &GenerateDeliveryReleaseNoteArgs{}
+	}
+
+	releaseNote := &commonmodels.DeliveryReleaseNote{
+		DeliveryVersionID: version.ID.Hex(),
+		ProjectName:       version.ProjectName,
+		Version:           version.Version,
+		Status:            setting.DeliveryReleaseNoteStatusGenerating,
+		CreatedBy:         username,
+		CreatedAt:         time.Now().Unix(),
+		UpdatedAt:         time.Now().Unix(),
+	}
+	if err := commonrepo.NewDeliveryReleaseNoteColl().Upsert(releaseNote); err != nil {
+		logger.Errorf("failed to save release note of version %s, err: %v", version.Version, err)
+		return nil, e.ErrGenerateReleaseNote.AddErr(err)
+	}
+
+	go generateDeliveryReleaseNote(version, releaseNote, args.Summarize)
+
+	return releaseNote, nil
+}
+
+func GetDeliveryReleaseNote(id string, logger *zap.SugaredLogger) (*commonmodels.DeliveryReleaseNote, error) {
+	releaseNote, err := commonrepo.NewDeliveryReleaseNoteColl().GetByDeliveryVersionID(id)
+	if err != nil {
+		logger.Errorf("failed to find release note of delivery version %s, err: %v", id, err)
+		return nil, e.ErrGetReleaseNote.AddErr(err)
+	}
+	return releaseNote, nil
+}
+
+// ExportDeliveryReleaseNote renders the release note of the delivery version as markdown
+func ExportDeliveryReleaseNote(id string, logger *zap.SugaredLogger) ([]byte, string, error) {
+	releaseNote, err := GetDeliveryReleaseNote(id, logger)
+	if err != nil {
+		return nil, "", err
+	}
+	if releaseNote.Status != setting.DeliveryReleaseNoteStatusSuccess {
+		return nil, "", e.ErrGetReleaseNote.AddDesc(fmt.Sprintf("release note is %s", releaseNote.Status))
+	}
+
+	fileName := fmt.Sprintf("%s-%s-release-note.md", releaseNote.ProjectName, releaseNote.Version)
+	return []byte(renderReleaseNoteMarkdown(releaseNote)), fileName, nil
+}
+
+func generateDeliveryReleaseNote(version *commonmodels.DeliveryVersionV2, releaseNote *commonmodels.DeliveryReleaseNote, summarize bool) {
+	logger := log.SugaredLogger().With("project", version.ProjectName, "version", version.Version)
+
+	err := buildDeliveryReleaseNote(version, releaseNote, logger)
+	if err != nil {
+		logger.Errorf("failed to generate release note, err: %v", err)
+		releaseNote.Status = setting.DeliveryReleaseNoteStatusFailed
+		releaseNote.Error = err.Error()
+	} else {
+		releaseNote.Status = setting.DeliveryReleaseNoteStatusSuccess
+		if summarize {
+			summary, err := summarizeReleaseNote(releaseNote)
+			if err != nil {
+				// the commit list is still useful without the summary, so we only record the error here
+				logger.Warnf("failed to summarize release note, err: %v", err)
+				releaseNote.Error = fmt.Sprintf("failed to summarize release note: %s", err)
+			}
+			releaseNote.Summary = summary
+		}
+	}
+
+	releaseNote.UpdatedAt = time.Now().Unix()
+	if err := commonrepo.NewDeliveryReleaseNoteColl().Upsert(releaseNote); err != nil {
+		logger.Errorf("failed to save release note, err: %v", err)
+	}
+}
+
+func buildDeliveryReleaseNote(version *commonmodels.DeliveryVersionV2, releaseNote *commonmodels.DeliveryReleaseNote, logger *zap.SugaredLogger) error {
+	previousVersions, err := listPreviousDeliveryVersions(version)
+	if err != nil {
+		return fmt.Errorf("failed to list previous versions, err: %v", err)
+	}
+	if len(previousVersions) > 0 {
+		releaseNote.PreviousVersion = previousVersions[0].Version
+	}
+
+	issueBaseURLs := getReleaseNoteIssueSystems(logger)
+	codehostClients := make(map[int]client.CodeHostClient)
+
+	releaseNote.Services = make([]*commonmodels.ReleaseNoteService, 0)
+	for _, service := range version.Services {
+		currentCommits := getDeliveryServiceCommits(service, logger)
+		if len(currentCommits) == 0 {
+			continue
+		}
+
+		// the previous commits of a service come from the latest previous version which contains the service
+		previousCommits := make([]*commonmodels.ActivityCommit, 0)
+		for _, previousVersion := range previousVersions {
+			for _, previousService := range previousVersion.Services {
+				if previousService.ServiceName == service.ServiceName {
+					previousCommits = getDeliveryServiceCommits(previousService, logger)
+					break
+				}
+			}
+			if len(previousCommits) > 0 {
+				break
+			}
+		}
+
+		noteService := &commonmodels.ReleaseNoteService{
+			ServiceName: service.ServiceName,
+			Repos:       make([]*commonmodels.ReleaseNoteRepo, 0),
+		}
+		authors := make(map[string]struct{})
+		for _, commit := range currentCommits {
+			noteRepo := &commonmodels.ReleaseNoteRepo{
+				CodehostID:    commit.CodehostID,
+				Source:        commit.Source,
+				RepoOwner:     commit.RepoOwner,
+				RepoNamespace: commit.RepoNamespace,
+				RepoName:      commit.RepoName,
+				Branch:        commit.Branch,
+				ToCommit:      commit.CommitID,
+				Commits:       make([]*commonmodels.ReleaseNoteCommit, 0),
+			}
+			for _, previousCommit := range previousCommits {
+				if previousCommit.RepoOwner == commit.RepoOwner && previousCommit.RepoName == commit.RepoName {
+					noteRepo.FromCommit = previousCommit.CommitID
+					break
+				}
+			}
+
+			if noteRepo.FromCommit != "" && noteRepo.FromCommit == noteRepo.ToCommit {
+				noteService.Repos = append(noteService.Repos, noteRepo)
+				continue
+			}
+
+			commits, truncated, err := listReleaseNoteCommits(commit, noteRepo.FromCommit, codehostClients, logger)
+			if err != nil {
+				logger.Warnf("failed to list commits of %s/%s, err: %v", commit.RepoOwner, commit.RepoName, err)
+				noteRepo.Error = err.Error()
+			}
+			noteRepo.Truncated = truncated
+			for _, c := range commits {
+				noteCommit := parseReleaseNoteCommit(c, issueBaseURLs)
+				noteRepo.Commits = append(noteRepo.Commits, noteCommit)
+				if noteCommit.Author != "" {
+					authors[noteCommit.Author] = struct{}{}
+				}
+			}
+			noteService.Repos = append(noteService.Repos, noteRepo)
+		}
+		for author := range authors {
+			noteService.Authors = append(noteService.Authors, author)
+		}
+		sort.Strings(noteService.Authors)
+
+		releaseNote.Services = append(releaseNote.Services, noteService)
+	}
+
+	return nil
+}
+
+// listPreviousDeliveryVersions returns the successful versions created before the given one in the same project,
+// sorted from the latest to the oldest.
+func listPreviousDeliveryVersions(version *commonmodels.DeliveryVersionV2) ([]*commonmodels.DeliveryVersionV2, error) {
+	resp := make([]*commonmodels.DeliveryVersionV2, 0)
+	for page := 1; ; page++ {
+		versions, _, err := commonrepo.NewDeliveryVersionV2Coll().List(&commonrepo.DeliveryVersionV2Args{
+			ProjectName: version.ProjectName,
+			Page:        page,
+			PerPage:     deliveryVersionsPerPage,
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		for _, v := range versions {
+			if v.ID == version.ID || v.CreatedAt > version.CreatedAt {
+				continue
+			}
+			if v.Status != setting.DeliveryVersionStatusSuccess || v.Production != version.Production {
+				continue
+			}
+			resp = append(resp, v)
+		}
+		if len(versions) < deliveryVersionsPerPage {
+			break
+		}
+	}
+	return resp, nil
+}
+
+// getDeliveryServiceCommits finds the repos and commits used to build the images of the service
+// through the delivery artifacts and their build activities.
+func getDeliveryServiceCommits(service *commonmodels.DeliveryVersionService, logger *zap.SugaredLogger) []*commonmodels.ActivityCommit {
+	resp := make([]*commonmodels.ActivityCommit, 0)
+	repoSet := make(map[string]struct{})
+	for _, image := range service.Images {
+		if image.SourceImage == "" {
+			continue
+		}
+		artifact, err := commonrepo.NewDeliveryArtifactColl().Get(&commonrepo.DeliveryArtifactArgs{Image: image.SourceImage})
+		if err != nil {
+			logger.Debugf("no delivery artifact found for image %s, err: %v", image.SourceImage, err)
+			continue
+		}
+		activities, _, err := commonrepo.NewDeliveryActivityColl().List(&commonrepo.DeliveryActivityArgs{ArtifactID: artifact.ID.Hex()})
+		if err != nil {
+			logger.Warnf("failed to list activities of artifact %s, err: %v", artifact.ID.Hex(), err)
+			continue
+		}
+		for _, activity := range activities {
+			if activity.Type != setting.BuildType {
+				continue
+			}
+			for _, commit := range activity.Commits {
+				key := fmt.Sprintf("%s/%s", commit.RepoOwner, commit.RepoName)
+				if _, ok := repoSet[key]; ok || commit.CommitID == "" {
+					continue
+				}
+				repoSet[key] = struct{}{}
+				resp = append(resp, commit)
+			}
+			break
+		}
+	}
+	return resp
+}
+
+// listReleaseNoteCommits lists the commits on the branch from the built commit back to the previously released commit (exclusive).
+// If the previous commit is unknown or can't be found within the scan limit, the result is marked as truncated.
+func listReleaseNoteCommits(commit *commonmodels.ActivityCommit, fromCommit string, clients map[int]client.CodeHostClient, logger *zap.SugaredLogger) ([]*client.Commit, bool, error) {
+	// listing commits is not implemented for gitee and gerrit, their clients return no commits
+	cli, err := open.OpenCommitClient(commit, clients, []string{types.ProviderGitee, types.ProviderGiteeEE, types.ProviderGerrit}, logger)
+	if err != nil {
+		return nil, false, err
+	}
+	namespace := open.CommitNamespace(commit)
+
+	resp := make([]*client.Commit, 0)
+	started := false
+	for page := 1; page <= releaseNoteMaxCommitPages; page++ {
+		commits, err := cli.ListCommits(client.ListOpt{
+			Namespace:    namespace,
+			ProjectName:  commit.RepoName,
+			TargetBranch: commit.Branch,
+			Page:         page,
+			PerPage:      releaseNoteCommitsPerPage,
+		})
+		if err != nil {
+			return resp, true, err
+		}
+
+		for _, c := range commits {
+			// the branch may have moved on after the build, skip the commits that are not released
+			if !started && c.ID != commit.CommitID {
+				continue
+			}
+			started = true
+			if fromCommit != "" && c.ID == fromCommit {
+				return resp, false, nil
+			}
+			resp = append(resp, c)
+		}
+
+		if len(commits) < releaseNoteCommitsPerPage {
+			break
+		}
+	}
+
+	return resp, true, nil
+}
+
+// getReleaseNoteIssueSystems returns the configured project management systems and their issue base urls
+func getReleaseNoteIssueSystems(logger *zap.SugaredLogger) map[setting.ProjectManagementType]string {
+	resp := make(map[setting.ProjectManagementType]string)
+	systems, err := commonrepo.NewProjectManagementColl().List()
+	if err != nil {
+		logger.Warnf("failed to list project management systems, err: %v", err)
+		return resp
+	}
+
+	for _, system := range systems {
+		switch system.Type {
+		case setting.ProjectManagementTypeJira:
+			spec, err := commonrepo.NewProjectManagementColl().GetJiraSpec(system.ID.Hex())
+			if err != nil {
+				resp[system.Type] = ""
+				continue
+			}
+			resp[system.Type] = strings.TrimSuffix(spec.JiraHost, "/") + "/browse/"
+		case setting.ProjectManagementTypePingCode:
+			spec, err := commonrepo.NewProjectManagementColl().GetPingCodeSpec(system.ID.Hex())
+			if err != nil {
+				resp[system.Type] = ""
+				continue
+			}
+			resp[system.Type] = strings.TrimSuffix(spec.PingCodeAddress, "/") + "/pjm/items/"
+		default:
+			resp[system.Type] = ""
+		}
+	}
+	return resp
+}
+
+// parseReleaseNoteCommit parses a commit message following the conventional commits specification,
+// and extracts the linked pull requests and the issues of the configured project management systems.
+func parseReleaseNoteCommit(commit *client.Commit, issueSystems map[setting.ProjectManagementType]string) *commonmodels.ReleaseNoteCommit {
+	resp := &commonmodels.ReleaseNoteCommit{
+		CommitID:  commit.ID,
+		Message:   commit.Message,
+		Type:      releaseNoteCommitTypeOther,
+		Author:    commit.Author,
+		CreatedAt: commit.CreatedAt,
+		PRs:       make([]int, 0),
+		Issues:    make([]*commonmodels.ReleaseNoteIssue, 0),
+	}
+
+	title := strings.TrimSpace(strings.SplitN(commit.Message, "\n", 2)[0])
+	resp.Subject = title
+	if match := conventionalCommitRegex.FindStringSubmatch(title); match != nil {
+		commitType := strings.ToLower(match[1])
+		for _, t := range releaseNoteCommitTypeTitles {
+			if t.Type == commitType {
+				resp.Type = commitType
+				resp.Scope = match[2]
+				resp.Breaking = match[3] == "!"
+				resp.Subject = match[4]
+				break
+			}
+		}
+	}
+	if breakingChangeRegex.MatchString(commit.Message) {
+		resp.Breaking = true
+	}
+
+	for _, re := range []*regexp.Regexp{githubMergePRRegex, githubSquashPRRegex, gitlabMergeRequestRe} {
+		for _, match := range re.FindAllStringSubmatch(commit.Message, -1) {
+			if pr, err := strconv.Atoi(match[1]); err == nil {
+				resp.PRs = append(resp.PRs, pr)
+			}
+		}
+	}
+
+	issueSet := make(map[string]struct{})
+	addIssue := func(system setting.ProjectManagementType, key, urlKey string) {
+		if _, ok := issueSet[string(system)+key]; ok {
+			return
+		}
+		issueSet[string(system)+key] = struct{}{}
+		issue := &commonmodels.ReleaseNoteIssue{System: system, Key: key}
+		if baseURL := issueSystems[system]; baseURL != "" {
+			issue.URL = baseURL + urlKey
+		}
+		resp.Issues = append(resp.Issues, issue)
+	}
+
+	if _, ok := issueSystems[setting.ProjectManagementTypeJira]; ok {
+		for _, match := range jiraIssueRegex.FindAllStringSubmatch(commit.Message, -1) {
+			addIssue(setting.ProjectManagementTypeJira, match[1], match[1])
+		}
+	}
+	if _, ok := issueSystems[setting.ProjectManagementTypePingCode]; ok {
+		for _, match := range pingCodeIssueRegex.FindAllStringSubmatch(commit.Message, -1) {
+			addIssue(setting.ProjectManagementTypePingCode, match[1], match[1])
+		}
+	}
+	if _, ok := issueSystems[setting.ProjectManagementTypeMeego]; ok {
+		for _, match := range meegoIssueRegex.FindAllStringSubmatch(commit.Message, -1) {
+			addIssue(setting.ProjectManagementTypeMeego, match[1], match[1])
+		}
+	}
+	if _, ok := issueSystems[setting.ProjectManagementTypeTapd]; ok {
+		for _, match := range tapdIssueRegex.FindAllStringSubmatch(commit.Message, -1) {
+			addIssue(setting.ProjectManagementTypeTapd, fmt.Sprintf("%s-%s", match[1], match[2]), match[2])
+		}
+	}
+
+	return resp
+}
+
+func renderReleaseNoteMarkdown(releaseNote *commonmodels.DeliveryReleaseNote) string {
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "# %s %s\n\n", releaseNote.ProjectName, releaseNote.Version)
+	if releaseNote.PreviousVersion != "" {
+		fmt.Fprintf(&sb, "Changes since %s.\n\n", releaseNote.PreviousVersion)
+	}
+	if releaseNote.Summary != "" {
+		fmt.Fprintf(&sb, "## Summary\n\n%s\n\n", strings.TrimSpace(releaseNote.Summary))
+	}
+
+	for _, service := range releaseNote.Services {
+		fmt.Fprintf(&sb, "## %s\n\n", service.ServiceName)
+
+		groups := make(map[string][]*commonmodels.ReleaseNoteCommit)
+		breaking := make([]*commonmodels.ReleaseNoteCommit, 0)
+		for _, repo := range service.Repos {
+			fromCommit := shortCommitID(repo.FromCommit)
+			if fromCommit == "" {
+				fromCommit = "-"
+			}
+			fmt.Fprintf(&sb, "- %s/%s@%s: %s...%s", repo.RepoOwner, repo.RepoName, repo.Branch, fromCommit, shortCommitID(repo.ToCommit))
+			if repo.Truncated {
+				sb.WriteString(" (truncated)")
+			}
+			sb.WriteString("\n")
+
+			for _, commit := range repo.Commits {
+				groups[commit.Type] = append(groups[commit.Type], commit)
+				if commit.Breaking {
+					breaking = append(breaking, commit)
+				}
+			}
+		}
+		sb.WriteString("\n")
+
+		if len(breaking) > 0 {
+			sb.WriteString("### Breaking Changes\n\n")
+			for _, commit := range breaking {
+				writeReleaseNoteCommitLine(&sb, commit)
+			}
+			sb.WriteString("\n")
+		}
+		for _, t := range releaseNoteCommitTypeTitles {
+			commits := groups[t.Type]
+			if len(commits) == 0 {
+				continue
+			}
+			fmt.Fprintf(&sb, "### %s\n\n", t.Title)
+			for _, commit := range commits {
+				writeReleaseNoteCommitLine(&sb, commit)
+			}
+			sb.WriteString("\n")
+		}
+
+		if len(service.Authors) > 0 {
+			fmt.Fprintf(&sb, "Contributors: %s\n\n", strings.Join(service.Authors, ", "))
+		}
+	}
+
+	return sb.String()
+}
+
+func writeReleaseNoteCommitLine(sb *strings.Builder, commit *commonmodels.ReleaseNoteCommit) {
+	sb.WriteString("- ")
+	if commit.Scope != "" {
+		fmt.Fprintf(sb, "**%s:** ", commit.Scope)
+	}
+	sb.WriteString(commit.Subject)
+	fmt.Fprintf(sb, " (%s", shortCommitID(commit.CommitID))
+	for _, pr := range commit.PRs {
+		fmt.Fprintf(sb, ", #%d", pr)
+	}
+	for _, issue := range commit.Issues {
+		if issue.URL != "" {
+			fmt.Fprintf(sb, ", [%s](%s)", issue.Key, issue.URL)
+		} else {
+			fmt.Fprintf(sb, ", %s", issue.Key)
+		}
+	}
+	sb.WriteString(")")
+	if commit.Author != "" {
+		fmt.Fprintf(sb, " by %s", commit.Author)
+	}
+	sb.WriteString("\n")
+}
+
+// truncateUTF8 cuts the content to at most max bytes without splitting a multi-byte character
+func truncateUTF8(content string, max int) string {
+	if len(content) <= max {
+		return content
+	}
+	for max > 0 && !utf8.RuneStart(content[max]) {
+		max--
+	}
+	return content[:max]
+}
+
+func shortCommitID(commitID string) string {
+	if len(commitID) > 8 {
+		return commitID[:8]
+	}
+	return commitID
+}
+
+func summarizeReleaseNote(releaseNote *commonmodels.DeliveryReleaseNote) (string, error) {
+	ctx := context.Background()
+	client, err := commonservice.GetDefaultLLMClient(ctx)
+	if err != nil {
+		return "", err
+	}
+
+	content := renderReleaseNoteMarkdown(releaseNote)
+	content = truncateUTF8(content, releaseNoteMaxSummaryInput)
+	prompt := fmt.Sprintf("%s\n\"\"\"%s\"\"\"", releaseNoteSummaryPrompt, content)
+
+	options := []llm.ParamOption{}
+	if client.GetModel() != "" {
+		options = append(options, llm.WithModel(client.GetModel()))
+	} else {
+		options = append(options, llm.WithModel(openapi.GPT4o))
+	}
+	return client.GetCompletion(ctx, prompt, options...)
+}
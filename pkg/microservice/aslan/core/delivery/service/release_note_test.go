@@ -0,0 +1,100 @@
+/*
+Copyright 2025 The KodeRover Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package service
+
+import (
+	"strings"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/koderover/zadig/v2/pkg/microservice/aslan/core/code/client"
+	commonmodels "github.com/koderover/zadig/v2/pkg/microservice/aslan/core/common/repository/models"
+	"github.com/koderover/zadig/v2/pkg/setting"
+)
+
+var _ = Describe("Testing release note", func() {
+
+	issueSystems := map[setting.ProjectManagementType]string{
+		setting.ProjectManagementTypeJira: "https://jira.example.com/browse/",
+		setting.ProjectManagementTypeTapd: "",
+	}
+
+	Context("parseReleaseNoteCommit", func() {
+		It("should parse conventional commits", func() {
+			commit := parseReleaseNoteCommit(&client.Commit{ID: "abc", Message: "feat(api)!: add release notes (#42)", Author: "alice"}, issueSystems)
+			Expect(commit.Type).To(Equal("feat"))
+			Expect(commit.Scope).To(Equal("api"))
+			Expect(commit.Breaking).To(BeTrue())
+			Expect(commit.Subject).To(Equal("add release notes (#42)"))
+			Expect(commit.PRs).To(Equal([]int{42}))
+		})
+
+		It("should fall back to other for unknown types", func() {
+			commit := parseReleaseNoteCommit(&client.Commit{ID: "abc", Message: "update readme\n\nBREAKING CHANGE: drop v1"}, issueSystems)
+			Expect(commit.Type).To(Equal(releaseNoteCommitTypeOther))
+			Expect(commit.Subject).To(Equal("update readme"))
+			Expect(commit.Breaking).To(BeTrue())
+		})
+
+		It("should link issues of configured systems only", func() {
+			commit := parseReleaseNoteCommit(&client.Commit{ID: "abc", Message: "fix: PROJ-12 crash --bug=1001 m-33\n\nSee merge request group/repo!7"}, issueSystems)
+			Expect(commit.PRs).To(Equal([]int{7}))
+			Expect(commit.Issues).To(HaveLen(2))
+			Expect(commit.Issues[0].Key).To(Equal("PROJ-12"))
+			Expect(commit.Issues[0].URL).To(Equal("https://jira.example.com/browse/PROJ-12"))
+			Expect(commit.Issues[1].System).To(Equal(setting.ProjectManagementTypeTapd))
+			Expect(commit.Issues[1].Key).To(Equal("bug-1001"))
+		})
+	})
+
+	Context("renderReleaseNoteMarkdown", func() {
+		It("should group commits by type", func() {
+			md := renderReleaseNoteMarkdown(&commonmodels.DeliveryReleaseNote{
+				ProjectName:     "demo",
+				Version:         "v2",
+				PreviousVersion: "v1",
+				Services: []*commonmodels.ReleaseNoteService{{
+					ServiceName: "svc",
+					Authors:     []string{"alice"},
+					Repos: []*commonmodels.ReleaseNoteRepo{{
+						RepoOwner: "koderover",
+						RepoName:  "demo",
+						Branch:    "main",
+						ToCommit:  "0123456789",
+						Commits: []*commonmodels.ReleaseNoteCommit{
+							{CommitID: "1111111111", Type: "fix", Subject: "fix crash"},
+							{CommitID: "2222222222", Type: "feat", Subject: "add api", Scope: "api"},
+						},
+					}},
+				}},
+			})
+			Expect(md).To(ContainSubstring("Changes since v1."))
+			Expect(md).To(ContainSubstring("- **api:** add api (22222222)"))
+			Expect(strings.Index(md, "### Features")).To(BeNumerically("<", strings.Index(md, "### Bug Fixes")))
+			Expect(md).To(ContainSubstring("Contributors: alice"))
+		})
+	})
+
+	Context("truncateUTF8", func() {
+		It("should not split multi-byte characters", func() {
+			Expect(truncateUTF8("修复崩溃", 4)).To(Equal("修"))
+			Expect(truncateUTF8("修复崩溃", 6)).To(Equal("修复"))
+			Expect(truncateUTF8("fix", 10)).To(Equal("fix"))
+		})
+	})
+})
@@ -39,6 +39,7 @@ import (
 	"go.mongodb.org/mongo-driver/mongo"
 	"go.uber.org/zap"
 	chartloader "helm.sh/helm/v3/pkg/chart/loader"
+	"helm.sh/helm/v3/pkg/registry"
 	"helm.sh/helm/v3/pkg/repo"
 	"k8s.io/apimachinery/pkg/util/sets"
 	"sigs.k8s.io/yaml"
@@ -113,6 +114,7 @@ func CreateK8SDeliveryVersionV2(args *CreateDeliveryVersionRequest, logger *zap.
 		CreatedAt:       time.Now().Unix(),
 	}
 
+	attachDeliveryImageSBOMs(versionObj, logger)
 	err = commonrepo.NewDeliveryVersionV2Coll().Create(versionObj)
 	if err != nil {
 		logger.Errorf("failed to insert version data, err: %s", err)
@@ -130,6 +132,25 @@ func CreateK8SDeliveryVersionV2(args *CreateDeliveryVersionRequest, logger *zap.
 	return nil
 }
 
+// attachDeliveryImageSBOMs links every image of the version to the latest sbom generated for it, images built
+// before sbom generation was enabled are left as they are.
+func attachDeliveryImageSBOMs(deliveryVersion *commonmodels.DeliveryVersionV2, logger *zap.SugaredLogger) {
+	for _, service := range deliveryVersion.Services {
+		for _, image := range service.Images {
+			sbom, err := commonrepo.NewSBOMColl().FindLatestByImage(image.SourceImage)
+			if err != nil {
+				logger.Warnf("failed to find sbom of image %s, err: %s", image.SourceImage, err)
+				continue
+			}
+			if sbom == nil {
+				continue
+			}
+			image.SBOMID = sbom.ID.Hex()
+			image.Digest = sbom.Digest
+		}
+	}
+}
+
 func getImageSourceRegistryV2(imageData *commonmodels.DeliveryVersionImage, registryMap map[string]*commonmodels.RegistryNamespace) (*commonmodels.RegistryNamespace, error) {
 	sourceImageTag := ""
 	registryURL := strings.TrimSuffix(imageData.SourceImage, fmt.Sprintf("/%s", imageData.ImageName))
@@ -423,9 +444,11 @@ func waitK8SImageVersionDoneV2(deliveryVersion *commonmodels.DeliveryVersionV2)
 }
 
 func updateVersionStatusV2(versionName, projectName string, status setting.DeliveryVersionStatus, errStr string) {
+	var versionInfo *commonmodels.DeliveryVersionV2
+	var err error
 	// send hook info when version build finished
 	if status == setting.DeliveryVersionStatusSuccess {
-		versionInfo, err := commonrepo.NewDeliveryVersionV2Coll().Get(&commonrepo.DeliveryVersionV2Args{
+		versionInfo, err = commonrepo.NewDeliveryVersionV2Coll().Get(&commonrepo.DeliveryVersionV2Args{
 			ProjectName: projectName,
 			Version:     versionName,
 		})
@@ -453,9 +476,18 @@ func updateVersionStatusV2(versionName, projectName string, status setting.Deliv
 		}
 	}
 
-	err := commonrepo.NewDeliveryVersionV2Coll().UpdateStatusByName(versionName, projectName, status, errStr)
+	err = commonrepo.NewDeliveryVersionV2Coll().UpdateStatusByName(versionName, projectName, status, errStr)
 	if err != nil {
 		log.Errorf("failed to update version status, name: %s, err: %s", versionName, err)
+		return
+	}
+
+	// generate the release note once the version is delivered
+	if versionInfo != nil {
+		_, err = GenerateDeliveryReleaseNote(versionInfo.ID.Hex(), nil, versionInfo.CreatedBy, log.SugaredLogger())
+		if err != nil {
+			log.Errorf("failed to generate release note for version: %s, err: %s", versionName, err)
+		}
 	}
 }
 
@@ -711,6 +743,7 @@ func CreateHelmDeliveryVersionV2(args *CreateDeliveryVersionRequest, logger *zap
 		CreatedAt:       time.Now().Unix(),
 	}
 
+	attachDeliveryImageSBOMs(versionObj, logger)
 	err = commonrepo.NewDeliveryVersionV2Coll().Create(versionObj)
 	if err != nil {
 		logger.Errorf("failed to insert version data, err: %s", err)
@@ -1330,6 +1363,11 @@ func DeleteDeliveryVersionV2(args *commonrepo.DeliveryVersionV2Args, log *zap.Su
 		log.Errorf("delete deliveryVersion error: %v", err)
 		return e.ErrDeleteDeliveryVersion
 	}
+
+	err = commonrepo.NewDeliveryReleaseNoteColl().DeleteByDeliveryVersionID(args.ID)
+	if err != nil {
+		log.Errorf("delete delivery release note error: %v", err)
+	}
 	return nil
 }
 
@@ -1428,13 +1466,15 @@ func getChartDir(project, versionName string) string {
 }
 
 func fillChartUrl(charts []*DeliveryVersionPayloadChart, chartRepoName string) error {
-	index, err := getIndexInfoFromChartRepo(chartRepoName)
-	if err != nil {
-		return err
-	}
 	chartMap := make(map[string]*DeliveryVersionPayloadChart)
+	chartNames := make([]string, 0, len(charts))
 	for _, chart := range charts {
 		chartMap[chart.ChartName] = chart
+		chartNames = append(chartNames, chart.ChartName)
+	}
+	index, err := getIndexInfoFromChartRepo(chartRepoName, chartNames)
+	if err != nil {
+		return err
 	}
 
 	for name, entries := range index.Entries {
@@ -1458,7 +1498,8 @@ func fillChartUrl(charts []*DeliveryVersionPayloadChart, chartRepoName string) e
 	return nil
 }
 
-func getIndexInfoFromChartRepo(chartRepoName string) (*repo.IndexFile, error) {
+// getIndexInfoFromChartRepo returns the index of the chart repo, the index of oci chart repos only contains the given charts
+func getIndexInfoFromChartRepo(chartRepoName string, chartNames []string) (*repo.IndexFile, error) {
 	chartRepo, err := getChartRepoData(chartRepoName)
 	if err != nil {
 		return nil, err
@@ -1467,6 +1508,9 @@ func getIndexInfoFromChartRepo(chartRepoName string) (*repo.IndexFile, error) {
 	if err != nil {
 		return nil, errors.Wrapf(err, "failed to create chart repo client")
 	}
+	if registry.IsOCI(chartRepo.URL) {
+		return hClient.FetchOCIIndex(commonutil.GeneHelmRepo(chartRepo), chartNames)
+	}
 	return hClient.FetchIndexYaml(commonutil.GeneHelmRepo(chartRepo))
 }
 
@@ -1522,12 +1566,12 @@ type ChartVersionResp struct {
 }
 
 func GetChartVersion(chartName, chartRepoName string) ([]*ChartVersionResp, error) {
-	index, err := getIndexInfoFromChartRepo(chartRepoName)
+	chartNameList := strings.Split(chartName, ",")
+	index, err := getIndexInfoFromChartRepo(chartRepoName, chartNameList)
 	if err != nil {
 		return nil, err
 	}
 
-	chartNameList := strings.Split(chartName, ",")
 	chartNameSet := sets.NewString(chartNameList...)
 	existedChartSet := sets.NewString()
 
@@ -0,0 +1,246 @@
+/*
+Copyright 2025 The KodeRover Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package handler
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/koderover/zadig/v2/pkg/microservice/aslan/core/common/service/mockservice"
+	internalhandler "github.com/koderover/zadig/v2/pkg/shared/handler"
+	e "github.com/koderover/zadig/v2/pkg/tool/errors"
+	"github.com/koderover/zadig/v2/pkg/types"
+)
+
+type deployEnvMockRequest struct {
+	ServiceName string `json:"service_name"`
+	// Revision is the revision of the mock to deploy, the latest revision is deployed if it is 0
+	Revision int64 `json:"revision"`
+}
+
+// @Summary List Environment Mocks
+// @Description List the mocks deployed in the environment
+// @Tags 	environment
+// @Accept 	json
+// @Produce json
+// @Param 	name		path		string							true	"env name"
+// @Param 	projectName	query		string							true	"project name"
+// @Success 200 		{array} 	mockservice.EnvMockInfo
+// @Router /api/aslan/environment/environments/{name}/mocks [get]
+func ListEnvMocks(c *gin.Context) {
+	ctx, err := internalhandler.NewContextWithAuthorization(c)
+	defer func() { internalhandler.JSONResponse(c, ctx) }()
+
+	if err != nil {
+		ctx.RespErr = fmt.Errorf("authorization Info Generation failed: err %s", err)
+		ctx.UnAuthorized = true
+		return
+	}
+
+	envName := c.Param("name")
+	projectKey := c.Query("projectName")
+
+	// authorization checks
+	if !ctx.Resources.IsSystemAdmin {
+		if _, ok := ctx.Resources.ProjectAuthInfo[projectKey]; !ok {
+			ctx.UnAuthorized = true
+			return
+		}
+		if !ctx.Resources.ProjectAuthInfo[projectKey].IsProjectAdmin &&
+			!ctx.Resources.ProjectAuthInfo[projectKey].Env.View {
+			permitted, err := internalhandler.GetCollaborationModePermission(ctx.UserID, projectKey, types.ResourceTypeEnvironment, envName, types.EnvActionView)
+			if err != nil || !permitted {
+				ctx.UnAuthorized = true
+				return
+			}
+		}
+	}
+
+	resp, err := mockservice.ListEnvMocks(projectKey, envName)
+	if err != nil {
+		ctx.RespErr = e.ErrListEnvMock.AddErr(err)
+		return
+	}
+	ctx.Resp = resp
+}
+
+// @Summary Deploy Environment Mock
+// @Description Deploy the mock of the service to the environment in place of the service
+// @Tags 	environment
+// @Accept 	json
+// @Produce json
+// @Param 	name		path		string							true	"env name"
+// @Param 	projectName	query		string							true	"project name"
+// @Param 	body 		body 		deployEnvMockRequest 			true 	"body"
+// @Success 200
+// @Router /api/aslan/environment/environments/{name}/mocks [post]
+func DeployEnvMock(c *gin.Context) {
+	ctx, err := internalhandler.NewContextWithAuthorization(c)
+	defer func() { internalhandler.JSONResponse(c, ctx) }()
+
+	if err != nil {
+		ctx.RespErr = fmt.Errorf("authorization Info Generation failed: err %s", err)
+		ctx.UnAuthorized = true
+		return
+	}
+
+	req := new(deployEnvMockRequest)
+	if err := c.ShouldBindJSON(req); err != nil {
+		ctx.RespErr = e.ErrInvalidParam.AddErr(err)
+		return
+	}
+	if req.ServiceName == "" {
+		ctx.RespErr = e.ErrInvalidParam.AddDesc("empty service name")
+		return
+	}
+
+	envName := c.Param("name")
+	projectKey := c.Query("projectName")
+
+	// authorization checks
+	if !ctx.Resources.IsSystemAdmin {
+		if _, ok := ctx.Resources.ProjectAuthInfo[projectKey]; !ok {
+			ctx.UnAuthorized = true
+			return
+		}
+		if !ctx.Resources.ProjectAuthInfo[projectKey].IsProjectAdmin &&
+			!ctx.Resources.ProjectAuthInfo[projectKey].Env.EditConfig {
+			permitted, err := internalhandler.GetCollaborationModePermission(ctx.UserID, projectKey, types.ResourceTypeEnvironment, envName, types.EnvActionEditConfig)
+			if err != nil || !permitted {
+				ctx.UnAuthorized = true
+				return
+			}
+		}
+	}
+
+	detail := fmt.Sprintf("环境名称:%s,服务名称:%s", envName, req.ServiceName)
+	detailEn := fmt.Sprintf("Environment Name: %s, Service Name: %s", envName, req.ServiceName)
+	internalhandler.InsertOperationLog(c, ctx.UserName, projectKey, "部署", "环境-服务Mock", detail, detailEn, "", types.RequestBodyTypeJSON, ctx.Logger)
+
+	if err := mockservice.DeployEnvMock(c, projectKey, envName, req.ServiceName, req.Revision, ctx.UserName); err != nil {
+		ctx.RespErr = e.ErrDeployEnvMock.AddErr(err)
+	}
+}
+
+// @Summary Delete Environment Mock
+// @Description Remove the mock of the service from the environment
+// @Tags 	environment
+// @Accept 	json
+// @Produce json
+// @Param 	name		path		string							true	"env name"
+// @Param 	serviceName	path		string							true	"service name"
+// @Param 	projectName	query		string							true	"project name"
+// @Success 200
+// @Router /api/aslan/environment/environments/{name}/mocks/{serviceName} [delete]
+func DeleteEnvMock(c *gin.Context) {
+	ctx, err := internalhandler.NewContextWithAuthorization(c)
+	defer func() { internalhandler.JSONResponse(c, ctx) }()
+
+	if err != nil {
+		ctx.RespErr = fmt.Errorf("authorization Info Generation failed: err %s", err)
+		ctx.UnAuthorized = true
+		return
+	}
+
+	envName := c.Param("name")
+	serviceName := c.Param("serviceName")
+	projectKey := c.Query("projectName")
+
+	// authorization checks
+	if !ctx.Resources.IsSystemAdmin {
+		if _, ok := ctx.Resources.ProjectAuthInfo[projectKey]; !ok {
+			ctx.UnAuthorized = true
+			return
+		}
+		if !ctx.Resources.ProjectAuthInfo[projectKey].IsProjectAdmin &&
+			!ctx.Resources.ProjectAuthInfo[projectKey].Env.EditConfig {
+			permitted, err := internalhandler.GetCollaborationModePermission(ctx.UserID, projectKey, types.ResourceTypeEnvironment, envName, types.EnvActionEditConfig)
+			if err != nil || !permitted {
+				ctx.UnAuthorized = true
+				return
+			}
+		}
+	}
+
+	detail := fmt.Sprintf("环境名称:%s,服务名称:%s", envName, serviceName)
+	detailEn := fmt.Sprintf("Environment Name: %s, Service Name: %s", envName, serviceName)
+	internalhandler.InsertOperationLog(c, ctx.UserName, projectKey, "删除", "环境-服务Mock", detail, detailEn, "", types.RequestBodyTypeJSON, ctx.Logger)
+
+	if err := mockservice.DeleteEnvMock(projectKey, envName, serviceName); err != nil {
+		ctx.RespErr = e.ErrDeleteEnvMock.AddErr(err)
+	}
+}
+
+// @Summary Get Environment Mock Journal
+// @Description Get the requests the mock in the environment received
+// @Tags 	environment
+// @Accept 	json
+// @Produce json
+// @Param 	name		path		string							true	"env name"
+// @Param 	serviceName	path		string							true	"service name"
+// @Param 	projectName	query		string							true	"project name"
+// @Param 	since		query		int								false	"unix time in milliseconds"
+// @Success 200 		{array} 	types.MockJournalEntry
+// @Router /api/aslan/environment/environments/{name}/mocks/{serviceName}/journal [get]
+func GetEnvMockJournal(c *gin.Context) {
+	ctx, err := internalhandler.NewContextWithAuthorization(c)
+	defer func() { internalhandler.JSONResponse(c, ctx) }()
+
+	if err != nil {
+		ctx.RespErr = fmt.Errorf("authorization Info Generation failed: err %s", err)
+		ctx.UnAuthorized = true
+		return
+	}
+
+	envName := c.Param("name")
+	serviceName := c.Param("serviceName")
+	projectKey := c.Query("projectName")
+
+	// authorization checks
+	if !ctx.Resources.IsSystemAdmin {
+		if _, ok := ctx.Resources.ProjectAuthInfo[projectKey]; !ok {
+			ctx.UnAuthorized = true
+			return
+		}
+		if !ctx.Resources.ProjectAuthInfo[projectKey].IsProjectAdmin &&
+			!ctx.Resources.ProjectAuthInfo[projectKey].Env.View {
+			permitted, err := internalhandler.GetCollaborationModePermission(ctx.UserID, projectKey, types.ResourceTypeEnvironment, envName, types.EnvActionView)
+			if err != nil || !permitted {
+				ctx.UnAuthorized = true
+				return
+			}
+		}
+	}
+
+	var since int64
+	if c.Query("since") != "" {
+		since, err = strconv.ParseInt(c.Query("since"), 10, 64)
+		if err != nil {
+			ctx.RespErr = e.ErrInvalidParam.AddDesc("invalid since")
+			return
+		}
+	}
+
+	resp, err := mockservice.GetEnvMockJournal(c, projectKey, envName, serviceName, since)
+	if err != nil {
+		ctx.RespErr = e.ErrGetEnvMockJournal.AddErr(err)
+		return
+	}
+	ctx.Resp = resp
+}
@@ -0,0 +1,136 @@
+/*
+Copyright 2025 The KodeRover Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package handler
+
+import (
+	"fmt"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/koderover/zadig/v2/pkg/microservice/aslan/core/environment/service"
+	"github.com/koderover/zadig/v2/pkg/setting"
+	internalhandler "github.com/koderover/zadig/v2/pkg/shared/handler"
+	"github.com/koderover/zadig/v2/pkg/types"
+)
+
+// @Summary Promote Rollout
+// @Description Promote the paused Argo Rollout or Kruise Rollout in the environment
+// @Tags 	environment
+// @Accept 	json
+// @Produce json
+// @Param 	projectName		query		string							true	"project name"
+// @Param 	name 			path		string							true	"env name"
+// @Param 	rolloutName 	path		string							true	"rollout name"
+// @Param 	production 		query		bool							false	"is production env"
+// @Param 	full 			query		bool							false	"skip all the remaining steps of argo rollout"
+// @Success 200
+// @Router /api/aslan/environment/environments/{name}/rollouts/{rolloutName}/promote [post]
+func PromoteRollout(c *gin.Context) {
+	ctx, err := internalhandler.NewContextWithAuthorization(c)
+	defer func() { internalhandler.JSONResponse(c, ctx) }()
+
+	if err != nil {
+		ctx.RespErr = fmt.Errorf("authorization Info Generation failed: err %s", err)
+		ctx.UnAuthorized = true
+		return
+	}
+
+	envName := c.Param("name")
+	projectKey := c.Query("projectName")
+	rolloutName := c.Param("rolloutName")
+	production := c.Query("production") == "true"
+	full := c.Query("full") == "true"
+
+	if !canManageRollout(ctx, projectKey, envName, production) {
+		ctx.UnAuthorized = true
+		return
+	}
+
+	detail := fmt.Sprintf("环境名称:%s,Rollout名称:%s", envName, rolloutName)
+	detailEn := fmt.Sprintf("Environment Name: %s, Rollout Name: %s", envName, rolloutName)
+	internalhandler.InsertDetailedOperationLog(c, ctx.UserName, projectKey, setting.OperationSceneEnv,
+		"推进", "环境-服务", detail, detailEn,
+		"", types.RequestBodyTypeJSON, ctx.Logger, envName)
+	ctx.RespErr = service.PromoteRollout(projectKey, envName, rolloutName, full, production, ctx.Logger)
+}
+
+// @Summary Abort Rollout
+// @Description Abort the update of the Argo Rollout in the environment
+// @Tags 	environment
+// @Accept 	json
+// @Produce json
+// @Param 	projectName		query		string							true	"project name"
+// @Param 	name 			path		string							true	"env name"
+// @Param 	rolloutName 	path		string							true	"rollout name"
+// @Param 	production 		query		bool							false	"is production env"
+// @Success 200
+// @Router /api/aslan/environment/environments/{name}/rollouts/{rolloutName}/abort [post]
+func AbortRollout(c *gin.Context) {
+	ctx, err := internalhandler.NewContextWithAuthorization(c)
+	defer func() { internalhandler.JSONResponse(c, ctx) }()
+
+	if err != nil {
+		ctx.RespErr = fmt.Errorf("authorization Info Generation failed: err %s", err)
+		ctx.UnAuthorized = true
+		return
+	}
+
+	envName := c.Param("name")
+	projectKey := c.Query("projectName")
+	rolloutName := c.Param("rolloutName")
+	production := c.Query("production") == "true"
+
+	if !canManageRollout(ctx, projectKey, envName, production) {
+		ctx.UnAuthorized = true
+		return
+	}
+
+	detail := fmt.Sprintf("环境名称:%s,Rollout名称:%s", envName, rolloutName)
+	detailEn := fmt.Sprintf("Environment Name: %s, Rollout Name: %s", envName, rolloutName)
+	internalhandler.InsertDetailedOperationLog(c, ctx.UserName, projectKey, setting.OperationSceneEnv,
+		"中止", "环境-服务", detail, detailEn,
+		"", types.RequestBodyTypeJSON, ctx.Logger, envName)
+	ctx.RespErr = service.AbortRollout(projectKey, envName, rolloutName, production, ctx.Logger)
+}
+
+// canManageRollout checks the pod management permission of the env, which is what promoting a rollout amounts to
+func canManageRollout(ctx *internalhandler.Context, projectKey, envName string, production bool) bool {
+	if ctx.Resources.IsSystemAdmin {
+		return true
+	}
+	authInfo, ok := ctx.Resources.ProjectAuthInfo[projectKey]
+	if !ok {
+		return false
+	}
+	if authInfo.IsProjectAdmin {
+		return true
+	}
+
+	if production {
+		if authInfo.ProductionEnv.ManagePods {
+			return true
+		}
+		permitted, err := internalhandler.GetCollaborationModePermission(ctx.UserID, projectKey, types.ResourceTypeEnvironment, envName, types.ProductionEnvActionManagePod)
+		return err == nil && permitted
+	}
+
+	if authInfo.Env.ManagePods {
+		return true
+	}
+	permitted, err := internalhandler.GetCollaborationModePermission(ctx.UserID, projectKey, types.ResourceTypeEnvironment, envName, types.EnvActionManagePod)
+	return err == nil && permitted
+}
@@ -146,6 +146,8 @@ func (*Router) Inject(router *gin.RouterGroup) {
 		environments.GET("/:name", GetEnvironment)
 		environments.PUT("/:name/envRecycle", UpdateProductRecycleDay)
 		environments.PUT("/:name/alias", UpdateProductAlias)
+		environments.GET("/:name/image-trust-policy", GetImageTrustPolicy)
+		environments.PUT("/:name/image-trust-policy", UpdateImageTrustPolicy)
 		environments.POST("/:name/affectedservices", AffectedServices)
 		environments.POST("/:name/estimated-values", EstimatedValues)
 
@@ -161,6 +163,11 @@ func (*Router) Inject(router *gin.RouterGroup) {
 		environments.DELETE("/:name", DeleteProduct)
 		environments.GET("/:name/groups", ListGroups)
 		environments.GET("/:name/workloads", ListWorkloadsInEnv)
+		environments.GET("/:name/dependencies", GetEnvServiceDependencies)
+		environments.GET("/:name/mocks", ListEnvMocks)
+		environments.POST("/:name/mocks", DeployEnvMock)
+		environments.DELETE("/:name/mocks/:serviceName", DeleteEnvMock)
+		environments.GET("/:name/mocks/:serviceName/journal", GetEnvMockJournal)
 
 		environments.GET("/:name/helm/releases", ListReleases)
 		environments.DELETE("/:name/helm/releases", DeleteHelmReleases)
@@ -178,6 +185,8 @@ func (*Router) Inject(router *gin.RouterGroup) {
 		environments.POST("/:name/services/:serviceName/restart", RestartService)
 		environments.POST("/:name/services/:serviceName/restartNew", RestartWorkload)
 		environments.POST("/:name/services/:serviceName/scaleNew", ScaleNewService)
+		environments.POST("/:name/rollouts/:rolloutName/promote", PromoteRollout)
+		environments.POST("/:name/rollouts/:rolloutName/abort", AbortRollout)
 
 		environments.POST("/:name/estimated-renderchart", GetEstimatedRenderCharts)
 
@@ -56,6 +56,7 @@ import (
 	helmservice "github.com/koderover/zadig/v2/pkg/microservice/aslan/core/common/service/helm"
 	"github.com/koderover/zadig/v2/pkg/microservice/aslan/core/common/service/imnotify"
 	"github.com/koderover/zadig/v2/pkg/microservice/aslan/core/common/service/kube"
+	"github.com/koderover/zadig/v2/pkg/microservice/aslan/core/common/service/mockservice"
 	"github.com/koderover/zadig/v2/pkg/microservice/aslan/core/common/service/notify"
 	"github.com/koderover/zadig/v2/pkg/microservice/aslan/core/common/service/render"
 	"github.com/koderover/zadig/v2/pkg/microservice/aslan/core/common/service/repository"
@@ -2438,6 +2439,9 @@ func DeleteProduct(username, envName, productName, requestID string, isDelete bo
 			if productInfo.Production {
 				return
 			}
+			if err := mockservice.DeleteEnvMocks(ctx, productInfo, isDelete); err != nil {
+				log.Warnf("failed to delete the mocks of env %s of product %s: %s", productInfo.EnvName, productInfo.ProductName, err)
+			}
 			if isDelete {
 				istioClient, err := clientmanager.NewKubeClientManager().GetIstioClientSet(productInfo.ClusterID)
 				if err != nil {
@@ -2519,6 +2523,9 @@ func DeleteProduct(username, envName, productName, requestID string, isDelete bo
 			if productInfo.Production {
 				return
 			}
+			if err := mockservice.DeleteEnvMocks(ctx, productInfo, isDelete); err != nil {
+				log.Warnf("failed to delete the mocks of env %s of product %s: %s", productInfo.EnvName, productInfo.ProductName, err)
+			}
 
 			if isDelete {
 				svcNames := make([]string, 0)
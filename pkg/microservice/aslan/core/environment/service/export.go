@@ -92,7 +92,9 @@ func ExportYaml(envName, productName, serviceName, source string, production boo
 		yamls = append(yamls, cronJobs...)
 		cloneSets := getKruiseYaml(kruise, namespace, selector, log)
 		yamls = append(yamls, cloneSets...)
-		if len(deploys) == 0 && len(stss) == 0 && len(cronJobs) == 0 {
+		rollouts := getRolloutYaml(kubeClient, namespace, selector, log)
+		yamls = append(yamls, rollouts...)
+		if len(deploys) == 0 && len(stss) == 0 && len(cronJobs) == 0 && len(rollouts) == 0 {
 			if source == "wd" {
 				needFetchByRenderedManifest = true
 			}
@@ -123,7 +125,7 @@ func ExportYaml(envName, productName, serviceName, source string, production boo
 				continue
 			}
 			switch u.GetKind() {
-			case setting.Deployment, setting.StatefulSet, setting.ConfigMap, setting.Service, setting.Ingress, setting.CronJob:
+			case setting.Deployment, setting.StatefulSet, setting.ConfigMap, setting.Service, setting.Ingress, setting.CronJob, setting.Rollout:
 				resource, exists, err := getter.GetResourceYamlInCache(namespace, u.GetName(), u.GroupVersionKind(), kubeClient)
 				if err != nil {
 					log.Errorf("failed to get resource yaml, err: %s", err)
@@ -211,6 +213,37 @@ func getKruiseYaml(kubeClient versioned.Interface, namespace string, selector la
 	return yamlBytes
 }
 
+// getRolloutYaml returns both Argo Rollouts and Kruise Rollouts, the rollouts are skipped if the CRDs are not installed.
+func getRolloutYaml(kubeClient client.Client, namespace string, selector labels.Selector, log *zap.SugaredLogger) [][]byte {
+	argoRollouts, err := getter.ListArgoRollouts(namespace, selector, kubeClient)
+	if err != nil {
+		log.Errorf("List Argo Rollout error: %v", err)
+	}
+	kruiseRollouts, err := getter.ListKruiseRollouts(namespace, selector, kubeClient)
+	if err != nil {
+		log.Errorf("List Kruise Rollout error: %v", err)
+	}
+
+	var yamlBytes [][]byte
+	for _, item := range append(argoRollouts, kruiseRollouts...) {
+		item.SetManagedFields(nil)
+		jsonData, err := item.MarshalJSON()
+		if err != nil {
+			log.Errorf("Failed to marshal Rollout %s to JSON: %v", item.GetName(), err)
+			continue
+		}
+
+		yamlData, err := yaml.JSONToYAML(jsonData)
+		if err != nil {
+			log.Errorf("Failed to convert Rollout %s JSON to YAML: %v", item.GetName(), err)
+			continue
+		}
+
+		yamlBytes = append(yamlBytes, yamlData)
+	}
+	return yamlBytes
+}
+
 func getStatefulSetYaml(kubeClient client.Client, namespace string, selector labels.Selector, log *zap.SugaredLogger) [][]byte {
 	resources, err := getter.ListStatefulSetsYaml(namespace, selector, kubeClient)
 	if err != nil {
@@ -0,0 +1,64 @@
+/*
+Copyright 2025 The KodeRover Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package service
+
+import (
+	commonmodels "github.com/koderover/zadig/v2/pkg/microservice/aslan/core/common/repository/models"
+	commonrepo "github.com/koderover/zadig/v2/pkg/microservice/aslan/core/common/repository/mongodb"
+	"github.com/koderover/zadig/v2/pkg/microservice/aslan/core/common/service/imagetrust"
+	e "github.com/koderover/zadig/v2/pkg/tool/errors"
+)
+
+func GetImageTrustPolicy(envName, productName string, production bool) (*commonmodels.ImageTrustPolicy, error) {
+	env, err := commonrepo.NewProductColl().Find(&commonrepo.ProductFindOptions{
+		Name:       productName,
+		EnvName:    envName,
+		Production: &production,
+	})
+	if err != nil {
+		return nil, e.ErrGetEnv.AddErr(err)
+	}
+	if env.ImageTrustPolicy == nil {
+		return &commonmodels.ImageTrustPolicy{TrustedKeys: make([]*commonmodels.KeyVaultKeyRef, 0)}, nil
+	}
+	return env.ImageTrustPolicy, nil
+}
+
+func UpdateImageTrustPolicy(envName, productName string, production bool, policy *commonmodels.ImageTrustPolicy) error {
+	_, err := commonrepo.NewProductColl().Find(&commonrepo.ProductFindOptions{
+		Name:       productName,
+		EnvName:    envName,
+		Production: &production,
+	})
+	if err != nil {
+		return e.ErrUpdateEnv.AddErr(err)
+	}
+
+	keys, err := imagetrust.TrustedKeys(productName, policy)
+	if err != nil {
+		return e.ErrUpdateEnv.AddErr(err)
+	}
+	if policy.Enabled && len(keys) == 0 {
+		return e.ErrUpdateEnv.AddDesc("at least one trusted key is required to enable the image trust policy")
+	}
+
+	err = commonrepo.NewProductColl().UpdateImageTrustPolicy(envName, productName, policy)
+	if err != nil {
+		return e.ErrUpdateEnv.AddErr(err)
+	}
+	return nil
+}
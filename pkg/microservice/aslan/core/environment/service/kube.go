@@ -982,7 +982,11 @@ func GetResourceDeployStatus(productName string, request *K8sDeployStatusCheckRe
 			continue
 		}
 
-		rederedYaml, err := kube.RenderServiceYaml(svc.Yaml, productName, svc.ServiceName, fakeRenderMap[svc.ServiceName])
+		svcYaml, err := kube.ServiceTemplateYaml(svc, request.EnvName, nil)
+		if err != nil {
+			return nil, e.ErrGetResourceDeployInfo.AddErr(fmt.Errorf("failed to build service yaml, serviceName：%s, err: %w", svc.ServiceName, err))
+		}
+		rederedYaml, err := kube.RenderServiceYaml(svcYaml, productName, svc.ServiceName, fakeRenderMap[svc.ServiceName])
 		if err != nil {
 			return nil, e.ErrGetResourceDeployInfo.AddErr(fmt.Errorf("failed to render service yaml, serviceName：%s, err: %w", svc.ServiceName, err))
 		}
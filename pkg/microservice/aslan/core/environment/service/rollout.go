@@ -0,0 +1,118 @@
+/*
+Copyright 2025 The KodeRover Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package service
+
+import (
+	"fmt"
+
+	"go.uber.org/zap"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	commonrepo "github.com/koderover/zadig/v2/pkg/microservice/aslan/core/common/repository/mongodb"
+	"github.com/koderover/zadig/v2/pkg/setting"
+	"github.com/koderover/zadig/v2/pkg/tool/clientmanager"
+	e "github.com/koderover/zadig/v2/pkg/tool/errors"
+	"github.com/koderover/zadig/v2/pkg/tool/kube/getter"
+	"github.com/koderover/zadig/v2/pkg/tool/kube/updater"
+)
+
+// PromoteRollout moves the paused Argo Rollout or Kruise Rollout in the environment on to the next step.
+// full skips all the remaining steps and analysis of an Argo Rollout, it's ignored by Kruise Rollouts.
+func PromoteRollout(projectName, envName, name string, full, production bool, log *zap.SugaredLogger) error {
+	namespace, kubeClient, err := getRolloutEnvClient(projectName, envName, production)
+	if err != nil {
+		return e.ErrPromoteRollout.AddErr(err)
+	}
+
+	provider, err := findRolloutProvider(namespace, name, kubeClient)
+	if err != nil {
+		return e.ErrPromoteRollout.AddErr(err)
+	}
+
+	switch provider {
+	case setting.RolloutProviderArgo:
+		err = updater.PromoteArgoRollout(namespace, name, full, kubeClient)
+	case setting.RolloutProviderKruise:
+		err = updater.ApproveKruiseRollout(namespace, name, kubeClient)
+	}
+	if err != nil {
+		log.Errorf("failed to promote rollout %s/%s, err: %s", namespace, name, err)
+		return e.ErrPromoteRollout.AddErr(err)
+	}
+	return nil
+}
+
+// AbortRollout aborts the update of the Argo Rollout in the environment, Kruise Rollouts can't be aborted.
+func AbortRollout(projectName, envName, name string, production bool, log *zap.SugaredLogger) error {
+	namespace, kubeClient, err := getRolloutEnvClient(projectName, envName, production)
+	if err != nil {
+		return e.ErrAbortRollout.AddErr(err)
+	}
+
+	provider, err := findRolloutProvider(namespace, name, kubeClient)
+	if err != nil {
+		return e.ErrAbortRollout.AddErr(err)
+	}
+	if provider != setting.RolloutProviderArgo {
+		return e.ErrAbortRollout.AddDesc("abort is not supported by kruise rollouts, roll the workload back instead")
+	}
+
+	if err := updater.AbortArgoRollout(namespace, name, kubeClient); err != nil {
+		log.Errorf("failed to abort rollout %s/%s, err: %s", namespace, name, err)
+		return e.ErrAbortRollout.AddErr(err)
+	}
+	return nil
+}
+
+func getRolloutEnvClient(projectName, envName string, production bool) (string, client.Client, error) {
+	product, err := commonrepo.NewProductColl().Find(&commonrepo.ProductFindOptions{
+		Name:       projectName,
+		EnvName:    envName,
+		Production: &production,
+	})
+	if err != nil {
+		return "", nil, fmt.Errorf("failed to find env %s/%s, err: %s", projectName, envName, err)
+	}
+	if product.IsSleeping() {
+		return "", nil, fmt.Errorf("environment is sleeping")
+	}
+
+	kubeClient, err := clientmanager.NewKubeClientManager().GetControllerRuntimeClient(product.ClusterID)
+	if err != nil {
+		return "", nil, err
+	}
+	return product.Namespace, kubeClient, nil
+}
+
+func findRolloutProvider(namespace, name string, kubeClient client.Client) (string, error) {
+	_, found, err := getter.GetArgoRollout(namespace, name, kubeClient)
+	if err != nil {
+		return "", err
+	}
+	if found {
+		return setting.RolloutProviderArgo, nil
+	}
+
+	_, found, err = getter.GetKruiseRollout(namespace, name, kubeClient)
+	if err != nil {
+		return "", err
+	}
+	if found {
+		return setting.RolloutProviderKruise, nil
+	}
+	return "", fmt.Errorf("rollout %s/%s not found", namespace, name)
+}
@@ -206,6 +206,7 @@ func WorkflowTaskV4ContainerLogStream(ctx context.Context, streamChan chan inter
 				string(config.JobZadigTesting),
 				string(config.JobZadigScanning),
 				string(config.JobZadigDistributeImage),
+				string(config.JobZadigImageScan),
 				string(config.JobBuild):
 				jobSpec := &commonmodels.JobTaskFreestyleSpec{}
 				if err := commonmodels.IToi(job.Spec, jobSpec); err != nil {
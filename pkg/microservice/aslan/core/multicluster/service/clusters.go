@@ -57,6 +57,7 @@ import (
 	"github.com/koderover/zadig/v2/pkg/shared/handler"
 	"github.com/koderover/zadig/v2/pkg/tool/clientmanager"
 	e "github.com/koderover/zadig/v2/pkg/tool/errors"
+	"github.com/koderover/zadig/v2/pkg/tool/kube/multicluster"
 	"github.com/koderover/zadig/v2/pkg/tool/kube/serializer"
 	"github.com/koderover/zadig/v2/pkg/tool/kube/updater"
 	"github.com/koderover/zadig/v2/pkg/tool/log"
@@ -99,6 +100,7 @@ type AdvancedConfig struct {
 	ScheduleStrategy  []*ScheduleStrategy `json:"schedule_strategy"         bson:"schedule_strategy"`
 	EnableIRSA        bool                `json:"enable_irsa"               bson:"enable_irsa"`
 	IRSARoleARM       string              `json:"irsa_role_arn"             bson:"irsa_role_arn"`
+	ImageBuildBackend string              `json:"image_build_backend"       bson:"image_build_backend"`
 
 	AgentNodeSelector string `json:"agent_node_selector"            bson:"agent_node_selector"`
 	AgentToleration   string `json:"agent_toleration"               bson:"agent_toleration"`
@@ -261,6 +263,7 @@ func ListClusters(ids []string, projectName string, logger *zap.SugaredLogger) (
 
 			advancedConfig.EnableIRSA = c.AdvancedConfig.EnableIRSA
 			advancedConfig.IRSARoleARM = c.AdvancedConfig.IRSARoleARM
+			advancedConfig.ImageBuildBackend = c.AdvancedConfig.ImageBuildBackend
 		}
 
 		if c.DindCfg == nil {
@@ -425,6 +428,7 @@ func UpdateCluster(ctx *handler.Context, id string, args *K8SCluster) (*commonmo
 	cluster.AdvancedConfig.ScheduleWorkflow = clusterArgs.AdvancedConfig.ScheduleWorkflow
 	cluster.AdvancedConfig.EnableIRSA = clusterArgs.AdvancedConfig.EnableIRSA
 	cluster.AdvancedConfig.IRSARoleARM = clusterArgs.AdvancedConfig.IRSARoleARM
+	cluster.AdvancedConfig.ImageBuildBackend = clusterArgs.AdvancedConfig.ImageBuildBackend
 
 	// Delete all projects associated with clusterID
 	hasErr := false
@@ -753,6 +757,31 @@ func GetClusterStatus() map[string]float64 {
 	return res
 }
 
+// GetHubAgentConnectionStatus returns whether the hub agent of each agent type cluster has a session in hub server
+func GetHubAgentConnectionStatus() map[string]bool {
+	res := make(map[string]bool)
+	cs, err := commonrepo.NewK8SClusterColl().List(&commonrepo.ClusterListOpts{})
+	if err != nil {
+		log.Errorf("Failed to list clusters, err: %s", err)
+		return res
+	}
+
+	hubClient, err := multicluster.NewHubClient(configbase.HubServerServiceAddress())
+	if err != nil {
+		log.Errorf("Failed to create hub client, err: %s", err)
+		return res
+	}
+
+	for _, c := range cs {
+		if c.Local || c.Type == setting.KubeConfigClusterType || c.Status == setting.Disconnected {
+			continue
+		}
+		res[c.Name] = hubClient.HasSession(c.ID.Hex()) == nil
+	}
+
+	return res
+}
+
 type ClusterDeletionInfo struct {
 	Deletable bool       `json:"deletable"`
 	EnvInUse  []*EnvInfo `json:"env_in_use,omitempty"`
@@ -1792,6 +1821,10 @@ func K8SClusterArgsToModel(args *K8SCluster) (*commonmodels.K8SCluster, error) {
 		advancedConfig.ScheduleWorkflow = args.AdvancedConfig.ScheduleWorkflow
 		advancedConfig.EnableIRSA = args.AdvancedConfig.EnableIRSA
 		advancedConfig.IRSARoleARM = args.AdvancedConfig.IRSARoleARM
+		advancedConfig.ImageBuildBackend = args.AdvancedConfig.ImageBuildBackend
+		if err := commonutil.CheckImageBuildBackend(advancedConfig.ImageBuildBackend); err != nil {
+			return nil, err
+		}
 
 		advancedConfig.ScheduleStrategy = make([]*commonmodels.ScheduleStrategy, 0)
 		for _, strategy := range args.AdvancedConfig.ScheduleStrategy {
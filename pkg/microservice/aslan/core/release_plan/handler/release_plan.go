@@ -341,6 +341,43 @@ func ApproveReleasePlan(c *gin.Context) {
 	ctx.RespErr = service.ApproveReleasePlan(ctx, c.Param("id"), req)
 }
 
+type RollbackReleasePlanResp struct {
+	PlanID string `json:"plan_id"`
+}
+
+// @Summary Rollback Release Plan
+// @Description Rollback the production services of the release plan to the state before it was executed, a new release plan will be created to track the rollback
+// @Tags 	releasePlan
+// @Accept 	json
+// @Produce json
+// @Param 	id 		path		string							true	"release plan id"
+// @Success 200 	{object} 	RollbackReleasePlanResp
+// @Router /api/aslan/release_plan/v1/{id}/rollback [post]
+func RollbackReleasePlan(c *gin.Context) {
+	ctx, err := internalhandler.NewContextWithAuthorization(c)
+	defer func() { internalhandler.JSONResponse(c, ctx) }()
+	if err != nil {
+		ctx.RespErr = fmt.Errorf("authorization Info Generation failed: err %s", err)
+		ctx.UnAuthorized = true
+		return
+	}
+
+	err = commonutil.CheckZadigEnterpriseLicense()
+	if err != nil {
+		ctx.RespErr = err
+		return
+	}
+
+	// only release plan manager can roll back release plan
+	// so no need to check authorization there
+	planID, err := service.RollbackReleasePlan(ctx, c.Param("id"), ctx.Resources.IsSystemAdmin)
+	if err != nil {
+		ctx.RespErr = err
+		return
+	}
+	ctx.Resp = &RollbackReleasePlanResp{PlanID: planID}
+}
+
 // @Summary List Release Plans
 // @Description List Release Plans
 // @Tags 	releasePlan
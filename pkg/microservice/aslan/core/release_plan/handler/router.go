@@ -37,6 +37,7 @@ func (*Router) Inject(router *gin.RouterGroup) {
 		v1.POST("/:id/skip", SkipReleaseJob)
 		v1.POST("/:id/status/:status", UpdateReleaseJobStatus)
 		v1.POST("/:id/approve", ApproveReleasePlan)
+		v1.POST("/:id/rollback", RollbackReleasePlan)
 
 		v1.GET("/hook/setting", GetReleasePlanHookSetting)
 		v1.PUT("/hook/setting", UpdateReleasePlanHookSetting)
@@ -146,6 +146,11 @@ func (e *WorkflowReleaseJobExecutor) Execute(plan *models.ReleasePlan) error {
 			return errors.Errorf("job %s status %s can't execute", job.Name, job.Status)
 		}
 
+		// the inverse workflows of a rollback plan are started one after another by the plan itself
+		if plan.RollbackFromPlanID != "" {
+			return errors.Errorf("jobs of rollback plan can not be executed manually")
+		}
+
 		workflowController := controller.CreateWorkflowController(spec.Workflow)
 		if err := workflowController.UpdateWithLatestWorkflow(nil); err != nil {
 			log.Errorf("cannot merge workflow %s's input with the latest workflow settings, the error is: %v", spec.Workflow.Name, err)
@@ -862,6 +862,9 @@ func SkipReleaseJob(c *handler.Context, planID string, args *SkipReleaseJobArgs,
 	if plan.Status != config.ReleasePlanStatusExecuting {
 		return errors.Errorf("plan status is %s, can not skip", plan.Status)
 	}
+	if plan.RollbackFromPlanID != "" {
+		return errors.Errorf("jobs of rollback plan can not be skipped")
+	}
 
 	if !(plan.StartTime == 0 && plan.EndTime == 0) {
 		now := time.Now().Unix()
@@ -955,6 +958,10 @@ func UpdateReleasePlanStatus(c *handler.Context, planID, targetStatus string, is
 		return errors.Errorf("can't convert plan status %s to %s", plan.Status, targetStatus)
 	}
 
+	if plan.RollbackFromPlanID != "" && config.ReleasePlanStatus(targetStatus) != config.ReleasePlanStatusCancel {
+		return errors.Errorf("rollback plan can only be canceled")
+	}
+
 	userInfo, err := user.New().GetUserByID(c.UserID)
 	if err != nil {
 		return errors.Wrap(err, "get user")
@@ -1317,6 +1324,9 @@ func checkReleasePlanJobsAllDone(plan *models.ReleasePlan) bool {
 }
 
 func setReleaseJobsForExecuting(plan *models.ReleasePlan) {
+	// capture the production state before any job runs, so the plan can be rolled back
+	captureReleasePlanRollbackSnapshot(plan)
+
 	for _, job := range plan.Jobs {
 		if job.LastStatus == config.ReleasePlanJobStatusDone && !job.Updated {
 			job.Status = config.ReleasePlanJobStatusDone
@@ -0,0 +1,643 @@
+/*
+ * Copyright 2025 The KodeRover Authors.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package service
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/pkg/errors"
+	"go.uber.org/zap"
+	"k8s.io/apimachinery/pkg/util/sets"
+
+	"github.com/koderover/zadig/v2/pkg/microservice/aslan/config"
+	"github.com/koderover/zadig/v2/pkg/microservice/aslan/core/common/repository/models"
+	"github.com/koderover/zadig/v2/pkg/microservice/aslan/core/common/repository/mongodb"
+	"github.com/koderover/zadig/v2/pkg/microservice/aslan/core/workflow/service/workflow"
+	"github.com/koderover/zadig/v2/pkg/setting"
+	"github.com/koderover/zadig/v2/pkg/shared/client/user"
+	"github.com/koderover/zadig/v2/pkg/shared/handler"
+	"github.com/koderover/zadig/v2/pkg/tool/log"
+)
+
+// captureReleasePlanRollbackSnapshot records the current production state of every service
+// deployed by the workflow jobs of the plan, so that the plan can be rolled back later.
+func captureReleasePlanRollbackSnapshot(plan *models.ReleasePlan) {
+	if plan.RollbackFromPlanID != "" {
+		return
+	}
+
+	snapshots := make([]*models.ReleasePlanServiceSnapshot, 0)
+	captured := make(map[string]bool)
+	capture := func(projectName, envName, serviceName string, isHelmChart bool) {
+		key := releasePlanSnapshotKey(projectName, envName, serviceName, isHelmChart)
+		if captured[key] {
+			return
+		}
+		captured[key] = true
+
+		snapshot, err := getReleasePlanServiceSnapshot(projectName, envName, serviceName, isHelmChart)
+		if err != nil {
+			log.Warnf("failed to capture rollback snapshot of %s for release plan %s, error: %v", key, plan.Name, err)
+			return
+		}
+		snapshots = append(snapshots, snapshot)
+	}
+
+	for _, job := range plan.Jobs {
+		if job.Type != config.JobWorkflow {
+			continue
+		}
+		spec := new(models.WorkflowReleaseJobSpec)
+		if err := models.IToi(job.Spec, spec); err != nil || spec.Workflow == nil {
+			continue
+		}
+
+		for _, stage := range spec.Workflow.Stages {
+			for _, workflowJob := range stage.Jobs {
+				switch workflowJob.JobType {
+				case config.JobZadigDeploy:
+					deploySpec := new(models.ZadigDeployJobSpec)
+					if err := models.IToi(workflowJob.Spec, deploySpec); err != nil {
+						continue
+					}
+					if !deploySpec.Production || deploySpec.Env == "" {
+						continue
+					}
+					for _, svc := range deploySpec.Services {
+						capture(spec.Workflow.Project, deploySpec.Env, svc.ServiceName, false)
+					}
+				case config.JobZadigHelmChartDeploy:
+					deploySpec := new(models.ZadigHelmChartDeployJobSpec)
+					if err := models.IToi(workflowJob.Spec, deploySpec); err != nil {
+						continue
+					}
+					if !deploySpec.Production || deploySpec.Env == "" {
+						continue
+					}
+					for _, chart := range deploySpec.DeployHelmCharts {
+						capture(spec.Workflow.Project, deploySpec.Env, chart.ReleaseName, true)
+					}
+				}
+			}
+		}
+	}
+
+	plan.RollbackSnapshot = snapshots
+}
+
+func getReleasePlanServiceSnapshot(projectName, envName, serviceName string, isHelmChart bool) (*models.ReleasePlanServiceSnapshot, error) {
+	production := true
+	env, err := mongodb.NewProductColl().Find(&mongodb.ProductFindOptions{
+		Name:       projectName,
+		EnvName:    envName,
+		Production: &production,
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "find env")
+	}
+
+	var svc *models.ProductService
+	if isHelmChart {
+		svc = env.GetChartServiceMap()[serviceName]
+	} else {
+		svc = env.GetServiceMap()[serviceName]
+	}
+	if svc == nil {
+		// the service is deployed into the env for the first time, nothing to roll back to
+		return nil, errors.Errorf("service not found in env")
+	}
+
+	revision, err := mongodb.NewEnvServiceVersionColl().GetLatestRevision(projectName, envName, serviceName, isHelmChart, production)
+	if err != nil {
+		return nil, errors.Wrap(err, "get latest env service revision")
+	}
+	if revision == 0 {
+		return nil, errors.Errorf("no env service version found")
+	}
+
+	snapshot := &models.ReleasePlanServiceSnapshot{
+		ProjectName: projectName,
+		EnvName:     envName,
+		ServiceName: serviceName,
+		ServiceType: svc.Type,
+		Production:  production,
+		IsHelmChart: isHelmChart,
+		Revision:    revision,
+		Containers:  svc.Containers,
+		CreateTime:  time.Now().Unix(),
+	}
+	if svc.Type == setting.HelmDeployType || svc.Type == setting.HelmChartDeployType {
+		render := svc.GetServiceRender()
+		snapshot.ValuesYaml = render.GetOverrideYaml()
+		snapshot.ChartRepo = render.ChartRepo
+		snapshot.ChartName = render.ChartName
+		snapshot.ChartVersion = render.ChartVersion
+	}
+	return snapshot, nil
+}
+
+func releasePlanSnapshotKey(projectName, envName, serviceName string, isHelmChart bool) string {
+	return fmt.Sprintf("%s/%s/%s/%v", projectName, envName, serviceName, isHelmChart)
+}
+
+// RollbackReleasePlan creates a new release plan which runs the inverse of the workflows of the given plan, restoring
+// the production services affected by it to the state captured before it was executed.
+func RollbackReleasePlan(c *handler.Context, planID string, isSystemAdmin bool) (string, error) {
+	approveLock := getLock(planID)
+	approveLock.Lock()
+	defer approveLock.Unlock()
+
+	ctx, cancel := context.WithTimeout(context.Background(), defaultTimeout)
+	defer cancel()
+	plan, err := mongodb.NewReleasePlanColl().GetByID(ctx, planID)
+	if err != nil {
+		return "", errors.Wrap(err, "get plan")
+	}
+
+	if c.UserID != plan.ManagerID && !isSystemAdmin {
+		return "", errors.Errorf("only manager can roll back the plan")
+	}
+	if plan.RollbackFromPlanID != "" {
+		return "", errors.Errorf("rollback plan can not be rolled back")
+	}
+	if plan.RollbackPlanID != "" {
+		// a failed or canceled rollback can be started again
+		lastRollbackPlan, err := mongodb.NewReleasePlanColl().GetByID(ctx, plan.RollbackPlanID)
+		if err == nil && lastRollbackPlan.Status != config.ReleasePlanStatusFailed && lastRollbackPlan.Status != config.ReleasePlanStatusCancel {
+			return "", errors.Errorf("plan has already been rolled back by plan %s", lastRollbackPlan.Name)
+		}
+	}
+	if plan.ExecutingTime == 0 {
+		return "", errors.Errorf("plan has not been executed")
+	}
+	if len(plan.RollbackSnapshot) == 0 {
+		return "", errors.Errorf("no production service snapshot found in the plan, nothing to roll back")
+	}
+
+	rollbackJobs, err := buildReleasePlanRollbackJobs(plan)
+	if err != nil {
+		return "", err
+	}
+	if len(rollbackJobs) == 0 {
+		return "", errors.Errorf("no production deploy job found in the plan, nothing to roll back")
+	}
+
+	nextID, err := mongodb.NewCounterColl().GetNextSeq(setting.ReleasePlanFmt)
+	if err != nil {
+		return "", errors.Wrap(err, "get next release plan index")
+	}
+
+	now := time.Now().Unix()
+	rollbackPlan := &models.ReleasePlan{
+		Index:              nextID,
+		Name:               fmt.Sprintf("%s-回滚", plan.Name),
+		Manager:            c.UserName,
+		ManagerID:          c.UserID,
+		Description:        fmt.Sprintf("回滚发布计划 %s", plan.Name),
+		CreatedBy:          c.UserName,
+		CreateTime:         now,
+		UpdatedBy:          c.UserName,
+		UpdateTime:         now,
+		Status:             config.ReleasePlanStatusExecuting,
+		PlanningTime:       now,
+		FinishPlanningTime: now,
+		ExecutingTime:      now,
+		Jobs:               rollbackJobs,
+		RollbackFromPlanID: planID,
+	}
+
+	rollbackPlanID, err := mongodb.NewReleasePlanColl().Create(rollbackPlan)
+	if err != nil {
+		return "", errors.Wrap(err, "create rollback plan")
+	}
+
+	plan.RollbackPlanID = rollbackPlanID
+	plan.UpdatedBy = c.UserName
+	plan.UpdateTime = now
+	if err = mongodb.NewReleasePlanColl().UpdateByID(ctx, planID, plan); err != nil {
+		return "", errors.Wrap(err, "update plan")
+	}
+
+	go executeReleasePlanRollback(rollbackPlanID, log.SugaredLogger().With("source", "release plan rollback"))
+
+	go func() {
+		if err := mongodb.NewReleasePlanLogColl().Create(&models.ReleasePlanLog{
+			PlanID:     planID,
+			Username:   c.UserName,
+			Account:    c.Account,
+			Verb:       VerbRollback,
+			TargetName: plan.Name,
+			TargetType: TargetTypeReleasePlan,
+			Detail:     rollbackPlan.Name,
+			CreatedAt:  time.Now().Unix(),
+		}); err != nil {
+			log.Errorf("create release plan log error: %v", err)
+		}
+	}()
+
+	return rollbackPlanID, nil
+}
+
+// buildReleasePlanRollbackJobs generates a workflow release job running the inverse workflow for every workflow job
+// of the plan which deployed production services, in the reverse order of the plan.
+func buildReleasePlanRollbackJobs(plan *models.ReleasePlan) ([]*models.ReleaseJob, error) {
+	jobs := make([]*models.ReleaseJob, 0)
+	for i := len(plan.Jobs) - 1; i >= 0; i-- {
+		job := plan.Jobs[i]
+		if job.Type != config.JobWorkflow {
+			continue
+		}
+		spec := new(models.WorkflowReleaseJobSpec)
+		if err := models.IToi(job.Spec, spec); err != nil {
+			return nil, errors.Wrapf(err, "invalid spec of release job %s", job.Name)
+		}
+		if spec.Workflow == nil {
+			continue
+		}
+
+		rollbackWorkflow, err := buildReleasePlanRollbackWorkflow(spec.Workflow, plan.RollbackSnapshot)
+		if err != nil {
+			return nil, errors.Wrapf(err, "build rollback workflow of release job %s", job.Name)
+		}
+		if rollbackWorkflow == nil {
+			continue
+		}
+
+		jobs = append(jobs, &models.ReleaseJob{
+			ID:   uuid.New().String(),
+			Name: job.Name,
+			Type: config.JobWorkflow,
+			Spec: &models.WorkflowReleaseJobSpec{
+				Workflow: rollbackWorkflow,
+			},
+			ReleaseJobRuntime: models.ReleaseJobRuntime{
+				Status: config.ReleasePlanJobStatusTodo,
+			},
+		})
+	}
+	return jobs, nil
+}
+
+// buildReleasePlanRollbackWorkflow builds the inverse of a workflow run by a release plan: its production deploy jobs
+// redeploy the images and values captured in the snapshots, every other job is skipped.
+// It returns nil if none of the deployed services has a snapshot to restore.
+func buildReleasePlanRollbackWorkflow(origin *models.WorkflowV4, snapshots []*models.ReleasePlanServiceSnapshot) (*models.WorkflowV4, error) {
+	snapshotMap := make(map[string]*models.ReleasePlanServiceSnapshot)
+	for _, snapshot := range snapshots {
+		snapshotMap[releasePlanSnapshotKey(snapshot.ProjectName, snapshot.EnvName, snapshot.ServiceName, snapshot.IsHelmChart)] = snapshot
+	}
+
+	wf := new(models.WorkflowV4)
+	if err := models.IToi(origin, wf); err != nil {
+		return nil, errors.Wrap(err, "copy workflow")
+	}
+
+	restored := false
+	for _, stage := range wf.Stages {
+		for _, job := range stage.Jobs {
+			var (
+				ok  bool
+				err error
+			)
+			switch job.JobType {
+			case config.JobZadigDeploy:
+				ok, err = setDeployJobRollback(job, wf.Project, snapshotMap)
+			case config.JobZadigHelmChartDeploy:
+				ok, err = setHelmChartDeployJobRollback(job, wf.Project, snapshotMap)
+			}
+			if err != nil {
+				return nil, errors.Wrapf(err, "job %s", job.Name)
+			}
+			if !ok {
+				job.Skipped = true
+				job.RunPolicy = config.DefaultRun
+				continue
+			}
+			job.Skipped = false
+			restored = true
+		}
+	}
+
+	if !restored {
+		return nil, nil
+	}
+	return wf, nil
+}
+
+func setDeployJobRollback(job *models.Job, projectName string, snapshotMap map[string]*models.ReleasePlanServiceSnapshot) (bool, error) {
+	spec := new(models.ZadigDeployJobSpec)
+	if err := models.IToi(job.Spec, spec); err != nil {
+		return false, err
+	}
+	if !spec.Production {
+		return false, nil
+	}
+
+	isHelm := spec.DeployType == setting.HelmDeployType
+	isK8s := spec.DeployType == setting.K8SDeployType
+	services := make([]*models.DeployServiceInfo, 0)
+	for _, svc := range spec.Services {
+		snapshot, ok := snapshotMap[releasePlanSnapshotKey(projectName, spec.Env, svc.ServiceName, false)]
+		if !ok {
+			continue
+		}
+
+		modules := make([]*models.DeployModuleInfo, 0)
+		for _, container := range snapshot.Containers {
+			modules = append(modules, &models.DeployModuleInfo{
+				ServiceModule: container.Name,
+				Image:         container.Image,
+				ImageName:     container.ImageName,
+			})
+		}
+
+		rollbackSvc := &models.DeployServiceInfo{
+			DeployBasicInfo: models.DeployBasicInfo{
+				ServiceName: svc.ServiceName,
+				Modules:     modules,
+				Deployed:    true,
+			},
+		}
+		if isHelm {
+			// the captured values replace whatever the plan has deployed
+			rollbackSvc.ValueMergeStrategy = config.ValueMergeStrategyOverride
+			rollbackSvc.VariableYaml = snapshot.ValuesYaml
+		}
+		if isK8s {
+			// the variables are restored from the render of the captured revision. The service template itself can not
+			// be restored, a deploy job can only move a service to the latest template, so UpdateConfig stays false.
+			version, err := mongodb.NewEnvServiceVersionColl().Find(projectName, spec.Env, svc.ServiceName, false, true, snapshot.Revision)
+			if err != nil {
+				return false, errors.Wrapf(err, "find revision %d of service %s", snapshot.Revision, svc.ServiceName)
+			}
+			if version.Service != nil {
+				rollbackSvc.VariableKVs = version.Service.GetServiceRender().OverrideYaml.RenderVariableKVs
+			}
+		}
+		services = append(services, rollbackSvc)
+	}
+	if len(services) == 0 {
+		return false, nil
+	}
+
+	spec.Source = config.SourceRuntime
+	spec.JobName = ""
+	spec.OriginJobName = ""
+	spec.DependencyOrder = false
+	spec.DeployContents = []config.DeployContent{config.DeployImage}
+	if isHelm || isK8s {
+		spec.DeployContents = append(spec.DeployContents, config.DeployVars)
+	}
+	if isHelm {
+		spec.ValueMergeStrategy = config.ValueMergeStrategyOverride
+	}
+	spec.Services = services
+	job.Spec = spec
+	return true, nil
+}
+
+func setHelmChartDeployJobRollback(job *models.Job, projectName string, snapshotMap map[string]*models.ReleasePlanServiceSnapshot) (bool, error) {
+	spec := new(models.ZadigHelmChartDeployJobSpec)
+	if err := models.IToi(job.Spec, spec); err != nil {
+		return false, err
+	}
+	if !spec.Production {
+		return false, nil
+	}
+
+	charts := make([]*models.DeployHelmChart, 0)
+	for _, chart := range spec.DeployHelmCharts {
+		snapshot, ok := snapshotMap[releasePlanSnapshotKey(projectName, spec.Env, chart.ReleaseName, true)]
+		if !ok {
+			continue
+		}
+		charts = append(charts, &models.DeployHelmChart{
+			ReleaseName:  chart.ReleaseName,
+			ChartRepo:    snapshot.ChartRepo,
+			ChartName:    snapshot.ChartName,
+			ChartVersion: snapshot.ChartVersion,
+			ValuesYaml:   snapshot.ValuesYaml,
+		})
+	}
+	if len(charts) == 0 {
+		return false, nil
+	}
+
+	spec.DeployHelmCharts = charts
+	job.Spec = spec
+	return true, nil
+}
+
+// releasePlanDoneStatus returns the status of a plan once all of its jobs are finished. A rollback plan only
+// succeeds if every inverse workflow has passed, a failed one leaves production partially restored.
+func releasePlanDoneStatus(plan *models.ReleasePlan) config.ReleasePlanStatus {
+	if plan.RollbackFromPlanID == "" {
+		return config.ReleasePlanStatusSuccess
+	}
+	for _, job := range plan.Jobs {
+		if job.Status != config.ReleasePlanJobStatusDone && job.Status != config.ReleasePlanJobStatusSkipped {
+			return config.ReleasePlanStatusFailed
+		}
+	}
+	return config.ReleasePlanStatusSuccess
+}
+
+// executeReleasePlanRollback starts the first inverse workflow of the rollback plan. The inverse workflows run one
+// after another in the order of the plan, the next one is started by WatchExecutingWorkflow once the previous passed.
+func executeReleasePlanRollback(planID string, logger *zap.SugaredLogger) {
+	releaseLock := getLock(planID)
+	releaseLock.Lock()
+	defer releaseLock.Unlock()
+
+	ctx, cancel := context.WithTimeout(context.Background(), defaultTimeout)
+	defer cancel()
+	plan, err := mongodb.NewReleasePlanColl().GetByID(ctx, planID)
+	if err != nil {
+		logger.Errorf("failed to get rollback plan %s, error: %v", planID, err)
+		return
+	}
+	if plan.Status != config.ReleasePlanStatusExecuting {
+		return
+	}
+
+	startNextReleasePlanRollbackJob(plan, logger)
+	plan.UpdateTime = time.Now().Unix()
+	if err = mongodb.NewReleasePlanColl().UpdateByID(ctx, planID, plan); err != nil {
+		logger.Errorf("failed to update rollback plan %s, error: %v", planID, err)
+	}
+}
+
+// advanceReleasePlanRollback records the services restored by the passed jobs of the rollback plan and starts the
+// next inverse workflow. A failed job fails the plan, the services after it are left untouched.
+// It returns true if the plan is finished.
+func advanceReleasePlanRollback(plan *models.ReleasePlan, passedJobs []*models.ReleaseJob, logger *zap.SugaredLogger) bool {
+	if len(passedJobs) > 0 {
+		recordReleasePlanRollback(plan, passedJobs, logger)
+	}
+
+	for _, job := range plan.Jobs {
+		switch job.Status {
+		case config.ReleasePlanJobStatusFailed:
+			plan.Status = config.ReleasePlanStatusFailed
+			return true
+		case config.ReleasePlanJobStatusRunning:
+			return false
+		}
+	}
+
+	if startNextReleasePlanRollbackJob(plan, logger) {
+		return false
+	}
+	if checkReleasePlanJobsAllDone(plan) {
+		plan.Status = releasePlanDoneStatus(plan)
+		if plan.Status == config.ReleasePlanStatusSuccess {
+			plan.SuccessTime = time.Now().Unix()
+		}
+		return true
+	}
+	return false
+}
+
+// startNextReleasePlanRollbackJob creates the workflow task of the first pending job of the rollback plan on behalf of
+// the user who started the rollback. It returns true if a task has been created.
+func startNextReleasePlanRollbackJob(plan *models.ReleasePlan, logger *zap.SugaredLogger) bool {
+	for _, job := range plan.Jobs {
+		if job.Type != config.JobWorkflow || job.Status != config.ReleasePlanJobStatusTodo {
+			continue
+		}
+		spec := new(models.WorkflowReleaseJobSpec)
+		if err := models.IToi(job.Spec, spec); err != nil || spec.Workflow == nil {
+			logger.Errorf("invalid spec of rollback release job %s, error: %v", job.Name, err)
+			job.Status = config.ReleasePlanJobStatusFailed
+			return false
+		}
+
+		c := releasePlanRollbackContext(plan, logger)
+		job.ExecutedBy = c.UserName
+		job.ExecutedTime = time.Now().Unix()
+		result, err := workflow.CreateWorkflowTaskV4(&workflow.CreateWorkflowTaskV4Args{
+			Name:               c.UserName,
+			Account:            c.Account,
+			UserID:             c.UserID,
+			SkipWorkflowUpdate: true,
+		}, spec.Workflow, logger)
+		if err != nil {
+			logger.Errorf("failed to create rollback workflow task %s of plan %s, error: %v", spec.Workflow.Name, plan.Name, err)
+			spec.Status = config.StatusFailed
+			job.Spec = spec
+			job.Status = config.ReleasePlanJobStatusFailed
+			return false
+		}
+
+		spec.TaskID = result.TaskID
+		spec.Status = config.StatusPrepare
+		job.Spec = spec
+		job.Status = config.ReleasePlanJobStatusRunning
+		return true
+	}
+	return false
+}
+
+// releasePlanRollbackContext returns the context of the user who started the rollback, the manager of the rollback plan.
+func releasePlanRollbackContext(plan *models.ReleasePlan, logger *zap.SugaredLogger) *handler.Context {
+	c := &handler.Context{
+		Context:  context.Background(),
+		Logger:   logger,
+		UserName: plan.Manager,
+		UserID:   plan.ManagerID,
+	}
+	if userInfo, err := user.New().GetUserByID(plan.ManagerID); err != nil {
+		logger.Warnf("failed to get user %s, error: %v", plan.ManagerID, err)
+	} else {
+		c.Account = userInfo.Account
+	}
+	return c
+}
+
+// recordReleasePlanRollback writes a rollback record for every production service restored by the passed jobs.
+func recordReleasePlanRollback(plan *models.ReleasePlan, passedJobs []*models.ReleaseJob, logger *zap.SugaredLogger) {
+	ctx, cancel := context.WithTimeout(context.Background(), defaultTimeout)
+	defer cancel()
+	originPlan, err := mongodb.NewReleasePlanColl().GetByID(ctx, plan.RollbackFromPlanID)
+	if err != nil {
+		logger.Errorf("failed to get plan %s rolled back by plan %s, error: %v", plan.RollbackFromPlanID, plan.Name, err)
+		return
+	}
+
+	c := releasePlanRollbackContext(plan, logger)
+	restored := sets.NewString()
+	for _, job := range passedJobs {
+		spec := new(models.WorkflowReleaseJobSpec)
+		if err := models.IToi(job.Spec, spec); err != nil || spec.Workflow == nil {
+			continue
+		}
+		restored.Insert(releasePlanWorkflowServices(spec.Workflow)...)
+	}
+
+	for _, snapshot := range originPlan.RollbackSnapshot {
+		if !restored.Has(releasePlanSnapshotKey(snapshot.ProjectName, snapshot.EnvName, snapshot.ServiceName, snapshot.IsHelmChart)) {
+			continue
+		}
+		if err := mongodb.NewEnvInfoColl().Create(c, &models.EnvInfo{
+			ProjectName:   snapshot.ProjectName,
+			EnvName:       snapshot.EnvName,
+			EnvType:       config.EnvTypeZadig,
+			Production:    snapshot.Production,
+			Operation:     config.EnvOperationRollback,
+			OperationType: config.EnvOperationTypeZadig,
+			ServiceName:   snapshot.ServiceName,
+			ServiceType:   config.ServiceType(snapshot.ServiceType),
+			Detail:        fmt.Sprintf("回滚发布计划 %s", plan.Name),
+		}); err != nil {
+			logger.Errorf("failed to create rollback record of service %s, error: %v", snapshot.ServiceName, err)
+		}
+	}
+}
+
+// releasePlanWorkflowServices returns the snapshot keys of the services deployed by the jobs of the workflow which will run.
+func releasePlanWorkflowServices(wf *models.WorkflowV4) []string {
+	keys := make([]string, 0)
+	for _, stage := range wf.Stages {
+		for _, job := range stage.Jobs {
+			if job.Skipped {
+				continue
+			}
+			switch job.JobType {
+			case config.JobZadigDeploy:
+				spec := new(models.ZadigDeployJobSpec)
+				if err := models.IToi(job.Spec, spec); err != nil {
+					continue
+				}
+				for _, svc := range spec.Services {
+					keys = append(keys, releasePlanSnapshotKey(wf.Project, spec.Env, svc.ServiceName, false))
+				}
+			case config.JobZadigHelmChartDeploy:
+				spec := new(models.ZadigHelmChartDeployJobSpec)
+				if err := models.IToi(job.Spec, spec); err != nil {
+					continue
+				}
+				for _, chart := range spec.DeployHelmCharts {
+					keys = append(keys, releasePlanSnapshotKey(wf.Project, spec.Env, chart.ReleaseName, true))
+				}
+			}
+		}
+	}
+	return keys
+}
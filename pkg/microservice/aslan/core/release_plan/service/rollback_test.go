@@ -0,0 +1,215 @@
+/*
+Copyright 2025 The KodeRover Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package service
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"github.com/koderover/zadig/v2/pkg/microservice/aslan/config"
+	"github.com/koderover/zadig/v2/pkg/microservice/aslan/core/common/repository/models"
+	"github.com/koderover/zadig/v2/pkg/setting"
+)
+
+func TestReleasePlanDoneStatus(t *testing.T) {
+	jobs := func(statuses ...config.ReleasePlanJobStatus) []*models.ReleaseJob {
+		resp := make([]*models.ReleaseJob, 0)
+		for _, status := range statuses {
+			resp = append(resp, &models.ReleaseJob{ReleaseJobRuntime: models.ReleaseJobRuntime{Status: status}})
+		}
+		return resp
+	}
+
+	tests := []struct {
+		name string
+		plan *models.ReleasePlan
+		want config.ReleasePlanStatus
+	}{
+		{
+			name: "plan with failed jobs",
+			plan: &models.ReleasePlan{Jobs: jobs(config.ReleasePlanJobStatusDone, config.ReleasePlanJobStatusFailed)},
+			want: config.ReleasePlanStatusSuccess,
+		},
+		{
+			name: "rollback plan with all jobs done",
+			plan: &models.ReleasePlan{RollbackFromPlanID: "1", Jobs: jobs(config.ReleasePlanJobStatusDone, config.ReleasePlanJobStatusSkipped)},
+			want: config.ReleasePlanStatusSuccess,
+		},
+		{
+			name: "rollback plan with a failed job",
+			plan: &models.ReleasePlan{RollbackFromPlanID: "1", Jobs: jobs(config.ReleasePlanJobStatusDone, config.ReleasePlanJobStatusFailed)},
+			want: config.ReleasePlanStatusFailed,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require.True(t, checkReleasePlanJobsAllDone(tt.plan))
+			require.Equal(t, tt.want, releasePlanDoneStatus(tt.plan))
+		})
+	}
+}
+
+func TestAdvanceReleasePlanRollback(t *testing.T) {
+	jobs := func(statuses ...config.ReleasePlanJobStatus) []*models.ReleaseJob {
+		resp := make([]*models.ReleaseJob, 0)
+		for _, status := range statuses {
+			resp = append(resp, &models.ReleaseJob{Type: config.JobWorkflow, ReleaseJobRuntime: models.ReleaseJobRuntime{Status: status}})
+		}
+		return resp
+	}
+
+	tests := []struct {
+		name       string
+		jobs       []*models.ReleaseJob
+		wantDone   bool
+		wantStatus config.ReleasePlanStatus
+		wantJobs   []config.ReleasePlanJobStatus
+	}{
+		{
+			name:       "previous job still running",
+			jobs:       jobs(config.ReleasePlanJobStatusDone, config.ReleasePlanJobStatusRunning, config.ReleasePlanJobStatusTodo),
+			wantDone:   false,
+			wantStatus: config.ReleasePlanStatusExecuting,
+			wantJobs:   []config.ReleasePlanJobStatus{config.ReleasePlanJobStatusDone, config.ReleasePlanJobStatusRunning, config.ReleasePlanJobStatusTodo},
+		},
+		{
+			name:       "failed job stops the rollback",
+			jobs:       jobs(config.ReleasePlanJobStatusFailed, config.ReleasePlanJobStatusTodo),
+			wantDone:   true,
+			wantStatus: config.ReleasePlanStatusFailed,
+			wantJobs:   []config.ReleasePlanJobStatus{config.ReleasePlanJobStatusFailed, config.ReleasePlanJobStatusTodo},
+		},
+		{
+			name:       "all jobs done",
+			jobs:       jobs(config.ReleasePlanJobStatusDone, config.ReleasePlanJobStatusSkipped),
+			wantDone:   true,
+			wantStatus: config.ReleasePlanStatusSuccess,
+			wantJobs:   []config.ReleasePlanJobStatus{config.ReleasePlanJobStatusDone, config.ReleasePlanJobStatusSkipped},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			plan := &models.ReleasePlan{RollbackFromPlanID: "1", Status: config.ReleasePlanStatusExecuting, Jobs: tt.jobs}
+			require.Equal(t, tt.wantDone, advanceReleasePlanRollback(plan, nil, zap.NewNop().Sugar()))
+			require.Equal(t, tt.wantStatus, plan.Status)
+			for i, job := range plan.Jobs {
+				require.Equal(t, tt.wantJobs[i], job.Status)
+			}
+		})
+	}
+}
+
+func TestBuildReleasePlanRollbackWorkflow(t *testing.T) {
+	origin := &models.WorkflowV4{
+		Name:    "release",
+		Project: "demo",
+		Stages: []*models.WorkflowStage{
+			{
+				Name: "build",
+				Jobs: []*models.Job{
+					{Name: "build", JobType: config.JobZadigBuild, RunPolicy: config.ForceRun, Spec: &models.ZadigBuildJobSpec{}},
+				},
+			},
+			{
+				Name: "deploy",
+				Jobs: []*models.Job{
+					{
+						Name:    "deploy",
+						JobType: config.JobZadigDeploy,
+						Spec: &models.ZadigDeployJobSpec{
+							Env:            "prod",
+							Production:     true,
+							DeployType:     setting.HelmDeployType,
+							Source:         config.SourceFromJob,
+							JobName:        "build",
+							DeployContents: []config.DeployContent{config.DeployImage},
+							Services: []*models.DeployServiceInfo{
+								{DeployBasicInfo: models.DeployBasicInfo{ServiceName: "api", Modules: []*models.DeployModuleInfo{{ServiceModule: "api", Image: "api:v2"}}}},
+								{DeployBasicInfo: models.DeployBasicInfo{ServiceName: "new"}},
+							},
+						},
+					},
+					{
+						Name:    "chart",
+						JobType: config.JobZadigHelmChartDeploy,
+						Spec: &models.ZadigHelmChartDeployJobSpec{
+							Env:        "prod",
+							Production: true,
+							DeployHelmCharts: []*models.DeployHelmChart{
+								{ReleaseName: "redis", ChartRepo: "bitnami", ChartName: "redis", ChartVersion: "2.0.0"},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+	snapshots := []*models.ReleasePlanServiceSnapshot{
+		{
+			ProjectName: "demo",
+			EnvName:     "prod",
+			ServiceName: "api",
+			ServiceType: setting.HelmDeployType,
+			Containers:  []*models.Container{{Name: "api", Image: "api:v1", ImageName: "api"}},
+			ValuesYaml:  "replicas: 2",
+		},
+		{
+			ProjectName:  "demo",
+			EnvName:      "prod",
+			ServiceName:  "redis",
+			ServiceType:  setting.HelmChartDeployType,
+			IsHelmChart:  true,
+			ValuesYaml:   "auth: false",
+			ChartRepo:    "bitnami",
+			ChartName:    "redis",
+			ChartVersion: "1.0.0",
+		},
+	}
+
+	wf, err := buildReleasePlanRollbackWorkflow(origin, snapshots)
+	require.NoError(t, err)
+	require.NotNil(t, wf)
+
+	build := wf.Stages[0].Jobs[0]
+	require.True(t, build.Skipped)
+	require.Equal(t, config.DefaultRun, build.RunPolicy)
+
+	deploySpec := new(models.ZadigDeployJobSpec)
+	require.NoError(t, models.IToi(wf.Stages[1].Jobs[0].Spec, deploySpec))
+	require.False(t, wf.Stages[1].Jobs[0].Skipped)
+	require.Equal(t, config.SourceRuntime, deploySpec.Source)
+	require.Empty(t, deploySpec.JobName)
+	require.Equal(t, []config.DeployContent{config.DeployImage, config.DeployVars}, deploySpec.DeployContents)
+	require.Len(t, deploySpec.Services, 1)
+	require.Equal(t, "api:v1", deploySpec.Services[0].Modules[0].Image)
+	require.Equal(t, "replicas: 2", deploySpec.Services[0].VariableYaml)
+	require.Equal(t, config.ValueMergeStrategy(config.ValueMergeStrategyOverride), deploySpec.Services[0].ValueMergeStrategy)
+
+	chartSpec := new(models.ZadigHelmChartDeployJobSpec)
+	require.NoError(t, models.IToi(wf.Stages[1].Jobs[1].Spec, chartSpec))
+	require.Equal(t, "1.0.0", chartSpec.DeployHelmCharts[0].ChartVersion)
+	require.Equal(t, "auth: false", chartSpec.DeployHelmCharts[0].ValuesYaml)
+
+	// the workflow of the plan is left untouched
+	require.Equal(t, config.SourceFromJob, origin.Stages[1].Jobs[0].Spec.(*models.ZadigDeployJobSpec).Source)
+
+	wf, err = buildReleasePlanRollbackWorkflow(origin, nil)
+	require.NoError(t, err)
+	require.Nil(t, wf)
+}
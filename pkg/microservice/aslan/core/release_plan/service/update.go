@@ -55,12 +55,13 @@ const (
 	TargetTypeApproval          = "审批"
 	TargetTypeDescription       = "需求关联"
 
-	VerbCreate  = "新建"
-	VerbUpdate  = "更新"
-	VerbDelete  = "删除"
-	VerbExecute = "执行"
-	VerbRetry   = "重试"
-	VerbSkip    = "跳过"
+	VerbCreate   = "新建"
+	VerbUpdate   = "更新"
+	VerbDelete   = "删除"
+	VerbExecute  = "执行"
+	VerbRetry    = "重试"
+	VerbSkip     = "跳过"
+	VerbRollback = "回滚"
 
 	DetailApprovalReject = "审批被拒绝"
 	DetailApprovalPass   = "审批通过"
@@ -78,11 +79,12 @@ var TargetTypeI18nMap = map[string]string{
 }
 
 var VerbI18nMap = map[string]string{
-	VerbCreate:  "Create",
-	VerbUpdate:  "Update",
-	VerbDelete:  "Delete",
-	VerbExecute: "Execute",
-	VerbSkip:    "Skip",
+	VerbCreate:   "Create",
+	VerbUpdate:   "Update",
+	VerbDelete:   "Delete",
+	VerbExecute:  "Execute",
+	VerbSkip:     "Skip",
+	VerbRollback: "Rollback",
 }
 
 var DetailI18nMap = map[string]string{
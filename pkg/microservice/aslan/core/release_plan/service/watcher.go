@@ -84,6 +84,7 @@ func updatePlanWorkflowReleaseJob(plan *models.ReleasePlan, log *zap.SugaredLogg
 
 	done := false
 	changed := false
+	passedJobs := make([]*models.ReleaseJob, 0)
 	for _, job := range plan.Jobs {
 		if job.Status == config.ReleasePlanJobStatusRunning && job.Type == config.JobWorkflow {
 			spec := new(models.WorkflowReleaseJobSpec)
@@ -103,17 +104,25 @@ func updatePlanWorkflowReleaseJob(plan *models.ReleasePlan, log *zap.SugaredLogg
 			}
 			if task.Status == config.StatusPassed {
 				job.Status = config.ReleasePlanJobStatusDone
+				passedJobs = append(passedJobs, job)
 				changed = true
 			}
 			if checkReleasePlanJobsAllDone(plan) {
-				plan.SuccessTime = time.Now().Unix()
-				plan.Status = config.ReleasePlanStatusSuccess
+				plan.Status = releasePlanDoneStatus(plan)
+				if plan.Status == config.ReleasePlanStatusSuccess {
+					plan.SuccessTime = time.Now().Unix()
+				}
 				changed = true
 				done = true
 			}
 		}
 	}
 
+	// the inverse workflows of a rollback plan run one after another
+	if plan.RollbackFromPlanID != "" && changed && advanceReleasePlanRollback(plan, passedJobs, log) {
+		done = true
+	}
+
 	if time.Now().Unix() > plan.EndTime && plan.EndTime != 0 {
 		plan.Status = config.ReleasePlanStatusTimeoutForWindow
 		changed = true
@@ -128,9 +137,11 @@ func updatePlanWorkflowReleaseJob(plan *models.ReleasePlan, log *zap.SugaredLogg
 		}
 
 		if done {
-			nextStatus, shouldWait := waitForExternalCheck(plan, hookSetting)
-			if shouldWait {
-				plan.Status = *nextStatus
+			if plan.Status == config.ReleasePlanStatusSuccess {
+				nextStatus, shouldWait := waitForExternalCheck(plan, hookSetting)
+				if shouldWait {
+					plan.Status = *nextStatus
+				}
 			}
 
 			sendWebhook = true
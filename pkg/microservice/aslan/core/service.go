@@ -34,6 +34,8 @@ import (
 	commonconfig "github.com/koderover/zadig/v2/pkg/config"
 	configbase "github.com/koderover/zadig/v2/pkg/config"
 	"github.com/koderover/zadig/v2/pkg/microservice/aslan/config"
+	applicationservice "github.com/koderover/zadig/v2/pkg/microservice/aslan/core/application/service"
+	costservice "github.com/koderover/zadig/v2/pkg/microservice/aslan/core/common/service/cost"
 	"github.com/koderover/zadig/v2/pkg/microservice/aslan/core/common/service/kube"
 	"github.com/koderover/zadig/v2/pkg/microservice/aslan/core/common/service/webhook"
 	"github.com/koderover/zadig/v2/pkg/microservice/aslan/core/common/service/workflowcontroller"
@@ -43,6 +45,7 @@ import (
 	releaseplanservice "github.com/koderover/zadig/v2/pkg/microservice/aslan/core/release_plan/service"
 	sprintservice "github.com/koderover/zadig/v2/pkg/microservice/aslan/core/sprint_management/service"
 	systemservice "github.com/koderover/zadig/v2/pkg/microservice/aslan/core/system/service"
+	vmservice "github.com/koderover/zadig/v2/pkg/microservice/aslan/core/vm/service"
 	hubserverconfig "github.com/koderover/zadig/v2/pkg/microservice/hubserver/config"
 	"github.com/koderover/zadig/v2/pkg/microservice/hubserver/core/repository/mongodb"
 	mongodb2 "github.com/koderover/zadig/v2/pkg/microservice/systemconfig/core/codehost/repository/mongodb"
@@ -55,12 +58,15 @@ import (
 	"github.com/koderover/zadig/v2/pkg/tool/log"
 	mongotool "github.com/koderover/zadig/v2/pkg/tool/mongo"
 	"github.com/koderover/zadig/v2/pkg/tool/rsa"
+	"github.com/koderover/zadig/v2/pkg/tool/tracing"
 )
 
 const (
 	webhookController = iota
 )
 
+var shutdownTracing = func(context.Context) error { return nil }
+
 type Controller interface {
 	Run(workers int, stopCh <-chan struct{})
 }
@@ -120,6 +126,12 @@ func Start(ctx context.Context) {
 		Development: commonconfig.Mode() != setting.ReleaseMode,
 	})
 
+	if shutdown, err := tracing.Init("aslan"); err != nil {
+		log.Errorf("failed to init tracing, err: %s", err)
+	} else {
+		shutdownTracing = shutdown
+	}
+
 	start := time.Now().UnixMilli()
 	initDatabaseConnection()
 	log.Debugf("init database connection took %s milli seconds", time.Now().UnixMilli()-start)
@@ -158,6 +170,8 @@ func Start(ctx context.Context) {
 
 	go multiclusterservice.ClusterApplyUpgrade()
 
+	go systemservice.StartAuditLogExporter()
+
 	initRsaKey()
 
 	log.Debugf("initRsaKey took %s milli seconds", time.Now().UnixMilli()-start)
@@ -174,6 +188,7 @@ func Start(ctx context.Context) {
 }
 
 func Stop(ctx context.Context) {
+	_ = shutdownTracing(ctx)
 	mongotool.Close(ctx)
 	gormtool.Close()
 }
@@ -208,6 +223,14 @@ func initCron() {
 
 	Scheduler.NewJob(newgoCron.DailyJob(1, newgoCron.NewAtTimes(newgoCron.NewAtTime(4, 0, 0))), newgoCron.NewTask(cleanCacheFiles))
 
+	Scheduler.NewJob(newgoCron.DailyJob(1, newgoCron.NewAtTimes(newgoCron.NewAtTime(4, 0, 0))), newgoCron.NewTask(systemservice.CleanExpiredOperationLogs))
+
+	Scheduler.NewJob(newgoCron.DurationJob(30*time.Second), newgoCron.NewTask(vmservice.ScaleAgentPools))
+
+	Scheduler.NewJob(newgoCron.DurationJob(time.Hour), newgoCron.NewTask(applicationservice.EvaluateApplicationScorecards))
+
+	Scheduler.NewJob(newgoCron.DurationJob(time.Hour), newgoCron.NewTask(costservice.CollectEnvironmentCosts))
+
 	Scheduler.Start()
 }
 
@@ -366,4 +389,10 @@ func initEventBusSubscription() {
 
 	eb.RegisterHandleFunc(setting.EventBusChannelClusterUpdate, kube.UpdateClusterHandler)
 	eb.Subscribe(context.Background(), setting.EventBusChannelClusterUpdate)
+
+	eb.RegisterHandleFunc(setting.EventBusChannelVMJobCreated, vmservice.VMJobCreatedHandler)
+	eb.Subscribe(context.Background(), setting.EventBusChannelVMJobCreated)
+
+	eb.RegisterHandleFunc(setting.EventBusChannelVMJobCancelled, vmservice.VMJobCancelledHandler)
+	eb.Subscribe(context.Background(), setting.EventBusChannelVMJobCancelled)
 }
@@ -0,0 +1,124 @@
+/*
+Copyright 2025 The KodeRover Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package handler
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/gin-gonic/gin"
+
+	commonutil "github.com/koderover/zadig/v2/pkg/microservice/aslan/core/common/util"
+	svcservice "github.com/koderover/zadig/v2/pkg/microservice/aslan/core/service/service"
+	internalhandler "github.com/koderover/zadig/v2/pkg/shared/handler"
+	e "github.com/koderover/zadig/v2/pkg/tool/errors"
+	"github.com/koderover/zadig/v2/pkg/types"
+)
+
+// @summary 从代码库加载Kustomize服务
+// @description
+// @tags 	service
+// @accept 	json
+// @produce json
+// @Param   production 		query 		bool 								true 	"production"
+// @Param 	body 			body 		svcservice.LoadKustomizeServiceReq  true 	"body"
+// @success 200             {object}    svcservice.ServiceOption
+// @router /api/aslan/service/kustomize [post]
+func LoadKustomizeService(c *gin.Context) {
+	loadKustomizeService(c, false)
+}
+
+// @summary 重新加载Kustomize服务
+// @description
+// @tags 	service
+// @accept 	json
+// @produce json
+// @Param   name 			path 		string 								true 	"service name"
+// @Param   production 		query 		bool 								true 	"production"
+// @Param 	body 			body 		svcservice.LoadKustomizeServiceReq  true 	"body"
+// @success 200             {object}    svcservice.ServiceOption
+// @router /api/aslan/service/kustomize/:name [put]
+func ReloadKustomizeService(c *gin.Context) {
+	loadKustomizeService(c, true)
+}
+
+func loadKustomizeService(c *gin.Context, reload bool) {
+	ctx, err := internalhandler.NewContextWithAuthorization(c)
+	defer func() { internalhandler.JSONResponse(c, ctx) }()
+
+	if err != nil {
+		ctx.RespErr = fmt.Errorf("authorization Info Generation failed: err %s", err)
+		ctx.UnAuthorized = true
+		return
+	}
+
+	args := new(svcservice.LoadKustomizeServiceReq)
+	if err := c.ShouldBindJSON(args); err != nil {
+		ctx.RespErr = e.ErrInvalidParam.AddErr(err)
+		return
+	}
+	if reload {
+		args.ServiceName = c.Param("name")
+	}
+
+	production := c.Query("production") == "true"
+	function := "项目管理-服务"
+	if production {
+		function = "项目管理-生产服务"
+	}
+	method := "新增"
+	if reload {
+		method = "更新"
+	}
+
+	bs, _ := json.Marshal(args)
+	detail := fmt.Sprintf("服务名称:%s", args.ServiceName)
+	detailEn := fmt.Sprintf("Service Name: %s", args.ServiceName)
+	internalhandler.InsertOperationLog(c, ctx.UserName, args.ProductName, method, function, detail, detailEn, string(bs), types.RequestBodyTypeJSON, ctx.Logger)
+
+	projectName := args.ProductName
+	if !ctx.Resources.IsSystemAdmin {
+		if _, ok := ctx.Resources.ProjectAuthInfo[projectName]; !ok {
+			ctx.UnAuthorized = true
+			return
+		}
+		if production {
+			if !ctx.Resources.ProjectAuthInfo[projectName].IsProjectAdmin &&
+				!(reload && ctx.Resources.ProjectAuthInfo[projectName].ProductionService.Edit) &&
+				!(!reload && ctx.Resources.ProjectAuthInfo[projectName].ProductionService.Create) {
+				ctx.UnAuthorized = true
+				return
+			}
+		} else {
+			if !ctx.Resources.ProjectAuthInfo[projectName].IsProjectAdmin &&
+				!(reload && ctx.Resources.ProjectAuthInfo[projectName].Service.Edit) &&
+				!(!reload && ctx.Resources.ProjectAuthInfo[projectName].Service.Create) {
+				ctx.UnAuthorized = true
+				return
+			}
+		}
+	}
+
+	if production {
+		if err := commonutil.CheckZadigProfessionalLicense(); err != nil {
+			ctx.RespErr = err
+			return
+		}
+	}
+
+	ctx.Resp, ctx.RespErr = svcservice.LoadKustomizeService(ctx.UserName, args, reload, production, ctx.Logger)
+}
@@ -0,0 +1,184 @@
+/*
+Copyright 2025 The KodeRover Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package handler
+
+import (
+	"fmt"
+
+	"github.com/gin-gonic/gin"
+
+	commonmodels "github.com/koderover/zadig/v2/pkg/microservice/aslan/core/common/repository/models"
+	"github.com/koderover/zadig/v2/pkg/microservice/aslan/core/common/service/mockservice"
+	internalhandler "github.com/koderover/zadig/v2/pkg/shared/handler"
+	e "github.com/koderover/zadig/v2/pkg/tool/errors"
+	"github.com/koderover/zadig/v2/pkg/types"
+)
+
+// @Summary Get Service Mock
+// @Description Get the latest revision of the mock of the service, null is returned if the service has no mock
+// @Tags 	service
+// @Accept 	json
+// @Produce json
+// @Param 	name			path		string							true	"service name"
+// @Param 	projectName		query		string							true	"project name"
+// @Success 200 			{object} 	commonmodels.MockService
+// @Router /api/aslan/service/services/{name}/mock [get]
+func GetServiceMock(c *gin.Context) {
+	ctx, err := internalhandler.NewContextWithAuthorization(c)
+	defer func() { internalhandler.JSONResponse(c, ctx) }()
+
+	if err != nil {
+		ctx.RespErr = fmt.Errorf("authorization Info Generation failed: err %s", err)
+		ctx.UnAuthorized = true
+		return
+	}
+
+	projectKey := c.Query("projectName")
+
+	// authorization checks
+	if !ctx.Resources.IsSystemAdmin {
+		if _, ok := ctx.Resources.ProjectAuthInfo[projectKey]; !ok {
+			ctx.UnAuthorized = true
+			return
+		}
+		if !ctx.Resources.ProjectAuthInfo[projectKey].IsProjectAdmin &&
+			!ctx.Resources.ProjectAuthInfo[projectKey].Service.View {
+			ctx.UnAuthorized = true
+			return
+		}
+	}
+
+	serviceName := c.Param("name")
+	if serviceName == "" {
+		ctx.RespErr = e.ErrInvalidParam.AddDesc("empty service name")
+		return
+	}
+
+	resp, err := mockservice.GetServiceMock(projectKey, serviceName)
+	if err != nil {
+		ctx.RespErr = e.ErrGetMockService.AddErr(err)
+		return
+	}
+	ctx.Resp = resp
+}
+
+// @Summary List Service Mock Revisions
+// @Description List the revisions of the mock of the service, the latest first
+// @Tags 	service
+// @Accept 	json
+// @Produce json
+// @Param 	name			path		string							true	"service name"
+// @Param 	projectName		query		string							true	"project name"
+// @Success 200 			{array} 	commonmodels.MockService
+// @Router /api/aslan/service/services/{name}/mock/revisions [get]
+func ListServiceMockRevisions(c *gin.Context) {
+	ctx, err := internalhandler.NewContextWithAuthorization(c)
+	defer func() { internalhandler.JSONResponse(c, ctx) }()
+
+	if err != nil {
+		ctx.RespErr = fmt.Errorf("authorization Info Generation failed: err %s", err)
+		ctx.UnAuthorized = true
+		return
+	}
+
+	projectKey := c.Query("projectName")
+
+	// authorization checks
+	if !ctx.Resources.IsSystemAdmin {
+		if _, ok := ctx.Resources.ProjectAuthInfo[projectKey]; !ok {
+			ctx.UnAuthorized = true
+			return
+		}
+		if !ctx.Resources.ProjectAuthInfo[projectKey].IsProjectAdmin &&
+			!ctx.Resources.ProjectAuthInfo[projectKey].Service.View {
+			ctx.UnAuthorized = true
+			return
+		}
+	}
+
+	serviceName := c.Param("name")
+	if serviceName == "" {
+		ctx.RespErr = e.ErrInvalidParam.AddDesc("empty service name")
+		return
+	}
+
+	resp, err := mockservice.ListServiceMockRevisions(projectKey, serviceName)
+	if err != nil {
+		ctx.RespErr = e.ErrGetMockService.AddErr(err)
+		return
+	}
+	ctx.Resp = resp
+}
+
+// @Summary Update Service Mock
+// @Description Save the mock of the service as a new revision
+// @Tags 	service
+// @Accept 	json
+// @Produce json
+// @Param 	name			path		string							true	"service name"
+// @Param 	projectName		query		string							true	"project name"
+// @Param 	body 			body 		commonmodels.MockService 		true 	"body"
+// @Success 200 			{object} 	commonmodels.MockService
+// @Router /api/aslan/service/services/{name}/mock [put]
+func UpdateServiceMock(c *gin.Context) {
+	ctx, err := internalhandler.NewContextWithAuthorization(c)
+	defer func() { internalhandler.JSONResponse(c, ctx) }()
+
+	if err != nil {
+		ctx.RespErr = fmt.Errorf("authorization Info Generation failed: err %s", err)
+		ctx.UnAuthorized = true
+		return
+	}
+
+	req := new(commonmodels.MockService)
+	if err := c.ShouldBindJSON(req); err != nil {
+		ctx.RespErr = e.ErrInvalidParam.AddErr(err)
+		return
+	}
+
+	projectKey := c.Query("projectName")
+
+	// authorization checks
+	if !ctx.Resources.IsSystemAdmin {
+		if _, ok := ctx.Resources.ProjectAuthInfo[projectKey]; !ok {
+			ctx.UnAuthorized = true
+			return
+		}
+		if !ctx.Resources.ProjectAuthInfo[projectKey].IsProjectAdmin &&
+			!ctx.Resources.ProjectAuthInfo[projectKey].Service.Edit {
+			ctx.UnAuthorized = true
+			return
+		}
+	}
+
+	serviceName := c.Param("name")
+	if serviceName == "" {
+		ctx.RespErr = e.ErrInvalidParam.AddDesc("empty service name")
+		return
+	}
+
+	detail := fmt.Sprintf("服务名称:%s", serviceName)
+	detailEn := fmt.Sprintf("Service Name: %s", serviceName)
+	internalhandler.InsertOperationLog(c, ctx.UserName, projectKey, "更新", "项目管理-服务Mock", detail, detailEn, "", types.RequestBodyTypeJSON, ctx.Logger)
+
+	resp, err := mockservice.UpdateServiceMock(projectKey, serviceName, req, ctx.UserName)
+	if err != nil {
+		ctx.RespErr = e.ErrUpdateMockService.AddErr(err)
+		return
+	}
+	ctx.Resp = resp
+}
@@ -51,6 +51,11 @@ func (*Router) Inject(router *gin.RouterGroup) {
 		k8s.GET("/:name", GetServiceTemplateOption)
 		k8s.POST("", GetServiceTemplateProductName, CreateServiceTemplate)
 		k8s.PUT("/:name/variable", UpdateServiceVariable)
+		k8s.GET("/:name/dependencies", GetServiceDependencies)
+		k8s.PUT("/:name/dependencies", UpdateServiceDependencies)
+		k8s.GET("/:name/mock", GetServiceMock)
+		k8s.PUT("/:name/mock", UpdateServiceMock)
+		k8s.GET("/:name/mock/revisions", ListServiceMockRevisions)
 		k8s.PUT("", UpdateServiceTemplate)
 		k8s.PUT("/yaml/validator", YamlValidator)
 		k8s.DELETE("/:name/:type", DeleteServiceTemplate)
@@ -85,6 +90,12 @@ func (*Router) Inject(router *gin.RouterGroup) {
 		loader.PUT("/load/:codehostId", SyncServiceTemplate)
 	}
 
+	kustomize := router.Group("kustomize")
+	{
+		kustomize.POST("", LoadKustomizeService)
+		kustomize.PUT("/:name", ReloadKustomizeService)
+	}
+
 	pm := router.Group("pm")
 	{
 		pm.PUT("/healthCheckUpdate", UpdateServiceHealthCheckStatus)
@@ -0,0 +1,161 @@
+/*
+Copyright 2025 The KodeRover Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package handler
+
+import (
+	"fmt"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/koderover/zadig/v2/pkg/microservice/aslan/core/common/service/servicedependency"
+	commonutil "github.com/koderover/zadig/v2/pkg/microservice/aslan/core/common/util"
+	internalhandler "github.com/koderover/zadig/v2/pkg/shared/handler"
+	e "github.com/koderover/zadig/v2/pkg/tool/errors"
+	"github.com/koderover/zadig/v2/pkg/types"
+)
+
+type updateServiceDependenciesRequest struct {
+	Dependencies []string `json:"dependencies"`
+}
+
+// @Summary Get Service Dependencies
+// @Description Get the declared and inferred dependencies of the service, and the services affected when it changes
+// @Tags 	service
+// @Accept 	json
+// @Produce json
+// @Param 	name			path		string								true	"service name"
+// @Param 	projectName		query		string								true	"project name"
+// @Param 	production		query		bool								false	"is production"
+// @Success 200 			{object} 	servicedependency.ServiceDependencies
+// @Router /api/aslan/service/services/{name}/dependencies [get]
+func GetServiceDependencies(c *gin.Context) {
+	ctx, err := internalhandler.NewContextWithAuthorization(c)
+	defer func() { internalhandler.JSONResponse(c, ctx) }()
+
+	if err != nil {
+		ctx.RespErr = fmt.Errorf("authorization Info Generation failed: err %s", err)
+		ctx.UnAuthorized = true
+		return
+	}
+
+	projectKey := c.Query("projectName")
+	production := c.Query("production") == "true"
+
+	// authorization checks
+	if !ctx.Resources.IsSystemAdmin {
+		if _, ok := ctx.Resources.ProjectAuthInfo[projectKey]; !ok {
+			ctx.UnAuthorized = true
+			return
+		}
+		if production {
+			if !ctx.Resources.ProjectAuthInfo[projectKey].IsProjectAdmin &&
+				!ctx.Resources.ProjectAuthInfo[projectKey].ProductionService.View {
+				ctx.UnAuthorized = true
+				return
+			}
+		} else {
+			if !ctx.Resources.ProjectAuthInfo[projectKey].IsProjectAdmin &&
+				!ctx.Resources.ProjectAuthInfo[projectKey].Service.View {
+				ctx.UnAuthorized = true
+				return
+			}
+		}
+	}
+
+	serviceName := c.Param("name")
+	if serviceName == "" {
+		ctx.RespErr = e.ErrInvalidParam.AddDesc("empty service name")
+		return
+	}
+
+	ctx.Resp, ctx.RespErr = servicedependency.GetServiceDependencies(projectKey, serviceName, production)
+}
+
+// @Summary Update Service Dependencies
+// @Description Replace the declared dependencies of the service
+// @Tags 	service
+// @Accept 	json
+// @Produce json
+// @Param 	name			path		string								true	"service name"
+// @Param 	projectName		query		string								true	"project name"
+// @Param 	production		query		bool								false	"is production"
+// @Param 	body 			body 		updateServiceDependenciesRequest 	true 	"body"
+// @Success 200
+// @Router /api/aslan/service/services/{name}/dependencies [put]
+func UpdateServiceDependencies(c *gin.Context) {
+	ctx, err := internalhandler.NewContextWithAuthorization(c)
+	defer func() { internalhandler.JSONResponse(c, ctx) }()
+
+	if err != nil {
+		ctx.RespErr = fmt.Errorf("authorization Info Generation failed: err %s", err)
+		ctx.UnAuthorized = true
+		return
+	}
+
+	req := new(updateServiceDependenciesRequest)
+	if err := c.ShouldBindJSON(req); err != nil {
+		ctx.RespErr = e.ErrInvalidParam.AddErr(err)
+		return
+	}
+
+	projectKey := c.Query("projectName")
+	production := c.Query("production") == "true"
+	function := "项目管理-服务依赖"
+	if production {
+		function = "项目管理-生产服务依赖"
+	}
+
+	// authorization checks
+	if !ctx.Resources.IsSystemAdmin {
+		if _, ok := ctx.Resources.ProjectAuthInfo[projectKey]; !ok {
+			ctx.UnAuthorized = true
+			return
+		}
+		if production {
+			if !ctx.Resources.ProjectAuthInfo[projectKey].IsProjectAdmin &&
+				!ctx.Resources.ProjectAuthInfo[projectKey].ProductionService.Edit {
+				ctx.UnAuthorized = true
+				return
+			}
+		} else {
+			if !ctx.Resources.ProjectAuthInfo[projectKey].IsProjectAdmin &&
+				!ctx.Resources.ProjectAuthInfo[projectKey].Service.Edit {
+				ctx.UnAuthorized = true
+				return
+			}
+		}
+	}
+
+	if production {
+		if err := commonutil.CheckZadigProfessionalLicense(); err != nil {
+			ctx.RespErr = err
+			return
+		}
+	}
+
+	serviceName := c.Param("name")
+	if serviceName == "" {
+		ctx.RespErr = e.ErrInvalidParam.AddDesc("empty service name")
+		return
+	}
+
+	detail := fmt.Sprintf("服务名称:%s", serviceName)
+	detailEn := fmt.Sprintf("Service Name: %s", serviceName)
+	internalhandler.InsertOperationLog(c, ctx.UserName, projectKey, "更新", function, detail, detailEn, "", types.RequestBodyTypeJSON, ctx.Logger)
+
+	ctx.RespErr = servicedependency.UpdateServiceDependencies(projectKey, serviceName, production, req.Dependencies, ctx.UserName)
+}
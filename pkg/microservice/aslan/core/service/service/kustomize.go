@@ -0,0 +1,155 @@
+/*
+Copyright 2025 The KodeRover Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package service
+
+import (
+	"fmt"
+	"io/fs"
+	"strings"
+
+	"github.com/27149chen/afero"
+	"go.uber.org/zap"
+
+	commonmodels "github.com/koderover/zadig/v2/pkg/microservice/aslan/core/common/repository/models"
+	commonrepo "github.com/koderover/zadig/v2/pkg/microservice/aslan/core/common/repository/mongodb"
+	fsservice "github.com/koderover/zadig/v2/pkg/microservice/aslan/core/common/service/fs"
+	"github.com/koderover/zadig/v2/pkg/microservice/aslan/core/common/service/repository"
+	"github.com/koderover/zadig/v2/pkg/setting"
+	e "github.com/koderover/zadig/v2/pkg/tool/errors"
+	"github.com/koderover/zadig/v2/pkg/tool/kustomize"
+)
+
+type LoadKustomizeServiceReq struct {
+	ServiceName    string                              `json:"service_name"`
+	ProductName    string                              `json:"product_name"`
+	CodehostID     int                                 `json:"codehost_id"`
+	RepoOwner      string                              `json:"repo_owner"`
+	RepoNamespace  string                              `json:"repo_namespace"`
+	RepoName       string                              `json:"repo_name"`
+	BranchName     string                              `json:"branch_name"`
+	LoadPath       string                              `json:"load_path"`
+	DefaultOverlay string                              `json:"default_overlay"`
+	EnvOverlays    []*commonmodels.KustomizeEnvOverlay `json:"env_overlays"`
+}
+
+// LoadKustomizeService loads the files under the load path of the repository and creates a k8s service from them,
+// the yaml of the service is built from the default overlay while each environment builds its own overlay when rendered.
+func LoadKustomizeService(username string, args *LoadKustomizeServiceReq, force, production bool, log *zap.SugaredLogger) (*ServiceOption, error) {
+	if args.ServiceName == "" || args.ProductName == "" {
+		return nil, e.ErrInvalidParam.AddDesc("service name and project name can't be empty")
+	}
+
+	tree, err := fsservice.DownloadFilesFromSource(
+		&fsservice.DownloadFromSourceArgs{CodehostID: args.CodehostID, Owner: args.RepoOwner, Namespace: args.RepoNamespace, Repo: args.RepoName, Path: args.LoadPath, Branch: args.BranchName},
+		func(afero.Fs) (string, error) {
+			return args.ServiceName, nil
+		})
+	if err != nil {
+		log.Errorf("Failed to download files of kustomize service %s, err: %s", args.ServiceName, err)
+		return nil, e.ErrLoadKustomizeService.AddErr(err)
+	}
+
+	files, err := readKustomizeFiles(tree, args.ServiceName)
+	if err != nil {
+		log.Errorf("Failed to read files of kustomize service %s, err: %s", args.ServiceName, err)
+		return nil, e.ErrLoadKustomizeService.AddErr(err)
+	}
+
+	overlays := []string{args.DefaultOverlay}
+	for _, overlay := range args.EnvOverlays {
+		overlays = append(overlays, overlay.Overlay)
+	}
+	for _, overlay := range overlays {
+		if !kustomize.IsKustomization(files, overlay) {
+			return nil, e.ErrLoadKustomizeService.AddDesc(fmt.Sprintf("kustomization not found in overlay %s", overlay))
+		}
+	}
+
+	manifests, err := kustomize.Build(files, args.DefaultOverlay, nil)
+	if err != nil {
+		log.Errorf("Failed to build default overlay of kustomize service %s, err: %s", args.ServiceName, err)
+		return nil, e.ErrBuildKustomizeService.AddErr(err)
+	}
+
+	svc := &commonmodels.Service{
+		ServiceName:   args.ServiceName,
+		ProductName:   args.ProductName,
+		Type:          setting.K8SDeployType,
+		Source:        setting.SourceFromKustomize,
+		Yaml:          manifests,
+		CodehostID:    args.CodehostID,
+		RepoOwner:     args.RepoOwner,
+		RepoNamespace: args.RepoNamespace,
+		RepoName:      args.RepoName,
+		BranchName:    args.BranchName,
+		LoadPath:      args.LoadPath,
+		LoadFromDir:   true,
+		Kustomize: &commonmodels.Kustomize{
+			DefaultOverlay: args.DefaultOverlay,
+			EnvOverlays:    args.EnvOverlays,
+			Files:          files,
+		},
+	}
+
+	if force {
+		// keep the variables and the environment settings of the current revision when the service is reloaded
+		current, err := repository.QueryTemplateService(&commonrepo.ServiceFindOption{
+			ProductName: args.ProductName,
+			ServiceName: args.ServiceName,
+		}, production)
+		if err == nil {
+			if current.Source != setting.SourceFromKustomize {
+				return nil, e.ErrLoadKustomizeService.AddDesc(fmt.Sprintf("service %s is not a kustomize service", args.ServiceName))
+			}
+			svc.VariableYaml = current.VariableYaml
+			svc.ServiceVariableKVs = current.ServiceVariableKVs
+			svc.EnvConfigs = current.EnvConfigs
+			svc.EnvStatuses = current.EnvStatuses
+		}
+	}
+
+	return CreateServiceTemplate(username, svc, force, production, log)
+}
+
+// readKustomizeFiles reads the files of the tree, the paths are relative to the load path.
+// The directory of the load path is renamed to the service name when it's the only entry of the tree.
+func readKustomizeFiles(tree fs.FS, serviceName string) ([]*kustomize.File, error) {
+	root := "."
+	if info, err := fs.Stat(tree, serviceName); err == nil && info.IsDir() {
+		root = serviceName
+	}
+
+	files := make([]*kustomize.File, 0)
+	err := fs.WalkDir(tree, root, func(p string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() {
+			return nil
+		}
+		content, err := fs.ReadFile(tree, p)
+		if err != nil {
+			return err
+		}
+		if root != "." {
+			p = strings.TrimPrefix(p, root+"/")
+		}
+		files = append(files, &kustomize.File{Path: p, Content: string(content)})
+		return nil
+	})
+	return files, err
+}
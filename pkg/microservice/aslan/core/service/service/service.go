@@ -45,6 +45,7 @@ import (
 	commonservice "github.com/koderover/zadig/v2/pkg/microservice/aslan/core/common/service"
 	"github.com/koderover/zadig/v2/pkg/microservice/aslan/core/common/service/fs"
 	"github.com/koderover/zadig/v2/pkg/microservice/aslan/core/common/service/kube"
+	"github.com/koderover/zadig/v2/pkg/microservice/aslan/core/common/service/mockservice"
 	"github.com/koderover/zadig/v2/pkg/microservice/aslan/core/common/service/pm"
 	"github.com/koderover/zadig/v2/pkg/microservice/aslan/core/common/service/repository"
 	commontypes "github.com/koderover/zadig/v2/pkg/microservice/aslan/core/common/types"
@@ -1057,6 +1058,14 @@ func DeleteServiceTemplate(serviceName, serviceType, productName string, product
 		}
 	}
 	commonservice.DeleteServiceWebhookByName(serviceName, productName, production, log)
+	if err := commonrepo.NewServiceDependencyColl().Delete(productName, serviceName, production); err != nil {
+		log.Errorf("failed to delete dependencies of service %s, error: %v", serviceName, err)
+	}
+	if !production {
+		if err := mockservice.DeleteServiceMock(productName, serviceName); err != nil {
+			log.Errorf("failed to delete the mock of service %s, error: %v", serviceName, err)
+		}
+	}
 	return nil
 }
 
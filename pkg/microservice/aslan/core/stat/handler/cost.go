@@ -0,0 +1,232 @@
+/*
+Copyright 2025 The KodeRover Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package handler
+
+import (
+	"fmt"
+
+	"github.com/gin-gonic/gin"
+
+	commonmodels "github.com/koderover/zadig/v2/pkg/microservice/aslan/core/common/repository/models"
+	"github.com/koderover/zadig/v2/pkg/microservice/aslan/core/stat/service"
+	internalhandler "github.com/koderover/zadig/v2/pkg/shared/handler"
+	e "github.com/koderover/zadig/v2/pkg/tool/errors"
+	"github.com/koderover/zadig/v2/pkg/types"
+)
+
+type getCostStatReq struct {
+	StartTime int64                 `json:"startDate" form:"startDate"`
+	EndTime   int64                 `json:"endDate"   form:"endDate"`
+	Projects  []string              `json:"projects"  form:"projects"`
+	Dimension service.CostDimension `json:"dimension" form:"dimension"`
+}
+
+// @Summary 获取资源成本统计
+// @Description 获取环境和构建、测试任务的资源成本，以及每日趋势
+// @Tags 	stat
+// @Accept 	json
+// @Produce json
+// @Param 	startDate		query		int								false	"开始时间，格式为时间戳，默认为 30 天前"
+// @Param 	endDate			query		int								false	"结束时间，格式为时间戳，默认为当前时间"
+// @Param 	projects		query		[]string						false	"项目列表"
+// @Param 	dimension		query		string							false	"统计维度，可选值为 project、env、service、category，默认为 project"
+// @Success 200 			{object} 	service.CostStatResponse
+// @Router /api/aslan/stat/v2/cost [get]
+func GetCostStats(c *gin.Context) {
+	ctx := internalhandler.NewContext(c)
+	defer func() { internalhandler.JSONResponse(c, ctx) }()
+
+	args := new(getCostStatReq)
+	if err := c.ShouldBindQuery(args); err != nil {
+		ctx.RespErr = e.ErrInvalidParam.AddErr(err)
+		return
+	}
+
+	ctx.Resp, ctx.RespErr = service.GetCostStats(args.StartTime, args.EndTime, args.Projects, args.Dimension, ctx.Logger)
+}
+
+// @Summary 获取成本单价配置
+// @Description
+// @Tags 	stat
+// @Accept 	json
+// @Produce json
+// @Success 200 			{object} 	commonmodels.CostPricing
+// @Router /api/aslan/stat/v2/cost/pricing [get]
+func GetCostPricing(c *gin.Context) {
+	ctx := internalhandler.NewContext(c)
+	defer func() { internalhandler.JSONResponse(c, ctx) }()
+
+	ctx.Resp, ctx.RespErr = service.GetCostPricing(ctx.Logger)
+}
+
+// @Summary 更新成本单价配置
+// @Description
+// @Tags 	stat
+// @Accept 	json
+// @Produce json
+// @Param 	body 			body 		commonmodels.CostPricing 		true 	"body"
+// @Success 200
+// @Router /api/aslan/stat/v2/cost/pricing [put]
+func UpdateCostPricing(c *gin.Context) {
+	ctx, err := internalhandler.NewContextWithAuthorization(c)
+	defer func() { internalhandler.JSONResponse(c, ctx) }()
+
+	if err != nil {
+		ctx.RespErr = fmt.Errorf("authorization Info Generation failed: err %s", err)
+		ctx.UnAuthorized = true
+		return
+	}
+
+	if !ctx.Resources.IsSystemAdmin {
+		ctx.UnAuthorized = true
+		return
+	}
+
+	args := new(commonmodels.CostPricing)
+	if err := c.ShouldBindJSON(args); err != nil {
+		ctx.RespErr = e.ErrInvalidParam.AddErr(err)
+		return
+	}
+
+	internalhandler.InsertOperationLog(c, ctx.UserName, "", "更新", "成本统计-单价配置", "", "", "", types.RequestBodyTypeJSON, ctx.Logger)
+
+	args.UpdateBy = ctx.UserName
+	ctx.RespErr = service.UpdateCostPricing(args, ctx.Logger)
+}
+
+type listCostBudgetReq struct {
+	Projects []string `form:"projects"`
+}
+
+// @Summary 获取成本预算列表
+// @Description
+// @Tags 	stat
+// @Accept 	json
+// @Produce json
+// @Param 	projects		query		[]string						false	"项目列表"
+// @Success 200 			{array} 	commonmodels.CostBudget
+// @Router /api/aslan/stat/v2/cost/budgets [get]
+func ListCostBudgets(c *gin.Context) {
+	ctx := internalhandler.NewContext(c)
+	defer func() { internalhandler.JSONResponse(c, ctx) }()
+
+	args := new(listCostBudgetReq)
+	if err := c.ShouldBindQuery(args); err != nil {
+		ctx.RespErr = e.ErrInvalidParam.AddErr(err)
+		return
+	}
+
+	ctx.Resp, ctx.RespErr = service.ListCostBudgets(args.Projects, ctx.Logger)
+}
+
+// @Summary 创建成本预算
+// @Description 成本预算按月计算，当月成本达到阈值时通过通知发送告警
+// @Tags 	stat
+// @Accept 	json
+// @Produce json
+// @Param 	body 			body 		commonmodels.CostBudget 		true 	"body"
+// @Success 200
+// @Router /api/aslan/stat/v2/cost/budgets [post]
+func CreateCostBudget(c *gin.Context) {
+	ctx, err := internalhandler.NewContextWithAuthorization(c)
+	defer func() { internalhandler.JSONResponse(c, ctx) }()
+
+	if err != nil {
+		ctx.RespErr = fmt.Errorf("authorization Info Generation failed: err %s", err)
+		ctx.UnAuthorized = true
+		return
+	}
+
+	if !ctx.Resources.IsSystemAdmin {
+		ctx.UnAuthorized = true
+		return
+	}
+
+	args := new(commonmodels.CostBudget)
+	if err := c.ShouldBindJSON(args); err != nil {
+		ctx.RespErr = e.ErrInvalidParam.AddErr(err)
+		return
+	}
+
+	internalhandler.InsertOperationLog(c, ctx.UserName, args.ProjectKey, "新增", "成本统计-预算", args.Name, args.Name, "", types.RequestBodyTypeJSON, ctx.Logger)
+
+	args.CreatedBy = ctx.UserName
+	ctx.RespErr = service.CreateCostBudget(args, ctx.Logger)
+}
+
+// @Summary 更新成本预算
+// @Description
+// @Tags 	stat
+// @Accept 	json
+// @Produce json
+// @Param 	id 				path 		string 							true 	"预算 ID"
+// @Param 	body 			body 		commonmodels.CostBudget 		true 	"body"
+// @Success 200
+// @Router /api/aslan/stat/v2/cost/budgets/{id} [put]
+func UpdateCostBudget(c *gin.Context) {
+	ctx, err := internalhandler.NewContextWithAuthorization(c)
+	defer func() { internalhandler.JSONResponse(c, ctx) }()
+
+	if err != nil {
+		ctx.RespErr = fmt.Errorf("authorization Info Generation failed: err %s", err)
+		ctx.UnAuthorized = true
+		return
+	}
+
+	if !ctx.Resources.IsSystemAdmin {
+		ctx.UnAuthorized = true
+		return
+	}
+
+	args := new(commonmodels.CostBudget)
+	if err := c.ShouldBindJSON(args); err != nil {
+		ctx.RespErr = e.ErrInvalidParam.AddErr(err)
+		return
+	}
+
+	internalhandler.InsertOperationLog(c, ctx.UserName, args.ProjectKey, "更新", "成本统计-预算", args.Name, args.Name, "", types.RequestBodyTypeJSON, ctx.Logger)
+
+	ctx.RespErr = service.UpdateCostBudget(c.Param("id"), args, ctx.Logger)
+}
+
+// @Summary 删除成本预算
+// @Description
+// @Tags 	stat
+// @Accept 	json
+// @Produce json
+// @Param 	id 				path 		string 							true 	"预算 ID"
+// @Success 200
+// @Router /api/aslan/stat/v2/cost/budgets/{id} [delete]
+func DeleteCostBudget(c *gin.Context) {
+	ctx, err := internalhandler.NewContextWithAuthorization(c)
+	defer func() { internalhandler.JSONResponse(c, ctx) }()
+
+	if err != nil {
+		ctx.RespErr = fmt.Errorf("authorization Info Generation failed: err %s", err)
+		ctx.UnAuthorized = true
+		return
+	}
+
+	if !ctx.Resources.IsSystemAdmin {
+		ctx.UnAuthorized = true
+		return
+	}
+
+	internalhandler.InsertOperationLog(c, ctx.UserName, "", "删除", "成本统计-预算", c.Param("id"), c.Param("id"), "", types.RequestBodyTypeJSON, ctx.Logger)
+
+	ctx.RespErr = service.DeleteCostBudget(c.Param("id"), ctx.Logger)
+}
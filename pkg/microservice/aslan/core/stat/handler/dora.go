@@ -0,0 +1,103 @@
+/*
+Copyright 2025 The KodeRover Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package handler
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/koderover/zadig/v2/pkg/microservice/aslan/core/stat/service"
+	internalhandler "github.com/koderover/zadig/v2/pkg/shared/handler"
+	e "github.com/koderover/zadig/v2/pkg/tool/errors"
+)
+
+func CreateWeeklyDoraStat(c *gin.Context) {
+	ctx := internalhandler.NewContext(c)
+	defer func() { internalhandler.JSONResponse(c, ctx) }()
+
+	ctx.RespErr = service.CreateWeeklyDoraStat(ctx.Logger)
+}
+
+type getDoraStatReq struct {
+	StartTime int64                 `json:"startDate" form:"startDate"`
+	EndTime   int64                 `json:"endDate"   form:"endDate"`
+	Projects  []string              `json:"projects"  form:"projects"`
+	Dimension service.DoraDimension `json:"dimension" form:"dimension"`
+	Format    string                `json:"format"    form:"format"`
+}
+
+// @Summary 获取 DORA 指标
+// @Description 获取生产环境的部署频率、变更前置时间、变更失败率和平均恢复时间，以及周趋势
+// @Tags 	stat
+// @Accept 	json
+// @Produce json
+// @Param 	startDate		query		int								true	"开始时间，格式为时间戳"
+// @Param 	endDate			query		int								true	"结束时间，格式为时间戳"
+// @Param 	projects		query		[]string						false	"项目列表"
+// @Param 	dimension		query		string							false	"统计维度，可选值为 project、team、service，默认为 project"
+// @Success 200 			{object} 	service.DoraStatResponse
+// @Router /api/aslan/stat/v2/quality/dora [get]
+func GetDoraStats(c *gin.Context) {
+	ctx := internalhandler.NewContext(c)
+	defer func() { internalhandler.JSONResponse(c, ctx) }()
+
+	args := new(getDoraStatReq)
+	if err := c.ShouldBindQuery(args); err != nil {
+		ctx.RespErr = e.ErrInvalidParam.AddErr(err)
+		return
+	}
+
+	ctx.Resp, ctx.RespErr = service.GetDoraStats(args.StartTime, args.EndTime, args.Projects, args.Dimension, ctx.Logger)
+}
+
+// @Summary 导出 DORA 指标
+// @Description
+// @Tags 	stat
+// @Accept 	json
+// @Produce octet-stream
+// @Param 	startDate		query		int								true	"开始时间，格式为时间戳"
+// @Param 	endDate			query		int								true	"结束时间，格式为时间戳"
+// @Param 	projects		query		[]string						false	"项目列表"
+// @Param 	dimension		query		string							false	"统计维度，可选值为 project、team、service，默认为 project"
+// @Param 	format			query		string							false	"导出格式，可选值为 csv、json，默认为 csv"
+// @Success 200
+// @Router /api/aslan/stat/v2/quality/dora/export [get]
+func ExportDoraStats(c *gin.Context) {
+	ctx := internalhandler.NewContext(c)
+	defer func() { internalhandler.JSONResponse(c, ctx) }()
+
+	args := new(getDoraStatReq)
+	if err := c.ShouldBindQuery(args); err != nil {
+		ctx.RespErr = e.ErrInvalidParam.AddErr(err)
+		return
+	}
+
+	fileBytes, fileName, err := service.ExportDoraStats(args.StartTime, args.EndTime, args.Projects, args.Dimension, args.Format, ctx.Logger)
+	if err != nil {
+		ctx.RespErr = err
+		return
+	}
+
+	contentType := "text/csv; charset=utf-8"
+	if args.Format == "json" {
+		contentType = "application/json; charset=utf-8"
+	}
+	c.Writer.Header().Set("Content-Disposition", fmt.Sprintf(`attachment; filename="%s"`, fileName))
+	c.Data(http.StatusOK, contentType, fileBytes)
+}
@@ -114,6 +114,13 @@ func (*Router) Inject(router *gin.RouterGroup) {
 		rollbackV2.GET("/stat", GetRollbackStat)
 	}
 
+	doraV2 := qualityV2.Group("dora")
+	{
+		doraV2.POST("/weekly", CreateWeeklyDoraStat)
+		doraV2.GET("", GetDoraStats)
+		doraV2.GET("/export", ExportDoraStats)
+	}
+
 	testV2 := qualityV2.Group("test")
 	{
 		testV2.GET("/count", GetTestCount)
@@ -121,6 +128,17 @@ func (*Router) Inject(router *gin.RouterGroup) {
 		testV2.GET("/recentTask", GetRecentTestTask)
 	}
 
+	costV2 := v2.Group("cost")
+	{
+		costV2.GET("", GetCostStats)
+		costV2.GET("/pricing", GetCostPricing)
+		costV2.PUT("/pricing", UpdateCostPricing)
+		costV2.GET("/budgets", ListCostBudgets)
+		costV2.POST("/budgets", CreateCostBudget)
+		costV2.PUT("/budgets/:id", UpdateCostBudget)
+		costV2.DELETE("/budgets/:id", DeleteCostBudget)
+	}
+
 }
 
 type OpenAPIRouter struct{}
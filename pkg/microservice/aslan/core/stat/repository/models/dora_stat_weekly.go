@@ -0,0 +1,38 @@
+/*
+Copyright 2025 The KodeRover Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package models
+
+// WeeklyDoraStat is the raw material of the DORA metrics of a service in production envs for a week,
+// the sums and counts are stored so that the metrics can be aggregated by project, team or service.
+type WeeklyDoraStat struct {
+	ProjectKey         string `bson:"project_key"                       json:"project_key"`
+	ServiceName        string `bson:"service_name"                      json:"service_name"`
+	DeployCount        int    `bson:"deploy_count"                      json:"deploy_count"`
+	FailedDeployCount  int    `bson:"failed_deploy_count"               json:"failed_deploy_count"`
+	ChangeFailureCount int    `bson:"change_failure_count"              json:"change_failure_count"`
+	LeadTimeTotal      int64  `bson:"lead_time_total"                   json:"lead_time_total"`
+	LeadTimeCount      int    `bson:"lead_time_count"                   json:"lead_time_count"`
+	RecoveryTimeTotal  int64  `bson:"recovery_time_total"               json:"recovery_time_total"`
+	RecoveryCount      int    `bson:"recovery_count"                    json:"recovery_count"`
+	Date               string `bson:"date"                              json:"date"`
+	CreateTime         int64  `bson:"create_time"                       json:"create_time"`
+	UpdateTime         int64  `bson:"update_time"                       json:"update_time"`
+}
+
+func (WeeklyDoraStat) TableName() string {
+	return "dora_stat_weekly"
+}
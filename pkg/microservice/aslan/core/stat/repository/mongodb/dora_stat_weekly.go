@@ -0,0 +1,110 @@
+/*
+Copyright 2025 The KodeRover Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package mongodb
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+
+	"github.com/koderover/zadig/v2/pkg/microservice/aslan/config"
+	"github.com/koderover/zadig/v2/pkg/microservice/aslan/core/stat/repository/models"
+	mongotool "github.com/koderover/zadig/v2/pkg/tool/mongo"
+)
+
+type WeeklyDoraStatColl struct {
+	*mongo.Collection
+
+	coll string
+}
+
+func NewWeeklyDoraStatColl() *WeeklyDoraStatColl {
+	name := models.WeeklyDoraStat{}.TableName()
+	return &WeeklyDoraStatColl{Collection: mongotool.Database(config.MongoDatabase()).Collection(name), coll: name}
+}
+
+func (c *WeeklyDoraStatColl) GetCollectionName() string {
+	return c.coll
+}
+
+func (c *WeeklyDoraStatColl) EnsureIndex(ctx context.Context) error {
+	mod := []mongo.IndexModel{
+		{
+			Keys: bson.D{
+				bson.E{Key: "project_key", Value: 1},
+				bson.E{Key: "service_name", Value: 1},
+				bson.E{Key: "date", Value: 1},
+			},
+			Options: options.Index().SetUnique(true),
+		},
+		{
+			Keys: bson.D{
+				bson.E{Key: "create_time", Value: 1},
+			},
+			Options: options.Index().SetUnique(false),
+		},
+	}
+
+	_, err := c.Indexes().CreateMany(ctx, mod, mongotool.CreateIndexOptions(ctx))
+	return err
+}
+
+func (c *WeeklyDoraStatColl) Upsert(args *models.WeeklyDoraStat) error {
+	if args == nil {
+		return fmt.Errorf("upsert data cannot be empty for %s", c.coll)
+	}
+
+	args.UpdateTime = time.Now().Unix()
+
+	filter := bson.M{
+		"project_key":  args.ProjectKey,
+		"service_name": args.ServiceName,
+		"date":         args.Date,
+	}
+
+	update := bson.M{
+		"$set": args,
+	}
+
+	_, err := c.UpdateOne(context.TODO(), filter, update, options.Update().SetUpsert(true))
+	return err
+}
+
+// List returns the weekly stats of the given projects created within the time range, sorted by date
+func (c *WeeklyDoraStatColl) List(startTime, endTime int64, projects []string) ([]*models.WeeklyDoraStat, error) {
+	query := bson.M{
+		"create_time": bson.M{"$gte": startTime, "$lte": endTime},
+	}
+	if len(projects) > 0 {
+		query["project_key"] = bson.M{"$in": projects}
+	}
+
+	cursor, err := c.Find(context.TODO(), query, options.Find().SetSort(bson.D{bson.E{Key: "create_time", Value: 1}}))
+	if err != nil {
+		return nil, err
+	}
+
+	resp := make([]*models.WeeklyDoraStat, 0)
+	if err := cursor.All(context.TODO(), &resp); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
@@ -0,0 +1,294 @@
+/*
+Copyright 2025 The KodeRover Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package service
+
+import (
+	"fmt"
+	"sort"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/koderover/zadig/v2/pkg/microservice/aslan/config"
+	commonmodels "github.com/koderover/zadig/v2/pkg/microservice/aslan/core/common/repository/models"
+	commonrepo "github.com/koderover/zadig/v2/pkg/microservice/aslan/core/common/repository/mongodb"
+	"github.com/koderover/zadig/v2/pkg/microservice/aslan/core/common/service/cost"
+	e "github.com/koderover/zadig/v2/pkg/tool/errors"
+)
+
+type CostDimension string
+
+const (
+	CostDimensionProject  CostDimension = "project"
+	CostDimensionEnv      CostDimension = "env"
+	CostDimensionService  CostDimension = "service"
+	CostDimensionCategory CostDimension = "category"
+)
+
+const costDateLayout = "2006-01-02"
+
+type CostItem struct {
+	Key         string              `json:"key"`
+	ProjectKey  string              `json:"project_key,omitempty"`
+	EnvName     string              `json:"env_name,omitempty"`
+	Production  bool                `json:"production,omitempty"`
+	ServiceName string              `json:"service_name,omitempty"`
+	Category    config.CostCategory `json:"category,omitempty"`
+	// ConfigName is the name of the build or testing, only set for the job costs in service dimension
+	ConfigName       string  `json:"config_name,omitempty"`
+	CPUCost          float64 `json:"cpu_cost"`
+	MemoryCost       float64 `json:"memory_cost"`
+	StorageCost      float64 `json:"storage_cost"`
+	LoadBalancerCost float64 `json:"load_balancer_cost"`
+	TotalCost        float64 `json:"total_cost"`
+}
+
+type CostTrendItem struct {
+	Date      string  `json:"date"`
+	TotalCost float64 `json:"total_cost"`
+}
+
+type CostStatResponse struct {
+	Currency  string           `json:"currency"`
+	TotalCost float64          `json:"total_cost"`
+	Items     []*CostItem      `json:"items"`
+	Trend     []*CostTrendItem `json:"trend"`
+}
+
+func GetCostPricing(log *zap.SugaredLogger) (*commonmodels.CostPricing, error) {
+	pricing, err := commonrepo.NewCostPricingColl().Get()
+	if err != nil {
+		log.Errorf("failed to get cost pricing, error: %s", err)
+		return nil, e.ErrGetCostPricing.AddErr(err)
+	}
+	if pricing == nil {
+		pricing = &commonmodels.CostPricing{
+			UsageMode:    config.CostUsageModeRequest,
+			DefaultPrice: &commonmodels.NodeClassPrice{Name: "default"},
+			NodeClasses:  make([]*commonmodels.NodeClassPrice, 0),
+		}
+	}
+	return pricing, nil
+}
+
+func UpdateCostPricing(args *commonmodels.CostPricing, log *zap.SugaredLogger) error {
+	if err := validateCostPricing(args); err != nil {
+		return e.ErrUpdateCostPricing.AddErr(err)
+	}
+
+	args.UpdateTime = time.Now().Unix()
+	if err := commonrepo.NewCostPricingColl().Upsert(args); err != nil {
+		log.Errorf("failed to update cost pricing, error: %s", err)
+		return e.ErrUpdateCostPricing.AddErr(err)
+	}
+	return nil
+}
+
+func validateCostPricing(args *commonmodels.CostPricing) error {
+	switch args.UsageMode {
+	case "":
+		args.UsageMode = config.CostUsageModeRequest
+	case config.CostUsageModeRequest, config.CostUsageModeUsage, config.CostUsageModeMax:
+	default:
+		return fmt.Errorf("invalid usage mode: %s", args.UsageMode)
+	}
+
+	if args.DefaultPrice == nil {
+		return fmt.Errorf("default price is required")
+	}
+	if args.StorageGBHour < 0 || args.LoadBalancerHour < 0 {
+		return fmt.Errorf("prices can not be negative")
+	}
+
+	names := make(map[string]bool)
+	for _, class := range append([]*commonmodels.NodeClassPrice{args.DefaultPrice}, args.NodeClasses...) {
+		if class.CPUCoreHour < 0 || class.MemoryGBHour < 0 {
+			return fmt.Errorf("prices of node class %s can not be negative", class.Name)
+		}
+		if class == args.DefaultPrice {
+			continue
+		}
+		if class.Name == "" {
+			return fmt.Errorf("node class name is required")
+		}
+		if names[class.Name] {
+			return fmt.Errorf("duplicated node class: %s", class.Name)
+		}
+		names[class.Name] = true
+		if len(class.NodeSelector) == 0 {
+			return fmt.Errorf("node selector of node class %s is required", class.Name)
+		}
+	}
+	return nil
+}
+
+func ListCostBudgets(projects []string, log *zap.SugaredLogger) ([]*commonmodels.CostBudget, error) {
+	budgets, err := commonrepo.NewCostBudgetColl().List(projects)
+	if err != nil {
+		log.Errorf("failed to list cost budgets, error: %s", err)
+		return nil, e.ErrListCostBudget.AddErr(err)
+	}
+	return budgets, nil
+}
+
+func CreateCostBudget(args *commonmodels.CostBudget, log *zap.SugaredLogger) error {
+	if err := validateCostBudget(args); err != nil {
+		return e.ErrCreateCostBudget.AddErr(err)
+	}
+
+	args.CreateTime = time.Now().Unix()
+	args.UpdateTime = args.CreateTime
+	if err := commonrepo.NewCostBudgetColl().Create(args); err != nil {
+		log.Errorf("failed to create cost budget %s, error: %s", args.Name, err)
+		return e.ErrCreateCostBudget.AddErr(err)
+	}
+	return nil
+}
+
+func UpdateCostBudget(id string, args *commonmodels.CostBudget, log *zap.SugaredLogger) error {
+	if err := validateCostBudget(args); err != nil {
+		return e.ErrUpdateCostBudget.AddErr(err)
+	}
+
+	args.UpdateTime = time.Now().Unix()
+	if err := commonrepo.NewCostBudgetColl().Update(id, args); err != nil {
+		log.Errorf("failed to update cost budget %s, error: %s", id, err)
+		return e.ErrUpdateCostBudget.AddErr(err)
+	}
+	return nil
+}
+
+func DeleteCostBudget(id string, log *zap.SugaredLogger) error {
+	if err := commonrepo.NewCostBudgetColl().DeleteByID(id); err != nil {
+		log.Errorf("failed to delete cost budget %s, error: %s", id, err)
+		return e.ErrDeleteCostBudget.AddErr(err)
+	}
+	return nil
+}
+
+func validateCostBudget(args *commonmodels.CostBudget) error {
+	if args.Name == "" || args.ProjectKey == "" {
+		return fmt.Errorf("name and project are required")
+	}
+	if args.Amount <= 0 {
+		return fmt.Errorf("amount must be positive")
+	}
+	if len(args.Thresholds) == 0 {
+		args.Thresholds = append([]int{}, cost.DefaultBudgetThresholds...)
+	}
+	for _, threshold := range args.Thresholds {
+		if threshold <= 0 {
+			return fmt.Errorf("invalid threshold: %d", threshold)
+		}
+	}
+	return nil
+}
+
+// GetCostStats returns the costs of the given projects in the time range grouped by the dimension, along with the daily trend
+func GetCostStats(startTime, endTime int64, projects []string, dimension CostDimension, log *zap.SugaredLogger) (*CostStatResponse, error) {
+	if dimension == "" {
+		dimension = CostDimensionProject
+	}
+	if dimension != CostDimensionProject && dimension != CostDimensionEnv && dimension != CostDimensionService && dimension != CostDimensionCategory {
+		return nil, e.ErrGetCostStat.AddDesc(fmt.Sprintf("invalid cost dimension: %s", dimension))
+	}
+	if endTime == 0 {
+		endTime = time.Now().Unix()
+	}
+	if startTime == 0 {
+		startTime = time.Unix(endTime, 0).AddDate(0, 0, -30).Unix()
+	}
+
+	pricing, err := commonrepo.NewCostPricingColl().Get()
+	if err != nil {
+		log.Errorf("failed to get cost pricing, error: %s", err)
+		return nil, e.ErrGetCostStat.AddErr(err)
+	}
+
+	costs, err := commonrepo.NewDailyCostColl().List(&commonrepo.DailyCostListOption{
+		StartDate: time.Unix(startTime, 0).Format(costDateLayout),
+		EndDate:   time.Unix(endTime, 0).Format(costDateLayout),
+		Projects:  projects,
+	})
+	if err != nil {
+		log.Errorf("failed to list daily costs, error: %s", err)
+		return nil, e.ErrGetCostStat.AddErr(err)
+	}
+
+	resp := &CostStatResponse{
+		Items: make([]*CostItem, 0),
+		Trend: make([]*CostTrendItem, 0),
+	}
+	if pricing != nil {
+		resp.Currency = pricing.Currency
+	}
+
+	itemMap := make(map[string]*CostItem)
+	trendMap := make(map[string]*CostTrendItem)
+	for _, dailyCost := range costs {
+		key, item := costItemOf(dailyCost, dimension)
+		if _, ok := itemMap[key]; !ok {
+			itemMap[key] = item
+			resp.Items = append(resp.Items, item)
+		}
+		item = itemMap[key]
+		item.CPUCost += dailyCost.CPUCost
+		item.MemoryCost += dailyCost.MemoryCost
+		item.StorageCost += dailyCost.StorageCost
+		item.LoadBalancerCost += dailyCost.LoadBalancerCost
+		item.TotalCost += dailyCost.TotalCost
+
+		if _, ok := trendMap[dailyCost.Date]; !ok {
+			trendMap[dailyCost.Date] = &CostTrendItem{Date: dailyCost.Date}
+			resp.Trend = append(resp.Trend, trendMap[dailyCost.Date])
+		}
+		trendMap[dailyCost.Date].TotalCost += dailyCost.TotalCost
+		resp.TotalCost += dailyCost.TotalCost
+	}
+
+	sort.SliceStable(resp.Items, func(i, j int) bool {
+		return resp.Items[i].TotalCost > resp.Items[j].TotalCost
+	})
+	sort.Slice(resp.Trend, func(i, j int) bool {
+		return resp.Trend[i].Date < resp.Trend[j].Date
+	})
+	return resp, nil
+}
+
+func costItemOf(dailyCost *commonmodels.DailyCost, dimension CostDimension) (string, *CostItem) {
+	switch dimension {
+	case CostDimensionEnv:
+		key := fmt.Sprintf("%s/%s/%s", dailyCost.ProjectKey, dailyCost.Category, dailyCost.EnvName)
+		return key, &CostItem{Key: key, ProjectKey: dailyCost.ProjectKey, Category: dailyCost.Category, EnvName: dailyCost.EnvName, Production: dailyCost.Production}
+	case CostDimensionService:
+		key := fmt.Sprintf("%s/%s/%s/%s/%s", dailyCost.ProjectKey, dailyCost.Category, dailyCost.EnvName, dailyCost.ServiceName, dailyCost.ConfigName)
+		return key, &CostItem{
+			Key:         key,
+			ProjectKey:  dailyCost.ProjectKey,
+			Category:    dailyCost.Category,
+			EnvName:     dailyCost.EnvName,
+			Production:  dailyCost.Production,
+			ServiceName: dailyCost.ServiceName,
+			ConfigName:  dailyCost.ConfigName,
+		}
+	case CostDimensionCategory:
+		key := string(dailyCost.Category)
+		return key, &CostItem{Key: key, Category: dailyCost.Category}
+	default:
+		return dailyCost.ProjectKey, &CostItem{Key: dailyCost.ProjectKey, ProjectKey: dailyCost.ProjectKey}
+	}
+}
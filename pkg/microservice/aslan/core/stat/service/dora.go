@@ -0,0 +1,608 @@
+/*
+Copyright 2025 The KodeRover Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package service
+
+import (
+	"bytes"
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/koderover/zadig/v2/pkg/microservice/aslan/config"
+	"github.com/koderover/zadig/v2/pkg/microservice/aslan/core/code/client"
+	"github.com/koderover/zadig/v2/pkg/microservice/aslan/core/code/client/open"
+	commonmodels "github.com/koderover/zadig/v2/pkg/microservice/aslan/core/common/repository/models"
+	commonrepo "github.com/koderover/zadig/v2/pkg/microservice/aslan/core/common/repository/mongodb"
+	templaterepo "github.com/koderover/zadig/v2/pkg/microservice/aslan/core/common/repository/mongodb/template"
+	"github.com/koderover/zadig/v2/pkg/microservice/aslan/core/stat/repository/models"
+	"github.com/koderover/zadig/v2/pkg/microservice/aslan/core/stat/repository/mongodb"
+	"github.com/koderover/zadig/v2/pkg/setting"
+	"github.com/koderover/zadig/v2/pkg/util"
+)
+
+type DoraDimension string
+
+const (
+	DoraDimensionProject DoraDimension = "project"
+	DoraDimensionTeam    DoraDimension = "team"
+	DoraDimensionService DoraDimension = "service"
+)
+
+const (
+	// a production deploy followed by a rollback or hotfix within this window is considered as a failed change
+	doraChangeFailureWindow = 24 * time.Hour
+	// a failed production deploy recovered later than this window is not counted into MTTR of the week
+	doraRecoveryWindow = 7 * 24 * time.Hour
+	doraHotfixKeyword  = "hotfix"
+)
+
+type DoraMetric struct {
+	Date        string        `json:"date,omitempty"`
+	Dimension   DoraDimension `json:"dimension"`
+	Key         string        `json:"key"`
+	ProjectKey  string        `json:"project_key,omitempty"`
+	ServiceName string        `json:"service_name,omitempty"`
+	TeamName    string        `json:"team_name,omitempty"`
+	// DeployFrequency is the average successful production deploy count per week
+	DeployFrequency    float64 `json:"deploy_frequency"`
+	DeployCount        int     `json:"deploy_count"`
+	FailedDeployCount  int     `json:"failed_deploy_count"`
+	ChangeFailureCount int     `json:"change_failure_count"`
+	// ChangeFailureRate is in percentage
+	ChangeFailureRate float64 `json:"change_failure_rate"`
+	// LeadTime and MTTR are in seconds
+	LeadTime float64 `json:"lead_time"`
+	MTTR     float64 `json:"mttr"`
+}
+
+type DoraStatResponse struct {
+	Metrics []*DoraMetric `json:"metrics"`
+	Trend   []*DoraMetric `json:"trend"`
+}
+
+// CreateWeeklyDoraStat creates the DORA stats of production envs for the last week. Like the other weekly stats,
+// this function MUST be called on Monday.
+func CreateWeeklyDoraStat(log *zap.SugaredLogger) error {
+	log.Info("start creating weekly dora stats..")
+
+	projects, err := templaterepo.NewProductColl().List()
+	if err != nil {
+		err = fmt.Errorf("failed to list project list to create dora stats, error: %s", err)
+		log.Error(err)
+		return err
+	}
+
+	startTime := time.Date(time.Now().Year(), time.Now().Month(), time.Now().Day(), 0, 0, 0, 0, time.Local).AddDate(0, 0, -7)
+	endTime := time.Date(time.Now().Year(), time.Now().Month(), time.Now().Day(), 23, 59, 59, 0, time.Local).AddDate(0, 0, -1)
+
+	calculator := newDoraCalculator(log)
+	failedProjects := make([]string, 0)
+	for _, project := range projects {
+		stats, err := calculator.calculate(project.ProductName, startTime, endTime)
+		if err != nil {
+			log.Errorf("failed to generate weekly dora stat for project: %s, error: %s", project.ProductName, err)
+			// in order to minimize the damage dealt from unexpected errors, the error will not be returned immediately, the failed project list will be returned in final error
+			failedProjects = append(failedProjects, project.ProductName)
+			continue
+		}
+
+		for _, stat := range stats {
+			if err := mongodb.NewWeeklyDoraStatColl().Upsert(stat); err != nil {
+				log.Errorf("failed to insert weekly dora stat for project: %s, service: %s, error: %s", project.ProductName, stat.ServiceName, err)
+				failedProjects = append(failedProjects, project.ProductName)
+				break
+			}
+		}
+	}
+
+	if len(failedProjects) > 0 {
+		err = fmt.Errorf("failed to do full dora stats, failed projects: %s", strings.Join(failedProjects, ", "))
+		log.Error(err)
+		return err
+	}
+
+	return nil
+}
+
+// GetDoraStats returns the DORA metrics of the given projects grouped by the dimension, along with the weekly trend.
+// The stats of the current week are calculated on the fly.
+func GetDoraStats(startTime, endTime int64, projects []string, dimension DoraDimension, log *zap.SugaredLogger) (*DoraStatResponse, error) {
+	if dimension == "" {
+		dimension = DoraDimensionProject
+	}
+	if dimension != DoraDimensionProject && dimension != DoraDimensionTeam && dimension != DoraDimensionService {
+		return nil, fmt.Errorf("invalid dora dimension: %s", dimension)
+	}
+
+	weeklyStats, err := mongodb.NewWeeklyDoraStatColl().List(startTime, endTime, projects)
+	if err != nil {
+		err = fmt.Errorf("failed to list weekly dora stats, error: %s", err)
+		log.Error(err)
+		return nil, err
+	}
+
+	firstDayOfWeek := util.GetMonday(time.Now())
+	firstDayOfWeek = time.Date(firstDayOfWeek.Year(), firstDayOfWeek.Month(), firstDayOfWeek.Day(), 0, 0, 0, 0, time.Local)
+	if endTime >= firstDayOfWeek.Unix() {
+		projectKeys := projects
+		if len(projectKeys) == 0 {
+			projectKeys, err = templaterepo.NewProductColl().ListAllName()
+			if err != nil {
+				err = fmt.Errorf("failed to list projects, error: %s", err)
+				log.Error(err)
+				return nil, err
+			}
+		}
+
+		calculator := newDoraCalculator(log)
+		for _, projectKey := range projectKeys {
+			stats, err := calculator.calculate(projectKey, firstDayOfWeek, time.Now())
+			if err != nil {
+				log.Warnf("failed to calculate dora stats of this week for project: %s, error: %s", projectKey, err)
+				continue
+			}
+			weeklyStats = append(weeklyStats, stats...)
+		}
+	}
+
+	teams, err := getProjectTeams()
+	if err != nil {
+		err = fmt.Errorf("failed to list project groups, error: %s", err)
+		log.Error(err)
+		return nil, err
+	}
+
+	weeks := math.Ceil(float64(endTime-startTime) / float64(7*24*3600))
+	if weeks < 1 {
+		weeks = 1
+	}
+
+	return &DoraStatResponse{
+		Metrics: aggregateDoraStats(weeklyStats, dimension, teams, false, weeks),
+		Trend:   aggregateDoraStats(weeklyStats, dimension, teams, true, 1),
+	}, nil
+}
+
+// ExportDoraStats exports the DORA metrics and weekly trend as csv or json file
+func ExportDoraStats(startTime, endTime int64, projects []string, dimension DoraDimension, format string, log *zap.SugaredLogger) ([]byte, string, error) {
+	stats, err := GetDoraStats(startTime, endTime, projects, dimension, log)
+	if err != nil {
+		return nil, "", err
+	}
+
+	fileName := fmt.Sprintf("dora-%s-%s", time.Unix(startTime, 0).Format(config.Date), time.Unix(endTime, 0).Format(config.Date))
+	switch format {
+	case "json":
+		data, err := json.MarshalIndent(stats, "", "  ")
+		if err != nil {
+			return nil, "", err
+		}
+		return data, fileName + ".json", nil
+	case "", "csv":
+		data, err := renderDoraStatsCSV(stats)
+		if err != nil {
+			return nil, "", err
+		}
+		return data, fileName + ".csv", nil
+	default:
+		return nil, "", fmt.Errorf("invalid export format: %s", format)
+	}
+}
+
+func renderDoraStatsCSV(stats *DoraStatResponse) ([]byte, error) {
+	buf := &bytes.Buffer{}
+	writer := csv.NewWriter(buf)
+	if err := writer.Write([]string{"date", "dimension", "key", "deploy_frequency", "deploy_count", "failed_deploy_count", "change_failure_count", "change_failure_rate(%)", "lead_time(s)", "mttr(s)"}); err != nil {
+		return nil, err
+	}
+
+	formatFloat := func(f float64) string {
+		return strconv.FormatFloat(f, 'f', 2, 64)
+	}
+	write := func(date string, metric *DoraMetric) error {
+		return writer.Write([]string{
+			date,
+			string(metric.Dimension),
+			metric.Key,
+			formatFloat(metric.DeployFrequency),
+			strconv.Itoa(metric.DeployCount),
+			strconv.Itoa(metric.FailedDeployCount),
+			strconv.Itoa(metric.ChangeFailureCount),
+			formatFloat(metric.ChangeFailureRate),
+			formatFloat(metric.LeadTime),
+			formatFloat(metric.MTTR),
+		})
+	}
+
+	for _, metric := range stats.Metrics {
+		if err := write("total", metric); err != nil {
+			return nil, err
+		}
+	}
+	for _, metric := range stats.Trend {
+		if err := write(metric.Date, metric); err != nil {
+			return nil, err
+		}
+	}
+
+	writer.Flush()
+	if err := writer.Error(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// getProjectTeams returns the project group name of each project, a project not in any group belongs to the ungrouped team
+func getProjectTeams() (map[string]string, error) {
+	groups, err := commonrepo.NewProjectGroupColl().List()
+	if err != nil {
+		return nil, err
+	}
+
+	resp := make(map[string]string)
+	for _, group := range groups {
+		for _, project := range group.Projects {
+			resp[project.ProjectKey] = group.Name
+		}
+	}
+	return resp, nil
+}
+
+func aggregateDoraStats(stats []*models.WeeklyDoraStat, dimension DoraDimension, teams map[string]string, byWeek bool, weeks float64) []*DoraMetric {
+	type doraSum struct {
+		metric            *DoraMetric
+		leadTimeTotal     int64
+		leadTimeCount     int
+		recoveryTimeTotal int64
+		recoveryCount     int
+	}
+
+	sums := make(map[string]*doraSum)
+	keys := make([]string, 0)
+	for _, stat := range stats {
+		metric := &DoraMetric{Dimension: dimension}
+		switch dimension {
+		case DoraDimensionProject:
+			metric.Key = stat.ProjectKey
+			metric.ProjectKey = stat.ProjectKey
+		case DoraDimensionTeam:
+			metric.TeamName = teams[stat.ProjectKey]
+			if metric.TeamName == "" {
+				metric.TeamName = setting.UNGROUPED
+			}
+			metric.Key = metric.TeamName
+		case DoraDimensionService:
+			metric.Key = fmt.Sprintf("%s/%s", stat.ProjectKey, stat.ServiceName)
+			metric.ProjectKey = stat.ProjectKey
+			metric.ServiceName = stat.ServiceName
+		}
+		if byWeek {
+			metric.Date = stat.Date
+		}
+
+		key := metric.Date + "|" + metric.Key
+		sum, ok := sums[key]
+		if !ok {
+			sum = &doraSum{metric: metric}
+			sums[key] = sum
+			keys = append(keys, key)
+		}
+		sum.metric.DeployCount += stat.DeployCount
+		sum.metric.FailedDeployCount += stat.FailedDeployCount
+		sum.metric.ChangeFailureCount += stat.ChangeFailureCount
+		sum.leadTimeTotal += stat.LeadTimeTotal
+		sum.leadTimeCount += stat.LeadTimeCount
+		sum.recoveryTimeTotal += stat.RecoveryTimeTotal
+		sum.recoveryCount += stat.RecoveryCount
+	}
+
+	sort.Strings(keys)
+	resp := make([]*DoraMetric, 0, len(keys))
+	for _, key := range keys {
+		sum := sums[key]
+		metric := sum.metric
+		metric.DeployFrequency = float64(metric.DeployCount) / weeks
+		if metric.DeployCount > 0 {
+			metric.ChangeFailureRate = float64(metric.ChangeFailureCount) * 100 / float64(metric.DeployCount)
+		}
+		if sum.leadTimeCount > 0 {
+			metric.LeadTime = float64(sum.leadTimeTotal) / float64(sum.leadTimeCount)
+		}
+		if sum.recoveryCount > 0 {
+			metric.MTTR = float64(sum.recoveryTimeTotal) / float64(sum.recoveryCount)
+		}
+		resp = append(resp, metric)
+	}
+	return resp
+}
+
+type doraDeploy struct {
+	*commonmodels.JobInfo
+	commits []*commonmodels.ActivityCommit
+}
+
+// doraCalculator calculates the DORA stats from the deploy jobs and rollback records, it caches the workflow tasks,
+// codehost clients and commit times it has seen so that it can be reused among projects.
+type doraCalculator struct {
+	log         *zap.SugaredLogger
+	tasks       map[string]*commonmodels.WorkflowTask
+	clients     map[int]client.CodeHostClient
+	commitTimes map[string]int64
+}
+
+func newDoraCalculator(log *zap.SugaredLogger) *doraCalculator {
+	return &doraCalculator{
+		log:         log,
+		tasks:       make(map[string]*commonmodels.WorkflowTask),
+		clients:     make(map[int]client.CodeHostClient),
+		commitTimes: make(map[string]int64),
+	}
+}
+
+func (c *doraCalculator) calculate(projectKey string, startTime, endTime time.Time) ([]*models.WeeklyDoraStat, error) {
+	// deploys and rollbacks after the end time are needed to tell whether a change failed and when it recovered
+	deployJobs, err := commonrepo.NewJobInfoColl().GetDeployJobs(startTime.Unix(), endTime.Add(doraRecoveryWindow).Unix(), []string{projectKey}, config.Production)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list production deploy jobs, error: %s", err)
+	}
+
+	production := true
+	rollbacks, _, err := commonrepo.NewEnvInfoColl().List(context.Background(), &commonrepo.ListEnvInfoOption{
+		ProjectName: projectKey,
+		Operation:   config.EnvOperationRollback,
+		StartTime:   startTime.Unix(),
+		EndTime:     endTime.Add(doraChangeFailureWindow).Unix(),
+		Production:  &production,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list production rollbacks, error: %s", err)
+	}
+
+	deploysByTarget := make(map[string][]*doraDeploy)
+	for _, job := range deployJobs {
+		if job.ServiceName == "" {
+			continue
+		}
+		key := job.TargetEnv + "/" + job.ServiceName
+		deploysByTarget[key] = append(deploysByTarget[key], &doraDeploy{JobInfo: job})
+	}
+
+	rollbackTimes := make(map[string][]int64)
+	for _, rollback := range rollbacks {
+		key := rollback.EnvName + "/" + rollback.ServiceName
+		rollbackTimes[key] = append(rollbackTimes[key], rollback.CreatTime)
+	}
+
+	date := startTime.Format(config.Date)
+	statMap := make(map[string]*models.WeeklyDoraStat)
+	getStat := func(serviceName string) *models.WeeklyDoraStat {
+		if stat, ok := statMap[serviceName]; ok {
+			return stat
+		}
+		stat := &models.WeeklyDoraStat{
+			ProjectKey:  projectKey,
+			ServiceName: serviceName,
+			Date:        date,
+			CreateTime:  endTime.Unix(),
+		}
+		statMap[serviceName] = stat
+		return stat
+	}
+
+	for key, deploys := range deploysByTarget {
+		sort.Slice(deploys, func(i, j int) bool {
+			return deploys[i].StartTime < deploys[j].StartTime
+		})
+
+		for i, deploy := range deploys {
+			if deploy.StartTime < startTime.Unix() || deploy.StartTime > endTime.Unix() {
+				continue
+			}
+
+			switch deploy.Status {
+			case string(config.StatusPassed):
+				stat := getStat(deploy.ServiceName)
+				stat.DeployCount++
+
+				for _, commit := range c.getDeployCommits(deploy) {
+					commitTime := c.getCommitTime(commit)
+					if commitTime <= 0 || commitTime > deploy.EndTime {
+						continue
+					}
+					stat.LeadTimeTotal += deploy.EndTime - commitTime
+					stat.LeadTimeCount++
+				}
+
+				if c.isFailedChange(deploy, deploys[i+1:], rollbackTimes[key]) {
+					stat.ChangeFailureCount++
+				}
+			case string(config.StatusFailed), string(config.StatusTimeout):
+				stat := getStat(deploy.ServiceName)
+				stat.FailedDeployCount++
+
+				if recoveryTime, ok := doraRecoveryTime(deploys, i); ok {
+					stat.RecoveryTimeTotal += recoveryTime
+					stat.RecoveryCount++
+				}
+			}
+		}
+	}
+
+	resp := make([]*models.WeeklyDoraStat, 0, len(statMap))
+	for _, stat := range statMap {
+		resp = append(resp, stat)
+	}
+	return resp, nil
+}
+
+// doraRecoveryTime returns the time taken by the service to recover from the failed deploy at index i of the sorted deploys,
+// false is returned if it doesn't start an outage or the outage isn't recovered within the window
+func doraRecoveryTime(deploys []*doraDeploy, i int) (int64, bool) {
+	failed := deploys[i]
+	// consecutive failures belong to the same outage, which starts at the first failure
+	if i > 0 && deploys[i-1].Status != string(config.StatusPassed) {
+		return 0, false
+	}
+	for _, next := range deploys[i+1:] {
+		if next.Status != string(config.StatusPassed) {
+			continue
+		}
+		if next.EndTime-failed.EndTime > int64(doraRecoveryWindow.Seconds()) {
+			return 0, false
+		}
+		return next.EndTime - failed.EndTime, true
+	}
+	return 0, false
+}
+
+// isFailedChange tells whether the production deploy is followed by a rollback or a hotfix deploy of the same service within the window
+func (c *doraCalculator) isFailedChange(deploy *doraDeploy, nextDeploys []*doraDeploy, rollbackTimes []int64) bool {
+	windowEnd := deploy.EndTime + int64(doraChangeFailureWindow.Seconds())
+	for _, rollbackTime := range rollbackTimes {
+		if rollbackTime >= deploy.EndTime && rollbackTime <= windowEnd {
+			return true
+		}
+	}
+
+	for _, next := range nextDeploys {
+		if next.StartTime > windowEnd {
+			break
+		}
+		if next.Status != string(config.StatusPassed) {
+			continue
+		}
+		if isDoraHotfix(next.WorkflowName, c.getDeployCommits(next)) {
+			return true
+		}
+	}
+	return false
+}
+
+func isDoraHotfix(workflowName string, commits []*commonmodels.ActivityCommit) bool {
+	if strings.Contains(strings.ToLower(workflowName), doraHotfixKeyword) {
+		return true
+	}
+	for _, commit := range commits {
+		if strings.Contains(strings.ToLower(commit.Branch), doraHotfixKeyword) {
+			return true
+		}
+	}
+	return false
+}
+
+// getDeployCommits finds the commits deployed by the job through the images in the workflow task and their build activities
+func (c *doraCalculator) getDeployCommits(deploy *doraDeploy) []*commonmodels.ActivityCommit {
+	if deploy.commits != nil {
+		return deploy.commits
+	}
+	deploy.commits = make([]*commonmodels.ActivityCommit, 0)
+
+	taskKey := fmt.Sprintf("%s/%d", deploy.WorkflowName, deploy.TaskID)
+	task, ok := c.tasks[taskKey]
+	if !ok {
+		var err error
+		task, err = commonrepo.NewworkflowTaskv4Coll().Find(deploy.WorkflowName, deploy.TaskID)
+		if err != nil {
+			c.log.Debugf("failed to find workflow task %s, error: %s", taskKey, err)
+		}
+		c.tasks[taskKey] = task
+	}
+	if task == nil {
+		return deploy.commits
+	}
+
+	images := make([]string, 0)
+	for _, stage := range task.Stages {
+		for _, job := range stage.Jobs {
+			switch job.JobType {
+			case string(config.JobZadigDeploy):
+				spec := new(commonmodels.JobTaskDeploySpec)
+				if err := commonmodels.IToi(job.Spec, spec); err != nil || spec.ServiceName != deploy.ServiceName || spec.Env != deploy.TargetEnv {
+					continue
+				}
+				for _, serviceAndImage := range spec.ServiceAndImages {
+					images = append(images, serviceAndImage.Image)
+				}
+			case string(config.JobZadigHelmDeploy):
+				spec := new(commonmodels.JobTaskHelmDeploySpec)
+				if err := commonmodels.IToi(job.Spec, spec); err != nil || spec.ServiceName != deploy.ServiceName || spec.Env != deploy.TargetEnv {
+					continue
+				}
+				images = append(images, spec.GetDeployImages()...)
+			}
+		}
+	}
+
+	repoSet := make(map[string]struct{})
+	for _, image := range images {
+		artifact, err := commonrepo.NewDeliveryArtifactColl().Get(&commonrepo.DeliveryArtifactArgs{Image: image})
+		if err != nil {
+			continue
+		}
+		activities, _, err := commonrepo.NewDeliveryActivityColl().List(&commonrepo.DeliveryActivityArgs{ArtifactID: artifact.ID.Hex()})
+		if err != nil {
+			continue
+		}
+		for _, activity := range activities {
+			if activity.Type != setting.BuildType {
+				continue
+			}
+			for _, commit := range activity.Commits {
+				key := fmt.Sprintf("%s/%s/%s", commit.RepoOwner, commit.RepoName, commit.CommitID)
+				if _, ok := repoSet[key]; ok || commit.CommitID == "" {
+					continue
+				}
+				repoSet[key] = struct{}{}
+				deploy.commits = append(deploy.commits, commit)
+			}
+			break
+		}
+	}
+	return deploy.commits
+}
+
+// getCommitTime returns the creation time of the commit from the codehost, 0 is returned if it can't be found
+func (c *doraCalculator) getCommitTime(commit *commonmodels.ActivityCommit) int64 {
+	key := fmt.Sprintf("%d/%s/%s/%s/%s", commit.CodehostID, commit.Address, commit.RepoOwner, commit.RepoName, commit.CommitID)
+	if commitTime, ok := c.commitTimes[key]; ok {
+		return commitTime
+	}
+	c.commitTimes[key] = 0
+
+	cli, err := open.OpenCommitClient(commit, c.clients, nil, c.log)
+	if err != nil {
+		c.log.Debugf("failed to get codehost client for repo %s/%s, error: %s", commit.RepoOwner, commit.RepoName, err)
+		return 0
+	}
+
+	detail, err := cli.GetCommit(open.CommitNamespace(commit), commit.RepoName, commit.CommitID)
+	if err != nil {
+		c.log.Debugf("failed to get commit %s of repo %s/%s, error: %v", commit.CommitID, commit.RepoOwner, commit.RepoName, err)
+		return 0
+	}
+
+	c.commitTimes[key] = detail.CreatedAt
+	return detail.CreatedAt
+}
@@ -0,0 +1,150 @@
+/*
+Copyright 2025 The KodeRover Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package service
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"github.com/koderover/zadig/v2/pkg/microservice/aslan/config"
+	commonmodels "github.com/koderover/zadig/v2/pkg/microservice/aslan/core/common/repository/models"
+	"github.com/koderover/zadig/v2/pkg/microservice/aslan/core/stat/repository/models"
+)
+
+const hour = int64(3600)
+
+func newDoraDeploy(workflowName string, status config.Status, endTime int64) *doraDeploy {
+	return &doraDeploy{
+		JobInfo: &commonmodels.JobInfo{
+			WorkflowName: workflowName,
+			Status:       string(status),
+			StartTime:    endTime - 60,
+			EndTime:      endTime,
+		},
+		// no artifact lookup is needed in the tests
+		commits: make([]*commonmodels.ActivityCommit, 0),
+	}
+}
+
+func TestIsFailedChange(t *testing.T) {
+	c := newDoraCalculator(zap.NewNop().Sugar())
+	deploy := newDoraDeploy("release", config.StatusPassed, 100*hour)
+
+	tests := []struct {
+		name          string
+		nextDeploys   []*doraDeploy
+		rollbackTimes []int64
+		want          bool
+	}{
+		{
+			name: "no rollback or hotfix",
+			nextDeploys: []*doraDeploy{
+				newDoraDeploy("release", config.StatusPassed, 102*hour),
+			},
+			want: false,
+		},
+		{
+			name:          "rolled back within the window",
+			rollbackTimes: []int64{110 * hour},
+			want:          true,
+		},
+		{
+			name:          "rolled back after the window",
+			rollbackTimes: []int64{125 * hour},
+			want:          false,
+		},
+		{
+			name: "hotfix deployed within the window",
+			nextDeploys: []*doraDeploy{
+				newDoraDeploy("hotfix-release", config.StatusPassed, 101*hour),
+			},
+			want: true,
+		},
+		{
+			name: "failed hotfix deploy",
+			nextDeploys: []*doraDeploy{
+				newDoraDeploy("hotfix-release", config.StatusFailed, 101*hour),
+			},
+			want: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require.Equal(t, tt.want, c.isFailedChange(deploy, tt.nextDeploys, tt.rollbackTimes))
+		})
+	}
+}
+
+func TestDoraRecoveryTime(t *testing.T) {
+	deploys := []*doraDeploy{
+		newDoraDeploy("release", config.StatusPassed, 1*hour),
+		newDoraDeploy("release", config.StatusFailed, 2*hour),
+		newDoraDeploy("release", config.StatusTimeout, 3*hour),
+		newDoraDeploy("release", config.StatusPassed, 5*hour),
+		newDoraDeploy("release", config.StatusFailed, 6*hour),
+		newDoraDeploy("release", config.StatusPassed, 200*hour),
+		newDoraDeploy("release", config.StatusFailed, 201*hour),
+	}
+
+	recoveryTime, ok := doraRecoveryTime(deploys, 1)
+	require.True(t, ok)
+	require.Equal(t, 3*hour, recoveryTime)
+
+	// the outage started at the previous failure
+	_, ok = doraRecoveryTime(deploys, 2)
+	require.False(t, ok)
+
+	// recovered later than the window
+	_, ok = doraRecoveryTime(deploys, 4)
+	require.False(t, ok)
+
+	// not recovered yet
+	_, ok = doraRecoveryTime(deploys, 6)
+	require.False(t, ok)
+}
+
+func TestAggregateDoraStats(t *testing.T) {
+	stats := []*models.WeeklyDoraStat{
+		{ProjectKey: "demo", ServiceName: "api", DeployCount: 3, ChangeFailureCount: 1, RecoveryTimeTotal: 2 * hour, RecoveryCount: 1, Date: "2026-10-05"},
+		{ProjectKey: "demo", ServiceName: "web", DeployCount: 1, ChangeFailureCount: 1, RecoveryTimeTotal: 4 * hour, RecoveryCount: 1, Date: "2026-10-12"},
+		{ProjectKey: "other", ServiceName: "api", DeployCount: 0, FailedDeployCount: 2, Date: "2026-10-12"},
+	}
+
+	metrics := aggregateDoraStats(stats, DoraDimensionProject, nil, false, 2)
+	require.Len(t, metrics, 2)
+
+	demo := metrics[0]
+	require.Equal(t, "demo", demo.Key)
+	require.Equal(t, 4, demo.DeployCount)
+	require.Equal(t, 2.0, demo.DeployFrequency)
+	require.Equal(t, 50.0, demo.ChangeFailureRate)
+	require.Equal(t, float64(3*hour), demo.MTTR)
+
+	other := metrics[1]
+	require.Equal(t, "other", other.Key)
+	require.Equal(t, 2, other.FailedDeployCount)
+	require.Zero(t, other.ChangeFailureRate)
+	require.Zero(t, other.MTTR)
+
+	trend := aggregateDoraStats(stats, DoraDimensionService, nil, true, 2)
+	require.Len(t, trend, 3)
+	require.Equal(t, "2026-10-05", trend[0].Date)
+	require.Equal(t, "demo/api", trend[0].Key)
+	require.InDelta(t, 33.33, trend[0].ChangeFailureRate, 0.01)
+}
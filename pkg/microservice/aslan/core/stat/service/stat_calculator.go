@@ -26,6 +26,7 @@ import (
 
 	"github.com/koderover/zadig/v2/pkg/microservice/aslan/config"
 	commonrepo "github.com/koderover/zadig/v2/pkg/microservice/aslan/core/common/repository/mongodb"
+	"github.com/koderover/zadig/v2/pkg/microservice/aslan/core/stat/repository/mongodb"
 	"github.com/koderover/zadig/v2/pkg/tool/httpclient"
 	"github.com/koderover/zadig/v2/pkg/util"
 )
@@ -108,6 +109,12 @@ func CreateCalculatorFromConfig(cfg *StatDashboardConfig) (StatCalculator, error
 			Weight:   cfg.Weight,
 			Function: cfg.Function,
 		}, nil
+	case config.DashboardDataTypeLeadTime, config.DashboardDataTypeChangeFailureRate, config.DashboardDataTypeMTTR:
+		return &DoraCalculator{
+			ID:       cfg.ID,
+			Weight:   cfg.Weight,
+			Function: cfg.Function,
+		}, nil
 	default:
 		return nil, fmt.Errorf("unsupported config id: %s", cfg.ID)
 	}
@@ -333,6 +340,40 @@ func (c *DeployAverageDurationCalculator) GetWeightedScore(fact float64) (float6
 	return calculateWeightedScore(fact, c.Function, c.Weight)
 }
 
+// DoraCalculator gets the DORA facts from the weekly dora stats of the project
+type DoraCalculator struct {
+	ID       string
+	Weight   int64
+	Function string
+}
+
+func (c *DoraCalculator) GetFact(startTime, endTime int64, project string) (float64, bool, error) {
+	weeklyStats, err := mongodb.NewWeeklyDoraStatColl().List(startTime, endTime, []string{project})
+	if err != nil {
+		return 0, false, err
+	}
+
+	metrics := aggregateDoraStats(weeklyStats, DoraDimensionProject, nil, false, 1)
+	if len(metrics) == 0 {
+		return 0, false, nil
+	}
+
+	switch c.ID {
+	case config.DashboardDataTypeLeadTime:
+		return metrics[0].LeadTime, metrics[0].LeadTime > 0, nil
+	case config.DashboardDataTypeChangeFailureRate:
+		return metrics[0].ChangeFailureRate, metrics[0].DeployCount > 0, nil
+	case config.DashboardDataTypeMTTR:
+		return metrics[0].MTTR, metrics[0].MTTR > 0, nil
+	default:
+		return 0, false, fmt.Errorf("unsupported dora config id: %s", c.ID)
+	}
+}
+
+func (c *DoraCalculator) GetWeightedScore(fact float64) (float64, error) {
+	return calculateWeightedScore(fact, c.Function, c.Weight)
+}
+
 type DeployFrequencyCalculator struct {
 	Weight   int64
 	Function string
@@ -0,0 +1,215 @@
+/*
+Copyright 2025 The KodeRover Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package handler
+
+import (
+	"fmt"
+
+	"github.com/gin-gonic/gin"
+
+	commonmodels "github.com/koderover/zadig/v2/pkg/microservice/aslan/core/common/repository/models"
+	"github.com/koderover/zadig/v2/pkg/microservice/aslan/core/common/service/admission"
+	"github.com/koderover/zadig/v2/pkg/microservice/aslan/core/system/service"
+	internalhandler "github.com/koderover/zadig/v2/pkg/shared/handler"
+	e "github.com/koderover/zadig/v2/pkg/tool/errors"
+	"github.com/koderover/zadig/v2/pkg/types"
+)
+
+// @Summary List Admission Policies
+// @Description List the rego policies evaluated when workflow tasks are created or deploy jobs start
+// @Tags system
+// @Accept json
+// @Produce json
+// @Success 200 {array} commonmodels.AdmissionPolicy
+// @Router /api/aslan/system/admission/policies [get]
+func ListAdmissionPolicies(c *gin.Context) {
+	ctx, err := internalhandler.NewContextWithAuthorization(c)
+	defer func() { internalhandler.JSONResponse(c, ctx) }()
+
+	if err != nil {
+		ctx.RespErr = fmt.Errorf("authorization Info Generation failed: err %s", err)
+		ctx.UnAuthorized = true
+		return
+	}
+
+	if !ctx.Resources.IsSystemAdmin {
+		ctx.UnAuthorized = true
+		return
+	}
+
+	ctx.Resp, ctx.RespErr = service.ListAdmissionPolicies(ctx.Logger)
+}
+
+// @Summary Get Admission Policy
+// @Description Get Admission Policy
+// @Tags system
+// @Accept json
+// @Produce json
+// @Param id path string true "policy id"
+// @Success 200 {object} commonmodels.AdmissionPolicy
+// @Router /api/aslan/system/admission/policies/{id} [get]
+func GetAdmissionPolicy(c *gin.Context) {
+	ctx, err := internalhandler.NewContextWithAuthorization(c)
+	defer func() { internalhandler.JSONResponse(c, ctx) }()
+
+	if err != nil {
+		ctx.RespErr = fmt.Errorf("authorization Info Generation failed: err %s", err)
+		ctx.UnAuthorized = true
+		return
+	}
+
+	if !ctx.Resources.IsSystemAdmin {
+		ctx.UnAuthorized = true
+		return
+	}
+
+	ctx.Resp, ctx.RespErr = service.GetAdmissionPolicy(c.Param("id"), ctx.Logger)
+}
+
+// @Summary Create Admission Policy
+// @Description Create an admission policy, the rego module must be in a package under zadig.admission and define a deny rule
+// @Tags system
+// @Accept json
+// @Produce json
+// @Param body body commonmodels.AdmissionPolicy true "admission policy"
+// @Success 200
+// @Router /api/aslan/system/admission/policies [post]
+func CreateAdmissionPolicy(c *gin.Context) {
+	ctx, err := internalhandler.NewContextWithAuthorization(c)
+	defer func() { internalhandler.JSONResponse(c, ctx) }()
+
+	if err != nil {
+		ctx.RespErr = fmt.Errorf("authorization Info Generation failed: err %s", err)
+		ctx.UnAuthorized = true
+		return
+	}
+
+	if !ctx.Resources.IsSystemAdmin {
+		ctx.UnAuthorized = true
+		return
+	}
+
+	args := new(commonmodels.AdmissionPolicy)
+	if err := c.ShouldBindJSON(args); err != nil {
+		ctx.RespErr = e.ErrInvalidParam.AddDesc("invalid admission policy args")
+		return
+	}
+
+	internalhandler.InsertOperationLog(c, ctx.UserName, "", "新增", "系统设置-准入策略", args.Name, args.Name, args.Rego, types.RequestBodyTypeJSON, ctx.Logger)
+
+	args.CreatedBy = ctx.UserName
+	args.UpdatedBy = ctx.UserName
+	ctx.RespErr = service.CreateAdmissionPolicy(args, ctx.Logger)
+}
+
+// @Summary Update Admission Policy
+// @Description Update Admission Policy
+// @Tags system
+// @Accept json
+// @Produce json
+// @Param id path string true "policy id"
+// @Param body body commonmodels.AdmissionPolicy true "admission policy"
+// @Success 200
+// @Router /api/aslan/system/admission/policies/{id} [put]
+func UpdateAdmissionPolicy(c *gin.Context) {
+	ctx, err := internalhandler.NewContextWithAuthorization(c)
+	defer func() { internalhandler.JSONResponse(c, ctx) }()
+
+	if err != nil {
+		ctx.RespErr = fmt.Errorf("authorization Info Generation failed: err %s", err)
+		ctx.UnAuthorized = true
+		return
+	}
+
+	if !ctx.Resources.IsSystemAdmin {
+		ctx.UnAuthorized = true
+		return
+	}
+
+	args := new(commonmodels.AdmissionPolicy)
+	if err := c.ShouldBindJSON(args); err != nil {
+		ctx.RespErr = e.ErrInvalidParam.AddDesc("invalid admission policy args")
+		return
+	}
+
+	internalhandler.InsertOperationLog(c, ctx.UserName, "", "更新", "系统设置-准入策略", args.Name, args.Name, args.Rego, types.RequestBodyTypeJSON, ctx.Logger)
+
+	args.UpdatedBy = ctx.UserName
+	ctx.RespErr = service.UpdateAdmissionPolicy(c.Param("id"), args, ctx.Logger)
+}
+
+// @Summary Delete Admission Policy
+// @Description Delete Admission Policy
+// @Tags system
+// @Accept json
+// @Produce json
+// @Param id path string true "policy id"
+// @Success 200
+// @Router /api/aslan/system/admission/policies/{id} [delete]
+func DeleteAdmissionPolicy(c *gin.Context) {
+	ctx, err := internalhandler.NewContextWithAuthorization(c)
+	defer func() { internalhandler.JSONResponse(c, ctx) }()
+
+	if err != nil {
+		ctx.RespErr = fmt.Errorf("authorization Info Generation failed: err %s", err)
+		ctx.UnAuthorized = true
+		return
+	}
+
+	if !ctx.Resources.IsSystemAdmin {
+		ctx.UnAuthorized = true
+		return
+	}
+
+	id := c.Param("id")
+	internalhandler.InsertOperationLog(c, ctx.UserName, "", "删除", "系统设置-准入策略", id, id, "", types.RequestBodyTypeJSON, ctx.Logger)
+
+	ctx.RespErr = service.DeleteAdmissionPolicy(id, ctx.Logger)
+}
+
+// @Summary Evaluate Admission Policy
+// @Description Evaluate the policy against a sample input, the violations are returned without being recorded
+// @Tags system
+// @Accept json
+// @Produce json
+// @Param id path string true "policy id"
+// @Param body body admission.Input true "policy input"
+// @Success 200 {array} admission.Violation
+// @Router /api/aslan/system/admission/policies/{id}/evaluate [post]
+func EvaluateAdmissionPolicy(c *gin.Context) {
+	ctx, err := internalhandler.NewContextWithAuthorization(c)
+	defer func() { internalhandler.JSONResponse(c, ctx) }()
+
+	if err != nil {
+		ctx.RespErr = fmt.Errorf("authorization Info Generation failed: err %s", err)
+		ctx.UnAuthorized = true
+		return
+	}
+
+	if !ctx.Resources.IsSystemAdmin {
+		ctx.UnAuthorized = true
+		return
+	}
+
+	input := new(admission.Input)
+	if err := c.ShouldBindJSON(input); err != nil {
+		ctx.RespErr = e.ErrInvalidParam.AddDesc("invalid policy input")
+		return
+	}
+
+	ctx.Resp, ctx.RespErr = service.EvaluateAdmissionPolicy(c.Param("id"), input, ctx.Logger)
+}
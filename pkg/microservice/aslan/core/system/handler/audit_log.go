@@ -0,0 +1,200 @@
+/*
+Copyright 2025 The KodeRover Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package handler
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+
+	commonmodels "github.com/koderover/zadig/v2/pkg/microservice/aslan/core/common/repository/models"
+	"github.com/koderover/zadig/v2/pkg/microservice/aslan/core/system/service"
+	internalhandler "github.com/koderover/zadig/v2/pkg/shared/handler"
+	e "github.com/koderover/zadig/v2/pkg/tool/errors"
+	"github.com/koderover/zadig/v2/pkg/types"
+)
+
+// @Summary Get Audit Log Settings
+// @Description Get the audit log retention and sinks, secrets are masked
+// @Tags system
+// @Accept json
+// @Produce json
+// @Success 200 {object} commonmodels.AuditLogSettings
+// @Router /api/aslan/system/auditlog/settings [get]
+func GetAuditLogSettings(c *gin.Context) {
+	ctx, err := internalhandler.NewContextWithAuthorization(c)
+	defer func() { internalhandler.JSONResponse(c, ctx) }()
+
+	if err != nil {
+		ctx.RespErr = fmt.Errorf("authorization Info Generation failed: err %s", err)
+		ctx.UnAuthorized = true
+		return
+	}
+
+	if !ctx.Resources.IsSystemAdmin {
+		ctx.UnAuthorized = true
+		return
+	}
+
+	ctx.Resp, ctx.RespErr = service.GetAuditLogSettings(ctx.Logger)
+}
+
+// @Summary Update Audit Log Settings
+// @Description Update the audit log retention and sinks, empty secrets keep their current values
+// @Tags system
+// @Accept json
+// @Produce json
+// @Param body body commonmodels.AuditLogSettings true "audit log settings"
+// @Success 200
+// @Router /api/aslan/system/auditlog/settings [put]
+func UpdateAuditLogSettings(c *gin.Context) {
+	ctx, err := internalhandler.NewContextWithAuthorization(c)
+	defer func() { internalhandler.JSONResponse(c, ctx) }()
+
+	if err != nil {
+		ctx.RespErr = fmt.Errorf("authorization Info Generation failed: err %s", err)
+		ctx.UnAuthorized = true
+		return
+	}
+
+	if !ctx.Resources.IsSystemAdmin {
+		ctx.UnAuthorized = true
+		return
+	}
+
+	args := new(commonmodels.AuditLogSettings)
+	if err := c.ShouldBindJSON(args); err != nil {
+		ctx.RespErr = e.ErrInvalidParam.AddDesc("invalid audit log settings")
+		return
+	}
+
+	detail := fmt.Sprintf("retention:%d sinks:%d", args.RetentionDays, len(args.Sinks))
+	internalhandler.InsertOperationLog(c, ctx.UserName, "", "更新", "系统设置-审计日志", detail, detail, "", types.RequestBodyTypeJSON, ctx.Logger)
+
+	ctx.RespErr = service.UpdateAuditLogSettings(args, ctx.Logger)
+}
+
+// @Summary Verify Operation Logs
+// @Description Verify the hash chain of the operation logs
+// @Tags system
+// @Accept json
+// @Produce json
+// @Success 200 {object} service.OperationLogVerifyResult
+// @Router /api/aslan/system/auditlog/verify [get]
+func VerifyOperationLogChain(c *gin.Context) {
+	ctx, err := internalhandler.NewContextWithAuthorization(c)
+	defer func() { internalhandler.JSONResponse(c, ctx) }()
+
+	if err != nil {
+		ctx.RespErr = fmt.Errorf("authorization Info Generation failed: err %s", err)
+		ctx.UnAuthorized = true
+		return
+	}
+
+	if !ctx.Resources.IsSystemAdmin {
+		ctx.UnAuthorized = true
+		return
+	}
+
+	ctx.Resp, ctx.RespErr = service.VerifyOperationLogChain(ctx.Logger)
+}
+
+// @Summary List Audit Log Dead Letters
+// @Description List the audit events that failed to be delivered to the sinks
+// @Tags system
+// @Accept json
+// @Produce json
+// @Param sinkID query string false "sink id"
+// @Param page query int false "page"
+// @Param perPage query int false "per page"
+// @Success 200 {object} service.AuditLogDeadLetterListResp
+// @Router /api/aslan/system/auditlog/deadletters [get]
+func ListAuditLogDeadLetters(c *gin.Context) {
+	ctx, err := internalhandler.NewContextWithAuthorization(c)
+	defer func() { internalhandler.JSONResponse(c, ctx) }()
+
+	if err != nil {
+		ctx.RespErr = fmt.Errorf("authorization Info Generation failed: err %s", err)
+		ctx.UnAuthorized = true
+		return
+	}
+
+	if !ctx.Resources.IsSystemAdmin {
+		ctx.UnAuthorized = true
+		return
+	}
+
+	page, _ := strconv.ParseInt(c.DefaultQuery("page", "1"), 10, 64)
+	perPage, _ := strconv.ParseInt(c.DefaultQuery("perPage", "20"), 10, 64)
+	ctx.Resp, ctx.RespErr = service.ListAuditLogDeadLetters(c.Query("sinkID"), page, perPage, ctx.Logger)
+}
+
+// @Summary Retry Audit Log Dead Letter
+// @Description Deliver a dead-lettered audit event again
+// @Tags system
+// @Accept json
+// @Produce json
+// @Param id path string true "dead letter id"
+// @Success 200
+// @Router /api/aslan/system/auditlog/deadletters/{id}/retry [post]
+func RetryAuditLogDeadLetter(c *gin.Context) {
+	ctx, err := internalhandler.NewContextWithAuthorization(c)
+	defer func() { internalhandler.JSONResponse(c, ctx) }()
+
+	if err != nil {
+		ctx.RespErr = fmt.Errorf("authorization Info Generation failed: err %s", err)
+		ctx.UnAuthorized = true
+		return
+	}
+
+	if !ctx.Resources.IsSystemAdmin {
+		ctx.UnAuthorized = true
+		return
+	}
+
+	ctx.RespErr = service.RetryAuditLogDeadLetter(c.Param("id"), ctx.Logger)
+}
+
+// @Summary Delete Audit Log Dead Letter
+// @Description Delete a dead-lettered audit event
+// @Tags system
+// @Accept json
+// @Produce json
+// @Param id path string true "dead letter id"
+// @Success 200
+// @Router /api/aslan/system/auditlog/deadletters/{id} [delete]
+func DeleteAuditLogDeadLetter(c *gin.Context) {
+	ctx, err := internalhandler.NewContextWithAuthorization(c)
+	defer func() { internalhandler.JSONResponse(c, ctx) }()
+
+	if err != nil {
+		ctx.RespErr = fmt.Errorf("authorization Info Generation failed: err %s", err)
+		ctx.UnAuthorized = true
+		return
+	}
+
+	if !ctx.Resources.IsSystemAdmin {
+		ctx.UnAuthorized = true
+		return
+	}
+
+	id := c.Param("id")
+	internalhandler.InsertOperationLog(c, ctx.UserName, "", "删除", "系统设置-审计日志死信", id, id, "", types.RequestBodyTypeJSON, ctx.Logger)
+
+	ctx.RespErr = service.DeleteAuditLogDeadLetter(id, ctx.Logger)
+}
@@ -19,6 +19,7 @@ package handler
 import (
 	"fmt"
 	"net/url"
+	"strings"
 
 	"github.com/gin-gonic/gin"
 
@@ -225,5 +226,9 @@ func ListCharts(c *gin.Context) {
 	ctx := internalhandler.NewContext(c)
 	defer func() { internalhandler.JSONResponse(c, ctx) }()
 
-	ctx.Resp, ctx.RespErr = service.ListCharts(c.Param("name"), ctx.Logger)
+	chartNames := make([]string, 0)
+	if chartName := c.Query("chartName"); chartName != "" {
+		chartNames = strings.Split(chartName, ",")
+	}
+	ctx.Resp, ctx.RespErr = service.ListCharts(c.Param("name"), chartNames, ctx.Logger)
 }
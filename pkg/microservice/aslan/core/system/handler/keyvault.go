@@ -399,3 +399,129 @@ func DeleteKeyVaultGroup(c *gin.Context) {
 
 	ctx.RespErr = service.DeleteKeyVaultGroup(group, projectName, isSystemVariable == "true", ctx.Logger)
 }
+
+// ListKeyVaultProviders lists the external secret backends of the keyvault
+// @Summary List KeyVault Providers
+// @Description List external keyvault providers with credentials removed
+// @Tags system
+// @Accept json
+// @Produce json
+// @Success 200 {array} commonmodels.KeyVaultProvider
+// @Router /api/aslan/system/keyvault/providers [get]
+func ListKeyVaultProviders(c *gin.Context) {
+	ctx, err := internalhandler.NewContextWithAuthorization(c)
+	defer func() { internalhandler.JSONResponse(c, ctx) }()
+
+	if err != nil {
+		ctx.RespErr = fmt.Errorf("authorization Info Generation failed: err %s", err)
+		ctx.UnAuthorized = true
+		return
+	}
+
+	ctx.Resp, ctx.RespErr = service.ListKeyVaultProviders(ctx.Logger)
+}
+
+// CreateKeyVaultProvider creates an external secret backend
+// @Summary Create KeyVault Provider
+// @Description Create an external keyvault provider, only system admins are allowed
+// @Tags system
+// @Accept json
+// @Produce json
+// @Param body body commonmodels.KeyVaultProvider true "keyvault provider"
+// @Success 200
+// @Router /api/aslan/system/keyvault/providers [post]
+func CreateKeyVaultProvider(c *gin.Context) {
+	ctx, err := internalhandler.NewContextWithAuthorization(c)
+	defer func() { internalhandler.JSONResponse(c, ctx) }()
+
+	if err != nil {
+		ctx.RespErr = fmt.Errorf("authorization Info Generation failed: err %s", err)
+		ctx.UnAuthorized = true
+		return
+	}
+
+	if !ctx.Resources.IsSystemAdmin {
+		ctx.UnAuthorized = true
+		return
+	}
+
+	args := new(commonmodels.KeyVaultProvider)
+	if err := c.ShouldBindJSON(args); err != nil {
+		ctx.RespErr = e.ErrInvalidParam.AddDesc("invalid keyvault provider args")
+		return
+	}
+
+	detail := fmt.Sprintf("name:%s type:%s", args.Name, args.Type)
+	internalhandler.InsertOperationLog(c, ctx.UserName, "", "新增", "系统设置-密钥库后端", detail, detail, "", types.RequestBodyTypeJSON, ctx.Logger)
+
+	args.UpdatedBy = ctx.UserName
+	ctx.RespErr = service.CreateKeyVaultProvider(args, ctx.Logger)
+}
+
+// UpdateKeyVaultProvider updates an external secret backend
+// @Summary Update KeyVault Provider
+// @Description Update an external keyvault provider, empty credentials keep their current values
+// @Tags system
+// @Accept json
+// @Produce json
+// @Param id path string true "provider id"
+// @Param body body commonmodels.KeyVaultProvider true "keyvault provider"
+// @Success 200
+// @Router /api/aslan/system/keyvault/providers/{id} [put]
+func UpdateKeyVaultProvider(c *gin.Context) {
+	ctx, err := internalhandler.NewContextWithAuthorization(c)
+	defer func() { internalhandler.JSONResponse(c, ctx) }()
+
+	if err != nil {
+		ctx.RespErr = fmt.Errorf("authorization Info Generation failed: err %s", err)
+		ctx.UnAuthorized = true
+		return
+	}
+
+	if !ctx.Resources.IsSystemAdmin {
+		ctx.UnAuthorized = true
+		return
+	}
+
+	args := new(commonmodels.KeyVaultProvider)
+	if err := c.ShouldBindJSON(args); err != nil {
+		ctx.RespErr = e.ErrInvalidParam.AddDesc("invalid keyvault provider args")
+		return
+	}
+
+	detail := fmt.Sprintf("id:%s name:%s type:%s", c.Param("id"), args.Name, args.Type)
+	internalhandler.InsertOperationLog(c, ctx.UserName, "", "更新", "系统设置-密钥库后端", detail, detail, "", types.RequestBodyTypeJSON, ctx.Logger)
+
+	args.UpdatedBy = ctx.UserName
+	ctx.RespErr = service.UpdateKeyVaultProvider(c.Param("id"), args, ctx.Logger)
+}
+
+// DeleteKeyVaultProvider deletes an external secret backend
+// @Summary Delete KeyVault Provider
+// @Description Delete an external keyvault provider that is not used by any keyvault item
+// @Tags system
+// @Accept json
+// @Produce json
+// @Param id path string true "provider id"
+// @Success 200
+// @Router /api/aslan/system/keyvault/providers/{id} [delete]
+func DeleteKeyVaultProvider(c *gin.Context) {
+	ctx, err := internalhandler.NewContextWithAuthorization(c)
+	defer func() { internalhandler.JSONResponse(c, ctx) }()
+
+	if err != nil {
+		ctx.RespErr = fmt.Errorf("authorization Info Generation failed: err %s", err)
+		ctx.UnAuthorized = true
+		return
+	}
+
+	if !ctx.Resources.IsSystemAdmin {
+		ctx.UnAuthorized = true
+		return
+	}
+
+	detail := fmt.Sprintf("id:%s", c.Param("id"))
+	internalhandler.InsertOperationLog(c, ctx.UserName, "", "删除", "系统设置-密钥库后端", detail, detail, "", types.RequestBodyTypeJSON, ctx.Logger)
+
+	ctx.RespErr = service.DeleteKeyVaultProvider(c.Param("id"), ctx.Logger)
+}
@@ -100,6 +100,13 @@ func (*Router) Inject(router *gin.RouterGroup) {
 		security.GET("", GetSecuritySettings)
 	}
 
+	// software bill of materials generated by build jobs
+	sbom := router.Group("sbom")
+	{
+		sbom.GET("/inventory", SearchSBOMInventory)
+		sbom.GET("/:id/download", DownloadSBOM)
+	}
+
 	// ---------------------------------------------------------------------------------------
 	// jenkins集成接口以及jobs和buildWithParameters接口
 	// ---------------------------------------------------------------------------------------
@@ -229,6 +236,16 @@ func (*Router) Inject(router *gin.RouterGroup) {
 		operation.PUT("/:id", UpdateOperationLog)
 	}
 
+	auditLog := router.Group("auditlog")
+	{
+		auditLog.GET("/settings", GetAuditLogSettings)
+		auditLog.PUT("/settings", UpdateAuditLogSettings)
+		auditLog.GET("/verify", VerifyOperationLogChain)
+		auditLog.GET("/deadletters", ListAuditLogDeadLetters)
+		auditLog.POST("/deadletters/:id/retry", RetryAuditLogDeadLetter)
+		auditLog.DELETE("/deadletters/:id", DeleteAuditLogDeadLetter)
+	}
+
 	// ---------------------------------------------------------------------------------------
 	// system external link
 	// ---------------------------------------------------------------------------------------
@@ -587,6 +604,23 @@ func (*Router) Inject(router *gin.RouterGroup) {
 		keyvault.PUT("/items/:id", UpdateKeyVaultItem)
 		keyvault.DELETE("/items/:id", DeleteKeyVaultItem)
 		keyvault.DELETE("/groups/:group", DeleteKeyVaultGroup)
+		keyvault.GET("/providers", ListKeyVaultProviders)
+		keyvault.POST("/providers", CreateKeyVaultProvider)
+		keyvault.PUT("/providers/:id", UpdateKeyVaultProvider)
+		keyvault.DELETE("/providers/:id", DeleteKeyVaultProvider)
+	}
+
+	// ---------------------------------------------------------------------------------------
+	// admission policy
+	// ---------------------------------------------------------------------------------------
+	admissionPolicy := router.Group("admission/policies")
+	{
+		admissionPolicy.GET("", ListAdmissionPolicies)
+		admissionPolicy.GET("/:id", GetAdmissionPolicy)
+		admissionPolicy.POST("", CreateAdmissionPolicy)
+		admissionPolicy.PUT("/:id", UpdateAdmissionPolicy)
+		admissionPolicy.DELETE("/:id", DeleteAdmissionPolicy)
+		admissionPolicy.POST("/:id/evaluate", EvaluateAdmissionPolicy)
 	}
 
 	// ---------------------------------------------------------------------------------------
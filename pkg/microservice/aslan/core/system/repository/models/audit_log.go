@@ -0,0 +1,50 @@
+/*
+Copyright 2025 The KodeRover Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package models
+
+import "go.mongodb.org/mongo-driver/bson/primitive"
+
+const AuditLogCheckpointKey = "checkpoint"
+
+// AuditLogState keeps the position of a consumer of the operation log chain: the export cursor of a sink, or the
+// checkpoint of the last log removed by the retention policy.
+type AuditLogState struct {
+	Key       string `bson:"_id"        json:"key"`
+	Seq       int64  `bson:"seq"        json:"seq"`
+	Hash      string `bson:"hash"       json:"hash"`
+	UpdatedAt int64  `bson:"updated_at" json:"updated_at"`
+}
+
+func (AuditLogState) TableName() string {
+	return "audit_log_state"
+}
+
+// AuditLogDeadLetter is an audit event that could not be delivered to a sink after all retries.
+type AuditLogDeadLetter struct {
+	ID        primitive.ObjectID `bson:"_id,omitempty" json:"id"`
+	SinkID    string             `bson:"sink_id"       json:"sink_id"`
+	SinkName  string             `bson:"sink_name"     json:"sink_name"`
+	LogID     string             `bson:"log_id"        json:"log_id"`
+	Seq       int64              `bson:"seq"           json:"seq"`
+	Error     string             `bson:"error"         json:"error"`
+	Attempts  int                `bson:"attempts"      json:"attempts"`
+	CreatedAt int64              `bson:"created_at"    json:"created_at"`
+}
+
+func (AuditLogDeadLetter) TableName() string {
+	return "audit_log_dead_letter"
+}
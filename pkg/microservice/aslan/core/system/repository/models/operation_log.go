@@ -17,6 +17,10 @@ limitations under the License.
 package models
 
 import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+
 	"github.com/koderover/zadig/v2/pkg/types"
 	"go.mongodb.org/mongo-driver/bson/primitive"
 )
@@ -35,8 +39,36 @@ type OperationLog struct {
 	BodyType    types.RequestBodyType `bson:"body_type"                   json:"body_type"`
 	Status      int                   `bson:"status"                      json:"status"`
 	CreatedAt   int64                 `bson:"created_at"                  json:"created_at"`
+
+	// Seq, PrevHash and Hash chain the logs together so that deleted or edited logs can be detected
+	Seq      int64  `bson:"seq,omitempty"       json:"seq,omitempty"`
+	PrevHash string `bson:"prev_hash,omitempty" json:"prev_hash,omitempty"`
+	Hash     string `bson:"hash,omitempty"      json:"hash,omitempty"`
 }
 
 func (OperationLog) TableName() string {
 	return "operation_log"
 }
+
+// ChainHash returns the hash of the log chained to the previous one. Status is not covered since it is filled in
+// after the request is handled.
+func (l *OperationLog) ChainHash() string {
+	payload, _ := json.Marshal([]interface{}{
+		l.Seq,
+		l.PrevHash,
+		l.ID.Hex(),
+		l.Username,
+		l.ProductName,
+		l.Method,
+		l.Function,
+		l.Scene,
+		l.Targets,
+		l.Name,
+		l.NameEn,
+		l.RequestBody,
+		l.BodyType,
+		l.CreatedAt,
+	})
+	sum := sha256.Sum256(payload)
+	return hex.EncodeToString(sum[:])
+}
@@ -0,0 +1,163 @@
+/*
+Copyright 2025 The KodeRover Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package mongodb
+
+import (
+	"context"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+
+	"github.com/koderover/zadig/v2/pkg/microservice/aslan/config"
+	"github.com/koderover/zadig/v2/pkg/microservice/aslan/core/system/repository/models"
+	mongotool "github.com/koderover/zadig/v2/pkg/tool/mongo"
+)
+
+type AuditLogStateColl struct {
+	*mongo.Collection
+
+	coll string
+}
+
+func NewAuditLogStateColl() *AuditLogStateColl {
+	name := models.AuditLogState{}.TableName()
+	return &AuditLogStateColl{
+		Collection: mongotool.Database(config.MongoDatabase()).Collection(name),
+		coll:       name,
+	}
+}
+
+func (c *AuditLogStateColl) GetCollectionName() string {
+	return c.coll
+}
+
+func (c *AuditLogStateColl) EnsureIndex(ctx context.Context) error {
+	return nil
+}
+
+// Get returns the state of the key, nil is returned when it has not been recorded yet.
+func (c *AuditLogStateColl) Get(key string) (*models.AuditLogState, error) {
+	resp := &models.AuditLogState{}
+	err := c.FindOne(context.TODO(), bson.M{"_id": key}).Decode(resp)
+	if err == mongo.ErrNoDocuments {
+		return nil, nil
+	}
+	return resp, err
+}
+
+func (c *AuditLogStateColl) Set(key string, seq int64, hash string) error {
+	change := bson.M{"$set": bson.M{
+		"seq":        seq,
+		"hash":       hash,
+		"updated_at": time.Now().Unix(),
+	}}
+	_, err := c.UpdateOne(context.TODO(), bson.M{"_id": key}, change, options.Update().SetUpsert(true))
+	return err
+}
+
+func (c *AuditLogStateColl) Delete(key string) error {
+	_, err := c.DeleteOne(context.TODO(), bson.M{"_id": key})
+	return err
+}
+
+type AuditLogDeadLetterColl struct {
+	*mongo.Collection
+
+	coll string
+}
+
+func NewAuditLogDeadLetterColl() *AuditLogDeadLetterColl {
+	name := models.AuditLogDeadLetter{}.TableName()
+	return &AuditLogDeadLetterColl{
+		Collection: mongotool.Database(config.MongoDatabase()).Collection(name),
+		coll:       name,
+	}
+}
+
+func (c *AuditLogDeadLetterColl) GetCollectionName() string {
+	return c.coll
+}
+
+func (c *AuditLogDeadLetterColl) EnsureIndex(ctx context.Context) error {
+	mod := mongo.IndexModel{
+		Keys: bson.D{
+			bson.E{Key: "sink_id", Value: 1},
+			bson.E{Key: "created_at", Value: -1},
+		},
+	}
+	_, err := c.Indexes().CreateOne(ctx, mod, mongotool.CreateIndexOptions(ctx))
+	return err
+}
+
+func (c *AuditLogDeadLetterColl) Create(args *models.AuditLogDeadLetter) error {
+	args.CreatedAt = time.Now().Unix()
+	_, err := c.InsertOne(context.TODO(), args)
+	return err
+}
+
+func (c *AuditLogDeadLetterColl) GetByID(id string) (*models.AuditLogDeadLetter, error) {
+	oid, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return nil, err
+	}
+
+	resp := &models.AuditLogDeadLetter{}
+	return resp, c.FindOne(context.TODO(), bson.M{"_id": oid}).Decode(resp)
+}
+
+type AuditLogDeadLetterListOption struct {
+	SinkID  string
+	Page    int64
+	PerPage int64
+}
+
+func (c *AuditLogDeadLetterColl) List(opt *AuditLogDeadLetterListOption) ([]*models.AuditLogDeadLetter, int64, error) {
+	query := bson.M{}
+	if opt.SinkID != "" {
+		query["sink_id"] = opt.SinkID
+	}
+
+	opts := options.Find().SetSort(bson.D{{Key: "created_at", Value: -1}})
+	if opt.Page > 0 && opt.PerPage > 0 {
+		opts.SetSkip((opt.Page - 1) * opt.PerPage).SetLimit(opt.PerPage)
+	}
+
+	resp := make([]*models.AuditLogDeadLetter, 0)
+	cursor, err := c.Collection.Find(context.TODO(), query, opts)
+	if err != nil {
+		return nil, 0, err
+	}
+	if err := cursor.All(context.TODO(), &resp); err != nil {
+		return nil, 0, err
+	}
+
+	count, err := c.CountDocuments(context.TODO(), query)
+	return resp, count, err
+}
+
+func (c *AuditLogDeadLetterColl) Delete(id string) error {
+	oid, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return err
+	}
+
+	_, err = c.DeleteOne(context.TODO(), bson.M{"_id": oid})
+	return err
+}
@@ -19,6 +19,7 @@ package mongodb
 import (
 	"context"
 	"errors"
+	"fmt"
 
 	"go.mongodb.org/mongo-driver/bson"
 	"go.mongodb.org/mongo-driver/bson/primitive"
@@ -87,27 +88,125 @@ func (c *OperationLogColl) EnsureIndex(ctx context.Context) error {
 			Keys:    bson.D{{Key: "created_at", Value: -1}}, // Sorting index
 			Options: options.Index().SetUnique(false),
 		},
+		{
+			// logs written before the chain was introduced have no seq
+			Keys:    bson.D{{Key: "seq", Value: 1}},
+			Options: options.Index().SetUnique(true).SetPartialFilterExpression(bson.M{"seq": bson.M{"$gt": 0}}),
+		},
 	}
 
 	_, err := c.Indexes().CreateMany(ctx, indexes, mongotool.CreateIndexOptions(ctx))
 	return err
 }
 
+// Insert appends the log to the hash chain. Concurrent writers from aslan and user service race on the unique seq
+// index, the loser reads the new head and tries again.
 func (c *OperationLogColl) Insert(args *models2.OperationLog) error {
 	if args == nil {
 		return errors.New("nil operation_log args")
 	}
+	if args.ID.IsZero() {
+		args.ID = primitive.NewObjectID()
+	}
 
-	res, err := c.InsertOne(context.TODO(), args)
-	if err != nil || res == nil {
-		return err
+	for i := 0; i < maxChainAppendAttempts; i++ {
+		headSeq, headHash, err := c.chainHead()
+		if err != nil {
+			return err
+		}
+		args.Seq = headSeq + 1
+		args.PrevHash = headHash
+		args.Hash = args.ChainHash()
+
+		_, err = c.InsertOne(context.TODO(), args)
+		if err == nil {
+			return nil
+		}
+		if !mongo.IsDuplicateKeyError(err) {
+			return err
+		}
 	}
+	return fmt.Errorf("failed to append operation log to the chain after %d attempts", maxChainAppendAttempts)
+}
 
-	if oid, ok := res.InsertedID.(primitive.ObjectID); ok {
-		args.ID = oid
+const maxChainAppendAttempts = 10
+
+// chainHead returns the seq and hash of the last log in the chain, the retention checkpoint is the head when all
+// the chained logs have been removed.
+func (c *OperationLogColl) chainHead() (int64, string, error) {
+	head, err := c.GetChainHead()
+	if err != nil {
+		return 0, "", err
+	}
+	if head != nil {
+		return head.Seq, head.Hash, nil
+	}
+
+	checkpoint, err := NewAuditLogStateColl().Get(models2.AuditLogCheckpointKey)
+	if err != nil {
+		return 0, "", err
+	}
+	if checkpoint == nil {
+		return 0, "", nil
+	}
+	return checkpoint.Seq, checkpoint.Hash, nil
+}
+
+// ListChain returns the chained logs after the seq in order.
+func (c *OperationLogColl) ListChain(afterSeq int64, limit int64) ([]*models2.OperationLog, error) {
+	resp := make([]*models2.OperationLog, 0)
+	opts := options.Find().SetSort(bson.D{{Key: "seq", Value: 1}}).SetLimit(limit)
+	cursor, err := c.Collection.Find(context.TODO(), bson.M{"seq": bson.M{"$gt": afterSeq}}, opts)
+	if err != nil {
+		return nil, err
+	}
+	return resp, cursor.All(context.TODO(), &resp)
+}
+
+// GetChainHead returns the last log in the chain, nil is returned when there is no chained log.
+func (c *OperationLogColl) GetChainHead() (*models2.OperationLog, error) {
+	resp := &models2.OperationLog{}
+	opts := options.FindOne().SetSort(bson.D{{Key: "seq", Value: -1}})
+	err := c.FindOne(context.TODO(), bson.M{"seq": bson.M{"$gt": 0}}, opts).Decode(resp)
+	if err == mongo.ErrNoDocuments {
+		return nil, nil
+	}
+	return resp, err
+}
+
+// GetLastChainedBefore returns the last chained log created before the time.
+func (c *OperationLogColl) GetLastChainedBefore(createdBefore int64) (*models2.OperationLog, error) {
+	resp := &models2.OperationLog{}
+	opts := options.FindOne().SetSort(bson.D{{Key: "seq", Value: -1}})
+	err := c.FindOne(context.TODO(), bson.M{"seq": bson.M{"$gt": 0}, "created_at": bson.M{"$lt": createdBefore}}, opts).Decode(resp)
+	if err == mongo.ErrNoDocuments {
+		return nil, nil
+	}
+	return resp, err
+}
+
+// DeleteExpired removes the chained logs up to the seq along with the logs written before the chain was introduced
+// that are older than the time.
+func (c *OperationLogColl) DeleteExpired(throughSeq, createdBefore int64) (int64, error) {
+	query := bson.M{"$or": []bson.M{
+		{"seq": bson.M{"$gt": 0, "$lte": throughSeq}},
+		{"seq": bson.M{"$exists": false}, "created_at": bson.M{"$lt": createdBefore}},
+	}}
+	res, err := c.DeleteMany(context.TODO(), query)
+	if err != nil {
+		return 0, err
+	}
+	return res.DeletedCount, nil
+}
+
+func (c *OperationLogColl) GetByID(id string) (*models2.OperationLog, error) {
+	oid, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return nil, err
 	}
 
-	return nil
+	resp := &models2.OperationLog{}
+	return resp, c.FindOne(context.TODO(), bson.M{"_id": oid}).Decode(resp)
 }
 
 func (c *OperationLogColl) Update(id string, status int) error {
@@ -0,0 +1,167 @@
+/*
+Copyright 2025 The KodeRover Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package service
+
+import (
+	"fmt"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.uber.org/zap"
+
+	"github.com/koderover/zadig/v2/pkg/microservice/aslan/config"
+	commonmodels "github.com/koderover/zadig/v2/pkg/microservice/aslan/core/common/repository/models"
+	commonrepo "github.com/koderover/zadig/v2/pkg/microservice/aslan/core/common/repository/mongodb"
+	"github.com/koderover/zadig/v2/pkg/microservice/aslan/core/common/service/admission"
+	e "github.com/koderover/zadig/v2/pkg/tool/errors"
+)
+
+func ListAdmissionPolicies(log *zap.SugaredLogger) ([]*commonmodels.AdmissionPolicy, error) {
+	resp, err := commonrepo.NewAdmissionPolicyColl().List()
+	if err != nil {
+		log.Errorf("failed to list admission policies, error: %s", err)
+		return nil, e.ErrListAdmissionPolicy.AddErr(err)
+	}
+	return resp, nil
+}
+
+func GetAdmissionPolicy(id string, log *zap.SugaredLogger) (*commonmodels.AdmissionPolicy, error) {
+	resp, err := commonrepo.NewAdmissionPolicyColl().GetByID(id)
+	if err != nil {
+		log.Errorf("failed to get admission policy %s, error: %s", id, err)
+		return nil, e.ErrListAdmissionPolicy.AddErr(err)
+	}
+	return resp, nil
+}
+
+// CreateAdmissionPolicy compiles the policy in OPA before saving it, so that a broken policy never blocks any task.
+func CreateAdmissionPolicy(args *commonmodels.AdmissionPolicy, log *zap.SugaredLogger) error {
+	if err := normalizeAdmissionPolicy(args); err != nil {
+		return e.ErrCreateAdmissionPolicy.AddErr(err)
+	}
+
+	args.ID = primitive.NewObjectID()
+	if err := checkAdmissionPolicyPackage(args); err != nil {
+		return e.ErrCreateAdmissionPolicy.AddErr(err)
+	}
+	if err := admission.Push(args); err != nil {
+		return e.ErrCreateAdmissionPolicy.AddDesc(fmt.Sprintf("invalid policy: %s", err))
+	}
+
+	if err := commonrepo.NewAdmissionPolicyColl().Create(args); err != nil {
+		log.Errorf("failed to create admission policy %s, error: %s", args.Name, err)
+		_ = admission.Remove(args)
+		return e.ErrCreateAdmissionPolicy.AddErr(err)
+	}
+	return nil
+}
+
+func UpdateAdmissionPolicy(id string, args *commonmodels.AdmissionPolicy, log *zap.SugaredLogger) error {
+	if err := normalizeAdmissionPolicy(args); err != nil {
+		return e.ErrUpdateAdmissionPolicy.AddErr(err)
+	}
+
+	policy, err := commonrepo.NewAdmissionPolicyColl().GetByID(id)
+	if err != nil {
+		return e.ErrUpdateAdmissionPolicy.AddErr(err)
+	}
+
+	args.ID = policy.ID
+	if err := checkAdmissionPolicyPackage(args); err != nil {
+		return e.ErrUpdateAdmissionPolicy.AddErr(err)
+	}
+	if err := admission.Push(args); err != nil {
+		// put the current module back since the server only keeps the last one pushed
+		_ = admission.Push(policy)
+		return e.ErrUpdateAdmissionPolicy.AddDesc(fmt.Sprintf("invalid policy: %s", err))
+	}
+
+	if err := commonrepo.NewAdmissionPolicyColl().Update(id, args); err != nil {
+		log.Errorf("failed to update admission policy %s, error: %s", id, err)
+		return e.ErrUpdateAdmissionPolicy.AddErr(err)
+	}
+	return nil
+}
+
+func DeleteAdmissionPolicy(id string, log *zap.SugaredLogger) error {
+	policy, err := commonrepo.NewAdmissionPolicyColl().GetByID(id)
+	if err != nil {
+		return e.ErrDeleteAdmissionPolicy.AddErr(err)
+	}
+
+	if err := commonrepo.NewAdmissionPolicyColl().Delete(id); err != nil {
+		log.Errorf("failed to delete admission policy %s, error: %s", id, err)
+		return e.ErrDeleteAdmissionPolicy.AddErr(err)
+	}
+	if err := admission.Remove(policy); err != nil {
+		log.Warnf("failed to remove admission policy %s from opa, error: %s", policy.Name, err)
+	}
+	return nil
+}
+
+// EvaluateAdmissionPolicy runs the policy against the input without recording the violations, it's used to try out
+// a policy before enabling it.
+func EvaluateAdmissionPolicy(id string, input *admission.Input, log *zap.SugaredLogger) ([]*admission.Violation, error) {
+	policy, err := commonrepo.NewAdmissionPolicyColl().GetByID(id)
+	if err != nil {
+		return nil, e.ErrEvaluateAdmissionPolicy.AddErr(err)
+	}
+	return admission.EvaluatePolicies([]*commonmodels.AdmissionPolicy{policy}, input), nil
+}
+
+func normalizeAdmissionPolicy(args *commonmodels.AdmissionPolicy) error {
+	if args.Name == "" {
+		return fmt.Errorf("name is required")
+	}
+
+	pkg, err := admission.ParsePackage(args.Rego)
+	if err != nil {
+		return err
+	}
+	args.Package = pkg
+
+	if len(args.Scopes) == 0 {
+		return fmt.Errorf("at least one scope is required")
+	}
+	for _, scope := range args.Scopes {
+		if scope != config.AdmissionPolicyScopeWorkflowTask && scope != config.AdmissionPolicyScopeDeploy {
+			return fmt.Errorf("unsupported scope %s", scope)
+		}
+	}
+
+	switch args.Enforcement {
+	case "":
+		args.Enforcement = config.AdmissionPolicyEnforcementDeny
+	case config.AdmissionPolicyEnforcementDeny, config.AdmissionPolicyEnforcementAudit:
+	default:
+		return fmt.Errorf("unsupported enforcement %s", args.Enforcement)
+	}
+	return nil
+}
+
+// checkAdmissionPolicyPackage makes sure the package is not used by other policies, OPA would merge their rules.
+func checkAdmissionPolicyPackage(args *commonmodels.AdmissionPolicy) error {
+	policies, err := commonrepo.NewAdmissionPolicyColl().List()
+	if err != nil {
+		return err
+	}
+	for _, policy := range policies {
+		if policy.ID != args.ID && policy.Package == args.Package {
+			return fmt.Errorf("package %s is already used by policy %s", args.Package, policy.Name)
+		}
+	}
+	return nil
+}
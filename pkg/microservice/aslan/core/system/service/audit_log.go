@@ -0,0 +1,522 @@
+/*
+Copyright 2025 The KodeRover Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sync"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.uber.org/zap"
+
+	commonmodels "github.com/koderover/zadig/v2/pkg/microservice/aslan/core/common/repository/models"
+	commonrepo "github.com/koderover/zadig/v2/pkg/microservice/aslan/core/common/repository/mongodb"
+	"github.com/koderover/zadig/v2/pkg/microservice/aslan/core/system/repository/models"
+	"github.com/koderover/zadig/v2/pkg/microservice/aslan/core/system/repository/mongodb"
+	"github.com/koderover/zadig/v2/pkg/tool/auditsink"
+	"github.com/koderover/zadig/v2/pkg/tool/cache"
+	e "github.com/koderover/zadig/v2/pkg/tool/errors"
+	"github.com/koderover/zadig/v2/pkg/tool/log"
+)
+
+const (
+	auditLogExportInterval   = 2 * time.Second
+	auditLogExportBatchSize  = 100
+	auditLogExportRoundLimit = time.Minute
+	// logs still waiting for the status of their request are held back, unless the request never reports back
+	auditLogPendingTimeout   = time.Minute
+	auditLogDeliveryAttempts = 3
+	auditLogVerifyBatchSize  = 1000
+)
+
+func GetAuditLogSettings(logger *zap.SugaredLogger) (*commonmodels.AuditLogSettings, error) {
+	systemSetting, err := commonrepo.NewSystemSettingColl().Get()
+	if err != nil {
+		logger.Errorf("failed to get system settings, error: %s", err)
+		return nil, e.ErrGetAuditLogSettings.AddErr(err)
+	}
+
+	settings := systemSetting.AuditLog
+	if settings == nil {
+		return &commonmodels.AuditLogSettings{Sinks: make([]*commonmodels.AuditLogSink, 0)}, nil
+	}
+	for _, sink := range settings.Sinks {
+		maskAuditLogSinkSecrets(sink)
+	}
+	return settings, nil
+}
+
+// UpdateAuditLogSettings saves the settings, secrets left empty keep their current values.
+func UpdateAuditLogSettings(args *commonmodels.AuditLogSettings, logger *zap.SugaredLogger) error {
+	if args.RetentionDays < 0 {
+		return e.ErrUpdateAuditLogSettings.AddDesc("retention days can't be negative")
+	}
+
+	systemSetting, err := commonrepo.NewSystemSettingColl().Get()
+	if err != nil {
+		logger.Errorf("failed to get system settings, error: %s", err)
+		return e.ErrUpdateAuditLogSettings.AddErr(err)
+	}
+	existingSinks := make(map[string]*commonmodels.AuditLogSink)
+	if systemSetting.AuditLog != nil {
+		for _, sink := range systemSetting.AuditLog.Sinks {
+			existingSinks[sink.ID] = sink
+		}
+	}
+
+	names := make(map[string]bool)
+	for _, sink := range args.Sinks {
+		if sink.Name == "" {
+			return e.ErrUpdateAuditLogSettings.AddDesc("sink name is required")
+		}
+		if names[sink.Name] {
+			return e.ErrUpdateAuditLogSettings.AddDesc(fmt.Sprintf("duplicated sink name %s", sink.Name))
+		}
+		names[sink.Name] = true
+
+		if existing, ok := existingSinks[sink.ID]; ok {
+			keepAuditLogSinkSecrets(sink, existing)
+		} else {
+			sink.ID = primitive.NewObjectID().Hex()
+		}
+		// building the sink validates its config without connecting to it
+		if _, err := auditsink.New(auditSinkConfig(sink)); err != nil {
+			return e.ErrUpdateAuditLogSettings.AddDesc(fmt.Sprintf("invalid sink %s: %s", sink.Name, err))
+		}
+	}
+
+	if err := commonrepo.NewSystemSettingColl().UpdateAuditLogSetting(args); err != nil {
+		logger.Errorf("failed to update audit log settings, error: %s", err)
+		return e.ErrUpdateAuditLogSettings.AddErr(err)
+	}
+	return nil
+}
+
+func maskAuditLogSinkSecrets(sink *commonmodels.AuditLogSink) {
+	if sink.Webhook != nil {
+		sink.Webhook.Secret = ""
+	}
+	if sink.Kafka != nil {
+		sink.Kafka.Password = ""
+		maskAuditLogSinkTLS(sink.Kafka.TLS)
+	}
+	if sink.Syslog != nil {
+		maskAuditLogSinkTLS(sink.Syslog.TLS)
+	}
+}
+
+func maskAuditLogSinkTLS(tls *commonmodels.AuditLogSinkTLS) {
+	if tls != nil {
+		tls.ClientKey = ""
+	}
+}
+
+func keepAuditLogSinkSecrets(sink, existing *commonmodels.AuditLogSink) {
+	if sink.Webhook != nil && sink.Webhook.Secret == "" && existing.Webhook != nil {
+		sink.Webhook.Secret = existing.Webhook.Secret
+	}
+	if sink.Kafka != nil && existing.Kafka != nil {
+		if sink.Kafka.Password == "" {
+			sink.Kafka.Password = existing.Kafka.Password
+		}
+		keepAuditLogSinkTLS(sink.Kafka.TLS, existing.Kafka.TLS)
+	}
+	if sink.Syslog != nil && existing.Syslog != nil {
+		keepAuditLogSinkTLS(sink.Syslog.TLS, existing.Syslog.TLS)
+	}
+}
+
+func keepAuditLogSinkTLS(tls, existing *commonmodels.AuditLogSinkTLS) {
+	if tls != nil && existing != nil && tls.ClientKey == "" {
+		tls.ClientKey = existing.ClientKey
+	}
+}
+
+func auditSinkConfig(sink *commonmodels.AuditLogSink) *auditsink.Config {
+	config := &auditsink.Config{Type: sink.Type}
+	if sink.Syslog != nil {
+		config.Syslog = &auditsink.SyslogConfig{
+			Address: sink.Syslog.Address,
+			AppName: sink.Syslog.AppName,
+			TLS:     auditSinkTLSConfig(sink.Syslog.TLS),
+		}
+	}
+	if sink.Webhook != nil {
+		config.Webhook = &auditsink.WebhookConfig{
+			URL:    sink.Webhook.URL,
+			Secret: sink.Webhook.Secret,
+		}
+	}
+	if sink.Kafka != nil {
+		config.Kafka = &auditsink.KafkaConfig{
+			RESTProxyURL: sink.Kafka.RESTProxyURL,
+			Topic:        sink.Kafka.Topic,
+			Username:     sink.Kafka.Username,
+			Password:     sink.Kafka.Password,
+			TLS:          auditSinkTLSConfig(sink.Kafka.TLS),
+		}
+	}
+	return config
+}
+
+func auditSinkTLSConfig(tls *commonmodels.AuditLogSinkTLS) *auditsink.TLSConfig {
+	if tls == nil {
+		return nil
+	}
+	return &auditsink.TLSConfig{
+		Enabled:            tls.Enabled,
+		CACert:             tls.CACert,
+		ClientCert:         tls.ClientCert,
+		ClientKey:          tls.ClientKey,
+		InsecureSkipVerify: tls.InsecureSkipVerify,
+	}
+}
+
+func auditEventFromLog(operationLog *models.OperationLog) *auditsink.Event {
+	return &auditsink.Event{
+		ID:          operationLog.ID.Hex(),
+		Seq:         operationLog.Seq,
+		Hash:        operationLog.Hash,
+		PrevHash:    operationLog.PrevHash,
+		Time:        time.Unix(operationLog.CreatedAt, 0),
+		Username:    operationLog.Username,
+		ProjectName: operationLog.ProductName,
+		Method:      operationLog.Method,
+		Function:    operationLog.Function,
+		Scene:       operationLog.Scene,
+		Targets:     operationLog.Targets,
+		Detail:      operationLog.Name,
+		DetailEn:    operationLog.NameEn,
+		Status:      operationLog.Status,
+	}
+}
+
+type OperationLogVerifyResult struct {
+	Valid   bool  `json:"valid"`
+	Checked int64 `json:"checked"`
+	// HeadSeq and HeadHash identify the newest log, compare them with the copy in the SIEM to detect a rewritten chain
+	HeadSeq   int64  `json:"head_seq"`
+	HeadHash  string `json:"head_hash"`
+	BrokenSeq int64  `json:"broken_seq,omitempty"`
+	Reason    string `json:"reason,omitempty"`
+}
+
+// VerifyOperationLogChain walks the chain from the retention checkpoint and checks every log is in place and unchanged.
+func VerifyOperationLogChain(logger *zap.SugaredLogger) (*OperationLogVerifyResult, error) {
+	checkpoint, err := mongodb.NewAuditLogStateColl().Get(models.AuditLogCheckpointKey)
+	if err != nil {
+		logger.Errorf("failed to get audit log checkpoint, error: %s", err)
+		return nil, e.ErrVerifyOperationLog.AddErr(err)
+	}
+
+	prevSeq, prevHash := int64(0), ""
+	if checkpoint != nil {
+		prevSeq, prevHash = checkpoint.Seq, checkpoint.Hash
+	}
+
+	resp := &OperationLogVerifyResult{Valid: true, HeadSeq: prevSeq, HeadHash: prevHash}
+	for {
+		logs, err := mongodb.NewOperationLogColl().ListChain(prevSeq, auditLogVerifyBatchSize)
+		if err != nil {
+			logger.Errorf("failed to list operation logs, error: %s", err)
+			return nil, e.ErrVerifyOperationLog.AddErr(err)
+		}
+
+		for _, operationLog := range logs {
+			reason := ""
+			switch {
+			case operationLog.Seq != prevSeq+1:
+				reason = fmt.Sprintf("logs %d to %d are missing", prevSeq+1, operationLog.Seq-1)
+			case operationLog.PrevHash != prevHash:
+				reason = "the previous hash doesn't match, the log before it was changed or replaced"
+			case operationLog.ChainHash() != operationLog.Hash:
+				reason = "the content doesn't match its hash"
+			}
+			if reason != "" {
+				resp.Valid = false
+				resp.BrokenSeq = operationLog.Seq
+				resp.Reason = reason
+				return resp, nil
+			}
+
+			resp.Checked++
+			resp.HeadSeq, resp.HeadHash = operationLog.Seq, operationLog.Hash
+			prevSeq, prevHash = operationLog.Seq, operationLog.Hash
+		}
+		if len(logs) < auditLogVerifyBatchSize {
+			return resp, nil
+		}
+	}
+}
+
+// CleanExpiredOperationLogs removes the logs older than the retention days. The last removed log is recorded as
+// the checkpoint the chain is verified from.
+func CleanExpiredOperationLogs() {
+	logger := log.SugaredLogger().With("func", "CleanExpiredOperationLogs")
+
+	systemSetting, err := commonrepo.NewSystemSettingColl().Get()
+	if err != nil {
+		logger.Errorf("failed to get system settings, error: %s", err)
+		return
+	}
+	if systemSetting.AuditLog == nil || systemSetting.AuditLog.RetentionDays <= 0 {
+		return
+	}
+
+	createdBefore := time.Now().AddDate(0, 0, -systemSetting.AuditLog.RetentionDays).Unix()
+	last, err := mongodb.NewOperationLogColl().GetLastChainedBefore(createdBefore)
+	if err != nil {
+		logger.Errorf("failed to find expired operation logs, error: %s", err)
+		return
+	}
+
+	throughSeq := int64(0)
+	if last != nil {
+		// the checkpoint goes first, a partial cleanup then only leaves logs the verification skips
+		if err := mongodb.NewAuditLogStateColl().Set(models.AuditLogCheckpointKey, last.Seq, last.Hash); err != nil {
+			logger.Errorf("failed to save audit log checkpoint, error: %s", err)
+			return
+		}
+		throughSeq = last.Seq
+	}
+
+	deleted, err := mongodb.NewOperationLogColl().DeleteExpired(throughSeq, createdBefore)
+	if err != nil {
+		logger.Errorf("failed to delete expired operation logs, error: %s", err)
+		return
+	}
+	logger.Infof("%d operation logs older than %d days are removed", deleted, systemSetting.AuditLog.RetentionDays)
+}
+
+type cachedAuditSink struct {
+	fingerprint string
+	sink        auditsink.Sink
+}
+
+var (
+	auditSinks   = make(map[string]*cachedAuditSink)
+	auditSinksMu sync.Mutex
+)
+
+// getAuditSink reuses the sink between export rounds so that syslog connections are kept, it's rebuilt when the
+// config changes.
+func getAuditSink(config *commonmodels.AuditLogSink) (auditsink.Sink, error) {
+	fingerprint, err := json.Marshal(config)
+	if err != nil {
+		return nil, err
+	}
+
+	auditSinksMu.Lock()
+	defer auditSinksMu.Unlock()
+
+	if cached, ok := auditSinks[config.ID]; ok {
+		if cached.fingerprint == string(fingerprint) {
+			return cached.sink, nil
+		}
+		_ = cached.sink.Close()
+		delete(auditSinks, config.ID)
+	}
+
+	sink, err := auditsink.New(auditSinkConfig(config))
+	if err != nil {
+		return nil, err
+	}
+	auditSinks[config.ID] = &cachedAuditSink{fingerprint: string(fingerprint), sink: sink}
+	return sink, nil
+}
+
+func deliverAuditEvent(sink auditsink.Sink, event *auditsink.Event) error {
+	var err error
+	for attempt := 0; attempt < auditLogDeliveryAttempts; attempt++ {
+		if attempt > 0 {
+			time.Sleep(time.Duration(1<<(attempt-1)) * time.Second)
+		}
+		ctx, cancel := context.WithTimeout(context.Background(), 15*time.Second)
+		err = sink.Send(ctx, event)
+		cancel()
+		if err == nil {
+			return nil
+		}
+	}
+	return err
+}
+
+// StartAuditLogExporter streams the operation logs to the enabled sinks. Each sink follows the chain with its own
+// cursor, events that still fail after the retries are dead-lettered so one bad event doesn't block the stream.
+func StartAuditLogExporter() {
+	logger := log.SugaredLogger().With("service", "AuditLogExporter")
+	for {
+		time.Sleep(auditLogExportInterval)
+
+		exportLock := cache.NewRedisLockWithExpiry("audit-log-export-lock", 5*time.Minute)
+		if err := exportLock.TryLock(); err != nil {
+			continue
+		}
+		exportAuditLogs(logger)
+		exportLock.Unlock()
+	}
+}
+
+func exportAuditLogs(logger *zap.SugaredLogger) {
+	systemSetting, err := commonrepo.NewSystemSettingColl().Get()
+	if err != nil {
+		logger.Errorf("failed to get system settings, error: %s", err)
+		return
+	}
+	if systemSetting.AuditLog == nil {
+		return
+	}
+
+	for _, sinkConfig := range systemSetting.AuditLog.Sinks {
+		if !sinkConfig.Enabled {
+			continue
+		}
+		if err := exportAuditLogsToSink(sinkConfig, logger); err != nil {
+			logger.Errorf("failed to export audit logs to sink %s, error: %s", sinkConfig.Name, err)
+		}
+	}
+}
+
+func exportAuditLogsToSink(sinkConfig *commonmodels.AuditLogSink, logger *zap.SugaredLogger) error {
+	cursorKey := "sink:" + sinkConfig.ID
+	cursor, err := mongodb.NewAuditLogStateColl().Get(cursorKey)
+	if err != nil {
+		return err
+	}
+	if cursor == nil {
+		// a new sink starts streaming from the current head instead of replaying the whole history
+		head, err := mongodb.NewOperationLogColl().GetChainHead()
+		if err != nil {
+			return err
+		}
+		if head == nil {
+			head = &models.OperationLog{}
+		}
+		return mongodb.NewAuditLogStateColl().Set(cursorKey, head.Seq, head.Hash)
+	}
+
+	logs, err := mongodb.NewOperationLogColl().ListChain(cursor.Seq, auditLogExportBatchSize)
+	if err != nil || len(logs) == 0 {
+		return err
+	}
+
+	sink, err := getAuditSink(sinkConfig)
+	if err != nil {
+		return err
+	}
+
+	start := time.Now()
+	for _, operationLog := range logs {
+		if operationLog.Status == 0 && time.Since(time.Unix(operationLog.CreatedAt, 0)) < auditLogPendingTimeout {
+			return nil
+		}
+		if time.Since(start) > auditLogExportRoundLimit {
+			return nil
+		}
+
+		if err := deliverAuditEvent(sink, auditEventFromLog(operationLog)); err != nil {
+			logger.Warnf("audit log %d is dead-lettered for sink %s, error: %s", operationLog.Seq, sinkConfig.Name, err)
+			deadLetter := &models.AuditLogDeadLetter{
+				SinkID:   sinkConfig.ID,
+				SinkName: sinkConfig.Name,
+				LogID:    operationLog.ID.Hex(),
+				Seq:      operationLog.Seq,
+				Error:    err.Error(),
+				Attempts: auditLogDeliveryAttempts,
+			}
+			if err := mongodb.NewAuditLogDeadLetterColl().Create(deadLetter); err != nil {
+				return fmt.Errorf("failed to save dead letter of log %d: %s", operationLog.Seq, err)
+			}
+		}
+
+		if err := mongodb.NewAuditLogStateColl().Set(cursorKey, operationLog.Seq, operationLog.Hash); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+type AuditLogDeadLetterListResp struct {
+	DeadLetters []*models.AuditLogDeadLetter `json:"dead_letters"`
+	Total       int64                        `json:"total"`
+}
+
+func ListAuditLogDeadLetters(sinkID string, page, perPage int64, logger *zap.SugaredLogger) (*AuditLogDeadLetterListResp, error) {
+	deadLetters, total, err := mongodb.NewAuditLogDeadLetterColl().List(&mongodb.AuditLogDeadLetterListOption{
+		SinkID:  sinkID,
+		Page:    page,
+		PerPage: perPage,
+	})
+	if err != nil {
+		logger.Errorf("failed to list audit log dead letters, error: %s", err)
+		return nil, e.ErrAuditLogDeadLetter.AddErr(err)
+	}
+	return &AuditLogDeadLetterListResp{DeadLetters: deadLetters, Total: total}, nil
+}
+
+// RetryAuditLogDeadLetter delivers the event again and removes the dead letter once it's delivered.
+func RetryAuditLogDeadLetter(id string, logger *zap.SugaredLogger) error {
+	deadLetter, err := mongodb.NewAuditLogDeadLetterColl().GetByID(id)
+	if err != nil {
+		return e.ErrAuditLogDeadLetter.AddErr(err)
+	}
+
+	systemSetting, err := commonrepo.NewSystemSettingColl().Get()
+	if err != nil {
+		return e.ErrAuditLogDeadLetter.AddErr(err)
+	}
+	var sinkConfig *commonmodels.AuditLogSink
+	if systemSetting.AuditLog != nil {
+		for _, sink := range systemSetting.AuditLog.Sinks {
+			if sink.ID == deadLetter.SinkID {
+				sinkConfig = sink
+			}
+		}
+	}
+	if sinkConfig == nil {
+		return e.ErrAuditLogDeadLetter.AddDesc(fmt.Sprintf("sink %s no longer exists", deadLetter.SinkName))
+	}
+
+	operationLog, err := mongodb.NewOperationLogColl().GetByID(deadLetter.LogID)
+	if err != nil {
+		return e.ErrAuditLogDeadLetter.AddDesc(fmt.Sprintf("operation log %d not found: %s", deadLetter.Seq, err))
+	}
+	sink, err := getAuditSink(sinkConfig)
+	if err != nil {
+		return e.ErrAuditLogDeadLetter.AddErr(err)
+	}
+	if err := deliverAuditEvent(sink, auditEventFromLog(operationLog)); err != nil {
+		logger.Errorf("failed to deliver audit log %d to sink %s, error: %s", deadLetter.Seq, sinkConfig.Name, err)
+		return e.ErrAuditLogDeadLetter.AddErr(err)
+	}
+
+	if err := mongodb.NewAuditLogDeadLetterColl().Delete(id); err != nil {
+		return e.ErrAuditLogDeadLetter.AddErr(err)
+	}
+	return nil
+}
+
+func DeleteAuditLogDeadLetter(id string, logger *zap.SugaredLogger) error {
+	if err := mongodb.NewAuditLogDeadLetterColl().Delete(id); err != nil {
+		logger.Errorf("failed to delete audit log dead letter %s, error: %s", id, err)
+		return e.ErrAuditLogDeadLetter.AddErr(err)
+	}
+	return nil
+}
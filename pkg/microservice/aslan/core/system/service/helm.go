@@ -20,6 +20,8 @@ import (
 	"fmt"
 
 	"go.uber.org/zap"
+	"helm.sh/helm/v3/pkg/registry"
+	"helm.sh/helm/v3/pkg/repo"
 
 	commonmodels "github.com/koderover/zadig/v2/pkg/microservice/aslan/core/common/repository/models"
 	commonrepo "github.com/koderover/zadig/v2/pkg/microservice/aslan/core/common/repository/mongodb"
@@ -59,7 +61,11 @@ func ValidateHelmRepo(args *commonmodels.HelmRepo, log *zap.SugaredLogger) error
 		return fmt.Errorf("创建 Helm 客户端失败: %s", err)
 	}
 
-	_, err = client.FetchIndexYaml(commonutil.GeneHelmRepo(args))
+	if registry.IsOCI(args.URL) {
+		err = client.ValidateOCIRegistry(commonutil.GeneHelmRepo(args))
+	} else {
+		_, err = client.FetchIndexYaml(commonutil.GeneHelmRepo(args))
+	}
 	if err != nil {
 		return fmt.Errorf("验证 Helm 仓库失败: %s", err)
 	}
@@ -83,7 +89,8 @@ func DeleteHelmRepo(id string, log *zap.SugaredLogger) error {
 	return nil
 }
 
-func ListCharts(name string, log *zap.SugaredLogger) (*IndexFileResp, error) {
+// ListCharts lists the charts in the chart repo, oci chart repos can't be enumerated so only the given charts are listed for them
+func ListCharts(name string, chartNames []string, log *zap.SugaredLogger) (*IndexFileResp, error) {
 	chartRepo, err := commonrepo.NewHelmRepoColl().Find(&commonrepo.HelmRepoFindOption{RepoName: name})
 	if err != nil {
 		return nil, err
@@ -94,7 +101,12 @@ func ListCharts(name string, log *zap.SugaredLogger) (*IndexFileResp, error) {
 		return nil, err
 	}
 
-	indexInfo, err := client.FetchIndexYaml(commonutil.GeneHelmRepo(chartRepo))
+	var indexInfo *repo.IndexFile
+	if registry.IsOCI(chartRepo.URL) {
+		indexInfo, err = client.FetchOCIIndex(commonutil.GeneHelmRepo(chartRepo), chartNames)
+	} else {
+		indexInfo, err = client.FetchIndexYaml(commonutil.GeneHelmRepo(chartRepo))
+	}
 	if err != nil {
 		return nil, err
 	}
@@ -17,8 +17,11 @@ limitations under the License.
 package service
 
 import (
+	"fmt"
+
 	"go.uber.org/zap"
 
+	"github.com/koderover/zadig/v2/pkg/microservice/aslan/config"
 	commonmodels "github.com/koderover/zadig/v2/pkg/microservice/aslan/core/common/repository/models"
 	commonrepo "github.com/koderover/zadig/v2/pkg/microservice/aslan/core/common/repository/mongodb"
 	commonservice "github.com/koderover/zadig/v2/pkg/microservice/aslan/core/common/service"
@@ -79,6 +82,10 @@ func GetKeyVaultItem(id string, log *zap.SugaredLogger) (*commonmodels.KeyVaultI
 
 // CreateKeyVaultItem creates a new keyvault item
 func CreateKeyVaultItem(args *commonmodels.KeyVaultItem, log *zap.SugaredLogger) error {
+	if err := normalizeKeyVaultItem(args); err != nil {
+		return e.ErrCreateKeyVaultItem.AddErr(err)
+	}
+
 	err := commonrepo.NewKeyVaultItemColl().Create(args)
 	if err != nil {
 		log.Errorf("KeyVaultItem.Create error: %s", err)
@@ -89,6 +96,10 @@ func CreateKeyVaultItem(args *commonmodels.KeyVaultItem, log *zap.SugaredLogger)
 
 // UpdateKeyVaultItem updates an existing keyvault item
 func UpdateKeyVaultItem(id string, args *commonmodels.KeyVaultItem, log *zap.SugaredLogger) error {
+	if err := normalizeKeyVaultItem(args); err != nil {
+		return e.ErrUpdateKeyVaultItem.AddErr(err)
+	}
+
 	err := commonrepo.NewKeyVaultItemColl().Update(id, args)
 	if err != nil {
 		log.Errorf("KeyVaultItem.Update %s error: %s", id, err)
@@ -120,3 +131,125 @@ func DeleteKeyVaultGroup(group string, projectName string, isSystemWide bool, lo
 	}
 	return nil
 }
+
+// normalizeKeyVaultItem checks the external provider of the item. Values of items in external providers stay in the
+// provider, the item only keeps the reference and is always treated as sensitive.
+func normalizeKeyVaultItem(args *commonmodels.KeyVaultItem) error {
+	if args.ProviderID == "" {
+		args.Reference = ""
+		return nil
+	}
+
+	if _, err := commonrepo.NewKeyVaultProviderColl().GetByID(args.ProviderID); err != nil {
+		return fmt.Errorf("keyvault provider %s not found: %s", args.ProviderID, err)
+	}
+	if args.Reference == "" {
+		return fmt.Errorf("reference is required for items stored in external providers")
+	}
+	args.Value = ""
+	args.IsSensitive = true
+	return nil
+}
+
+// ListKeyVaultProviders returns the external providers with their credentials removed
+func ListKeyVaultProviders(log *zap.SugaredLogger) ([]*commonmodels.KeyVaultProvider, error) {
+	providers, err := commonrepo.NewKeyVaultProviderColl().List()
+	if err != nil {
+		log.Errorf("KeyVaultProvider.List error: %s", err)
+		return nil, e.ErrListKeyVaultProvider.AddErr(err)
+	}
+
+	for _, provider := range providers {
+		if provider.Vault != nil {
+			provider.Vault.Token = ""
+		}
+		if provider.AWS != nil {
+			provider.AWS.SecretAccessKey = ""
+		}
+	}
+	return providers, nil
+}
+
+func CreateKeyVaultProvider(args *commonmodels.KeyVaultProvider, log *zap.SugaredLogger) error {
+	if err := validateKeyVaultProvider(args); err != nil {
+		return e.ErrCreateKeyVaultProvider.AddErr(err)
+	}
+
+	if err := commonrepo.NewKeyVaultProviderColl().Create(args); err != nil {
+		log.Errorf("KeyVaultProvider.Create error: %s", err)
+		return e.ErrCreateKeyVaultProvider.AddErr(err)
+	}
+	return nil
+}
+
+// UpdateKeyVaultProvider updates the provider, credentials left empty keep their current values
+func UpdateKeyVaultProvider(id string, args *commonmodels.KeyVaultProvider, log *zap.SugaredLogger) error {
+	existing, err := commonrepo.NewKeyVaultProviderColl().GetByID(id)
+	if err != nil {
+		log.Errorf("KeyVaultProvider.GetByID %s error: %s", id, err)
+		return e.ErrUpdateKeyVaultProvider.AddErr(err)
+	}
+
+	if args.Vault != nil && args.Vault.Token == "" && existing.Vault != nil {
+		args.Vault.Token = existing.Vault.Token
+	}
+	if args.AWS != nil && args.AWS.SecretAccessKey == "" && existing.AWS != nil {
+		args.AWS.SecretAccessKey = existing.AWS.SecretAccessKey
+	}
+	if err := validateKeyVaultProvider(args); err != nil {
+		return e.ErrUpdateKeyVaultProvider.AddErr(err)
+	}
+
+	if err := commonrepo.NewKeyVaultProviderColl().Update(id, args); err != nil {
+		log.Errorf("KeyVaultProvider.Update %s error: %s", id, err)
+		return e.ErrUpdateKeyVaultProvider.AddErr(err)
+	}
+	return nil
+}
+
+// DeleteKeyVaultProvider deletes the provider, providers still referenced by keyvault items can't be deleted
+func DeleteKeyVaultProvider(id string, log *zap.SugaredLogger) error {
+	count, err := commonrepo.NewKeyVaultItemColl().CountByProvider(id)
+	if err != nil {
+		log.Errorf("KeyVaultItem.CountByProvider %s error: %s", id, err)
+		return e.ErrDeleteKeyVaultProvider.AddErr(err)
+	}
+	if count > 0 {
+		return e.ErrDeleteKeyVaultProvider.AddDesc(fmt.Sprintf("%d keyvault items are stored in the provider", count))
+	}
+
+	if err := commonrepo.NewKeyVaultProviderColl().Delete(id); err != nil {
+		log.Errorf("KeyVaultProvider.Delete %s error: %s", id, err)
+		return e.ErrDeleteKeyVaultProvider.AddErr(err)
+	}
+	return nil
+}
+
+func validateKeyVaultProvider(args *commonmodels.KeyVaultProvider) error {
+	if args.Name == "" {
+		return fmt.Errorf("name is required")
+	}
+
+	switch args.Type {
+	case config.KeyVaultProviderTypeVault:
+		if args.Vault == nil || args.Vault.Address == "" || args.Vault.Token == "" {
+			return fmt.Errorf("vault address and token are required")
+		}
+		args.AWS, args.Kubernetes = nil, nil
+	case config.KeyVaultProviderTypeAWSSecretsManager:
+		if args.AWS == nil || args.AWS.Region == "" {
+			return fmt.Errorf("aws region is required")
+		}
+		args.Vault, args.Kubernetes = nil, nil
+	case config.KeyVaultProviderTypeKubernetes:
+		if args.Kubernetes == nil || args.Kubernetes.ClusterID == "" || args.Kubernetes.Namespace == "" {
+			return fmt.Errorf("cluster and namespace are required")
+		}
+		args.Vault, args.AWS = nil, nil
+	case config.KeyVaultProviderTypeNative:
+		return fmt.Errorf("the native keyvault is built in and can't be added as a provider")
+	default:
+		return fmt.Errorf("unsupported keyvault provider type: %s", args.Type)
+	}
+	return nil
+}
@@ -56,8 +56,14 @@ var OperationLogI18NMethodMap = map[string]string{
 	"重试":                 "Retry",
 	"手动执行":               "Manually Execute",
 	"重启":                 "Restart",
+	"推进":                 "Promote",
+	"中止":                 "Abort",
 	"关联":                 "Associate",
 	"扩缩容":                "Scale",
+	"登录":                 "Login",
+	"登出":                 "Logout",
+	"拒绝":                 "Deny",
+	"告警":                 "Warn",
 	"接入主机":               "Join Host",
 	"下线主机":               "Offline Host",
 	"恢复主机":               "Restore Host",
@@ -175,6 +181,9 @@ var OperationLogI18NFunctionMap = map[string]string{
 	"资源配置-镜像仓库":      "Resource Configuration - Image Repository",
 	"资源配置-集群":        "Resource Configuration - Cluster",
 	"资源管理-主机管理":      "Resource Management - Host Management",
+	"系统设置-审计日志":      "System Configuration - Audit Log",
+	"系统设置-审计日志死信":    "System Configuration - Audit Log Dead Letter",
+	"系统设置-准入策略":      "System Configuration - Admission Policy",
 
 	"模版-构建":          "Template - Build",
 	"模版-YAML":        "Template - YAML",
@@ -212,6 +221,9 @@ var OperationLogI18NFunctionMap = map[string]string{
 	"角色绑定":  "Role Binding",
 	"全局角色":  "Global Role",
 	"角色":    "Role",
+	"用户登录":  "User Login",
+	"用户登出":  "User Logout",
+	"准入策略":  "Admission Policy",
 }
 
 type OperationLogI18N struct {
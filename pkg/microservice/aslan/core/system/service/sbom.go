@@ -0,0 +1,199 @@
+/*
+Copyright 2025 The KodeRover Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package service
+
+import (
+	"fmt"
+	"io"
+	"path"
+
+	"go.uber.org/zap"
+
+	commonmodels "github.com/koderover/zadig/v2/pkg/microservice/aslan/core/common/repository/models"
+	commonrepo "github.com/koderover/zadig/v2/pkg/microservice/aslan/core/common/repository/mongodb"
+	"github.com/koderover/zadig/v2/pkg/microservice/aslan/core/common/service/s3"
+	e "github.com/koderover/zadig/v2/pkg/tool/errors"
+	s3tool "github.com/koderover/zadig/v2/pkg/tool/s3"
+	"github.com/koderover/zadig/v2/pkg/tool/sbom"
+)
+
+type SBOMInventoryResp struct {
+	// Artifacts are the built images shipping the component
+	Artifacts []*SBOMArtifact `json:"artifacts"`
+	// Deployments are the containers currently running an image shipping the component
+	Deployments []*SBOMDeployment `json:"deployments"`
+}
+
+type SBOMArtifact struct {
+	SBOMID        string            `json:"sbom_id"`
+	ProjectName   string            `json:"project_name"`
+	ServiceName   string            `json:"service_name"`
+	ServiceModule string            `json:"service_module"`
+	Image         string            `json:"image"`
+	Digest        string            `json:"digest"`
+	WorkflowName  string            `json:"workflow_name"`
+	TaskID        int64             `json:"task_id"`
+	CreateTime    int64             `json:"create_time"`
+	Components    []*sbom.Component `json:"components"`
+}
+
+type SBOMDeployment struct {
+	SBOMID      string            `json:"sbom_id"`
+	ProjectName string            `json:"project_name"`
+	EnvName     string            `json:"env_name"`
+	Production  bool              `json:"production"`
+	ServiceName string            `json:"service_name"`
+	Container   string            `json:"container"`
+	Image       string            `json:"image"`
+	Digest      string            `json:"digest"`
+	Components  []*sbom.Component `json:"components"`
+}
+
+// SearchSBOMInventory answers which artifacts ship the component and where they currently run. An image
+// deployed in an environment is counted only when its latest sbom contains the component, since tags can be
+// pushed again with different content.
+func SearchSBOMInventory(component, version string, logger *zap.SugaredLogger) (*SBOMInventoryResp, error) {
+	if component == "" {
+		return nil, e.ErrInvalidParam.AddDesc("component can't be empty")
+	}
+
+	sboms, err := commonrepo.NewSBOMColl().ListByComponent(component, version)
+	if err != nil {
+		logger.Errorf("failed to list sbom by component %s@%s, err: %s", component, version, err)
+		return nil, e.ErrGetSBOM.AddErr(err)
+	}
+
+	resp := &SBOMInventoryResp{
+		Artifacts:   make([]*SBOMArtifact, 0),
+		Deployments: make([]*SBOMDeployment, 0),
+	}
+	matchedImages := make(map[string]bool)
+	matchedSBOMs := make(map[string]*SBOMArtifact)
+	for _, s := range sboms {
+		artifact := &SBOMArtifact{
+			SBOMID:        s.ID.Hex(),
+			ProjectName:   s.ProjectName,
+			ServiceName:   s.ServiceName,
+			ServiceModule: s.ServiceModule,
+			Image:         s.Image,
+			Digest:        s.Digest,
+			WorkflowName:  s.WorkflowName,
+			TaskID:        s.TaskID,
+			CreateTime:    s.CreateTime,
+			Components:    matchComponents(s.Components, component, version),
+		}
+		if len(artifact.Components) == 0 {
+			continue
+		}
+		resp.Artifacts = append(resp.Artifacts, artifact)
+		matchedImages[s.Image] = true
+		matchedSBOMs[artifact.SBOMID] = artifact
+	}
+	if len(matchedImages) == 0 {
+		return resp, nil
+	}
+
+	envs, err := commonrepo.NewProductColl().List(&commonrepo.ProductListOptions{})
+	if err != nil {
+		logger.Errorf("failed to list environments, err: %s", err)
+		return nil, e.ErrGetSBOM.AddErr(err)
+	}
+	latestSBOMs := make(map[string]*commonmodels.SBOM)
+	for _, env := range envs {
+		for _, svc := range env.GetSvcList() {
+			for _, container := range svc.Containers {
+				if !matchedImages[container.Image] {
+					continue
+				}
+				latest, ok := latestSBOMs[container.Image]
+				if !ok {
+					latest, err = commonrepo.NewSBOMColl().FindLatestByImage(container.Image)
+					if err != nil {
+						logger.Errorf("failed to find sbom of image %s, err: %s", container.Image, err)
+						return nil, e.ErrGetSBOM.AddErr(err)
+					}
+					latestSBOMs[container.Image] = latest
+				}
+				if latest == nil {
+					continue
+				}
+				artifact, ok := matchedSBOMs[latest.ID.Hex()]
+				if !ok {
+					continue
+				}
+				resp.Deployments = append(resp.Deployments, &SBOMDeployment{
+					SBOMID:      artifact.SBOMID,
+					ProjectName: env.ProductName,
+					EnvName:     env.EnvName,
+					Production:  env.Production,
+					ServiceName: svc.ServiceName,
+					Container:   container.Name,
+					Image:       container.Image,
+					Digest:      artifact.Digest,
+					Components:  artifact.Components,
+				})
+			}
+		}
+	}
+	return resp, nil
+}
+
+func matchComponents(components []*sbom.Component, name, version string) []*sbom.Component {
+	resp := make([]*sbom.Component, 0)
+	for _, c := range components {
+		if c.Match(name, version) {
+			resp = append(resp, c)
+		}
+	}
+	return resp
+}
+
+// DownloadSBOM returns the sbom document and its file name.
+func DownloadSBOM(id string, logger *zap.SugaredLogger) ([]byte, string, error) {
+	doc, err := commonrepo.NewSBOMColl().GetByID(id)
+	if err != nil {
+		return nil, "", e.ErrGetSBOM.AddErr(err)
+	}
+
+	storage, err := s3.FindDefaultS3()
+	if err != nil {
+		return nil, "", e.ErrGetSBOM.AddErr(err)
+	}
+	if doc.StorageID != "" && doc.StorageID != storage.ID.Hex() {
+		// the default storage has changed since the sbom was archived
+		archived, err := commonrepo.NewS3StorageColl().Find(doc.StorageID)
+		if err != nil {
+			return nil, "", e.ErrGetSBOM.AddErr(fmt.Errorf("find storage %s error: %s", doc.StorageID, err))
+		}
+		storage = &s3.S3{S3Storage: archived}
+	}
+	client, err := s3tool.NewClient(storage.Endpoint, storage.Ak, storage.Sk, storage.Region, storage.Insecure, storage.Provider)
+	if err != nil {
+		return nil, "", e.ErrGetSBOM.AddErr(err)
+	}
+	object, err := client.GetFile(storage.Bucket, doc.ObjectKey, &s3tool.DownloadOption{RetryNum: 2})
+	if err != nil {
+		logger.Errorf("failed to get sbom %s, err: %s", doc.ObjectKey, err)
+		return nil, "", e.ErrGetSBOM.AddErr(err)
+	}
+	defer object.Body.Close()
+	content, err := io.ReadAll(object.Body)
+	if err != nil {
+		return nil, "", e.ErrGetSBOM.AddErr(err)
+	}
+	return content, path.Base(doc.ObjectKey), nil
+}
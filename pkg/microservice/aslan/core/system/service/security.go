@@ -17,13 +17,34 @@ limitations under the License.
 package service
 
 import (
+	"context"
+	"fmt"
+	"strings"
+
 	"go.uber.org/zap"
 
+	"github.com/koderover/zadig/v2/pkg/microservice/aslan/config"
+	commonmodels "github.com/koderover/zadig/v2/pkg/microservice/aslan/core/common/repository/models"
 	commonrepo "github.com/koderover/zadig/v2/pkg/microservice/aslan/core/common/repository/mongodb"
+	"github.com/koderover/zadig/v2/pkg/microservice/aslan/core/common/service/keyvault"
+	e "github.com/koderover/zadig/v2/pkg/tool/errors"
+	"github.com/koderover/zadig/v2/pkg/tool/imagesign"
 )
 
 func CreateOrUpdateSecuritySettings(args *SecurityAndPrivacySettings, logger *zap.SugaredLogger) error {
-	err := commonrepo.NewSystemSettingColl().UpdateSecuritySetting(args.TokenExpirationTime)
+	if err := validateImageSigningSettings(args.ImageSigning); err != nil {
+		return e.ErrInvalidParam.AddErr(err)
+	}
+	if args.SBOM != nil && args.SBOM.Enabled && !args.SBOM.Format.Valid() {
+		return e.ErrInvalidParam.AddDesc(fmt.Sprintf("unsupported sbom format %s", args.SBOM.Format))
+	}
+
+	err := commonrepo.NewSystemSettingColl().UpdateSecuritySetting(&commonmodels.SecuritySettings{
+		TokenExpirationTime:         args.TokenExpirationTime,
+		ProductionImageScanRequired: args.ProductionImageScanRequired,
+		ImageSigning:                args.ImageSigning,
+		SBOM:                        args.SBOM,
+	})
 	if err != nil {
 		logger.Errorf("failed to update security settings, error: %s", err)
 		return err
@@ -44,8 +65,14 @@ func GetSecuritySettings(logger *zap.SugaredLogger) (*SecurityAndPrivacySettings
 		return nil, err
 	}
 	var tokenExpirationTime int64 = 24
+	var productionImageScanRequired bool
+	var imageSigning *commonmodels.ImageSigningSettings
+	var sbomSettings *commonmodels.SBOMSettings
 	if systemSetting.Security != nil {
 		tokenExpirationTime = systemSetting.Security.TokenExpirationTime
+		productionImageScanRequired = systemSetting.Security.ProductionImageScanRequired
+		imageSigning = systemSetting.Security.ImageSigning
+		sbomSettings = systemSetting.Security.SBOM
 	}
 
 	var improvementPlan bool = true
@@ -53,7 +80,42 @@ func GetSecuritySettings(logger *zap.SugaredLogger) (*SecurityAndPrivacySettings
 		improvementPlan = systemSetting.Privacy.ImprovementPlan
 	}
 	return &SecurityAndPrivacySettings{
-		TokenExpirationTime: tokenExpirationTime,
-		ImprovementPlan:     improvementPlan,
+		TokenExpirationTime:         tokenExpirationTime,
+		ImprovementPlan:             improvementPlan,
+		ProductionImageScanRequired: productionImageScanRequired,
+		ImageSigning:                imageSigning,
+		SBOM:                        sbomSettings,
 	}, nil
 }
+
+func validateImageSigningSettings(settings *commonmodels.ImageSigningSettings) error {
+	if settings == nil || !settings.Enabled {
+		return nil
+	}
+
+	switch settings.KeySource {
+	case config.ImageSigningKeySourceKeyVault:
+		item, err := commonrepo.NewKeyVaultItemColl().Find(&commonrepo.KeyVaultItemFindOption{
+			Group:        settings.KeyVaultGroup,
+			Key:          settings.KeyVaultKey,
+			IsSystemWide: true,
+		})
+		if err != nil {
+			return fmt.Errorf("failed to find signing key %s/%s in the system key vault: %s", settings.KeyVaultGroup, settings.KeyVaultKey, err)
+		}
+		value, err := keyvault.ResolveItem(context.TODO(), item)
+		if err != nil {
+			return err
+		}
+		if _, err := imagesign.ParsePrivateKey([]byte(value)); err != nil {
+			return fmt.Errorf("invalid signing key %s/%s: %s", settings.KeyVaultGroup, settings.KeyVaultKey, err)
+		}
+	case config.ImageSigningKeySourceKMS:
+		if !strings.HasPrefix(settings.KMSKeyRef, imagesign.KeyRefEnvScheme) && !strings.HasPrefix(settings.KMSKeyRef, imagesign.KeyRefFileScheme) {
+			return fmt.Errorf("invalid kms key reference %s, it should start with %s or %s", settings.KMSKeyRef, imagesign.KeyRefEnvScheme, imagesign.KeyRefFileScheme)
+		}
+	default:
+		return fmt.Errorf("invalid signing key source %s", settings.KeySource)
+	}
+	return nil
+}
@@ -249,8 +249,12 @@ type OpenAPICluster struct {
 }
 
 type SecurityAndPrivacySettings struct {
-	TokenExpirationTime int64 `json:"token_expiration_time"`
-	ImprovementPlan     bool  `json:"improvement_plan"`
+	TokenExpirationTime         int64 `json:"token_expiration_time"`
+	ImprovementPlan             bool  `json:"improvement_plan"`
+	ProductionImageScanRequired bool  `json:"production_image_scan_required"`
+
+	ImageSigning *commonmodels.ImageSigningSettings `json:"image_signing"`
+	SBOM         *commonmodels.SBOMSettings         `json:"sbom"`
 }
 
 type ApolloConfig struct {
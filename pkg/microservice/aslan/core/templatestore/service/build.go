@@ -46,6 +46,11 @@ func AddBuildTemplate(userName string, build *commonmodels.BuildTemplate, logger
 	if err := commonutil.CheckDefineResourceParam(build.PreBuild.ResReq, build.PreBuild.ResReqSpec); err != nil {
 		return e.ErrCreateBuildModule.AddDesc(err.Error())
 	}
+	if build.PostBuild != nil && build.PostBuild.DockerBuild != nil {
+		if err := commonutil.CheckImageBuildBackend(build.PostBuild.DockerBuild.Backend); err != nil {
+			return e.ErrCreateBuildModule.AddDesc(err.Error())
+		}
+	}
 	build.UpdateBy = userName
 	if err := commonrepo.NewBuildTemplateColl().Create(build); err != nil {
 		log.Errorf("[Build.Upsert] %s error: %s", build.Name, err)
@@ -114,6 +119,11 @@ func UpdateBuildTemplate(id string, buildTemplate *commonmodels.BuildTemplate, l
 	if err := commonutil.CheckDefineResourceParam(buildTemplate.PreBuild.ResReq, buildTemplate.PreBuild.ResReqSpec); err != nil {
 		return e.ErrCreateBuildModule.AddDesc(err.Error())
 	}
+	if buildTemplate.PostBuild != nil && buildTemplate.PostBuild.DockerBuild != nil {
+		if err := commonutil.CheckImageBuildBackend(buildTemplate.PostBuild.DockerBuild.Backend); err != nil {
+			return e.ErrCreateBuildModule.AddDesc(err.Error())
+		}
+	}
 	return commonrepo.NewBuildTemplateColl().Update(id, buildTemplate)
 }
 
@@ -29,6 +29,11 @@ func (*Router) Inject(router *gin.RouterGroup) {
 		vm.PUT("/:vmid/agent/upgrade", UpgradeAgent)
 		vm.GET("/vms", ListVMs)
 		vm.GET("/labels", ListVMLabels)
+
+		vm.GET("/pools", ListAgentPools)
+		vm.POST("/pools", CreateAgentPool)
+		vm.PUT("/pools/:name", UpdateAgentPool)
+		vm.DELETE("/pools/:name", DeleteAgentPool)
 	}
 
 	vmAgent := router.Group("agents")
@@ -38,6 +43,7 @@ func (*Router) Inject(router *gin.RouterGroup) {
 		vmAgent.POST("/heartbeat", HeartbeatAgent)
 		vmAgent.GET("/job/request", PollingAgentJob)
 		vmAgent.POST("/job/report", ReportAgentJob)
+		vmAgent.GET("/connect", ConnectAgent)
 		vmAgent.GET("/tempFile/download/:fileId", DownloadTemporaryFile)
 	}
 }
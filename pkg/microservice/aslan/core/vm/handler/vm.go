@@ -190,6 +190,19 @@ func PollingAgentJob(c *gin.Context) {
 	ctx.Resp, ctx.RespErr = service.PollingAgentJob(token, 0, ctx.Logger)
 }
 
+func ConnectAgent(c *gin.Context) {
+	ctx := internalhandler.NewContext(c)
+	defer func() { internalhandler.JSONResponse(c, ctx) }()
+
+	token := c.Query("token")
+	if token == "" {
+		ctx.RespErr = fmt.Errorf("invalid request: %s", "token is empty")
+		return
+	}
+
+	ctx.RespErr = service.ConnectAgent(c, token, ctx.Logger)
+}
+
 func ReportAgentJob(c *gin.Context) {
 	ctx := internalhandler.NewContext(c)
 	defer func() { internalhandler.JSONResponse(c, ctx) }()
@@ -0,0 +1,287 @@
+/*
+Copyright 2025 The KodeRover Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package service
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/gorilla/websocket"
+	"go.uber.org/zap"
+
+	commonrepo "github.com/koderover/zadig/v2/pkg/microservice/aslan/core/common/repository/mongodb"
+	vmmongodb "github.com/koderover/zadig/v2/pkg/microservice/aslan/core/common/repository/mongodb/vm"
+	"github.com/koderover/zadig/v2/pkg/setting"
+	"github.com/koderover/zadig/v2/pkg/tool/log"
+)
+
+const (
+	// AgentMessageReady is sent by the agent with its free job slots and the jobs it holds, the agent sends it
+	// right after receiving a job to acknowledge it
+	AgentMessageReady = "ready"
+	// AgentMessageHeartbeat is sent by the agent with the heartbeat parameters, the server replies the heartbeat response
+	AgentMessageHeartbeat = "heartbeat"
+	// AgentMessageJob pushes a job to the agent
+	AgentMessageJob = "job"
+	// AgentMessageCancel tells the agent to cancel the running job
+	AgentMessageCancel = "cancel"
+
+	// the agent sends ready or heartbeat messages periodically, the connection is closed if nothing is received in time
+	agentConnReadTimeout  = 60 * time.Second
+	agentConnWriteTimeout = 10 * time.Second
+)
+
+// AgentMessage is the message exchanged with zadig-agent over the job connection
+type AgentMessage struct {
+	Type      string          `json:"type"`
+	JobID     string          `json:"job_id,omitempty"`
+	FreeSlots int             `json:"free_slots,omitempty"`
+	JobIDs    []string        `json:"job_ids,omitempty"`
+	Data      json.RawMessage `json:"data,omitempty"`
+}
+
+var agentUpgrader = websocket.Upgrader{
+	ReadBufferSize:  1024,
+	WriteBufferSize: 1024,
+	CheckOrigin: func(r *http.Request) bool {
+		return true
+	},
+}
+
+type agentConn struct {
+	vmID   string
+	token  string
+	ws     *websocket.Conn
+	logger *zap.SugaredLogger
+	// pollJob takes the next waiting job of the vm, it returns nil if there is none
+	pollJob func() (*PollingJobResp, error)
+
+	writeMu    sync.Mutex
+	dispatchMu sync.Mutex
+	freeSlots  int
+	// inflight holds the jobs pushed to the agent but not acknowledged yet, the free slots reported
+	// by the agent don't count them
+	inflight map[string]bool
+}
+
+func newAgentConn(vmID, token string, ws *websocket.Conn, logger *zap.SugaredLogger) *agentConn {
+	return &agentConn{
+		vmID:   vmID,
+		token:  token,
+		ws:     ws,
+		logger: logger,
+		pollJob: func() (*PollingJobResp, error) {
+			return PollingAgentJob(token, 0, logger)
+		},
+		inflight: make(map[string]bool),
+	}
+}
+
+func (c *agentConn) send(msg *AgentMessage) error {
+	c.writeMu.Lock()
+	defer c.writeMu.Unlock()
+
+	if err := c.ws.SetWriteDeadline(time.Now().Add(agentConnWriteTimeout)); err != nil {
+		return err
+	}
+	return c.ws.WriteJSON(msg)
+}
+
+// ready updates the free slots with the ready message of the agent, the jobs held by the agent are acknowledged
+// and the ones still on the way take their slots
+func (c *agentConn) ready(freeSlots int, jobIDs []string) {
+	c.dispatchMu.Lock()
+	defer c.dispatchMu.Unlock()
+
+	for _, jobID := range jobIDs {
+		delete(c.inflight, jobID)
+	}
+	c.freeSlots = freeSlots - len(c.inflight)
+	if c.freeSlots < 0 {
+		c.freeSlots = 0
+	}
+}
+
+func (c *agentConn) cancel(jobID string) error {
+	return c.send(&AgentMessage{Type: AgentMessageCancel, JobID: jobID})
+}
+
+// dispatch pushes the waiting jobs to the agent until its free slots are used up
+func (c *agentConn) dispatch() {
+	c.dispatchMu.Lock()
+	defer c.dispatchMu.Unlock()
+
+	for c.freeSlots > 0 {
+		job, err := c.pollJob()
+		if err != nil {
+			c.logger.Errorf("failed to dispatch job to vm %s, error: %s", c.vmID, err)
+			return
+		}
+		if job == nil {
+			return
+		}
+
+		data, err := json.Marshal(job)
+		if err != nil {
+			c.logger.Errorf("failed to marshal job %s, error: %s", job.ID, err)
+			return
+		}
+		if err := c.send(&AgentMessage{Type: AgentMessageJob, JobID: job.ID, Data: data}); err != nil {
+			c.logger.Errorf("failed to push job %s to vm %s, error: %s", job.ID, c.vmID, err)
+			// the job is not delivered, put it back so that other agents can take it
+			if err := vmmongodb.NewVMJobColl().UpdateStatus(job.ID, setting.VMJobStatusCreated); err != nil {
+				c.logger.Errorf("failed to requeue job %s, error: %s", job.ID, err)
+			}
+			return
+		}
+		c.inflight[job.ID] = true
+		c.freeSlots--
+	}
+}
+
+type agentConnHub struct {
+	mu    sync.RWMutex
+	conns map[string]*agentConn
+}
+
+var agentConns = &agentConnHub{conns: make(map[string]*agentConn)}
+
+func (h *agentConnHub) add(conn *agentConn) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	// an agent keeps only one job connection, the stale one is closed when it reconnects
+	if old, ok := h.conns[conn.vmID]; ok {
+		old.ws.Close()
+	}
+	h.conns[conn.vmID] = conn
+}
+
+func (h *agentConnHub) remove(conn *agentConn) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	if h.conns[conn.vmID] == conn {
+		delete(h.conns, conn.vmID)
+	}
+}
+
+func (h *agentConnHub) get(vmID string) *agentConn {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+	return h.conns[vmID]
+}
+
+func (h *agentConnHub) list() []*agentConn {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+
+	resp := make([]*agentConn, 0, len(h.conns))
+	for _, conn := range h.conns {
+		resp = append(resp, conn)
+	}
+	return resp
+}
+
+// ConnectAgent holds the job connection of zadig-agent, jobs, cancel signals and heartbeats go over it
+// instead of polling. The agent falls back to polling when the connection is broken.
+func ConnectAgent(c *gin.Context, token string, logger *zap.SugaredLogger) error {
+	vm, err := commonrepo.NewPrivateKeyColl().Find(commonrepo.FindPrivateKeyOption{
+		Token: token,
+	})
+	if err != nil {
+		logger.Errorf("failed to find vm by token %s, error: %s", token, err)
+		return fmt.Errorf("failed to find vm by token %s, error: %s", token, err)
+	}
+
+	ws, err := agentUpgrader.Upgrade(c.Writer, c.Request, nil)
+	if err != nil {
+		logger.Errorf("failed to upgrade job connection of vm %s, error: %s", vm.Name, err)
+		return err
+	}
+	defer ws.Close()
+
+	conn := newAgentConn(vm.ID.Hex(), token, ws, logger)
+	agentConns.add(conn)
+	defer agentConns.remove(conn)
+
+	logger.Infof("vm %s job connection is established", vm.Name)
+	for {
+		if err := ws.SetReadDeadline(time.Now().Add(agentConnReadTimeout)); err != nil {
+			return nil
+		}
+
+		msg := new(AgentMessage)
+		if err := ws.ReadJSON(msg); err != nil {
+			logger.Infof("vm %s job connection is closed: %s", vm.Name, err)
+			return nil
+		}
+
+		switch msg.Type {
+		case AgentMessageReady:
+			conn.ready(msg.FreeSlots, msg.JobIDs)
+			conn.dispatch()
+		case AgentMessageHeartbeat:
+			params := new(HeartbeatParameters)
+			if err := json.Unmarshal(msg.Data, params); err != nil {
+				logger.Errorf("invalid heartbeat of vm %s, error: %s", vm.Name, err)
+				continue
+			}
+			resp, err := Heartbeat(&HeartbeatRequest{Token: token, Parameters: params}, logger)
+			if err != nil {
+				continue
+			}
+			data, err := json.Marshal(resp)
+			if err != nil {
+				continue
+			}
+			if err := conn.send(&AgentMessage{Type: AgentMessageHeartbeat, Data: data}); err != nil {
+				logger.Errorf("failed to reply heartbeat to vm %s, error: %s", vm.Name, err)
+				return nil
+			}
+		}
+	}
+}
+
+// VMJobCreatedHandler pushes the new vm job to the agents connected to this aslan instance
+func VMJobCreatedHandler(jobID string) {
+	for _, conn := range agentConns.list() {
+		conn.dispatch()
+	}
+	go ScaleAgentPools()
+}
+
+// VMJobCancelledHandler tells the agent running the job to cancel it at once
+func VMJobCancelledHandler(jobID string) {
+	job, err := vmmongodb.NewVMJobColl().FindByID(jobID)
+	if err != nil {
+		log.Errorf("failed to find vm job %s, error: %s", jobID, err)
+		return
+	}
+
+	conn := agentConns.get(job.VMID)
+	if conn == nil {
+		return
+	}
+	if err := conn.cancel(jobID); err != nil {
+		log.Errorf("failed to send cancel signal of job %s to vm %s, error: %s", jobID, job.VMID, err)
+	}
+}
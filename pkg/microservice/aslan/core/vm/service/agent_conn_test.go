@@ -0,0 +1,143 @@
+/*
+Copyright 2025 The KodeRover Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package service
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gorilla/websocket"
+	"github.com/stretchr/testify/require"
+
+	"github.com/koderover/zadig/v2/pkg/tool/log"
+)
+
+// newTestAgentConn returns the server side of a job connection and the agent side of it
+func newTestAgentConn(t *testing.T, jobs []string) (*agentConn, *websocket.Conn) {
+	ast := require.New(t)
+	log.Init(&log.Config{Level: "debug"})
+
+	connCh := make(chan *websocket.Conn, 1)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ws, err := agentUpgrader.Upgrade(w, r, nil)
+		if err != nil {
+			return
+		}
+		connCh <- ws
+	}))
+	t.Cleanup(server.Close)
+
+	client, _, err := websocket.DefaultDialer.Dial("ws"+strings.TrimPrefix(server.URL, "http"), nil)
+	ast.NoError(err)
+	t.Cleanup(func() { client.Close() })
+
+	ws := <-connCh
+	t.Cleanup(func() { ws.Close() })
+
+	conn := newAgentConn("vm", "token", ws, log.SugaredLogger())
+	conn.pollJob = func() (*PollingJobResp, error) {
+		if len(jobs) == 0 {
+			return nil, nil
+		}
+		job := &PollingJobResp{ID: jobs[0]}
+		jobs = jobs[1:]
+		return job, nil
+	}
+	return conn, client
+}
+
+func readAgentMessage(t *testing.T, client *websocket.Conn) *AgentMessage {
+	ast := require.New(t)
+
+	ast.NoError(client.SetReadDeadline(time.Now().Add(time.Second)))
+	msg := new(AgentMessage)
+	ast.NoError(client.ReadJSON(msg))
+	return msg
+}
+
+func TestAgentConnDispatch(t *testing.T) {
+	ast := require.New(t)
+
+	conn, client := newTestAgentConn(t, []string{"job1", "job2", "job3", "job4"})
+
+	conn.ready(2, nil)
+	conn.dispatch()
+	for _, jobID := range []string{"job1", "job2"} {
+		msg := readAgentMessage(t, client)
+		ast.Equal(AgentMessageJob, msg.Type)
+		ast.Equal(jobID, msg.JobID)
+	}
+	ast.Equal(0, conn.freeSlots)
+	ast.Len(conn.inflight, 2)
+
+	// the ready sent before the jobs arrived doesn't count them, the jobs on the way take the slots
+	conn.ready(2, nil)
+	ast.Equal(0, conn.freeSlots)
+
+	conn.ready(1, []string{"job1"})
+	ast.Equal(0, conn.freeSlots)
+	ast.Len(conn.inflight, 1)
+
+	conn.ready(2, []string{"job2"})
+	ast.Equal(2, conn.freeSlots)
+	ast.Empty(conn.inflight)
+
+	conn.dispatch()
+	for _, jobID := range []string{"job3", "job4"} {
+		msg := readAgentMessage(t, client)
+		ast.Equal(jobID, msg.JobID)
+	}
+
+	// no waiting jobs left, the slots are kept for the next jobs
+	conn.ready(4, []string{"job3", "job4"})
+	conn.dispatch()
+	ast.Equal(4, conn.freeSlots)
+	ast.Empty(conn.inflight)
+}
+
+func TestAgentConnDispatchError(t *testing.T) {
+	ast := require.New(t)
+
+	conn, _ := newTestAgentConn(t, nil)
+	conn.pollJob = func() (*PollingJobResp, error) {
+		return nil, fmt.Errorf("vm is disabled")
+	}
+
+	conn.ready(1, nil)
+	conn.dispatch()
+	ast.Equal(1, conn.freeSlots)
+	ast.Empty(conn.inflight)
+}
+
+func TestAgentConnCancel(t *testing.T) {
+	ast := require.New(t)
+
+	conn, client := newTestAgentConn(t, nil)
+	ast.NoError(conn.cancel("job1"))
+
+	msg := readAgentMessage(t, client)
+	ast.Equal(AgentMessageCancel, msg.Type)
+	ast.Equal("job1", msg.JobID)
+
+	agentConns.add(conn)
+	defer agentConns.remove(conn)
+	ast.Equal(conn, agentConns.get("vm"))
+}
@@ -0,0 +1,404 @@
+/*
+Copyright 2025 The KodeRover Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package service
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.uber.org/zap"
+	"k8s.io/apimachinery/pkg/util/sets"
+
+	"github.com/koderover/zadig/v2/pkg/microservice/aslan/config"
+	commonmodels "github.com/koderover/zadig/v2/pkg/microservice/aslan/core/common/repository/models"
+	vmmodel "github.com/koderover/zadig/v2/pkg/microservice/aslan/core/common/repository/models/vm"
+	commonrepo "github.com/koderover/zadig/v2/pkg/microservice/aslan/core/common/repository/mongodb"
+	vmmongodb "github.com/koderover/zadig/v2/pkg/microservice/aslan/core/common/repository/mongodb/vm"
+	commonservice "github.com/koderover/zadig/v2/pkg/microservice/aslan/core/common/service"
+	"github.com/koderover/zadig/v2/pkg/setting"
+	"github.com/koderover/zadig/v2/pkg/tool/agentprovisioner"
+	"github.com/koderover/zadig/v2/pkg/tool/cache"
+	"github.com/koderover/zadig/v2/pkg/tool/log"
+)
+
+const (
+	agentPoolScaleLockKey = "vm_agent_pool_scale"
+	// agents not registered in time are treated as failed provisioning and destroyed
+	agentPoolProvisionTimeout   = 10 * 60
+	agentPoolDefaultIdleTimeout = 10 * 60
+	agentPoolProvisionDeadline  = 5 * time.Minute
+	agentPoolScaleLockExpiry    = 2 * agentPoolProvisionDeadline
+)
+
+func ListAgentPools(logger *zap.SugaredLogger) ([]*vmmodel.AgentPool, error) {
+	pools, err := vmmongodb.NewAgentPoolColl().List()
+	if err != nil {
+		logger.Errorf("failed to list agent pools, error: %s", err)
+		return nil, err
+	}
+	return pools, nil
+}
+
+func CreateAgentPool(args *vmmodel.AgentPool, user string, logger *zap.SugaredLogger) error {
+	if err := validateAgentPool(args); err != nil {
+		return err
+	}
+	if _, err := vmmongodb.NewAgentPoolColl().Find(args.Name); err == nil {
+		return fmt.Errorf("agent pool %s already exists", args.Name)
+	}
+
+	args.UpdateBy = user
+	if err := vmmongodb.NewAgentPoolColl().Create(args); err != nil {
+		logger.Errorf("failed to create agent pool %s, error: %s", args.Name, err)
+		return fmt.Errorf("failed to create agent pool %s, error: %s", args.Name, err)
+	}
+	return nil
+}
+
+func UpdateAgentPool(name string, args *vmmodel.AgentPool, user string, logger *zap.SugaredLogger) error {
+	pool, err := vmmongodb.NewAgentPoolColl().Find(name)
+	if err != nil {
+		return fmt.Errorf("agent pool %s not found", name)
+	}
+
+	// the name is referenced by the agents of the pool
+	args.ID = pool.ID
+	args.Name = pool.Name
+	args.CreateTime = pool.CreateTime
+	if err := validateAgentPool(args); err != nil {
+		return err
+	}
+
+	args.UpdateBy = user
+	if err := vmmongodb.NewAgentPoolColl().Update(name, args); err != nil {
+		logger.Errorf("failed to update agent pool %s, error: %s", name, err)
+		return fmt.Errorf("failed to update agent pool %s, error: %s", name, err)
+	}
+	return nil
+}
+
+// DeleteAgentPool destroys all the agents of the pool, the jobs running on them are lost
+func DeleteAgentPool(name string, logger *zap.SugaredLogger) error {
+	pool, err := vmmongodb.NewAgentPoolColl().Find(name)
+	if err != nil {
+		return fmt.Errorf("agent pool %s not found", name)
+	}
+
+	agents, err := commonrepo.NewPrivateKeyColl().List(&commonrepo.PrivateKeyArgs{PoolName: name})
+	if err != nil {
+		return fmt.Errorf("failed to list agents of pool %s, error: %s", name, err)
+	}
+	provisioner, err := newAgentProvisioner(pool)
+	if err != nil {
+		return err
+	}
+	defer provisioner.Close()
+	for _, agent := range agents {
+		if err := destroyPoolAgent(provisioner, agent); err != nil {
+			return err
+		}
+	}
+
+	if err := vmmongodb.NewAgentPoolColl().Delete(name); err != nil {
+		logger.Errorf("failed to delete agent pool %s, error: %s", name, err)
+		return fmt.Errorf("failed to delete agent pool %s, error: %s", name, err)
+	}
+	return nil
+}
+
+func validateAgentPool(pool *vmmodel.AgentPool) error {
+	if !config.CVMNameRegex.MatchString(pool.Name) {
+		return fmt.Errorf("invalid agent pool name %s, only letters, digits and underscores are allowed and it can't start with a digit", pool.Name)
+	}
+	if pool.Label == "" {
+		return fmt.Errorf("label of agent pool is required")
+	}
+	if pool.MaxAgents <= 0 || pool.MinAgents < 0 || pool.MinAgents > pool.MaxAgents {
+		return fmt.Errorf("invalid agent number range [%d, %d]", pool.MinAgents, pool.MaxAgents)
+	}
+	if pool.Ephemeral {
+		pool.TaskConcurrency = 1
+	}
+	if pool.TaskConcurrency <= 0 {
+		return fmt.Errorf("task concurrency of agent pool must be positive")
+	}
+	if pool.IdleTimeout <= 0 {
+		pool.IdleTimeout = agentPoolDefaultIdleTimeout
+	}
+
+	provisioner, err := newAgentProvisioner(pool)
+	if err != nil {
+		return err
+	}
+	return provisioner.Close()
+}
+
+func newAgentProvisioner(pool *vmmodel.AgentPool) (agentprovisioner.Provisioner, error) {
+	if pool.Provisioner == nil {
+		return nil, fmt.Errorf("provisioner of agent pool %s is not configured", pool.Name)
+	}
+
+	cfg := &agentprovisioner.Config{Type: pool.Provisioner.Type}
+	if pool.Provisioner.Docker != nil {
+		cfg.Docker = &agentprovisioner.DockerConfig{
+			Host:    pool.Provisioner.Docker.Host,
+			Image:   pool.Provisioner.Docker.Image,
+			Network: pool.Provisioner.Docker.Network,
+			Envs:    pool.Provisioner.Docker.Envs,
+		}
+	}
+	return agentprovisioner.New(cfg)
+}
+
+// ScaleAgentPools creates agents for the vm jobs queued on the pool labels, and destroys the idle agents and
+// the ephemeral agents whose job finished. Only one aslan instance scales the pools at a time.
+func ScaleAgentPools() {
+	lock := cache.NewRedisLockWithExpiry(agentPoolScaleLockKey, agentPoolScaleLockExpiry)
+	if err := lock.TryLock(); err != nil {
+		return
+	}
+	defer lock.Unlock()
+
+	// a provisioner call takes up to agentPoolProvisionDeadline, no call is started after the deadline so that
+	// the pass ends before the lock expires, the agents left are created or destroyed by the next pass
+	deadline := time.Now().Add(agentPoolScaleLockExpiry - agentPoolProvisionDeadline)
+
+	pools, err := vmmongodb.NewAgentPoolColl().List()
+	if err != nil {
+		log.Errorf("failed to list agent pools, error: %s", err)
+		return
+	}
+	if len(pools) == 0 {
+		return
+	}
+
+	queuedJobs, err := vmmongodb.NewVMJobColl().ListByOpts(&vmmongodb.VMJobOpts{Status: string(config.StatusCreated)})
+	if err != nil {
+		log.Errorf("failed to list queued vm jobs, error: %s", err)
+		return
+	}
+	runningJobs := make(map[string]int)
+	for _, status := range []config.Status{config.StatusPrepare, config.StatusRunning} {
+		jobs, err := vmmongodb.NewVMJobColl().ListByOpts(&vmmongodb.VMJobOpts{Status: string(status)})
+		if err != nil {
+			log.Errorf("failed to list %s vm jobs, error: %s", status, err)
+			return
+		}
+		for _, job := range jobs {
+			runningJobs[job.VMID]++
+		}
+	}
+
+	for _, pool := range pools {
+		if !pool.Enabled {
+			continue
+		}
+		if time.Now().After(deadline) {
+			return
+		}
+		if err := scaleAgentPool(pool, queuedJobs, runningJobs, deadline); err != nil {
+			log.Errorf("failed to scale agent pool %s, error: %s", pool.Name, err)
+		}
+	}
+}
+
+func scaleAgentPool(pool *vmmodel.AgentPool, queuedJobs []*vmmodel.VMJob, runningJobs map[string]int, deadline time.Time) error {
+	provisioner, err := newAgentProvisioner(pool)
+	if err != nil {
+		return err
+	}
+	defer provisioner.Close()
+	canProvision := func() bool {
+		return time.Now().Before(deadline)
+	}
+	agents, err := commonrepo.NewPrivateKeyColl().List(&commonrepo.PrivateKeyArgs{PoolName: pool.Name})
+	if err != nil {
+		return fmt.Errorf("failed to list agents, error: %s", err)
+	}
+
+	// only the jobs asking for the pool label explicitly scale the pool
+	queued := 0
+	for _, job := range queuedJobs {
+		if sets.NewString(job.VMLabels...).Has(pool.Label) {
+			queued++
+		}
+	}
+
+	now := time.Now().Unix()
+	alive, freeSlots := 0, 0
+	idleAgents := make([]*commonmodels.PrivateKey, 0)
+	for _, agent := range agents {
+		running := runningJobs[agent.ID.Hex()]
+		switch {
+		case agent.Status == setting.VMOffline:
+			// destroyed by the previous round but failed to clean up
+			if !canProvision() {
+				continue
+			}
+			if err := destroyPoolAgent(provisioner, agent); err != nil {
+				log.Errorf("failed to destroy agent %s, error: %s", agent.Name, err)
+			}
+			continue
+		case agent.PoolAgent.Ephemeral && agent.PoolAgent.JobID != "" && running == 0:
+			if !canProvision() {
+				continue
+			}
+			if err := destroyPoolAgent(provisioner, agent); err != nil {
+				log.Errorf("failed to destroy ephemeral agent %s, error: %s", agent.Name, err)
+			}
+			continue
+		case agent.Status != setting.VMNormal && now-agent.CreateTime > agentPoolProvisionTimeout:
+			log.Warnf("agent %s of pool %s is not ready in time, status: %s", agent.Name, pool.Name, agent.Status)
+			if !canProvision() {
+				continue
+			}
+			if err := destroyPoolAgent(provisioner, agent); err != nil {
+				log.Errorf("failed to destroy agent %s, error: %s", agent.Name, err)
+			}
+			continue
+		}
+
+		alive++
+		if agent.PoolAgent.Ephemeral && agent.PoolAgent.JobID != "" {
+			continue
+		}
+		if slots := pool.TaskConcurrency - running; slots > 0 {
+			freeSlots += slots
+		}
+
+		idleSince := agent.PoolAgent.IdleSince
+		if running > 0 {
+			idleSince = 0
+		} else if idleSince == 0 {
+			idleSince = now
+		}
+		if idleSince != agent.PoolAgent.IdleSince {
+			if err := commonrepo.NewPrivateKeyColl().UpdatePoolAgentIdleSince(agent.ID.Hex(), idleSince); err != nil {
+				log.Errorf("failed to update agent %s, error: %s", agent.Name, err)
+			}
+		}
+		if idleSince > 0 && now-idleSince >= pool.IdleTimeout {
+			idleAgents = append(idleAgents, agent)
+		}
+	}
+
+	toCreate := 0
+	if queued > freeSlots {
+		toCreate = (queued - freeSlots + pool.TaskConcurrency - 1) / pool.TaskConcurrency
+	}
+	if alive+toCreate < pool.MinAgents {
+		toCreate = pool.MinAgents - alive
+	}
+	if alive+toCreate > pool.MaxAgents {
+		toCreate = pool.MaxAgents - alive
+	}
+
+	for i := 0; i < toCreate && canProvision(); i++ {
+		if err := createPoolAgent(provisioner, pool); err != nil {
+			return err
+		}
+	}
+
+	if queued > 0 {
+		return nil
+	}
+	for _, agent := range idleAgents {
+		if alive <= pool.MinAgents || !canProvision() {
+			break
+		}
+		if err := destroyPoolAgent(provisioner, agent); err != nil {
+			log.Errorf("failed to destroy idle agent %s, error: %s", agent.Name, err)
+			continue
+		}
+		alive--
+	}
+	return nil
+}
+
+func createPoolAgent(provisioner agentprovisioner.Provisioner, pool *vmmodel.AgentPool) error {
+	serverURL, err := commonservice.GetSystemServerURL()
+	if err != nil {
+		return fmt.Errorf("failed to get system server URL: %s", err)
+	}
+
+	agent := &commonmodels.PrivateKey{
+		Name:             fmt.Sprintf("%s_%s", pool.Name, primitive.NewObjectID().Hex()[16:]),
+		Description:      fmt.Sprintf("created by agent pool %s", pool.Name),
+		Label:            pool.Label,
+		Status:           setting.VMCreated,
+		ScheduleWorkflow: true,
+		Type:             setting.NewVMType,
+		UpdateBy:         setting.SystemUser,
+		Agent: &commonmodels.VMAgent{
+			Token:           GenerateAgentToken(),
+			Workspace:       pool.Workspace,
+			TaskConcurrency: pool.TaskConcurrency,
+		},
+		PoolAgent: &commonmodels.PoolAgent{
+			PoolName:  pool.Name,
+			Ephemeral: pool.Ephemeral,
+		},
+	}
+	if err := commonrepo.NewPrivateKeyColl().Create(agent); err != nil {
+		return fmt.Errorf("failed to create agent of pool %s, error: %s", pool.Name, err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), agentPoolProvisionDeadline)
+	defer cancel()
+	instanceID, err := provisioner.Create(ctx, &agentprovisioner.AgentSpec{
+		Name:      agent.Name,
+		ServerURL: serverURL,
+		Token:     agent.Agent.Token,
+	})
+	if err != nil {
+		if err := commonrepo.NewPrivateKeyColl().Delete(agent.ID.Hex()); err != nil {
+			log.Errorf("failed to delete agent %s, error: %s", agent.Name, err)
+		}
+		return err
+	}
+
+	if err := commonrepo.NewPrivateKeyColl().UpdatePoolAgentInstanceID(agent.ID.Hex(), instanceID); err != nil {
+		return fmt.Errorf("failed to update agent %s, error: %s", agent.Name, err)
+	}
+	log.Infof("agent %s of pool %s is created", agent.Name, pool.Name)
+	return nil
+}
+
+// destroyPoolAgent takes the agent offline first so that it doesn't take new jobs, then destroys its instance
+func destroyPoolAgent(provisioner agentprovisioner.Provisioner, agent *commonmodels.PrivateKey) error {
+	if agent.Status != setting.VMOffline {
+		if err := commonrepo.NewPrivateKeyColl().UpdateStatusByID(agent.ID.Hex(), setting.VMOffline); err != nil {
+			return fmt.Errorf("failed to offline agent %s, error: %s", agent.Name, err)
+		}
+	}
+
+	if agent.PoolAgent.InstanceID != "" {
+		ctx, cancel := context.WithTimeout(context.Background(), agentPoolProvisionDeadline)
+		defer cancel()
+		if err := provisioner.Destroy(ctx, agent.PoolAgent.InstanceID); err != nil {
+			return err
+		}
+	}
+
+	if err := commonrepo.NewPrivateKeyColl().Delete(agent.ID.Hex()); err != nil {
+		return fmt.Errorf("failed to delete agent %s, error: %s", agent.Name, err)
+	}
+	log.Infof("agent %s of pool %s is destroyed", agent.Name, agent.PoolAgent.PoolName)
+	return nil
+}
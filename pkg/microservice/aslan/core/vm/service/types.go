@@ -55,6 +55,13 @@ type AgentBriefListResp struct {
 	IP           string `json:"ip"`
 }
 
+type AgentMetrics struct {
+	Name            string
+	HeartbeatAge    int64
+	TaskConcurrency int
+	RunningJobs     int
+}
+
 type RegisterAgentParameters struct {
 	IP            string `json:"ip"`
 	OS            string `json:"os"`
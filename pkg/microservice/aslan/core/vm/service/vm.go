@@ -17,6 +17,7 @@ limitations under the License.
 package service
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"strings"
@@ -36,6 +37,7 @@ import (
 	commonrepo "github.com/koderover/zadig/v2/pkg/microservice/aslan/core/common/repository/mongodb"
 	vmmongodb "github.com/koderover/zadig/v2/pkg/microservice/aslan/core/common/repository/mongodb/vm"
 	commonservice "github.com/koderover/zadig/v2/pkg/microservice/aslan/core/common/service"
+	"github.com/koderover/zadig/v2/pkg/microservice/aslan/core/common/service/workflowcontroller/jobcontroller"
 	systemservice "github.com/koderover/zadig/v2/pkg/microservice/aslan/core/system/service"
 	"github.com/koderover/zadig/v2/pkg/setting"
 	e "github.com/koderover/zadig/v2/pkg/tool/errors"
@@ -356,6 +358,45 @@ func ListVMs(logger *zap.SugaredLogger) ([]*AgentBriefListResp, error) {
 	return resp, nil
 }
 
+// ListAgentMetrics returns the heartbeat and concurrency usage of the vm agents for prometheus
+func ListAgentMetrics() ([]*AgentMetrics, error) {
+	vms, err := commonrepo.NewPrivateKeyColl().List(&commonrepo.PrivateKeyArgs{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list VMs, error: %s", err)
+	}
+
+	runningJobs := make(map[string]int)
+	for _, status := range []config.Status{config.StatusPrepare, config.StatusRunning} {
+		jobs, err := vmmongodb.NewVMJobColl().ListByOpts(&vmmongodb.VMJobOpts{Status: string(status)})
+		if err != nil {
+			return nil, fmt.Errorf("failed to list %s vm jobs, error: %s", status, err)
+		}
+		for _, job := range jobs {
+			runningJobs[job.VMID]++
+		}
+	}
+
+	now := time.Now().Unix()
+	resp := make([]*AgentMetrics, 0, len(vms))
+	for _, vm := range vms {
+		if vm.Agent == nil {
+			continue
+		}
+
+		m := &AgentMetrics{
+			Name:            vm.Name,
+			TaskConcurrency: vm.Agent.TaskConcurrency,
+			RunningJobs:     runningJobs[vm.ID.Hex()],
+		}
+		if vm.Agent.LastHeartbeatTime > 0 {
+			m.HeartbeatAge = now - vm.Agent.LastHeartbeatTime
+		}
+		resp = append(resp, m)
+	}
+
+	return resp, nil
+}
+
 func ListVMLabels(projectKey string, logger *zap.SugaredLogger) ([]string, error) {
 	vms, err := commonrepo.NewPrivateKeyColl().List(&commonrepo.PrivateKeyArgs{})
 	if err != nil {
@@ -557,6 +598,10 @@ func PollingAgentJob(token string, retry int, logger *zap.SugaredLogger) (*Polli
 	if vm.Status != setting.VMNormal {
 		return nil, fmt.Errorf("vm %s status is %s", vm.Name, vm.Status)
 	}
+	// an ephemeral agent of agent pool runs only one job
+	if vm.PoolAgent != nil && vm.PoolAgent.Ephemeral && vm.PoolAgent.JobID != "" {
+		return nil, nil
+	}
 
 	var resp *PollingJobResp
 	jobs, err := vmmongodb.NewVMJobColl().ListByOpts(&vmmongodb.VMJobOpts{
@@ -590,6 +635,32 @@ func PollingAgentJob(token string, retry int, logger *zap.SugaredLogger) (*Polli
 			logger.Errorf("failed to update job %s, error: %s", job.ID.Hex(), err)
 			return nil, fmt.Errorf("failed to update job %s, error: %s", job.ID.Hex(), err)
 		}
+		if vm.PoolAgent != nil && vm.PoolAgent.Ephemeral {
+			if err := commonrepo.NewPrivateKeyColl().UpdatePoolAgentJobID(vm.ID.Hex(), job.ID.Hex()); err != nil {
+				logger.Errorf("failed to update vm %s, error: %s", vm.Name, err)
+			}
+		}
+
+		// the stored job context keeps the placeholders of external keyvault items, they are resolved only when
+		// the context is handed to the agent
+		jobCtx, err := jobcontroller.ResolveJobContextSecrets(context.Background(), []byte(job.JobCtx))
+		if err != nil {
+			job.Status = string(config.StatusFailed)
+			job.Error = fmt.Sprintf("failed to resolve keyvault secrets: %s", err)
+			if err := vmmongodb.NewVMJobColl().Update(job.ID.Hex(), job); err != nil {
+				logger.Errorf("failed to update job %s, error: %s", job.ID.Hex(), err)
+			}
+			return nil, fmt.Errorf("job %s %s", job.ID.Hex(), job.Error)
+		}
+		jobCtx, err = jobcontroller.ResolveJobContainerAuth(jobCtx)
+		if err != nil {
+			job.Status = string(config.StatusFailed)
+			job.Error = fmt.Sprintf("failed to resolve job container registry: %s", err)
+			if err := vmmongodb.NewVMJobColl().Update(job.ID.Hex(), job); err != nil {
+				logger.Errorf("failed to update job %s, error: %s", job.ID.Hex(), err)
+			}
+			return nil, fmt.Errorf("job %s %s", job.ID.Hex(), job.Error)
+		}
 
 		resp = &PollingJobResp{
 			ID:            job.ID.Hex(),
@@ -600,7 +671,7 @@ func PollingAgentJob(token string, retry int, logger *zap.SugaredLogger) (*Polli
 			JobName:       job.JobName,
 			JobType:       job.JobType,
 			Status:        job.Status,
-			JobCtx:        job.JobCtx,
+			JobCtx:        string(jobCtx),
 		}
 	} else {
 		retry++
@@ -28,6 +28,7 @@ import (
 	"github.com/koderover/zadig/v2/pkg/microservice/aslan/core/workflow/service/webhook"
 	internalhandler "github.com/koderover/zadig/v2/pkg/shared/handler"
 	"github.com/koderover/zadig/v2/pkg/tool/gitee"
+	"github.com/koderover/zadig/v2/pkg/tool/metrics"
 )
 
 func ProcessGitWebHook(c *gin.Context) {
@@ -41,16 +42,27 @@ func ProcessGitWebHook(c *gin.Context) {
 	}
 
 	if github.WebHookType(c.Request) != "" {
+		defer trackWebhookInFlight("github")()
 		ctx.RespErr = processGithub(payload, c.Request, ctx.RequestID, ctx.Logger)
 	} else if gitlab.HookEventType(c.Request) != "" {
+		defer trackWebhookInFlight("gitlab")()
 		ctx.RespErr = webhook.ProcessGitlabHook(payload, c.Request, ctx.RequestID, ctx.Logger)
 	} else if gitee.HookEventType(c.Request) != "" {
+		defer trackWebhookInFlight("gitee")()
 		ctx.RespErr = webhook.ProcessGiteeHook(payload, c.Request, ctx.RequestID, ctx.Logger)
 	} else {
+		defer trackWebhookInFlight("gerrit")()
 		ctx.RespErr = webhook.ProcessGerritHook(payload, c.Request, ctx.RequestID, ctx.Logger)
 	}
 }
 
+func trackWebhookInFlight(source string) func() {
+	metrics.WebhookInFlight.WithLabelValues(source).Inc()
+	return func() {
+		metrics.WebhookInFlight.WithLabelValues(source).Dec()
+	}
+}
+
 func processGithub(payload []byte, req *http.Request, requestID string, log *zap.SugaredLogger) error {
 	errs := &multierror.Error{}
 
@@ -100,6 +100,8 @@ func CreateJobController(job *commonmodels.Job, workflow *commonmodels.WorkflowV
 		return CreateRestartJobController(job, workflow)
 	case config.JobZadigDistributeImage:
 		return CreateDistributeImageJobController(job, workflow)
+	case config.JobZadigImageScan:
+		return CreateImageScanJobController(job, workflow)
 	case config.JobDMS:
 		return CreateDMSJobController(job, workflow)
 	case config.JobFreestyle:
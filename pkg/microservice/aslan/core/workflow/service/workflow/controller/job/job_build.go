@@ -31,6 +31,7 @@ import (
 	commonrepo "github.com/koderover/zadig/v2/pkg/microservice/aslan/core/common/repository/mongodb"
 	commonservice "github.com/koderover/zadig/v2/pkg/microservice/aslan/core/common/service"
 	"github.com/koderover/zadig/v2/pkg/microservice/aslan/core/common/service/repository"
+	"github.com/koderover/zadig/v2/pkg/microservice/aslan/core/common/service/resourceprofile"
 	templ "github.com/koderover/zadig/v2/pkg/microservice/aslan/core/common/service/template"
 	commonutil "github.com/koderover/zadig/v2/pkg/microservice/aslan/core/common/util"
 	codehostrepo "github.com/koderover/zadig/v2/pkg/microservice/systemconfig/core/codehost/repository/mongodb"
@@ -324,6 +325,8 @@ func (j BuildJobController) ToTask(taskID int64) ([]*commonmodels.JobTask, error
 	if err != nil {
 		return nil, err
 	}
+	signImage := imageSigningEnabled()
+	buildSBOMFormat := sbomFormat()
 
 	defaultS3, err := commonrepo.NewS3StorageColl().FindDefault()
 	if err != nil {
@@ -369,25 +372,28 @@ func (j BuildJobController) ToTask(taskID int64) ([]*commonmodels.JobTask, error
 				"service_module": build.ServiceModule,
 				JobNameKey:       j.name,
 			},
-			Key:            genJobKey(j.name, build.ServiceName, build.ServiceModule),
-			Name:           GenJobName(j.workflow, j.name, jobSubTaskID),
-			DisplayName:    genJobDisplayName(j.name, build.ServiceName, build.ServiceModule),
-			OriginName:     j.name,
-			JobType:        string(config.JobZadigBuild),
-			Spec:           jobTaskSpec,
-			Timeout:        int64(buildInfo.Timeout),
-			Outputs:        outputs,
-			Infrastructure: buildInfo.Infrastructure,
-			VMLabels:       buildInfo.VMLabels,
-			ErrorPolicy:    j.errorPolicy,
-			ExecutePolicy:  j.executePolicy,
+			Key:             genJobKey(j.name, build.ServiceName, build.ServiceModule),
+			Name:            GenJobName(j.workflow, j.name, jobSubTaskID),
+			DisplayName:     genJobDisplayName(j.name, build.ServiceName, build.ServiceModule),
+			OriginName:      j.name,
+			JobType:         string(config.JobZadigBuild),
+			Spec:            jobTaskSpec,
+			Timeout:         int64(buildInfo.Timeout),
+			Outputs:         outputs,
+			Infrastructure:  buildInfo.Infrastructure,
+			VMLabels:        buildInfo.VMLabels,
+			VMExecutionMode: buildInfo.VMExecutionMode,
+			ErrorPolicy:     j.errorPolicy,
+			ExecutePolicy:   j.executePolicy,
 		}
 		customEnvs := applyKeyVals(buildInfo.PreBuild.Envs.ToRuntimeList(), build.KeyVals, true).ToKVList()
+		resReq, resReqSpec := resourceprofile.ResolveRequest(buildInfo.PreBuild.ResReq, buildInfo.PreBuild.ResReqSpec, buildInfo.ProductName, resourceprofile.ConfigTypeBuild, buildInfo.Name, logger)
 
 		jobTaskSpec.Properties = commonmodels.JobProperties{
 			Timeout:             int64(buildInfo.Timeout),
-			ResourceRequest:     buildInfo.PreBuild.ResReq,
-			ResReqSpec:          buildInfo.PreBuild.ResReqSpec,
+			ResourceRequest:     resReq,
+			ResReqSpec:          resReqSpec,
+			ResourceProfile:     &commonmodels.ResourceProfile{ProjectName: buildInfo.ProductName, ConfigType: resourceprofile.ConfigTypeBuild, ConfigName: buildInfo.Name},
 			CustomEnvs:          customEnvs,
 			ClusterID:           buildInfo.PreBuild.ClusterID,
 			StrategyID:          buildInfo.PreBuild.StrategyID,
@@ -596,6 +602,13 @@ func (j BuildJobController) ToTask(taskID int64) ([]*commonmodels.JobTask, error
 				}
 			}
 
+			// vm agents always build with their own docker daemon
+			imageBuildBackend := setting.ImageBuildBackendDocker
+			if jobTask.Infrastructure != setting.JobVMInfrastructure {
+				imageBuildBackend = getImageBuildBackend(buildInfo.PostBuild.DockerBuild, jobTaskSpec.Properties.ClusterID)
+			}
+			jobTaskSpec.Properties.ImageBuildBackend = imageBuildBackend
+
 			dockerBuildStep := &commonmodels.StepTask{
 				Name:     build.ServiceName + "-docker-build",
 				JobName:  jobTask.Name,
@@ -610,6 +623,8 @@ func (j BuildJobController) ToTask(taskID int64) ([]*commonmodels.JobTask, error
 					DockerTemplateContent: dockefileContent,
 					EnableBuildkit:        buildInfo.PostBuild.DockerBuild.EnableBuildkit,
 					Platform:              buildInfo.PostBuild.DockerBuild.Platform,
+					Platforms:             buildInfo.PostBuild.DockerBuild.Platforms,
+					Backend:               imageBuildBackend,
 					BuildKitImage:         config.BuildKitImage(),
 					DockerRegistry: &step.DockerRegistry{
 						DockerRegistryID: j.jobSpec.DockerRegistryID,
@@ -622,6 +637,38 @@ func (j BuildJobController) ToTask(taskID int64) ([]*commonmodels.JobTask, error
 				},
 			}
 			jobTaskSpec.Steps = append(jobTaskSpec.Steps, dockerBuildStep)
+
+			if signImage {
+				// the docker build step pushes $IMAGE, sign the same one
+				jobTaskSpec.Steps = append(jobTaskSpec.Steps, newImageSignStep(build.ServiceName+"-image-sign", jobTask.Name, getRegistry(registry), []*step.ImageSignTarget{
+					{
+						ServiceName:   build.ServiceName,
+						ServiceModule: build.ServiceModule,
+						Image:         "${IMAGE}",
+					},
+				}))
+			}
+
+			if buildSBOMFormat != "" {
+				jobTaskSpec.Steps = append(jobTaskSpec.Steps, &commonmodels.StepTask{
+					Name:     build.ServiceName + "-sbom",
+					JobName:  jobTask.Name,
+					StepType: config.StepSBOM,
+					Spec: &step.StepSBOMSpec{
+						Registry: getRegistry(registry),
+						Targets: []*step.SBOMTarget{
+							{
+								ServiceName:   build.ServiceName,
+								ServiceModule: build.ServiceModule,
+								Image:         "${IMAGE}",
+							},
+						},
+						Format:    buildSBOMFormat,
+						DestDir:   "/tmp/sbom",
+						S3DestDir: path.Join(j.workflow.Name, fmt.Sprint(taskID), jobTask.Name, "sbom"),
+					},
+				})
+			}
 		}
 
 		// init object cache step
@@ -1397,3 +1444,18 @@ func ensureBuiltInBuildOutputs(outputs []*commonmodels.Output) []*commonmodels.O
 	}
 	return outputs
 }
+
+// getImageBuildBackend returns the image build backend of the build, which falls back to the one of the cluster running the job.
+func getImageBuildBackend(dockerBuild *commonmodels.DockerBuild, clusterID string) string {
+	if dockerBuild.Backend != "" {
+		return dockerBuild.Backend
+	}
+	if clusterID == "" {
+		clusterID = setting.LocalClusterID
+	}
+	cluster, err := commonrepo.NewK8SClusterColl().Get(clusterID)
+	if err != nil || cluster.AdvancedConfig == nil || cluster.AdvancedConfig.ImageBuildBackend == "" {
+		return setting.ImageBuildBackendDocker
+	}
+	return cluster.AdvancedConfig.ImageBuildBackend
+}
@@ -21,6 +21,7 @@ import (
 	"strings"
 
 	"golang.org/x/exp/slices"
+	"k8s.io/apimachinery/pkg/util/sets"
 
 	"github.com/koderover/zadig/v2/pkg/microservice/aslan/config"
 	commonmodels "github.com/koderover/zadig/v2/pkg/microservice/aslan/core/common/repository/models"
@@ -29,6 +30,7 @@ import (
 	commonservice "github.com/koderover/zadig/v2/pkg/microservice/aslan/core/common/service"
 	"github.com/koderover/zadig/v2/pkg/microservice/aslan/core/common/service/kube"
 	"github.com/koderover/zadig/v2/pkg/microservice/aslan/core/common/service/repository"
+	"github.com/koderover/zadig/v2/pkg/microservice/aslan/core/common/service/servicedependency"
 	commontypes "github.com/koderover/zadig/v2/pkg/microservice/aslan/core/common/types"
 	aslanUtil "github.com/koderover/zadig/v2/pkg/microservice/aslan/core/common/util"
 	"github.com/koderover/zadig/v2/pkg/setting"
@@ -140,6 +142,7 @@ func (j DeployJobController) Update(useUserInput bool, ticket *commonmodels.Appr
 	}
 	j.jobSpec.SkipCheckRunStatus = latestSpec.SkipCheckRunStatus
 	j.jobSpec.SkipCheckHelmWorkloadStatus = latestSpec.SkipCheckHelmWorkloadStatus
+	j.jobSpec.DependencyOrder = latestSpec.DependencyOrder
 	j.jobSpec.DeployContents = latestSpec.DeployContents
 	j.jobSpec.ServiceVariableConfig = latestSpec.ServiceVariableConfig
 	j.jobSpec.DefaultServices = latestSpec.DefaultServices
@@ -700,6 +703,53 @@ func (j DeployJobController) ToTask(taskID int64) ([]*commonmodels.JobTask, erro
 		}
 	}
 
+	if j.jobSpec.DependencyOrder {
+		resp, err = orderByServiceDependency(resp, product)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return resp, nil
+}
+
+// orderByServiceDependency sorts the deploy job tasks by the service dependency graph of the env,
+// and makes every job task wait for the ones deploying the services it depends on
+func orderByServiceDependency(jobTasks []*commonmodels.JobTask, product *commonmodels.Product) ([]*commonmodels.JobTask, error) {
+	g, err := servicedependency.GetEnvGraph(product)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get service dependency graph of env %s, error: %s", product.EnvName, err)
+	}
+
+	resp := make([]*commonmodels.JobTask, 0, len(jobTasks))
+	services := make([]string, 0, len(jobTasks))
+	serviceJobMap := make(map[string]*commonmodels.JobTask)
+	for _, jobTask := range jobTasks {
+		jobInfo, ok := jobTask.JobInfo.(map[string]string)
+		if !ok || jobInfo["service_name"] == "" {
+			resp = append(resp, jobTask)
+			continue
+		}
+		serviceName := jobInfo["service_name"]
+		services = append(services, serviceName)
+		serviceJobMap[serviceName] = jobTask
+	}
+
+	deps := g.SubsetDependencies(services)
+	placed := sets.NewString()
+	for _, layer := range g.Layers(services) {
+		for _, serviceName := range layer {
+			jobTask := serviceJobMap[serviceName]
+			// services depending on each other in a cycle are deployed together
+			for _, dep := range deps[serviceName] {
+				if placed.Has(dep) {
+					jobTask.DependsOn = append(jobTask.DependsOn, serviceJobMap[dep].Name)
+				}
+			}
+			resp = append(resp, jobTask)
+		}
+		placed.Insert(layer...)
+	}
 	return resp, nil
 }
 
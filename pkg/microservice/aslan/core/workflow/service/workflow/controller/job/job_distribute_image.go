@@ -276,6 +276,18 @@ func (j DistributeImageJobController) ToTask(taskID int64) ([]*commonmodels.JobT
 			},
 		},
 	}
+	// nothing is pushed by the job with the cloud sync method, the registry syncs the images by itself
+	if imageSigningEnabled() && j.jobSpec.DistributeMethod != config.DistributeImageMethodCloudSync {
+		signTargets := make([]*step.ImageSignTarget, 0)
+		for _, target := range stepSpec.DistributeTarget {
+			signTargets = append(signTargets, &step.ImageSignTarget{
+				ServiceName:   target.ServiceName,
+				ServiceModule: target.ServiceModule,
+				Distribute:    target,
+			})
+		}
+		jobTaskSpec.Steps = append(jobTaskSpec.Steps, newImageSignStep("image-sign", "", stepSpec.TargetRegistry, signTargets))
+	}
 	jobTask := &commonmodels.JobTask{
 		Name:        GenJobName(j.workflow, j.name, 0),
 		Key:         genJobKey(j.name),
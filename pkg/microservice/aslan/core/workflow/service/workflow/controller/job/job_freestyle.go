@@ -647,8 +647,9 @@ func (j FreestyleJobController) generateSubTask(taskID int64, jobSubTaskID int,
 		ErrorPolicy:   j.errorPolicy,
 		ExecutePolicy: j.executePolicy,
 
-		Infrastructure: j.jobSpec.Runtime.Infrastructure,
-		VMLabels:       j.jobSpec.Runtime.VMLabels,
+		Infrastructure:  j.jobSpec.Runtime.Infrastructure,
+		VMLabels:        j.jobSpec.Runtime.VMLabels,
+		VMExecutionMode: j.jobSpec.Runtime.VMExecutionMode,
 	}
 
 	serviceName := ""
@@ -0,0 +1,372 @@
+/*
+Copyright 2025 The KodeRover Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package job
+
+import (
+	"fmt"
+	"path"
+	"strings"
+
+	"github.com/koderover/zadig/v2/pkg/microservice/aslan/config"
+	commonmodels "github.com/koderover/zadig/v2/pkg/microservice/aslan/core/common/repository/models"
+	commonservice "github.com/koderover/zadig/v2/pkg/microservice/aslan/core/common/service"
+	"github.com/koderover/zadig/v2/pkg/microservice/aslan/core/common/service/repository"
+	"github.com/koderover/zadig/v2/pkg/microservice/aslan/core/common/util"
+	"github.com/koderover/zadig/v2/pkg/setting"
+	"github.com/koderover/zadig/v2/pkg/tool/log"
+	"github.com/koderover/zadig/v2/pkg/types"
+	"github.com/koderover/zadig/v2/pkg/types/job"
+	"github.com/koderover/zadig/v2/pkg/types/step"
+)
+
+const (
+	ImageScanTimeout        int64 = 30
+	ImageScanReportFileName       = "image-scan-report.json"
+)
+
+type ImageScanJobController struct {
+	*BasicInfo
+
+	jobSpec *commonmodels.ZadigImageScanJobSpec
+}
+
+func CreateImageScanJobController(job *commonmodels.Job, workflow *commonmodels.WorkflowV4) (Job, error) {
+	spec := new(commonmodels.ZadigImageScanJobSpec)
+	if err := commonmodels.IToi(job.Spec, spec); err != nil {
+		return nil, fmt.Errorf("failed to create image scan job controller, error: %s", err)
+	}
+
+	basicInfo := &BasicInfo{
+		name:          job.Name,
+		jobType:       job.JobType,
+		errorPolicy:   job.ErrorPolicy,
+		executePolicy: job.ExecutePolicy,
+		workflow:      workflow,
+	}
+
+	return ImageScanJobController{
+		BasicInfo: basicInfo,
+		jobSpec:   spec,
+	}, nil
+}
+
+func (j ImageScanJobController) SetWorkflow(wf *commonmodels.WorkflowV4) {
+	j.workflow = wf
+}
+
+func (j ImageScanJobController) GetSpec() interface{} {
+	return j.jobSpec
+}
+
+func (j ImageScanJobController) Validate(isExecution bool) error {
+	// the scan step runs trivy, it is not part of the job image
+	trivyInstalled := false
+	for _, install := range j.jobSpec.Installs {
+		if install != nil && strings.EqualFold(install.Name, "trivy") {
+			trivyInstalled = true
+			break
+		}
+	}
+	if !trivyInstalled {
+		return fmt.Errorf("trivy must be installed in image scan job %s", j.name)
+	}
+
+	if j.jobSpec.Policy != nil {
+		for _, severity := range j.jobSpec.Policy.Severities {
+			switch strings.ToUpper(severity) {
+			case step.ImageScanSeverityCritical, step.ImageScanSeverityHigh, step.ImageScanSeverityMedium, step.ImageScanSeverityLow, step.ImageScanSeverityUnknown:
+			default:
+				return fmt.Errorf("invalid severity %s in image scan job %s", severity, j.name)
+			}
+		}
+	}
+
+	if j.jobSpec.Source != config.SourceFromJob {
+		return nil
+	}
+	jobRankMap := GetJobRankMap(j.workflow.Stages)
+	referredJobRank, ok := jobRankMap[j.jobSpec.JobName]
+	if !ok || referredJobRank >= jobRankMap[j.name] {
+		return fmt.Errorf("can not quote job %s in job %s", j.jobSpec.JobName, j.name)
+	}
+
+	return nil
+}
+
+func (j ImageScanJobController) Update(useUserInput bool, ticket *commonmodels.ApprovalTicket) error {
+	latestJob, err := j.workflow.FindJob(j.name, j.jobType)
+	if err != nil {
+		return err
+	}
+
+	latestSpec := new(commonmodels.ZadigImageScanJobSpec)
+	if err := commonmodels.IToi(latestJob.Spec, latestSpec); err != nil {
+		return fmt.Errorf("failed to decode image scan job spec, error: %s", err)
+	}
+
+	j.errorPolicy = latestJob.ErrorPolicy
+	j.executePolicy = latestJob.ExecutePolicy
+
+	if useUserInput {
+		if j.jobSpec.Source == config.SourceFromJob && latestSpec.Source == config.SourceRuntime {
+			j.jobSpec.Targets = make([]*commonmodels.ImageScanTarget, 0)
+		}
+	} else {
+		j.jobSpec.Targets = latestSpec.Targets
+	}
+	j.jobSpec.Source = latestSpec.Source
+
+	if j.jobSpec.Source == config.SourceFromJob {
+		j.jobSpec.JobName = latestSpec.JobName
+	} else {
+		j.jobSpec.RegistryID = latestSpec.RegistryID
+	}
+
+	j.jobSpec.Installs = latestSpec.Installs
+	j.jobSpec.Policy = latestSpec.Policy
+	j.jobSpec.Timeout = latestSpec.Timeout
+	j.jobSpec.ClusterID = latestSpec.ClusterID
+	j.jobSpec.ClusterSource = latestSpec.ClusterSource
+	j.jobSpec.StrategyID = latestSpec.StrategyID
+	j.jobSpec.CustomAnnotations = latestSpec.CustomAnnotations
+	j.jobSpec.CustomLabels = latestSpec.CustomLabels
+
+	return nil
+}
+
+func (j ImageScanJobController) SetOptions(ticket *commonmodels.ApprovalTicket) error {
+	servicesMap, err := repository.GetMaxRevisionsServicesMap(j.workflow.Project, false)
+	if err != nil {
+		return fmt.Errorf("get services map error: %v", err)
+	}
+
+	options := make([]*commonmodels.ImageScanTarget, 0)
+	for _, svc := range servicesMap {
+		for _, module := range svc.Containers {
+			if ticket.IsAllowedService(j.workflow.Project, svc.ServiceName, module.Name) {
+				options = append(options, &commonmodels.ImageScanTarget{
+					ServiceName:   svc.ServiceName,
+					ServiceModule: module.Name,
+					ImageName:     util.ExtractImageName(module.Image),
+				})
+			}
+		}
+	}
+
+	j.jobSpec.TargetOptions = options
+	return nil
+}
+
+func (j ImageScanJobController) ClearOptions() {
+	j.jobSpec.TargetOptions = make([]*commonmodels.ImageScanTarget, 0)
+}
+
+func (j ImageScanJobController) ClearSelection() {
+	j.jobSpec.Targets = make([]*commonmodels.ImageScanTarget, 0)
+}
+
+func (j ImageScanJobController) ToTask(taskID int64) ([]*commonmodels.JobTask, error) {
+	logger := log.SugaredLogger()
+	resp := make([]*commonmodels.JobTask, 0)
+
+	switch j.jobSpec.Source {
+	case config.SourceFromJob:
+		serviceReferredJob := getOriginJobName(j.workflow, j.jobSpec.JobName)
+		targets, registryID, err := j.getReferredJobTargets(serviceReferredJob, j.jobSpec.JobName)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get referred job info for image scan job: %s, error: %s", j.name, err)
+		}
+		j.jobSpec.RegistryID = registryID
+		j.jobSpec.Targets = targets
+	case config.SourceRuntime:
+		reg, err := commonservice.FindRegistryById(j.jobSpec.RegistryID, true, logger)
+		if err != nil {
+			return resp, fmt.Errorf("image registry: %s not found: %v", j.jobSpec.RegistryID, err)
+		}
+		for _, target := range j.jobSpec.Targets {
+			if target.ImageName == "" {
+				target.Image = getImage(target.ServiceModule, target.Tag, reg)
+			} else {
+				target.Image = getImage(target.ImageName, target.Tag, reg)
+			}
+		}
+	}
+
+	reg, err := commonservice.FindRegistryById(j.jobSpec.RegistryID, true, logger)
+	if err != nil {
+		return resp, fmt.Errorf("image registry: %s not found: %v", j.jobSpec.RegistryID, err)
+	}
+
+	jobName := GenJobName(j.workflow, j.name, 0)
+	stepSpec := &step.StepImageScanSpec{
+		Registry:  getRegistry(reg),
+		Targets:   make([]*step.ImageScanTarget, 0),
+		DestDir:   "/tmp",
+		S3DestDir: path.Join(j.workflow.Name, fmt.Sprint(taskID), jobName, "image-scan"),
+		FileName:  ImageScanReportFileName,
+	}
+	if j.jobSpec.Policy != nil {
+		stepSpec.Policy = &step.ImageScanPolicy{
+			Severities:  j.jobSpec.Policy.Severities,
+			FixableOnly: j.jobSpec.Policy.FixableOnly,
+		}
+	}
+	for _, target := range j.jobSpec.Targets {
+		stepSpec.Targets = append(stepSpec.Targets, &step.ImageScanTarget{
+			ServiceName:   target.ServiceName,
+			ServiceModule: target.ServiceModule,
+			Image:         target.Image,
+		})
+	}
+
+	tools := []*step.Tool{}
+	for _, tool := range j.jobSpec.Installs {
+		tools = append(tools, &step.Tool{
+			Name:    tool.Name,
+			Version: tool.Version,
+		})
+	}
+
+	timeout := j.jobSpec.Timeout
+	if timeout == 0 {
+		timeout = ImageScanTimeout
+	}
+
+	jobTaskSpec := &commonmodels.JobTaskFreestyleSpec{
+		Properties: commonmodels.JobProperties{
+			Timeout:           timeout,
+			ResourceRequest:   setting.MinRequest,
+			ClusterID:         j.jobSpec.ClusterID,
+			StrategyID:        j.jobSpec.StrategyID,
+			BuildOS:           "focal",
+			ImageFrom:         commonmodels.ImageFromKoderover,
+			CustomAnnotations: j.jobSpec.CustomAnnotations,
+			CustomLabels:      j.jobSpec.CustomLabels,
+		},
+		Steps: []*commonmodels.StepTask{
+			{
+				Name:     "tool-install",
+				JobName:  jobName,
+				StepType: config.StepTools,
+				Spec:     step.StepToolInstallSpec{Installs: tools},
+			},
+			{
+				Name:     "image-scan",
+				JobName:  jobName,
+				StepType: config.StepImageScan,
+				Spec:     stepSpec,
+			},
+		},
+	}
+	jobTask := &commonmodels.JobTask{
+		Name:        jobName,
+		Key:         genJobKey(j.name),
+		DisplayName: genJobDisplayName(j.name),
+		OriginName:  j.name,
+		JobInfo: map[string]string{
+			JobNameKey: j.name,
+		},
+		JobType:       string(config.JobZadigImageScan),
+		Spec:          jobTaskSpec,
+		Timeout:       timeout,
+		ErrorPolicy:   j.errorPolicy,
+		ExecutePolicy: j.executePolicy,
+	}
+	resp = append(resp, jobTask)
+
+	return resp, nil
+}
+
+func (j ImageScanJobController) SetRepo(repo *types.Repository) error {
+	return nil
+}
+
+func (j ImageScanJobController) SetRepoCommitInfo() error {
+	return nil
+}
+
+func (j ImageScanJobController) GetVariableList(jobName string, getAggregatedVariables, getRuntimeVariables, getPlaceHolderVariables, getServiceSpecificVariables, useUserInputValue bool) ([]*commonmodels.KeyVal, error) {
+	return make([]*commonmodels.KeyVal, 0), nil
+}
+
+func (j ImageScanJobController) GetUsedRepos() ([]*types.Repository, error) {
+	return make([]*types.Repository, 0), nil
+}
+
+func (j ImageScanJobController) RenderDynamicVariableOptions(key string, option *RenderDynamicVariableValue) ([]string, error) {
+	return nil, fmt.Errorf("invalid job type: %s to render dynamic variable", j.name)
+}
+
+func (j ImageScanJobController) IsServiceTypeJob() bool {
+	return true
+}
+
+// getReferredJobTargets gets the images produced by the referred build or distribute job, the image itself is
+// the output variable of the referred job which is rendered when the scan job runs.
+func (j ImageScanJobController) getReferredJobTargets(serviceReferredJob, imageReferredJob string) ([]*commonmodels.ImageScanTarget, string, error) {
+	targets := make([]*commonmodels.ImageScanTarget, 0)
+	var registryID string
+	found := false
+serviceLoop:
+	for _, stage := range j.workflow.Stages {
+		for _, job := range stage.Jobs {
+			if job.Name != serviceReferredJob {
+				continue
+			}
+			switch job.JobType {
+			case config.JobZadigBuild:
+				buildSpec := &commonmodels.ZadigBuildJobSpec{}
+				if err := commonmodels.IToi(job.Spec, buildSpec); err != nil {
+					return nil, "", fmt.Errorf("failed to decode build job spec, error: %s", err)
+				}
+				for _, build := range buildSpec.ServiceAndBuilds {
+					targets = append(targets, &commonmodels.ImageScanTarget{
+						ServiceName:   build.ServiceName,
+						ServiceModule: build.ServiceModule,
+					})
+				}
+				registryID = buildSpec.DockerRegistryID
+				found = true
+				break serviceLoop
+			case config.JobZadigDistributeImage:
+				distributeSpec := &commonmodels.ZadigDistributeImageJobSpec{}
+				if err := commonmodels.IToi(job.Spec, distributeSpec); err != nil {
+					return nil, "", fmt.Errorf("failed to decode distribute job spec, error: %s", err)
+				}
+				for _, distribute := range distributeSpec.Targets {
+					targets = append(targets, &commonmodels.ImageScanTarget{
+						ServiceName:   distribute.ServiceName,
+						ServiceModule: distribute.ServiceModule,
+					})
+				}
+				registryID = distributeSpec.TargetRegistryID
+				found = true
+				break serviceLoop
+			}
+		}
+	}
+
+	if !found {
+		return nil, "", fmt.Errorf("ImageScanJob: referred job %s not found", serviceReferredJob)
+	}
+
+	for _, target := range targets {
+		target.Image = job.GetJobOutputKey(fmt.Sprintf("%s.%s.%s", imageReferredJob, target.ServiceName, target.ServiceModule), IMAGEKEY)
+	}
+
+	return targets, registryID, nil
+}
@@ -32,6 +32,7 @@ import (
 	commonmodels "github.com/koderover/zadig/v2/pkg/microservice/aslan/core/common/repository/models"
 	commonrepo "github.com/koderover/zadig/v2/pkg/microservice/aslan/core/common/repository/mongodb"
 	commonservice "github.com/koderover/zadig/v2/pkg/microservice/aslan/core/common/service"
+	"github.com/koderover/zadig/v2/pkg/microservice/aslan/core/common/service/resourceprofile"
 	commonutil "github.com/koderover/zadig/v2/pkg/microservice/aslan/core/common/util"
 	codehostrepo "github.com/koderover/zadig/v2/pkg/microservice/systemconfig/core/codehost/repository/mongodb"
 	"github.com/koderover/zadig/v2/pkg/setting"
@@ -639,26 +640,30 @@ func (j TestingJobController) toJobTask(jobSubTaskID int, testing *commonmodels.
 		}
 	}
 
+	resReq, resReqSpec := resourceprofile.ResolveRequest(testingInfo.PreTest.ResReq, testingInfo.PreTest.ResReqSpec, testingInfo.ProductName, resourceprofile.ConfigTypeTesting, testingInfo.Name, logger)
+
 	jobTaskSpec := &commonmodels.JobTaskFreestyleSpec{}
 	jobTask := &commonmodels.JobTask{
-		Key:            jobKey,
-		Name:           jobName,
-		DisplayName:    jobDisplayName,
-		OriginName:     j.name,
-		JobInfo:        jobInfo,
-		JobType:        string(config.JobZadigTesting),
-		Spec:           jobTaskSpec,
-		Timeout:        int64(testingInfo.Timeout),
-		Outputs:        testingInfo.Outputs,
-		Infrastructure: testingInfo.Infrastructure,
-		VMLabels:       testingInfo.VMLabels,
-		ErrorPolicy:    j.errorPolicy,
-		ExecutePolicy:  j.executePolicy,
+		Key:             jobKey,
+		Name:            jobName,
+		DisplayName:     jobDisplayName,
+		OriginName:      j.name,
+		JobInfo:         jobInfo,
+		JobType:         string(config.JobZadigTesting),
+		Spec:            jobTaskSpec,
+		Timeout:         int64(testingInfo.Timeout),
+		Outputs:         testingInfo.Outputs,
+		Infrastructure:  testingInfo.Infrastructure,
+		VMLabels:        testingInfo.VMLabels,
+		VMExecutionMode: testingInfo.VMExecutionMode,
+		ErrorPolicy:     j.errorPolicy,
+		ExecutePolicy:   j.executePolicy,
 	}
 	jobTaskSpec.Properties = commonmodels.JobProperties{
 		Timeout:             int64(testingInfo.Timeout),
-		ResourceRequest:     testingInfo.PreTest.ResReq,
-		ResReqSpec:          testingInfo.PreTest.ResReqSpec,
+		ResourceRequest:     resReq,
+		ResReqSpec:          resReqSpec,
+		ResourceProfile:     &commonmodels.ResourceProfile{ProjectName: testingInfo.ProductName, ConfigType: resourceprofile.ConfigTypeTesting, ConfigName: testingInfo.Name},
 		CustomEnvs:          customEnvs,
 		ClusterID:           testingInfo.PreTest.ClusterID,
 		StrategyID:          testingInfo.PreTest.StrategyID,
@@ -826,6 +831,31 @@ func (j TestingJobController) toJobTask(jobSubTaskID int, testing *commonmodels.
 	}
 	jobTaskSpec.Steps = append(jobTaskSpec.Steps, debugAfterStep)
 
+	// init mock verify step
+	if len(testingInfo.MockAssertions) > 0 {
+		if jobTask.Infrastructure == setting.JobVMInfrastructure {
+			return jobTask, fmt.Errorf("mock assertions of testing %s are not supported on vm infrastructure", testing.Name)
+		}
+		targets := make([]*step.MockVerifyTarget, 0, len(testingInfo.MockAssertions))
+		for _, assertion := range testingInfo.MockAssertions {
+			targets = append(targets, &step.MockVerifyTarget{
+				EnvName:     commonutil.RenderEnv(assertion.EnvName, jobTaskSpec.Properties.Envs),
+				ServiceName: assertion.ServiceName,
+				Assertion:   assertion,
+			})
+		}
+		jobTaskSpec.Steps = append(jobTaskSpec.Steps, &commonmodels.StepTask{
+			Name:     testing.Name + "-mock_verify",
+			JobName:  jobTask.Name,
+			StepType: config.StepMockVerify,
+			Spec: &step.StepMockVerifySpec{
+				ProjectName: j.workflow.Project,
+				ClusterID:   jobTaskSpec.Properties.ClusterID,
+				Targets:     targets,
+			},
+		})
+	}
+
 	tarDestDir := "/tmp"
 	if testingInfo.ScriptType == types.ScriptTypeBatchFile {
 		tarDestDir = "%TMP%"
@@ -30,9 +30,12 @@ import (
 	configbase "github.com/koderover/zadig/v2/pkg/config"
 	"github.com/koderover/zadig/v2/pkg/microservice/aslan/config"
 	commonmodels "github.com/koderover/zadig/v2/pkg/microservice/aslan/core/common/repository/models"
+	commonrepo "github.com/koderover/zadig/v2/pkg/microservice/aslan/core/common/repository/mongodb"
 	commonservice "github.com/koderover/zadig/v2/pkg/microservice/aslan/core/common/service"
 	commonutil "github.com/koderover/zadig/v2/pkg/microservice/aslan/core/common/util"
 	"github.com/koderover/zadig/v2/pkg/setting"
+	"github.com/koderover/zadig/v2/pkg/tool/log"
+	"github.com/koderover/zadig/v2/pkg/tool/sbom"
 	"github.com/koderover/zadig/v2/pkg/types"
 	"github.com/koderover/zadig/v2/pkg/types/job"
 	"github.com/koderover/zadig/v2/pkg/types/step"
@@ -695,3 +698,39 @@ func renderParams(origin, input []*commonmodels.Param) []*commonmodels.Param {
 
 	return resp
 }
+
+// imageSigningEnabled tells whether the images pushed by build and distribute jobs should be signed.
+func imageSigningEnabled() bool {
+	systemSetting, err := commonrepo.NewSystemSettingColl().Get()
+	if err != nil {
+		log.Errorf("failed to get system settings, error: %s", err)
+		return false
+	}
+	return systemSetting.Security != nil && systemSetting.Security.ImageSigning != nil && systemSetting.Security.ImageSigning.Enabled
+}
+
+// sbomFormat returns the format of the sbom generated for the images pushed by build jobs, empty when disabled.
+func sbomFormat() sbom.Format {
+	systemSetting, err := commonrepo.NewSystemSettingColl().Get()
+	if err != nil {
+		log.Errorf("failed to get system settings, error: %s", err)
+		return ""
+	}
+	if systemSetting.Security == nil || systemSetting.Security.SBOM == nil || !systemSetting.Security.SBOM.Enabled {
+		return ""
+	}
+	return systemSetting.Security.SBOM.Format
+}
+
+// newImageSignStep creates the step signing the given images, the signing key is filled by the step controller when the job starts.
+func newImageSignStep(name, jobName string, registry *step.RegistryNamespace, targets []*step.ImageSignTarget) *commonmodels.StepTask {
+	return &commonmodels.StepTask{
+		Name:     name,
+		JobName:  jobName,
+		StepType: config.StepImageSign,
+		Spec: &step.StepImageSignSpec{
+			Registry: registry,
+			Targets:  targets,
+		},
+	}
+}
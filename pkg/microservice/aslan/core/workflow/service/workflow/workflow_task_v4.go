@@ -43,6 +43,7 @@ import (
 	templaterepo "github.com/koderover/zadig/v2/pkg/microservice/aslan/core/common/repository/mongodb/template"
 	"github.com/koderover/zadig/v2/pkg/microservice/aslan/core/common/service"
 	commonservice "github.com/koderover/zadig/v2/pkg/microservice/aslan/core/common/service"
+	"github.com/koderover/zadig/v2/pkg/microservice/aslan/core/common/service/admission"
 	"github.com/koderover/zadig/v2/pkg/microservice/aslan/core/common/service/dingtalk"
 	"github.com/koderover/zadig/v2/pkg/microservice/aslan/core/common/service/instantmessage"
 	"github.com/koderover/zadig/v2/pkg/microservice/aslan/core/common/service/lark"
@@ -246,6 +247,11 @@ type DistributeImageJobSpec struct {
 	DistributeTarget []*step.DistributeTaskTarget `bson:"distribute_target"            json:"distribute_target"`
 }
 
+type ImageScanJobSpec struct {
+	Policy  *step.ImageScanPolicy   `bson:"policy"                       json:"policy"`
+	Targets []*step.ImageScanTarget `bson:"targets"                      json:"targets"`
+}
+
 func GetWorkflowV4Preset(encryptedKey, workflowName, uid, username, ticketID string, log *zap.SugaredLogger) (*commonmodels.WorkflowV4, error) {
 	workflow, err := commonrepo.NewWorkflowV4Coll().Find(workflowName)
 	if err != nil {
@@ -659,6 +665,11 @@ func CreateWorkflowTaskV4(args *CreateWorkflowTaskV4Args, workflow *commonmodels
 		workflowTask.Type = config.WorkflowTaskTypeWorkflow
 	}
 
+	if err := admission.Evaluate(admission.NewTaskInput(config.AdmissionPolicyScopeWorkflowTask, workflowTask)); err != nil {
+		log.Errorf("workflow %s task %d is denied: %s", workflow.Name, nextTaskID, err)
+		return resp, e.ErrAdmissionPolicyDenied.AddDesc(err.Error())
+	}
+
 	workflowTask.WorkflowArgs, _, err = service.FillServiceModules2Jobs(workflowTask.WorkflowArgs)
 	if err != nil {
 		log.Errorf("fill serviceModules to jobs error: %v", err)
@@ -2531,6 +2542,23 @@ func jobsToJobPreviews(jobs []*commonmodels.JobTask, context map[string]string,
 				}
 			}
 			jobPreview.Spec = spec
+		case string(config.JobZadigImageScan):
+			spec := &ImageScanJobSpec{}
+			taskJobSpec := &commonmodels.JobTaskFreestyleSpec{}
+			if err := commonmodels.IToi(job.Spec, taskJobSpec); err != nil {
+				continue
+			}
+
+			for _, step := range taskJobSpec.Steps {
+				if step.StepType == config.StepImageScan {
+					stepSpec := &stepspec.StepImageScanSpec{}
+					commonmodels.IToi(step.Spec, &stepSpec)
+					spec.Policy = stepSpec.Policy
+					spec.Targets = stepSpec.Targets
+					break
+				}
+			}
+			jobPreview.Spec = spec
 		case string(config.JobZadigTesting):
 			spec := &ZadigTestingJobSpec{}
 			jobPreview.Spec = spec
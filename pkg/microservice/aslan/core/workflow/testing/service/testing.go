@@ -36,12 +36,14 @@ import (
 	"github.com/koderover/zadig/v2/pkg/microservice/aslan/core/common/repository/mongodb"
 	commonrepo "github.com/koderover/zadig/v2/pkg/microservice/aslan/core/common/repository/mongodb"
 	commonservice "github.com/koderover/zadig/v2/pkg/microservice/aslan/core/common/service"
+	"github.com/koderover/zadig/v2/pkg/microservice/aslan/core/common/service/resourceprofile"
 	"github.com/koderover/zadig/v2/pkg/microservice/aslan/core/common/service/s3"
 	"github.com/koderover/zadig/v2/pkg/microservice/aslan/core/common/service/webhook"
 	commonutil "github.com/koderover/zadig/v2/pkg/microservice/aslan/core/common/util"
 	workflowservice "github.com/koderover/zadig/v2/pkg/microservice/aslan/core/workflow/service/workflow"
 	"github.com/koderover/zadig/v2/pkg/setting"
 	e "github.com/koderover/zadig/v2/pkg/tool/errors"
+	"github.com/koderover/zadig/v2/pkg/tool/mockresponder"
 	s3tool "github.com/koderover/zadig/v2/pkg/tool/s3"
 	tartool "github.com/koderover/zadig/v2/pkg/tool/tar"
 	"github.com/koderover/zadig/v2/pkg/types"
@@ -55,6 +57,9 @@ func CreateTesting(username string, testing *commonmodels.Testing, log *zap.Suga
 	if err := commonutil.CheckDefineResourceParam(testing.PreTest.ResReq, testing.PreTest.ResReqSpec); err != nil {
 		return e.ErrCreateTestModule.AddDesc(err.Error())
 	}
+	if err := validateMockAssertions(testing.MockAssertions); err != nil {
+		return e.ErrCreateTestModule.AddDesc(err.Error())
+	}
 	err := HandleCronjob(testing, log)
 	if err != nil {
 		return e.ErrCreateTestModule.AddErr(err)
@@ -118,6 +123,9 @@ func UpdateTesting(username string, testing *commonmodels.Testing, log *zap.Suga
 	if err := commonutil.CheckDefineResourceParam(testing.PreTest.ResReq, testing.PreTest.ResReqSpec); err != nil {
 		return e.ErrUpdateTestModule.AddDesc(err.Error())
 	}
+	if err := validateMockAssertions(testing.MockAssertions); err != nil {
+		return e.ErrUpdateTestModule.AddDesc(err.Error())
+	}
 	err := HandleCronjob(testing, log)
 	if err != nil {
 		return e.ErrUpdateTestModule.AddErr(err)
@@ -162,6 +170,21 @@ type TestingOpt struct {
 	Verbs       []string                   `bson:"-"                      json:"verbs,omitempty"`
 }
 
+func validateMockAssertions(assertions []*types.MockAssertion) error {
+	for _, assertion := range assertions {
+		if assertion.EnvName == "" || assertion.ServiceName == "" {
+			return fmt.Errorf("env and service of the mock assertion are required")
+		}
+		if assertion.MinCount < 0 || (assertion.MaxCount != nil && *assertion.MaxCount < assertion.MinCount) {
+			return fmt.Errorf("invalid count range of the mock assertion of service %s", assertion.ServiceName)
+		}
+		if err := mockresponder.ValidateRequest(assertion.Request); err != nil {
+			return fmt.Errorf("invalid request of the mock assertion of service %s: %s", assertion.ServiceName, err)
+		}
+	}
+	return nil
+}
+
 func ListTestingOpt(productNames []string, testType string, log *zap.SugaredLogger) ([]*TestingOpt, error) {
 	allTestings := make([]*commonmodels.Testing, 0)
 	// TODO: remove the test type cases, there are only function test type right now. (v2.1.0)
@@ -363,6 +386,14 @@ func GetTesting(name, productName string, log *zap.SugaredLogger) (*commonmodels
 
 	ensureTestingResp(resp)
 
+	if resp.PreTest != nil {
+		recommendation, err := resourceprofile.Recommend(resp.ProductName, resourceprofile.ConfigTypeTesting, resp.Name)
+		if err != nil {
+			log.Warnf("failed to recommend resource for testing %s: %s", name, err)
+		}
+		resp.PreTest.ResourceRecommendation = recommendation
+	}
+
 	return resp, nil
 }
 
@@ -17,6 +17,8 @@ limitations under the License.
 package rest
 
 import (
+	"net/http"
+
 	"github.com/gin-gonic/gin"
 	"github.com/prometheus/client_golang/prometheus"
 	"github.com/prometheus/client_golang/prometheus/promhttp"
@@ -29,6 +31,7 @@ import (
 	codehosthandler "github.com/koderover/zadig/v2/pkg/microservice/aslan/core/code/handler"
 	collaborationhandler "github.com/koderover/zadig/v2/pkg/microservice/aslan/core/collaboration/handler"
 	commonhandler "github.com/koderover/zadig/v2/pkg/microservice/aslan/core/common/handler"
+	"github.com/koderover/zadig/v2/pkg/microservice/aslan/core/common/service/webhook"
 	"github.com/koderover/zadig/v2/pkg/microservice/aslan/core/common/service/workflowcontroller"
 	cronhandler "github.com/koderover/zadig/v2/pkg/microservice/aslan/core/cron/handler"
 	deliveryhandler "github.com/koderover/zadig/v2/pkg/microservice/aslan/core/delivery/handler"
@@ -46,6 +49,7 @@ import (
 	templatehandler "github.com/koderover/zadig/v2/pkg/microservice/aslan/core/templatestore/handler"
 	tickethandler "github.com/koderover/zadig/v2/pkg/microservice/aslan/core/ticket/handler"
 	vmhandler "github.com/koderover/zadig/v2/pkg/microservice/aslan/core/vm/handler"
+	vmservice "github.com/koderover/zadig/v2/pkg/microservice/aslan/core/vm/service"
 	workflowhandler "github.com/koderover/zadig/v2/pkg/microservice/aslan/core/workflow/handler"
 	testinghandler "github.com/koderover/zadig/v2/pkg/microservice/aslan/core/workflow/testing/handler"
 	evaluationhandler "github.com/koderover/zadig/v2/pkg/microservice/picket/core/evaluation/handler"
@@ -56,6 +60,7 @@ import (
 	connectorHandler "github.com/koderover/zadig/v2/pkg/microservice/systemconfig/core/connector/handler"
 	emailHandler "github.com/koderover/zadig/v2/pkg/microservice/systemconfig/core/email/handler"
 	featuresHandler "github.com/koderover/zadig/v2/pkg/microservice/systemconfig/core/features/handler"
+	"github.com/koderover/zadig/v2/pkg/tool/log"
 	"github.com/koderover/zadig/v2/pkg/tool/metrics"
 	// Note: have to load docs for swagger to work. See https://blog.csdn.net/weixin_43249914/article/details/103035711
 	// _ "github.com/koderover/zadig/v2/pkg/microservice/aslan/server/rest/doc"
@@ -75,6 +80,16 @@ func init() {
 	metrics.Metrics.MustRegister(metrics.Healthy)
 	metrics.Metrics.MustRegister(metrics.Cluster)
 	metrics.Metrics.MustRegister(metrics.ResponseTime)
+	metrics.Metrics.MustRegister(metrics.JobDuration)
+	metrics.Metrics.MustRegister(metrics.QueueWaitTime)
+	metrics.Metrics.MustRegister(metrics.TaskTotal)
+	metrics.Metrics.MustRegister(metrics.JobErrorPolicyTotal)
+	metrics.Metrics.MustRegister(metrics.VMAgentHeartbeatAge)
+	metrics.Metrics.MustRegister(metrics.VMAgentConcurrency)
+	metrics.Metrics.MustRegister(metrics.VMAgentRunningJobs)
+	metrics.Metrics.MustRegister(metrics.HubAgentConnected)
+	metrics.Metrics.MustRegister(metrics.WebhookInFlight)
+	metrics.Metrics.MustRegister(metrics.WebhookControllerQueueLength)
 
 	metrics.UpdatePodMetrics()
 }
@@ -205,9 +220,31 @@ func (s *engine) injectRouterGroup(router *gin.RouterGroup) {
 			metrics.SetClusterStatus(clusterName, status)
 		}
 
+		metrics.SetHubAgentConnected(clusterservice.GetHubAgentConnectionStatus())
+
+		agentMetrics, err := vmservice.ListAgentMetrics()
+		if err != nil {
+			log.Errorf("failed to list vm agent metrics, err: %s", err)
+		}
+		agentStatus := make([]*metrics.VMAgentStatus, 0, len(agentMetrics))
+		for _, agent := range agentMetrics {
+			agentStatus = append(agentStatus, &metrics.VMAgentStatus{
+				Name:         agent.Name,
+				HeartbeatAge: agent.HeartbeatAge,
+				Concurrency:  agent.TaskConcurrency,
+				RunningJobs:  agent.RunningJobs,
+			})
+		}
+		metrics.SetVMAgentStatus(agentStatus)
+
+		metrics.SetWebhookControllerQueueLength(webhook.QueueLength())
+
 		promhttp.HandlerFor(metrics.Metrics, promhttp.HandlerOpts{}).ServeHTTP(c.Writer, c.Request)
 	}
 	router.GET("/api/metrics", handlefunc)
+	router.GET("/api/metrics/grafana-dashboard", func(c *gin.Context) {
+		c.Data(http.StatusOK, "application/json", metrics.GrafanaDashboard)
+	})
 }
 
 type injector interface {
@@ -58,6 +58,13 @@ func (c *Client) InitStatData(log *zap.SugaredLogger) error {
 		if err != nil {
 			log.Errorf("creating weekly rollback stats error :%v", err)
 		}
+
+		url = fmt.Sprintf("%s/api/stat/v2/quality/dora/weekly", configbase.AslanServiceAddress())
+		log.Info("start creating weekly dora stats..")
+		_, err = c.sendPostRequest(url, nil, log)
+		if err != nil {
+			log.Errorf("creating weekly dora stats error :%v", err)
+		}
 	}
 
 	// if it is the first day of a month, do the monthly deploy stats
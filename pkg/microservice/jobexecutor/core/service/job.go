@@ -33,6 +33,7 @@ import (
 	"github.com/koderover/zadig/v2/pkg/microservice/jobexecutor/core/service/meta"
 	"github.com/koderover/zadig/v2/pkg/microservice/jobexecutor/core/service/step"
 	"github.com/koderover/zadig/v2/pkg/tool/log"
+	"github.com/koderover/zadig/v2/pkg/tool/tracing"
 	"github.com/koderover/zadig/v2/pkg/types/job"
 )
 
@@ -43,6 +44,7 @@ type Job struct {
 	UserEnvs         map[string]string
 	OutputsJsonBytes []byte
 	ConfigMapUpdater configmap.Updater
+	Tracer           *tracing.Tracer
 }
 
 const (
@@ -68,8 +70,15 @@ func NewJob() (*Job, error) {
 		ctx.Paths = config.Path()
 	}
 
+	tracer, err := tracing.NewTracer("job-executor", ctx.TraceEndpoint)
+	if err != nil {
+		log.Errorf("failed to init tracer, tracing is disabled: %v", err)
+		tracer, _ = tracing.NewTracer("job-executor", "")
+	}
+
 	job := &Job{
-		Ctx: ctx,
+		Ctx:    ctx,
+		Tracer: tracer,
 	}
 
 	err = job.EnsureActiveWorkspace(ctx.Workspace)
@@ -122,6 +131,9 @@ func (j *Job) getUserEnvs() []string {
 	j.Ctx.Paths = strings.Replace(j.Ctx.Paths, "$HOME", config.Home(), -1)
 	envs = append(envs, fmt.Sprintf("PATH=%s", j.Ctx.Paths))
 	envs = append(envs, fmt.Sprintf("DOCKER_HOST=%s", config.DockerHost()))
+	if j.Ctx.TraceParent != "" {
+		envs = append(envs, fmt.Sprintf("%s=%s", tracing.EnvTraceParent, j.Ctx.TraceParent))
+	}
 	envs = append(envs, j.Ctx.Envs...)
 	envs = append(envs, j.Ctx.SecretEnvs...)
 	// @var share output var between steps.
@@ -142,11 +154,21 @@ func (j *Job) Run(ctx context.Context) error {
 	}
 	hasFailed := false
 	var respErr error
+	ctx = tracing.ContextFromTraceParent(ctx, j.Ctx.TraceParent)
 	for _, stepInfo := range j.Ctx.Steps {
 		if hasFailed && !stepInfo.Onfailure {
 			continue
 		}
-		if err := step.RunStep(ctx, stepInfo, j.ActiveWorkspace, j.Ctx.Paths, j.getUserEnvs(), j.Ctx.SecretEnvs, j.ConfigMapUpdater); err != nil {
+		stepCtx, span := j.Tracer.StartSpan(ctx, "step "+stepInfo.Name,
+			tracing.AttrWorkflow.String(j.Ctx.WorkflowName),
+			tracing.AttrTaskID.Int64(j.Ctx.TaskID),
+			tracing.AttrJob.String(j.Ctx.Name),
+			tracing.AttrStep.String(stepInfo.Name),
+			tracing.AttrStepType.String(stepInfo.StepType),
+		)
+		err := step.RunStep(stepCtx, stepInfo, j.ActiveWorkspace, j.Ctx.Paths, j.getUserEnvs(), j.Ctx.SecretEnvs, j.ConfigMapUpdater)
+		tracing.EndSpan(span, err)
+		if err != nil {
 			hasFailed = true
 			respErr = err
 		}
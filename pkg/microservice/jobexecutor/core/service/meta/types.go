@@ -32,6 +32,10 @@ type JobContext struct {
 	Paths string `yaml:"paths"`
 	// ConfigMapName save the name of the configmap in which the jobContext resides
 	ConfigMapName string `yaml:"config_map_name"`
+	// TraceParent w3c trace context of the job span, used to link the step spans to the workflow task trace
+	TraceParent string `yaml:"trace_parent"`
+	// TraceEndpoint otlp endpoint to export the step spans to, tracing is disabled if it is empty
+	TraceEndpoint string `yaml:"trace_endpoint"`
 
 	Steps   []*Step  `yaml:"steps"`
 	Outputs []string `yaml:"outputs"`
@@ -29,6 +29,7 @@ import (
 	"gopkg.in/yaml.v2"
 
 	"github.com/koderover/zadig/v2/pkg/microservice/aslan/config"
+	"github.com/koderover/zadig/v2/pkg/tool/imagecopy"
 	"github.com/koderover/zadig/v2/pkg/tool/log"
 	"github.com/koderover/zadig/v2/pkg/types/step"
 )
@@ -95,87 +96,32 @@ func (s *DistributeImageStep) Run(ctx context.Context) error {
 		}
 		return nil
 	} else {
-		if err := s.loginSourceRegistry(); err != nil {
-			return err
-		}
-
-		for _, target := range s.spec.DistributeTarget {
-			wg.Add(1)
-			go func(target *step.DistributeTaskTarget) {
-				defer wg.Done()
-				pullCmd := dockerPullCmd(target.SourceImage, s.spec.Architecture)
-				out := bytes.Buffer{}
-				pullCmd.Stdout = &out
-				pullCmd.Stderr = &out
-				if err := pullCmd.Run(); err != nil {
-					errMsg := fmt.Sprintf("failed to pull image: %s %s", err, out.String())
-					appendError(errors.New(errMsg))
-					return
-				}
-				log.Infof("pull source image [%s] succeed", target.SourceImage)
-
-				tagCmd := dockerTagCmd(target.SourceImage, target.TargetImage)
-				out = bytes.Buffer{}
-				tagCmd.Stdout = &out
-				tagCmd.Stderr = &out
-				if err := tagCmd.Run(); err != nil {
-					errMsg := fmt.Sprintf("failed to tag image: %s %s", err, out.String())
-					appendError(errors.New(errMsg))
-					return
-				}
-				log.Infof("tag image [%s] to [%s] succeed", target.SourceImage, target.TargetImage)
-			}(target)
-		}
-		wg.Wait()
-		if err := errList.ErrorOrNil(); err != nil {
-			return fmt.Errorf("prepare source images error: %v", err)
-		}
-		log.Infof("Finish prepare source images.")
-
-		if err := s.loginTargetRegistry(); err != nil {
-			return err
-		}
+		// images are copied between the registries directly instead of pulled and pushed by docker, which only
+		// keeps the platform of the host, so that all the platforms of a multi-arch image are kept
+		sourceAuth := registryAuth(s.spec.SourceRegistry)
+		targetAuth := registryAuth(s.spec.TargetRegistry)
 		for _, target := range s.spec.DistributeTarget {
 			wg.Add(1)
 			go func(target *step.DistributeTaskTarget) {
 				defer wg.Done()
-				pushCmd := dockerPush(target.TargetImage)
-				out := bytes.Buffer{}
-				pushCmd.Stdout = &out
-				pushCmd.Stderr = &out
-				if err := pushCmd.Run(); err != nil {
-					errMsg := fmt.Sprintf("failed to push image: %s %s", err, out.String())
-					appendError(errors.New(errMsg))
+				digest, err := imagecopy.Copy(target.SourceImage, target.TargetImage, s.spec.Architecture, sourceAuth, targetAuth)
+				if err != nil {
+					appendError(err)
 					return
 				}
-				log.Infof("push image [%s] succeed", target.TargetImage)
+				log.Infof("copy image [%s] to [%s@%s] succeed", target.SourceImage, target.TargetImage, digest)
 			}(target)
 		}
 		wg.Wait()
 		if err := errList.ErrorOrNil(); err != nil {
-			return fmt.Errorf("push target images error: %v", err)
+			return fmt.Errorf("copy images error: %v", err)
 		}
-
 	}
 
 	log.Info("Finish distribute images.")
 	return nil
 }
 
-func (s *DistributeImageStep) loginSourceRegistry() error {
-	log.Info("Logging in Docker Source Registry.")
-	startTimeDockerLogin := time.Now()
-	loginCmd := dockerLogin(s.spec.SourceRegistry.AccessKey, s.spec.SourceRegistry.SecretKey, s.spec.SourceRegistry.RegAddr)
-	var out bytes.Buffer
-	loginCmd.Stdout = &out
-	loginCmd.Stderr = &out
-	if err := loginCmd.Run(); err != nil {
-		return fmt.Errorf("failed to login docker registry: %s %s", err, out.String())
-	}
-	log.Infof("Login ended. Duration: %.2f seconds.", time.Since(startTimeDockerLogin).Seconds())
-	return nil
-}
-
 func (s *DistributeImageStep) loginTargetRegistry() error {
 	log.Info("Logging in Docker Target Registry.")
 	startTimeDockerLogin := time.Now()
@@ -200,9 +146,10 @@ func dockerPullCmd(fullImage string, architecture string) *exec.Cmd {
 	return exec.Command("sh", args...)
 }
 
-func dockerTagCmd(sourceImage, targetImage string) *exec.Cmd {
-	args := []string{"-c"}
-	dockerTagCommand := fmt.Sprintf("docker tag %s %s", sourceImage, targetImage)
-	args = append(args, dockerTagCommand)
-	return exec.Command("sh", args...)
+func registryAuth(reg *step.RegistryNamespace) *imagecopy.RegistryAuth {
+	return &imagecopy.RegistryAuth{
+		Username: reg.AccessKey,
+		Password: reg.SecretKey,
+		Insecure: !reg.TLSEnabled,
+	}
 }
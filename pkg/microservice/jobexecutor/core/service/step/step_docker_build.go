@@ -19,6 +19,8 @@ package step
 import (
 	"bytes"
 	"context"
+	"encoding/base64"
+	"encoding/json"
 	"fmt"
 	"io"
 	"os"
@@ -28,6 +30,7 @@ import (
 	"sync"
 	"time"
 
+	"github.com/google/shlex"
 	"gopkg.in/yaml.v2"
 
 	"github.com/koderover/zadig/v2/pkg/setting"
@@ -70,7 +73,11 @@ func (s *DockerBuildStep) Run(ctx context.Context) error {
 	s.spec.DockerFile = util.ReplaceEnvWithValue(s.spec.DockerFile, envMap)
 	s.spec.BuildArgs = util.ReplaceEnvWithValue(s.spec.BuildArgs, envMap)
 
-	if err := s.dockerLogin(); err != nil {
+	if s.spec.Backend == setting.ImageBuildBackendBuildKit {
+		if err := s.buildKitLogin(); err != nil {
+			return err
+		}
+	} else if err := s.dockerLogin(); err != nil {
 		return err
 	}
 	return s.runDockerBuild()
@@ -124,6 +131,31 @@ func (s DockerBuildStep) dockerLogin() error {
 	return nil
 }
 
+// buildKitLogin writes the registry credential into a docker config file for buildctl,
+// there is no docker daemon to login with the buildkit backend
+func (s *DockerBuildStep) buildKitLogin() error {
+	configDir := filepath.Join(os.TempDir(), "buildkit-docker-config")
+	s.envs = append(s.envs, fmt.Sprintf("DOCKER_CONFIG=%s", configDir))
+	if s.spec.DockerRegistry == nil || s.spec.DockerRegistry.UserName == "" {
+		return nil
+	}
+
+	log.Infof("Writing credential of Docker Registry: %s.", s.spec.DockerRegistry.Host)
+	auth := base64.StdEncoding.EncodeToString([]byte(s.spec.DockerRegistry.UserName + ":" + s.spec.DockerRegistry.Password))
+	config, err := json.Marshal(map[string]interface{}{
+		"auths": map[string]interface{}{
+			s.spec.DockerRegistry.Host: map[string]string{"auth": auth},
+		},
+	})
+	if err != nil {
+		return fmt.Errorf("failed to marshal docker config: %s", err)
+	}
+	if err := os.MkdirAll(configDir, 0700); err != nil {
+		return fmt.Errorf("failed to create docker config dir: %s", err)
+	}
+	return os.WriteFile(filepath.Join(configDir, "config.json"), config, 0600)
+}
+
 func (s *DockerBuildStep) runDockerBuild() error {
 	if s.spec == nil {
 		return nil
@@ -147,7 +179,11 @@ func (s *DockerBuildStep) runDockerBuild() error {
 	log.Infof("Running Docker Build.")
 	startTimeDockerBuild := time.Now()
 	envs := s.envs
-	for _, c := range s.dockerCommands() {
+	cmds, err := s.dockerCommands()
+	if err != nil {
+		return err
+	}
+	for _, c := range cmds {
 		cmdOutReader, err := c.StdoutPipe()
 		if err != nil {
 			return err
@@ -186,24 +222,40 @@ func (s *DockerBuildStep) runDockerBuild() error {
 	return nil
 }
 
-func (s *DockerBuildStep) dockerCommands() []*exec.Cmd {
+func (s *DockerBuildStep) dockerCommands() ([]*exec.Cmd, error) {
 	cmds := make([]*exec.Cmd, 0)
 	if s.spec.WorkDir == "" {
 		s.spec.WorkDir = "."
 	}
 
+	if s.spec.Backend == setting.ImageBuildBackendBuildKit {
+		cmd, err := buildctlCmd(
+			s.spec.GetDockerFile(),
+			s.spec.ImageName,
+			s.spec.WorkDir,
+			s.spec.BuildArgs,
+			s.spec.IgnoreCache,
+			s.spec.GetPlatform(),
+			append(append([]string{}, s.envs...), s.secretEnvs...),
+		)
+		if err != nil {
+			return nil, err
+		}
+		return append(cmds, cmd), nil
+	}
+
 	buildCmd := dockerBuildCmd(
 		s.spec.GetDockerFile(),
 		s.spec.ImageName,
 		s.spec.WorkDir,
 		s.spec.BuildArgs,
 		s.spec.IgnoreCache,
-		s.spec.EnableBuildkit,
-		s.spec.Platform,
+		s.spec.UseBuildx(),
+		s.spec.GetPlatform(),
 	)
 
-	if s.spec.EnableBuildkit {
-		initBuildxCmd := dockerInitBuildxCmd(s.spec.Platform, s.spec.BuildKitImage)
+	if s.spec.UseBuildx() {
+		initBuildxCmd := dockerInitBuildxCmd(s.spec.GetPlatform(), s.spec.BuildKitImage)
 		cmds = append(
 			cmds,
 			initBuildxCmd,
@@ -218,7 +270,7 @@ func (s *DockerBuildStep) dockerCommands() []*exec.Cmd {
 			pushCmd,
 		)
 	}
-	return cmds
+	return cmds, nil
 }
 
 func dockerInitBuildxCmd(platform string, buildKitImage string) *exec.Cmd {
@@ -251,6 +303,101 @@ func dockerBuildCmd(dockerfile, fullImage, ctx, buildArgs string, ignoreCache, e
 	return exec.Command("sh", args...)
 }
 
+func buildctlCmd(dockerfile, fullImage, ctx, buildArgs string, ignoreCache bool, platform string, envs []string) (*exec.Cmd, error) {
+	opts, err := buildKitFrontendOpts(buildArgs, envs)
+	if err != nil {
+		return nil, err
+	}
+
+	addr := os.Getenv(setting.BuildKitHost)
+	if addr == "" {
+		addr = setting.RootlessBuildKitAddr
+	}
+	cacheRef := imageRepository(fullImage) + ":buildcache"
+
+	args := []string{
+		"--addr", addr,
+		"build",
+		"--frontend", "dockerfile.v0",
+		"--local", "context=" + ctx,
+		"--local", "dockerfile=" + filepath.Dir(dockerfile),
+		"--opt", "filename=" + filepath.Base(dockerfile),
+		"--output", fmt.Sprintf("type=image,name=%s,push=true", fullImage),
+		"--export-cache", fmt.Sprintf("type=registry,ref=%s,mode=max", cacheRef),
+	}
+	if ignoreCache {
+		args = append(args, "--no-cache")
+	} else {
+		args = append(args, "--import-cache", fmt.Sprintf("type=registry,ref=%s", cacheRef))
+	}
+	if platform != "" {
+		args = append(args, "--opt", "platform="+platform)
+	}
+	for _, opt := range opts {
+		args = append(args, "--opt", opt)
+	}
+	return exec.Command(setting.RootlessBuildctlPath, args...), nil
+}
+
+// buildKitFrontendOpts converts the docker build args into dockerfile frontend options of buildctl, only --build-arg
+// and --target are supported. Like docker, --build-arg KEY takes the value from the envs and is left out if it's not set.
+func buildKitFrontendOpts(buildArgs string, envs []string) ([]string, error) {
+	fields, err := shlex.Split(buildArgs)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse build args %s: %s", buildArgs, err)
+	}
+
+	opts := make([]string, 0)
+	for i := 0; i < len(fields); i++ {
+		flag, value, hasValue := strings.Cut(fields[i], "=")
+		if flag != "--build-arg" && flag != "--target" {
+			return nil, fmt.Errorf("build arg %s is not supported by the buildkit backend, only --build-arg and --target are supported", fields[i])
+		}
+		if !hasValue {
+			if i+1 >= len(fields) {
+				return nil, fmt.Errorf("build arg %s has no value", flag)
+			}
+			i++
+			value = fields[i]
+		}
+
+		if flag == "--target" {
+			opts = append(opts, "target="+value)
+			continue
+		}
+		if !strings.Contains(value, "=") {
+			envValue, ok := lookupEnv(envs, value)
+			if !ok {
+				continue
+			}
+			value = value + "=" + envValue
+		}
+		opts = append(opts, "build-arg:"+value)
+	}
+	return opts, nil
+}
+
+// lookupEnv returns the value of the key in the envs, the last one wins if the key is set more than once
+func lookupEnv(envs []string, key string) (string, bool) {
+	for i := len(envs) - 1; i >= 0; i-- {
+		if k, v, ok := strings.Cut(envs[i], "="); ok && k == key {
+			return v, true
+		}
+	}
+	return "", false
+}
+
+// imageRepository returns the image name without tag or digest
+func imageRepository(image string) string {
+	if i := strings.Index(image, "@"); i > 0 {
+		image = image[:i]
+	}
+	if i := strings.LastIndex(image, ":"); i > strings.LastIndex(image, "/") {
+		image = image[:i]
+	}
+	return image
+}
+
 func dockerPush(fullImage string) *exec.Cmd {
 	args := []string{"-c"}
 	dockerPushCommand := "docker push " + fullImage
@@ -0,0 +1,138 @@
+/*
+Copyright 2025 The KodeRover Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package step
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/koderover/zadig/v2/pkg/setting"
+)
+
+func TestBuildKitFrontendOpts(t *testing.T) {
+	ast := require.New(t)
+
+	envs := []string{"VERSION=1.0.0", "GOPROXY=https://proxy.golang.org,direct", "VERSION=1.0.1"}
+	tests := []struct {
+		buildArgs string
+		want      []string
+		wantErr   bool
+	}{
+		{buildArgs: "", want: []string{}},
+		{buildArgs: "--build-arg A=1 --build-arg=B=2", want: []string{"build-arg:A=1", "build-arg:B=2"}},
+		{buildArgs: `--build-arg "MSG=hello world" --target release`, want: []string{"build-arg:MSG=hello world", "target=release"}},
+		{buildArgs: "--build-arg VERSION --build-arg GOPROXY --build-arg MISSING", want: []string{"build-arg:VERSION=1.0.1", "build-arg:GOPROXY=https://proxy.golang.org,direct"}},
+		{buildArgs: "--target=builder", want: []string{"target=builder"}},
+		{buildArgs: "--build-arg A=1 --network host", wantErr: true},
+		{buildArgs: "--no-cache", wantErr: true},
+		{buildArgs: "--build-arg", wantErr: true},
+		{buildArgs: `--build-arg "A=1`, wantErr: true},
+	}
+	for _, tt := range tests {
+		opts, err := buildKitFrontendOpts(tt.buildArgs, envs)
+		if tt.wantErr {
+			ast.NotNil(err, tt.buildArgs)
+			continue
+		}
+		ast.Nil(err, tt.buildArgs)
+		ast.Equal(tt.want, opts, tt.buildArgs)
+	}
+}
+
+func TestBuildctlCmd(t *testing.T) {
+	ast := require.New(t)
+	t.Setenv(setting.BuildKitHost, "")
+
+	tests := []struct {
+		name        string
+		buildArgs   string
+		ignoreCache bool
+		platform    string
+		want        []string
+		wantErr     bool
+	}{
+		{
+			name: "cached build",
+			want: []string{
+				"--addr", setting.RootlessBuildKitAddr,
+				"build",
+				"--frontend", "dockerfile.v0",
+				"--local", "context=.",
+				"--local", "dockerfile=docker",
+				"--opt", "filename=Dockerfile",
+				"--output", "type=image,name=registry.example.com/app/service:v1,push=true",
+				"--export-cache", "type=registry,ref=registry.example.com/app/service:buildcache,mode=max",
+				"--import-cache", "type=registry,ref=registry.example.com/app/service:buildcache",
+			},
+		},
+		{
+			name:        "no cache with platform and build args",
+			buildArgs:   "--build-arg A=1 --target release",
+			ignoreCache: true,
+			platform:    "linux/amd64,linux/arm64",
+			want: []string{
+				"--addr", setting.RootlessBuildKitAddr,
+				"build",
+				"--frontend", "dockerfile.v0",
+				"--local", "context=.",
+				"--local", "dockerfile=docker",
+				"--opt", "filename=Dockerfile",
+				"--output", "type=image,name=registry.example.com/app/service:v1,push=true",
+				"--export-cache", "type=registry,ref=registry.example.com/app/service:buildcache,mode=max",
+				"--no-cache",
+				"--opt", "platform=linux/amd64,linux/arm64",
+				"--opt", "build-arg:A=1",
+				"--opt", "target=release",
+			},
+		},
+		{
+			name:      "unsupported build arg",
+			buildArgs: "--squash",
+			wantErr:   true,
+		},
+	}
+	for _, tt := range tests {
+		cmd, err := buildctlCmd("docker/Dockerfile", "registry.example.com/app/service:v1", ".", tt.buildArgs, tt.ignoreCache, tt.platform, nil)
+		if tt.wantErr {
+			ast.NotNil(err, tt.name)
+			continue
+		}
+		ast.Nil(err, tt.name)
+		ast.Equal(setting.RootlessBuildctlPath, cmd.Path, tt.name)
+		ast.Equal(tt.want, cmd.Args[1:], tt.name)
+	}
+}
+
+func TestImageRepository(t *testing.T) {
+	ast := require.New(t)
+
+	tests := []struct {
+		image string
+		want  string
+	}{
+		{image: "nginx", want: "nginx"},
+		{image: "nginx:1.25", want: "nginx"},
+		{image: "registry.example.com:5000/app/service", want: "registry.example.com:5000/app/service"},
+		{image: "registry.example.com:5000/app/service:v1", want: "registry.example.com:5000/app/service"},
+		{image: "registry.example.com/app/service@sha256:0123456789abcdef", want: "registry.example.com/app/service"},
+		{image: "registry.example.com/app/service:v1@sha256:0123456789abcdef", want: "registry.example.com/app/service"},
+	}
+	for _, tt := range tests {
+		ast.Equal(tt.want, imageRepository(tt.image), tt.image)
+	}
+}
@@ -0,0 +1,201 @@
+/*
+Copyright 2025 The KodeRover Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package step
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path"
+	"path/filepath"
+	"strings"
+
+	"gopkg.in/yaml.v2"
+
+	"github.com/koderover/zadig/v2/pkg/tool/log"
+	"github.com/koderover/zadig/v2/pkg/tool/s3"
+	"github.com/koderover/zadig/v2/pkg/types/step"
+)
+
+type ImageScanStep struct {
+	spec       *step.StepImageScanSpec
+	envs       []string
+	secretEnvs []string
+	workspace  string
+}
+
+func NewImageScanStep(spec interface{}, workspace string, envs, secretEnvs []string) (*ImageScanStep, error) {
+	imageScanStep := &ImageScanStep{workspace: workspace, envs: envs, secretEnvs: secretEnvs}
+	yamlBytes, err := yaml.Marshal(spec)
+	if err != nil {
+		return imageScanStep, fmt.Errorf("marshal spec %+v failed", spec)
+	}
+	if err := yaml.Unmarshal(yamlBytes, &imageScanStep.spec); err != nil {
+		return imageScanStep, fmt.Errorf("unmarshal spec %s to image scan spec failed", yamlBytes)
+	}
+	return imageScanStep, nil
+}
+
+// trivyReport is the subset of the trivy json output used by the scan step.
+type trivyReport struct {
+	Metadata struct {
+		RepoDigests []string `json:"RepoDigests"`
+	} `json:"Metadata"`
+	Results []struct {
+		Vulnerabilities []struct {
+			VulnerabilityID  string `json:"VulnerabilityID"`
+			PkgName          string `json:"PkgName"`
+			InstalledVersion string `json:"InstalledVersion"`
+			FixedVersion     string `json:"FixedVersion"`
+			Severity         string `json:"Severity"`
+			Title            string `json:"Title"`
+			PrimaryURL       string `json:"PrimaryURL"`
+		} `json:"Vulnerabilities"`
+	} `json:"Results"`
+}
+
+func (s *ImageScanStep) Run(ctx context.Context) error {
+	log.Info("Start scan images.")
+	if len(s.spec.Targets) == 0 {
+		return errors.New("no image to scan")
+	}
+
+	reports := make([]*step.ImageScanReport, 0)
+	failedImages := make([]string, 0)
+	for _, target := range s.spec.Targets {
+		log.Infof("scanning image [%s]", target.Image)
+		report, err := s.scan(ctx, target.Image)
+		if err != nil {
+			return fmt.Errorf("failed to scan image %s: %s", target.Image, err)
+		}
+		reports = append(reports, report)
+
+		summary := report.Summary()
+		log.Infof("image [%s] digest [%s] vulnerabilities: CRITICAL %d, HIGH %d, MEDIUM %d, LOW %d, UNKNOWN %d",
+			target.Image, report.Digest,
+			summary[step.ImageScanSeverityCritical], summary[step.ImageScanSeverityHigh], summary[step.ImageScanSeverityMedium],
+			summary[step.ImageScanSeverityLow], summary[step.ImageScanSeverityUnknown])
+		violations := s.spec.Policy.Violations(report)
+		for _, violation := range violations {
+			log.Errorf("image [%s] violates the scan policy: %s", target.Image, violation)
+		}
+		if len(violations) > 0 {
+			failedImages = append(failedImages, target.Image)
+		}
+	}
+	log.Info("Finish scan images.")
+
+	if err := s.archive(reports); err != nil {
+		return err
+	}
+
+	if len(failedImages) > 0 {
+		return fmt.Errorf("images %s failed the scan policy", strings.Join(failedImages, ", "))
+	}
+	return nil
+}
+
+func (s *ImageScanStep) scan(ctx context.Context, image string) (*step.ImageScanReport, error) {
+	args := []string{"image", "--format", "json", "--quiet", "--scanners", "vuln"}
+	envs := os.Environ()
+	if s.spec.Registry != nil {
+		if !s.spec.Registry.TLSEnabled {
+			args = append(args, "--insecure")
+		}
+		if s.spec.Registry.AccessKey != "" {
+			envs = append(envs, fmt.Sprintf("TRIVY_USERNAME=%s", s.spec.Registry.AccessKey), fmt.Sprintf("TRIVY_PASSWORD=%s", s.spec.Registry.SecretKey))
+		}
+	}
+	args = append(args, image)
+
+	out := bytes.Buffer{}
+	errOut := bytes.Buffer{}
+	cmd := exec.CommandContext(ctx, "trivy", args...)
+	cmd.Env = envs
+	cmd.Dir = s.workspace
+	cmd.Stdout = &out
+	cmd.Stderr = &errOut
+	if err := cmd.Run(); err != nil {
+		return nil, fmt.Errorf("%s %s", err, errOut.String())
+	}
+
+	result := &trivyReport{}
+	if err := json.Unmarshal(out.Bytes(), result); err != nil {
+		return nil, fmt.Errorf("unmarshal trivy report error: %s", err)
+	}
+
+	report := &step.ImageScanReport{
+		Image:           image,
+		Vulnerabilities: make([]*step.ImageVulnerability, 0),
+	}
+	for _, repoDigest := range result.Metadata.RepoDigests {
+		if idx := strings.LastIndex(repoDigest, "@"); idx != -1 {
+			report.Digest = repoDigest[idx+1:]
+			break
+		}
+	}
+	for _, res := range result.Results {
+		for _, vuln := range res.Vulnerabilities {
+			report.Vulnerabilities = append(report.Vulnerabilities, &step.ImageVulnerability{
+				ID:               vuln.VulnerabilityID,
+				PkgName:          vuln.PkgName,
+				InstalledVersion: vuln.InstalledVersion,
+				FixedVersion:     vuln.FixedVersion,
+				Severity:         vuln.Severity,
+				Title:            vuln.Title,
+				PrimaryURL:       vuln.PrimaryURL,
+			})
+		}
+	}
+	return report, nil
+}
+
+func (s *ImageScanStep) archive(reports []*step.ImageScanReport) error {
+	if s.spec.S3DestDir == "" || s.spec.FileName == "" || s.spec.S3Storage == nil {
+		return nil
+	}
+	log.Infof("Start archive %s.", s.spec.FileName)
+	if err := os.MkdirAll(s.spec.DestDir, os.ModePerm); err != nil {
+		return fmt.Errorf("create dest dir: %s error: %s", s.spec.DestDir, err)
+	}
+	content, err := json.Marshal(reports)
+	if err != nil {
+		return fmt.Errorf("marshal image scan report error: %s", err)
+	}
+	absFilePath := path.Join(s.spec.DestDir, s.spec.FileName)
+	if err := os.WriteFile(absFilePath, content, 0644); err != nil {
+		return fmt.Errorf("write image scan report error: %s", err)
+	}
+
+	client, err := s3.NewClient(s.spec.S3Storage.Endpoint, s.spec.S3Storage.Ak, s.spec.S3Storage.Sk, s.spec.S3Storage.Region, s.spec.S3Storage.Insecure, s.spec.S3Storage.Provider)
+	if err != nil {
+		return fmt.Errorf("failed to create s3 client to upload file, err: %s", err)
+	}
+	s3DestDir := s.spec.S3DestDir
+	if len(s.spec.S3Storage.Subfolder) > 0 {
+		s3DestDir = strings.TrimLeft(path.Join(s.spec.S3Storage.Subfolder, s3DestDir), "/")
+	}
+	if err := client.Upload(s.spec.S3Storage.Bucket, absFilePath, filepath.Join(s3DestDir, s.spec.FileName)); err != nil {
+		return err
+	}
+	log.Infof("Finish archive to %s.", s.spec.FileName)
+	return nil
+}
@@ -0,0 +1,87 @@
+/*
+Copyright 2025 The KodeRover Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package step
+
+import (
+	"context"
+	"crypto"
+	"errors"
+	"fmt"
+
+	"gopkg.in/yaml.v2"
+
+	"github.com/koderover/zadig/v2/pkg/tool/imagesign"
+	"github.com/koderover/zadig/v2/pkg/tool/log"
+	"github.com/koderover/zadig/v2/pkg/types/step"
+	"github.com/koderover/zadig/v2/pkg/util"
+)
+
+type ImageSignStep struct {
+	spec       *step.StepImageSignSpec
+	envs       []string
+	secretEnvs []string
+	workspace  string
+}
+
+func NewImageSignStep(spec interface{}, workspace string, envs, secretEnvs []string) (*ImageSignStep, error) {
+	imageSignStep := &ImageSignStep{workspace: workspace, envs: envs, secretEnvs: secretEnvs}
+	yamlBytes, err := yaml.Marshal(spec)
+	if err != nil {
+		return imageSignStep, fmt.Errorf("marshal spec %+v failed", spec)
+	}
+	if err := yaml.Unmarshal(yamlBytes, &imageSignStep.spec); err != nil {
+		return imageSignStep, fmt.Errorf("unmarshal spec %s to image sign spec failed", yamlBytes)
+	}
+	return imageSignStep, nil
+}
+
+func (s *ImageSignStep) Run(ctx context.Context) error {
+	log.Info("Start sign images.")
+	signer, err := s.loadSigner()
+	if err != nil {
+		return err
+	}
+
+	auth := &imagesign.RegistryAuth{}
+	if s.spec.Registry != nil {
+		auth.Username = s.spec.Registry.AccessKey
+		auth.Password = s.spec.Registry.SecretKey
+		auth.Insecure = !s.spec.Registry.TLSEnabled
+	}
+
+	envMap := util.MakeEnvMap(s.envs, s.secretEnvs)
+	for _, target := range s.spec.Targets {
+		image := util.ReplaceEnvWithValue(target.Image, envMap)
+		digest, err := imagesign.Sign(image, signer, auth)
+		if err != nil {
+			return fmt.Errorf("failed to sign image %s: %s", image, err)
+		}
+		log.Infof("image [%s@%s] signed", image, digest)
+	}
+	log.Info("Finish sign images.")
+	return nil
+}
+
+func (s *ImageSignStep) loadSigner() (crypto.Signer, error) {
+	if s.spec.PrivateKey != "" {
+		return imagesign.ParsePrivateKey([]byte(s.spec.PrivateKey))
+	}
+	if s.spec.KeyRef != "" {
+		return imagesign.LoadSigner(s.spec.KeyRef)
+	}
+	return nil, errors.New("no signing key configured")
+}
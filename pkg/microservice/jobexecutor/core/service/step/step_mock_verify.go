@@ -0,0 +1,137 @@
+/*
+Copyright 2025 The KodeRover Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package step
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"gopkg.in/yaml.v2"
+
+	"github.com/koderover/zadig/v2/pkg/tool/log"
+	"github.com/koderover/zadig/v2/pkg/tool/mockresponder"
+	"github.com/koderover/zadig/v2/pkg/types"
+	"github.com/koderover/zadig/v2/pkg/types/step"
+)
+
+type MockVerifyStep struct {
+	spec       *step.StepMockVerifySpec
+	envs       []string
+	secretEnvs []string
+	workspace  string
+}
+
+func NewMockVerifyStep(spec interface{}, workspace string, envs, secretEnvs []string) (*MockVerifyStep, error) {
+	mockVerifyStep := &MockVerifyStep{workspace: workspace, envs: envs, secretEnvs: secretEnvs}
+	yamlBytes, err := yaml.Marshal(spec)
+	if err != nil {
+		return mockVerifyStep, fmt.Errorf("marshal spec %+v failed", spec)
+	}
+	if err := yaml.Unmarshal(yamlBytes, &mockVerifyStep.spec); err != nil {
+		return mockVerifyStep, fmt.Errorf("unmarshal spec %s to mock verify spec failed", yamlBytes)
+	}
+	return mockVerifyStep, nil
+}
+
+func (s *MockVerifyStep) Run(ctx context.Context) error {
+	log.Info("Start verify mock requests.")
+	failures := make([]string, 0)
+	for _, target := range s.spec.Targets {
+		journal, truncated, err := s.fetchJournal(ctx, target)
+		if err != nil {
+			return fmt.Errorf("failed to get the requests received by the mock of service %s in env %s: %s", target.ServiceName, target.EnvName, err)
+		}
+
+		assertion := target.Assertion
+		count := 0
+		for _, entry := range journal {
+			if mockresponder.Match(assertion.Request, entry) {
+				count++
+			}
+		}
+
+		expected := fmt.Sprintf("at least %d", assertion.MinCount)
+		if assertion.MaxCount != nil {
+			expected = fmt.Sprintf("%d to %d", assertion.MinCount, *assertion.MaxCount)
+		}
+		result := fmt.Sprintf("mock of service %s in env %s received %d matching requests, expected %s", target.ServiceName, target.EnvName, count, expected)
+		if truncated {
+			// the journal keeps the latest requests only, the earlier requests since the job started are not counted
+			result += ", the request journal of the mock is truncated and some of the requests are not counted, raise its journal size to count them all"
+			log.Warnf("the request journal of the mock of service %s in env %s is truncated", target.ServiceName, target.EnvName)
+		}
+		if count < assertion.MinCount || (assertion.MaxCount != nil && count > *assertion.MaxCount) {
+			failures = append(failures, result)
+			continue
+		}
+		log.Info(result)
+	}
+
+	if len(failures) > 0 {
+		for _, failure := range failures {
+			log.Error(failure)
+		}
+		return fmt.Errorf("mock verification failed: %s", strings.Join(failures, "; "))
+	}
+	return nil
+}
+
+// fetchJournal returns the requests received by the mock since the job started, and whether some of them were dropped
+// for exceeding the journal size
+func (s *MockVerifyStep) fetchJournal(ctx context.Context, target *step.MockVerifyTarget) ([]*types.MockJournalEntry, bool, error) {
+	u, err := url.Parse(target.JournalURL)
+	if err != nil {
+		return nil, false, err
+	}
+	query := u.Query()
+	query.Set("since", strconv.FormatInt(target.Since, 10))
+	u.RawQuery = query.Encode()
+
+	ctx, cancel := context.WithTimeout(ctx, 30*time.Second)
+	defer cancel()
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u.String(), nil)
+	if err != nil {
+		return nil, false, err
+	}
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return nil, false, err
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, false, err
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, false, fmt.Errorf("unexpected status %d: %s", resp.StatusCode, body)
+	}
+
+	journal := make([]*types.MockJournalEntry, 0)
+	if err := json.Unmarshal(body, &journal); err != nil {
+		return nil, false, err
+	}
+	truncated, _ := strconv.ParseBool(resp.Header.Get(types.MockJournalTruncatedHeader))
+	return journal, truncated, nil
+}
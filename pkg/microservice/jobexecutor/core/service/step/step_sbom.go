@@ -0,0 +1,164 @@
+/*
+Copyright 2025 The KodeRover Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package step
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path"
+	"path/filepath"
+	"strings"
+
+	"gopkg.in/yaml.v2"
+
+	"github.com/koderover/zadig/v2/pkg/tool/imagesign"
+	"github.com/koderover/zadig/v2/pkg/tool/log"
+	"github.com/koderover/zadig/v2/pkg/tool/s3"
+	"github.com/koderover/zadig/v2/pkg/types/step"
+	"github.com/koderover/zadig/v2/pkg/util"
+)
+
+type SBOMStep struct {
+	spec       *step.StepSBOMSpec
+	envs       []string
+	secretEnvs []string
+	workspace  string
+}
+
+func NewSBOMStep(spec interface{}, workspace string, envs, secretEnvs []string) (*SBOMStep, error) {
+	sbomStep := &SBOMStep{workspace: workspace, envs: envs, secretEnvs: secretEnvs}
+	yamlBytes, err := yaml.Marshal(spec)
+	if err != nil {
+		return sbomStep, fmt.Errorf("marshal spec %+v failed", spec)
+	}
+	if err := yaml.Unmarshal(yamlBytes, &sbomStep.spec); err != nil {
+		return sbomStep, fmt.Errorf("unmarshal spec %s to sbom spec failed", yamlBytes)
+	}
+	return sbomStep, nil
+}
+
+func (s *SBOMStep) Run(ctx context.Context) error {
+	log.Info("Start generate sbom.")
+	if len(s.spec.Targets) == 0 {
+		return errors.New("no image to generate sbom for")
+	}
+	if !s.spec.Format.Valid() {
+		return fmt.Errorf("unsupported sbom format: %s", s.spec.Format)
+	}
+	if err := os.MkdirAll(s.spec.DestDir, os.ModePerm); err != nil {
+		return fmt.Errorf("create dest dir: %s error: %s", s.spec.DestDir, err)
+	}
+
+	auth := &imagesign.RegistryAuth{}
+	if s.spec.Registry != nil {
+		auth.Username = s.spec.Registry.AccessKey
+		auth.Password = s.spec.Registry.SecretKey
+		auth.Insecure = !s.spec.Registry.TLSEnabled
+	}
+
+	envMap := util.MakeEnvMap(s.envs, s.secretEnvs)
+	index := make([]*step.SBOMTarget, 0)
+	for _, target := range s.spec.Targets {
+		image := util.ReplaceEnvWithValue(target.Image, envMap)
+		digest, err := imagesign.ResolveDigest(image, auth)
+		if err != nil {
+			return fmt.Errorf("failed to resolve digest of image %s: %s", image, err)
+		}
+
+		fileName := strings.ReplaceAll(digest.DigestStr(), ":", "-") + s.spec.Format.FileExtension()
+		log.Infof("generating sbom of image [%s@%s]", image, digest.DigestStr())
+		if err := s.generate(ctx, image, path.Join(s.spec.DestDir, fileName)); err != nil {
+			return fmt.Errorf("failed to generate sbom of image %s: %s", image, err)
+		}
+		index = append(index, &step.SBOMTarget{
+			ServiceName:   target.ServiceName,
+			ServiceModule: target.ServiceModule,
+			Image:         image,
+			Digest:        digest.DigestStr(),
+			FileName:      fileName,
+		})
+	}
+
+	content, err := json.Marshal(index)
+	if err != nil {
+		return fmt.Errorf("marshal sbom index error: %s", err)
+	}
+	if err := os.WriteFile(path.Join(s.spec.DestDir, step.SBOMIndexFileName), content, 0644); err != nil {
+		return fmt.Errorf("write sbom index error: %s", err)
+	}
+
+	if err := s.archive(index); err != nil {
+		return err
+	}
+	log.Info("Finish generate sbom.")
+	return nil
+}
+
+func (s *SBOMStep) generate(ctx context.Context, image, output string) error {
+	args := []string{"image", "--format", string(s.spec.Format), "--quiet", "--output", output}
+	envs := os.Environ()
+	if s.spec.Registry != nil {
+		if !s.spec.Registry.TLSEnabled {
+			args = append(args, "--insecure")
+		}
+		if s.spec.Registry.AccessKey != "" {
+			envs = append(envs, fmt.Sprintf("TRIVY_USERNAME=%s", s.spec.Registry.AccessKey), fmt.Sprintf("TRIVY_PASSWORD=%s", s.spec.Registry.SecretKey))
+		}
+	}
+	args = append(args, image)
+
+	errOut := bytes.Buffer{}
+	cmd := exec.CommandContext(ctx, "trivy", args...)
+	cmd.Env = envs
+	cmd.Dir = s.workspace
+	cmd.Stderr = &errOut
+	if err := cmd.Run(); err != nil {
+		return fmt.Errorf("%s %s", err, errOut.String())
+	}
+	return nil
+}
+
+func (s *SBOMStep) archive(index []*step.SBOMTarget) error {
+	if s.spec.S3DestDir == "" || s.spec.S3Storage == nil {
+		return nil
+	}
+	client, err := s3.NewClient(s.spec.S3Storage.Endpoint, s.spec.S3Storage.Ak, s.spec.S3Storage.Sk, s.spec.S3Storage.Region, s.spec.S3Storage.Insecure, s.spec.S3Storage.Provider)
+	if err != nil {
+		return fmt.Errorf("failed to create s3 client to upload file, err: %s", err)
+	}
+	s3DestDir := s.spec.S3DestDir
+	if len(s.spec.S3Storage.Subfolder) > 0 {
+		s3DestDir = strings.TrimLeft(path.Join(s.spec.S3Storage.Subfolder, s3DestDir), "/")
+	}
+
+	files := []string{step.SBOMIndexFileName}
+	for _, target := range index {
+		files = append(files, target.FileName)
+	}
+	for _, file := range files {
+		if err := client.Upload(s.spec.S3Storage.Bucket, path.Join(s.spec.DestDir, file), filepath.Join(s3DestDir, file)); err != nil {
+			return err
+		}
+	}
+	log.Infof("Finish archive sbom to %s.", s3DestDir)
+	return nil
+}
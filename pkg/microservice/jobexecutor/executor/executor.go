@@ -18,6 +18,7 @@ package executor
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"os"
 	"time"
@@ -31,6 +32,7 @@ import (
 	"github.com/koderover/zadig/v2/pkg/microservice/jobexecutor/core/service/configmap"
 	"github.com/koderover/zadig/v2/pkg/setting"
 	"github.com/koderover/zadig/v2/pkg/tool/log"
+	"github.com/koderover/zadig/v2/pkg/tool/resourceusage"
 	"github.com/koderover/zadig/v2/pkg/types"
 )
 
@@ -76,6 +78,19 @@ func Execute(ctx context.Context) error {
 
 	j.ConfigMapUpdater = configmap.NewUpdater(j.Ctx.ConfigMapName, string(ns), clientset)
 
+	// sample the resource usage of the job container for resource profiling
+	sampler := resourceusage.NewSampler(5 * time.Second)
+	sampler.Start()
+
+	defer func() {
+		// flush the step spans before the pod exits
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		if err := j.Tracer.Shutdown(shutdownCtx); err != nil {
+			log.Warnf("failed to flush trace spans: %v", err)
+		}
+	}()
+
 	defer func() {
 		resultMsg := types.JobSuccess
 		if err != nil {
@@ -92,6 +107,11 @@ func Execute(ctx context.Context) error {
 		}
 		cm.Data[types.JobResultKey] = string(resultMsg)
 		cm.Data[types.JobOutputsKey] = string(j.OutputsJsonBytes)
+		if usage := sampler.Stop(); usage != nil {
+			if usageBytes, err := json.Marshal(usage); err == nil {
+				cm.Data[types.JobResourceUsageKey] = string(usageBytes)
+			}
+		}
 		if j.ConfigMapUpdater.UpdateWithRetry(cm, 3, 3*time.Second) != nil {
 			log.Errorf("failed to update job context ConfigMap: %v", err)
 			return
@@ -0,0 +1,60 @@
+/*
+Copyright 2025 The KodeRover Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/koderover/zadig/v2/pkg/tool/log"
+	"github.com/koderover/zadig/v2/pkg/tool/mockresponder"
+	"github.com/koderover/zadig/v2/pkg/types"
+)
+
+const (
+	// envConfigDir is the directory the config map of the mock is mounted to
+	envConfigDir     = "MOCK_CONFIG_DIR"
+	defaultConfigDir = "/etc/zadig-mock"
+)
+
+func init() {
+	log.Init(&log.Config{
+		Level: "info",
+	})
+}
+
+func Serve(ctx context.Context) error {
+	dir := os.Getenv(envConfigDir)
+	if dir == "" {
+		dir = defaultConfigDir
+	}
+
+	data, err := os.ReadFile(filepath.Join(dir, types.MockConfigFileName))
+	if err != nil {
+		return fmt.Errorf("failed to read mock config: %s", err)
+	}
+	config := &types.MockConfig{}
+	if err := json.Unmarshal(data, config); err != nil {
+		return fmt.Errorf("failed to parse mock config: %s", err)
+	}
+
+	log.Infof("Start mock responder of %s with %d stubs.", config.Name, len(config.Stubs))
+	return mockresponder.Serve(ctx, config)
+}
@@ -21,6 +21,7 @@ import (
 
 	"github.com/koderover/zadig/v2/pkg/microservice/user/core/service/login"
 	internalhandler "github.com/koderover/zadig/v2/pkg/shared/handler"
+	"github.com/koderover/zadig/v2/pkg/types"
 )
 
 func LocalLogin(c *gin.Context) {
@@ -31,6 +32,9 @@ func LocalLogin(c *gin.Context) {
 		ctx.RespErr = err
 		return
 	}
+	// the request body is left out so that the password never reaches the audit log
+	internalhandler.InsertOperationLog(c, args.Account, "", "登录", "用户登录", args.Account, args.Account, "", types.RequestBodyTypeJSON, ctx.Logger)
+
 	resp, failedCount, err := login.LocalLogin(args, ctx.Logger)
 	if failedCount >= 5 {
 		c.Header("x-require-captcha", "true")
@@ -69,6 +73,8 @@ func LocalLogout(c *gin.Context) {
 	ctx := internalhandler.NewContext(c)
 	defer func() { internalhandler.JSONResponse(c, ctx) }()
 
+	internalhandler.InsertOperationLog(c, ctx.UserName, "", "登出", "用户登出", ctx.Account, ctx.Account, "", types.RequestBodyTypeJSON, ctx.Logger)
+
 	shouldRedirect, redirectURL, _ := login.LocalLogout(ctx.UserID, ctx.Logger)
 	// TODO: for now only oauth2 service need to actually logout, so we just do nothing when an error happen
 	// this need to be fixed when there are more logout logic.
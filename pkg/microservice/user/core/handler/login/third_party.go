@@ -40,6 +40,7 @@ import (
 	internalhandler "github.com/koderover/zadig/v2/pkg/shared/handler"
 	e "github.com/koderover/zadig/v2/pkg/tool/errors"
 	"github.com/koderover/zadig/v2/pkg/tool/log"
+	"github.com/koderover/zadig/v2/pkg/types"
 )
 
 func provider() *oidc.Provider {
@@ -165,6 +166,8 @@ func Callback(c *gin.Context) {
 		ctx.RespErr = err
 		return
 	}
+	detail := fmt.Sprintf("%s(%s)", user.Account, claims.FederatedClaims.ConnectorId)
+	internalhandler.InsertOperationLog(c, user.Name, "", "登录", "用户登录", detail, detail, "", types.RequestBodyTypeJSON, ctx.Logger)
 
 	systemSettings, err := aslan.New(configbase.AslanServiceAddress()).GetSystemSecurityAndPrivacySettings()
 	if err != nil {
@@ -26,6 +26,8 @@ const (
 	ENVSystemAddress              = "ADDRESS"
 	ENVImagePullPolicy            = "IMAGE_PULL_POLICY"
 	ENVBuildKitImage              = "BUILD_KIT_IMAGE"
+	ENVRootlessBuildKitImage      = "ROOTLESS_BUILD_KIT_IMAGE"
+	ENVMockResponderImage         = "MOCK_RESPONDER_IMAGE"
 	ENVMode                       = "MODE"
 	ENVMongoDBConnectionString    = "MONGODB_CONNECTION_STRING"
 	ENVIsDocumentDB               = "IS_DOCUMENT_DB"
@@ -94,6 +96,7 @@ const (
 	DockerAuthDir   = "DOCKER_AUTH_DIR"
 	Path            = "PATH"
 	DockerHost      = "DOCKER_HOST"
+	BuildKitHost    = "BUILDKIT_HOST"
 	BuildURL        = "BUILD_URL"
 	DefaultDockSock = "/var/run/docker.sock"
 
@@ -132,6 +135,7 @@ const (
 	Service               = "Service"
 	Deployment            = "Deployment"
 	CloneSet              = "CloneSet"
+	Rollout               = "Rollout" // kind of both Argo Rollouts and Kruise Rollouts, told apart by the api group
 	StatefulSet           = "StatefulSet"
 	Pod                   = "Pod"
 	ReplicaSet            = "ReplicaSet"
@@ -143,6 +147,15 @@ const (
 	Role                  = "Role"
 	RoleBinding           = "RoleBinding"
 
+	// phases of rollouts, the states of Kruise Rollouts are mapped to the phases of Argo Rollouts
+	RolloutPhaseHealthy     = "Healthy"
+	RolloutPhaseProgressing = "Progressing"
+	RolloutPhasePaused      = "Paused"
+	RolloutPhaseDegraded    = "Degraded"
+
+	RolloutProviderArgo   = "argo"
+	RolloutProviderKruise = "kruise"
+
 	// labels
 	TaskLabel                       = "s-task"
 	TypeLabel                       = "s-type"
@@ -263,6 +276,8 @@ const (
 	SourceFromChartRepo   = "chartRepo"
 	SourceFromCustomEdit  = "customEdit"
 	SourceFromVariableSet = "variableSet"
+	// SourceFromKustomize The configuration source is a kustomization in a repository
+	SourceFromKustomize = "kustomize"
 
 	// SourceFromGUI The configuration source is gui
 	SourceFromGUI = "gui"
@@ -395,6 +410,14 @@ const (
 	DeliveryVersionStatusRetrying DeliveryVersionStatus = "retrying"
 )
 
+type DeliveryReleaseNoteStatus string
+
+const (
+	DeliveryReleaseNoteStatusGenerating DeliveryReleaseNoteStatus = "generating"
+	DeliveryReleaseNoteStatusSuccess    DeliveryReleaseNoteStatus = "success"
+	DeliveryReleaseNoteStatusFailed     DeliveryReleaseNoteStatus = "failed"
+)
+
 const (
 	DeliveryVersionPackageStatusSuccess   = "success"
 	DeliveryVersionPackageStatusFailed    = "failed"
@@ -449,6 +472,8 @@ const (
 	// ProductionServiceTemplateCounterName use aslan/core/common/util.GenerateServiceNextRevision() to generate service revision
 	ProductionServiceTemplateCounterName = "productionservice:%s&project:%s"
 	EnvServiceVersionCounterName         = "project:%s&env:%s&service:%s&ishelmchart:%v"
+	// MockServiceCounterName is used to generate the revision of the mock of a service
+	MockServiceCounterName = "mockservice:%s&project:%s"
 	// GerritDefaultOwner
 	GerritDefaultOwner = "dafault"
 	// YamlFileSeperator ...
@@ -618,6 +643,23 @@ const (
 	ZadigDockerfilePath = "zadig-dockerfile"
 )
 
+// image build backends of the docker build step
+const (
+	// ImageBuildBackendDocker builds with the docker daemon of dind or the host
+	ImageBuildBackendDocker = "docker"
+	// ImageBuildBackendBuildKit builds with a rootless buildkitd running beside the job, no privileged pod or docker socket is needed
+	ImageBuildBackendBuildKit = "buildkit"
+
+	DefaultRootlessBuildKitImage = "moby/buildkit:rootless"
+	// RootlessBuildKitAddr is the address of the buildkitd sidecar in the job pod
+	RootlessBuildKitAddr = "tcp://127.0.0.1:1234"
+	// RootlessBuildctlPath is where the init container of the job puts buildctl
+	RootlessBuildctlPath = "/executor/buildctl"
+)
+
+// DefaultMockResponderImage is the image of the mock responders deployed in the environments
+const DefaultMockResponderImage = "koderover.tencentcloudcr.com/koderover-public/mock-responder:latest"
+
 // Yaml template constant
 const (
 	RegExpParameter = `{{.(\w)+}}`
@@ -894,6 +936,14 @@ const (
 	JobVMInfrastructure  string = "vm"
 )
 
+// execution mode of the jobs on vm agents
+const (
+	// VMExecutionModeHost runs the steps directly on the agent host, it is the default mode
+	VMExecutionModeHost string = "host"
+	// VMExecutionModeContainer runs the script steps in a container of the job image with the workspace bind-mounted
+	VMExecutionModeContainer string = "container"
+)
+
 const (
 	WorkflowTimeFormat = "[2006-01-02 15:04:05]"
 )
@@ -970,6 +1020,10 @@ const (
 
 const (
 	EventBusChannelClusterUpdate = "cluster_update"
+	// EventBusChannelVMJobCreated and EventBusChannelVMJobCancelled carry the vm job id to the aslan instances
+	// holding the job connections of zadig-agent
+	EventBusChannelVMJobCreated   = "vm_job_created"
+	EventBusChannelVMJobCancelled = "vm_job_cancelled"
 )
 
 type LarkEventType string
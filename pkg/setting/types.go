@@ -33,6 +33,8 @@ const (
 	DefaultRequest Request = "default"
 	// DefineRequest x CPU x G
 	DefineRequest Request = "define"
+	// AutoRequest sizes the job from the p95 resource usage of recent runs
+	AutoRequest Request = "auto"
 )
 
 type RequestSpec struct {
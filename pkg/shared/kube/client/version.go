@@ -23,6 +23,7 @@ import (
 
 var KubernetesVersion122 *version.Version
 var KubernetesVersion121 *version.Version
+var KubernetesVersion129 *version.Version
 
 func init() {
 	// as of zadig v1.11.0. Only kubernetes version 1.17+ is supported
@@ -35,6 +36,10 @@ func init() {
 	// Use to determine which apiVersion we should use when fetching cronJobs
 	v121, _ := version.ParseGeneric("v1.21.0")
 	KubernetesVersion121 = v121
+
+	// Native sidecar containers, init containers with restartPolicy Always, are enabled by default since 1.29
+	v129, _ := version.ParseGeneric("v1.29.0")
+	KubernetesVersion129 = v129
 }
 
 func VersionLessThan122(ver *k8sversion.Info) bool {
@@ -46,3 +51,8 @@ func VersionLessThan121(ver *k8sversion.Info) bool {
 	currVersion, _ := version.ParseGeneric(ver.String())
 	return currVersion.LessThan(KubernetesVersion121)
 }
+
+func VersionLessThan129(ver *k8sversion.Info) bool {
+	currVersion, _ := version.ParseGeneric(ver.String())
+	return currVersion.LessThan(KubernetesVersion129)
+}
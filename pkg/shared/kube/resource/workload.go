@@ -26,6 +26,16 @@ type Workload struct {
 	// frontend should limit or allow some operations on these workloads
 	ZadigXReleaseType string `json:"zadigx_release_type"`
 	ZadigXReleaseTag  string `json:"zadigx_release_tag"`
+	// Rollout is set when the workload is an Argo Rollout or is managed by a Kruise Rollout
+	Rollout *RolloutStatus `json:"rollout,omitempty"`
+}
+
+type RolloutStatus struct {
+	Name     string `json:"name"`
+	Provider string `json:"provider"`
+	Phase    string `json:"phase"`
+	Message  string `json:"message"`
+	Step     int64  `json:"step"`
 }
 
 type ContainerImage struct {
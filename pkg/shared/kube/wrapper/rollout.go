@@ -0,0 +1,209 @@
+/*
+Copyright 2025 The KodeRover Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package wrapper
+
+import (
+	"fmt"
+	"strconv"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+
+	"github.com/koderover/zadig/v2/pkg/setting"
+	"github.com/koderover/zadig/v2/pkg/shared/kube/resource"
+	"github.com/koderover/zadig/v2/pkg/util"
+)
+
+// argoRollout is the wrapper for the Argo Rollout, which is a custom resource kept as unstructured.
+type argoRollout struct {
+	*unstructured.Unstructured
+	template corev1.PodTemplateSpec
+	selector *metav1.LabelSelector
+}
+
+// ArgoRollout creates a wrapper for the given Argo Rollout object.
+func ArgoRollout(u *unstructured.Unstructured) *argoRollout {
+	if u == nil {
+		return nil
+	}
+	r := &argoRollout{
+		Unstructured: u,
+		selector:     &metav1.LabelSelector{},
+	}
+	if template, found, _ := unstructured.NestedMap(u.Object, "spec", "template"); found {
+		_ = runtime.DefaultUnstructuredConverter.FromUnstructured(template, &r.template)
+	}
+	if selector, found, _ := unstructured.NestedMap(u.Object, "spec", "selector"); found {
+		_ = runtime.DefaultUnstructuredConverter.FromUnstructured(selector, r.selector)
+	}
+	return r
+}
+
+// Template returns the pod template, it's empty if the rollout references a workload by workloadRef.
+func (r *argoRollout) Template() corev1.PodTemplateSpec {
+	return r.template
+}
+
+func (r *argoRollout) Selector() *metav1.LabelSelector {
+	return r.selector
+}
+
+func (r *argoRollout) Replicas() int32 {
+	replicas, found, _ := unstructured.NestedInt64(r.Object, "spec", "replicas")
+	if !found {
+		return 1
+	}
+	return int32(replicas)
+}
+
+// Phase returns the phase of the rollout, the rollout is regarded as progressing until the controller observes the
+// latest spec, since the phase is left unchanged for a while after the spec is updated.
+func (r *argoRollout) Phase() string {
+	if !rolloutObserved(r.Unstructured) {
+		return setting.RolloutPhaseProgressing
+	}
+	phase, _, _ := unstructured.NestedString(r.Object, "status", "phase")
+	if phase == "" {
+		return setting.RolloutPhaseProgressing
+	}
+	return phase
+}
+
+func (r *argoRollout) Ready() bool {
+	return r.Phase() == setting.RolloutPhaseHealthy
+}
+
+// RolloutStatus returns the status of the rollout shown in environments.
+func (r *argoRollout) RolloutStatus() *resource.RolloutStatus {
+	message, _, _ := unstructured.NestedString(r.Object, "status", "message")
+	step, _, _ := unstructured.NestedInt64(r.Object, "status", "currentStepIndex")
+	return &resource.RolloutStatus{
+		Name:     r.GetName(),
+		Provider: setting.RolloutProviderArgo,
+		Phase:    r.Phase(),
+		Message:  message,
+		Step:     step,
+	}
+}
+
+// WorkloadResource creates a Workload representation for the rollout.
+func (r *argoRollout) WorkloadResource(pods []*corev1.Pod) *resource.Workload {
+	wl := &resource.Workload{
+		Name:     r.GetName(),
+		Type:     setting.Rollout,
+		Replicas: r.Replicas(),
+		Images:   make([]resource.ContainerImage, 0),
+		Pods:     make([]*resource.Pod, 0, len(pods)),
+		Rollout:  r.RolloutStatus(),
+	}
+	for _, container := range r.template.Spec.Containers {
+		wl.Images = append(wl.Images, resource.ContainerImage{
+			Name:      container.Name,
+			Image:     container.Image,
+			ImageName: util.ExtractImageName(container.Image),
+		})
+	}
+	for _, pod := range pods {
+		wl.Pods = append(wl.Pods, Pod(pod).Resource())
+	}
+	return wl
+}
+
+// ImageInfos returns the images used by the containers in the rollout.
+func (r *argoRollout) ImageInfos() (images []string) {
+	for _, container := range r.template.Spec.Containers {
+		images = append(images, container.Image)
+	}
+	return
+}
+
+// GetContainers returns the container details of the rollout.
+func (r *argoRollout) GetContainers() []*resource.ContainerImage {
+	containers := make([]*resource.ContainerImage, 0, len(r.template.Spec.Containers))
+	for _, container := range r.template.Spec.Containers {
+		containers = append(containers, &resource.ContainerImage{
+			Name:      container.Name,
+			Image:     container.Image,
+			ImageName: util.ExtractImageName(container.Image),
+		})
+	}
+	return containers
+}
+
+// kruiseRollout is the wrapper for the Kruise Rollout, which manages the release of a Deployment or a CloneSet.
+type kruiseRollout struct {
+	*unstructured.Unstructured
+}
+
+// KruiseRollout creates a wrapper for the given Kruise Rollout object.
+func KruiseRollout(u *unstructured.Unstructured) *kruiseRollout {
+	if u == nil {
+		return nil
+	}
+	return &kruiseRollout{
+		Unstructured: u,
+	}
+}
+
+// WorkloadRef returns the kind and the name of the workload managed by the rollout.
+func (r *kruiseRollout) WorkloadRef() (kind, name string) {
+	kind, _, _ = unstructured.NestedString(r.Object, "spec", "workloadRef", "kind")
+	name, _, _ = unstructured.NestedString(r.Object, "spec", "workloadRef", "name")
+	return
+}
+
+// Phase maps the state of the rollout to the phases of Argo Rollouts. Failures of Kruise Rollouts are reported by the
+// workload instead of the rollout, so there is no degraded phase. Like Argo Rollouts, the rollout is regarded as
+// progressing until the controller observes the latest spec.
+func (r *kruiseRollout) Phase() string {
+	if !rolloutObserved(r.Unstructured) {
+		return setting.RolloutPhaseProgressing
+	}
+	for _, field := range []string{"canaryStatus", "blueGreenStatus"} {
+		state, _, _ := unstructured.NestedString(r.Object, "status", field, "currentStepState")
+		if state == "StepPaused" {
+			return setting.RolloutPhasePaused
+		}
+	}
+	phase, _, _ := unstructured.NestedString(r.Object, "status", "phase")
+	if phase == setting.RolloutPhaseHealthy {
+		return setting.RolloutPhaseHealthy
+	}
+	return setting.RolloutPhaseProgressing
+}
+
+// RolloutStatus returns the status of the rollout shown with the workload it manages.
+func (r *kruiseRollout) RolloutStatus() *resource.RolloutStatus {
+	message, _, _ := unstructured.NestedString(r.Object, "status", "message")
+	step, _, _ := unstructured.NestedInt64(r.Object, "status", "canaryStatus", "currentStepIndex")
+	return &resource.RolloutStatus{
+		Name:     r.GetName(),
+		Provider: setting.RolloutProviderKruise,
+		Phase:    r.Phase(),
+		Message:  message,
+		Step:     step,
+	}
+}
+
+// rolloutObserved returns whether the rollout controller has observed the latest spec of the rollout. Argo Rollouts
+// records the generation as a string while Kruise Rollouts records it as a number.
+func rolloutObserved(u *unstructured.Unstructured) bool {
+	observed, found, _ := unstructured.NestedFieldNoCopy(u.Object, "status", "observedGeneration")
+	return found && fmt.Sprint(observed) == strconv.FormatInt(u.GetGeneration(), 10)
+}
@@ -0,0 +1,190 @@
+/*
+Copyright 2025 The KodeRover Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package wrapper
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+
+	"github.com/koderover/zadig/v2/pkg/setting"
+)
+
+func newArgoRolloutFixture(generation int64, status map[string]interface{}) *unstructured.Unstructured {
+	u := &unstructured.Unstructured{Object: map[string]interface{}{
+		"apiVersion": "argoproj.io/v1alpha1",
+		"kind":       "Rollout",
+		"metadata": map[string]interface{}{
+			"name":       "demo",
+			"namespace":  "default",
+			"generation": generation,
+		},
+		"spec": map[string]interface{}{
+			"replicas": int64(2),
+		},
+	}}
+	if status != nil {
+		u.Object["status"] = status
+	}
+	return u
+}
+
+func newKruiseRolloutFixture(generation int64, status map[string]interface{}) *unstructured.Unstructured {
+	u := &unstructured.Unstructured{Object: map[string]interface{}{
+		"apiVersion": "rollouts.kruise.io/v1beta1",
+		"kind":       "Rollout",
+		"metadata": map[string]interface{}{
+			"name":       "demo",
+			"namespace":  "default",
+			"generation": generation,
+		},
+		"spec": map[string]interface{}{
+			"workloadRef": map[string]interface{}{
+				"apiVersion": "apps/v1",
+				"kind":       "Deployment",
+				"name":       "demo",
+			},
+		},
+	}}
+	if status != nil {
+		u.Object["status"] = status
+	}
+	return u
+}
+
+func TestArgoRolloutPhase(t *testing.T) {
+	tests := []struct {
+		name    string
+		rollout *unstructured.Unstructured
+		want    string
+	}{
+		{
+			name:    "healthy",
+			rollout: newArgoRolloutFixture(2, map[string]interface{}{"observedGeneration": "2", "phase": "Healthy"}),
+			want:    setting.RolloutPhaseHealthy,
+		},
+		{
+			name:    "progressing",
+			rollout: newArgoRolloutFixture(2, map[string]interface{}{"observedGeneration": "2", "phase": "Progressing"}),
+			want:    setting.RolloutPhaseProgressing,
+		},
+		{
+			name:    "paused",
+			rollout: newArgoRolloutFixture(2, map[string]interface{}{"observedGeneration": "2", "phase": "Paused", "currentStepIndex": int64(1)}),
+			want:    setting.RolloutPhasePaused,
+		},
+		{
+			name:    "degraded",
+			rollout: newArgoRolloutFixture(2, map[string]interface{}{"observedGeneration": "2", "phase": "Degraded"}),
+			want:    setting.RolloutPhaseDegraded,
+		},
+		{
+			name:    "stale observed generation",
+			rollout: newArgoRolloutFixture(3, map[string]interface{}{"observedGeneration": "2", "phase": "Healthy"}),
+			want:    setting.RolloutPhaseProgressing,
+		},
+		{
+			name:    "no phase yet",
+			rollout: newArgoRolloutFixture(1, map[string]interface{}{"observedGeneration": "1"}),
+			want:    setting.RolloutPhaseProgressing,
+		},
+		{
+			name:    "no status",
+			rollout: newArgoRolloutFixture(1, nil),
+			want:    setting.RolloutPhaseProgressing,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ast := require.New(t)
+
+			r := ArgoRollout(tt.rollout)
+			ast.Equal(tt.want, r.Phase())
+			ast.Equal(tt.want == setting.RolloutPhaseHealthy, r.Ready())
+		})
+	}
+}
+
+func TestKruiseRolloutPhase(t *testing.T) {
+	tests := []struct {
+		name    string
+		rollout *unstructured.Unstructured
+		want    string
+	}{
+		{
+			name:    "healthy",
+			rollout: newKruiseRolloutFixture(2, map[string]interface{}{"observedGeneration": int64(2), "phase": "Healthy"}),
+			want:    setting.RolloutPhaseHealthy,
+		},
+		{
+			name: "progressing",
+			rollout: newKruiseRolloutFixture(2, map[string]interface{}{
+				"observedGeneration": int64(2),
+				"phase":              "Progressing",
+				"canaryStatus":       map[string]interface{}{"currentStepIndex": int64(1), "currentStepState": "StepUpgrade"},
+			}),
+			want: setting.RolloutPhaseProgressing,
+		},
+		{
+			name: "canary step paused",
+			rollout: newKruiseRolloutFixture(2, map[string]interface{}{
+				"observedGeneration": int64(2),
+				"phase":              "Progressing",
+				"canaryStatus":       map[string]interface{}{"currentStepIndex": int64(1), "currentStepState": "StepPaused"},
+			}),
+			want: setting.RolloutPhasePaused,
+		},
+		{
+			name: "blue green step paused",
+			rollout: newKruiseRolloutFixture(2, map[string]interface{}{
+				"observedGeneration": int64(2),
+				"phase":              "Progressing",
+				"blueGreenStatus":    map[string]interface{}{"currentStepIndex": int64(1), "currentStepState": "StepPaused"},
+			}),
+			want: setting.RolloutPhasePaused,
+		},
+		{
+			name:    "initial phase",
+			rollout: newKruiseRolloutFixture(1, map[string]interface{}{"observedGeneration": int64(1), "phase": "Initial"}),
+			want:    setting.RolloutPhaseProgressing,
+		},
+		{
+			name: "stale observed generation",
+			rollout: newKruiseRolloutFixture(3, map[string]interface{}{
+				"observedGeneration": int64(2),
+				"phase":              "Healthy",
+				"canaryStatus":       map[string]interface{}{"currentStepIndex": int64(1), "currentStepState": "StepPaused"},
+			}),
+			want: setting.RolloutPhaseProgressing,
+		},
+		{
+			name:    "no status",
+			rollout: newKruiseRolloutFixture(1, nil),
+			want:    setting.RolloutPhaseProgressing,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ast := require.New(t)
+
+			r := KruiseRollout(tt.rollout)
+			ast.Equal(tt.want, r.Phase())
+			ast.Equal(tt.want, r.RolloutStatus().Phase)
+		})
+	}
+}
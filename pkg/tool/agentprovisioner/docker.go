@@ -0,0 +1,118 @@
+/*
+Copyright 2025 The KodeRover Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package agentprovisioner
+
+import (
+	"context"
+	"fmt"
+	"io"
+
+	"github.com/docker/docker/api/types"
+	"github.com/docker/docker/api/types/container"
+	"github.com/docker/docker/api/types/network"
+	"github.com/docker/docker/client"
+)
+
+const dockerAgentLabel = "zadig.koderover.io/agent"
+
+type DockerConfig struct {
+	// Host is the docker daemon address, the DOCKER_HOST of aslan is used if it is empty
+	Host string
+	// Image must contain zadig-agent in its PATH
+	Image   string
+	Network string
+	Envs    []string
+}
+
+type dockerProvisioner struct {
+	config *DockerConfig
+	client *client.Client
+}
+
+// NewDockerProvisioner runs every agent in a container on a docker daemon. It is meant for trying agent pools
+// locally, the cloud providers should be used in production.
+func NewDockerProvisioner(config *DockerConfig) (Provisioner, error) {
+	if config == nil || config.Image == "" {
+		return nil, fmt.Errorf("docker image of the agent is required")
+	}
+
+	opts := []client.Opt{client.FromEnv, client.WithAPIVersionNegotiation()}
+	if config.Host != "" {
+		opts = append(opts, client.WithHost(config.Host))
+	}
+	cli, err := client.NewClientWithOpts(opts...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create docker client: %s", err)
+	}
+	return &dockerProvisioner{config: config, client: cli}, nil
+}
+
+func (p *dockerProvisioner) Create(ctx context.Context, spec *AgentSpec) (string, error) {
+	containerConfig := &container.Config{
+		Image: p.config.Image,
+		Env:   p.config.Envs,
+		Cmd:   []string{"zadig-agent", "start", "--server-url", spec.ServerURL, "--token", spec.Token},
+		Labels: map[string]string{
+			dockerAgentLabel: spec.Name,
+		},
+	}
+	hostConfig := &container.HostConfig{}
+	if p.config.Network != "" {
+		hostConfig.NetworkMode = container.NetworkMode(p.config.Network)
+	}
+
+	resp, err := p.client.ContainerCreate(ctx, containerConfig, hostConfig, &network.NetworkingConfig{}, nil, spec.Name)
+	if client.IsErrNotFound(err) {
+		if err = p.pullImage(ctx); err != nil {
+			return "", err
+		}
+		resp, err = p.client.ContainerCreate(ctx, containerConfig, hostConfig, &network.NetworkingConfig{}, nil, spec.Name)
+	}
+	if err != nil {
+		return "", fmt.Errorf("failed to create agent container %s: %s", spec.Name, err)
+	}
+
+	if err := p.client.ContainerStart(ctx, resp.ID, container.StartOptions{}); err != nil {
+		_ = p.Destroy(ctx, resp.ID)
+		return "", fmt.Errorf("failed to start agent container %s: %s", spec.Name, err)
+	}
+	return resp.ID, nil
+}
+
+func (p *dockerProvisioner) pullImage(ctx context.Context) error {
+	reader, err := p.client.ImagePull(ctx, p.config.Image, types.ImagePullOptions{})
+	if err != nil {
+		return fmt.Errorf("failed to pull agent image %s: %s", p.config.Image, err)
+	}
+	defer reader.Close()
+
+	// the pull is done when the progress stream ends
+	_, err = io.Copy(io.Discard, reader)
+	return err
+}
+
+func (p *dockerProvisioner) Destroy(ctx context.Context, instanceID string) error {
+	err := p.client.ContainerRemove(ctx, instanceID, container.RemoveOptions{Force: true, RemoveVolumes: true})
+	if err != nil && !client.IsErrNotFound(err) {
+		return fmt.Errorf("failed to remove agent container %s: %s", instanceID, err)
+	}
+	return nil
+}
+
+func (p *dockerProvisioner) Close() error {
+	return p.client.Close()
+}
@@ -0,0 +1,60 @@
+/*
+Copyright 2025 The KodeRover Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package agentprovisioner
+
+import (
+	"context"
+	"fmt"
+)
+
+const (
+	TypeDocker = "docker"
+)
+
+// Provisioner creates and destroys the machines running zadig-agent for agent pools. The agent started by the
+// provisioner registers itself to aslan with the token in the spec.
+type Provisioner interface {
+	// Create starts a new agent and returns the instance id used to destroy it
+	Create(ctx context.Context, spec *AgentSpec) (string, error)
+	Destroy(ctx context.Context, instanceID string) error
+	// Close releases the connections of the provisioner
+	Close() error
+}
+
+type AgentSpec struct {
+	Name      string
+	ServerURL string
+	Token     string
+}
+
+type Config struct {
+	Type   string
+	Docker *DockerConfig
+}
+
+func New(config *Config) (Provisioner, error) {
+	if config == nil {
+		return nil, fmt.Errorf("provisioner is not configured")
+	}
+
+	switch config.Type {
+	case TypeDocker:
+		return NewDockerProvisioner(config.Docker)
+	default:
+		return nil, fmt.Errorf("unsupported provisioner type: %s", config.Type)
+	}
+}
@@ -0,0 +1,80 @@
+/*
+Copyright 2025 The KodeRover Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package agentprovisioner
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestNew(t *testing.T) {
+	_, err := New(nil)
+	require.Error(t, err)
+
+	_, err = New(&Config{Type: "libvirt"})
+	require.Error(t, err)
+
+	_, err = New(&Config{Type: TypeDocker, Docker: &DockerConfig{}})
+	require.Error(t, err)
+}
+
+func TestDockerProvisioner(t *testing.T) {
+	var created map[string]interface{}
+	removed := ""
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Api-Version", "1.44")
+		switch {
+		case r.URL.Path == "/_ping":
+			_, _ = w.Write([]byte("OK"))
+		case strings.HasSuffix(r.URL.Path, "/containers/create"):
+			require.Equal(t, "pool_1", r.URL.Query().Get("name"))
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&created))
+			w.WriteHeader(http.StatusCreated)
+			_, _ = w.Write([]byte(`{"Id":"c1"}`))
+		case strings.HasSuffix(r.URL.Path, "/containers/c1/start"):
+			w.WriteHeader(http.StatusNoContent)
+		case r.Method == http.MethodDelete && strings.HasSuffix(r.URL.Path, "/containers/c1"):
+			removed = "c1"
+			w.WriteHeader(http.StatusNoContent)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	p, err := NewDockerProvisioner(&DockerConfig{
+		Host:  "tcp://" + strings.TrimPrefix(server.URL, "http://"),
+		Image: "koderover/zadig-agent:latest",
+	})
+	require.NoError(t, err)
+	defer p.Close()
+
+	id, err := p.Create(context.Background(), &AgentSpec{Name: "pool_1", ServerURL: "http://zadig", Token: "t"})
+	require.NoError(t, err)
+	require.Equal(t, "c1", id)
+	require.Equal(t, "koderover/zadig-agent:latest", created["Image"])
+	require.Equal(t, []interface{}{"zadig-agent", "start", "--server-url", "http://zadig", "--token", "t"}, created["Cmd"])
+
+	require.NoError(t, p.Destroy(context.Background(), id))
+	require.Equal(t, "c1", removed)
+}
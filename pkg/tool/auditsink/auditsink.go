@@ -0,0 +1,129 @@
+/*
+Copyright 2025 The KodeRover Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package auditsink delivers audit events to external systems such as SIEMs.
+package auditsink
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"time"
+)
+
+type Type string
+
+const (
+	TypeSyslog  Type = "syslog"
+	TypeWebhook Type = "webhook"
+	TypeKafka   Type = "kafka"
+)
+
+// Event is an audit event as delivered to the sinks. Request bodies are left out on purpose since they may hold
+// credentials, the hash links the event to the tamper evident chain of the stored audit log.
+type Event struct {
+	ID          string    `json:"id"`
+	Seq         int64     `json:"seq"`
+	Hash        string    `json:"hash"`
+	PrevHash    string    `json:"prev_hash"`
+	Time        time.Time `json:"time"`
+	Username    string    `json:"username"`
+	ProjectName string    `json:"project_name"`
+	Method      string    `json:"method"`
+	Function    string    `json:"function"`
+	Scene       string    `json:"scene,omitempty"`
+	Targets     []string  `json:"targets,omitempty"`
+	Detail      string    `json:"detail"`
+	DetailEn    string    `json:"detail_en,omitempty"`
+	Status      int       `json:"status"`
+}
+
+// Failed reports whether the audited request failed.
+func (e *Event) Failed() bool {
+	return e.Status >= 400
+}
+
+type Sink interface {
+	Send(ctx context.Context, event *Event) error
+	Close() error
+}
+
+type Config struct {
+	Type    Type
+	Syslog  *SyslogConfig
+	Webhook *WebhookConfig
+	Kafka   *KafkaConfig
+}
+
+// New creates the sink described by the config.
+func New(config *Config) (Sink, error) {
+	switch config.Type {
+	case TypeSyslog:
+		if config.Syslog == nil {
+			return nil, fmt.Errorf("syslog config is required")
+		}
+		return NewSyslogSink(config.Syslog)
+	case TypeWebhook:
+		if config.Webhook == nil {
+			return nil, fmt.Errorf("webhook config is required")
+		}
+		return NewWebhookSink(config.Webhook)
+	case TypeKafka:
+		if config.Kafka == nil {
+			return nil, fmt.Errorf("kafka config is required")
+		}
+		return NewKafkaSink(config.Kafka)
+	default:
+		return nil, fmt.Errorf("unsupported audit sink type: %s", config.Type)
+	}
+}
+
+// TLSConfig configures the TLS connection to a sink, the system roots are used when CACert is empty.
+type TLSConfig struct {
+	Enabled            bool
+	CACert             string
+	ClientCert         string
+	ClientKey          string
+	InsecureSkipVerify bool
+}
+
+func (c *TLSConfig) build(serverName string) (*tls.Config, error) {
+	if c == nil || !c.Enabled {
+		return nil, nil
+	}
+
+	config := &tls.Config{
+		ServerName:         serverName,
+		InsecureSkipVerify: c.InsecureSkipVerify,
+		MinVersion:         tls.VersionTLS12,
+	}
+	if c.CACert != "" {
+		pool := x509.NewCertPool()
+		if !pool.AppendCertsFromPEM([]byte(c.CACert)) {
+			return nil, fmt.Errorf("invalid CA certificate")
+		}
+		config.RootCAs = pool
+	}
+	if c.ClientCert != "" || c.ClientKey != "" {
+		cert, err := tls.X509KeyPair([]byte(c.ClientCert), []byte(c.ClientKey))
+		if err != nil {
+			return nil, fmt.Errorf("invalid client certificate: %s", err)
+		}
+		config.Certificates = []tls.Certificate{cert}
+	}
+	return config, nil
+}
@@ -0,0 +1,173 @@
+/*
+Copyright 2025 The KodeRover Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package auditsink
+
+import (
+	"bufio"
+	"context"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func testEvent() *Event {
+	return &Event{
+		ID:          "65f0c0ffee",
+		Seq:         42,
+		Hash:        "abc",
+		Time:        time.Date(2025, 3, 1, 8, 30, 0, 0, time.UTC),
+		Username:    "alice",
+		ProjectName: "demo",
+		Method:      "删除",
+		Function:    "环境",
+		Detail:      `dev "main"]`,
+		Status:      403,
+	}
+}
+
+func TestFormatRFC5424(t *testing.T) {
+	message, err := FormatRFC5424(testEvent(), "aslan-0", "")
+	require.NoError(t, err)
+
+	// facility 13 * 8 + warning 4 since the request failed
+	require.True(t, strings.HasPrefix(message, "<108>1 2025-03-01T08:30:00.000000Z aslan-0 zadig - audit [audit@32473 "), message)
+	require.Contains(t, message, `seq="42"`)
+	require.Contains(t, message, `user="alice"`)
+	require.Contains(t, message, `status="403"`)
+	require.Contains(t, message, `"detail":"dev \"main\"]"`)
+
+	event := testEvent()
+	event.Status = 200
+	event.Username = `a"b]c\`
+	message, err = FormatRFC5424(event, "", "zadig-audit")
+	require.NoError(t, err)
+	require.True(t, strings.HasPrefix(message, "<109>1 2025-03-01T08:30:00.000000Z - zadig-audit - audit "), message)
+	require.Contains(t, message, `user="a\"b\]c\\"`)
+}
+
+func TestSyslogSink(t *testing.T) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	defer listener.Close()
+
+	received := make(chan string, 1)
+	go func() {
+		conn, err := listener.Accept()
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+
+		reader := bufio.NewReader(conn)
+		length, err := reader.ReadString(' ')
+		if err != nil {
+			return
+		}
+		n, _ := strconv.Atoi(strings.TrimSpace(length))
+		buf := make([]byte, n)
+		if _, err := io.ReadFull(reader, buf); err != nil {
+			return
+		}
+		received <- string(buf)
+	}()
+
+	sink, err := New(&Config{Type: TypeSyslog, Syslog: &SyslogConfig{Address: listener.Addr().String()}})
+	require.NoError(t, err)
+	defer sink.Close()
+
+	require.NoError(t, sink.Send(context.Background(), testEvent()))
+	select {
+	case message := <-received:
+		require.True(t, strings.HasPrefix(message, "<108>1 "))
+		require.True(t, strings.HasSuffix(message, "}"))
+	case <-time.After(5 * time.Second):
+		t.Fatal("syslog message not received")
+	}
+}
+
+func TestWebhookSink(t *testing.T) {
+	var (
+		gotBody      []byte
+		gotSignature string
+		gotTimestamp string
+	)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotBody, _ = io.ReadAll(r.Body)
+		gotSignature = r.Header.Get(WebhookSignatureHeader)
+		gotTimestamp = r.Header.Get(WebhookTimestampHeader)
+		if strings.Contains(string(gotBody), `"seq":0`) {
+			w.WriteHeader(http.StatusInternalServerError)
+		}
+	}))
+	defer server.Close()
+
+	sink, err := New(&Config{Type: TypeWebhook, Webhook: &WebhookConfig{URL: server.URL, Secret: "s3cret"}})
+	require.NoError(t, err)
+
+	require.NoError(t, sink.Send(context.Background(), testEvent()))
+	require.Equal(t, "sha256="+SignWebhook("s3cret", gotTimestamp, gotBody), gotSignature)
+	require.NotEqual(t, "sha256="+SignWebhook("other", gotTimestamp, gotBody), gotSignature)
+
+	event := testEvent()
+	event.Seq = 0
+	require.Error(t, sink.Send(context.Background(), event))
+}
+
+func TestKafkaSink(t *testing.T) {
+	var gotPath, gotContentType, gotUser string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotPath = r.URL.Path
+		gotContentType = r.Header.Get("Content-Type")
+		gotUser, _, _ = r.BasicAuth()
+		body, _ := io.ReadAll(r.Body)
+		if strings.Contains(string(body), `"key":"rejected"`) {
+			_, _ = w.Write([]byte(`{"offsets":[{"partition":null,"offset":null,"error_code":40403,"error":"topic not authorized"}]}`))
+			return
+		}
+		_, _ = w.Write([]byte(`{"offsets":[{"partition":0,"offset":7}]}`))
+	}))
+	defer server.Close()
+
+	sink, err := New(&Config{Type: TypeKafka, Kafka: &KafkaConfig{RESTProxyURL: server.URL + "/", Topic: "zadig-audit", Username: "producer"}})
+	require.NoError(t, err)
+	defer sink.Close()
+
+	require.NoError(t, sink.Send(context.Background(), testEvent()))
+	require.Equal(t, "/topics/zadig-audit", gotPath)
+	require.Equal(t, kafkaRESTContentType, gotContentType)
+	require.Equal(t, "producer", gotUser)
+
+	event := testEvent()
+	event.ProjectName = "rejected"
+	require.ErrorContains(t, sink.Send(context.Background(), event), "topic not authorized")
+}
+
+func TestNewInvalidConfig(t *testing.T) {
+	_, err := New(&Config{Type: "splunk"})
+	require.Error(t, err)
+	_, err = New(&Config{Type: TypeKafka, Kafka: &KafkaConfig{RESTProxyURL: "http://kafka-rest:8082"}})
+	require.Error(t, err)
+	_, err = New(&Config{Type: TypeSyslog, Syslog: &SyslogConfig{Address: "siem", TLS: &TLSConfig{Enabled: true}}})
+	require.Error(t, err)
+}
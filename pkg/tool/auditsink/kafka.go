@@ -0,0 +1,134 @@
+/*
+Copyright 2025 The KodeRover Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package auditsink
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+const kafkaRESTContentType = "application/vnd.kafka.json.v2+json"
+
+type KafkaConfig struct {
+	// RESTProxyURL is the address of the Kafka REST proxy (v2 API) the events are produced through
+	RESTProxyURL string
+	Topic        string
+	// Username and Password are used for basic auth when the proxy requires it
+	Username string
+	Password string
+	TLS      *TLSConfig
+}
+
+type kafkaSink struct {
+	config   *KafkaConfig
+	endpoint string
+	client   *http.Client
+}
+
+type kafkaRecord struct {
+	Key   string `json:"key"`
+	Value *Event `json:"value"`
+}
+
+type kafkaProduceRequest struct {
+	Records []*kafkaRecord `json:"records"`
+}
+
+type kafkaProduceResponse struct {
+	Offsets []struct {
+		Partition *int   `json:"partition"`
+		ErrorCode *int   `json:"error_code"`
+		Error     string `json:"error"`
+	} `json:"offsets"`
+	Message string `json:"message"`
+}
+
+// NewKafkaSink produces events to the topic through the Kafka REST proxy, keyed by project so the events of a
+// project keep their order.
+func NewKafkaSink(config *KafkaConfig) (Sink, error) {
+	if config.RESTProxyURL == "" || config.Topic == "" {
+		return nil, fmt.Errorf("kafka rest proxy url and topic are required")
+	}
+	proxyURL, err := url.Parse(config.RESTProxyURL)
+	if err != nil || proxyURL.Host == "" {
+		return nil, fmt.Errorf("invalid kafka rest proxy url %s", config.RESTProxyURL)
+	}
+
+	tlsConfig, err := config.TLS.build(proxyURL.Hostname())
+	if err != nil {
+		return nil, err
+	}
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	if tlsConfig != nil {
+		transport.TLSClientConfig = tlsConfig
+	}
+
+	return &kafkaSink{
+		config:   config,
+		endpoint: strings.TrimRight(config.RESTProxyURL, "/") + "/topics/" + url.PathEscape(config.Topic),
+		client:   &http.Client{Timeout: 10 * time.Second, Transport: transport},
+	}, nil
+}
+
+func (s *kafkaSink) Send(ctx context.Context, event *Event) error {
+	body, err := json.Marshal(&kafkaProduceRequest{Records: []*kafkaRecord{{Key: event.ProjectName, Value: event}}})
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, s.endpoint, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", kafkaRESTContentType)
+	req.Header.Set("Accept", "application/vnd.kafka.v2+json")
+	if s.config.Username != "" {
+		req.SetBasicAuth(s.config.Username, s.config.Password)
+	}
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to produce audit event to kafka: %s", err)
+	}
+	defer resp.Body.Close()
+	respBody, _ := io.ReadAll(resp.Body)
+
+	result := &kafkaProduceResponse{}
+	_ = json.Unmarshal(respBody, result)
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("kafka rest proxy responded with status %d: %s", resp.StatusCode, result.Message)
+	}
+	// the proxy answers 200 even when a record is rejected, the error is reported per offset
+	for _, offset := range result.Offsets {
+		if offset.ErrorCode != nil || offset.Error != "" {
+			return fmt.Errorf("kafka rejected the audit event: %s", offset.Error)
+		}
+	}
+	return nil
+}
+
+func (s *kafkaSink) Close() error {
+	s.client.CloseIdleConnections()
+	return nil
+}
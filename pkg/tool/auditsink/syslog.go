@@ -0,0 +1,186 @@
+/*
+Copyright 2025 The KodeRover Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package auditsink
+
+import (
+	"context"
+	"crypto/tls"
+	"encoding/json"
+	"fmt"
+	"net"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	// facility 13 is "log audit"
+	syslogFacilityAudit = 13
+	syslogSeverityWarn  = 4
+	syslogSeverityNote  = 5
+
+	defaultSyslogAppName = "zadig"
+	// 32473 is the private enterprise number reserved for documentation, it scopes the structured data of the event
+	syslogSDID = "audit@32473"
+)
+
+type SyslogConfig struct {
+	// Address is the host:port of the syslog receiver, messages are sent over TCP with octet counting framing (RFC 5425)
+	Address string
+	AppName string
+	TLS     *TLSConfig
+}
+
+type syslogSink struct {
+	config    *SyslogConfig
+	tlsConfig *tls.Config
+	hostname  string
+
+	mu   sync.Mutex
+	conn net.Conn
+}
+
+// NewSyslogSink sends events as RFC 5424 messages, over TLS when it's enabled.
+func NewSyslogSink(config *SyslogConfig) (Sink, error) {
+	host, _, err := net.SplitHostPort(config.Address)
+	if err != nil {
+		return nil, fmt.Errorf("invalid syslog address %s: %s", config.Address, err)
+	}
+	tlsConfig, err := config.TLS.build(host)
+	if err != nil {
+		return nil, err
+	}
+
+	hostname, err := os.Hostname()
+	if err != nil || hostname == "" {
+		hostname = "-"
+	}
+	return &syslogSink{config: config, tlsConfig: tlsConfig, hostname: hostname}, nil
+}
+
+func (s *syslogSink) Send(ctx context.Context, event *Event) error {
+	message, err := FormatRFC5424(event, s.hostname, s.config.AppName)
+	if err != nil {
+		return err
+	}
+	frame := strconv.Itoa(len(message)) + " " + message
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.conn == nil {
+		if s.conn, err = s.dial(ctx); err != nil {
+			return err
+		}
+	}
+	if deadline, ok := ctx.Deadline(); ok {
+		_ = s.conn.SetWriteDeadline(deadline)
+	} else {
+		_ = s.conn.SetWriteDeadline(time.Now().Add(10 * time.Second))
+	}
+	if _, err := s.conn.Write([]byte(frame)); err != nil {
+		// drop the broken connection, the next attempt reconnects
+		_ = s.conn.Close()
+		s.conn = nil
+		return fmt.Errorf("failed to write to syslog %s: %s", s.config.Address, err)
+	}
+	return nil
+}
+
+func (s *syslogSink) dial(ctx context.Context) (net.Conn, error) {
+	dialer := &net.Dialer{Timeout: 10 * time.Second}
+	var (
+		conn net.Conn
+		err  error
+	)
+	if s.tlsConfig != nil {
+		conn, err = (&tls.Dialer{NetDialer: dialer, Config: s.tlsConfig}).DialContext(ctx, "tcp", s.config.Address)
+	} else {
+		conn, err = dialer.DialContext(ctx, "tcp", s.config.Address)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to syslog %s: %s", s.config.Address, err)
+	}
+	return conn, nil
+}
+
+func (s *syslogSink) Close() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.conn == nil {
+		return nil
+	}
+	err := s.conn.Close()
+	s.conn = nil
+	return err
+}
+
+// FormatRFC5424 renders the event as an RFC 5424 syslog message. The key fields are kept in the structured data
+// for SIEM parsing and the full event is sent as JSON in the message body.
+func FormatRFC5424(event *Event, hostname, appName string) (string, error) {
+	if hostname == "" {
+		hostname = "-"
+	}
+	if appName == "" {
+		appName = defaultSyslogAppName
+	}
+
+	severity := syslogSeverityNote
+	if event.Failed() {
+		severity = syslogSeverityWarn
+	}
+
+	body, err := json.Marshal(event)
+	if err != nil {
+		return "", err
+	}
+
+	params := [][2]string{
+		{"id", event.ID},
+		{"seq", strconv.FormatInt(event.Seq, 10)},
+		{"user", event.Username},
+		{"project", event.ProjectName},
+		{"method", event.Method},
+		{"function", event.Function},
+		{"status", strconv.Itoa(event.Status)},
+		{"hash", event.Hash},
+	}
+	sd := &strings.Builder{}
+	sd.WriteString("[" + syslogSDID)
+	for _, param := range params {
+		fmt.Fprintf(sd, ` %s="%s"`, param[0], escapeSDParam(param[1]))
+	}
+	sd.WriteString("]")
+
+	return fmt.Sprintf("<%d>1 %s %s %s - audit %s %s",
+		syslogFacilityAudit*8+severity,
+		event.Time.UTC().Format("2006-01-02T15:04:05.000000Z07:00"),
+		hostname,
+		appName,
+		sd.String(),
+		body,
+	), nil
+}
+
+var sdParamEscaper = strings.NewReplacer(`\`, `\\`, `"`, `\"`, `]`, `\]`)
+
+func escapeSDParam(value string) string {
+	return sdParamEscaper.Replace(value)
+}
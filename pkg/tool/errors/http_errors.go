@@ -555,6 +555,7 @@ var (
 	ErrCodehostListPrs        = NewHTTPError(6553, "请确认是否为有效代码源，列出pr失败")
 	ErrCodehostListTags       = NewHTTPError(6554, "请确认是否为有效代码源，列出tag失败")
 	ErrCodehostListCommits    = NewHTTPError(6555, "请确认是否为有效代码源，列出commit失败")
+	ErrCodehostGetCommit      = NewHTTPError(6556, "请确认是否为有效代码源，获取commit失败")
 
 	//-----------------------------------------------------------------------------------------------
 	// delivery_version APIs Range: 6560 - 6569
@@ -567,6 +568,8 @@ var (
 	ErrFindDeliveryProducts  = NewHTTPError(6564, "查询交付中心产品列表失败")
 	ErrUpdateDeliveryVersion = NewHTTPError(6565, "更新交付中心版本失败")
 	ErrCheckDeliveryVersion  = NewHTTPError(6566, "检查交付中心版本失败")
+	ErrGenerateReleaseNote   = NewHTTPError(6567, "生成交付中心版本发布说明失败")
+	ErrGetReleaseNote        = NewHTTPError(6568, "查询交付中心版本发布说明失败")
 
 	//-----------------------------------------------------------------------------------------------
 	// delivery_build APIs Range: 6570 - 6579
@@ -630,10 +633,14 @@ var (
 	//-----------------------------------------------------------------------------------------------
 	// operation APIs Range: 6650 - 6659
 	//-----------------------------------------------------------------------------------------------
-	ErrCreateOperationLog    = NewHTTPError(6651, "添加操作日志失败")
-	ErrFindOperationLog      = NewHTTPError(6652, "获取操作日志列表失败")
-	ErrFindOperationLogCount = NewHTTPError(6653, "获取操作日志总数失败")
-	ErrUpdateOperationLog    = NewHTTPError(6654, "更新操作日志失败")
+	ErrCreateOperationLog     = NewHTTPError(6651, "添加操作日志失败")
+	ErrFindOperationLog       = NewHTTPError(6652, "获取操作日志列表失败")
+	ErrFindOperationLogCount  = NewHTTPError(6653, "获取操作日志总数失败")
+	ErrUpdateOperationLog     = NewHTTPError(6654, "更新操作日志失败")
+	ErrGetAuditLogSettings    = NewHTTPError(6655, "获取审计日志设置失败")
+	ErrUpdateAuditLogSettings = NewHTTPError(6656, "更新审计日志设置失败")
+	ErrVerifyOperationLog     = NewHTTPError(6657, "校验操作日志完整性失败")
+	ErrAuditLogDeadLetter     = NewHTTPError(6658, "处理审计日志投递失败记录失败")
 
 	//-----------------------------------------------------------------------------------------------
 	// operation APIs Range: 6660 - 6669
@@ -1009,11 +1016,15 @@ var (
 	//-----------------------------------------------------------------------------------------------
 	// keyvault releated errors: 7170 - 7179
 	//-----------------------------------------------------------------------------------------------
-	ErrCreateKeyVaultItem = NewHTTPError(7170, "创建密钥库条目失败")
-	ErrUpdateKeyVaultItem = NewHTTPError(7171, "更新密钥库条目失败")
-	ErrDeleteKeyVaultItem = NewHTTPError(7172, "删除密钥库条目失败")
-	ErrListKeyVaultItem   = NewHTTPError(7173, "获取密钥库条目列表失败")
-	ErrGetKeyVaultItem    = NewHTTPError(7174, "获取密钥库条目详情失败")
+	ErrCreateKeyVaultItem     = NewHTTPError(7170, "创建密钥库条目失败")
+	ErrUpdateKeyVaultItem     = NewHTTPError(7171, "更新密钥库条目失败")
+	ErrDeleteKeyVaultItem     = NewHTTPError(7172, "删除密钥库条目失败")
+	ErrListKeyVaultItem       = NewHTTPError(7173, "获取密钥库条目列表失败")
+	ErrGetKeyVaultItem        = NewHTTPError(7174, "获取密钥库条目详情失败")
+	ErrListKeyVaultProvider   = NewHTTPError(7175, "获取密钥库后端列表失败")
+	ErrCreateKeyVaultProvider = NewHTTPError(7176, "创建密钥库后端失败")
+	ErrUpdateKeyVaultProvider = NewHTTPError(7177, "更新密钥库后端失败")
+	ErrDeleteKeyVaultProvider = NewHTTPError(7178, "删除密钥库后端失败")
 
 	//-----------------------------------------------------------------------------------------------
 	// api gateway releated errors: 7180 - 7189
@@ -1023,4 +1034,52 @@ var (
 	ErrDeleteApiGateway   = NewHTTPError(7182, "删除API网关集成失败")
 	ErrListApiGateway     = NewHTTPError(7183, "列出API网关集成失败")
 	ErrValidateApiGateway = NewHTTPError(7184, "校验API网关集成失败")
+
+	//-----------------------------------------------------------------------------------------------
+	// sbom releated errors: 7190 - 7199
+	//-----------------------------------------------------------------------------------------------
+	ErrGetSBOM = NewHTTPError(7190, "获取软件物料清单失败")
+
+	//-----------------------------------------------------------------------------------------------
+	// admission policy releated errors: 7200 - 7209
+	//-----------------------------------------------------------------------------------------------
+	ErrListAdmissionPolicy     = NewHTTPError(7200, "获取准入策略列表失败")
+	ErrCreateAdmissionPolicy   = NewHTTPError(7201, "创建准入策略失败")
+	ErrUpdateAdmissionPolicy   = NewHTTPError(7202, "更新准入策略失败")
+	ErrDeleteAdmissionPolicy   = NewHTTPError(7203, "删除准入策略失败")
+	ErrEvaluateAdmissionPolicy = NewHTTPError(7204, "执行准入策略失败")
+	ErrAdmissionPolicyDenied   = NewHTTPError(7205, "准入策略拒绝")
+
+	//-----------------------------------------------------------------------------------------------
+	// kustomize service releated errors: 7210 - 7219
+	//-----------------------------------------------------------------------------------------------
+	ErrLoadKustomizeService  = NewHTTPError(7210, "加载Kustomize服务失败")
+	ErrBuildKustomizeService = NewHTTPError(7211, "构建Kustomize服务失败")
+
+	//-----------------------------------------------------------------------------------------------
+	// rollout releated errors: 7220 - 7229
+	//-----------------------------------------------------------------------------------------------
+	ErrPromoteRollout = NewHTTPError(7220, "推进Rollout失败")
+	ErrAbortRollout   = NewHTTPError(7221, "中止Rollout失败")
+
+	//-----------------------------------------------------------------------------------------------
+	// cost releated errors: 7230 - 7239
+	//-----------------------------------------------------------------------------------------------
+	ErrGetCostPricing    = NewHTTPError(7230, "获取成本单价配置失败")
+	ErrUpdateCostPricing = NewHTTPError(7231, "更新成本单价配置失败")
+	ErrGetCostStat       = NewHTTPError(7232, "获取成本统计失败")
+	ErrListCostBudget    = NewHTTPError(7233, "获取成本预算列表失败")
+	ErrCreateCostBudget  = NewHTTPError(7234, "创建成本预算失败")
+	ErrUpdateCostBudget  = NewHTTPError(7235, "更新成本预算失败")
+	ErrDeleteCostBudget  = NewHTTPError(7236, "删除成本预算失败")
+
+	//-----------------------------------------------------------------------------------------------
+	// mock service releated errors: 7240 - 7249
+	//-----------------------------------------------------------------------------------------------
+	ErrGetMockService    = NewHTTPError(7240, "获取服务Mock失败")
+	ErrUpdateMockService = NewHTTPError(7241, "更新服务Mock失败")
+	ErrListEnvMock       = NewHTTPError(7242, "获取环境Mock列表失败")
+	ErrDeployEnvMock     = NewHTTPError(7243, "部署环境Mock失败")
+	ErrDeleteEnvMock     = NewHTTPError(7244, "删除环境Mock失败")
+	ErrGetEnvMockJournal = NewHTTPError(7245, "获取环境Mock请求记录失败")
 )
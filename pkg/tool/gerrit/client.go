@@ -156,6 +156,14 @@ func (c *Client) GetCommitByBranch(project string, branch string) (*gerrit.Commi
 	return commit, err
 }
 
+func (c *Client) GetCommitByID(project string, commitID string) (*gerrit.CommitInfo, error) {
+	commit, _, err := c.cli.Projects.GetCommit(Unescape(project), commitID)
+	if err != nil {
+		return nil, err
+	}
+	return commit, nil
+}
+
 func (c *Client) GetCommitByTag(project string, tag string) (*gerrit.CommitInfo, error) {
 	project = Unescape(project)
 	tagInfo, _, err := c.cli.Projects.GetTag(project, tag)
@@ -56,6 +56,8 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/client-go/rest"
 	"k8s.io/helm/pkg/chartutil"
+	"oras.land/oras-go/v2/registry/remote"
+	"oras.land/oras-go/v2/registry/remote/auth"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/yaml"
 
@@ -92,6 +94,8 @@ type HelmClient struct {
 	RestConfig     *rest.Config
 	RegistryClient *registry.Client
 	Transport      *http.Transport
+	// PlainHTTP talks to oci registries over http, e.g. a local registry:2 without tls
+	PlainHTTP bool
 }
 
 // NewClient returns a new Helm client with no construct parameters
@@ -679,7 +683,7 @@ func (hClient *HelmClient) DownloadChart(repoEntry *repo.Entry, chartRef string,
 		if len(chartNameStr) < 2 {
 			return fmt.Errorf("chart name is not valid")
 		}
-		chartRef = fmt.Sprintf("%s/%s", repoEntry.URL, chartNameStr[len(chartNameStr)-1])
+		chartRef = OCIChartRef(repoEntry.URL, chartNameStr[len(chartNameStr)-1])
 		return hClient.downloadOCIChart(repoEntry, chartRef, chartVersion, destDir, unTar)
 	}
 
@@ -689,47 +693,158 @@ func (hClient *HelmClient) DownloadChart(repoEntry *repo.Entry, chartRef string,
 	}
 
 	pull := action.NewPullWithOpts(action.WithConfig(&action.Configuration{}))
-	pull.Password = repoEntry.Username
-	pull.Username = repoEntry.Password
+	pull.Username = repoEntry.Username
+	pull.Password = repoEntry.Password
 	pull.Version = chartVersion
 	pull.Settings = generalSettings
 	pull.DestDir = destDir
 	pull.UntarDir = destDir
 	pull.Untar = unTar
-	_, err = hClient.runPull(pull, chartRef)
+	_, err = hClient.runPull(pull, chartRef, hClient.RegistryClient)
 	return err
 }
 
 func (hClient *HelmClient) downloadOCIChart(repoEntry *repo.Entry, chartRef string, chartVersion string, destDir string, unTar bool) error {
-	pullConfig := &action.Configuration{}
-	var err error
-	pullConfig.RegistryClient, err = registry.NewClient(
-		registry.ClientOptEnableCache(true),
-		registry.ClientOptDebug(true),
-		registry.ClientOptWriter(os.Stdout),
-	)
-	if err != nil {
-		return err
-	}
-	hostUrl := strings.TrimPrefix(repoEntry.URL, fmt.Sprintf("%s://", registry.OCIScheme))
-	err = pullConfig.RegistryClient.Login(hostUrl, registry.LoginOptBasicAuth(repoEntry.Username, repoEntry.Password))
+	registryClient, err := hClient.newOCIRegistryClient(repoEntry)
 	if err != nil {
 		return err
 	}
-	pull := action.NewPullWithOpts(action.WithConfig(pullConfig))
-	pull.Password = repoEntry.Username
-	pull.Username = repoEntry.Password
-	pull.Version = chartVersion
+	pull := action.NewPullWithOpts(action.WithConfig(&action.Configuration{RegistryClient: registryClient}))
+	pull.Username = repoEntry.Username
+	pull.Password = repoEntry.Password
+	// the version may be given as the tag in the registry, helm converts it to the tag again when pulling
+	pull.Version = OCIChartVersion(chartVersion)
 	pull.Settings = generalSettings
 	pull.DestDir = destDir
 	pull.UntarDir = destDir
 	pull.Untar = unTar
-	_, err = hClient.runPull(pull, chartRef)
+	_, err = hClient.runPull(pull, chartRef, registryClient)
 	return err
 }
 
+// FetchOCIIndex builds the index of the given charts in the oci chart repo, oci registries have no index.yaml
+// so the versions of a chart are the tags of its repository, charts not pushed yet are left out of the index
+func (hClient *HelmClient) FetchOCIIndex(repoEntry *repo.Entry, chartNames []string) (*repo.IndexFile, error) {
+	registryClient, err := hClient.newOCIRegistryClient(repoEntry)
+	if err != nil {
+		return nil, err
+	}
+
+	index := repo.NewIndexFile()
+	for _, chartName := range chartNames {
+		chartRef := OCIChartRef(repoEntry.URL, chartName)
+		tags, err := registryClient.Tags(strings.TrimPrefix(chartRef, fmt.Sprintf("%s://", registry.OCIScheme)))
+		if err != nil {
+			log.Warnf("failed to list tags of chart %s, err: %s", chartRef, err)
+			continue
+		}
+		for _, tag := range tags {
+			version := OCIChartVersion(tag)
+			index.Entries[chartName] = append(index.Entries[chartName], &repo.ChartVersion{
+				Metadata: &chart.Metadata{Name: chartName, Version: version},
+				URLs:     []string{fmt.Sprintf("%s:%s", chartRef, OCITag(version))},
+			})
+		}
+	}
+	index.SortEntries()
+	return index, nil
+}
+
+// ValidateOCIRegistry checks the credentials of the oci chart repo
+func (hClient *HelmClient) ValidateOCIRegistry(repoEntry *repo.Entry) error {
+	host := ociRegistryHost(repoEntry.URL)
+	reg, err := remote.NewRegistry(host)
+	if err != nil {
+		return fmt.Errorf("invalid oci registry %s, err: %w", host, err)
+	}
+	reg.PlainHTTP = hClient.PlainHTTP
+	reg.Client = hClient.newOCIAuthClient(repoEntry)
+	if err := reg.Ping(context.Background()); err != nil {
+		return fmt.Errorf("failed to login oci registry %s, err: %w", host, err)
+	}
+	return nil
+}
+
+// OCIChartRef returns the reference of the chart in the oci chart repo, like oci://registry.example.com/charts/nginx
+func OCIChartRef(repoURL, chartName string) string {
+	return fmt.Sprintf("%s/%s", strings.TrimSuffix(repoURL, "/"), chartName)
+}
+
+// OCITag returns the tag of the chart version in oci registries, `+` is not allowed in tags so helm pushes
+// the build metadata of a version like 1.0.0+build.1 as 1.0.0_build.1
+// See https://github.com/helm/helm/issues/10166
+func OCITag(version string) string {
+	return strings.ReplaceAll(version, "+", "_")
+}
+
+// OCIChartVersion returns the chart version of the tag in oci registries, it's the reverse of OCITag
+func OCIChartVersion(tag string) string {
+	return strings.ReplaceAll(tag, "_", "+")
+}
+
+// ociRegistryHost returns the host of the oci chart repo, the repo url may contain the project path,
+// e.g. oci://harbor.example.com/library
+func ociRegistryHost(repoURL string) string {
+	host := strings.TrimPrefix(repoURL, fmt.Sprintf("%s://", registry.OCIScheme))
+	return strings.SplitN(host, "/", 2)[0]
+}
+
+// newOCIAuthClient returns the http client of the oci chart repo, the credentials are kept in memory instead of
+// the credentials file shared by all repos which is written by `helm registry login`
+func (hClient *HelmClient) newOCIAuthClient(repoEntry *repo.Entry) *auth.Client {
+	// From https://github.com/google/go-containerregistry/blob/31786c6cbb82d6ec4fb8eb79cd9387905130534e/pkg/v1/remote/options.go#L87
+	transport := &http.Transport{
+		DialContext: (&net.Dialer{
+			// By default we wrap the transport in retries, so reduce the
+			// default dial timeout to 5s to avoid 5x 30s of connection
+			// timeouts when doing the "ping" on certain http registries.
+			Timeout:   5 * time.Second,
+			KeepAlive: 30 * time.Second,
+		}).DialContext,
+		ForceAttemptHTTP2:     true,
+		MaxIdleConns:          100,
+		IdleConnTimeout:       90 * time.Second,
+		TLSHandshakeTimeout:   10 * time.Second,
+		ExpectContinueTimeout: 1 * time.Second,
+	}
+
+	if hClient.Transport != nil {
+		transport.Proxy = hClient.Transport.Proxy
+		transport.TLSClientConfig = hClient.Transport.TLSClientConfig
+	}
+
+	authClient := &auth.Client{
+		Client: &http.Client{Transport: transport},
+		Cache:  auth.NewCache(),
+	}
+	if repoEntry.Username != "" || repoEntry.Password != "" {
+		authClient.Credential = auth.StaticCredential(ociRegistryHost(repoEntry.URL), auth.Credential{
+			Username: repoEntry.Username,
+			Password: repoEntry.Password,
+		})
+	}
+	return authClient
+}
+
+// newOCIRegistryClient returns a registry client authorized to the oci chart repo
+func (hClient *HelmClient) newOCIRegistryClient(repoEntry *repo.Entry) (*registry.Client, error) {
+	opts := []registry.ClientOption{
+		registry.ClientOptDebug(true),
+		registry.ClientOptWriter(os.Stdout),
+		registry.ClientOptAuthorizer(*hClient.newOCIAuthClient(repoEntry)),
+	}
+	if hClient.PlainHTTP {
+		opts = append(opts, registry.ClientOptPlainHTTP())
+	}
+	registryClient, err := registry.NewClient(opts...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create registry client, err: %w", err)
+	}
+	return registryClient, nil
+}
+
 // rewrite Pull.Run in helm.sh/helm/v3/pkg/action to support proxy
-func (hClient *HelmClient) runPull(p *action.Pull, chartRef string) (string, error) {
+func (hClient *HelmClient) runPull(p *action.Pull, chartRef string, registryClient *registry.Client) (string, error) {
 	var out strings.Builder
 
 	c := downloader.ChartDownloader{
@@ -743,7 +858,7 @@ func (hClient *HelmClient) runPull(p *action.Pull, chartRef string) (string, err
 			getter.WithTLSClientConfig(p.CertFile, p.KeyFile, p.CaFile),
 			getter.WithInsecureSkipVerifyTLS(p.InsecureSkipTLSverify),
 		},
-		RegistryClient:   hClient.RegistryClient,
+		RegistryClient:   registryClient,
 		RepositoryConfig: p.Settings.RepositoryConfig,
 		RepositoryCache:  p.Settings.RepositoryCache,
 	}
@@ -751,7 +866,7 @@ func (hClient *HelmClient) runPull(p *action.Pull, chartRef string) (string, err
 
 	if registry.IsOCI(chartRef) {
 		c.Options = append(c.Options,
-			getter.WithRegistryClient(hClient.RegistryClient))
+			getter.WithRegistryClient(registryClient))
 	}
 
 	if p.Verify {
@@ -864,51 +979,17 @@ func (hClient *HelmClient) pushChartMuseum(repoEntry *repo.Entry, chartPath stri
 }
 
 func (hClient *HelmClient) pushOCIRegistry(repoEntry *repo.Entry, chartPath string) error {
-	var err error
-
-	// From https://github.com/google/go-containerregistry/blob/31786c6cbb82d6ec4fb8eb79cd9387905130534e/pkg/v1/remote/options.go#L87
-	transport := &http.Transport{
-		DialContext: (&net.Dialer{
-			// By default we wrap the transport in retries, so reduce the
-			// default dial timeout to 5s to avoid 5x 30s of connection
-			// timeouts when doing the "ping" on certain http registries.
-			Timeout:   5 * time.Second,
-			KeepAlive: 30 * time.Second,
-		}).DialContext,
-		ForceAttemptHTTP2:     true,
-		MaxIdleConns:          100,
-		IdleConnTimeout:       90 * time.Second,
-		TLSHandshakeTimeout:   10 * time.Second,
-		ExpectContinueTimeout: 1 * time.Second,
-	}
-
-	if hClient.Transport != nil {
-		transport.Proxy = hClient.Transport.Proxy
-		transport.TLSClientConfig = hClient.Transport.TLSClientConfig
-	}
-
-	// copy from helm.sh/helm/v3/pkg/registry
-	httpclient := &http.Client{
-		Transport: transport,
-	}
-
-	pushConfig := &action.Configuration{}
-	pushConfig.RegistryClient, err = registry.NewClient(
-		registry.ClientOptEnableCache(true),
-		registry.ClientOptDebug(true),
-		registry.ClientOptWriter(os.Stdout),
-		registry.ClientOptHTTPClient(httpclient),
-	)
-
-	hostUrl := strings.TrimPrefix(repoEntry.URL, fmt.Sprintf("%s://", registry.OCIScheme))
-	err = pushConfig.RegistryClient.Login(hostUrl, registry.LoginOptBasicAuth(repoEntry.Username, repoEntry.Password))
+	registryClient, err := hClient.newOCIRegistryClient(repoEntry)
 	if err != nil {
 		return err
 	}
 
-	push := action.NewPushWithOpts(action.WithPushConfig(pushConfig))
+	push := action.NewPushWithOpts(
+		action.WithPushConfig(&action.Configuration{RegistryClient: registryClient}),
+		action.WithPlainHTTP(hClient.PlainHTTP),
+	)
 	push.Settings = generalSettings
-	_, err = push.Run(chartPath, repoEntry.URL)
+	_, err = push.Run(chartPath, strings.TrimSuffix(repoEntry.URL, "/"))
 	return err
 }
 
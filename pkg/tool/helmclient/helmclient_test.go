@@ -0,0 +1,103 @@
+/*
+Copyright 2025 The KodeRover Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package helmclient
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/google/go-containerregistry/pkg/authn"
+	"github.com/google/go-containerregistry/pkg/name"
+	"github.com/google/go-containerregistry/pkg/registry"
+	"github.com/google/go-containerregistry/pkg/v1/random"
+	"github.com/google/go-containerregistry/pkg/v1/remote"
+	"github.com/stretchr/testify/require"
+	"helm.sh/helm/v3/pkg/repo"
+
+	"github.com/koderover/zadig/v2/pkg/tool/log"
+)
+
+func TestOCITag(t *testing.T) {
+	ast := require.New(t)
+
+	tests := []struct {
+		version string
+		tag     string
+	}{
+		{version: "1.0.0", tag: "1.0.0"},
+		{version: "1.0.0+build.1", tag: "1.0.0_build.1"},
+		{version: "1.0.0-rc.1+build.1", tag: "1.0.0-rc.1_build.1"},
+	}
+	for _, tt := range tests {
+		ast.Equal(tt.tag, OCITag(tt.version))
+		ast.Equal(tt.version, OCIChartVersion(tt.tag))
+	}
+}
+
+func TestOCIRegistry(t *testing.T) {
+	log.Init(&log.Config{
+		Level: "debug",
+	})
+	ast := require.New(t)
+
+	configHome := t.TempDir()
+	t.Setenv("HELM_CONFIG_HOME", configHome)
+
+	regHandler := registry.New()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if username, password, ok := r.BasicAuth(); !ok || username != "admin" || password != "secret" {
+			w.Header().Set("WWW-Authenticate", `Basic realm="test"`)
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		regHandler.ServeHTTP(w, r)
+	}))
+	defer server.Close()
+
+	host := strings.TrimPrefix(server.URL, "http://")
+	for _, tag := range []string{"0.1.0", "1.0.0_build.1", "latest"} {
+		ref, err := name.ParseReference(fmt.Sprintf("%s/charts/nginx:%s", host, tag), name.Insecure)
+		ast.Nil(err)
+		img, err := random.Image(128, 1)
+		ast.Nil(err)
+		ast.Nil(remote.Write(ref, img, remote.WithAuth(&authn.Basic{Username: "admin", Password: "secret"})))
+	}
+
+	hClient := &HelmClient{lock: &sync.Mutex{}, PlainHTTP: true}
+	repoEntry := &repo.Entry{URL: fmt.Sprintf("oci://%s/charts", host), Username: "admin", Password: "secret"}
+
+	ast.Nil(hClient.ValidateOCIRegistry(repoEntry))
+	ast.NotNil(hClient.ValidateOCIRegistry(&repo.Entry{URL: repoEntry.URL, Username: "admin", Password: "wrong"}))
+
+	index, err := hClient.FetchOCIIndex(repoEntry, []string{"nginx", "redis"})
+	ast.Nil(err)
+	ast.Len(index.Entries["nginx"], 2)
+	ast.Equal("1.0.0+build.1", index.Entries["nginx"][0].Version)
+	ast.Equal([]string{fmt.Sprintf("oci://%s/charts/nginx:1.0.0_build.1", host)}, index.Entries["nginx"][0].URLs)
+	ast.Equal("0.1.0", index.Entries["nginx"][1].Version)
+	ast.Empty(index.Entries["redis"])
+
+	// the credentials are never written to the credentials file shared by all repos
+	_, err = os.Stat(filepath.Join(configHome, "registry", "config.json"))
+	ast.True(os.IsNotExist(err))
+}
@@ -0,0 +1,118 @@
+/*
+Copyright 2025 The KodeRover Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package imagecopy copies container images between registries without a docker daemon, a manifest list is
+// copied as a whole so every platform of a multi-arch image is kept.
+package imagecopy
+
+import (
+	"crypto/tls"
+	"fmt"
+	"net/http"
+
+	"github.com/google/go-containerregistry/pkg/authn"
+	"github.com/google/go-containerregistry/pkg/name"
+	v1 "github.com/google/go-containerregistry/pkg/v1"
+	"github.com/google/go-containerregistry/pkg/v1/remote"
+)
+
+// RegistryAuth is the credential used to access the registry of an image.
+type RegistryAuth struct {
+	Username string
+	Password string
+	// Insecure allows plain http and skips the verification of the registry certificate.
+	Insecure bool
+}
+
+// Copy copies the source image to the target image, platform selects a single platform like linux/arm64 out of
+// a manifest list, an empty platform copies the image with all its platforms.
+// The digest of the copied manifest is returned.
+func Copy(source, target, platform string, sourceAuth, targetAuth *RegistryAuth) (string, error) {
+	sourceRef, err := parseReference(source, sourceAuth)
+	if err != nil {
+		return "", fmt.Errorf("invalid source image %s: %w", source, err)
+	}
+	targetRef, err := parseReference(target, targetAuth)
+	if err != nil {
+		return "", fmt.Errorf("invalid target image %s: %w", target, err)
+	}
+
+	desc, err := remote.Get(sourceRef, remoteOptions(sourceAuth)...)
+	if err != nil {
+		return "", fmt.Errorf("failed to get source image %s: %w", source, err)
+	}
+
+	if desc.MediaType.IsIndex() && platform == "" {
+		index, err := desc.ImageIndex()
+		if err != nil {
+			return "", fmt.Errorf("failed to read manifest list of %s: %w", source, err)
+		}
+		if err := remote.WriteIndex(targetRef, index, remoteOptions(targetAuth)...); err != nil {
+			return "", fmt.Errorf("failed to push manifest list %s: %w", target, err)
+		}
+		return desc.Digest.String(), nil
+	}
+
+	opts := remoteOptions(sourceAuth)
+	if platform != "" {
+		p, err := v1.ParsePlatform(platform)
+		if err != nil {
+			return "", fmt.Errorf("invalid platform %s: %w", platform, err)
+		}
+		opts = append(opts, remote.WithPlatform(*p))
+		// the platform only takes effect when the image is resolved from the reference again
+		desc, err = remote.Get(sourceRef, opts...)
+		if err != nil {
+			return "", fmt.Errorf("failed to get source image %s: %w", source, err)
+		}
+	}
+	image, err := desc.Image()
+	if err != nil {
+		return "", fmt.Errorf("failed to read image %s: %w", source, err)
+	}
+	if err := remote.Write(targetRef, image, remoteOptions(targetAuth)...); err != nil {
+		return "", fmt.Errorf("failed to push image %s: %w", target, err)
+	}
+	digest, err := image.Digest()
+	if err != nil {
+		return "", err
+	}
+	return digest.String(), nil
+}
+
+func parseReference(image string, auth *RegistryAuth) (name.Reference, error) {
+	opts := make([]name.Option, 0)
+	if auth != nil && auth.Insecure {
+		opts = append(opts, name.Insecure)
+	}
+	return name.ParseReference(image, opts...)
+}
+
+func remoteOptions(auth *RegistryAuth) []remote.Option {
+	opts := make([]remote.Option, 0)
+	if auth == nil {
+		return opts
+	}
+	if auth.Username != "" {
+		opts = append(opts, remote.WithAuth(&authn.Basic{Username: auth.Username, Password: auth.Password}))
+	}
+	if auth.Insecure {
+		tr := http.DefaultTransport.(*http.Transport).Clone()
+		tr.TLSClientConfig = &tls.Config{InsecureSkipVerify: true}
+		opts = append(opts, remote.WithTransport(tr))
+	}
+	return opts
+}
@@ -0,0 +1,82 @@
+/*
+Copyright 2025 The KodeRover Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package imagecopy
+
+import (
+	"fmt"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/google/go-containerregistry/pkg/registry"
+	v1 "github.com/google/go-containerregistry/pkg/v1"
+	"github.com/google/go-containerregistry/pkg/v1/empty"
+	"github.com/google/go-containerregistry/pkg/v1/mutate"
+	"github.com/google/go-containerregistry/pkg/v1/random"
+	"github.com/google/go-containerregistry/pkg/v1/remote"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCopy(t *testing.T) {
+	ast := require.New(t)
+
+	server := httptest.NewServer(registry.New())
+	defer server.Close()
+	host := strings.TrimPrefix(server.URL, "http://")
+	auth := &RegistryAuth{Insecure: true}
+
+	amd64, err := random.Image(128, 1)
+	ast.Nil(err)
+	arm64, err := random.Image(128, 1)
+	ast.Nil(err)
+	index := mutate.AppendManifests(empty.Index, mutate.IndexAddendum{
+		Add:        amd64,
+		Descriptor: v1.Descriptor{Platform: &v1.Platform{OS: "linux", Architecture: "amd64"}},
+	}, mutate.IndexAddendum{
+		Add:        arm64,
+		Descriptor: v1.Descriptor{Platform: &v1.Platform{OS: "linux", Architecture: "arm64"}},
+	})
+
+	source := fmt.Sprintf("%s/source/service1:v1", host)
+	sourceRef, err := parseReference(source, auth)
+	ast.Nil(err)
+	ast.Nil(remote.WriteIndex(sourceRef, index))
+
+	// all the platforms are kept
+	target := fmt.Sprintf("%s/target/service1:v1", host)
+	digest, err := Copy(source, target, "", auth, auth)
+	ast.Nil(err)
+	indexDigest, err := index.Digest()
+	ast.Nil(err)
+	ast.Equal(indexDigest.String(), digest)
+
+	targetRef, err := parseReference(target, auth)
+	ast.Nil(err)
+	copied, err := remote.Index(targetRef)
+	ast.Nil(err)
+	manifest, err := copied.IndexManifest()
+	ast.Nil(err)
+	ast.Len(manifest.Manifests, 2)
+
+	// a single platform is picked out of the manifest list
+	target = fmt.Sprintf("%s/target/service1:arm64", host)
+	digest, err = Copy(source, target, "linux/arm64", auth, auth)
+	ast.Nil(err)
+	arm64Digest, err := arm64.Digest()
+	ast.Nil(err)
+	ast.Equal(arm64Digest.String(), digest)
+}
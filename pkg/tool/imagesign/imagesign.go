@@ -0,0 +1,330 @@
+/*
+Copyright 2025 The KodeRover Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package imagesign signs container images and verifies their signatures in the format used by cosign:
+// the signature of an image is stored in the same repository under the tag sha256-<digest>.sig, every
+// layer of it being a simple signing payload with the base64 encoded signature in its annotations.
+package imagesign
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+
+	"github.com/google/go-containerregistry/pkg/authn"
+	"github.com/google/go-containerregistry/pkg/name"
+	v1 "github.com/google/go-containerregistry/pkg/v1"
+	"github.com/google/go-containerregistry/pkg/v1/empty"
+	"github.com/google/go-containerregistry/pkg/v1/mutate"
+	"github.com/google/go-containerregistry/pkg/v1/remote"
+	"github.com/google/go-containerregistry/pkg/v1/remote/transport"
+	"github.com/google/go-containerregistry/pkg/v1/static"
+	"github.com/google/go-containerregistry/pkg/v1/types"
+)
+
+const (
+	SignatureTagSuffix     = ".sig"
+	SignatureAnnotationKey = "dev.cosignproject.cosign/signature"
+	SimpleSigningMediaType = "application/vnd.dev.cosign.simplesigning.v1+json"
+	SimpleSigningType      = "cosign container image signature"
+)
+
+// RegistryAuth is the credential used to access the registry of the image.
+type RegistryAuth struct {
+	Username string
+	Password string
+	// Insecure allows plain http and skips the verification of the registry certificate.
+	Insecure bool
+}
+
+// VerifyResult is the result of a signature verification.
+type VerifyResult struct {
+	Image    string `bson:"image"     json:"image"     yaml:"image"`
+	Digest   string `bson:"digest"    json:"digest"    yaml:"digest"`
+	Verified bool   `bson:"verified"  json:"verified"  yaml:"verified"`
+	Message  string `bson:"message"   json:"message"   yaml:"message"`
+}
+
+type simpleSigning struct {
+	Critical struct {
+		Identity struct {
+			DockerReference string `json:"docker-reference"`
+		} `json:"identity"`
+		Image struct {
+			DockerManifestDigest string `json:"docker-manifest-digest"`
+		} `json:"image"`
+		Type string `json:"type"`
+	} `json:"critical"`
+	Optional map[string]interface{} `json:"optional"`
+}
+
+// Sign signs the image with the given key and pushes the signature to the registry of the image.
+// The digest of the signed manifest is returned.
+func Sign(image string, signer crypto.Signer, auth *RegistryAuth) (string, error) {
+	digest, err := ResolveDigest(image, auth)
+	if err != nil {
+		return "", err
+	}
+
+	payload, err := newPayload(digest)
+	if err != nil {
+		return "", err
+	}
+	signature, err := signPayload(signer, payload)
+	if err != nil {
+		return "", fmt.Errorf("failed to sign payload of %s: %s", image, err)
+	}
+
+	sigTag := signatureTag(digest)
+	base, err := remote.Image(sigTag, remoteOptions(auth)...)
+	if err != nil {
+		if !isNotFound(err) {
+			return "", fmt.Errorf("failed to get signatures of %s: %s", image, err)
+		}
+		base = mutate.ConfigMediaType(mutate.MediaType(empty.Image, types.OCIManifestSchema1), types.OCIConfigJSON)
+	}
+
+	sigImage, err := mutate.Append(base, mutate.Addendum{
+		Layer: static.NewLayer(payload, SimpleSigningMediaType),
+		Annotations: map[string]string{
+			SignatureAnnotationKey: base64.StdEncoding.EncodeToString(signature),
+		},
+	})
+	if err != nil {
+		return "", err
+	}
+	if err := remote.Write(sigTag, sigImage, remoteOptions(auth)...); err != nil {
+		return "", fmt.Errorf("failed to push signature of %s: %s", image, err)
+	}
+	return digest.DigestStr(), nil
+}
+
+// Verify checks that the image carries a signature made by one of the given public keys.
+// An error is returned only when the registry can not be accessed, an image without any
+// valid signature gives a result with Verified set to false.
+func Verify(image string, keys []crypto.PublicKey, auth *RegistryAuth) (*VerifyResult, error) {
+	result := &VerifyResult{Image: image}
+	digest, err := ResolveDigest(image, auth)
+	if err != nil {
+		return nil, err
+	}
+	result.Digest = digest.DigestStr()
+
+	sigImage, err := remote.Image(signatureTag(digest), remoteOptions(auth)...)
+	if err != nil {
+		if isNotFound(err) {
+			result.Message = "no signature found"
+			return result, nil
+		}
+		return nil, fmt.Errorf("failed to get signatures of %s: %s", image, err)
+	}
+	manifest, err := sigImage.Manifest()
+	if err != nil {
+		return nil, err
+	}
+
+	for _, desc := range manifest.Layers {
+		signature, err := base64.StdEncoding.DecodeString(desc.Annotations[SignatureAnnotationKey])
+		if err != nil || len(signature) == 0 {
+			continue
+		}
+		layer, err := sigImage.LayerByDigest(desc.Digest)
+		if err != nil {
+			return nil, err
+		}
+		payload, err := readLayer(layer)
+		if err != nil {
+			return nil, err
+		}
+		if !payloadMatches(payload, digest) {
+			continue
+		}
+		for _, key := range keys {
+			if verifySignature(key, payload, signature) == nil {
+				result.Verified = true
+				result.Message = "signature verified"
+				return result, nil
+			}
+		}
+	}
+	result.Message = "no signature matches the trusted keys"
+	return result, nil
+}
+
+// ResolveDigest returns the digest reference of the image manifest.
+func ResolveDigest(image string, auth *RegistryAuth) (name.Digest, error) {
+	ref, err := parseReference(image, auth)
+	if err != nil {
+		return name.Digest{}, err
+	}
+	if digest, ok := ref.(name.Digest); ok {
+		return digest, nil
+	}
+	desc, err := remote.Head(ref, remoteOptions(auth)...)
+	if err != nil {
+		return name.Digest{}, fmt.Errorf("failed to get manifest of %s: %s", image, err)
+	}
+	return ref.Context().Digest(desc.Digest.String()), nil
+}
+
+// ParsePrivateKey parses a PEM encoded PKCS8, EC or PKCS1 private key.
+func ParsePrivateKey(keyPEM []byte) (crypto.Signer, error) {
+	block, _ := pem.Decode(keyPEM)
+	if block == nil {
+		return nil, errors.New("invalid PEM encoded private key")
+	}
+	if key, err := x509.ParsePKCS8PrivateKey(block.Bytes); err == nil {
+		signer, ok := key.(crypto.Signer)
+		if !ok {
+			return nil, errors.New("unsupported private key type")
+		}
+		return signer, nil
+	}
+	if key, err := x509.ParseECPrivateKey(block.Bytes); err == nil {
+		return key, nil
+	}
+	if key, err := x509.ParsePKCS1PrivateKey(block.Bytes); err == nil {
+		return key, nil
+	}
+	return nil, fmt.Errorf("unsupported private key %s", block.Type)
+}
+
+// ParsePublicKeys parses all the PEM encoded public keys in the given content.
+func ParsePublicKeys(keysPEM []byte) ([]crypto.PublicKey, error) {
+	resp := make([]crypto.PublicKey, 0)
+	rest := keysPEM
+	for {
+		var block *pem.Block
+		block, rest = pem.Decode(rest)
+		if block == nil {
+			break
+		}
+		key, err := x509.ParsePKIXPublicKey(block.Bytes)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse public key: %s", err)
+		}
+		resp = append(resp, key)
+	}
+	if len(resp) == 0 {
+		return nil, errors.New("no PEM encoded public key found")
+	}
+	return resp, nil
+}
+
+func newPayload(digest name.Digest) ([]byte, error) {
+	payload := &simpleSigning{}
+	payload.Critical.Identity.DockerReference = digest.Context().String()
+	payload.Critical.Image.DockerManifestDigest = digest.DigestStr()
+	payload.Critical.Type = SimpleSigningType
+	return json.Marshal(payload)
+}
+
+func payloadMatches(payload []byte, digest name.Digest) bool {
+	resp := &simpleSigning{}
+	if err := json.Unmarshal(payload, resp); err != nil {
+		return false
+	}
+	return resp.Critical.Type == SimpleSigningType && resp.Critical.Image.DockerManifestDigest == digest.DigestStr()
+}
+
+func signPayload(signer crypto.Signer, payload []byte) ([]byte, error) {
+	if _, ok := signer.Public().(ed25519.PublicKey); ok {
+		return signer.Sign(rand.Reader, payload, crypto.Hash(0))
+	}
+	hash := sha256.Sum256(payload)
+	return signer.Sign(rand.Reader, hash[:], crypto.SHA256)
+}
+
+func verifySignature(key crypto.PublicKey, payload, signature []byte) error {
+	hash := sha256.Sum256(payload)
+	switch k := key.(type) {
+	case *ecdsa.PublicKey:
+		if !ecdsa.VerifyASN1(k, hash[:], signature) {
+			return errors.New("invalid signature")
+		}
+		return nil
+	case *rsa.PublicKey:
+		if err := rsa.VerifyPKCS1v15(k, crypto.SHA256, hash[:], signature); err != nil {
+			return rsa.VerifyPSS(k, crypto.SHA256, hash[:], signature, nil)
+		}
+		return nil
+	case ed25519.PublicKey:
+		if !ed25519.Verify(k, payload, signature) {
+			return errors.New("invalid signature")
+		}
+		return nil
+	default:
+		return fmt.Errorf("unsupported public key type %T", key)
+	}
+}
+
+func signatureTag(digest name.Digest) name.Tag {
+	return digest.Context().Tag(strings.Replace(digest.DigestStr(), ":", "-", 1) + SignatureTagSuffix)
+}
+
+func parseReference(image string, auth *RegistryAuth) (name.Reference, error) {
+	opts := make([]name.Option, 0)
+	if auth != nil && auth.Insecure {
+		opts = append(opts, name.Insecure)
+	}
+	return name.ParseReference(image, opts...)
+}
+
+func remoteOptions(auth *RegistryAuth) []remote.Option {
+	opts := make([]remote.Option, 0)
+	if auth == nil {
+		return opts
+	}
+	if auth.Username != "" {
+		opts = append(opts, remote.WithAuth(&authn.Basic{Username: auth.Username, Password: auth.Password}))
+	}
+	if auth.Insecure {
+		tr := http.DefaultTransport.(*http.Transport).Clone()
+		tr.TLSClientConfig = &tls.Config{InsecureSkipVerify: true}
+		opts = append(opts, remote.WithTransport(tr))
+	}
+	return opts
+}
+
+func readLayer(layer v1.Layer) ([]byte, error) {
+	rc, err := layer.Compressed()
+	if err != nil {
+		return nil, err
+	}
+	defer rc.Close()
+	return io.ReadAll(rc)
+}
+
+func isNotFound(err error) bool {
+	var terr *transport.Error
+	if errors.As(err, &terr) {
+		return terr.StatusCode == http.StatusNotFound
+	}
+	return false
+}
@@ -0,0 +1,103 @@
+/*
+Copyright 2025 The KodeRover Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package imagesign
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"encoding/pem"
+	"fmt"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/google/go-containerregistry/pkg/registry"
+	"github.com/google/go-containerregistry/pkg/v1/random"
+	"github.com/google/go-containerregistry/pkg/v1/remote"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSignAndVerify(t *testing.T) {
+	ast := require.New(t)
+
+	server := httptest.NewServer(registry.New())
+	defer server.Close()
+
+	auth := &RegistryAuth{Insecure: true}
+	image := fmt.Sprintf("%s/test/service1:v1", strings.TrimPrefix(server.URL, "http://"))
+	ref, err := parseReference(image, auth)
+	ast.Nil(err)
+	img, err := random.Image(128, 1)
+	ast.Nil(err)
+	ast.Nil(remote.Write(ref, img))
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	ast.Nil(err)
+	otherKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	ast.Nil(err)
+
+	result, err := Verify(image, []crypto.PublicKey{key.Public()}, auth)
+	ast.Nil(err)
+	ast.False(result.Verified)
+
+	digest, err := Sign(image, key, auth)
+	ast.Nil(err)
+	expected, err := img.Digest()
+	ast.Nil(err)
+	ast.Equal(expected.String(), digest)
+
+	result, err = Verify(image, []crypto.PublicKey{otherKey.Public()}, auth)
+	ast.Nil(err)
+	ast.False(result.Verified)
+
+	// a second signature is appended to the existing ones
+	_, err = Sign(image, otherKey, auth)
+	ast.Nil(err)
+	for _, pub := range []crypto.PublicKey{key.Public(), otherKey.Public()} {
+		result, err = Verify(image, []crypto.PublicKey{pub}, auth)
+		ast.Nil(err)
+		ast.True(result.Verified)
+		ast.Equal(digest, result.Digest)
+	}
+}
+
+func TestParseKeys(t *testing.T) {
+	ast := require.New(t)
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	ast.Nil(err)
+	privBytes, err := x509.MarshalPKCS8PrivateKey(key)
+	ast.Nil(err)
+	pubBytes, err := x509.MarshalPKIXPublicKey(key.Public())
+	ast.Nil(err)
+
+	t.Setenv("ZADIG_TEST_SIGNING_KEY", string(pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: privBytes})))
+	signer, err := LoadSigner("env://ZADIG_TEST_SIGNING_KEY")
+	ast.Nil(err)
+	ast.True(key.PublicKey.Equal(signer.Public()))
+
+	pubPEM := pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: pubBytes})
+	keys, err := ParsePublicKeys(append(pubPEM, pubPEM...))
+	ast.Nil(err)
+	ast.Len(keys, 2)
+
+	_, err = LoadSigner("vault://key")
+	ast.NotNil(err)
+}
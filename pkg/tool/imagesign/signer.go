@@ -0,0 +1,54 @@
+/*
+Copyright 2025 The KodeRover Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package imagesign
+
+import (
+	"crypto"
+	"fmt"
+	"os"
+	"strings"
+)
+
+const (
+	KeyRefEnvScheme  = "env://"
+	KeyRefFileScheme = "file://"
+)
+
+// LoadSigner loads the signing key by its reference. It stands in for a KMS: the private key never
+// leaves the executor and is read either from an environment variable (env://NAME) or from a file
+// mounted into it (file:///path/to/key.pem).
+func LoadSigner(keyRef string) (crypto.Signer, error) {
+	var keyPEM []byte
+	switch {
+	case strings.HasPrefix(keyRef, KeyRefEnvScheme):
+		env := strings.TrimPrefix(keyRef, KeyRefEnvScheme)
+		value, ok := os.LookupEnv(env)
+		if !ok {
+			return nil, fmt.Errorf("signing key env %s is not set", env)
+		}
+		keyPEM = []byte(value)
+	case strings.HasPrefix(keyRef, KeyRefFileScheme):
+		content, err := os.ReadFile(strings.TrimPrefix(keyRef, KeyRefFileScheme))
+		if err != nil {
+			return nil, fmt.Errorf("failed to read signing key: %s", err)
+		}
+		keyPEM = content
+	default:
+		return nil, fmt.Errorf("unsupported signing key reference %s", keyRef)
+	}
+	return ParsePrivateKey(keyPEM)
+}
@@ -0,0 +1,80 @@
+/*
+Copyright 2025 The KodeRover Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package getter
+
+import (
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// Argo Rollouts and Kruise Rollouts share the kind Rollout, they are told apart by the api group.
+var ArgoRolloutGVK = schema.GroupVersionKind{
+	Group:   "argoproj.io",
+	Kind:    "Rollout",
+	Version: "v1alpha1",
+}
+
+var KruiseRolloutGVK = schema.GroupVersionKind{
+	Group:   "rollouts.kruise.io",
+	Kind:    "Rollout",
+	Version: "v1beta1",
+}
+
+// GetArgoRollout gets the Argo Rollout, it's regarded as not found if the CRD is not installed in the cluster.
+func GetArgoRollout(ns, name string, cl client.Reader) (*unstructured.Unstructured, bool, error) {
+	return getRollout(ns, name, ArgoRolloutGVK, cl)
+}
+
+// GetKruiseRollout gets the Kruise Rollout, it's regarded as not found if the CRD is not installed in the cluster.
+func GetKruiseRollout(ns, name string, cl client.Reader) (*unstructured.Unstructured, bool, error) {
+	return getRollout(ns, name, KruiseRolloutGVK, cl)
+}
+
+func ListArgoRollouts(ns string, selector labels.Selector, cl client.Reader) ([]*unstructured.Unstructured, error) {
+	return listRollouts(ns, selector, ArgoRolloutGVK, cl)
+}
+
+func ListKruiseRollouts(ns string, selector labels.Selector, cl client.Reader) ([]*unstructured.Unstructured, error) {
+	return listRollouts(ns, selector, KruiseRolloutGVK, cl)
+}
+
+func getRollout(ns, name string, gvk schema.GroupVersionKind, cl client.Reader) (*unstructured.Unstructured, bool, error) {
+	u := &unstructured.Unstructured{}
+	u.SetGroupVersionKind(gvk)
+	found, err := GetResourceInCache(ns, name, u, cl)
+	if err != nil {
+		if meta.IsNoMatchError(err) {
+			return nil, false, nil
+		}
+		return nil, false, err
+	}
+	if !found {
+		return nil, false, nil
+	}
+	return u, true, nil
+}
+
+func listRollouts(ns string, selector labels.Selector, gvk schema.GroupVersionKind, cl client.Reader) ([]*unstructured.Unstructured, error) {
+	res, err := ListUnstructuredResourceInCache(ns, selector, nil, gvk, cl)
+	if err != nil && meta.IsNoMatchError(err) {
+		return nil, nil
+	}
+	return res, err
+}
@@ -62,6 +62,10 @@ func DeleteConfigMap(ns, name string, cl client.Client) error {
 	}, cl)
 }
 
+func CreateOrPatchConfigMap(cm *corev1.ConfigMap, cl client.Client) error {
+	return createOrPatchObject(cm, cl)
+}
+
 func CreateConfigMap(cm *corev1.ConfigMap, cl client.Client) error {
 	return createObjectNeverAnnotation(cm, cl)
 }
@@ -0,0 +1,93 @@
+/*
+Copyright 2025 The KodeRover Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package updater
+
+import (
+	"context"
+	"fmt"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/koderover/zadig/v2/pkg/tool/kube/getter"
+)
+
+// UpdateArgoRolloutImage sets the image of the container in the pod template of the Argo Rollout.
+// Strategic merge patch is not supported by custom resources, the container is patched by its index instead.
+func UpdateArgoRolloutImage(ns, name, container, image string, initContainer bool, cl client.Client) error {
+	rollout, found, err := getter.GetArgoRollout(ns, name, cl)
+	if err != nil {
+		return err
+	}
+	if !found {
+		return fmt.Errorf("rollout %s/%s not found", ns, name)
+	}
+
+	field := "containers"
+	if initContainer {
+		field = "initContainers"
+	}
+	containers, _, err := unstructured.NestedSlice(rollout.Object, "spec", "template", "spec", field)
+	if err != nil {
+		return err
+	}
+	for i, c := range containers {
+		m, ok := c.(map[string]interface{})
+		if !ok || m["name"] != container {
+			continue
+		}
+		patchBytes := []byte(fmt.Sprintf(`[{"op":"replace","path":"/spec/template/spec/%s/%d/image","value":"%s"}]`, field, i, image))
+		return cl.Patch(context.TODO(), rollout, client.RawPatch(types.JSONPatchType, patchBytes))
+	}
+	return fmt.Errorf("container %s not found in rollout %s/%s", container, ns, name)
+}
+
+// PromoteArgoRollout resumes the paused Argo Rollout, or skips all the remaining steps and analysis if full is true.
+func PromoteArgoRollout(ns, name string, full bool, cl client.Client) error {
+	rollout := newRollout(ns, name, getter.ArgoRolloutGVK)
+	if full {
+		return patchRolloutStatus(rollout, []byte(`{"status":{"promoteFull":true}}`), cl)
+	}
+	if err := cl.Patch(context.TODO(), rollout, client.RawPatch(types.MergePatchType, []byte(`{"spec":{"paused":false}}`))); err != nil {
+		return err
+	}
+	return patchRolloutStatus(rollout, []byte(`{"status":{"pauseConditions":null}}`), cl)
+}
+
+// AbortArgoRollout aborts the update of the Argo Rollout and scales the stable version back up.
+func AbortArgoRollout(ns, name string, cl client.Client) error {
+	return patchRolloutStatus(newRollout(ns, name, getter.ArgoRolloutGVK), []byte(`{"status":{"abort":true}}`), cl)
+}
+
+// ApproveKruiseRollout lets the Kruise Rollout paused at a step move on to the next step.
+func ApproveKruiseRollout(ns, name string, cl client.Client) error {
+	return patchRolloutStatus(newRollout(ns, name, getter.KruiseRolloutGVK), []byte(`{"status":{"canaryStatus":{"currentStepState":"StepReady"}}}`), cl)
+}
+
+func newRollout(ns, name string, gvk schema.GroupVersionKind) *unstructured.Unstructured {
+	u := &unstructured.Unstructured{}
+	u.SetGroupVersionKind(gvk)
+	u.SetNamespace(ns)
+	u.SetName(name)
+	return u
+}
+
+func patchRolloutStatus(rollout *unstructured.Unstructured, patchBytes []byte, cl client.Client) error {
+	return cl.Status().Patch(context.TODO(), rollout, client.RawPatch(types.MergePatchType, patchBytes))
+}
@@ -0,0 +1,137 @@
+/*
+Copyright 2025 The KodeRover Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package kustomize builds kustomizations kept in memory, the files of a kustomization are loaded from a repository
+// once and built again whenever the service is rendered.
+package kustomize
+
+import (
+	"fmt"
+	"path"
+	"strings"
+
+	"sigs.k8s.io/kustomize/api/krusty"
+	"sigs.k8s.io/kustomize/api/types"
+	"sigs.k8s.io/kustomize/kyaml/filesys"
+	"sigs.k8s.io/yaml"
+)
+
+const (
+	sourceDir = "/src"
+	// imagesDir holds the kustomization wrapping the overlay to override images without changing the user's files
+	imagesDir = "/zadig-images"
+)
+
+var kustomizationFileNames = []string{"kustomization.yaml", "kustomization.yml", "Kustomization"}
+
+type File struct {
+	Path    string `bson:"path"    json:"path"`
+	Content string `bson:"content" json:"content"`
+}
+
+// Image overrides the images named Name, it's the same as an entry of the images field of a kustomization.
+type Image struct {
+	Name    string
+	NewName string
+	NewTag  string
+	Digest  string
+}
+
+// IsKustomization returns whether the directory of the files contains a kustomization.
+func IsKustomization(files []*File, dir string) bool {
+	dir = cleanPath(dir)
+	for _, file := range files {
+		for _, name := range kustomizationFileNames {
+			if cleanPath(file.Path) == cleanPath(path.Join(dir, name)) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// Build builds the kustomization in the directory of the files and returns the manifests.
+func Build(files []*File, dir string, images []*Image) (string, error) {
+	fSys := filesys.MakeFsInMemory()
+	for _, file := range files {
+		p := cleanPath(file.Path)
+		if p == "" {
+			return "", fmt.Errorf("invalid file path %s", file.Path)
+		}
+		if err := fSys.WriteFile(path.Join(sourceDir, p), []byte(file.Content)); err != nil {
+			return "", err
+		}
+	}
+
+	dir = cleanPath(dir)
+	if !IsKustomization(files, dir) {
+		return "", fmt.Errorf("kustomization file not found in %s", dir)
+	}
+	target := path.Join(sourceDir, dir)
+
+	if len(images) > 0 {
+		kustomization := &types.Kustomization{
+			TypeMeta: types.TypeMeta{
+				APIVersion: types.KustomizationVersion,
+				Kind:       types.KustomizationKind,
+			},
+			Resources: []string{path.Join("..", sourceDir, dir)},
+		}
+		for _, image := range images {
+			kustomization.Images = append(kustomization.Images, types.Image{
+				Name:    image.Name,
+				NewName: image.NewName,
+				NewTag:  image.NewTag,
+				Digest:  image.Digest,
+			})
+		}
+		content, err := yaml.Marshal(kustomization)
+		if err != nil {
+			return "", err
+		}
+		if err := fSys.WriteFile(path.Join(imagesDir, "kustomization.yaml"), content); err != nil {
+			return "", err
+		}
+		target = imagesDir
+	}
+
+	resMap, err := krusty.MakeKustomizer(krusty.MakeDefaultOptions()).Run(fSys, target)
+	if err != nil {
+		return "", err
+	}
+	out, err := resMap.AsYaml()
+	if err != nil {
+		return "", err
+	}
+	return string(out), nil
+}
+
+// ParseImage splits the image into the name used to match the images field and its tag or digest.
+func ParseImage(image string) (name, tag, digest string) {
+	if i := strings.Index(image, "@"); i >= 0 {
+		image, digest = image[:i], image[i+1:]
+	}
+	if i := strings.LastIndex(image, ":"); i > strings.LastIndex(image, "/") {
+		image, tag = image[:i], image[i+1:]
+	}
+	return image, tag, digest
+}
+
+// cleanPath returns the path relative to the root, paths can't escape the root.
+func cleanPath(p string) string {
+	p = path.Clean("/" + strings.TrimSpace(p))
+	return strings.TrimPrefix(p, "/")
+}
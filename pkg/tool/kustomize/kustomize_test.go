@@ -0,0 +1,66 @@
+/*
+Copyright 2025 The KodeRover Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package kustomize
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+var testFiles = []*File{
+	{Path: "base/kustomization.yaml", Content: "resources:\n- deployment.yaml\n"},
+	{Path: "base/deployment.yaml", Content: `apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: app
+spec:
+  template:
+    spec:
+      containers:
+      - name: app
+        image: registry.example.com/app:v1
+`},
+	{Path: "overlays/prod/kustomization.yaml", Content: "resources:\n- ../../base\nnamePrefix: prod-\n"},
+}
+
+func TestBuild(t *testing.T) {
+	out, err := Build(testFiles, "overlays/prod", nil)
+	require.NoError(t, err)
+	require.Contains(t, out, "name: prod-app")
+	require.Contains(t, out, "image: registry.example.com/app:v1")
+
+	out, err = Build(testFiles, "overlays/prod", []*Image{{Name: "registry.example.com/app", NewTag: "v2"}})
+	require.NoError(t, err)
+	require.Contains(t, out, "name: prod-app")
+	require.Contains(t, out, "image: registry.example.com/app:v2")
+
+	_, err = Build(testFiles, "overlays/dev", nil)
+	require.Error(t, err)
+}
+
+func TestParseImage(t *testing.T) {
+	name, tag, digest := ParseImage("registry.example.com:5000/app:v1")
+	require.Equal(t, "registry.example.com:5000/app", name)
+	require.Equal(t, "v1", tag)
+	require.Empty(t, digest)
+
+	name, tag, digest = ParseImage("registry.example.com:5000/app@sha256:abc")
+	require.Equal(t, "registry.example.com:5000/app", name)
+	require.Empty(t, tag)
+	require.Equal(t, "sha256:abc", digest)
+}
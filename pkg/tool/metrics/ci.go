@@ -0,0 +1,268 @@
+/*
+Copyright 2025 The KodeRover Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package metrics
+
+import (
+	_ "embed"
+	"sort"
+	"sync"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// GrafanaDashboard is the bundled grafana dashboard for the metrics below
+//
+//go:embed grafana_dashboard.json
+var GrafanaDashboard []byte
+
+const (
+	otherLabelValue = "other"
+
+	maxProjectLabelValues = 200
+	maxJobTypeLabelValues = 100
+	maxAgentLabelValues   = 500
+	maxClusterLabelValues = 200
+)
+
+var (
+	projectLabel = newLabelLimiter(maxProjectLabelValues)
+	jobTypeLabel = newLabelLimiter(maxJobTypeLabelValues)
+)
+
+var (
+	JobDuration = prometheus.NewHistogramVec(
+		prometheus.HistogramOpts{
+			Name:    "job_duration_seconds",
+			Help:    "Duration of workflow jobs in seconds",
+			Buckets: prometheus.ExponentialBuckets(10, 2, 10),
+		},
+		[]string{"project", "job_type", "status"},
+	)
+
+	QueueWaitTime = prometheus.NewHistogramVec(
+		prometheus.HistogramOpts{
+			Name:    "workflow_queue_wait_seconds",
+			Help:    "Time workflow tasks spent in the queue before running, in seconds",
+			Buckets: prometheus.ExponentialBuckets(1, 2, 12),
+		},
+		[]string{"project"},
+	)
+
+	TaskTotal = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "workflow_task_total",
+			Help: "Number of finished workflow tasks by status",
+		},
+		[]string{"project", "status"},
+	)
+
+	JobErrorPolicyTotal = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "job_error_policy_total",
+			Help: "Number of error policy actions taken for failed jobs",
+		},
+		[]string{"project", "job_type", "policy"},
+	)
+
+	VMAgentHeartbeatAge = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "vm_agent_heartbeat_age_seconds",
+			Help: "Seconds since the last heartbeat of vm agents",
+		},
+		[]string{"agent"},
+	)
+
+	VMAgentConcurrency = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "vm_agent_task_concurrency",
+			Help: "Task concurrency limit of vm agents",
+		},
+		[]string{"agent"},
+	)
+
+	VMAgentRunningJobs = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "vm_agent_running_jobs",
+			Help: "Number of jobs running on vm agents",
+		},
+		[]string{"agent"},
+	)
+
+	HubAgentConnected = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "hub_agent_connected",
+			Help: "Whether the hub agent of the cluster is connected to hub server, 1 for connected",
+		},
+		[]string{"cluster"},
+	)
+
+	WebhookInFlight = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "webhook_in_flight",
+			Help: "Number of code host webhook events being processed",
+		},
+		[]string{"source"},
+	)
+
+	WebhookControllerQueueLength = prometheus.NewGauge(
+		prometheus.GaugeOpts{
+			Name: "webhook_controller_queue_length",
+			Help: "Number of pending webhook creation and deletion tasks",
+		},
+	)
+)
+
+func ObserveJobDuration(project, jobType, status string, seconds int64) {
+	JobDuration.WithLabelValues(projectLabel.value(project), jobTypeLabel.value(jobType), status).Observe(float64(seconds))
+}
+
+func ObserveQueueWaitTime(project string, seconds int64) {
+	QueueWaitTime.WithLabelValues(projectLabel.value(project)).Observe(float64(seconds))
+}
+
+func IncTaskTotal(project, status string) {
+	TaskTotal.WithLabelValues(projectLabel.value(project), status).Inc()
+}
+
+func IncJobErrorPolicy(project, jobType, policy string) {
+	JobErrorPolicyTotal.WithLabelValues(projectLabel.value(project), jobTypeLabel.value(jobType), policy).Inc()
+}
+
+type VMAgentStatus struct {
+	Name         string
+	HeartbeatAge int64
+	Concurrency  int
+	RunningJobs  int
+}
+
+// SetVMAgentStatus replaces the vm agent gauges with the given agents. Only the agents running the most jobs get their
+// own label, the rest share the "other" label and are aggregated before being set: the heartbeat age is the oldest
+// one, concurrency and running jobs are summed up.
+func SetVMAgentStatus(agents []*VMAgentStatus) {
+	setVMAgentStatus(agents, maxAgentLabelValues)
+}
+
+func setVMAgentStatus(agents []*VMAgentStatus, limit int) {
+	sorted := make([]*VMAgentStatus, len(agents))
+	copy(sorted, agents)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		if sorted[i].RunningJobs != sorted[j].RunningJobs {
+			return sorted[i].RunningJobs > sorted[j].RunningJobs
+		}
+		return sorted[i].Name < sorted[j].Name
+	})
+
+	aggregated := make(map[string]*VMAgentStatus)
+	for i, agent := range sorted {
+		label := agent.Name
+		if i >= limit {
+			label = otherLabelValue
+		}
+		sum, ok := aggregated[label]
+		if !ok {
+			aggregated[label] = &VMAgentStatus{
+				Name:         label,
+				HeartbeatAge: agent.HeartbeatAge,
+				Concurrency:  agent.Concurrency,
+				RunningJobs:  agent.RunningJobs,
+			}
+			continue
+		}
+		if agent.HeartbeatAge > sum.HeartbeatAge {
+			sum.HeartbeatAge = agent.HeartbeatAge
+		}
+		sum.Concurrency += agent.Concurrency
+		sum.RunningJobs += agent.RunningJobs
+	}
+
+	VMAgentHeartbeatAge.Reset()
+	VMAgentConcurrency.Reset()
+	VMAgentRunningJobs.Reset()
+	for label, agent := range aggregated {
+		VMAgentHeartbeatAge.WithLabelValues(label).Set(float64(agent.HeartbeatAge))
+		VMAgentConcurrency.WithLabelValues(label).Set(float64(agent.Concurrency))
+		VMAgentRunningJobs.WithLabelValues(label).Set(float64(agent.RunningJobs))
+	}
+}
+
+// SetHubAgentConnected replaces the hub agent gauge with the given clusters. Only the first clusters by name get their
+// own label, the rest share the "other" label and are only reported as connected if all of them are connected.
+func SetHubAgentConnected(clusters map[string]bool) {
+	setHubAgentConnected(clusters, maxClusterLabelValues)
+}
+
+func setHubAgentConnected(clusters map[string]bool, limit int) {
+	names := make([]string, 0, len(clusters))
+	for cluster := range clusters {
+		names = append(names, cluster)
+	}
+	sort.Strings(names)
+
+	aggregated := make(map[string]bool)
+	for i, cluster := range names {
+		label, connected := cluster, clusters[cluster]
+		if i >= limit {
+			label = otherLabelValue
+		}
+		if last, ok := aggregated[label]; ok {
+			connected = connected && last
+		}
+		aggregated[label] = connected
+	}
+
+	HubAgentConnected.Reset()
+	for label, connected := range aggregated {
+		if connected {
+			HubAgentConnected.WithLabelValues(label).Set(1.0)
+		} else {
+			HubAgentConnected.WithLabelValues(label).Set(0.0)
+		}
+	}
+}
+
+func SetWebhookControllerQueueLength(length int) {
+	WebhookControllerQueueLength.Set(float64(length))
+}
+
+// labelLimiter caps the number of distinct values of a label of the cumulative metrics, values beyond the cap are
+// reported as "other" so that a large installation can not blow up the series count.
+type labelLimiter struct {
+	mu     sync.Mutex
+	limit  int
+	values map[string]struct{}
+}
+
+func newLabelLimiter(limit int) *labelLimiter {
+	return &labelLimiter{
+		limit:  limit,
+		values: make(map[string]struct{}),
+	}
+}
+
+func (l *labelLimiter) value(v string) string {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if _, ok := l.values[v]; ok {
+		return v
+	}
+	if len(l.values) >= l.limit {
+		return otherLabelValue
+	}
+	l.values[v] = struct{}{}
+	return v
+}
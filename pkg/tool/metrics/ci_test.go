@@ -0,0 +1,65 @@
+/*
+Copyright 2025 The KodeRover Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package metrics
+
+import (
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus"
+	dto "github.com/prometheus/client_model/go"
+	"github.com/stretchr/testify/require"
+)
+
+func gaugeValue(t *testing.T, vec *prometheus.GaugeVec, label string) float64 {
+	m := &dto.Metric{}
+	require.NoError(t, vec.WithLabelValues(label).Write(m))
+	return m.GetGauge().GetValue()
+}
+
+func TestSetVMAgentStatusAggregatesOtherLabel(t *testing.T) {
+	setVMAgentStatus([]*VMAgentStatus{
+		{Name: "vm-1", HeartbeatAge: 5, Concurrency: 2, RunningJobs: 1},
+		{Name: "vm-2", HeartbeatAge: 30, Concurrency: 4, RunningJobs: 3},
+		{Name: "vm-3", HeartbeatAge: 10, Concurrency: 1, RunningJobs: 1},
+	}, 1)
+
+	require.Equal(t, 30.0, gaugeValue(t, VMAgentHeartbeatAge, "vm-2"))
+	require.Equal(t, 10.0, gaugeValue(t, VMAgentHeartbeatAge, otherLabelValue))
+	require.Equal(t, 3.0, gaugeValue(t, VMAgentConcurrency, otherLabelValue))
+	require.Equal(t, 2.0, gaugeValue(t, VMAgentRunningJobs, otherLabelValue))
+
+	// the labels follow the current agents, an agent which stopped running jobs gives its label away
+	setVMAgentStatus([]*VMAgentStatus{
+		{Name: "vm-2", HeartbeatAge: 5, Concurrency: 4, RunningJobs: 0},
+		{Name: "vm-3", HeartbeatAge: 10, Concurrency: 1, RunningJobs: 1},
+	}, 1)
+
+	require.Equal(t, 10.0, gaugeValue(t, VMAgentHeartbeatAge, "vm-3"))
+	require.Equal(t, 4.0, gaugeValue(t, VMAgentConcurrency, otherLabelValue))
+}
+
+func TestSetHubAgentConnectedAggregatesOtherLabel(t *testing.T) {
+	setHubAgentConnected(map[string]bool{"a": true, "b": true}, 0)
+	require.Equal(t, 1.0, gaugeValue(t, HubAgentConnected, otherLabelValue))
+
+	setHubAgentConnected(map[string]bool{"a": true, "b": false, "c": true}, 0)
+	require.Equal(t, 0.0, gaugeValue(t, HubAgentConnected, otherLabelValue))
+
+	setHubAgentConnected(map[string]bool{"a": false, "b": true, "c": true}, 1)
+	require.Equal(t, 0.0, gaugeValue(t, HubAgentConnected, "a"))
+	require.Equal(t, 1.0, gaugeValue(t, HubAgentConnected, otherLabelValue))
+}
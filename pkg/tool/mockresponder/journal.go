@@ -0,0 +1,79 @@
+/*
+Copyright 2025 The KodeRover Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package mockresponder
+
+import (
+	"sync"
+
+	"github.com/koderover/zadig/v2/pkg/types"
+)
+
+const defaultJournalSize = 1000
+
+// Journal keeps the latest requests received by the mock responder
+type Journal struct {
+	mu      sync.RWMutex
+	size    int
+	entries []*types.MockJournalEntry
+	// droppedUntil is the time of the latest request dropped for exceeding the size
+	droppedUntil int64
+}
+
+func NewJournal(size int) *Journal {
+	if size <= 0 {
+		size = defaultJournalSize
+	}
+	return &Journal{size: size}
+}
+
+func (j *Journal) Add(entry *types.MockJournalEntry) {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+
+	j.entries = append(j.entries, entry)
+	if len(j.entries) > j.size {
+		for _, dropped := range j.entries[:len(j.entries)-j.size] {
+			if dropped.Time > j.droppedUntil {
+				j.droppedUntil = dropped.Time
+			}
+		}
+		j.entries = j.entries[len(j.entries)-j.size:]
+	}
+}
+
+// List returns the requests received since the given unix time in milliseconds, the earliest first. truncated is
+// true if some of the requests since then were dropped for exceeding the size.
+func (j *Journal) List(since int64) (entries []*types.MockJournalEntry, truncated bool) {
+	j.mu.RLock()
+	defer j.mu.RUnlock()
+
+	resp := make([]*types.MockJournalEntry, 0)
+	for _, entry := range j.entries {
+		if entry.Time >= since {
+			resp = append(resp, entry)
+		}
+	}
+	return resp, j.droppedUntil > 0 && j.droppedUntil >= since
+}
+
+func (j *Journal) Reset() {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+
+	j.entries = nil
+	j.droppedUntil = 0
+}
@@ -0,0 +1,143 @@
+/*
+Copyright 2025 The KodeRover Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package mockresponder
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"regexp"
+	"strings"
+
+	"github.com/koderover/zadig/v2/pkg/types"
+)
+
+// Match tells whether the request matches the matcher, a nil matcher matches anything
+func Match(matcher *types.MockRequest, req *types.MockJournalEntry) bool {
+	if matcher == nil {
+		return true
+	}
+
+	if matcher.Method != "" && !strings.EqualFold(matcher.Method, req.Method) {
+		return false
+	}
+	if matcher.Path != "" {
+		if matcher.PathRegex {
+			matched, err := regexp.MatchString("^(?:"+matcher.Path+")$", req.Path)
+			if err != nil || !matched {
+				return false
+			}
+		} else if matcher.Path != req.Path {
+			return false
+		}
+	}
+	for k, v := range matcher.Headers {
+		if req.Headers[strings.ToLower(k)] != v {
+			return false
+		}
+	}
+	for k, v := range matcher.Query {
+		if req.Query[k] != v {
+			return false
+		}
+	}
+	if matcher.BodyContains != "" && !strings.Contains(req.Body, matcher.BodyContains) {
+		return false
+	}
+	if matcher.BodyJSON != "" {
+		var expected, actual interface{}
+		if err := json.Unmarshal([]byte(matcher.BodyJSON), &expected); err != nil {
+			return false
+		}
+		if err := json.Unmarshal([]byte(req.Body), &actual); err != nil {
+			return false
+		}
+		if !jsonSubset(expected, actual) {
+			return false
+		}
+	}
+	return true
+}
+
+// ValidateRequest checks the regular expression and the json document of the matcher
+func ValidateRequest(matcher *types.MockRequest) error {
+	if matcher == nil {
+		return nil
+	}
+	if matcher.PathRegex {
+		if _, err := regexp.Compile(matcher.Path); err != nil {
+			return fmt.Errorf("invalid path pattern %s: %s", matcher.Path, err)
+		}
+	}
+	if matcher.BodyJSON != "" && !json.Valid([]byte(matcher.BodyJSON)) {
+		return fmt.Errorf("invalid body json: %s", matcher.BodyJSON)
+	}
+	return nil
+}
+
+// SelectStub returns the stub with the highest priority among the ones matching the request, the earlier one wins a tie
+func SelectStub(stubs []*types.MockStub, req *types.MockJournalEntry) *types.MockStub {
+	var selected *types.MockStub
+	for _, stub := range stubs {
+		if !Match(stub.Request, req) {
+			continue
+		}
+		if selected == nil || stub.Priority > selected.Priority {
+			selected = stub
+		}
+	}
+	return selected
+}
+
+// jsonSubset tells whether every field of the expected document is in the actual one, an expected array is a subset
+// if each of its elements is a subset of an element of the actual array
+func jsonSubset(expected, actual interface{}) bool {
+	switch e := expected.(type) {
+	case map[string]interface{}:
+		a, ok := actual.(map[string]interface{})
+		if !ok {
+			return false
+		}
+		for k, v := range e {
+			av, ok := a[k]
+			if !ok || !jsonSubset(v, av) {
+				return false
+			}
+		}
+		return true
+	case []interface{}:
+		a, ok := actual.([]interface{})
+		if !ok {
+			return false
+		}
+		for _, ev := range e {
+			found := false
+			for _, av := range a {
+				if jsonSubset(ev, av) {
+					found = true
+					break
+				}
+			}
+			if !found {
+				return false
+			}
+		}
+		return true
+	default:
+		return reflect.DeepEqual(expected, actual)
+	}
+}
@@ -0,0 +1,185 @@
+/*
+Copyright 2025 The KodeRover Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package mockresponder
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/descriptorpb"
+
+	"github.com/koderover/zadig/v2/pkg/types"
+)
+
+func TestMatch(t *testing.T) {
+	ast := require.New(t)
+
+	req := &types.MockJournalEntry{
+		Method:  "POST",
+		Path:    "/v1/charges/ch_123",
+		Query:   map[string]string{"expand": "customer"},
+		Headers: map[string]string{"content-type": "application/json"},
+		Body:    `{"amount":100,"currency":"usd","items":[{"id":"a","qty":1},{"id":"b","qty":2}]}`,
+	}
+
+	ast.True(Match(nil, req))
+	ast.True(Match(&types.MockRequest{Method: "post", Path: "/v1/charges/ch_123"}, req))
+	ast.False(Match(&types.MockRequest{Method: "GET"}, req))
+	ast.True(Match(&types.MockRequest{Path: "/v1/charges/[a-z_0-9]+", PathRegex: true}, req))
+	ast.False(Match(&types.MockRequest{Path: "/v1/charges", PathRegex: true}, req))
+	ast.True(Match(&types.MockRequest{Headers: map[string]string{"Content-Type": "application/json"}}, req))
+	ast.False(Match(&types.MockRequest{Query: map[string]string{"expand": "invoice"}}, req))
+	ast.True(Match(&types.MockRequest{BodyContains: `"currency":"usd"`}, req))
+	ast.True(Match(&types.MockRequest{BodyJSON: `{"amount":100,"items":[{"id":"b"}]}`}, req))
+	ast.False(Match(&types.MockRequest{BodyJSON: `{"amount":200}`}, req))
+	ast.False(Match(&types.MockRequest{BodyJSON: `{"items":[{"id":"c"}]}`}, req))
+
+	ast.Error(ValidateRequest(&types.MockRequest{Path: "(", PathRegex: true}))
+	ast.Error(ValidateRequest(&types.MockRequest{BodyJSON: "{"}))
+}
+
+func TestSelectStub(t *testing.T) {
+	ast := require.New(t)
+
+	stubs := []*types.MockStub{
+		{Name: "any", Request: &types.MockRequest{Path: "/.*", PathRegex: true}},
+		{Name: "users", Request: &types.MockRequest{Path: "/users"}},
+		{Name: "users-high", Priority: 1, Request: &types.MockRequest{Path: "/users", Method: "GET"}},
+	}
+
+	ast.Equal("users-high", SelectStub(stubs, &types.MockJournalEntry{Method: "GET", Path: "/users"}).Name)
+	ast.Equal("any", SelectStub(stubs, &types.MockJournalEntry{Method: "POST", Path: "/users"}).Name)
+	ast.Nil(SelectStub(stubs[1:], &types.MockJournalEntry{Method: "GET", Path: "/orders"}))
+}
+
+func TestJournal(t *testing.T) {
+	ast := require.New(t)
+
+	journal := NewJournal(2)
+	journal.Add(&types.MockJournalEntry{Time: 1, Path: "/a"})
+	journal.Add(&types.MockJournalEntry{Time: 2, Path: "/b"})
+	journal.Add(&types.MockJournalEntry{Time: 3, Path: "/c"})
+
+	entries, truncated := journal.List(0)
+	ast.Len(entries, 2)
+	ast.True(truncated)
+
+	// the requests since 2 are all kept
+	entries, truncated = journal.List(2)
+	ast.Len(entries, 2)
+	ast.False(truncated)
+	entries, _ = journal.List(3)
+	ast.Equal("/c", entries[0].Path)
+
+	journal.Reset()
+	entries, truncated = journal.List(0)
+	ast.Empty(entries)
+	ast.False(truncated)
+}
+
+func TestServeHTTP(t *testing.T) {
+	ast := require.New(t)
+
+	r, err := New(&types.MockConfig{
+		Protocol: types.MockProtocolHTTP,
+		Stubs: []*types.MockStub{
+			{
+				Name:     "create-charge",
+				Request:  &types.MockRequest{Method: "POST", Path: "/v1/charges"},
+				Response: &types.MockResponse{Status: http.StatusCreated, Headers: map[string]string{"Content-Type": "application/json"}, Body: `{"id":"ch_1"}`},
+			},
+		},
+	})
+	ast.NoError(err)
+
+	server := httptest.NewServer(r)
+	defer server.Close()
+	admin := httptest.NewServer(r.AdminHandler())
+	defer admin.Close()
+
+	resp, err := http.Post(server.URL+"/v1/charges?source=test", "application/json", strings.NewReader(`{"amount":1}`))
+	ast.NoError(err)
+	body, _ := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	ast.Equal(http.StatusCreated, resp.StatusCode)
+	ast.Equal(`{"id":"ch_1"}`, string(body))
+
+	resp, err = http.Get(server.URL + "/v1/refunds")
+	ast.NoError(err)
+	resp.Body.Close()
+	ast.Equal(http.StatusNotFound, resp.StatusCode)
+
+	resp, err = http.Get(admin.URL + types.MockJournalPath)
+	ast.NoError(err)
+	entries := make([]*types.MockJournalEntry, 0)
+	ast.NoError(json.NewDecoder(resp.Body).Decode(&entries))
+	resp.Body.Close()
+	ast.Equal("false", resp.Header.Get(types.MockJournalTruncatedHeader))
+	ast.Len(entries, 2)
+	ast.Equal("create-charge", entries[0].Stub)
+	ast.Equal("test", entries[0].Query["source"])
+	ast.Equal(`{"amount":1}`, entries[0].Body)
+	ast.Equal("", entries[1].Stub)
+	ast.Equal(http.StatusNotFound, entries[1].Status)
+}
+
+func TestParseDescriptorSet(t *testing.T) {
+	ast := require.New(t)
+
+	fdSet := &descriptorpb.FileDescriptorSet{
+		File: []*descriptorpb.FileDescriptorProto{
+			{
+				Name:    proto.String("greeter.proto"),
+				Package: proto.String("helloworld"),
+				Syntax:  proto.String("proto3"),
+				MessageType: []*descriptorpb.DescriptorProto{
+					{
+						Name: proto.String("HelloRequest"),
+						Field: []*descriptorpb.FieldDescriptorProto{
+							{Name: proto.String("name"), Number: proto.Int32(1), Type: descriptorpb.FieldDescriptorProto_TYPE_STRING.Enum(), JsonName: proto.String("name")},
+						},
+					},
+				},
+				Service: []*descriptorpb.ServiceDescriptorProto{
+					{
+						Name: proto.String("Greeter"),
+						Method: []*descriptorpb.MethodDescriptorProto{
+							{Name: proto.String("SayHello"), InputType: proto.String(".helloworld.HelloRequest"), OutputType: proto.String(".helloworld.HelloRequest")},
+						},
+					},
+				},
+			},
+		},
+	}
+	data, err := proto.Marshal(fdSet)
+	ast.NoError(err)
+
+	methods, err := ParseDescriptorSet(base64.StdEncoding.EncodeToString(data))
+	ast.NoError(err)
+	ast.Contains(methods, "/helloworld.Greeter/SayHello")
+	ast.Equal("helloworld.HelloRequest", string(methods["/helloworld.Greeter/SayHello"].Input().FullName()))
+
+	_, err = ParseDescriptorSet("not base64")
+	ast.Error(err)
+}
@@ -0,0 +1,334 @@
+/*
+Copyright 2025 The KodeRover Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package mockresponder
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protodesc"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/types/descriptorpb"
+	"google.golang.org/protobuf/types/dynamicpb"
+
+	"github.com/koderover/zadig/v2/pkg/tool/log"
+	"github.com/koderover/zadig/v2/pkg/types"
+)
+
+// maxBodySize is the max size of the request body recorded in the journal
+const maxBodySize = 1 << 20
+
+// Responder answers the requests with the stubs of a mock and records them in the journal
+type Responder struct {
+	config  *types.MockConfig
+	journal *Journal
+	// methods are the grpc methods in the descriptor set keyed by the full method name
+	methods map[string]protoreflect.MethodDescriptor
+}
+
+func New(config *types.MockConfig) (*Responder, error) {
+	r := &Responder{
+		config:  config,
+		journal: NewJournal(config.JournalSize),
+		methods: make(map[string]protoreflect.MethodDescriptor),
+	}
+	for _, stub := range config.Stubs {
+		if err := ValidateRequest(stub.Request); err != nil {
+			return nil, fmt.Errorf("invalid stub %s: %s", stub.Name, err)
+		}
+	}
+
+	if config.Protocol == types.MockProtocolGRPC {
+		methods, err := ParseDescriptorSet(config.DescriptorSet)
+		if err != nil {
+			return nil, err
+		}
+		r.methods = methods
+	}
+	return r, nil
+}
+
+// ParseDescriptorSet returns the methods of the services in the base64 encoded FileDescriptorSet keyed by the full method name
+func ParseDescriptorSet(descriptorSet string) (map[string]protoreflect.MethodDescriptor, error) {
+	data, err := base64.StdEncoding.DecodeString(descriptorSet)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode descriptor set: %s", err)
+	}
+	fdSet := &descriptorpb.FileDescriptorSet{}
+	if err := proto.Unmarshal(data, fdSet); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal descriptor set: %s", err)
+	}
+	files, err := protodesc.NewFiles(fdSet)
+	if err != nil {
+		return nil, fmt.Errorf("failed to resolve descriptor set: %s", err)
+	}
+
+	methods := make(map[string]protoreflect.MethodDescriptor)
+	files.RangeFiles(func(fd protoreflect.FileDescriptor) bool {
+		for i := 0; i < fd.Services().Len(); i++ {
+			svc := fd.Services().Get(i)
+			for j := 0; j < svc.Methods().Len(); j++ {
+				method := svc.Methods().Get(j)
+				methods[fmt.Sprintf("/%s/%s", svc.FullName(), method.Name())] = method
+			}
+		}
+		return true
+	})
+	if len(methods) == 0 {
+		return nil, fmt.Errorf("no grpc service found in descriptor set")
+	}
+	return methods, nil
+}
+
+func (r *Responder) Journal() *Journal {
+	return r.journal
+}
+
+// ServeHTTP answers the http requests with the stubs
+func (r *Responder) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	body, _ := io.ReadAll(io.LimitReader(req.Body, maxBodySize))
+	entry := &types.MockJournalEntry{
+		Time:    time.Now().UnixMilli(),
+		Method:  req.Method,
+		Path:    req.URL.Path,
+		Query:   make(map[string]string),
+		Headers: make(map[string]string),
+		Body:    string(body),
+	}
+	for k := range req.URL.Query() {
+		entry.Query[k] = req.URL.Query().Get(k)
+	}
+	for k, v := range req.Header {
+		entry.Headers[strings.ToLower(k)] = strings.Join(v, ",")
+	}
+	defer r.journal.Add(entry)
+
+	stub := SelectStub(r.config.Stubs, entry)
+	if stub == nil {
+		entry.Status = http.StatusNotFound
+		http.Error(w, "no stub matched the request", http.StatusNotFound)
+		return
+	}
+	entry.Stub = stub.Name
+
+	resp := stub.Response
+	if resp == nil {
+		resp = &types.MockResponse{}
+	}
+	if resp.Delay > 0 {
+		time.Sleep(time.Duration(resp.Delay) * time.Millisecond)
+	}
+	for k, v := range resp.Headers {
+		w.Header().Set(k, v)
+	}
+	entry.Status = resp.Status
+	if entry.Status == 0 {
+		entry.Status = http.StatusOK
+	}
+	w.WriteHeader(entry.Status)
+	_, _ = w.Write([]byte(resp.Body))
+}
+
+// AdminHandler serves the request journal
+func (r *Responder) AdminHandler() http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/healthz", func(w http.ResponseWriter, _ *http.Request) {
+		_, _ = w.Write([]byte("ok"))
+	})
+	mux.HandleFunc(types.MockJournalPath, func(w http.ResponseWriter, req *http.Request) {
+		switch req.Method {
+		case http.MethodGet:
+			since, _ := strconv.ParseInt(req.URL.Query().Get("since"), 10, 64)
+			entries, truncated := r.journal.List(since)
+			w.Header().Set("Content-Type", "application/json")
+			w.Header().Set(types.MockJournalTruncatedHeader, strconv.FormatBool(truncated))
+			_ = json.NewEncoder(w).Encode(entries)
+		case http.MethodDelete:
+			r.journal.Reset()
+			w.WriteHeader(http.StatusNoContent)
+		default:
+			w.WriteHeader(http.StatusMethodNotAllowed)
+		}
+	})
+	return mux
+}
+
+// GRPCServer returns the server answering the grpc calls of the methods in the descriptor set with the stubs,
+// only the first message of a client stream is read and a single message is sent back
+func (r *Responder) GRPCServer() *grpc.Server {
+	return grpc.NewServer(grpc.ForceServerCodec(rawCodec{}), grpc.UnknownServiceHandler(r.handleStream))
+}
+
+func (r *Responder) handleStream(_ interface{}, stream grpc.ServerStream) error {
+	fullMethod, _ := grpc.MethodFromServerStream(stream)
+	method, ok := r.methods[fullMethod]
+	if !ok {
+		return status.Errorf(codes.Unimplemented, "method %s is not in the descriptor set", fullMethod)
+	}
+
+	var in []byte
+	if err := stream.RecvMsg(&in); err != nil {
+		return err
+	}
+	reqMsg := dynamicpb.NewMessage(method.Input())
+	if err := proto.Unmarshal(in, reqMsg); err != nil {
+		return status.Errorf(codes.InvalidArgument, "failed to unmarshal request: %s", err)
+	}
+
+	entry := &types.MockJournalEntry{
+		Time:    time.Now().UnixMilli(),
+		Method:  fullMethod,
+		Path:    fullMethod,
+		Headers: make(map[string]string),
+		Body:    messageToJSON(reqMsg),
+	}
+	if md, ok := metadata.FromIncomingContext(stream.Context()); ok {
+		for k, v := range md {
+			entry.Headers[k] = strings.Join(v, ",")
+		}
+	}
+	defer r.journal.Add(entry)
+
+	stub := SelectStub(r.config.Stubs, entry)
+	if stub == nil {
+		entry.Status = int(codes.Unimplemented)
+		return status.Errorf(codes.Unimplemented, "no stub matched the request")
+	}
+	entry.Stub = stub.Name
+
+	resp := stub.Response
+	if resp == nil {
+		resp = &types.MockResponse{}
+	}
+	if resp.Delay > 0 {
+		time.Sleep(time.Duration(resp.Delay) * time.Millisecond)
+	}
+	if len(resp.Headers) > 0 {
+		_ = stream.SetHeader(metadata.New(resp.Headers))
+	}
+	entry.Status = resp.Status
+	if codes.Code(resp.Status) != codes.OK {
+		return status.Error(codes.Code(resp.Status), resp.Message)
+	}
+
+	respMsg := dynamicpb.NewMessage(method.Output())
+	if resp.Body != "" {
+		if err := protojson.Unmarshal([]byte(resp.Body), respMsg); err != nil {
+			return status.Errorf(codes.Internal, "failed to unmarshal the response of stub %s: %s", stub.Name, err)
+		}
+	}
+	out, err := proto.Marshal(respMsg)
+	if err != nil {
+		return status.Errorf(codes.Internal, "failed to marshal response: %s", err)
+	}
+	return stream.SendMsg(&out)
+}
+
+// messageToJSON returns the compact json form of the message, protojson adds random spaces on purpose which would
+// break the body matching
+func messageToJSON(msg proto.Message) string {
+	data, err := protojson.Marshal(msg)
+	if err != nil {
+		return ""
+	}
+	buf := &bytes.Buffer{}
+	if err := json.Compact(buf, data); err != nil {
+		return string(data)
+	}
+	return buf.String()
+}
+
+// Serve serves the mock on the configured port and the journal on the admin port until the context is done
+func Serve(ctx context.Context, config *types.MockConfig) error {
+	r, err := New(config)
+	if err != nil {
+		return err
+	}
+
+	admin := &http.Server{Addr: fmt.Sprintf(":%d", types.MockAdminPort), Handler: r.AdminHandler()}
+	errCh := make(chan error, 2)
+	go func() {
+		errCh <- admin.ListenAndServe()
+	}()
+
+	var stop func()
+	if config.Protocol == types.MockProtocolGRPC {
+		lis, err := net.Listen("tcp", fmt.Sprintf(":%d", config.Port))
+		if err != nil {
+			return err
+		}
+		server := r.GRPCServer()
+		go func() {
+			errCh <- server.Serve(lis)
+		}()
+		stop = server.GracefulStop
+	} else {
+		server := &http.Server{Addr: fmt.Sprintf(":%d", config.Port), Handler: r}
+		go func() {
+			errCh <- server.ListenAndServe()
+		}()
+		stop = func() { _ = server.Shutdown(context.TODO()) }
+	}
+	log.Infof("mock %s is serving %s on port %d", config.Name, config.Protocol, config.Port)
+
+	select {
+	case <-ctx.Done():
+		stop()
+		return admin.Shutdown(context.TODO())
+	case err := <-errCh:
+		return err
+	}
+}
+
+// rawCodec passes the messages through as bytes, they are decoded with the descriptors of the methods
+type rawCodec struct{}
+
+func (rawCodec) Marshal(v interface{}) ([]byte, error) {
+	data, ok := v.(*[]byte)
+	if !ok {
+		return nil, fmt.Errorf("unexpected message type %T", v)
+	}
+	return *data, nil
+}
+
+func (rawCodec) Unmarshal(data []byte, v interface{}) error {
+	dst, ok := v.(*[]byte)
+	if !ok {
+		return fmt.Errorf("unexpected message type %T", v)
+	}
+	*dst = append([]byte(nil), data...)
+	return nil
+}
+
+func (rawCodec) Name() string {
+	return "proto"
+}
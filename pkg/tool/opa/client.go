@@ -0,0 +1,127 @@
+/*
+Copyright 2025 The KodeRover Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package opa
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/koderover/zadig/v2/pkg/tool/httpclient"
+)
+
+// Client talks to the REST API of an OPA server, it's used to manage the policies pushed by zadig besides the bundle.
+type Client struct {
+	*httpclient.Client
+}
+
+func NewClient(host string) *Client {
+	return &Client{
+		Client: httpclient.New(
+			httpclient.SetHostURL(host),
+			httpclient.SetIgnoreCodes(http.StatusBadRequest, http.StatusNotFound),
+		),
+	}
+}
+
+type apiError struct {
+	Code    string `json:"code"`
+	Message string `json:"message"`
+	Errors  []struct {
+		Code     string `json:"code"`
+		Message  string `json:"message"`
+		Location *struct {
+			Row int `json:"row"`
+			Col int `json:"col"`
+		} `json:"location"`
+	} `json:"errors"`
+}
+
+func (e *apiError) Error() string {
+	if len(e.Errors) == 0 {
+		return e.Message
+	}
+	msgs := make([]string, 0, len(e.Errors))
+	for _, err := range e.Errors {
+		if err.Location != nil {
+			msgs = append(msgs, fmt.Sprintf("%d:%d: %s", err.Location.Row, err.Location.Col, err.Message))
+		} else {
+			msgs = append(msgs, err.Message)
+		}
+	}
+	return fmt.Sprintf("%s: %s", e.Message, strings.Join(msgs, "; "))
+}
+
+func parseAPIError(body []byte, statusCode int) error {
+	apiErr := &apiError{}
+	if err := json.Unmarshal(body, apiErr); err != nil || apiErr.Message == "" {
+		return fmt.Errorf("opa responded with status code %d: %s", statusCode, string(body))
+	}
+	return apiErr
+}
+
+// PutPolicy creates or replaces the policy module, compile errors of the module are returned with their positions.
+func (c *Client) PutPolicy(id, module string) error {
+	res, err := c.Put("/v1/policies/"+id, httpclient.SetHeader("Content-Type", "text/plain"), httpclient.SetBody(module))
+	if err != nil {
+		return err
+	}
+	if res.IsError() {
+		return parseAPIError(res.Body(), res.StatusCode())
+	}
+	return nil
+}
+
+// DeletePolicy removes the policy module, a module which doesn't exist is ignored.
+func (c *Client) DeletePolicy(id string) error {
+	res, err := c.Delete("/v1/policies/" + id)
+	if err != nil {
+		return err
+	}
+	if res.IsError() && res.StatusCode() != http.StatusNotFound {
+		return parseAPIError(res.Body(), res.StatusCode())
+	}
+	return nil
+}
+
+// Query evaluates the document at the dotted path with the input, it returns false if the document is undefined.
+func (c *Client) Query(path string, input, result interface{}) (bool, error) {
+	req := struct {
+		Input interface{} `json:"input"`
+	}{
+		Input: input,
+	}
+
+	resp := struct {
+		Result json.RawMessage `json:"result"`
+	}{}
+	res, err := c.Post("/v1/data/"+strings.ReplaceAll(path, ".", "/"), httpclient.SetBody(req))
+	if err != nil {
+		return false, err
+	}
+	if res.IsError() {
+		return false, parseAPIError(res.Body(), res.StatusCode())
+	}
+	if err := json.Unmarshal(res.Body(), &resp); err != nil {
+		return false, err
+	}
+	if len(resp.Result) == 0 {
+		return false, nil
+	}
+	return true, json.Unmarshal(resp.Result, result)
+}
@@ -0,0 +1,81 @@
+/*
+Copyright 2025 The KodeRover Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package opa
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/koderover/zadig/v2/pkg/tool/log"
+)
+
+func TestClient(t *testing.T) {
+	log.Init(&log.Config{
+		Level: "debug",
+	})
+
+	modules := make(map[string]string)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == http.MethodPut && r.URL.Path == "/v1/policies/bad":
+			w.WriteHeader(http.StatusBadRequest)
+			_, _ = w.Write([]byte(`{"code":"invalid_parameter","message":"error(s) occurred while compiling module(s)","errors":[{"code":"rego_parse_error","message":"unexpected eof token","location":{"file":"bad","row":3,"col":1}}]}`))
+		case r.Method == http.MethodPut:
+			body, _ := io.ReadAll(r.Body)
+			modules[r.URL.Path] = string(body)
+			_, _ = w.Write([]byte(`{}`))
+		case r.Method == http.MethodDelete:
+			w.WriteHeader(http.StatusNotFound)
+			_, _ = w.Write([]byte(`{"code":"resource_not_found","message":"storage_not_found_error: policy id \"missing\""}`))
+		case r.URL.Path == "/v1/data/zadig/admission/tags/deny":
+			req := struct {
+				Input map[string]interface{} `json:"input"`
+			}{}
+			_ = json.NewDecoder(r.Body).Decode(&req)
+			require.Equal(t, "prod", req.Input["env"])
+			_, _ = w.Write([]byte(`{"result":["latest tag is not allowed"]}`))
+		default:
+			_, _ = w.Write([]byte(`{}`))
+		}
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL)
+
+	require.NoError(t, client.PutPolicy("good", "package zadig.admission.tags"))
+	require.Equal(t, "package zadig.admission.tags", modules["/v1/policies/good"])
+
+	err := client.PutPolicy("bad", "package")
+	require.EqualError(t, err, "error(s) occurred while compiling module(s): 3:1: unexpected eof token")
+
+	require.NoError(t, client.DeletePolicy("missing"))
+
+	deny := make([]string, 0)
+	defined, err := client.Query("zadig.admission.tags.deny", map[string]string{"env": "prod"}, &deny)
+	require.NoError(t, err)
+	require.True(t, defined)
+	require.Equal(t, []string{"latest tag is not allowed"}, deny)
+
+	defined, err = client.Query("zadig.admission.missing.deny", nil, &deny)
+	require.NoError(t, err)
+	require.False(t, defined)
+}
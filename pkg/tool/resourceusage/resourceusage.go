@@ -0,0 +1,279 @@
+/*
+Copyright 2025 The KodeRover Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package resourceusage
+
+import (
+	"bufio"
+	"fmt"
+	"math"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/koderover/zadig/v2/pkg/setting"
+)
+
+const defaultCgroupRoot = "/sys/fs/cgroup"
+
+// Usage is the resource usage of a job container, cpu is in millicores and memory is in MiB
+type Usage struct {
+	CpuPeak    int `json:"cpu_peak"`
+	CpuAvg     int `json:"cpu_avg"`
+	MemoryPeak int `json:"memory_peak"`
+	MemoryAvg  int `json:"memory_avg"`
+	Samples    int `json:"samples"`
+	// OOMKilled marks the usage of a run killed for running out of memory, the memory is taken at the limit
+	// and there is no cpu usage since the executor could not report it
+	OOMKilled bool `json:"oom_killed,omitempty"`
+}
+
+// Sampler samples the cpu and memory usage of the current container from its cgroup stats
+type Sampler struct {
+	root     string
+	interval time.Duration
+
+	mu        sync.Mutex
+	lastCPU   uint64
+	lastTime  time.Time
+	cpuSum    int64
+	cpuCount  int
+	memorySum int64
+	usage     Usage
+
+	stopCh chan struct{}
+	doneCh chan struct{}
+}
+
+func NewSampler(interval time.Duration) *Sampler {
+	return newSampler(defaultCgroupRoot, interval)
+}
+
+func newSampler(root string, interval time.Duration) *Sampler {
+	return &Sampler{
+		root:     root,
+		interval: interval,
+		stopCh:   make(chan struct{}),
+		doneCh:   make(chan struct{}),
+	}
+}
+
+// Start samples the usage periodically until Stop is called
+func (s *Sampler) Start() {
+	go func() {
+		defer close(s.doneCh)
+
+		ticker := time.NewTicker(s.interval)
+		defer ticker.Stop()
+		s.sample(time.Now())
+		for {
+			select {
+			case <-s.stopCh:
+				return
+			case now := <-ticker.C:
+				s.sample(now)
+			}
+		}
+	}()
+}
+
+// Stop stops sampling and returns the usage, it returns nil if the cgroup stats can not be read
+func (s *Sampler) Stop() *Usage {
+	close(s.stopCh)
+	<-s.doneCh
+	s.sample(time.Now())
+	return s.Usage()
+}
+
+func (s *Sampler) Usage() *Usage {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.usage.Samples == 0 {
+		return nil
+	}
+	usage := s.usage
+	if s.cpuCount > 0 {
+		usage.CpuAvg = int(s.cpuSum / int64(s.cpuCount))
+	}
+	usage.MemoryAvg = int(s.memorySum / int64(s.usage.Samples))
+	return &usage
+}
+
+func (s *Sampler) sample(now time.Time) {
+	cpuUsec, memoryBytes, err := s.read()
+	if err != nil {
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	// samples closer than a microsecond have no elapsed time to divide the cpu usage by, the cpu usage
+	// is left to the next sample
+	elapsed := now.Sub(s.lastTime).Microseconds()
+	if s.lastTime.IsZero() || elapsed > 0 {
+		if !s.lastTime.IsZero() && cpuUsec >= s.lastCPU {
+			cpu := int((cpuUsec - s.lastCPU) * 1000 / uint64(elapsed))
+			s.cpuSum += int64(cpu)
+			s.cpuCount++
+			if cpu > s.usage.CpuPeak {
+				s.usage.CpuPeak = cpu
+			}
+		}
+		s.lastCPU = cpuUsec
+		s.lastTime = now
+	}
+
+	memory := int(memoryBytes / 1024 / 1024)
+	s.memorySum += int64(memory)
+	if memory > s.usage.MemoryPeak {
+		s.usage.MemoryPeak = memory
+	}
+	s.usage.Samples++
+}
+
+// read returns the cpu usage in microseconds and the memory working set in bytes, both cgroup v2 and v1 are supported
+func (s *Sampler) read() (uint64, uint64, error) {
+	if _, err := os.Stat(filepath.Join(s.root, "cgroup.controllers")); err == nil {
+		cpuStat, err := readStat(filepath.Join(s.root, "cpu.stat"))
+		if err != nil {
+			return 0, 0, err
+		}
+		memory, err := readUint(filepath.Join(s.root, "memory.current"))
+		if err != nil {
+			return 0, 0, err
+		}
+		memoryStat, _ := readStat(filepath.Join(s.root, "memory.stat"))
+		return cpuStat["usage_usec"], workingSet(memory, memoryStat["inactive_file"]), nil
+	}
+
+	cpuNsec, err := readUint(filepath.Join(s.root, "cpuacct", "cpuacct.usage"))
+	if err != nil {
+		return 0, 0, err
+	}
+	memory, err := readUint(filepath.Join(s.root, "memory", "memory.usage_in_bytes"))
+	if err != nil {
+		return 0, 0, err
+	}
+	memoryStat, _ := readStat(filepath.Join(s.root, "memory", "memory.stat"))
+	return cpuNsec / 1000, workingSet(memory, memoryStat["total_inactive_file"]), nil
+}
+
+// workingSet excludes the inactive page cache from the memory usage the same way as kubelet does
+func workingSet(usage, inactiveFile uint64) uint64 {
+	if inactiveFile > usage {
+		return 0
+	}
+	return usage - inactiveFile
+}
+
+func readUint(path string) (uint64, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return 0, err
+	}
+	return strconv.ParseUint(strings.TrimSpace(string(content)), 10, 64)
+}
+
+func readStat(path string) (map[string]uint64, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	stat := make(map[string]uint64)
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) != 2 {
+			continue
+		}
+		value, err := strconv.ParseUint(fields[1], 10, 64)
+		if err != nil {
+			continue
+		}
+		stat[fields[0]] = value
+	}
+	if len(stat) == 0 {
+		return nil, fmt.Errorf("no stat found in %s", path)
+	}
+	return stat, scanner.Err()
+}
+
+const (
+	// MinRecommendSamples is the least number of runs needed to recommend the resource spec
+	MinRecommendSamples = 3
+
+	minCpu    = 100
+	minMemory = 128
+)
+
+// Percentile returns the p-th percentile of the values with the nearest-rank method
+func Percentile(values []int, p float64) int {
+	if len(values) == 0 {
+		return 0
+	}
+	sorted := append([]int(nil), values...)
+	sort.Ints(sorted)
+	rank := int(math.Ceil(p / 100 * float64(len(sorted))))
+	if rank < 1 {
+		rank = 1
+	}
+	if rank > len(sorted) {
+		rank = len(sorted)
+	}
+	return sorted[rank-1]
+}
+
+// Recommend sizes the job pod from the p95 of the usages of recent runs:
+// requests cover the p95 of the average cpu and the peak memory, and limits leave
+// headroom above the p95 of the peaks so that the job is neither throttled nor OOM killed.
+// ok is false if there are not enough runs to recommend.
+func Recommend(usages []*Usage) (spec setting.RequestSpec, ok bool) {
+	if len(usages) < MinRecommendSamples {
+		return spec, false
+	}
+
+	cpuAvg, cpuPeak, memoryPeak := make([]int, 0, len(usages)), make([]int, 0, len(usages)), make([]int, 0, len(usages))
+	for _, usage := range usages {
+		memoryPeak = append(memoryPeak, usage.MemoryPeak)
+		if usage.OOMKilled {
+			continue
+		}
+		cpuAvg = append(cpuAvg, usage.CpuAvg)
+		cpuPeak = append(cpuPeak, usage.CpuPeak)
+	}
+
+	spec.CpuReq = roundUp(Percentile(cpuAvg, 95), 100, minCpu)
+	spec.CpuLimit = roundUp(Percentile(cpuPeak, 95)*5/4, 100, spec.CpuReq)
+	spec.MemoryReq = roundUp(Percentile(memoryPeak, 95), 64, minMemory)
+	spec.MemoryLimit = roundUp(Percentile(memoryPeak, 95)*3/2, 64, spec.MemoryReq)
+	return spec, true
+}
+
+func roundUp(value, step, min int) int {
+	value = (value + step - 1) / step * step
+	if value < min {
+		return min
+	}
+	return value
+}
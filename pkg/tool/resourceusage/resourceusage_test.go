@@ -0,0 +1,121 @@
+/*
+Copyright 2025 The KodeRover Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package resourceusage
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/koderover/zadig/v2/pkg/setting"
+)
+
+func TestSamplerCgroupV2(t *testing.T) {
+	ast := require.New(t)
+
+	root := t.TempDir()
+	write := func(name, content string) {
+		ast.NoError(os.WriteFile(filepath.Join(root, name), []byte(content), 0644))
+	}
+	write("cgroup.controllers", "cpu memory")
+	write("cpu.stat", "usage_usec 1000000\nuser_usec 800000\n")
+	write("memory.current", "805306368\n")
+	write("memory.stat", "anon 268435456\ninactive_file 268435456\n")
+
+	s := newSampler(root, time.Second)
+	start := time.Now()
+	s.sample(start)
+
+	write("cpu.stat", "usage_usec 3000000\n")
+	write("memory.current", "1342177280\n")
+	s.sample(start.Add(time.Second))
+
+	usage := s.Usage()
+	ast.NotNil(usage)
+	ast.Equal(2, usage.Samples)
+	ast.Equal(2000, usage.CpuPeak)
+	ast.Equal(2000, usage.CpuAvg)
+	ast.Equal(1024, usage.MemoryPeak)
+	ast.Equal(768, usage.MemoryAvg)
+}
+
+func TestSamplerSameTime(t *testing.T) {
+	ast := require.New(t)
+
+	root := t.TempDir()
+	write := func(name, content string) {
+		ast.NoError(os.WriteFile(filepath.Join(root, name), []byte(content), 0644))
+	}
+	write("cgroup.controllers", "cpu memory")
+	write("cpu.stat", "usage_usec 1000000\n")
+	write("memory.current", "268435456\n")
+
+	s := newSampler(root, time.Second)
+	start := time.Now()
+	s.sample(start)
+
+	write("cpu.stat", "usage_usec 2000000\n")
+	s.sample(start)
+	usage := s.Usage()
+	ast.NotNil(usage)
+	ast.Equal(2, usage.Samples)
+	ast.Equal(0, usage.CpuPeak)
+
+	write("cpu.stat", "usage_usec 3000000\n")
+	s.sample(start.Add(time.Second))
+	usage = s.Usage()
+	ast.Equal(3, usage.Samples)
+	ast.Equal(2000, usage.CpuPeak)
+	ast.Equal(2000, usage.CpuAvg)
+}
+
+func TestSamplerNoCgroup(t *testing.T) {
+	ast := require.New(t)
+
+	s := newSampler(t.TempDir(), time.Second)
+	s.sample(time.Now())
+	ast.Nil(s.Usage())
+}
+
+func TestRecommend(t *testing.T) {
+	ast := require.New(t)
+
+	_, ok := Recommend([]*Usage{{CpuPeak: 1000}})
+	ast.False(ok)
+
+	usages := []*Usage{
+		{CpuAvg: 450, CpuPeak: 1500, MemoryPeak: 900},
+		{CpuAvg: 500, CpuPeak: 1600, MemoryPeak: 1000},
+		{CpuAvg: 30, CpuPeak: 200, MemoryPeak: 50},
+	}
+	spec, ok := Recommend(usages)
+	ast.True(ok)
+	ast.Equal(setting.RequestSpec{CpuReq: 500, CpuLimit: 2000, MemoryReq: 1024, MemoryLimit: 1536}, spec)
+
+	// the cpu of OOM killed runs is unknown, their memory is taken at the limit
+	usages = append(usages[:2], &Usage{MemoryPeak: 2048, MemoryAvg: 2048, OOMKilled: true})
+	spec, ok = Recommend(usages)
+	ast.True(ok)
+	ast.Equal(setting.RequestSpec{CpuReq: 500, CpuLimit: 2000, MemoryReq: 2048, MemoryLimit: 3072}, spec)
+
+	spec, ok = Recommend([]*Usage{{}, {}, {}})
+	ast.True(ok)
+	ast.Equal(setting.RequestSpec{CpuReq: 100, CpuLimit: 100, MemoryReq: 128, MemoryLimit: 128}, spec)
+}
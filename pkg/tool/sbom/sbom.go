@@ -0,0 +1,163 @@
+/*
+Copyright 2025 The KodeRover Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package sbom reads the component list out of the SPDX and CycloneDX documents produced by the build jobs.
+package sbom
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+type Format string
+
+const (
+	FormatCycloneDX Format = "cyclonedx"
+	FormatSPDX      Format = "spdx-json"
+)
+
+// Component is a package found in an image, PURL is the package url like pkg:maven/org.apache.logging.log4j/log4j-core@2.14.1
+type Component struct {
+	Name    string `bson:"name"       json:"name"       yaml:"name"`
+	Version string `bson:"version"    json:"version"    yaml:"version"`
+	Type    string `bson:"type"       json:"type"       yaml:"type"`
+	PURL    string `bson:"purl"       json:"purl"       yaml:"purl"`
+}
+
+func (f Format) Valid() bool {
+	return f == FormatCycloneDX || f == FormatSPDX
+}
+
+// FileExtension is used to name the archived sbom document.
+func (f Format) FileExtension() string {
+	if f == FormatSPDX {
+		return ".spdx.json"
+	}
+	return ".cdx.json"
+}
+
+type cycloneDXDocument struct {
+	BOMFormat  string `json:"bomFormat"`
+	Components []struct {
+		Name    string `json:"name"`
+		Group   string `json:"group"`
+		Version string `json:"version"`
+		Type    string `json:"type"`
+		PURL    string `json:"purl"`
+	} `json:"components"`
+}
+
+type spdxDocument struct {
+	SPDXVersion string `json:"spdxVersion"`
+	Packages    []struct {
+		Name           string `json:"name"`
+		VersionInfo    string `json:"versionInfo"`
+		PrimaryPurpose string `json:"primaryPackagePurpose"`
+		ExternalRefs   []struct {
+			ReferenceCategory string `json:"referenceCategory"`
+			ReferenceType     string `json:"referenceType"`
+			ReferenceLocator  string `json:"referenceLocator"`
+		} `json:"externalRefs"`
+	} `json:"packages"`
+}
+
+// Parse returns the components listed in the sbom document, duplicated components are merged.
+func Parse(format Format, data []byte) ([]*Component, error) {
+	resp := make([]*Component, 0)
+	switch format {
+	case FormatCycloneDX:
+		doc := &cycloneDXDocument{}
+		if err := json.Unmarshal(data, doc); err != nil {
+			return nil, fmt.Errorf("invalid cyclonedx document: %s", err)
+		}
+		if doc.BOMFormat != "CycloneDX" {
+			return nil, fmt.Errorf("invalid cyclonedx document: unexpected bomFormat %q", doc.BOMFormat)
+		}
+		for _, c := range doc.Components {
+			name := c.Name
+			if c.Group != "" {
+				name = c.Group + "/" + c.Name
+			}
+			resp = append(resp, &Component{Name: name, Version: c.Version, Type: c.Type, PURL: c.PURL})
+		}
+	case FormatSPDX:
+		doc := &spdxDocument{}
+		if err := json.Unmarshal(data, doc); err != nil {
+			return nil, fmt.Errorf("invalid spdx document: %s", err)
+		}
+		if !strings.HasPrefix(doc.SPDXVersion, "SPDX-") {
+			return nil, fmt.Errorf("invalid spdx document: unexpected spdxVersion %q", doc.SPDXVersion)
+		}
+		for _, p := range doc.Packages {
+			c := &Component{Name: p.Name, Version: p.VersionInfo, Type: strings.ToLower(p.PrimaryPurpose)}
+			for _, ref := range p.ExternalRefs {
+				if ref.ReferenceType == "purl" {
+					c.PURL = ref.ReferenceLocator
+					break
+				}
+			}
+			resp = append(resp, c)
+		}
+	default:
+		return nil, fmt.Errorf("unsupported sbom format: %s", format)
+	}
+	return dedup(resp), nil
+}
+
+// Match reports whether the component is the one asked for. The name matches either the component name,
+// the last segment of a grouped name or the package name in the purl, and an empty version matches any version.
+func (c *Component) Match(name, version string) bool {
+	if version != "" && c.Version != version {
+		return false
+	}
+	if strings.EqualFold(c.Name, name) {
+		return true
+	}
+	if idx := strings.LastIndex(c.Name, "/"); idx != -1 && strings.EqualFold(c.Name[idx+1:], name) {
+		return true
+	}
+	return strings.EqualFold(purlName(c.PURL), name)
+}
+
+func purlName(purl string) string {
+	if purl == "" {
+		return ""
+	}
+	purl = strings.SplitN(purl, "?", 2)[0]
+	purl = strings.SplitN(purl, "#", 2)[0]
+	if idx := strings.LastIndex(purl, "@"); idx != -1 {
+		purl = purl[:idx]
+	}
+	if idx := strings.LastIndex(purl, "/"); idx != -1 {
+		purl = purl[idx+1:]
+	}
+	return purl
+}
+
+func dedup(components []*Component) []*Component {
+	resp := make([]*Component, 0, len(components))
+	seen := make(map[string]bool)
+	for _, c := range components {
+		key := c.Name + "@" + c.Version + "@" + c.PURL
+		if seen[key] {
+			continue
+		}
+		seen[key] = true
+		resp = append(resp, c)
+	}
+	return resp
+}
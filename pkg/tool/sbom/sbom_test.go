@@ -0,0 +1,65 @@
+/*
+Copyright 2025 The KodeRover Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package sbom
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+const cycloneDXDoc = `{
+  "bomFormat": "CycloneDX",
+  "specVersion": "1.5",
+  "components": [
+    {"type": "library", "group": "org.apache.logging.log4j", "name": "log4j-core", "version": "2.14.1", "purl": "pkg:maven/org.apache.logging.log4j/log4j-core@2.14.1"},
+    {"type": "library", "name": "openssl", "version": "3.0.2", "purl": "pkg:deb/ubuntu/openssl@3.0.2?arch=amd64"},
+    {"type": "library", "name": "openssl", "version": "3.0.2", "purl": "pkg:deb/ubuntu/openssl@3.0.2?arch=amd64"}
+  ]
+}`
+
+const spdxDoc = `{
+  "spdxVersion": "SPDX-2.3",
+  "packages": [
+    {"name": "log4j-core", "versionInfo": "2.17.1", "primaryPackagePurpose": "LIBRARY",
+     "externalRefs": [{"referenceCategory": "PACKAGE-MANAGER", "referenceType": "purl", "referenceLocator": "pkg:maven/org.apache.logging.log4j/log4j-core@2.17.1"}]}
+  ]
+}`
+
+func TestParse(t *testing.T) {
+	ast := require.New(t)
+
+	components, err := Parse(FormatCycloneDX, []byte(cycloneDXDoc))
+	ast.NoError(err)
+	ast.Len(components, 2)
+	ast.Equal("org.apache.logging.log4j/log4j-core", components[0].Name)
+	ast.True(components[0].Match("log4j-core", "2.14.1"))
+	ast.True(components[0].Match("log4j-core", ""))
+	ast.False(components[0].Match("log4j-core", "2.17.1"))
+	ast.True(components[1].Match("OpenSSL", "3.0.2"))
+
+	components, err = Parse(FormatSPDX, []byte(spdxDoc))
+	ast.NoError(err)
+	ast.Len(components, 1)
+	ast.Equal("library", components[0].Type)
+	ast.True(components[0].Match("log4j-core", "2.17.1"))
+
+	_, err = Parse(FormatSPDX, []byte(cycloneDXDoc))
+	ast.Error(err)
+	_, err = Parse("syft-json", []byte(cycloneDXDoc))
+	ast.Error(err)
+}
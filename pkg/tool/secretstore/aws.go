@@ -0,0 +1,80 @@
+/*
+Copyright 2025 The KodeRover Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package secretstore
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/credentials"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/secretsmanager"
+)
+
+type AWSSecretsManagerConfig struct {
+	Region string
+	// AccessKeyID and SecretAccessKey are optional, the default credential chain is used when they are empty
+	AccessKeyID     string
+	SecretAccessKey string
+}
+
+type awsSecretsManagerStore struct {
+	client *secretsmanager.SecretsManager
+}
+
+// NewAWSSecretsManagerStore reads secrets from AWS Secrets Manager, the reference is the secret name or ARN and
+// the field picks a key of a JSON secret, e.g. prod/db#password.
+func NewAWSSecretsManagerStore(config *AWSSecretsManagerConfig) (Store, error) {
+	if config == nil || config.Region == "" {
+		return nil, fmt.Errorf("aws region is required")
+	}
+	awsConfig := aws.NewConfig().WithRegion(config.Region)
+	if config.AccessKeyID != "" {
+		awsConfig = awsConfig.WithCredentials(credentials.NewStaticCredentials(config.AccessKeyID, config.SecretAccessKey, ""))
+	}
+	sess, err := session.NewSession(awsConfig)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create aws session: %s", err)
+	}
+	return &awsSecretsManagerStore{client: secretsmanager.New(sess)}, nil
+}
+
+func (s *awsSecretsManagerStore) Get(ctx context.Context, ref string) (string, error) {
+	secretID, field := ParseReference(ref)
+	if secretID == "" {
+		return "", fmt.Errorf("empty aws secret id")
+	}
+	output, err := s.client.GetSecretValueWithContext(ctx, &secretsmanager.GetSecretValueInput{SecretId: aws.String(secretID)})
+	if err != nil {
+		return "", fmt.Errorf("failed to read aws secret %s: %s", secretID, err)
+	}
+	value := aws.StringValue(output.SecretString)
+	if output.SecretString == nil {
+		value = string(output.SecretBinary)
+	}
+	if field == "" {
+		return value, nil
+	}
+
+	data := make(map[string]interface{})
+	if err := json.Unmarshal([]byte(value), &data); err != nil {
+		return "", fmt.Errorf("aws secret %s is not a JSON object, field %s can't be read", secretID, field)
+	}
+	return pickField(data, field, ref)
+}
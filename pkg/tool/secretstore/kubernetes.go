@@ -0,0 +1,64 @@
+/*
+Copyright 2025 The KodeRover Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package secretstore
+
+import (
+	"context"
+	"fmt"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+)
+
+type kubernetesStore struct {
+	client    kubernetes.Interface
+	namespace string
+}
+
+// NewKubernetesStore reads secrets from kubernetes secrets in the namespace, the reference is the secret name
+// and the field is the data key, e.g. db-credentials#password.
+func NewKubernetesStore(client kubernetes.Interface, namespace string) (Store, error) {
+	if client == nil || namespace == "" {
+		return nil, fmt.Errorf("kubernetes client and namespace are required")
+	}
+	return &kubernetesStore{client: client, namespace: namespace}, nil
+}
+
+func (s *kubernetesStore) Get(ctx context.Context, ref string) (string, error) {
+	name, field := ParseReference(ref)
+	if name == "" {
+		return "", fmt.Errorf("empty kubernetes secret name")
+	}
+	secret, err := s.client.CoreV1().Secrets(s.namespace).Get(ctx, name, metav1.GetOptions{})
+	if err != nil {
+		return "", fmt.Errorf("failed to read kubernetes secret %s/%s: %s", s.namespace, name, err)
+	}
+
+	if field == "" {
+		if len(secret.Data) != 1 {
+			return "", fmt.Errorf("secret %s has %d fields, specify one with #field", ref, len(secret.Data))
+		}
+		for _, v := range secret.Data {
+			return string(v), nil
+		}
+	}
+	v, ok := secret.Data[field]
+	if !ok {
+		return "", fmt.Errorf("field %s not found in secret %s", field, ref)
+	}
+	return string(v), nil
+}
@@ -0,0 +1,65 @@
+/*
+Copyright 2025 The KodeRover Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package secretstore reads secrets out of the external stores backing the key vault.
+package secretstore
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+// Store returns the secret identified by the reference. A reference is the location of the secret in the store,
+// optionally followed by #field to pick one field when the secret holds several key value pairs.
+type Store interface {
+	Get(ctx context.Context, ref string) (string, error)
+}
+
+// ParseReference splits the reference into the secret location and the field.
+func ParseReference(ref string) (string, string) {
+	location, field, _ := strings.Cut(ref, "#")
+	return strings.Trim(location, "/"), field
+}
+
+// pickField returns the field of the secret, the only value is returned when no field is given.
+func pickField(data map[string]interface{}, field, ref string) (string, error) {
+	if field == "" {
+		if len(data) != 1 {
+			return "", fmt.Errorf("secret %s has %d fields, specify one with #field", ref, len(data))
+		}
+		for _, v := range data {
+			return stringify(v)
+		}
+	}
+	v, ok := data[field]
+	if !ok {
+		return "", fmt.Errorf("field %s not found in secret %s", field, ref)
+	}
+	return stringify(v)
+}
+
+func stringify(v interface{}) (string, error) {
+	if s, ok := v.(string); ok {
+		return s, nil
+	}
+	b, err := json.Marshal(v)
+	if err != nil {
+		return "", err
+	}
+	return string(b), nil
+}
@@ -0,0 +1,95 @@
+/*
+Copyright 2025 The KodeRover Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package secretstore
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func TestVaultStore(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("X-Vault-Token") != "root" {
+			w.WriteHeader(http.StatusForbidden)
+			_, _ = w.Write([]byte(`{"errors":["permission denied"]}`))
+			return
+		}
+		switch r.URL.Path {
+		case "/v1/kv/data/app/prod":
+			_, _ = w.Write([]byte(`{"data":{"data":{"password":"p=ss","port":5432},"metadata":{"version":1}}}`))
+		case "/v1/kv/data/app/single":
+			_, _ = w.Write([]byte(`{"data":{"data":{"token":"abc"}}}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+			_, _ = w.Write([]byte(`{"errors":[]}`))
+		}
+	}))
+	defer server.Close()
+
+	store, err := NewVaultStore(&VaultConfig{Address: server.URL, Token: "root", Mount: "kv"})
+	require.NoError(t, err)
+
+	value, err := store.Get(context.Background(), "app/prod#password")
+	require.NoError(t, err)
+	require.Equal(t, "p=ss", value)
+
+	value, err = store.Get(context.Background(), "app/prod#port")
+	require.NoError(t, err)
+	require.Equal(t, "5432", value)
+
+	value, err = store.Get(context.Background(), "/app/single")
+	require.NoError(t, err)
+	require.Equal(t, "abc", value)
+
+	_, err = store.Get(context.Background(), "app/prod")
+	require.Error(t, err)
+	_, err = store.Get(context.Background(), "app/prod#user")
+	require.Error(t, err)
+	_, err = store.Get(context.Background(), "app/missing#password")
+	require.Error(t, err)
+
+	store, err = NewVaultStore(&VaultConfig{Address: server.URL, Token: "wrong", Mount: "kv"})
+	require.NoError(t, err)
+	_, err = store.Get(context.Background(), "app/prod#password")
+	require.ErrorContains(t, err, "permission denied")
+}
+
+func TestKubernetesStore(t *testing.T) {
+	client := fake.NewSimpleClientset(&corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "db", Namespace: "zadig-secrets"},
+		Data:       map[string][]byte{"password": []byte("secret"), "user": []byte("admin")},
+	})
+
+	store, err := NewKubernetesStore(client, "zadig-secrets")
+	require.NoError(t, err)
+
+	value, err := store.Get(context.Background(), "db#user")
+	require.NoError(t, err)
+	require.Equal(t, "admin", value)
+
+	_, err = store.Get(context.Background(), "db")
+	require.Error(t, err)
+	_, err = store.Get(context.Background(), "missing#user")
+	require.Error(t, err)
+}
@@ -0,0 +1,99 @@
+/*
+Copyright 2025 The KodeRover Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package secretstore
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+)
+
+const defaultVaultMount = "secret"
+
+type VaultConfig struct {
+	Address string
+	Token   string
+	// Namespace is only used by Vault Enterprise
+	Namespace string
+	// Mount is the path the KV v2 engine is mounted at, secret by default
+	Mount string
+}
+
+type vaultStore struct {
+	config *VaultConfig
+	client *http.Client
+}
+
+// NewVaultStore reads secrets from a HashiCorp Vault KV v2 engine, the reference is the path of the secret
+// under the mount, e.g. app/prod#password.
+func NewVaultStore(config *VaultConfig) (Store, error) {
+	if config == nil || config.Address == "" || config.Token == "" {
+		return nil, fmt.Errorf("vault address and token are required")
+	}
+	return &vaultStore{config: config, client: &http.Client{Timeout: 10 * time.Second}}, nil
+}
+
+type vaultKVResponse struct {
+	Data struct {
+		Data map[string]interface{} `json:"data"`
+	} `json:"data"`
+	Errors []string `json:"errors"`
+}
+
+func (s *vaultStore) Get(ctx context.Context, ref string) (string, error) {
+	path, field := ParseReference(ref)
+	if path == "" {
+		return "", fmt.Errorf("empty vault secret path")
+	}
+	mount := strings.Trim(s.config.Mount, "/")
+	if mount == "" {
+		mount = defaultVaultMount
+	}
+
+	url := fmt.Sprintf("%s/v1/%s/data/%s", strings.TrimRight(s.config.Address, "/"), mount, path)
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return "", err
+	}
+	req.Header.Set("X-Vault-Token", s.config.Token)
+	if s.config.Namespace != "" {
+		req.Header.Set("X-Vault-Namespace", s.config.Namespace)
+	}
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return "", fmt.Errorf("failed to read vault secret %s: %s", path, err)
+	}
+	defer resp.Body.Close()
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return "", err
+	}
+
+	result := &vaultKVResponse{}
+	if err := json.Unmarshal(body, result); err != nil && resp.StatusCode == http.StatusOK {
+		return "", fmt.Errorf("invalid vault response: %s", err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("failed to read vault secret %s: status %d %s", path, resp.StatusCode, strings.Join(result.Errors, "; "))
+	}
+	return pickField(result.Data.Data, field, ref)
+}
@@ -0,0 +1,249 @@
+/*
+Copyright 2025 The KodeRover Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tracing
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/trace"
+	"go.opentelemetry.io/otel/trace/noop"
+)
+
+const (
+	// EnvOTLPEndpoint is the standard otel env, tracing is disabled when it is empty
+	EnvOTLPEndpoint = "OTEL_EXPORTER_OTLP_ENDPOINT"
+	// EnvTraceParent carries the w3c trace context from aslan to the job executors
+	EnvTraceParent = "TRACEPARENT"
+
+	tracerName = "github.com/koderover/zadig"
+)
+
+const (
+	AttrProject     = attribute.Key("zadig.project")
+	AttrWorkflow    = attribute.Key("zadig.workflow")
+	AttrTaskID      = attribute.Key("zadig.task_id")
+	AttrStage       = attribute.Key("zadig.stage")
+	AttrJob         = attribute.Key("zadig.job")
+	AttrJobType     = attribute.Key("zadig.job_type")
+	AttrStep        = attribute.Key("zadig.step")
+	AttrStepType    = attribute.Key("zadig.step_type")
+	AttrService     = attribute.Key("zadig.service")
+	AttrCluster     = attribute.Key("zadig.cluster")
+	AttrInfra       = attribute.Key("zadig.infrastructure")
+	AttrStatus      = attribute.Key("zadig.status")
+	AttrTaskCreator = attribute.Key("zadig.task_creator")
+)
+
+var propagator = propagation.NewCompositeTextMapPropagator(propagation.TraceContext{}, propagation.Baggage{})
+
+// Init installs an otlp/http tracer provider exporting to OTEL_EXPORTER_OTLP_ENDPOINT as the global one,
+// other options of the exporter are read from the standard OTEL_EXPORTER_OTLP_* envs.
+// It does nothing if no endpoint is configured. The returned function flushes the pending spans
+// and must be called before the process exits.
+func Init(serviceName string) (func(context.Context) error, error) {
+	otel.SetTextMapPropagator(propagator)
+	if os.Getenv(EnvOTLPEndpoint) == "" {
+		return func(context.Context) error { return nil }, nil
+	}
+
+	provider, err := newProvider(serviceName, "")
+	if err != nil {
+		return nil, err
+	}
+	otel.SetTracerProvider(provider)
+	enabled = true
+
+	return provider.Shutdown, nil
+}
+
+// Enabled reports whether the global tracer provider exports spans.
+func Enabled() bool {
+	return enabled
+}
+
+// Endpoint returns the otlp endpoint which the job executors should export their spans to.
+func Endpoint() string {
+	if !enabled {
+		return ""
+	}
+	return os.Getenv(EnvOTLPEndpoint)
+}
+
+var enabled bool
+
+func newProvider(serviceName, endpoint string) (*sdktrace.TracerProvider, error) {
+	opts := []otlptracehttp.Option{}
+	if endpoint != "" {
+		// same as OTEL_EXPORTER_OTLP_ENDPOINT, the endpoint is the base url of the collector
+		opts = append(opts, otlptracehttp.WithEndpointURL(strings.TrimSuffix(endpoint, "/")+"/v1/traces"))
+	}
+	exporter, err := otlptracehttp.New(context.Background(), opts...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create otlp trace exporter: %s", err)
+	}
+
+	return sdktrace.NewTracerProvider(
+		sdktrace.WithBatcher(exporter),
+		sdktrace.WithIDGenerator(&idGenerator{}),
+		sdktrace.WithResource(resource.NewSchemaless(attribute.String("service.name", serviceName))),
+	), nil
+}
+
+// Tracer reports live spans of a single job, it is used by the job executors which get the
+// endpoint and the parent span from the job context instead of the process envs.
+type Tracer struct {
+	tracer   trace.Tracer
+	shutdown func(context.Context) error
+}
+
+// NewTracer returns a tracer exporting to the given endpoint, or a noop one if the endpoint is empty.
+func NewTracer(serviceName, endpoint string) (*Tracer, error) {
+	if endpoint == "" {
+		return &Tracer{
+			tracer:   noop.NewTracerProvider().Tracer(tracerName),
+			shutdown: func(context.Context) error { return nil },
+		}, nil
+	}
+
+	provider, err := newProvider(serviceName, endpoint)
+	if err != nil {
+		return nil, err
+	}
+	return &Tracer{
+		tracer:   provider.Tracer(tracerName),
+		shutdown: provider.Shutdown,
+	}, nil
+}
+
+// StartSpan starts a live span as a child of the span in ctx.
+func (t *Tracer) StartSpan(ctx context.Context, name string, attrs ...attribute.KeyValue) (context.Context, trace.Span) {
+	return t.tracer.Start(ctx, name, trace.WithAttributes(attrs...))
+}
+
+// Shutdown flushes the pending spans, it should be called when the job finishes.
+func (t *Tracer) Shutdown(ctx context.Context) error {
+	return t.shutdown(ctx)
+}
+
+// TaskTraceID returns the trace id of a workflow task. It is derived from the task identity so that every
+// component, and aslan itself after a restart, attaches its spans to the same trace.
+func TaskTraceID(workflowName string, taskID int64) trace.TraceID {
+	var id trace.TraceID
+	sum := sha256.Sum256([]byte(fmt.Sprintf("%s/%d", workflowName, taskID)))
+	copy(id[:], sum[:])
+	return id
+}
+
+// SpanID returns a stable span id for the given parts, e.g. workflow name, task id and job name.
+func SpanID(parts ...string) trace.SpanID {
+	var id trace.SpanID
+	sum := sha256.Sum256([]byte(strings.Join(parts, "/")))
+	copy(id[:], sum[:])
+	return id
+}
+
+// TraceParent encodes the given span as a w3c traceparent header value.
+func TraceParent(traceID trace.TraceID, spanID trace.SpanID) string {
+	carrier := propagation.MapCarrier{}
+	propagation.TraceContext{}.Inject(trace.ContextWithRemoteSpanContext(context.Background(), trace.NewSpanContext(trace.SpanContextConfig{
+		TraceID:    traceID,
+		SpanID:     spanID,
+		TraceFlags: trace.FlagsSampled,
+		Remote:     true,
+	})), carrier)
+	return carrier.Get("traceparent")
+}
+
+// ContextFromTraceParent returns a context whose remote parent is the span encoded in traceParent.
+func ContextFromTraceParent(ctx context.Context, traceParent string) context.Context {
+	if traceParent == "" {
+		return ctx
+	}
+	return propagation.TraceContext{}.Extract(ctx, propagation.MapCarrier{"traceparent": traceParent})
+}
+
+// Span describes a span which has already finished, used to export the spans of a workflow task
+// from the timestamps recorded in it.
+type Span struct {
+	Name       string
+	TraceID    trace.TraceID
+	SpanID     trace.SpanID
+	Start      time.Time
+	End        time.Time
+	Failed     bool
+	Message    string
+	Attributes []attribute.KeyValue
+}
+
+// Record exports the span as a child of the span in ctx and returns a context containing it.
+func Record(ctx context.Context, span *Span) context.Context {
+	_, s := otel.Tracer(tracerName).Start(context.WithValue(ctx, idsKey{}, span), span.Name, trace.WithTimestamp(span.Start), trace.WithAttributes(span.Attributes...))
+	if span.Failed {
+		s.SetStatus(codes.Error, span.Message)
+	} else {
+		s.SetStatus(codes.Ok, "")
+	}
+	s.End(trace.WithTimestamp(span.End))
+	return trace.ContextWithSpan(ctx, s)
+}
+
+// EndSpan ends the span, marking it as failed if err is not nil.
+func EndSpan(span trace.Span, err error) {
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	} else {
+		span.SetStatus(codes.Ok, "")
+	}
+	span.End()
+}
+
+type idsKey struct{}
+
+// idGenerator uses the ids given by Record if there are any, otherwise random ones.
+type idGenerator struct{}
+
+func (g *idGenerator) NewIDs(ctx context.Context) (trace.TraceID, trace.SpanID) {
+	if span, ok := ctx.Value(idsKey{}).(*Span); ok && span.TraceID.IsValid() && span.SpanID.IsValid() {
+		return span.TraceID, span.SpanID
+	}
+	var traceID trace.TraceID
+	_, _ = rand.Read(traceID[:])
+	return traceID, g.NewSpanID(ctx, traceID)
+}
+
+func (g *idGenerator) NewSpanID(ctx context.Context, traceID trace.TraceID) trace.SpanID {
+	if span, ok := ctx.Value(idsKey{}).(*Span); ok && span.SpanID.IsValid() {
+		return span.SpanID
+	}
+	var spanID trace.SpanID
+	_, _ = rand.Read(spanID[:])
+	return spanID
+}
@@ -0,0 +1,84 @@
+/*
+Copyright 2025 The KodeRover Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tracing
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/trace"
+)
+
+func TestTaskTraceID(t *testing.T) {
+	ast := require.New(t)
+
+	id := TaskTraceID("workflow", 1)
+	ast.True(id.IsValid())
+	ast.Equal(id, TaskTraceID("workflow", 1))
+	ast.NotEqual(id, TaskTraceID("workflow", 2))
+	ast.NotEqual(id, TaskTraceID("workflow-1", 1))
+}
+
+func TestSpanID(t *testing.T) {
+	ast := require.New(t)
+
+	id := SpanID("workflow", "1", "job")
+	ast.True(id.IsValid())
+	ast.Equal(id, SpanID("workflow", "1", "job"))
+	ast.NotEqual(id, SpanID("workflow", "1", "other-job"))
+	ast.NotEqual(id, SpanID("workflow", "1"))
+}
+
+func TestTraceParent(t *testing.T) {
+	tests := []struct {
+		name        string
+		traceParent string
+		wantValid   bool
+	}{
+		{
+			name:        "round trip",
+			traceParent: TraceParent(TaskTraceID("workflow", 1), SpanID("workflow", "1", "job")),
+			wantValid:   true,
+		},
+		{
+			name:        "empty traceparent",
+			traceParent: "",
+			wantValid:   false,
+		},
+		{
+			name:        "malformed traceparent",
+			traceParent: "00-not-a-trace-01",
+			wantValid:   false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ast := require.New(t)
+
+			sc := trace.SpanContextFromContext(ContextFromTraceParent(context.Background(), tt.traceParent))
+			ast.Equal(tt.wantValid, sc.IsValid())
+			if !tt.wantValid {
+				return
+			}
+			ast.True(sc.IsRemote())
+			ast.True(sc.IsSampled())
+			ast.Equal(TaskTraceID("workflow", 1), sc.TraceID())
+			ast.Equal(SpanID("workflow", "1", "job"), sc.SpanID())
+		})
+	}
+}
@@ -26,6 +26,8 @@ const (
 const (
 	JobResultKey  = "job-result"
 	JobOutputsKey = "job-outputs"
+	// JobResourceUsageKey stores the cpu and memory usage of the job container sampled by the executor
+	JobResourceUsageKey = "job-resource-usage"
 
 	JobDebugStatusKey    = "job-debug-status"
 	JobDebugStatusBefore = "before"
@@ -28,6 +28,9 @@ const IstioLabelValueInjection = "enabled"
 
 var OriginSpec = fmt.Sprintf("%s/origin", ZadigDomain)
 
+// ZadigLabelKeyMock is put on the resources of the mock responders, the value is the name of the mocked service
+var ZadigLabelKeyMock = fmt.Sprintf("%s/mock", ZadigDomain)
+
 const (
 	ZadigReleaseVersionLabelKey     = "zadigx-release-version"
 	ZadigReleaseTypeLabelKey        = "zadigx-release-type"
@@ -0,0 +1,110 @@
+/*
+Copyright 2025 The KodeRover Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package types
+
+type MockProtocol string
+
+const (
+	MockProtocolHTTP MockProtocol = "http"
+	MockProtocolGRPC MockProtocol = "grpc"
+)
+
+const (
+	// MockAdminPort is the port the mock responder serves its request journal on
+	MockAdminPort = 8686
+	// MockConfigFileName is the file name of the MockConfig in the config map mounted to the mock responder
+	MockConfigFileName = "mock.json"
+	// MockJournalPath is the path of the request journal on the admin port, GET lists the requests and DELETE clears them
+	MockJournalPath = "/requests"
+	// MockJournalTruncatedHeader is set to true in the journal response if some of the requests asked for were dropped
+	// for exceeding the journal size
+	MockJournalTruncatedHeader = "X-Mock-Journal-Truncated"
+)
+
+// MockConfig is what the mock responder loads on startup
+type MockConfig struct {
+	Name     string       `json:"name"`
+	Protocol MockProtocol `json:"protocol"`
+	Port     int          `json:"port"`
+	// DescriptorSet is the base64 encoded FileDescriptorSet of the grpc services, including the imports
+	DescriptorSet string      `json:"descriptor_set,omitempty"`
+	Stubs         []*MockStub `json:"stubs"`
+	// JournalSize is the number of the latest requests kept in the journal
+	JournalSize int `json:"journal_size,omitempty"`
+}
+
+// MockStub is the response returned for the requests matching the stub, the stub with the highest priority wins
+// when several stubs match a request
+type MockStub struct {
+	Name     string        `bson:"name"           json:"name"           yaml:"name"`
+	Priority int           `bson:"priority"       json:"priority"       yaml:"priority"`
+	Request  *MockRequest  `bson:"request"        json:"request"        yaml:"request"`
+	Response *MockResponse `bson:"response"       json:"response"       yaml:"response"`
+}
+
+// MockRequest matches the requests received by a mock, empty fields match anything
+type MockRequest struct {
+	// Method is the http method, or the full method name like /helloworld.Greeter/SayHello for grpc
+	Method string `bson:"method"         json:"method"         yaml:"method"`
+	// Path is matched as a regular expression if PathRegex is set, it is ignored for grpc
+	Path      string `bson:"path"           json:"path"           yaml:"path"`
+	PathRegex bool   `bson:"path_regex"     json:"path_regex"     yaml:"path_regex"`
+	// Headers are the http headers or the grpc metadata, the values are compared exactly
+	Headers map[string]string `bson:"headers"        json:"headers"        yaml:"headers"`
+	Query   map[string]string `bson:"query"          json:"query"          yaml:"query"`
+	// BodyContains is a substring of the body, the body of grpc requests is the json form of the request message
+	BodyContains string `bson:"body_contains"  json:"body_contains"  yaml:"body_contains"`
+	// BodyJSON is a json document which the body must be a superset of
+	BodyJSON string `bson:"body_json"      json:"body_json"      yaml:"body_json"`
+}
+
+type MockResponse struct {
+	// Status is the http status code, or the grpc status code for grpc
+	Status  int               `bson:"status"         json:"status"         yaml:"status"`
+	Headers map[string]string `bson:"headers"        json:"headers"        yaml:"headers"`
+	// Body is the http response body, or the json form of the response message for grpc
+	Body string `bson:"body"           json:"body"           yaml:"body"`
+	// Message is the grpc status message, it is only used when the status is not OK
+	Message string `bson:"message"        json:"message"        yaml:"message"`
+	// Delay is in milliseconds
+	Delay int `bson:"delay"          json:"delay"          yaml:"delay"`
+}
+
+// MockJournalEntry is a request received by the mock responder
+type MockJournalEntry struct {
+	// Time is the unix time in milliseconds
+	Time    int64             `json:"time"`
+	Method  string            `json:"method"`
+	Path    string            `json:"path"`
+	Query   map[string]string `json:"query,omitempty"`
+	Headers map[string]string `json:"headers,omitempty"`
+	Body    string            `json:"body"`
+	// Stub is the name of the matched stub, empty if no stub matched the request
+	Stub   string `json:"stub"`
+	Status int    `json:"status"`
+}
+
+// MockAssertion asserts the number of the requests matching the request matcher that a mock received during a testing
+type MockAssertion struct {
+	// EnvName could refer to a variable of the testing like $ENV_NAME
+	EnvName     string       `bson:"env_name"       json:"env_name"       yaml:"env_name"`
+	ServiceName string       `bson:"service_name"   json:"service_name"   yaml:"service_name"`
+	Request     *MockRequest `bson:"request"        json:"request"        yaml:"request"`
+	MinCount    int          `bson:"min_count"      json:"min_count"      yaml:"min_count"`
+	// MaxCount is unlimited if not set
+	MaxCount *int `bson:"max_count"      json:"max_count"      yaml:"max_count"`
+}
@@ -18,6 +18,7 @@ package step
 
 import (
 	"fmt"
+	"strings"
 
 	"github.com/koderover/zadig/v2/pkg/setting"
 	"github.com/koderover/zadig/v2/pkg/types"
@@ -29,6 +30,8 @@ type StepDockerBuildSpec struct {
 	RegistryHost          string              `bson:"registry_host"                       json:"registry_host"                          yaml:"registry_host"`
 	EnableBuildkit        bool                `bson:"enable_buildkit"                     json:"enable_buildkit"                        yaml:"enable_buildkit"`
 	Platform              string              `bson:"platform"                            json:"platform"                               yaml:"platform"`
+	Platforms             []string            `bson:"platforms,omitempty"                 json:"platforms,omitempty"                    yaml:"platforms,omitempty"`
+	Backend               string              `bson:"backend,omitempty"                   json:"backend,omitempty"                      yaml:"backend,omitempty"`
 	BuildKitImage         string              `bson:"build_kit_image"                     json:"build_kit_image"                        yaml:"build_kit_image"`
 	DockerFile            string              `bson:"docker_file"                         json:"docker_file"                            yaml:"docker_file"`
 	ImageName             string              `bson:"image_name"                          json:"image_name"                             yaml:"image_name"`
@@ -59,3 +62,16 @@ func (s *StepDockerBuildSpec) GetDockerFile() string {
 	}
 	return s.DockerFile
 }
+
+// GetPlatform returns the platforms to build for in the form of the buildx --platform flag.
+func (s *StepDockerBuildSpec) GetPlatform() string {
+	if len(s.Platforms) > 0 {
+		return strings.Join(s.Platforms, ",")
+	}
+	return s.Platform
+}
+
+// UseBuildx reports whether the image is built with buildx, images of multiple platforms can only be built by it.
+func (s *StepDockerBuildSpec) UseBuildx() bool {
+	return s.EnableBuildkit || len(s.Platforms) > 1
+}
@@ -0,0 +1,107 @@
+/*
+Copyright 2025 The KodeRover Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package step
+
+import (
+	"fmt"
+	"strings"
+)
+
+const (
+	ImageScanSeverityCritical = "CRITICAL"
+	ImageScanSeverityHigh     = "HIGH"
+	ImageScanSeverityMedium   = "MEDIUM"
+	ImageScanSeverityLow      = "LOW"
+	ImageScanSeverityUnknown  = "UNKNOWN"
+)
+
+type StepImageScanSpec struct {
+	Registry  *RegistryNamespace `bson:"registry"                   json:"registry"                    yaml:"registry"`
+	Targets   []*ImageScanTarget `bson:"targets"                    json:"targets"                     yaml:"targets"`
+	Policy    *ImageScanPolicy   `bson:"policy"                     json:"policy"                      yaml:"policy"`
+	DestDir   string             `bson:"dest_dir"                   json:"dest_dir"                    yaml:"dest_dir"`
+	S3DestDir string             `bson:"s3_dest_dir"                json:"s3_dest_dir"                 yaml:"s3_dest_dir"`
+	FileName  string             `bson:"file_name"                  json:"file_name"                   yaml:"file_name"`
+	S3Storage *S3                `bson:"s3_storage"                 json:"s3_storage"                  yaml:"s3_storage"`
+}
+
+type ImageScanTarget struct {
+	ServiceName   string         `bson:"service_name"       json:"service_name"       yaml:"service_name"`
+	ServiceModule string         `bson:"service_module"     json:"service_module"     yaml:"service_module"`
+	Image         string         `bson:"image"              json:"image"              yaml:"image"`
+	Digest        string         `bson:"digest"             json:"digest"             yaml:"digest"`
+	Passed        bool           `bson:"passed"             json:"passed"             yaml:"passed"`
+	Summary       map[string]int `bson:"summary"            json:"summary"            yaml:"summary"`
+	Violations    []string       `bson:"violations"         json:"violations"         yaml:"violations"`
+}
+
+// ImageScanPolicy describes which findings fail the scan. With Severities = [CRITICAL] and
+// FixableOnly = true the policy reads "no CRITICAL vulnerability with a fix available".
+type ImageScanPolicy struct {
+	Severities  []string `bson:"severities"       json:"severities"       yaml:"severities"`
+	FixableOnly bool     `bson:"fixable_only"     json:"fixable_only"     yaml:"fixable_only"`
+}
+
+// ImageScanReport is the per image result written by the scan step and archived to s3.
+type ImageScanReport struct {
+	Image           string                `json:"image"`
+	Digest          string                `json:"digest"`
+	Vulnerabilities []*ImageVulnerability `json:"vulnerabilities"`
+}
+
+type ImageVulnerability struct {
+	ID               string `bson:"id"                 json:"id"`
+	PkgName          string `bson:"pkg_name"           json:"pkg_name"`
+	InstalledVersion string `bson:"installed_version"  json:"installed_version"`
+	FixedVersion     string `bson:"fixed_version"      json:"fixed_version"`
+	Severity         string `bson:"severity"           json:"severity"`
+	Title            string `bson:"title"              json:"title"`
+	PrimaryURL       string `bson:"primary_url"        json:"primary_url"`
+}
+
+// Summary counts the vulnerabilities of the report by severity.
+func (r *ImageScanReport) Summary() map[string]int {
+	summary := make(map[string]int)
+	for _, vuln := range r.Vulnerabilities {
+		summary[strings.ToUpper(vuln.Severity)]++
+	}
+	return summary
+}
+
+// Violations returns a description of every finding in the report which is not allowed by the policy.
+// A nil policy allows everything.
+func (p *ImageScanPolicy) Violations(report *ImageScanReport) []string {
+	if p == nil || len(p.Severities) == 0 {
+		return nil
+	}
+	severities := make(map[string]bool)
+	for _, severity := range p.Severities {
+		severities[strings.ToUpper(severity)] = true
+	}
+
+	resp := make([]string, 0)
+	for _, vuln := range report.Vulnerabilities {
+		if !severities[strings.ToUpper(vuln.Severity)] {
+			continue
+		}
+		if p.FixableOnly && vuln.FixedVersion == "" {
+			continue
+		}
+		resp = append(resp, fmt.Sprintf("%s %s in %s@%s", strings.ToUpper(vuln.Severity), vuln.ID, vuln.PkgName, vuln.InstalledVersion))
+	}
+	return resp
+}
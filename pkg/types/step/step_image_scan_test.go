@@ -0,0 +1,77 @@
+/*
+Copyright 2025 The KodeRover Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package step
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestImageScanPolicyViolations(t *testing.T) {
+	report := &ImageScanReport{
+		Image: "koderover/zadig:latest",
+		Vulnerabilities: []*ImageVulnerability{
+			{ID: "CVE-1", PkgName: "openssl", InstalledVersion: "1.0", FixedVersion: "1.1", Severity: "CRITICAL"},
+			{ID: "CVE-2", PkgName: "curl", InstalledVersion: "7.0", Severity: "critical"},
+			{ID: "CVE-3", PkgName: "zlib", InstalledVersion: "1.2", FixedVersion: "1.3", Severity: "HIGH"},
+			{ID: "CVE-4", PkgName: "bash", InstalledVersion: "5.0", FixedVersion: "5.1", Severity: "LOW"},
+		},
+	}
+
+	tests := []struct {
+		name   string
+		policy *ImageScanPolicy
+		want   []string
+	}{
+		{
+			name:   "nil policy",
+			policy: nil,
+			want:   nil,
+		},
+		{
+			name:   "empty policy",
+			policy: &ImageScanPolicy{},
+			want:   nil,
+		},
+		{
+			name:   "severities match case insensitively",
+			policy: &ImageScanPolicy{Severities: []string{"critical"}},
+			want:   []string{"CRITICAL CVE-1 in openssl@1.0", "CRITICAL CVE-2 in curl@7.0"},
+		},
+		{
+			name:   "multiple severities",
+			policy: &ImageScanPolicy{Severities: []string{ImageScanSeverityHigh, ImageScanSeverityLow}},
+			want:   []string{"HIGH CVE-3 in zlib@1.2", "LOW CVE-4 in bash@5.0"},
+		},
+		{
+			name:   "fixable only",
+			policy: &ImageScanPolicy{Severities: []string{ImageScanSeverityCritical}, FixableOnly: true},
+			want:   []string{"CRITICAL CVE-1 in openssl@1.0"},
+		},
+		{
+			name:   "no finding of the severities",
+			policy: &ImageScanPolicy{Severities: []string{ImageScanSeverityMedium}},
+			want:   []string{},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require.Equal(t, tt.want, tt.policy.Violations(report))
+		})
+	}
+}
@@ -0,0 +1,35 @@
+/*
+Copyright 2025 The KodeRover Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package step
+
+type StepImageSignSpec struct {
+	Registry *RegistryNamespace `bson:"registry"             json:"registry"              yaml:"registry"`
+	Targets  []*ImageSignTarget `bson:"targets"              json:"targets"               yaml:"targets"`
+	// PrivateKey is the keyvault placeholder of the PEM encoded signing key, it is resolved when the job context is sent to the executor.
+	PrivateKey string `bson:"private_key,omitempty"  json:"private_key,omitempty"   yaml:"private_key,omitempty"`
+	// KeyRef references a signing key held by the executor, env://NAME or file:///path/to/key.pem
+	KeyRef string `bson:"key_ref"              json:"key_ref"               yaml:"key_ref"`
+}
+
+type ImageSignTarget struct {
+	ServiceName   string `bson:"service_name"       json:"service_name"       yaml:"service_name"`
+	ServiceModule string `bson:"service_module"     json:"service_module"     yaml:"service_module"`
+	Image         string `bson:"image"              json:"image"              yaml:"image"`
+	// Distribute is set when the image is pushed by a distribute step, the image to sign is its target image
+	// which is only known when the job starts.
+	Distribute *DistributeTaskTarget `bson:"distribute,omitempty" json:"distribute,omitempty" yaml:"distribute,omitempty"`
+}
@@ -0,0 +1,36 @@
+/*
+Copyright 2025 The KodeRover Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package step
+
+import "github.com/koderover/zadig/v2/pkg/types"
+
+type StepMockVerifySpec struct {
+	ProjectName string              `bson:"project_name"               json:"project_name"                yaml:"project_name"`
+	Targets     []*MockVerifyTarget `bson:"targets"                    json:"targets"                     yaml:"targets"`
+	// ClusterID is the cluster the testing runs in, the journals are only reachable from the same cluster
+	ClusterID string `bson:"cluster_id"                 json:"cluster_id"                  yaml:"cluster_id"`
+}
+
+type MockVerifyTarget struct {
+	EnvName     string `bson:"env_name"           json:"env_name"           yaml:"env_name"`
+	ServiceName string `bson:"service_name"       json:"service_name"       yaml:"service_name"`
+	// JournalURL is the request journal of the mock in the environment, it is resolved when the job starts
+	JournalURL string `bson:"journal_url"        json:"journal_url"        yaml:"journal_url"`
+	// Since is the unix time in milliseconds the job started at, the requests received before are not counted
+	Since     int64                `bson:"since"              json:"since"              yaml:"since"`
+	Assertion *types.MockAssertion `bson:"assertion"          json:"assertion"          yaml:"assertion"`
+}
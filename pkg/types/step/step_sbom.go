@@ -0,0 +1,41 @@
+/*
+Copyright 2025 The KodeRover Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package step
+
+import "github.com/koderover/zadig/v2/pkg/tool/sbom"
+
+// SBOMIndexFileName is the file listing the sbom documents generated by a step, it is archived next to the documents.
+const SBOMIndexFileName = "sbom-index.json"
+
+type StepSBOMSpec struct {
+	Registry  *RegistryNamespace `bson:"registry"                   json:"registry"                    yaml:"registry"`
+	Targets   []*SBOMTarget      `bson:"targets"                    json:"targets"                     yaml:"targets"`
+	Format    sbom.Format        `bson:"format"                     json:"format"                      yaml:"format"`
+	DestDir   string             `bson:"dest_dir"                   json:"dest_dir"                    yaml:"dest_dir"`
+	S3DestDir string             `bson:"s3_dest_dir"                json:"s3_dest_dir"                 yaml:"s3_dest_dir"`
+	S3Storage *S3                `bson:"s3_storage"                 json:"s3_storage"                  yaml:"s3_storage"`
+}
+
+type SBOMTarget struct {
+	ServiceName   string `bson:"service_name"       json:"service_name"       yaml:"service_name"`
+	ServiceModule string `bson:"service_module"     json:"service_module"     yaml:"service_module"`
+	Image         string `bson:"image"              json:"image"              yaml:"image"`
+	Digest        string `bson:"digest"             json:"digest"             yaml:"digest"`
+	// FileName is the name of the archived sbom document under S3DestDir
+	FileName   string `bson:"file_name"          json:"file_name"          yaml:"file_name"`
+	Components int    `bson:"components"         json:"components"         yaml:"components"`
+}
@@ -30,7 +30,8 @@ func MaskSecretEnvs(message string, secretEnvs []string) string {
 		if len(val) == 0 {
 			continue
 		}
-		sl := strings.Split(val, "=")
+		// values such as base64 encoded secrets may contain "=", only the first one separates the key
+		sl := strings.SplitN(val, "=", 2)
 
 		if len(sl) != 2 {
 			continue
@@ -40,7 +41,7 @@ func MaskSecretEnvs(message string, secretEnvs []string) string {
 			// invalid key value pair received
 			continue
 		}
-		out = strings.Replace(out, strings.Join(sl[1:], "="), secretEnvMask, -1)
+		out = strings.Replace(out, sl[1], secretEnvMask, -1)
 	}
 	return out
 }